@@ -0,0 +1,766 @@
+/*
+Package analyzer resolves names in statements parsed by xsqlparser.
+
+Analyze resolves every column reference to the source it comes from, that is
+a table, a CTE, a subquery in FROM clause or an alias in the select list, and
+reports ambiguous and unknown columns.
+
+	result := analyzer.Analyze(stmt, catalog)
+	for _, d := range result.Diagnostics {
+		fmt.Println(d)
+	}
+	for ref, col := range result.Columns {
+		fmt.Println(ref.ToSQLString(), "=>", col.Source.Name, col.Name)
+	}
+
+With a Catalog, e.g. a MemoryCatalog loaded from CREATE TABLE statements,
+statements can be validated against the schema:
+
+	catalog, err := analyzer.LoadCatalog(schemaFile, &dialect.PostgresqlDialect{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, d := range analyzer.Analyze(stmt, catalog).Diagnostics {
+		fmt.Println(d) // e.g. 1:8: unknown column: nme
+	}
+
+Without a Catalog the columns of tables are unknown, so a column is resolved
+only when a single source can provide it, and unknown columns are reported
+only when every source in scope has known columns (e.g. CTEs and subqueries).
+*/
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// SourceKind is the kind of Source.
+type SourceKind int
+
+const (
+	TableSource    SourceKind = iota // a table (or a view) in the database
+	CTESource                        // a reference to a CTE
+	DerivedSource                    // a subquery in FROM clause
+	FunctionSource                   // a table function, UNNEST, LATERAL VIEW or ARRAY JOIN
+)
+
+func (k SourceKind) String() string {
+	switch k {
+	case TableSource:
+		return "TABLE"
+	case CTESource:
+		return "CTE"
+	case DerivedSource:
+		return "DERIVED"
+	case FunctionSource:
+		return "FUNCTION"
+	}
+	return ""
+}
+
+// Source is a relation which columns can be referenced from, i.e: an item of
+// FROM clause or the target table of INSERT, UPDATE, DELETE and MERGE.
+type Source struct {
+	Kind    SourceKind
+	Name    string             // alias, or the table name if no alias is given
+	Table   *sqlast.ObjectName // referenced table or CTE name (TableSource and CTESource)
+	CTE     *sqlast.CTE        // CTESource only
+	Node    sqlast.Node        // e.g. *sqlast.Table, *sqlast.Derived, *sqlast.LateralView
+	Columns []string           // nil if unknown
+	Def     *Table             // definition in the Catalog (TableSource only)
+
+	outputs []*output // output columns of the query (CTESource and DerivedSource)
+}
+
+// output is an output column of a query.
+type output struct {
+	name   string
+	quoted bool        // name is a quoted identifier
+	expr   sqlast.Node // nil if the column is expanded from a wildcard
+
+	// column of source which is expanded from a wildcard
+	source *Source
+	column string
+	merged bool // merged by USING or NATURAL JOIN
+
+	// the columns at the same position in the other operands of set
+	// operations (and rows of VALUES), whose name and type are of the first one
+	union []*output
+}
+
+func outputNames(outputs []*output) []string {
+	if outputs == nil {
+		return nil
+	}
+	names := make([]string, 0, len(outputs))
+	for _, o := range outputs {
+		names = append(names, o.name)
+	}
+	return names
+}
+
+// Column is a resolved column reference.
+type Column struct {
+	Name string
+	// Source is the relation the column belongs to. It is nil if the
+	// reference is an alias in the select list, e.g. `ORDER BY total`.
+	Source *Source
+	// SelectItem is the select list item the reference points to, if Source is nil.
+	SelectItem *sqlast.AliasSelectItem
+
+	merged bool // merged by USING or NATURAL JOIN
+}
+
+// DiagnosticKind is the kind of Diagnostic.
+type DiagnosticKind int
+
+const (
+	UnknownColumn   DiagnosticKind = iota // no source has the column
+	AmbiguousColumn                       // more than one source has the column
+	UnknownTable                          // the qualifier of a column matches no source
+	MissingTable                          // a table is not in the Catalog
+)
+
+func (k DiagnosticKind) String() string {
+	switch k {
+	case UnknownColumn:
+		return "unknown column"
+	case AmbiguousColumn:
+		return "ambiguous column"
+	case UnknownTable:
+		return "unknown table"
+	case MissingTable:
+		return "missing table"
+	}
+	return ""
+}
+
+// Diagnostic is a problem of a column reference found by Analyze.
+type Diagnostic struct {
+	Kind       DiagnosticKind
+	Node       sqlast.Node // *sqlast.Ident, *sqlast.CompoundIdent, *sqlast.QualifiedWildcardSelectItem or *sqlast.ObjectName (MissingTable)
+	Candidates []*Source   // sources having the column (AmbiguousColumn only)
+}
+
+func (d *Diagnostic) Error() string {
+	pos := d.Node.Pos()
+	return fmt.Sprintf("%d:%d: %s: %s", pos.Line, pos.Col, d.Kind, d.Node.ToSQLString())
+}
+
+// Result is the result of Analyze.
+type Result struct {
+	// Columns maps column references (*sqlast.Ident and *sqlast.CompoundIdent)
+	// to their resolution. References which can't be resolved are not included.
+	Columns     map[sqlast.Node]*Column
+	Diagnostics []*Diagnostic
+
+	queries    map[*sqlast.QueryStmt][]*output
+	wildcards  map[sqlast.SQLSelectItem][]*output // nil if the columns are unknown
+	unresolved []sqlast.Node                      // column references which can be of more than one source
+	tables     []*Source                          // sources of table names, i.e. TableSource and CTESource
+	prefixes   map[*sqlast.QualifiedWildcardSelectItem]*Source
+}
+
+// Analyze resolves the column references in node, which is a statement or
+// a *sqlast.File. catalog may be nil. If catalog is given, tables which are
+// not in it are reported as MissingTable.
+func Analyze(node sqlast.Node, catalog Catalog) *Result {
+	a := &analyzer{
+		catalog: catalog,
+		result:  newResult(),
+	}
+	a.stmt(node)
+	return a.result
+}
+
+func newResult() *Result {
+	return &Result{
+		Columns:   make(map[sqlast.Node]*Column),
+		queries:   make(map[*sqlast.QueryStmt][]*output),
+		wildcards: make(map[sqlast.SQLSelectItem][]*output),
+		prefixes:  make(map[*sqlast.QualifiedWildcardSelectItem]*Source),
+	}
+}
+
+type analyzer struct {
+	catalog Catalog
+	result  *Result
+}
+
+func (a *analyzer) stmt(node sqlast.Node) {
+	switch n := node.(type) {
+	case *sqlast.File:
+		for _, s := range n.Stmts {
+			a.stmt(s)
+		}
+	case *sqlast.QueryStmt:
+		a.query(n, nil)
+	case *sqlast.ExplainStmt:
+		a.stmt(n.Stmt)
+	case *sqlast.PrepareStmt:
+		a.stmt(n.Stmt)
+	case *sqlast.DeclareCursorStmt:
+		a.query(n.Query, nil)
+	case *sqlast.CreateViewStmt:
+		a.query(n.Query, nil)
+	case *sqlast.InsertStmt:
+		sc := &scope{sources: []*Source{a.tableSource(nil, n.TableName, nil, n.TableName)}}
+		a.targetColumns(sc, n.Columns)
+		switch src := n.Source.(type) {
+		case *sqlast.SubQuerySource:
+			a.query(src.SubQuery, nil)
+		case *sqlast.ConstructorSource:
+			for _, row := range src.Rows {
+				a.exprs(&scope{}, row.Values...)
+			}
+		}
+		a.assignments(sc, n.UpdateAssignments)
+		a.selectItems(sc, n.Returning)
+	case *sqlast.UpdateStmt:
+		sc := &scope{sources: []*Source{a.tableSource(nil, n.TableName, nil, n.TableName)}}
+		a.assignments(sc, n.Assignments)
+		a.exprs(sc, n.Selection)
+		a.selectItems(sc, n.Returning)
+	case *sqlast.DeleteStmt:
+		sc := &scope{sources: []*Source{a.tableSource(nil, n.TableName, n.Alias, n.TableName)}}
+		a.from(sc, n.Using)
+		a.exprs(sc, n.Selection)
+		a.selectItems(sc, n.Returning)
+	case *sqlast.MergeStmt:
+		target := a.tableSource(nil, n.Target, n.Alias, n.Target)
+		sc := &scope{sources: []*Source{target}}
+		a.from(sc, []sqlast.TableReference{n.Source})
+		a.exprs(sc, n.On)
+		for _, c := range n.Clauses {
+			a.exprs(sc, c.Condition)
+			switch act := c.Action.(type) {
+			case *sqlast.MergeUpdate:
+				a.assignments(&scope{sources: []*Source{target}}, act.Assignments)
+			case *sqlast.MergeInsert:
+				a.targetColumns(&scope{sources: []*Source{target}}, act.Columns)
+				if act.Values != nil {
+					a.exprs(sc, act.Values.Values...)
+				}
+			}
+		}
+	}
+}
+
+// targetColumns resolves the column list of INSERT and MERGE.
+func (a *analyzer) targetColumns(sc *scope, columns []*sqlast.Ident) {
+	for _, c := range columns {
+		a.resolve(sc, c, nil, c.Value)
+	}
+}
+
+// assignments resolves the targets of SET in sc, which has the target table
+// only, and their values in the same scope.
+func (a *analyzer) assignments(sc *scope, assignments []*sqlast.Assignment) {
+	for _, as := range assignments {
+		a.resolve(sc, as.ID, nil, as.ID.Value)
+		a.exprs(sc, as.Value)
+	}
+}
+
+// query resolves q and returns its output columns (nil if unknown).
+func (a *analyzer) query(q *sqlast.QueryStmt, parent *scope) []*output {
+	sc := &scope{parent: parent}
+	for _, cte := range q.CTEs {
+		outputs := a.query(cte.Query, sc)
+		sc.ctes = append(sc.ctes, &cteDef{cte: cte, outputs: outputs})
+	}
+	outputs, bodyScope := a.setExpr(q.Body, sc)
+
+	order := bodyScope.withAliases(aliasesOf(q.Body), true)
+	for _, o := range q.OrderBy {
+		a.exprs(order, o.Expr)
+	}
+	a.result.queries[q] = outputs
+	return outputs
+}
+
+// setExpr resolves expr and returns its output columns and the scope of its
+// leftmost SELECT.
+func (a *analyzer) setExpr(expr sqlast.SQLSetExpr, parent *scope) ([]*output, *scope) {
+	switch s := expr.(type) {
+	case *sqlast.SQLSelect:
+		return a.sqlSelect(s, parent)
+	case *sqlast.SelectExpr:
+		return a.sqlSelect(s.Select, parent)
+	case *sqlast.SetOperationExpr:
+		left, sc := a.setExpr(s.Left, parent)
+		right, _ := a.setExpr(s.Right, parent)
+		return unionOutputs(left, right), sc
+	case *sqlast.QueryExpr:
+		return a.query(s.Query, parent), &scope{parent: parent}
+	case *sqlast.SQLValues:
+		var outputs []*output
+		for i, r := range s.Rows {
+			for j, v := range r.Values {
+				o := &output{name: fmt.Sprintf("column%d", j+1), expr: v}
+				switch {
+				case i == 0:
+					outputs = append(outputs, o)
+				case j < len(outputs):
+					outputs[j].union = append(outputs[j].union, o)
+				}
+			}
+			a.exprs(parent, r.Values...)
+		}
+		return outputs, &scope{parent: parent}
+	}
+	return nil, &scope{parent: parent}
+}
+
+func (a *analyzer) sqlSelect(s *sqlast.SQLSelect, parent *scope) ([]*output, *scope) {
+	sc := &scope{parent: parent}
+	a.from(sc, s.FromClause)
+	for _, lv := range s.LateralViews {
+		a.exprs(sc, lv.Generator)
+		src := &Source{Kind: FunctionSource, Node: lv, Columns: identValues(lv.ColumnAliases)}
+		if lv.TableAlias != nil {
+			src.Name = lv.TableAlias.Value
+		}
+		sc.sources = append(sc.sources, src)
+	}
+	for _, aj := range s.ArrayJoins {
+		a.selectItems(sc, aj.Items)
+		var columns []string
+		for _, item := range aj.Items {
+			if i, ok := item.(*sqlast.AliasSelectItem); ok {
+				columns = append(columns, i.Alias.Value)
+			}
+		}
+		if len(columns) != 0 {
+			sc.sources = append(sc.sources, &Source{Kind: FunctionSource, Node: aj, Columns: columns})
+		}
+	}
+
+	a.exprs(sc, s.DistinctOn...)
+	a.selectItems(sc, s.Projection)
+	a.exprs(sc, s.WhereClause)
+
+	grouping := sc.withAliases(aliasesOf(s), false)
+	a.exprs(grouping, s.GroupByClause...)
+	a.exprs(grouping, s.HavingClause)
+	for _, w := range s.WindowClause {
+		a.exprs(sc, w.Spec)
+	}
+	a.exprs(sc, s.DistributeBy...)
+	for _, o := range s.SortBy {
+		a.exprs(sc, o.Expr)
+	}
+	a.exprs(sc, s.ClusterBy...)
+
+	return a.outputs(sc, s.Projection), sc
+}
+
+func (a *analyzer) selectItems(sc *scope, items []sqlast.SQLSelectItem) {
+	for _, item := range items {
+		switch i := item.(type) {
+		case *sqlast.UnnamedSelectItem:
+			a.exprs(sc, i.Node)
+		case *sqlast.AliasSelectItem:
+			a.exprs(sc, i.Expr)
+		case *sqlast.QualifiedWildcardSelectItem:
+			switch sources := sc.lookup(identValues(i.Prefix.Idents)); len(sources) {
+			case 0:
+				a.report(&Diagnostic{Kind: UnknownTable, Node: i})
+			case 1:
+				a.result.prefixes[i] = sources[0]
+			}
+		}
+	}
+}
+
+// outputs returns the output columns of items, or nil if some of them are
+// unknown. The columns expanded from wildcards are recorded to the result.
+func (a *analyzer) outputs(sc *scope, items []sqlast.SQLSelectItem) []*output {
+	var outputs []*output
+	known := true
+	for _, item := range items {
+		var expanded []*output
+		switch i := item.(type) {
+		case *sqlast.AliasSelectItem:
+			outputs = append(outputs, &output{name: i.Alias.Value, quoted: i.Alias.Quoted, expr: i.Expr})
+			continue
+		case *sqlast.UnnamedSelectItem:
+			// the parser keeps `*` as an unnamed item
+			if _, ok := i.Node.(*sqlast.Wildcard); !ok {
+				name, quoted := columnName(i.Node)
+				outputs = append(outputs, &output{name: name, quoted: quoted, expr: i.Node})
+				continue
+			}
+			expanded = expandWildcard(sc)
+		case *sqlast.WildcardSelectItem:
+			expanded = expandWildcard(sc)
+		case *sqlast.QualifiedWildcardSelectItem:
+			if sources := sc.lookup(identValues(i.Prefix.Idents)); len(sources) == 1 {
+				expanded = sourceOutputs(sources[0], nil)
+			}
+		}
+		a.result.wildcards[item] = expanded
+		if expanded == nil {
+			known = false
+		}
+		outputs = append(outputs, expanded...)
+	}
+	if !known {
+		return nil
+	}
+	return outputs
+}
+
+// expandWildcard returns the columns of `*` in sc, or nil if they are unknown.
+// Columns merged by USING and NATURAL JOIN come first and appear only once.
+func expandWildcard(sc *scope) []*output {
+	var outputs, merged []*output
+	for _, m := range sc.merged {
+		if hasOutput(merged, m) {
+			continue
+		}
+		for _, src := range sc.sources {
+			if hasColumn(src.Columns, m) {
+				merged = append(merged, &output{name: m, quoted: isQuoted(src, m), source: src, column: m, merged: true})
+				break
+			}
+		}
+	}
+	for _, src := range sc.sources {
+		columns := sourceOutputs(src, merged)
+		if columns == nil {
+			return nil
+		}
+		outputs = append(outputs, columns...)
+	}
+	return append(merged, outputs...)
+}
+
+// sourceOutputs returns the columns of src except merged ones, or nil if they are unknown.
+func sourceOutputs(src *Source, merged []*output) []*output {
+	if src.Columns == nil {
+		return nil
+	}
+	outputs := []*output{}
+	for _, c := range src.Columns {
+		if !hasOutput(merged, c) {
+			outputs = append(outputs, &output{name: c, quoted: isQuoted(src, c), source: src, column: c})
+		}
+	}
+	return outputs
+}
+
+// isQuoted returns whether the name of column of src is a quoted identifier.
+// It is unknown for the columns of tables in the Catalog, so false is returned.
+func isQuoted(src *Source, column string) bool {
+	for _, o := range src.outputs {
+		if strings.EqualFold(o.name, column) {
+			return o.quoted
+		}
+	}
+	return false
+}
+
+func hasOutput(outputs []*output, name string) bool {
+	for _, o := range outputs {
+		if strings.EqualFold(o.name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnName returns the name of the output column of expr without alias,
+// and whether it is a quoted identifier.
+func columnName(expr sqlast.Node) (string, bool) {
+	switch e := expr.(type) {
+	case *sqlast.Ident:
+		return e.Value, e.Quoted
+	case *sqlast.CompoundIdent:
+		last := e.Idents[len(e.Idents)-1]
+		return last.Value, last.Quoted
+	case *sqlast.Function:
+		last := e.Name.Idents[len(e.Name.Idents)-1]
+		return last.Value, last.Quoted
+	case *sqlast.Cast:
+		return columnName(e.Expr)
+	case *sqlast.Nested:
+		return columnName(e.AST)
+	}
+	return "?column?", false
+}
+
+// from registers the sources of refs to sc. Join conditions are resolved
+// after every source is registered.
+func (a *analyzer) from(sc *scope, refs []sqlast.TableReference) {
+	var conds []sqlast.Node
+	var walkRef func(ref sqlast.Node) []*Source
+	walkRef = func(ref sqlast.Node) []*Source {
+		switch r := ref.(type) {
+		case *sqlast.Table:
+			src := a.tableSource(sc, r.Name, r.Alias, r)
+			if len(r.Columns) != 0 {
+				aliasColumns(src, r.Columns)
+			}
+			sc.sources = append(sc.sources, src)
+			return []*Source{src}
+		case *sqlast.Derived:
+			var outputs []*output
+			if r.Lateral {
+				outputs = a.query(r.SubQuery, sc)
+			} else {
+				outputs = a.query(r.SubQuery, sc.parent)
+			}
+			src := &Source{Kind: DerivedSource, Node: r, Columns: outputNames(outputs), outputs: outputs}
+			if len(r.Columns) != 0 {
+				src.Columns = identValues(r.Columns)
+				if src.outputs = renameOutputs(outputs, r.Columns); src.outputs != nil {
+					src.Columns = outputNames(src.outputs)
+				}
+			}
+			if r.Alias != nil {
+				src.Name = r.Alias.Value
+			}
+			sc.sources = append(sc.sources, src)
+			return []*Source{src}
+		case *sqlast.TableFunction:
+			conds = append(conds, r.Function)
+			return []*Source{sc.add(functionSource(r, r.Alias, r.Columns))}
+		case *sqlast.Unnest:
+			conds = append(conds, r.Exprs...)
+			return []*Source{sc.add(functionSource(r, r.Alias, r.Columns))}
+		case *sqlast.PartitionedJoinTable:
+			for _, c := range r.ColumnList {
+				conds = append(conds, c)
+			}
+			return walkRef(r.Factor)
+		case *sqlast.TableJoinElement:
+			return walkRef(r.Ref)
+		case *sqlast.QualifiedJoin:
+			left := walkRef(r.LeftElement)
+			right := walkRef(r.RightElement)
+			// USING columns are resolved to the left side
+			switch spec := r.Spec.(type) {
+			case *sqlast.JoinCondition:
+				conds = append(conds, spec.SearchCondition)
+			case *sqlast.NamedColumnsJoin:
+				for _, c := range spec.ColumnList {
+					sc.merge(c.Value)
+					a.resolveIn(c, c.Value, left)
+				}
+			}
+			return append(left, right...)
+		case *sqlast.NaturalJoin:
+			left := walkRef(r.LeftElement)
+			right := walkRef(r.RightElement)
+			for _, l := range left {
+				for _, rs := range right {
+					for _, c := range l.Columns {
+						if hasColumn(rs.Columns, c) {
+							sc.merge(c)
+						}
+					}
+				}
+			}
+			return append(left, right...)
+		case *sqlast.CrossJoin:
+			return append(walkRef(r.Reference), walkRef(r.Factor)...)
+		}
+		return nil
+	}
+	for _, ref := range refs {
+		walkRef(ref)
+	}
+	a.exprs(sc, conds...)
+}
+
+// tableSource returns the source of a table named name. It is a CTE if
+// name refers to a CTE visible from sc.
+func (a *analyzer) tableSource(sc *scope, name *sqlast.ObjectName, alias *sqlast.Ident, node sqlast.Node) *Source {
+	src := &Source{Kind: TableSource, Name: name.ToSQLString(), Table: name, Node: node}
+	if alias != nil {
+		src.Name = alias.Value
+	}
+	a.result.tables = append(a.result.tables, src)
+	if len(name.Idents) == 1 {
+		if cte := sc.cte(name.Idents[0].Value); cte != nil {
+			src.Kind = CTESource
+			src.CTE = cte.cte
+			src.Columns = outputNames(cte.outputs)
+			src.outputs = cte.outputs
+			return src
+		}
+	}
+	if a.catalog != nil {
+		if t, ok := a.catalog.Table(name); ok {
+			src.Def = t
+			src.Columns = t.ColumnNames()
+		} else {
+			a.report(&Diagnostic{Kind: MissingTable, Node: name})
+		}
+	}
+	return src
+}
+
+// unionOutputs returns the outputs of a set operation on left and right.
+func unionOutputs(left, right []*output) []*output {
+	if left == nil || len(left) != len(right) {
+		return left
+	}
+	outputs := make([]*output, 0, len(left))
+	for i, l := range left {
+		o := *l
+		o.union = append(append([]*output{}, l.union...), right[i])
+		outputs = append(outputs, &o)
+	}
+	return outputs
+}
+
+// renameOutputs returns outputs renamed by column aliases, i.e: `AS t(names...)`.
+func renameOutputs(outputs []*output, names []*sqlast.Ident) []*output {
+	if len(outputs) < len(names) {
+		return nil
+	}
+	renamed := make([]*output, 0, len(outputs))
+	for i, o := range outputs {
+		r := *o
+		if i < len(names) {
+			r.name = names[i].Value
+			r.quoted = names[i].Quoted
+		}
+		renamed = append(renamed, &r)
+	}
+	return renamed
+}
+
+// aliasColumns renames the columns of the table or CTE src by column
+// aliases, i.e: `AS t(names...)`. The columns stay unknown if they are.
+func aliasColumns(src *Source, names []*sqlast.Ident) {
+	if src.outputs != nil {
+		if src.outputs = renameOutputs(src.outputs, names); src.outputs != nil {
+			src.Columns = outputNames(src.outputs)
+		} else {
+			src.Columns = identValues(names)
+		}
+		return
+	}
+	if src.Columns == nil {
+		return
+	}
+	columns := identValues(names)
+	if len(src.Columns) > len(names) {
+		columns = append(columns, src.Columns[len(names):]...)
+	}
+	src.Columns = columns
+}
+
+func functionSource(node sqlast.Node, alias *sqlast.Ident, columns []*sqlast.Ident) *Source {
+	src := &Source{Kind: FunctionSource, Node: node, Columns: identValues(columns)}
+	if alias != nil {
+		src.Name = alias.Value
+	}
+	return src
+}
+
+func (a *analyzer) exprs(sc *scope, nodes ...sqlast.Node) {
+	for _, n := range nodes {
+		if n == nil {
+			continue
+		}
+		sqlast.Walk(&exprVisitor{a: a, sc: sc}, n)
+	}
+}
+
+type exprVisitor struct {
+	a  *analyzer
+	sc *scope
+}
+
+func (v *exprVisitor) Visit(node sqlast.Node) sqlast.Visitor {
+	switch n := node.(type) {
+	case *sqlast.Ident:
+		if !n.Quoted && isNiladic(n.Value) {
+			return nil
+		}
+		v.a.resolve(v.sc, n, nil, n.Value)
+		return nil
+	case *sqlast.CompoundIdent:
+		last := len(n.Idents) - 1
+		v.a.resolve(v.sc, n, identValues(n.Idents[:last]), n.Idents[last].Value)
+		return nil
+	case *sqlast.QueryStmt:
+		v.a.query(n, v.sc)
+		return nil
+	case *sqlast.Extract:
+		v.a.exprs(v.sc, n.Expr)
+		return nil
+	case *sqlast.Function:
+		// window names are not columns
+		v.a.exprs(v.sc, n.Args...)
+		for _, o := range n.WithinGroup {
+			v.a.exprs(v.sc, o)
+		}
+		v.a.exprs(v.sc, n.Filter)
+		if n.Over != nil {
+			v.a.exprs(v.sc, n.Over)
+		}
+		return nil
+	case *sqlast.WindowSpec:
+		v.a.exprs(v.sc, n.PartitionBy...)
+		for _, o := range n.OrderBy {
+			v.a.exprs(v.sc, o)
+		}
+		if n.WindowsFrame != nil {
+			v.a.exprs(v.sc, n.WindowsFrame)
+		}
+		return nil
+	case *sqlast.NamedArg:
+		// parameter names are not columns
+		v.a.exprs(v.sc, n.Value)
+		return nil
+	case *sqlast.ObjectName:
+		// function, type and collation names are not columns
+		return nil
+	}
+	return v
+}
+
+// niladic are the functions called without parentheses, which are parsed as identifiers.
+var niladic = map[string]struct{}{
+	"CURRENT_CATALOG":   {},
+	"CURRENT_DATE":      {},
+	"CURRENT_ROLE":      {},
+	"CURRENT_SCHEMA":    {},
+	"CURRENT_TIME":      {},
+	"CURRENT_TIMESTAMP": {},
+	"CURRENT_USER":      {},
+	"DEFAULT":           {},
+	"LOCALTIME":         {},
+	"LOCALTIMESTAMP":    {},
+	"SESSION_USER":      {},
+	"SYSDATE":           {},
+	"USER":              {},
+}
+
+func isNiladic(name string) bool {
+	_, ok := niladic[strings.ToUpper(name)]
+	return ok
+}
+
+func (a *analyzer) report(d *Diagnostic) {
+	a.result.Diagnostics = append(a.result.Diagnostics, d)
+}
+
+func identValues(idents []*sqlast.Ident) []string {
+	if idents == nil {
+		return nil
+	}
+	values := make([]string, 0, len(idents))
+	for _, i := range idents {
+		values = append(values, i.Value)
+	}
+	return values
+}
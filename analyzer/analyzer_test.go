@@ -0,0 +1,145 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+const testSchema = `
+CREATE TABLE users (id int PRIMARY KEY, name varchar(255));
+CREATE TABLE orders (id int PRIMARY KEY, user_id int NOT NULL, total numeric(10, 2));
+`
+
+func TestAnalyze(t *testing.T) {
+	testCatalog, err := LoadCatalog(strings.NewReader(testSchema), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	cases := []struct {
+		name        string
+		src         string
+		catalog     Catalog
+		resolved    []string
+		diagnostics []string
+	}{
+		{
+			name:     "join with aliases",
+			src:      "SELECT u.id, name, total FROM users AS u INNER JOIN orders AS o ON u.id = o.user_id",
+			catalog:  testCatalog,
+			resolved: []string{"u.id=>u.id", "name=>u.name", "total=>o.total", "u.id=>u.id", "o.user_id=>o.user_id"},
+		},
+		{
+			name:        "ambiguous column",
+			src:         "SELECT id FROM users, orders",
+			catalog:     testCatalog,
+			diagnostics: []string{"1:8: ambiguous column: id"},
+		},
+		{
+			name:        "unknown column and table",
+			src:         "SELECT nme, x.id FROM users",
+			catalog:     testCatalog,
+			diagnostics: []string{"1:8: unknown column: nme", "1:13: unknown table: x.id"},
+		},
+		{
+			name:     "using",
+			src:      "SELECT id, name FROM users JOIN orders USING (id)",
+			catalog:  testCatalog,
+			resolved: []string{"id=>users.id", "name=>users.name"},
+		},
+		{
+			name:     "correlated subquery",
+			src:      "SELECT name FROM users AS u WHERE EXISTS (SELECT 1 FROM orders WHERE user_id = u.id)",
+			catalog:  testCatalog,
+			resolved: []string{"name=>u.name", "user_id=>orders.user_id", "u.id=>u.id"},
+		},
+		{
+			name:     "cte and derived table without catalog",
+			src:      "WITH c AS (SELECT id AS uid FROM users) SELECT d.uid, v FROM (SELECT uid, 1 AS v FROM c) AS d",
+			resolved: []string{"id=>users.id", "d.uid=>d.uid", "v=>d.v", "uid=>c.uid"},
+		},
+		{
+			name:        "unknown column of cte",
+			src:         "WITH c AS (SELECT id FROM users) SELECT name FROM c",
+			diagnostics: []string{"1:41: unknown column: name"},
+			resolved:    []string{"id=>users.id"},
+		},
+		{
+			name:     "order by alias",
+			src:      "SELECT total * 2 AS doubled FROM orders GROUP BY doubled ORDER BY doubled",
+			catalog:  testCatalog,
+			resolved: []string{"total=>orders.total", "doubled=>doubled", "doubled=>doubled"},
+		},
+		{
+			name:     "unknown tables",
+			src:      "SELECT a, CURRENT_DATE FROM t WHERE b IN (SELECT c FROM u, v)",
+			resolved: []string{"a=>t.a", "b=>t.b"},
+		},
+		{
+			name:        "update",
+			src:         "UPDATE users SET nme = 'x' WHERE id = 1",
+			catalog:     testCatalog,
+			resolved:    []string{"id=>users.id"},
+			diagnostics: []string{"1:18: unknown column: nme"},
+		},
+		{
+			name:        "missing table",
+			src:         "SELECT id FROM users JOIN payments AS p ON p.user_id = users.id",
+			catalog:     testCatalog,
+			resolved:    []string{"id=>users.id", "p.user_id=>p.user_id", "users.id=>users.id"},
+			diagnostics: []string{"1:27: missing table: payments"},
+		},
+		{
+			name:     "insert select",
+			src:      "INSERT INTO orders (user_id, total) SELECT id, 0 FROM users",
+			catalog:  testCatalog,
+			resolved: []string{"user_id=>orders.user_id", "total=>orders.total", "id=>users.id"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			result := Analyze(stmt, c.catalog)
+
+			var resolved []string
+			sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+				if col, ok := result.Columns[node]; ok {
+					target := col.Name
+					if col.Source != nil {
+						target = col.Source.Name + "." + col.Name
+					}
+					resolved = append(resolved, fmt.Sprintf("%s=>%s", node.ToSQLString(), target))
+				}
+				return true
+			})
+			var diagnostics []string
+			for _, d := range result.Diagnostics {
+				diagnostics = append(diagnostics, d.Error())
+			}
+
+			if diff := cmp.Diff(c.resolved, resolved); diff != "" {
+				t.Errorf("resolved diff: %s", diff)
+			}
+			if diff := cmp.Diff(c.diagnostics, diagnostics); diff != "" {
+				t.Errorf("diagnostics diff: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package analyzer
+
+import (
+	"io"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// Catalog provides the definitions of tables, which Analyze uses to resolve
+// columns and to validate statements.
+type Catalog interface {
+	// Table returns the definition of the table (or the view) named name,
+	// or false if it is unknown.
+	Table(name *sqlast.ObjectName) (*Table, bool)
+}
+
+// Table is the definition of a table or a view.
+type Table struct {
+	Name    *sqlast.ObjectName
+	Columns []*TableColumn
+	Checks  []*sqlast.CheckConstraint // CHECK constraints of the table and of the columns
+}
+
+// TableColumn is a column of Table.
+type TableColumn struct {
+	Name    string
+	Type    sqlast.Type // nil if unknown, e.g. NULL columns of views
+	NotNull bool
+	Default sqlast.Node // nil if the column has no DEFAULT
+}
+
+// Column returns the column named name, or nil if t has no such column.
+func (t *Table) Column(name string) *TableColumn {
+	for _, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// ColumnNames returns the names of the columns of t.
+func (t *Table) ColumnNames() []string {
+	names := make([]string, 0, len(t.Columns))
+	for _, c := range t.Columns {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// MemoryCatalog is a Catalog holding tables in memory. Names are case
+// insensitive, and a table added without a schema can be referenced with
+// any schema and vice versa as long as the table name is unique.
+type MemoryCatalog struct {
+	tables map[string]*Table // keyed by the lower-cased qualified name
+}
+
+var _ Catalog = &MemoryCatalog{}
+
+func NewMemoryCatalog() *MemoryCatalog {
+	return &MemoryCatalog{tables: make(map[string]*Table)}
+}
+
+// LoadCatalog parses src and returns a MemoryCatalog loaded from the statements.
+func LoadCatalog(src io.Reader, d dialect.Dialect) (*MemoryCatalog, error) {
+	parser, err := xsqlparser.NewParser(src, d)
+	if err != nil {
+		return nil, errors.Errorf("NewParser failed: %w", err)
+	}
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		return nil, errors.Errorf("ParseSQL failed: %w", err)
+	}
+	m := NewMemoryCatalog()
+	m.Load(stmts...)
+	return m, nil
+}
+
+// Add adds t, replacing the table of the same name.
+func (m *MemoryCatalog) Add(t *Table) {
+	m.tables[catalogKey(t.Name)] = t
+}
+
+// Remove removes the table named name.
+func (m *MemoryCatalog) Remove(name *sqlast.ObjectName) {
+	if t, ok := m.Table(name); ok {
+		delete(m.tables, catalogKey(t.Name))
+	}
+}
+
+func (m *MemoryCatalog) Table(name *sqlast.ObjectName) (*Table, bool) {
+	if t, ok := m.tables[catalogKey(name)]; ok {
+		return t, true
+	}
+
+	last := strings.ToLower(name.Idents[len(name.Idents)-1].Value)
+	var found *Table
+	for _, t := range m.tables {
+		if len(t.Name.Idents) != 1 && len(name.Idents) != 1 {
+			continue
+		}
+		if strings.ToLower(t.Name.Idents[len(t.Name.Idents)-1].Value) != last {
+			continue
+		}
+		if found != nil {
+			return nil, false
+		}
+		found = t
+	}
+	return found, found != nil
+}
+
+// Load applies DDL statements to m in order: CREATE TABLE, CREATE VIEW,
+// ALTER TABLE (ADD COLUMN, DROP COLUMN, ALTER COLUMN TYPE / NOT NULL /
+// DEFAULT, and ADD / DROP CONSTRAINT of CHECK) and DROP TABLE. Other
+// statements are ignored. A partition created by CREATE TABLE ... PARTITION
+// OF has the columns of the parent loaded before it.
+func (m *MemoryCatalog) Load(stmts ...sqlast.Stmt) {
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *sqlast.CreateTableStmt:
+			if _, ok := m.tables[catalogKey(s.Name)]; ok && s.NotExists {
+				continue
+			}
+			t := &Table{Name: s.Name, Checks: s.Checks()}
+			if s.PartitionOf != nil {
+				if parent, ok := m.Table(s.PartitionOf); ok {
+					for _, c := range parent.Columns {
+						copied := *c
+						t.Columns = append(t.Columns, &copied)
+					}
+					t.Checks = append(append([]*sqlast.CheckConstraint{}, parent.Checks...), t.Checks...)
+				}
+			}
+			for _, c := range s.Columns() {
+				t.Columns = append(t.Columns, tableColumn(c))
+			}
+			m.Add(t)
+		case *sqlast.CreateViewStmt:
+			a := &analyzer{catalog: m, result: newResult()}
+			t := &Table{Name: s.Name}
+			a.query(s.Query, nil)
+			types := a.result.OutputTypes(s.Query)
+			for i, c := range a.result.OutputColumns(s.Query) {
+				t.Columns = append(t.Columns, &TableColumn{Name: c, Type: types[i]})
+			}
+			m.Add(t)
+		case *sqlast.AlterTableStmt:
+			t, ok := m.Table(s.TableName)
+			if !ok {
+				continue
+			}
+			switch a := s.Action.(type) {
+			case *sqlast.AddColumnTableAction:
+				t.Columns = append(t.Columns, tableColumn(a.Column))
+				t.Checks = append(t.Checks, a.Column.Checks()...)
+			case *sqlast.RemoveColumnTableAction:
+				for i, c := range t.Columns {
+					if strings.EqualFold(c.Name, a.Name.Value) {
+						t.Columns = append(t.Columns[:i:i], t.Columns[i+1:]...)
+						break
+					}
+				}
+				t.removeChecks(func(c *sqlast.CheckConstraint) bool {
+					return c.Column != nil && strings.EqualFold(c.Column.Name.Value, a.Name.Value)
+				})
+			case *sqlast.AddConstraintTableAction:
+				if check, ok := a.Constraint.Spec.(*sqlast.CheckTableConstraint); ok {
+					t.Checks = append(t.Checks, &sqlast.CheckConstraint{Name: a.Constraint.Name, Expr: check.Expr})
+				}
+			case *sqlast.DropConstraintTableAction:
+				t.removeChecks(func(c *sqlast.CheckConstraint) bool {
+					return c.Name != nil && strings.EqualFold(c.Name.Value, a.Name.Value)
+				})
+			case *sqlast.AlterColumnTableAction:
+				c := t.Column(a.ColumnName.Value)
+				if c == nil {
+					continue
+				}
+				switch ca := a.Action.(type) {
+				case *sqlast.PGAlterDataTypeColumnAction:
+					c.Type = ca.DataType
+				case *sqlast.PGSetNotNullColumnAction:
+					c.NotNull = true
+				case *sqlast.PGDropNotNullColumnAction:
+					c.NotNull = false
+				case *sqlast.SetDefaultColumnAction:
+					c.Default = ca.Default
+				case *sqlast.DropDefaultColumnAction:
+					c.Default = nil
+				}
+			}
+		case *sqlast.DropTableStmt:
+			for _, n := range s.TableNames {
+				m.Remove(n)
+			}
+		}
+	}
+}
+
+func tableColumn(def *sqlast.ColumnDef) *TableColumn {
+	return &TableColumn{Name: def.Name.Value, Type: def.DataType, NotNull: def.NotNull(), Default: def.Default}
+}
+
+func (t *Table) removeChecks(f func(*sqlast.CheckConstraint) bool) {
+	checks := t.Checks[:0:0]
+	for _, c := range t.Checks {
+		if !f(c) {
+			checks = append(checks, c)
+		}
+	}
+	t.Checks = checks
+}
+
+func catalogKey(name *sqlast.ObjectName) string {
+	parts := make([]string, 0, len(name.Idents))
+	for _, i := range name.Idents {
+		parts = append(parts, strings.ToLower(i.Value))
+	}
+	return strings.Join(parts, ".")
+}
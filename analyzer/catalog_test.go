@@ -0,0 +1,124 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestMemoryCatalog(t *testing.T) {
+	src := `
+CREATE TABLE public.users (id int PRIMARY KEY, name varchar(255), email text);
+CREATE TABLE logs (id int, message text);
+CREATE TABLE IF NOT EXISTS logs (other int);
+CREATE TABLE logs_2020 PARTITION OF logs FOR VALUES FROM (1) TO (100);
+CREATE VIEW active_users AS SELECT u.id, name AS user_name FROM users AS u;
+ALTER TABLE users ADD COLUMN age int;
+ALTER TABLE users DROP COLUMN email;
+ALTER TABLE users ALTER COLUMN name SET NOT NULL;
+CREATE TABLE tmp (a int);
+DROP TABLE tmp;
+`
+	catalog, err := LoadCatalog(strings.NewReader(src), &dialect.PostgresqlDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	cases := []struct {
+		name    string
+		table   *sqlast.ObjectName
+		columns []string
+	}{
+		{name: "qualified", table: sqlast.NewObjectName("public", "users"), columns: []string{"id:int:true", "name:character varying(255):true", "age:int:false"}},
+		{name: "without schema", table: sqlast.NewObjectName("USERS"), columns: []string{"id:int:true", "name:character varying(255):true", "age:int:false"}},
+		{name: "with schema", table: sqlast.NewObjectName("public", "logs"), columns: []string{"id:int:false", "message:text:false"}},
+		{name: "partition", table: sqlast.NewObjectName("logs_2020"), columns: []string{"id:int:false", "message:text:false"}},
+		{name: "view", table: sqlast.NewObjectName("active_users"), columns: []string{"id:int:false", "user_name:character varying(255):false"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			table, ok := catalog.Table(c.table)
+			if !ok {
+				t.Fatalf("%s must be found", c.table.ToSQLString())
+			}
+			var columns []string
+			for _, col := range table.Columns {
+				var typ string
+				if col.Type != nil {
+					typ = col.Type.ToSQLString()
+				}
+				columns = append(columns, col.Name+":"+typ+":"+map[bool]string{true: "true", false: "false"}[col.NotNull])
+			}
+			if diff := cmp.Diff(c.columns, columns); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+
+	t.Run("dropped", func(t *testing.T) {
+		if _, ok := catalog.Table(sqlast.NewObjectName("tmp")); ok {
+			t.Error("tmp must be dropped")
+		}
+	})
+
+	t.Run("other schema", func(t *testing.T) {
+		if _, ok := catalog.Table(sqlast.NewObjectName("private", "users")); ok {
+			t.Error("private.users must not be found")
+		}
+	})
+}
+
+func TestMemoryCatalog_Constraints(t *testing.T) {
+	src := `
+CREATE TABLE items (id int PRIMARY KEY, price numeric(10,2) DEFAULT 0 CHECK(price >= 0), qty int DEFAULT 1 CONSTRAINT qty_positive CHECK(qty > 0), note text, CONSTRAINT price_limit CHECK(price < 1000));
+ALTER TABLE items ALTER COLUMN note SET DEFAULT '';
+ALTER TABLE items ALTER COLUMN qty DROP DEFAULT;
+ALTER TABLE items ADD CONSTRAINT note_length CHECK(length(note) < 100);
+ALTER TABLE items DROP CONSTRAINT price_limit;
+ALTER TABLE items ADD COLUMN discount int CHECK(discount >= 0);
+ALTER TABLE items DROP COLUMN qty;
+`
+	catalog, err := LoadCatalog(strings.NewReader(src), &dialect.PostgresqlDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	table, ok := catalog.Table(sqlast.NewObjectName("items"))
+	if !ok {
+		t.Fatal("items must be found")
+	}
+
+	var defaults []string
+	for _, c := range table.Columns {
+		if c.Default != nil {
+			defaults = append(defaults, c.Name+": "+c.Default.ToSQLString())
+		}
+	}
+	if diff := cmp.Diff([]string{"price: 0", "note: ''"}, defaults); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+
+	var checks []string
+	for _, c := range table.Checks {
+		var name, column string
+		if c.Name != nil {
+			name = c.Name.Value
+		}
+		if c.Column != nil {
+			column = c.Column.Name.Value
+		}
+		checks = append(checks, name+":"+column+": "+c.Expr.ToSQLString())
+	}
+	expect := []string{
+		":price: price >= 0",
+		"note_length:: length(note) < 100",
+		":discount: discount >= 0",
+	}
+	if diff := cmp.Diff(expect, checks); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
@@ -0,0 +1,150 @@
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// ExpandWildcards replaces `*` and `t.*` in every SELECT under stmt with the
+// columns they stand for, taking the columns of tables from catalog.
+// stmt is modified in place.
+//
+// Columns are qualified with the table name (or alias) if they are of more
+// than one table, except for the ones merged by USING and NATURAL JOIN.
+// If the columns of any wildcard are unknown, e.g. the table is missing in
+// catalog, it returns an error without modifying stmt.
+func ExpandWildcards(stmt sqlast.Node, catalog Catalog) error {
+	result := Analyze(stmt, catalog)
+
+	var selects []*sqlast.SQLSelect
+	var err error
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		if err != nil {
+			return false
+		}
+		s, ok := node.(*sqlast.SQLSelect)
+		if !ok {
+			return true
+		}
+		found := false
+		for _, item := range s.Projection {
+			columns, ok := result.wildcards[item]
+			if !ok {
+				continue
+			}
+			if columns == nil {
+				pos := item.Pos()
+				err = errors.Errorf("%d:%d: columns of %s are unknown", pos.Line, pos.Col, item.ToSQLString())
+				return false
+			}
+			found = true
+		}
+		if found {
+			selects = append(selects, s)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range selects {
+		var projection []sqlast.SQLSelectItem
+		for _, item := range s.Projection {
+			columns, ok := result.wildcards[item]
+			if !ok {
+				projection = append(projection, item)
+				continue
+			}
+			_, qualified := item.(*sqlast.QualifiedWildcardSelectItem)
+			qualified = qualified || len(sourcesOf(columns)) > 1
+			for _, c := range columns {
+				projection = append(projection, &sqlast.UnnamedSelectItem{Node: columnRef(c, qualified)})
+			}
+		}
+		s.Projection = projection
+	}
+	return nil
+}
+
+// sourcesOf returns the distinct sources of columns.
+func sourcesOf(columns []*output) []*Source {
+	var sources []*Source
+	for _, c := range columns {
+		found := false
+		for _, s := range sources {
+			if s == c.source {
+				found = true
+				break
+			}
+		}
+		if !found {
+			sources = append(sources, c.source)
+		}
+	}
+	return sources
+}
+
+// columnRef returns the reference to column c expanded from a wildcard.
+func columnRef(c *output, qualified bool) sqlast.Node {
+	column := columnIdent(c.name, c.quoted)
+	if !qualified || c.merged {
+		return column
+	}
+	qualifier := qualifierOf(c.source)
+	if qualifier == nil {
+		return column
+	}
+	return &sqlast.CompoundIdent{Idents: append(qualifier, column)}
+}
+
+// qualifierOf returns the name which the columns of src are qualified with,
+// or nil if src has no name.
+func qualifierOf(src *Source) []*sqlast.Ident {
+	var alias *sqlast.Ident
+	switch n := src.Node.(type) {
+	case *sqlast.Table:
+		if n.Alias == nil {
+			return copyIdents(n.Name.Idents)
+		}
+		alias = n.Alias
+	case *sqlast.Derived:
+		alias = n.Alias
+	case *sqlast.TableFunction:
+		alias = n.Alias
+	case *sqlast.Unnest:
+		alias = n.Alias
+	case *sqlast.ObjectName:
+		// the target table of INSERT, UPDATE, DELETE and MERGE
+		if src.Name == n.ToSQLString() {
+			return copyIdents(n.Idents)
+		}
+		return []*sqlast.Ident{columnIdent(src.Name, false)}
+	}
+	if alias == nil {
+		return nil
+	}
+	return copyIdents([]*sqlast.Ident{alias})
+}
+
+func copyIdents(idents []*sqlast.Ident) []*sqlast.Ident {
+	copied := make([]*sqlast.Ident, 0, len(idents))
+	for _, i := range idents {
+		copied = append(copied, &sqlast.Ident{Value: i.Value, Quoted: i.Quoted, QuoteStyle: i.QuoteStyle})
+	}
+	return copied
+}
+
+var plainIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// columnIdent returns the identifier of the column name, which is also
+// quoted if it can't be written as is.
+func columnIdent(name string, quoted bool) *sqlast.Ident {
+	_, reserved := dialect.ReservedKeywords[strings.ToUpper(name)]
+	return &sqlast.Ident{Value: name, Quoted: quoted || reserved || !plainIdent.MatchString(name)}
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestExpandWildcards(t *testing.T) {
+	testCatalog, err := LoadCatalog(strings.NewReader(testSchema), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	cases := []struct {
+		name   string
+		src    string
+		expect string
+		err    bool
+	}{
+		{
+			name:   "single table",
+			src:    "SELECT * FROM users WHERE id = 1",
+			expect: "SELECT id, name FROM users WHERE id = 1",
+		},
+		{
+			name:   "join",
+			src:    "SELECT *, 1 AS x FROM users AS u JOIN orders AS o ON u.id = o.user_id",
+			expect: "SELECT u.id, u.name, o.id, o.user_id, o.total, 1 AS x FROM users AS u JOIN orders AS o ON u.id = o.user_id",
+		},
+		{
+			name:   "qualified wildcard",
+			src:    "SELECT o.*, u.name FROM users AS u, orders AS o",
+			expect: "SELECT o.id, o.user_id, o.total, u.name FROM users AS u, orders AS o",
+		},
+		{
+			name:   "using",
+			src:    "SELECT * FROM users JOIN orders USING (id)",
+			expect: "SELECT id, users.name, orders.user_id, orders.total FROM users JOIN orders USING (id)",
+		},
+		{
+			name:   "cte, derived table and subquery",
+			src:    "WITH c AS (SELECT id, total * 2 AS \"Doubled\" FROM orders) SELECT * FROM (SELECT * FROM c) AS d WHERE EXISTS (SELECT * FROM users)",
+			expect: "WITH c AS (SELECT id, total * 2 AS \"Doubled\" FROM orders) SELECT id, \"Doubled\" FROM (SELECT id, \"Doubled\" FROM c) AS d WHERE EXISTS (SELECT id, name FROM users)",
+		},
+		{
+			name:   "missing table",
+			src:    "SELECT u.* FROM users AS u, payments",
+			expect: "SELECT u.id, u.name FROM users AS u, payments",
+		},
+		{
+			name: "unknown columns",
+			src:  "SELECT * FROM users, payments",
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			err = ExpandWildcards(stmt, testCatalog)
+			if c.err {
+				if err == nil {
+					t.Fatal("must be error")
+				}
+				if stmt.ToSQLString() != c.src {
+					t.Errorf("must not be modified but %s", stmt.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if stmt.ToSQLString() != c.expect {
+				t.Errorf("must be \n%s but \n%s", c.expect, stmt.ToSQLString())
+			}
+		})
+	}
+}
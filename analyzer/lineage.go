@@ -0,0 +1,143 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// ColumnLineage is the lineage of an output column: the columns of tables
+// its values derive from.
+type ColumnLineage struct {
+	Name    string
+	Sources []*SourceColumn
+}
+
+// SourceColumn is a column of a table (or a view) in the FROM clause.
+type SourceColumn struct {
+	Table  *sqlast.ObjectName
+	Column string
+}
+
+func (c *SourceColumn) String() string {
+	return c.Table.ToSQLString() + "." + c.Column
+}
+
+// Lineage returns the lineage of each output column of stmt, which is a
+// query, INSERT ... SELECT or CREATE VIEW, traversing CTEs, subqueries and
+// set operations. The output columns of INSERT are the target columns.
+// Columns referenced only in conditions (WHERE, JOIN ... ON, ...) are not
+// included. It returns nil if the output columns are unknown, e.g.
+// `SELECT *` from a table missing in catalog.
+func Lineage(stmt sqlast.Node, catalog Catalog) []*ColumnLineage {
+	result := Analyze(stmt, catalog)
+	switch s := stmt.(type) {
+	case *sqlast.QueryStmt:
+		return result.Lineage(s)
+	case *sqlast.CreateViewStmt:
+		return result.Lineage(s.Query)
+	case *sqlast.InsertStmt:
+		src, ok := s.Source.(*sqlast.SubQuerySource)
+		if !ok {
+			return nil
+		}
+		lineage := result.Lineage(src.SubQuery)
+		targets := identValues(s.Columns)
+		if targets == nil && catalog != nil {
+			if t, ok := catalog.Table(s.TableName); ok {
+				targets = t.ColumnNames()
+			}
+		}
+		if targets == nil {
+			return lineage
+		}
+		if len(lineage) > len(targets) {
+			lineage = lineage[:len(targets)]
+		}
+		for i, l := range lineage {
+			l.Name = targets[i]
+		}
+		return lineage
+	}
+	return nil
+}
+
+// Lineage returns the lineage of each output column of q, or nil if they are unknown.
+func (r *Result) Lineage(q *sqlast.QueryStmt) []*ColumnLineage {
+	outputs := r.queries[q]
+	if outputs == nil {
+		return nil
+	}
+	lineage := make([]*ColumnLineage, 0, len(outputs))
+	for _, o := range outputs {
+		l := &lineageCollector{result: r}
+		l.output(o)
+		lineage = append(lineage, &ColumnLineage{Name: o.name, Sources: l.sources})
+	}
+	return lineage
+}
+
+type lineageCollector struct {
+	result  *Result
+	sources []*SourceColumn
+}
+
+func (l *lineageCollector) add(table *sqlast.ObjectName, column string) {
+	for _, s := range l.sources {
+		if strings.EqualFold(s.Column, column) && strings.EqualFold(s.Table.ToSQLString(), table.ToSQLString()) {
+			return
+		}
+	}
+	l.sources = append(l.sources, &SourceColumn{Table: table, Column: column})
+}
+
+func (l *lineageCollector) output(o *output) {
+	if o.expr != nil {
+		l.expr(o.expr)
+	} else {
+		l.column(o.source, o.column)
+	}
+	for _, u := range o.union {
+		l.output(u)
+	}
+}
+
+func (l *lineageCollector) column(src *Source, name string) {
+	switch src.Kind {
+	case TableSource:
+		l.add(src.Table, name)
+	case CTESource, DerivedSource:
+		for _, o := range src.outputs {
+			if strings.EqualFold(o.name, name) {
+				l.output(o)
+				return
+			}
+		}
+	}
+}
+
+func (l *lineageCollector) expr(expr sqlast.Node) {
+	sqlast.Inspect(expr, func(node sqlast.Node) bool {
+		switch n := node.(type) {
+		case *sqlast.Ident, *sqlast.CompoundIdent:
+			c, ok := l.result.Columns[n]
+			switch {
+			case !ok:
+			case c.SelectItem != nil:
+				l.expr(c.SelectItem.Expr)
+			case c.Source != nil:
+				l.column(c.Source, c.Name)
+			}
+			return false
+		case *sqlast.SubQuery:
+			for _, o := range l.result.queries[n.Query] {
+				l.output(o)
+			}
+			return false
+		case *sqlast.QueryStmt:
+			// EXISTS and IN (subquery) only filter the values
+			return false
+		}
+		return true
+	})
+}
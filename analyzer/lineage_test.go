@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestLineage(t *testing.T) {
+	testCatalog, err := LoadCatalog(strings.NewReader(testSchema), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	cases := []struct {
+		name    string
+		src     string
+		catalog Catalog
+		expect  []string
+	}{
+		{
+			name:    "expressions",
+			src:     "SELECT u.id, upper(name) AS n, o.total * 2 AS t, 1 AS one FROM users AS u JOIN orders AS o ON u.id = o.user_id WHERE o.total > 0",
+			catalog: testCatalog,
+			expect:  []string{"id: users.id", "n: users.name", "t: orders.total", "one:"},
+		},
+		{
+			name:    "cte, derived table and wildcard",
+			src:     "WITH c AS (SELECT user_id, sum(total) AS s FROM orders GROUP BY user_id) SELECT * FROM (SELECT name, s FROM users JOIN c ON users.id = c.user_id) AS d",
+			catalog: testCatalog,
+			expect:  []string{"name: users.name", "s: orders.total"},
+		},
+		{
+			name:    "set operation",
+			src:     "SELECT id, name FROM users UNION ALL SELECT user_id, 'order' FROM orders",
+			catalog: testCatalog,
+			expect:  []string{"id: users.id, orders.user_id", "name: users.name"},
+		},
+		{
+			name:    "scalar subquery",
+			src:     "SELECT name, (SELECT max(total) FROM orders WHERE user_id = u.id) AS m FROM users AS u WHERE EXISTS (SELECT 1 FROM orders)",
+			catalog: testCatalog,
+			expect:  []string{"name: users.name", "m: orders.total"},
+		},
+		{
+			name:    "insert select",
+			src:     "INSERT INTO orders SELECT id, id, 0 FROM users",
+			catalog: testCatalog,
+			expect:  []string{"id: users.id", "user_id: users.id", "total:"},
+		},
+		{
+			name:   "without catalog",
+			src:    "CREATE VIEW v AS SELECT a + b AS c FROM public.t",
+			expect: []string{"c: public.t.a, public.t.b"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			var lineage []string
+			for _, l := range Lineage(stmt, c.catalog) {
+				var sources []string
+				for _, s := range l.Sources {
+					sources = append(sources, s.String())
+				}
+				lineage = append(lineage, strings.TrimSpace(l.Name+": "+strings.Join(sources, ", ")))
+			}
+			if diff := cmp.Diff(c.expect, lineage); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package analyzer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqlastutil"
+)
+
+// Parameter is a placeholder of a bind argument in a statement.
+type Parameter struct {
+	Node *sqlast.Placeholder
+	// Index is the 1-origin number of the bind argument, i.e: n of `$n` or
+	// the order of `?` in the statement. It is 0 for a named placeholder
+	// `:name`.
+	Index int
+	// Type is inferred from the context, e.g. the other operand of a
+	// comparison, the column assigned by SET or INSERT, or CAST. It is nil if
+	// unknown.
+	Type sqlast.Type
+	// Clause is the innermost clause containing the placeholder, e.g.
+	// "WHERE", "SET" or "VALUES", or "" if unknown.
+	Clause string
+}
+
+// Parameters returns the placeholders in stmt in order of appearance, taking
+// the types of columns from catalog, which may be nil. The number of bind
+// arguments stmt takes is the largest Index.
+func Parameters(stmt sqlast.Node, catalog Catalog) []*Parameter {
+	return Analyze(stmt, catalog).Parameters(stmt)
+}
+
+// clauses are the clauses of Parameter by the names of the fields.
+var clauses = map[string]string{
+	"Projection":        "SELECT",
+	"FromClause":        "FROM",
+	"WhereClause":       "WHERE",
+	"Selection":         "WHERE",
+	"SearchCondition":   "ON",
+	"GroupByClause":     "GROUP BY",
+	"HavingClause":      "HAVING",
+	"OrderBy":           "ORDER BY",
+	"Assignments":       "SET",
+	"UpdateAssignments": "SET",
+	"Rows":              "VALUES",
+	"Returning":         "RETURNING",
+	"LimitValue":        "LIMIT",
+	"OffsetValue":       "OFFSET",
+	"Fetch":             "FETCH",
+}
+
+// Parameters returns the placeholders in stmt, which is the node r is the
+// result of, as the function Parameters.
+func (r *Result) Parameters(stmt sqlast.Node) []*Parameter {
+	var params []*Parameter
+	var stack []*sqlastutil.Cursor
+	questions := 0
+	sqlastutil.Apply(stmt, func(c *sqlastutil.Cursor) bool {
+		if c.Node() == nil {
+			return false
+		}
+		cursor := *c
+		stack = append(stack, &cursor)
+		p, ok := c.Node().(*sqlast.Placeholder)
+		if !ok {
+			return true
+		}
+
+		param := &Parameter{Node: p}
+		if p.Value == "?" {
+			questions++
+			param.Index = questions
+		} else {
+			param.Index, _ = strconv.Atoi(strings.TrimPrefix(p.Value, "$"))
+		}
+		for i := len(stack) - 1; i > 0 && param.Clause == ""; i-- {
+			param.Clause = clauses[stack[i].Name()]
+		}
+		param.Type = r.parameterType(stack)
+		params = append(params, param)
+		return true
+	}, func(c *sqlastutil.Cursor) bool {
+		stack = stack[:len(stack)-1]
+		return true
+	})
+	return params
+}
+
+// parameterType infers the type of the placeholder at the end of stack from
+// its parent.
+func (r *Result) parameterType(stack []*sqlastutil.Cursor) sqlast.Type {
+	if len(stack) < 2 {
+		return nil
+	}
+	c := stack[len(stack)-1]
+	p := c.Node()
+	switch parent := c.Parent().(type) {
+	case *sqlast.BinaryExpr:
+		switch parent.Op.Type {
+		case sqlast.And, sqlast.Or:
+			return &sqlast.Boolean{}
+		}
+		if p == parent.Left {
+			return r.TypeOf(parent.Right)
+		}
+		return r.TypeOf(parent.Left)
+	case *sqlast.UnaryExpr:
+		if parent.Op.Type == sqlast.Not {
+			return &sqlast.Boolean{}
+		}
+		return nil
+	case *sqlast.Between:
+		if p != parent.Expr {
+			return r.TypeOf(parent.Expr)
+		}
+		return firstType(r, parent.Low, parent.High)
+	case *sqlast.InList:
+		if p != parent.Expr {
+			return r.TypeOf(parent.Expr)
+		}
+		return firstType(r, parent.List...)
+	case *sqlast.Cast:
+		return parent.DataType
+	case *sqlast.Assignment:
+		return r.TypeOf(parent.ID)
+	case *sqlast.RowValueExpr:
+		return r.insertType(stack, c.Index())
+	case *sqlast.LimitExpr, *sqlast.Fetch, *sqlast.Top:
+		return &sqlast.BigInt{}
+	}
+
+	// conditions
+	switch c.Name() {
+	case "WhereClause", "Selection", "HavingClause", "SearchCondition":
+		return &sqlast.Boolean{}
+	}
+	return nil
+}
+
+// firstType returns the type of the first expression in exprs whose type is known.
+func firstType(r *Result, exprs ...sqlast.Node) sqlast.Type {
+	for _, e := range exprs {
+		if t := r.TypeOf(e); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// insertType returns the type of the column of INSERT which the value at
+// index of a row at the end of stack is inserted to.
+func (r *Result) insertType(stack []*sqlastutil.Cursor, index int) sqlast.Type {
+	var insert *sqlast.InsertStmt
+	for i := len(stack) - 1; i >= 0 && insert == nil; i-- {
+		switch n := stack[i].Node().(type) {
+		case *sqlast.InsertStmt:
+			insert = n
+		case *sqlast.QueryStmt:
+			// VALUES in a subquery
+			return nil
+		}
+	}
+	if insert == nil || index < 0 {
+		return nil
+	}
+	if len(insert.Columns) != 0 {
+		if index < len(insert.Columns) {
+			return r.TypeOf(insert.Columns[index])
+		}
+		return nil
+	}
+	for _, src := range r.tables {
+		if src.Node == insert.TableName && src.Def != nil && index < len(src.Def.Columns) {
+			return src.Def.Columns[index].Type
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestParameters(t *testing.T) {
+	testCatalog, err := LoadCatalog(strings.NewReader(testSchema), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	cases := []struct {
+		name   string
+		src    string
+		expect []string
+	}{
+		{
+			name: "select",
+			src:  "SELECT name, ? FROM users AS u JOIN orders AS o ON o.user_id = u.id AND o.total > ? WHERE u.id IN (?, ?) AND ? < u.name GROUP BY name HAVING count(*) > ?",
+			expect: []string{
+				"1:14 ?1 <nil> SELECT",
+				"1:83 ?2 numeric(10,2) ON",
+				"1:100 ?3 int WHERE",
+				"1:103 ?4 int WHERE",
+				"1:110 ?5 character varying(255) WHERE",
+				"1:153 ?6 bigint HAVING",
+			},
+		},
+		{
+			name: "numbered",
+			src:  "SELECT * FROM orders WHERE total BETWEEN $1 AND $2 OR user_id = $1 AND CAST($3 AS text) = ''",
+			expect: []string{
+				"1:42 $1 numeric(10,2) WHERE",
+				"1:49 $2 numeric(10,2) WHERE",
+				"1:65 $1 int WHERE",
+				"1:77 $3 text WHERE",
+			},
+		},
+		{
+			name: "insert",
+			src:  "INSERT INTO orders VALUES (?, ?, ?), (?, ?, ?)",
+			expect: []string{
+				"1:28 ?1 int VALUES",
+				"1:31 ?2 int VALUES",
+				"1:34 ?3 numeric(10,2) VALUES",
+				"1:39 ?4 int VALUES",
+				"1:42 ?5 int VALUES",
+				"1:45 ?6 numeric(10,2) VALUES",
+			},
+		},
+		{
+			name: "insert columns",
+			src:  "INSERT INTO users (name, id) VALUES (?, ?)",
+			expect: []string{
+				"1:38 ?1 character varying(255) VALUES",
+				"1:41 ?2 int VALUES",
+			},
+		},
+		{
+			name: "update",
+			src:  "UPDATE orders SET total = ? WHERE id = ? AND ?",
+			expect: []string{
+				"1:27 ?1 numeric(10,2) SET",
+				"1:40 ?2 int WHERE",
+				"1:46 ?3 boolean WHERE",
+			},
+		},
+		{
+			name: "limit",
+			src:  "SELECT * FROM orders LIMIT ? OFFSET ? FETCH FIRST ? ROWS ONLY",
+			expect: []string{
+				"1:28 ?1 bigint LIMIT",
+				"1:37 ?2 bigint OFFSET",
+				"1:51 ?3 bigint FETCH",
+			},
+		},
+		{
+			name: "subquery",
+			src:  "SELECT * FROM users WHERE id = (SELECT user_id FROM orders WHERE total > ?)",
+			expect: []string{
+				"1:74 ?1 numeric(10,2) WHERE",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			var act []string
+			for _, p := range Parameters(stmt, testCatalog) {
+				typ := "<nil>"
+				if p.Type != nil {
+					typ = p.Type.ToSQLString()
+				}
+				pos := p.Node.Pos()
+				act = append(act, fmt.Sprintf("%d:%d %s%d %s %s", pos.Line, pos.Col, p.Node.Value[:1], p.Index, typ, p.Clause))
+			}
+			if diff := cmp.Diff(c.expect, act); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
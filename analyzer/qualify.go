@@ -0,0 +1,57 @@
+package analyzer
+
+import (
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqlastutil"
+)
+
+// QualifyColumns rewrites the unqualified column references under stmt into
+// ones qualified with the table name (or alias) of their sources, taking the
+// columns of tables from catalog. stmt is modified in place.
+//
+// References to select list aliases, columns merged by USING and NATURAL
+// JOIN, the targets of SET and the ORDER BY of set operations are kept as
+// they are. If a column is unknown or ambiguous, or can't be told which
+// table it belongs to, it returns an error without modifying stmt.
+func QualifyColumns(stmt sqlast.Node, catalog Catalog) error {
+	result := Analyze(stmt, catalog)
+	for _, d := range result.Diagnostics {
+		if d.Kind == UnknownColumn || d.Kind == AmbiguousColumn {
+			return errors.Errorf("qualify columns failed: %w", d)
+		}
+	}
+	if len(result.unresolved) != 0 {
+		n := result.unresolved[0]
+		pos := n.Pos()
+		return errors.Errorf("%d:%d: table of column %s is unknown", pos.Line, pos.Col, n.ToSQLString())
+	}
+
+	sqlastutil.Apply(stmt, func(c *sqlastutil.Cursor) bool {
+		switch n := c.Node().(type) {
+		case *sqlast.OrderByExpr:
+			// ORDER BY of set operations refers to the output columns
+			if q, ok := c.Parent().(*sqlast.QueryStmt); ok {
+				if _, ok := q.Body.(*sqlast.SetOperationExpr); ok {
+					return false
+				}
+			}
+		case *sqlast.Ident:
+			col, ok := result.Columns[n]
+			if !ok || col.Source == nil || col.merged {
+				return false
+			}
+			qualifier := qualifierOf(col.Source)
+			if qualifier == nil {
+				return false
+			}
+			if q := (&sqlast.CompoundIdent{Idents: append(qualifier, n)}); c.CanReplace(q) {
+				c.Replace(q)
+			}
+			return false
+		}
+		return true
+	}, nil)
+	return nil
+}
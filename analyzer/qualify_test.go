@@ -0,0 +1,117 @@
+package analyzer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestQualifyColumns(t *testing.T) {
+	testCatalog, err := LoadCatalog(strings.NewReader(testSchema), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	cases := []struct {
+		name      string
+		src       string
+		noCatalog bool
+		expect    string
+		err       bool
+	}{
+		{
+			name:   "single table",
+			src:    "SELECT id, name FROM users WHERE id = 1 ORDER BY name",
+			expect: "SELECT users.id, users.name FROM users WHERE users.id = 1 ORDER BY users.name",
+		},
+		{
+			name:   "join",
+			src:    "SELECT name, total FROM users AS u JOIN orders AS o ON u.id = user_id",
+			expect: "SELECT u.name, o.total FROM users AS u JOIN orders AS o ON u.id = o.user_id",
+		},
+		{
+			name:   "aliases and functions",
+			src:    "SELECT user_id, sum(total) AS s FROM orders GROUP BY user_id HAVING count(*) > 1 ORDER BY s",
+			expect: "SELECT orders.user_id, sum(orders.total) AS s FROM orders GROUP BY orders.user_id HAVING count(*) > 1 ORDER BY s",
+		},
+		{
+			name:   "using",
+			src:    "SELECT id, name FROM users JOIN orders USING (id)",
+			expect: "SELECT id, users.name FROM users JOIN orders USING (id)",
+		},
+		{
+			name:   "subqueries",
+			src:    "SELECT name FROM users WHERE EXISTS (SELECT 1 FROM orders WHERE user_id = id) AND id IN (SELECT x FROM (SELECT id AS x FROM orders) AS d)",
+			expect: "SELECT users.name FROM users WHERE EXISTS (SELECT 1 FROM orders WHERE orders.user_id = orders.id) AND users.id IN (SELECT d.x FROM (SELECT orders.id AS x FROM orders) AS d)",
+		},
+		{
+			name:   "set operation",
+			src:    "SELECT id FROM users UNION SELECT id FROM orders ORDER BY id",
+			expect: "SELECT users.id FROM users UNION SELECT orders.id FROM orders ORDER BY id",
+		},
+		{
+			name:   "update",
+			src:    "UPDATE orders SET total = total * 2 WHERE id = 1",
+			expect: "UPDATE orders SET total = orders.total * 2 WHERE orders.id = 1",
+		},
+		{
+			name:   "delete",
+			src:    "DELETE FROM orders AS o WHERE total > 1",
+			expect: "DELETE FROM orders AS o WHERE o.total > 1",
+		},
+		{
+			name:      "without catalog",
+			src:       "SELECT a FROM t WHERE b = 1",
+			noCatalog: true,
+			expect:    "SELECT t.a FROM t WHERE t.b = 1",
+		},
+		{
+			name: "ambiguous column",
+			src:  "SELECT id FROM users, orders",
+			err:  true,
+		},
+		{
+			name:      "unknown table of column",
+			src:       "SELECT a FROM t, u",
+			noCatalog: true,
+			err:       true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			var catalog Catalog = testCatalog
+			if c.noCatalog {
+				catalog = nil
+			}
+			err = QualifyColumns(stmt, catalog)
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but %s", stmt.ToSQLString())
+				}
+				if stmt.ToSQLString() != c.src {
+					t.Errorf("must not be modified but %s", stmt.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if stmt.ToSQLString() != c.expect {
+				t.Errorf("must be \n%s but \n%s", c.expect, stmt.ToSQLString())
+			}
+		})
+	}
+}
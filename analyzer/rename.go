@@ -0,0 +1,401 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// RenameTable renames the table oldName to newName in stmt, which is
+// modified in place. It updates table references in FROM and the targets of
+// INSERT, UPDATE, DELETE and MERGE, column qualifiers and `t.*` written with
+// the table name, and the tables of CREATE TABLE, ALTER TABLE, DROP TABLE,
+// CREATE INDEX, TRUNCATE, COPY, REFERENCES and FOR UPDATE OF.
+//
+// Names may be qualified by a schema, e.g. `public.orders`. An unqualified
+// oldName also matches qualified references, whose schema is kept if newName
+// is unqualified. CTEs of the same name are not renamed, and neither are
+// references through aliases, which stay valid.
+func RenameTable(stmt sqlast.Node, oldName, newName string) error {
+	from, err := splitName(oldName)
+	if err != nil {
+		return err
+	}
+	to, err := splitName(newName)
+	if err != nil {
+		return err
+	}
+	result := Analyze(stmt, nil)
+
+	// every name is renamed after the qualifiers referring to it are found
+	renamed := make(map[*sqlast.ObjectName][]*sqlast.Ident)
+	rename := func(name *sqlast.ObjectName) {
+		if matchName(name.Idents, from) {
+			renamed[name] = renameIdents(name.Idents, to)
+		}
+	}
+	// isTable reports whether src is the table referred to by its name.
+	isTable := func(src *Source) bool {
+		return src != nil && src.Kind == TableSource && matchName(src.Table.Idents, from) &&
+			src.Name == src.Table.ToSQLString()
+	}
+	qualifier := func(idents []*sqlast.Ident) []*sqlast.Ident {
+		if len(idents) == 1 {
+			return renameIdents(idents, to[len(to)-1:])
+		}
+		return renameIdents(idents, to)
+	}
+
+	for _, src := range result.tables {
+		if src.Kind == TableSource {
+			rename(src.Table)
+		}
+	}
+	var compounds []*sqlast.CompoundIdent
+	for ref, col := range result.Columns {
+		if c, ok := ref.(*sqlast.CompoundIdent); ok && isTable(col.Source) {
+			compounds = append(compounds, c)
+		}
+	}
+	var prefixes []*sqlast.ObjectName
+	for item, src := range result.prefixes {
+		if isTable(src) {
+			prefixes = append(prefixes, item.Prefix)
+		}
+	}
+
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		switch n := node.(type) {
+		case *sqlast.CreateTableStmt:
+			rename(n.Name)
+		case *sqlast.AlterTableStmt:
+			rename(n.TableName)
+		case *sqlast.DropTableStmt:
+			for _, name := range n.TableNames {
+				rename(name)
+			}
+		case *sqlast.CreateIndexStmt:
+			rename(n.TableName)
+		case *sqlast.TruncateStmt:
+			for _, name := range n.TableNames {
+				rename(name)
+			}
+		case *sqlast.CopyStmt:
+			rename(n.TableName)
+		case *sqlast.ReferencesColumnSpec:
+			rename(n.TableName)
+		case *sqlast.ReferenceKeyExpr:
+			if matchName([]*sqlast.Ident{n.TableName}, from) {
+				n.TableName = renameIdents([]*sqlast.Ident{n.TableName}, to[len(to)-1:])[0]
+			}
+		case *sqlast.LockingClause:
+			for _, name := range n.Of {
+				rename(name)
+			}
+		}
+		return true
+	})
+
+	for _, c := range compounds {
+		last := len(c.Idents) - 1
+		c.Idents = append(qualifier(c.Idents[:last]), c.Idents[last])
+	}
+	for _, p := range prefixes {
+		p.Idents = qualifier(p.Idents)
+	}
+	for name, idents := range renamed {
+		name.Idents = idents
+	}
+	return nil
+}
+
+// RenameColumn renames the column oldName of table to newName in stmt, which
+// is modified in place. It updates the references resolved to the column,
+// including the targets of INSERT and SET and RETURNING, and the column
+// names of CREATE TABLE, ALTER TABLE, CREATE INDEX, COPY and REFERENCES of
+// the table. table is matched as in RenameTable.
+//
+// Unaliased references in the select lists of subqueries in FROM, CTEs and
+// views are aliased with oldName so that the columns they output keep their
+// names. It returns an error without modifying stmt if a reference might be
+// to the column but can't be resolved, or if the column is merged by USING
+// or NATURAL JOIN.
+func RenameColumn(stmt sqlast.Node, table, oldName, newName string) error {
+	tbl, err := splitName(table)
+	if err != nil {
+		return err
+	}
+	if oldName == "" || newName == "" {
+		return errors.Errorf("column name must not be empty")
+	}
+	result := Analyze(stmt, nil)
+
+	referenced := false
+	for _, src := range result.tables {
+		if src.Kind == TableSource && matchName(src.Table.Idents, tbl) {
+			referenced = true
+		}
+	}
+
+	using := make(map[sqlast.Node]bool)
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		if j, ok := node.(*sqlast.NamedColumnsJoin); ok {
+			for _, c := range j.ColumnList {
+				using[c] = true
+			}
+		}
+		return true
+	})
+
+	var refs []sqlast.Node
+	if referenced {
+		for _, n := range result.unresolved {
+			if name, _ := columnName(n); strings.EqualFold(name, oldName) {
+				refs = append(refs, n)
+			}
+		}
+	}
+	for ref, col := range result.Columns {
+		if col.Source != nil && col.Source.Kind == TableSource && matchName(col.Source.Table.Idents, tbl) &&
+			strings.EqualFold(col.Name, oldName) && !aliasedColumn(col.Source, col.Name) {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		pi, pj := refs[i].Pos(), refs[j].Pos()
+		return pi.Line < pj.Line || pi.Line == pj.Line && pi.Col < pj.Col
+	})
+
+	var idents []*sqlast.Ident
+	renamed := make(map[sqlast.Node]bool)
+	for _, ref := range refs {
+		col, ok := result.Columns[ref]
+		pos := ref.Pos()
+		switch {
+		case !ok:
+			return errors.Errorf("%d:%d: table of column %s is unknown", pos.Line, pos.Col, ref.ToSQLString())
+		case col.merged || using[ref]:
+			return errors.Errorf("%d:%d: column %s is merged by USING or NATURAL JOIN", pos.Line, pos.Col, ref.ToSQLString())
+		}
+		switch r := ref.(type) {
+		case *sqlast.Ident:
+			idents = append(idents, r)
+		case *sqlast.CompoundIdent:
+			idents = append(idents, r.Idents[len(r.Idents)-1])
+		}
+		renamed[ref] = true
+	}
+
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		switch n := node.(type) {
+		case *sqlast.Derived:
+			if len(n.Columns) == 0 {
+				keepOutputNames(n.SubQuery.Body, renamed)
+			}
+		case *sqlast.CTE:
+			keepOutputNames(n.Query.Body, renamed)
+		case *sqlast.CreateViewStmt:
+			keepOutputNames(n.Query.Body, renamed)
+		}
+		return true
+	})
+
+	columns := func(names []*sqlast.Ident) {
+		for _, i := range names {
+			if strings.EqualFold(i.Value, oldName) {
+				idents = append(idents, i)
+			}
+		}
+	}
+	expr := func(n sqlast.Node) {
+		if n == nil {
+			return
+		}
+		sqlast.Inspect(n, func(node sqlast.Node) bool {
+			switch e := node.(type) {
+			case *sqlast.Ident:
+				columns([]*sqlast.Ident{e})
+			case *sqlast.CompoundIdent:
+				last := len(e.Idents) - 1
+				if matchName(e.Idents[:last], tbl) {
+					columns(e.Idents[last:])
+				}
+				return false
+			case *sqlast.ObjectName, *sqlast.QueryStmt:
+				return false
+			}
+			return true
+		})
+	}
+	constraint := func(spec sqlast.TableConstraintSpec) {
+		switch s := spec.(type) {
+		case *sqlast.UniqueTableConstraint:
+			columns(s.Columns)
+		case *sqlast.ReferentialTableConstraint:
+			columns(s.Columns)
+		case *sqlast.CheckTableConstraint:
+			expr(s.Expr)
+		}
+	}
+	columnConstraints := func(constraints []*sqlast.ColumnConstraint) {
+		for _, cons := range constraints {
+			if check, ok := cons.Spec.(*sqlast.CheckColumnSpec); ok {
+				expr(check.Expr)
+			}
+		}
+	}
+	columnDef := func(c *sqlast.ColumnDef) {
+		columns([]*sqlast.Ident{c.Name})
+		columnConstraints(c.Constraints)
+	}
+
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		switch n := node.(type) {
+		case *sqlast.CreateTableStmt:
+			if !matchName(n.Name.Idents, tbl) {
+				break
+			}
+			for _, e := range n.Elements {
+				switch el := e.(type) {
+				case *sqlast.ColumnDef:
+					columnDef(el)
+				case *sqlast.PartitionColumn:
+					columns([]*sqlast.Ident{el.Name})
+					columnConstraints(el.Constraints)
+				case *sqlast.TableConstraint:
+					constraint(el.Spec)
+				}
+			}
+		case *sqlast.AlterTableStmt:
+			if !matchName(n.TableName.Idents, tbl) {
+				break
+			}
+			switch a := n.Action.(type) {
+			case *sqlast.AddColumnTableAction:
+				columnDef(a.Column)
+			case *sqlast.AlterColumnTableAction:
+				columns([]*sqlast.Ident{a.ColumnName})
+			case *sqlast.RemoveColumnTableAction:
+				columns([]*sqlast.Ident{a.Name})
+			case *sqlast.AddConstraintTableAction:
+				constraint(a.Constraint.Spec)
+			}
+		case *sqlast.CreateIndexStmt:
+			if matchName(n.TableName.Idents, tbl) {
+				columns(n.ColumnNames)
+				expr(n.Selection)
+			}
+		case *sqlast.CopyStmt:
+			if matchName(n.TableName.Idents, tbl) {
+				columns(n.Columns)
+			}
+		case *sqlast.ReferencesColumnSpec:
+			if matchName(n.TableName.Idents, tbl) {
+				columns(n.Columns)
+			}
+		case *sqlast.ReferenceKeyExpr:
+			if matchName([]*sqlast.Ident{n.TableName}, tbl) {
+				columns(n.Columns)
+			}
+		}
+		return true
+	})
+
+	seen := make(map[*sqlast.Ident]bool)
+	for _, i := range idents {
+		if !seen[i] {
+			seen[i] = true
+			setIdent(i, newName)
+		}
+	}
+	return nil
+}
+
+// keepOutputNames aliases the unaliased items of the select lists in expr
+// which refer to renamed columns with their current names.
+func keepOutputNames(expr sqlast.SQLSetExpr, renamed map[sqlast.Node]bool) {
+	switch s := expr.(type) {
+	case *sqlast.SetOperationExpr:
+		keepOutputNames(s.Left, renamed)
+		keepOutputNames(s.Right, renamed)
+	case *sqlast.SelectExpr:
+		keepOutputNames(s.Select, renamed)
+	case *sqlast.SQLSelect:
+		for i, item := range s.Projection {
+			u, ok := item.(*sqlast.UnnamedSelectItem)
+			if !ok || !renamed[u.Node] {
+				continue
+			}
+			name, quoted := columnName(u.Node)
+			s.Projection[i] = &sqlast.AliasSelectItem{Expr: u.Node, Alias: &sqlast.Ident{Value: name, Quoted: quoted}}
+		}
+	}
+}
+
+// splitName splits a dotted name into its parts.
+func splitName(name string) ([]string, error) {
+	parts := strings.Split(name, ".")
+	for _, p := range parts {
+		if p == "" {
+			return nil, errors.Errorf("invalid name: %q", name)
+		}
+	}
+	return parts, nil
+}
+
+// matchName reports whether idents is the name of parts, comparing the
+// trailing parts both have.
+func matchName(idents []*sqlast.Ident, parts []string) bool {
+	if len(idents) == 0 {
+		return false
+	}
+	for i, j := len(idents)-1, len(parts)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if !strings.EqualFold(idents[i].Value, parts[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// renameIdents returns the name idents renamed to parts, keeping the schema
+// of idents if parts is a single name.
+func renameIdents(idents []*sqlast.Ident, parts []string) []*sqlast.Ident {
+	if len(parts) == 1 {
+		last := len(idents) - 1
+		renamed := append(copyIdents(idents[:last]), copyIdents(idents[last:])...)
+		setIdent(renamed[last], parts[0])
+		return renamed
+	}
+	renamed := make([]*sqlast.Ident, 0, len(parts))
+	for _, p := range parts {
+		renamed = append(renamed, columnIdent(p, false))
+	}
+	return renamed
+}
+
+// setIdent sets the value of i, which is also quoted if it can't be written
+// as is.
+func setIdent(i *sqlast.Ident, value string) {
+	i.Value = value
+	if !i.Quoted {
+		i.Quoted = columnIdent(value, false).Quoted
+	}
+}
+
+// aliasedColumn reports whether name is a column alias of src, i.e: one of
+// names of `AS t(names...)`, which isn't the name of the column in the table.
+func aliasedColumn(src *Source, name string) bool {
+	t, ok := src.Node.(*sqlast.Table)
+	if !ok {
+		return false
+	}
+	for _, c := range t.Columns {
+		if strings.EqualFold(c.Value, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package analyzer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestRenameTable(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		from   string
+		to     string
+		expect string
+	}{
+		{
+			name:   "query",
+			src:    "SELECT orders.id, o.total, orders.* FROM orders, public.orders AS o WHERE orders.id IN (SELECT order_id FROM items WHERE items.order_id = orders.id) FOR UPDATE OF orders",
+			from:   "orders",
+			to:     "purchases",
+			expect: "SELECT purchases.id, o.total, purchases.* FROM purchases, public.purchases AS o WHERE purchases.id IN (SELECT order_id FROM items WHERE items.order_id = purchases.id) FOR UPDATE OF purchases",
+		},
+		{
+			name:   "cte",
+			src:    "WITH orders AS (SELECT * FROM orders) SELECT orders.id FROM orders",
+			from:   "orders",
+			to:     "purchases",
+			expect: "WITH orders AS (SELECT * FROM purchases) SELECT orders.id FROM orders",
+		},
+		{
+			name:   "schema",
+			src:    "SELECT public.orders.id FROM public.orders, other.orders AS o",
+			from:   "public.orders",
+			to:     "archive.orders",
+			expect: "SELECT archive.orders.id FROM archive.orders, other.orders AS o",
+		},
+		{
+			name:   "dml",
+			src:    "DELETE FROM orders WHERE orders.id = 1 RETURNING orders.id",
+			from:   "orders",
+			to:     "purchases",
+			expect: "DELETE FROM purchases WHERE purchases.id = 1 RETURNING purchases.id",
+		},
+		{
+			name:   "returning of insert",
+			src:    "INSERT INTO orders (id) VALUES (1) RETURNING orders.id",
+			from:   "orders",
+			to:     "purchases",
+			expect: "INSERT INTO purchases (id) VALUES (1) RETURNING purchases.id",
+		},
+		{
+			name:   "returning of update",
+			src:    "UPDATE orders SET total = 0 RETURNING orders.*",
+			from:   "orders",
+			to:     "purchases",
+			expect: "UPDATE purchases SET total = 0 RETURNING purchases.*",
+		},
+		{
+			name:   "ddl",
+			src:    "CREATE TABLE items (id int, order_id int REFERENCES orders(id), FOREIGN KEY(order_id) REFERENCES orders(id))",
+			from:   "orders",
+			to:     "purchases",
+			expect: "CREATE TABLE items (id int, order_id int REFERENCES purchases(id), FOREIGN KEY(order_id) REFERENCES purchases(id))",
+		},
+		{
+			name:   "quoted",
+			src:    `ALTER TABLE "Orders" ADD COLUMN x int`,
+			from:   "orders",
+			to:     "order",
+			expect: `ALTER TABLE "order" ADD COLUMN x int`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if err := RenameTable(stmt, c.from, c.to); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if stmt.ToSQLString() != c.expect {
+				t.Errorf("must be \n%s but \n%s", c.expect, stmt.ToSQLString())
+			}
+		})
+	}
+}
+
+func TestRenameColumn(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		expect string
+		err    bool
+	}{
+		{
+			name: "unresolved column",
+			src:  "SELECT total FROM orders AS o JOIN users AS u ON o.user_id = u.id",
+			err:  true,
+		},
+		{
+			name:   "single table",
+			src:    "SELECT total, orders.total AS t FROM orders WHERE total > 1 ORDER BY total",
+			expect: "SELECT amount, orders.amount AS t FROM orders WHERE amount > 1 ORDER BY amount",
+		},
+		{
+			name:   "qualified",
+			src:    "SELECT o.total, u.total FROM orders AS o JOIN users AS u ON o.user_id = u.id",
+			expect: "SELECT o.amount, u.total FROM orders AS o JOIN users AS u ON o.user_id = u.id",
+		},
+		{
+			name:   "output names",
+			src:    "WITH c AS (SELECT total FROM orders) SELECT d.total FROM (SELECT total FROM orders) AS d, c",
+			expect: "WITH c AS (SELECT amount AS total FROM orders) SELECT d.total FROM (SELECT amount AS total FROM orders) AS d, c",
+		},
+		{
+			name:   "dml",
+			src:    "INSERT INTO orders (id, total) VALUES (1, 2)",
+			expect: "INSERT INTO orders (id, amount) VALUES (1, 2)",
+		},
+		{
+			name:   "update",
+			src:    "UPDATE orders SET total = total * 2 WHERE total IS NOT NULL",
+			expect: "UPDATE orders SET amount = amount * 2 WHERE amount IS NOT NULL",
+		},
+		{
+			name:   "column aliases",
+			src:    "SELECT o.total, p.total FROM orders AS o(id, total), orders AS p",
+			expect: "SELECT o.total, p.amount FROM orders AS o(id, total), orders AS p",
+		},
+		{
+			name:   "returning",
+			src:    "DELETE FROM orders AS o WHERE id = 1 RETURNING o.total",
+			expect: "DELETE FROM orders AS o WHERE id = 1 RETURNING o.amount",
+		},
+		{
+			name:   "returning of insert",
+			src:    "INSERT INTO orders (id, total) VALUES (1, 2) RETURNING total",
+			expect: "INSERT INTO orders (id, amount) VALUES (1, 2) RETURNING amount",
+		},
+		{
+			name:   "returning of update",
+			src:    "UPDATE orders SET total = 0 RETURNING orders.total",
+			expect: "UPDATE orders SET amount = 0 RETURNING orders.amount",
+		},
+		{
+			name:   "ddl",
+			src:    "CREATE TABLE orders (id int, total int CHECK(total > 0), UNIQUE(id, total))",
+			expect: "CREATE TABLE orders (id int, amount int CHECK(amount > 0), UNIQUE(id, amount))",
+		},
+		{
+			name:   "index",
+			src:    "CREATE INDEX orders_total ON orders (total)",
+			expect: "CREATE INDEX orders_total ON orders (amount)",
+		},
+		{
+			name: "using",
+			src:  "SELECT * FROM orders JOIN archived USING (total)",
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			err = RenameColumn(stmt, "orders", "total", "amount")
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but %s", stmt.ToSQLString())
+				}
+				if stmt.ToSQLString() != c.src {
+					t.Errorf("must not be modified but %s", stmt.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if stmt.ToSQLString() != c.expect {
+				t.Errorf("must be \n%s but \n%s", c.expect, stmt.ToSQLString())
+			}
+		})
+	}
+}
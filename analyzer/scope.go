@@ -0,0 +1,199 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+type cteDef struct {
+	cte     *sqlast.CTE
+	outputs []*output
+}
+
+type scope struct {
+	parent  *scope
+	ctes    []*cteDef
+	sources []*Source
+	merged  []string // columns merged by USING and NATURAL JOIN
+
+	// aliases in the select list which can be referenced.
+	// They take precedence over the columns of sources if preferAliases is true (ORDER BY).
+	aliases       []*sqlast.AliasSelectItem
+	preferAliases bool
+}
+
+func (s *scope) add(src *Source) *Source {
+	s.sources = append(s.sources, src)
+	return src
+}
+
+func (s *scope) merge(column string) {
+	s.merged = append(s.merged, column)
+}
+
+// withAliases returns a scope which sees aliases in addition to s.
+func (s *scope) withAliases(aliases []*sqlast.AliasSelectItem, prefer bool) *scope {
+	c := *s
+	c.aliases = aliases
+	c.preferAliases = prefer
+	return &c
+}
+
+func (s *scope) cte(name string) *cteDef {
+	for c := s; c != nil; c = c.parent {
+		for i := len(c.ctes) - 1; i >= 0; i-- {
+			if strings.EqualFold(c.ctes[i].cte.Alias.Value, name) {
+				return c.ctes[i]
+			}
+		}
+	}
+	return nil
+}
+
+// lookup returns the sources qualifier refers to in the nearest scope.
+func (s *scope) lookup(qualifier []string) []*Source {
+	q := strings.Join(qualifier, ".")
+	for c := s; c != nil; c = c.parent {
+		var found []*Source
+		for _, src := range c.sources {
+			if src.Name == "" {
+				continue
+			}
+			if strings.EqualFold(src.Name, q) {
+				found = append(found, src)
+				continue
+			}
+			// `schema.table` can be referenced as `table`
+			if src.Table != nil && src.Table.ToSQLString() == src.Name && len(qualifier) == 1 &&
+				strings.EqualFold(src.Table.Idents[len(src.Table.Idents)-1].Value, q) {
+				found = append(found, src)
+			}
+		}
+		if len(found) != 0 {
+			return found
+		}
+	}
+	return nil
+}
+
+func (s *scope) alias(name string) *sqlast.AliasSelectItem {
+	for _, a := range s.aliases {
+		if strings.EqualFold(a.Alias.Value, name) {
+			return a
+		}
+	}
+	return nil
+}
+
+func (s *scope) isMerged(column string) bool {
+	for _, m := range s.merged {
+		if strings.EqualFold(m, column) {
+			return true
+		}
+	}
+	return false
+}
+
+func aliasesOf(expr sqlast.SQLSetExpr) []*sqlast.AliasSelectItem {
+	var items []sqlast.SQLSelectItem
+	switch s := expr.(type) {
+	case *sqlast.SQLSelect:
+		items = s.Projection
+	case *sqlast.SelectExpr:
+		items = s.Select.Projection
+	case *sqlast.SetOperationExpr:
+		return aliasesOf(s.Left)
+	}
+	var aliases []*sqlast.AliasSelectItem
+	for _, item := range items {
+		if a, ok := item.(*sqlast.AliasSelectItem); ok {
+			aliases = append(aliases, a)
+		}
+	}
+	return aliases
+}
+
+func hasColumn(columns []string, column string) bool {
+	for _, c := range columns {
+		if strings.EqualFold(c, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve resolves the column reference node, which is `qualifier.column`
+// (or just `column` if qualifier is empty), in sc.
+func (a *analyzer) resolve(sc *scope, node sqlast.Node, qualifier []string, column string) {
+	if len(qualifier) != 0 {
+		sources := sc.lookup(qualifier)
+		switch {
+		case len(sources) == 0:
+			a.report(&Diagnostic{Kind: UnknownTable, Node: node})
+		case len(sources) > 1:
+			a.report(&Diagnostic{Kind: AmbiguousColumn, Node: node, Candidates: sources})
+		default:
+			a.resolveIn(node, column, sources)
+		}
+		return
+	}
+
+	if sc.preferAliases {
+		if item := sc.alias(column); item != nil {
+			a.result.Columns[node] = &Column{Name: item.Alias.Value, SelectItem: item}
+			return
+		}
+	}
+
+	for c := sc; c != nil; c = c.parent {
+		var matches, unknown []*Source
+		for _, src := range c.sources {
+			if src.Columns == nil {
+				unknown = append(unknown, src)
+			} else if hasColumn(src.Columns, column) {
+				matches = append(matches, src)
+			}
+		}
+
+		switch {
+		case len(matches) == 1:
+			a.result.Columns[node] = &Column{Name: column, Source: matches[0], merged: c.isMerged(column)}
+			return
+		case len(matches) > 1:
+			if c.isMerged(column) {
+				a.result.Columns[node] = &Column{Name: column, Source: matches[0], merged: true}
+				return
+			}
+			a.report(&Diagnostic{Kind: AmbiguousColumn, Node: node, Candidates: matches})
+			return
+		}
+		if item := c.alias(column); item != nil {
+			a.result.Columns[node] = &Column{Name: item.Alias.Value, SelectItem: item}
+			return
+		}
+		switch {
+		case len(unknown) == 1:
+			a.result.Columns[node] = &Column{Name: column, Source: unknown[0]}
+			return
+		case len(unknown) > 1:
+			// it can't be told which source has the column
+			a.result.unresolved = append(a.result.unresolved, node)
+			return
+		}
+	}
+	a.report(&Diagnostic{Kind: UnknownColumn, Node: node})
+}
+
+// resolveIn resolves node to column of sources, which must be a single source.
+func (a *analyzer) resolveIn(node sqlast.Node, column string, sources []*Source) {
+	if len(sources) != 1 {
+		return
+	}
+	src := sources[0]
+	if src.Columns != nil && !hasColumn(src.Columns, column) {
+		a.report(&Diagnostic{Kind: UnknownColumn, Node: node})
+		return
+	}
+	a.result.Columns[node] = &Column{Name: column, Source: src}
+}
@@ -0,0 +1,357 @@
+package analyzer
+
+import (
+	"math"
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// FunctionType returns the result type of a function called with arguments
+// of args. Elements of args are nil if their types are unknown.
+type FunctionType func(args []sqlast.Type) sqlast.Type
+
+// Functions is the registry of the built-in function signatures used by
+// TypeOf, keyed by the upper-cased function name. Entries can be added or
+// replaced to support user defined functions.
+var Functions = map[string]FunctionType{
+	"COUNT": returns(&sqlast.BigInt{}),
+	"SUM":   sumType,
+	"AVG":   avgType,
+	"MIN":   firstArg,
+	"MAX":   firstArg,
+	"ABS":   firstArg,
+	"ROUND": firstArg,
+	"CEIL":  firstArg,
+	"FLOOR": firstArg,
+
+	"COALESCE": commonArgs,
+	"NULLIF":   firstArg,
+	"GREATEST": commonArgs,
+	"LEAST":    commonArgs,
+
+	"LOWER":       returns(&sqlast.Text{}),
+	"UPPER":       returns(&sqlast.Text{}),
+	"CONCAT":      returns(&sqlast.Text{}),
+	"SUBSTR":      returns(&sqlast.Text{}),
+	"TRIM":        returns(&sqlast.Text{}),
+	"REPLACE":     returns(&sqlast.Text{}),
+	"LENGTH":      returns(&sqlast.Int{}),
+	"CHAR_LENGTH": returns(&sqlast.Int{}),
+
+	"NOW": returns(&sqlast.Timestamp{WithTimeZone: true}),
+
+	"ROW_NUMBER": returns(&sqlast.BigInt{}),
+	"RANK":       returns(&sqlast.BigInt{}),
+	"DENSE_RANK": returns(&sqlast.BigInt{}),
+}
+
+func returns(t sqlast.Type) FunctionType {
+	return func([]sqlast.Type) sqlast.Type {
+		return t
+	}
+}
+
+func firstArg(args []sqlast.Type) sqlast.Type {
+	if len(args) == 0 {
+		return nil
+	}
+	return args[0]
+}
+
+func commonArgs(args []sqlast.Type) sqlast.Type {
+	return commonType(args)
+}
+
+func sumType(args []sqlast.Type) sqlast.Type {
+	t := firstArg(args)
+	switch numericRank(t) {
+	case rankNone:
+		return nil
+	case rankSmallInt, rankInt, rankBigInt:
+		return &sqlast.BigInt{}
+	case rankDecimal:
+		return &sqlast.Decimal{}
+	}
+	return t
+}
+
+func avgType(args []sqlast.Type) sqlast.Type {
+	t := firstArg(args)
+	switch numericRank(t) {
+	case rankNone:
+		return nil
+	case rankReal, rankDouble:
+		return &sqlast.Double{}
+	}
+	return &sqlast.Decimal{}
+}
+
+// TypeOf returns the type of expr, which must be an expression in the
+// statement analyzed by Analyze. It returns nil if the type can't be
+// inferred, e.g. a column of a table missing in the Catalog or NULL.
+func (r *Result) TypeOf(expr sqlast.Node) sqlast.Type {
+	switch e := expr.(type) {
+	case *sqlast.Ident:
+		if !e.Quoted && isNiladic(e.Value) {
+			return niladicType(e.Value)
+		}
+		return r.columnType(r.Columns[e])
+	case *sqlast.CompoundIdent:
+		return r.columnType(r.Columns[e])
+	case *sqlast.LongValue:
+		if e.Long > math.MaxInt32 || e.Long < math.MinInt32 {
+			return &sqlast.BigInt{}
+		}
+		return &sqlast.Int{}
+	case *sqlast.DoubleValue, *sqlast.NumericValue:
+		return &sqlast.Decimal{}
+	case *sqlast.SingleQuotedString, *sqlast.DollarQuotedString, *sqlast.NationalStringLiteral, *sqlast.EscapedStringLiteral,
+		*sqlast.UnicodeStringLiteral:
+		return &sqlast.Text{}
+	case *sqlast.BooleanValue:
+		return &sqlast.Boolean{}
+	case *sqlast.DateValue:
+		return &sqlast.Date{}
+	case *sqlast.TimeValue:
+		return &sqlast.Time{}
+	case *sqlast.DateTimeValue, *sqlast.TimestampValue:
+		return &sqlast.Timestamp{}
+	case *sqlast.IntervalValue:
+		return customType("interval")
+	case *sqlast.Nested:
+		return r.TypeOf(e.AST)
+	case *sqlast.Cast:
+		return e.DataType
+	case *sqlast.Collate:
+		return r.TypeOf(e.Expr)
+	case *sqlast.UnaryExpr:
+		if e.Op.Type == sqlast.Not {
+			return &sqlast.Boolean{}
+		}
+		return r.TypeOf(e.Expr)
+	case *sqlast.BinaryExpr:
+		return r.binaryType(e)
+	case *sqlast.IsNull, *sqlast.IsNotNull, *sqlast.InList, *sqlast.InSubQuery, *sqlast.Between, *sqlast.LikeEscape, *sqlast.Exists:
+		return &sqlast.Boolean{}
+	case *sqlast.CaseExpr:
+		types := make([]sqlast.Type, 0, len(e.Results)+1)
+		for _, res := range e.Results {
+			types = append(types, r.TypeOf(res))
+		}
+		if e.ElseResult != nil {
+			types = append(types, r.TypeOf(e.ElseResult))
+		}
+		return commonType(types)
+	case *sqlast.SubQuery:
+		if types := r.OutputTypes(e.Query); len(types) != 0 {
+			return types[0]
+		}
+		return nil
+	case *sqlast.Function:
+		f, ok := Functions[strings.ToUpper(e.Name.Idents[len(e.Name.Idents)-1].Value)]
+		if !ok {
+			return nil
+		}
+		args := make([]sqlast.Type, 0, len(e.Args))
+		for _, a := range e.Args {
+			args = append(args, r.TypeOf(a))
+		}
+		return f(args)
+	case *sqlast.Substring, *sqlast.Trim, *sqlast.Overlay:
+		return &sqlast.Text{}
+	case *sqlast.Position:
+		return &sqlast.Int{}
+	case *sqlast.Extract:
+		return &sqlast.Decimal{}
+	}
+	return nil
+}
+
+// OutputColumns returns the names of the output columns of q, or nil if
+// they are unknown, e.g. `SELECT *` from a table missing in the Catalog.
+func (r *Result) OutputColumns(q *sqlast.QueryStmt) []string {
+	return outputNames(r.queries[q])
+}
+
+// OutputTypes returns the types of the output columns of q in the same order
+// as OutputColumns. Elements are nil if their types can't be inferred.
+func (r *Result) OutputTypes(q *sqlast.QueryStmt) []sqlast.Type {
+	outputs := r.queries[q]
+	if outputs == nil {
+		return nil
+	}
+	types := make([]sqlast.Type, 0, len(outputs))
+	for _, o := range outputs {
+		types = append(types, r.outputType(o))
+	}
+	return types
+}
+
+func (r *Result) outputType(o *output) sqlast.Type {
+	if o.expr != nil {
+		return r.TypeOf(o.expr)
+	}
+	return r.sourceColumnType(o.source, o.column)
+}
+
+func (r *Result) columnType(c *Column) sqlast.Type {
+	switch {
+	case c == nil:
+		return nil
+	case c.SelectItem != nil:
+		return r.TypeOf(c.SelectItem.Expr)
+	}
+	return r.sourceColumnType(c.Source, c.Name)
+}
+
+func (r *Result) sourceColumnType(src *Source, name string) sqlast.Type {
+	if src == nil {
+		return nil
+	}
+	if src.Def != nil {
+		if c := src.Def.Column(name); c != nil {
+			return c.Type
+		}
+		return nil
+	}
+	for _, o := range src.outputs {
+		if strings.EqualFold(o.name, name) {
+			return r.outputType(o)
+		}
+	}
+	return nil
+}
+
+func (r *Result) binaryType(e *sqlast.BinaryExpr) sqlast.Type {
+	switch e.Op.Type {
+	case sqlast.JSONGetText, sqlast.JSONPathGetText:
+		return &sqlast.Text{}
+	case sqlast.JSONGet, sqlast.JSONPathGet, sqlast.ShiftLeft, sqlast.ShiftRight:
+		return r.TypeOf(e.Left)
+	case sqlast.StringConcat:
+		return &sqlast.Text{}
+	case sqlast.BitwiseAnd, sqlast.BitwiseOr, sqlast.BitwiseXor:
+		return numericType(r.TypeOf(e.Left), r.TypeOf(e.Right))
+	case sqlast.Plus, sqlast.Minus, sqlast.Multiply, sqlast.Divide, sqlast.Modulus:
+	default:
+		// comparison, logical and pattern matching operators
+		return &sqlast.Boolean{}
+	}
+
+	left, right := r.TypeOf(e.Left), r.TypeOf(e.Right)
+	switch {
+	case isTemporal(left) && (e.Op.Type == sqlast.Plus || e.Op.Type == sqlast.Minus):
+		if e.Op.Type == sqlast.Minus && isTemporal(right) {
+			if _, ok := left.(*sqlast.Date); ok {
+				return &sqlast.Int{}
+			}
+			return customType("interval")
+		}
+		return left
+	case isTemporal(right) && e.Op.Type == sqlast.Plus:
+		return right
+	}
+	return numericType(left, right)
+}
+
+func isTemporal(t sqlast.Type) bool {
+	switch t.(type) {
+	case *sqlast.Date, *sqlast.Time, *sqlast.Timestamp:
+		return true
+	}
+	return false
+}
+
+func niladicType(name string) sqlast.Type {
+	switch strings.ToUpper(name) {
+	case "CURRENT_DATE":
+		return &sqlast.Date{}
+	case "CURRENT_TIME", "LOCALTIME":
+		return &sqlast.Time{}
+	case "CURRENT_TIMESTAMP":
+		return &sqlast.Timestamp{WithTimeZone: true}
+	case "LOCALTIMESTAMP", "SYSDATE":
+		return &sqlast.Timestamp{}
+	case "DEFAULT":
+		return nil
+	}
+	return &sqlast.Text{}
+}
+
+func customType(name string) *sqlast.Custom {
+	return &sqlast.Custom{Ty: sqlast.NewObjectName(name)}
+}
+
+// numeric types in order of promotion
+const (
+	rankNone = iota
+	rankSmallInt
+	rankInt
+	rankBigInt
+	rankDecimal
+	rankReal
+	rankDouble
+)
+
+func numericRank(t sqlast.Type) int {
+	switch t := t.(type) {
+	case *sqlast.SmallInt, *sqlast.SmallSerial:
+		return rankSmallInt
+	case *sqlast.Int, *sqlast.Serial:
+		return rankInt
+	case *sqlast.BigInt, *sqlast.BigSerial:
+		return rankBigInt
+	case *sqlast.Decimal:
+		return rankDecimal
+	case *sqlast.Real:
+		return rankReal
+	case *sqlast.Float, *sqlast.Double:
+		return rankDouble
+	case *sqlast.Custom:
+		switch strings.ToLower(t.Ty.ToSQLString()) {
+		case "int2":
+			return rankSmallInt
+		case "int4":
+			return rankInt
+		case "int8":
+			return rankBigInt
+		case "float4":
+			return rankReal
+		case "float8":
+			return rankDouble
+		}
+	}
+	return rankNone
+}
+
+// numericType returns the type of an arithmetic operation on left and right.
+// A non-numeric operand takes precedence, e.g. `interval * int` is interval.
+func numericType(left, right sqlast.Type) sqlast.Type {
+	l, r := numericRank(left), numericRank(right)
+	switch {
+	case l == rankNone && left != nil:
+		return left
+	case r == rankNone && right != nil:
+		return right
+	case l >= r:
+		return left
+	}
+	return right
+}
+
+// commonType returns the type to which all of types are converted, ignoring
+// unknown ones (NULL).
+func commonType(types []sqlast.Type) sqlast.Type {
+	var common sqlast.Type
+	for _, t := range types {
+		switch {
+		case t == nil:
+		case common == nil:
+			common = t
+		case numericRank(common) != rankNone && numericRank(t) != rankNone:
+			common = numericType(common, t)
+		}
+	}
+	return common
+}
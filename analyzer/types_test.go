@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestResult_OutputTypes(t *testing.T) {
+	testCatalog, err := LoadCatalog(strings.NewReader(testSchema), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	cases := []struct {
+		name    string
+		src     string
+		columns []string
+		types   []string
+	}{
+		{
+			name:    "columns and literals",
+			src:     "SELECT id, name, total, 1, 10000000000, 1.5, 'a', true, NULL FROM users, orders",
+			columns: []string{"id", "name", "total", "?column?", "?column?", "?column?", "?column?", "?column?", "?column?"},
+			types:   []string{"", "character varying(255)", "numeric(10,2)", "int", "bigint", "numeric", "text", "boolean", ""},
+		},
+		{
+			name:    "binary operators",
+			src:     "SELECT u.id + 1 AS a, o.total * 2 AS b, u.id = o.user_id AS c, NOT (u.id > 1) AS d FROM users AS u JOIN orders AS o ON u.id = o.user_id",
+			columns: []string{"a", "b", "c", "d"},
+			types:   []string{"int", "numeric(10,2)", "boolean", "boolean"},
+		},
+		{
+			name:    "string and bitwise operators",
+			src:     "SELECT name || '!' AS a, id & 1 AS b, id << 2 AS c FROM users",
+			columns: []string{"a", "b", "c"},
+			types:   []string{"text", "int", "int"},
+		},
+		{
+			name:    "functions",
+			src:     "SELECT count(*), sum(user_id), sum(total), avg(user_id), max(total), lower(name), coalesce(NULL, id, 1.5), now() FROM users, orders GROUP BY name",
+			columns: []string{"count", "sum", "sum", "avg", "max", "lower", "coalesce", "now"},
+			types:   []string{"bigint", "bigint", "numeric", "numeric", "numeric(10,2)", "text", "numeric", "timestamp with time zone"},
+		},
+		{
+			name:    "case, cast and predicates",
+			src:     "SELECT CASE WHEN id > 1 THEN 1 ELSE user_id * 2.5 END AS a, CAST(total AS int) AS b, id IS NULL AS c, id IN (1, 2) AS d, unknown_fn(id) AS e FROM orders",
+			columns: []string{"a", "b", "c", "d", "e"},
+			types:   []string{"numeric", "int", "boolean", "boolean", ""},
+		},
+		{
+			name:    "derived table, cte and wildcard",
+			src:     "WITH c AS (SELECT id, total * 2 AS doubled FROM orders) SELECT d.*, (SELECT max(doubled) FROM c) AS m FROM (SELECT * FROM c) AS d(x, y)",
+			columns: []string{"x", "y", "m"},
+			types:   []string{"int", "numeric(10,2)", "numeric(10,2)"},
+		},
+		{
+			name:    "date arithmetic",
+			src:     "SELECT CURRENT_DATE + 1 AS a, CURRENT_DATE - CURRENT_DATE AS b, CURRENT_TIMESTAMP - INTERVAL '1 day' AS c, CURRENT_USER AS d FROM users",
+			columns: []string{"a", "b", "c", "d"},
+			types:   []string{"date", "int", "timestamp with time zone", "text"},
+		},
+		{
+			name:    "unknown table",
+			src:     "SELECT a, a + 1 AS b, count(*) AS c FROM t",
+			columns: []string{"a", "b", "c"},
+			types:   []string{"", "int", "bigint"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			q := stmt.(*sqlast.QueryStmt)
+			result := Analyze(q, testCatalog)
+
+			var types []string
+			for _, ty := range result.OutputTypes(q) {
+				if ty == nil {
+					types = append(types, "")
+					continue
+				}
+				types = append(types, ty.ToSQLString())
+			}
+
+			if diff := cmp.Diff(c.columns, result.OutputColumns(q)); diff != "" {
+				t.Errorf("columns diff: %s", diff)
+			}
+			if diff := cmp.Diff(c.types, types); diff != "" {
+				t.Errorf("types diff: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+var (
+	nodeType = reflect.TypeOf((*sqlast.Node)(nil)).Elem()
+	posType  = reflect.TypeOf(sqltoken.Pos{})
+)
+
+// toJSON converts an AST into values which encoding/json can marshal.
+// Every node becomes an object with its type name in "Type".
+func toJSON(n sqlast.Node) interface{} {
+	return jsonValue(reflect.ValueOf(n))
+}
+
+func jsonValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Ptr && v.Type().Implements(nodeType) {
+			return jsonStruct(v.Elem(), v.Type().Elem().Name())
+		}
+		return jsonValue(v.Elem())
+	case reflect.Struct:
+		if v.Type() == posType {
+			return v.Interface()
+		}
+		name := ""
+		if reflect.PtrTo(v.Type()).Implements(nodeType) {
+			name = v.Type().Name()
+		}
+		return jsonStruct(v, name)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		list := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			list = append(list, jsonValue(v.Index(i)))
+		}
+		return list
+	}
+	return v.Interface()
+}
+
+func jsonStruct(v reflect.Value, name string) interface{} {
+	obj := make(map[string]interface{})
+	if name != "" {
+		obj["Type"] = name
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		// skip unexported fields and marker structs
+		if f.PkgPath != "" || f.Anonymous {
+			continue
+		}
+		obj[f.Name] = jsonValue(v.Field(i))
+	}
+	return obj
+}
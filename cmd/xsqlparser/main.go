@@ -0,0 +1,200 @@
+// Command xsqlparser parses, formats and checks SQL.
+//
+//	xsqlparser parse [-dialect name] [-tree] [file...]  dump the AST as JSON, or as a tree by -tree
+//	xsqlparser fmt   [-dialect name] [file...]          print formatted SQL
+//	xsqlparser check [-dialect name] [file...]          report syntax errors
+//
+// SQL is read from stdin when no file is given (or the file is `-`).
+// The exit code is 0 on success and for -h or --help, 1 if any input has
+// a syntax error, and 2 on usage or I/O errors.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+const (
+	exitOK = iota
+	exitSyntaxError
+	exitUsage
+)
+
+const usage = `usage: xsqlparser <command> [-dialect name] [file...]
+
+commands:
+  parse  dump the AST as JSON (or as an indented tree with -tree)
+  fmt    print formatted SQL
+  check  report syntax errors with positions
+
+dialects:
+  generic (default), postgresql, mysql, oracle, hive, clickhouse, redshift, sqlserver
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	cmd := args[0]
+	switch cmd {
+	case "parse", "fmt", "check":
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n%s", cmd, usage)
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dialectName := fs.String("dialect", "generic",
+		"sql dialect (generic, postgresql, mysql, oracle, hive, clickhouse, redshift, sqlserver)")
+	tree := fs.Bool("tree", false, "dump the AST as an indented tree instead of JSON (parse only)")
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	d, err := getDialect(*dialectName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	code := exitOK
+	for _, name := range files {
+		src, err := readInput(name, stdin)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitUsage
+		}
+
+		stmts, perr := parse(src, d)
+		if perr != nil {
+			fmt.Fprintf(stderr, "%s:%d:%d: %v\n", displayName(name), perr.pos.Line, perr.pos.Col, perr.err)
+			code = exitSyntaxError
+			continue
+		}
+
+		switch cmd {
+		case "parse":
+			if *tree {
+				for _, s := range stmts {
+					if err := sqlast.Fdump(stdout, s); err != nil {
+						fmt.Fprintln(stderr, err)
+						return exitUsage
+					}
+				}
+				continue
+			}
+			nodes := make([]interface{}, 0, len(stmts))
+			for _, s := range stmts {
+				nodes = append(nodes, toJSON(s))
+			}
+			enc := json.NewEncoder(stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(nodes); err != nil {
+				fmt.Fprintln(stderr, err)
+				return exitUsage
+			}
+		case "fmt":
+			for _, s := range stmts {
+				fmt.Fprintf(stdout, "%s;\n", s.ToSQLString())
+			}
+		}
+	}
+
+	return code
+}
+
+func getDialect(name string) (dialect.Dialect, error) {
+	switch strings.ToLower(name) {
+	case "generic":
+		return &dialect.GenericSQLDialect{}, nil
+	case "postgresql", "postgres":
+		return &dialect.PostgresqlDialect{}, nil
+	case "mysql":
+		return &dialect.MySQLDialect{}, nil
+	case "oracle":
+		return &dialect.OracleDialect{}, nil
+	case "hive":
+		return &dialect.HiveDialect{}, nil
+	case "clickhouse":
+		return &dialect.ClickHouseDialect{}, nil
+	case "redshift":
+		return &dialect.RedshiftDialect{}, nil
+	case "sqlserver", "mssql":
+		return &dialect.SQLServerDialect{}, nil
+	}
+	return nil, fmt.Errorf("unknown dialect %q", name)
+}
+
+func readInput(name string, stdin io.Reader) ([]byte, error) {
+	if name == "-" {
+		return ioutil.ReadAll(stdin)
+	}
+	return ioutil.ReadFile(name)
+}
+
+func displayName(name string) string {
+	if name == "-" {
+		return "<stdin>"
+	}
+	return name
+}
+
+type parseError struct {
+	pos sqltoken.Pos
+	err error
+}
+
+func parse(src []byte, d dialect.Dialect) (stmts []sqlast.Stmt, perr *parseError) {
+	// tokenized beforehand for the position of a tokenize error
+	tokenizer := sqltoken.NewTokenizer(strings.NewReader(string(src)), d)
+	if _, err := tokenizer.Tokenize(); err != nil {
+		return nil, &parseError{pos: tokenizer.Pos(), err: err}
+	}
+
+	parser, err := xsqlparser.NewParser(strings.NewReader(string(src)), d)
+	if err != nil {
+		return nil, &parseError{pos: tokenizer.Pos(), err: err}
+	}
+
+	// some parse failures are still reported as panics
+	defer func() {
+		if r := recover(); r != nil {
+			perr = &parseError{pos: parser.Pos(), err: fmt.Errorf("%v", r)}
+		}
+	}()
+
+	stmts, err = parser.ParseSQL()
+	if err != nil {
+		return nil, &parseError{pos: parser.Pos(), err: err}
+	}
+	return stmts, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		in     string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name:   "fmt",
+			args:   []string{"fmt"},
+			in:     "select a , b from t where a=1;\ndelete from t",
+			code:   exitOK,
+			stdout: "SELECT a, b FROM t WHERE a = 1;\nDELETE FROM t;\n",
+		},
+		{
+			name: "check ok",
+			args: []string{"check", "-dialect", "postgresql"},
+			in:   "SELECT a::text FROM t;",
+			code: exitOK,
+		},
+		{
+			name:   "check syntax error",
+			args:   []string{"check"},
+			in:     "SELECT a\nFROM t WHERE CASE b WHEN 1 'x' END",
+			code:   exitSyntaxError,
+			stderr: "<stdin>:2:26: ",
+		},
+		{
+			name:   "unknown dialect",
+			args:   []string{"check", "-dialect", "foo"},
+			code:   exitUsage,
+			stderr: "unknown dialect",
+		},
+		{
+			name:   "sqlserver",
+			args:   []string{"fmt", "-dialect", "sqlserver"},
+			in:     "select top 3 a from [t]",
+			code:   exitOK,
+			stdout: "SELECT TOP 3 a FROM [t];\n",
+		},
+		{
+			name:   "oracle",
+			args:   []string{"fmt", "-dialect", "oracle"},
+			in:     "select a from t minus select a from u",
+			code:   exitOK,
+			stdout: "SELECT a FROM t MINUS SELECT a FROM u;\n",
+		},
+		{
+			name:   "help",
+			args:   []string{"--help"},
+			code:   exitOK,
+			stdout: usage,
+		},
+		{
+			name: "help of command",
+			args: []string{"fmt", "-h"},
+			code: exitOK,
+		},
+		{
+			name:   "unknown command",
+			args:   []string{"foo"},
+			code:   exitUsage,
+			stderr: "unknown command",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(c.args, strings.NewReader(c.in), &stdout, &stderr)
+			if code != c.code {
+				t.Errorf("exit code should be %d but %d (stderr: %s)", c.code, code, stderr.String())
+			}
+			if stdout.String() != c.stdout {
+				t.Errorf("stdout should be \n %s but \n %s", c.stdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), c.stderr) {
+				t.Errorf("stderr should contain %q but %q", c.stderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunParse(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"parse"}, strings.NewReader("SELECT a FROM t"), &stdout, &stderr); code != exitOK {
+		t.Fatalf("exit code %d: %s", code, stderr.String())
+	}
+
+	var stmts []map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &stmts); err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 1 {
+		t.Fatalf("must be 1 stmt but %d", len(stmts))
+	}
+	if stmts[0]["Type"] != "QueryStmt" {
+		t.Errorf("Type should be QueryStmt but %v", stmts[0]["Type"])
+	}
+	body, ok := stmts[0]["Body"].(map[string]interface{})
+	if !ok || body["Type"] != "SQLSelect" {
+		t.Errorf("Body should be SQLSelect but %v", stmts[0]["Body"])
+	}
+}
+
+func TestRunParseTree(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"parse", "-tree"}, strings.NewReader("SELECT a FROM t"), &stdout, &stderr); code != exitOK {
+		t.Fatalf("exit code %d: %s", code, stderr.String())
+	}
+
+	if act := stdout.String(); !strings.HasPrefix(act, "*QueryStmt 1:1-1:16\n  Body: *SQLSelect") {
+		t.Errorf("should be dumped as a tree but %s", act)
+	}
+}
@@ -0,0 +1,204 @@
+package xsqlparser
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// Completion is the set of candidates valid at a cursor position returned by
+// ParseForCompletion.
+type Completion struct {
+	Prefix     string       // the word being typed right before the cursor
+	From       sqltoken.Pos // position of Prefix, or the cursor if Prefix is empty
+	Keywords   []string     // keywords, or sequences of them such as `ORDER BY`, starting with Prefix
+	Tokens     []string     // punctuations such as `(` and `,`, only if Prefix is empty
+	Identifier bool         // whether a name of a table, a column, an alias and so on is valid
+	Expression bool         // whether an expression such as a column, a function call or a literal is valid
+}
+
+// completion collects the candidates while the parser tries to parse the
+// tokens before the cursor.
+type completion struct {
+	keywords   map[string]struct{}
+	tokens     map[string]struct{}
+	identifier bool
+	expression bool
+}
+
+var (
+	// statementKeywords are the keywords starting a statement, see ParseStatement.
+	statementKeywords = []string{
+		"SELECT", "WITH", "VALUES", "CREATE", "DELETE", "INSERT", "ALTER", "UPDATE", "DROP", "COPY",
+		"TRUNCATE", "MERGE", "PREPARE", "EXECUTE", "CALL", "DEALLOCATE", "DECLARE", "FETCH", "CLOSE",
+		"SET", "SHOW", "RESET", "EXPLAIN", "VACUUM", "ANALYZE", "REINDEX",
+	}
+	// expressionKeywords are the keywords starting an expression, see parsePrefix.
+	expressionKeywords = []string{
+		"TRUE", "FALSE", "NULL", "CASE", "CAST", "INTERVAL", "EXISTS", "NOT",
+		"EXTRACT", "POSITION", "SUBSTRING", "TRIM", "OVERLAY", "ARRAY", "ROW",
+	}
+	// infixKeywords are the keywords of binary operators, see getPrecedence.
+	infixKeywords = []string{
+		"OR", "AND", "NOT", "IS", "IN", "BETWEEN", "LIKE", "ILIKE", "SIMILAR", "COLLATE",
+	}
+	// joinKeywords are the keywords starting a join, see parseTableReferenceRight.
+	joinKeywords = []string{
+		"JOIN", "INNER JOIN", "LEFT JOIN", "LEFT OUTER JOIN", "RIGHT JOIN", "RIGHT OUTER JOIN",
+		"FULL JOIN", "FULL OUTER JOIN", "CROSS JOIN", "NATURAL JOIN",
+	}
+	// typeKeywords are the names of the data types, see parseDataType.
+	typeKeywords = []string{
+		"BOOLEAN", "FLOAT", "REAL", "DOUBLE PRECISION", "SMALLINT", "INTEGER", "INT", "BIGINT",
+		"VARCHAR", "CHAR", "CHARACTER", "UUID", "DATE", "TIMESTAMP", "TIME", "REGCLASS", "TEXT",
+		"BYTEA", "NUMERIC",
+	}
+	punctuations = map[sqltoken.Kind]string{
+		sqltoken.Comma:     ",",
+		sqltoken.Period:    ".",
+		sqltoken.Semicolon: ";",
+		sqltoken.LParen:    "(",
+		sqltoken.RParen:    ")",
+		sqltoken.LBracket:  "[",
+		sqltoken.RBracket:  "]",
+		sqltoken.Eq:        "=",
+		sqltoken.Mult:      "*",
+	}
+)
+
+// ParseForCompletion returns the candidates syntactically valid at the byte
+// offset of src, for autocompletion in editors and CLIs.
+//
+// The statement containing the offset is parsed up to it. Keywords,
+// punctuations and names which the parser would accept there are collected,
+// except the word being typed, which filters the keywords instead. The
+// completion is empty if the statement has a syntax error before the offset.
+func ParseForCompletion(src string, offset int, dialect dialect.Dialect, opts ...ParserOption) (*Completion, error) {
+	if offset < 0 || offset > len(src) {
+		return nil, errors.Errorf("offset %d out of source of %d bytes", offset, len(src))
+	}
+	tokenizer := sqltoken.NewTokenizer(strings.NewReader(src[:offset]), dialect)
+	var tokens []*sqltoken.Token
+	for {
+		tok, err := tokenizer.NextToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Errorf("tokenize failed: %w", err)
+		}
+		tokens = append(tokens, tok)
+	}
+
+	c := &Completion{From: tokenizer.Pos()}
+	if n := len(tokens); n != 0 {
+		last := tokens[n-1]
+		if w, ok := last.Value.(*sqltoken.SQLWord); ok && w.QuoteStyle == 0 && last.EndOffset() == offset {
+			c.Prefix, c.From = w.Value, last.From
+			tokens = tokens[:n-1]
+		}
+	}
+
+	p := NewParserWithOptions(opts...)
+	p.dialect = dialect
+	p.tokens = tokens
+	for {
+		// only the last statement matters
+		_, to, _ := p.scanStatement(int(p.index))
+		if to == len(tokens) {
+			break
+		}
+		p.index = uint(to + 1)
+	}
+
+	p.completion = &completion{
+		keywords: make(map[string]struct{}),
+		tokens:   make(map[string]struct{}),
+	}
+	if _, err := p.ParseStatement(); err == nil {
+		// the statement may end at the cursor, or before it if an optional
+		// clause being typed was rolled back, e.g: `ORDER` without `BY`
+		p.suggestToken(sqltoken.Semicolon)
+	}
+
+	prefix := strings.ToUpper(c.Prefix)
+	for k := range p.completion.keywords {
+		if strings.HasPrefix(k, prefix) {
+			c.Keywords = append(c.Keywords, k)
+		}
+	}
+	sort.Strings(c.Keywords)
+	if prefix == "" {
+		for t := range p.completion.tokens {
+			c.Tokens = append(c.Tokens, t)
+		}
+		sort.Strings(c.Tokens)
+	}
+	c.Identifier = p.completion.identifier
+	c.Expression = p.completion.expression
+
+	return c, nil
+}
+
+// atCursor reports whether p collects the completion candidates and reached
+// the cursor, i.e. the end of the tokens.
+func (p *Parser) atCursor() bool {
+	if p.completion == nil {
+		return false
+	}
+	_, err := p.tilNonWhitespace()
+	return err == EOF
+}
+
+func (p *Parser) suggestKeywords(keywords ...string) {
+	if !p.atCursor() {
+		return
+	}
+	for _, k := range keywords {
+		p.completion.keywords[k] = struct{}{}
+	}
+}
+
+func (p *Parser) suggestToken(kind sqltoken.Kind) {
+	if !p.atCursor() {
+		return
+	}
+	if s, ok := punctuations[kind]; ok {
+		p.completion.tokens[s] = struct{}{}
+	}
+}
+
+func (p *Parser) suggestIdentifier() {
+	if p.atCursor() {
+		p.completion.identifier = true
+	}
+}
+
+func (p *Parser) suggestStatement() {
+	if !p.atCursor() {
+		return
+	}
+	p.suggestKeywords(statementKeywords...)
+	if p.redshift() {
+		p.suggestKeywords("UNLOAD")
+	}
+	if p.mySQLSyntax() {
+		p.suggestKeywords("DESCRIBE", "USE")
+	}
+	p.suggestToken(sqltoken.LParen)
+}
+
+func (p *Parser) suggestExpression() {
+	if !p.atCursor() {
+		return
+	}
+	p.completion.identifier = true
+	p.completion.expression = true
+	p.suggestKeywords(expressionKeywords...)
+	p.suggestToken(sqltoken.LParen)
+}
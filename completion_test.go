@@ -0,0 +1,137 @@
+package xsqlparser
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+func TestParseForCompletion(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string // `|` is the cursor
+		expect *Completion
+	}{
+		{
+			name: "statement",
+			src:  "SELECT 1;\nIN|",
+			expect: &Completion{
+				Prefix:   "IN",
+				From:     sqltoken.NewPos(2, 1),
+				Keywords: []string{"INSERT"},
+			},
+		},
+		{
+			name: "table",
+			src:  "SELECT * FROM |",
+			expect: &Completion{
+				From:       sqltoken.NewPos(1, 15),
+				Keywords:   []string{"LATERAL", "UNNEST"},
+				Identifier: true,
+			},
+		},
+		{
+			name: "clause",
+			src:  "SELECT * FROM t W|",
+			expect: &Completion{
+				Prefix:   "W",
+				From:     sqltoken.NewPos(1, 17),
+				Keywords: []string{"WHERE", "WINDOW", "WITH"},
+			},
+		},
+		{
+			name: "sequence of keywords",
+			src:  "SELECT * FROM t ORDER |",
+			expect: &Completion{
+				From:     sqltoken.NewPos(1, 23),
+				Keywords: []string{"BY"},
+			},
+		},
+		{
+			name: "set operation",
+			src:  "SELECT * FROM t WHERE a = 1 UN|",
+			expect: &Completion{
+				Prefix:   "UN",
+				From:     sqltoken.NewPos(1, 29),
+				Keywords: []string{"UNION"},
+			},
+		},
+		{
+			name: "join",
+			src:  "SELECT * FROM t LEFT JOIN u ON t.id = u.id LEF|",
+			expect: &Completion{
+				Prefix:   "LEF",
+				From:     sqltoken.NewPos(1, 44),
+				Keywords: []string{"LEFT JOIN", "LEFT OUTER JOIN"},
+			},
+		},
+		{
+			name: "expression",
+			src:  "SELECT * FROM t WHERE |",
+			expect: &Completion{
+				From:       sqltoken.NewPos(1, 23),
+				Keywords:   expressionKeywords,
+				Tokens:     []string{"("},
+				Identifier: true,
+				Expression: true,
+			},
+		},
+		{
+			name: "column of a table",
+			src:  "SELECT u.| FROM users AS u",
+			expect: &Completion{
+				From:       sqltoken.NewPos(1, 10),
+				Identifier: true,
+			},
+		},
+		{
+			name: "data type",
+			src:  "CREATE TABLE t (a BIG|",
+			expect: &Completion{
+				Prefix:   "BIG",
+				From:     sqltoken.NewPos(1, 19),
+				Keywords: []string{"BIGINT"},
+			},
+		},
+		{
+			name: "end of statement",
+			src:  "UPDATE t SET a = 1 |",
+			expect: &Completion{
+				From:     sqltoken.NewPos(1, 20),
+				Keywords: append([]string{"WHERE", "RETURNING"}, infixKeywords...),
+				Tokens:   []string{",", ";"},
+			},
+		},
+		{
+			name:   "syntax error",
+			src:    "SELECT * FROM WHERE a |",
+			expect: &Completion{From: sqltoken.NewPos(1, 23)},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			offset := strings.Index(c.src, "|")
+			src := c.src[:offset] + c.src[offset+1:]
+			actual, err := ParseForCompletion(src, offset, &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			expect := *c.expect
+			expect.Keywords = append([]string(nil), expect.Keywords...)
+			sort.Strings(expect.Keywords)
+			if diff := cmp.Diff(&expect, actual); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+
+	if _, err := ParseForCompletion("SELECT 'a", 9, &dialect.GenericSQLDialect{}); err == nil {
+		t.Error("must be error in a string literal")
+	}
+}
@@ -0,0 +1,191 @@
+package xsqlparser
+
+import (
+	"strings"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// candidates of DetectDialect, in the order of preference on a tie
+const (
+	detectPostgres = iota
+	detectMySQL
+	detectOracle
+	detectHive
+	detectClickHouse
+	detectRedshift
+	numDetect
+)
+
+var detectDialects = [numDetect]func() dialect.Dialect{
+	detectPostgres:   func() dialect.Dialect { return &dialect.PostgresqlDialect{} },
+	detectMySQL:      func() dialect.Dialect { return &dialect.MySQLDialect{} },
+	detectOracle:     func() dialect.Dialect { return &dialect.OracleDialect{} },
+	detectHive:       func() dialect.Dialect { return &dialect.HiveDialect{} },
+	detectClickHouse: func() dialect.Dialect { return &dialect.ClickHouseDialect{} },
+	detectRedshift:   func() dialect.Dialect { return &dialect.RedshiftDialect{} },
+}
+
+type detectScores [numDetect]int
+
+// keyword sequences which are specific to (a part of) the dialects
+var detectKeywordMarkers = []struct {
+	keywords []string
+	scores   detectScores
+}{
+	{[]string{"AUTO_INCREMENT"}, detectScores{detectMySQL: 3}},
+	{[]string{"DISTINCT", "ON"}, detectScores{detectPostgres: 3}},
+	{[]string{"ILIKE"}, detectScores{detectPostgres: 1, detectRedshift: 1}},
+	{[]string{"RETURNING"}, detectScores{detectPostgres: 1}},
+	{[]string{"MINUS"}, detectScores{detectOracle: 2}},
+	{[]string{"ROWNUM"}, detectScores{detectOracle: 3}},
+	{[]string{"CONNECT", "BY"}, detectScores{detectOracle: 3}},
+	{[]string{"DUAL"}, detectScores{detectOracle: 1, detectMySQL: 1}},
+	{[]string{"LATERAL", "VIEW"}, detectScores{detectHive: 4}},
+	{[]string{"DISTRIBUTE", "BY"}, detectScores{detectHive: 3}},
+	{[]string{"SORT", "BY"}, detectScores{detectHive: 3}},
+	{[]string{"CLUSTER", "BY"}, detectScores{detectHive: 3}},
+	{[]string{"STORED", "AS"}, detectScores{detectHive: 3}},
+	{[]string{"ARRAY", "JOIN"}, detectScores{detectClickHouse: 4}},
+	{[]string{"PREWHERE"}, detectScores{detectClickHouse: 4}},
+	{[]string{"FINAL"}, detectScores{detectClickHouse: 2}},
+	{[]string{"SAMPLE"}, detectScores{detectClickHouse: 1}},
+	{[]string{"UNLOAD"}, detectScores{detectRedshift: 3}},
+	{[]string{"IAM_ROLE"}, detectScores{detectRedshift: 3}},
+	{[]string{"DISTKEY"}, detectScores{detectRedshift: 3}},
+	{[]string{"SORTKEY"}, detectScores{detectRedshift: 3}},
+	{[]string{"DISTSTYLE"}, detectScores{detectRedshift: 3}},
+}
+
+// DetectDialect guesses the dialect of src by scanning its tokens for
+// dialect-specific markers such as backquoted identifiers, `LIMIT x, y`,
+// `::` casts, `(+)` outer joins or `LATERAL VIEW`. It returns the best-guess
+// dialect and the confidence in [0, 1], which is the share of the evidence
+// for the returned dialect. If no marker is found, it returns
+// GenericSQLDialect with the confidence 0.
+//
+// Only the dialects in package dialect are candidates, so markers of other
+// dialects (e.g. `[bracketed]` identifiers and TOP of SQL Server) are not
+// taken into account. src is not parsed, and scanning stops at the first
+// token which cannot be tokenized.
+func DetectDialect(src string) (dialect.Dialect, float64) {
+	scores := scoreDialects(significantTokens(src))
+
+	best, total := 0, 0
+	for i, s := range scores {
+		total += s
+		if s > scores[best] {
+			best = i
+		}
+	}
+	if total == 0 {
+		return &dialect.GenericSQLDialect{}, 0
+	}
+	return detectDialects[best](), float64(scores[best]) / float64(total)
+}
+
+// detectTokenizerDialect accepts the lexical elements of every candidate,
+// so that the markers can be found regardless of the dialect.
+type detectTokenizerDialect struct {
+	dialect.GenericSQLDialect
+}
+
+func (*detectTokenizerDialect) IsDelimitedIdentifierStart(r rune) bool {
+	return r == '"' || r == '`'
+}
+
+func (*detectTokenizerDialect) Operators() []string {
+	return append([]string{"<=>"}, (&dialect.PostgresqlDialect{}).Operators()...)
+}
+
+var _ dialect.OperatorDialect = &detectTokenizerDialect{}
+
+// significantTokens returns tokens of src except whitespace and comments.
+func significantTokens(src string) []*sqltoken.Token {
+	tokenizer := sqltoken.NewTokenizer(strings.NewReader(src), &detectTokenizerDialect{})
+
+	var tokens []*sqltoken.Token
+	for {
+		tok, err := tokenizer.NextToken()
+		if err != nil {
+			return tokens
+		}
+		if tok.Kind == sqltoken.Whitespace || tok.Kind == sqltoken.Comment {
+			continue
+		}
+		tokens = append(tokens, tok)
+	}
+}
+
+func scoreDialects(tokens []*sqltoken.Token) detectScores {
+	var scores detectScores
+	add := func(s detectScores) {
+		for i := range scores {
+			scores[i] += s[i]
+		}
+	}
+
+	kindAt := func(i int) sqltoken.Kind {
+		if i < 0 || i >= len(tokens) {
+			return sqltoken.ILLEGAL
+		}
+		return tokens[i].Kind
+	}
+	keywordAt := func(i int) string {
+		if kindAt(i) != sqltoken.SQLKeyword {
+			return ""
+		}
+		w := tokens[i].Value.(*sqltoken.SQLWord)
+		if w.QuoteStyle != 0 {
+			return ""
+		}
+		return w.Keyword
+	}
+
+	for i, tok := range tokens {
+		switch tok.Kind {
+		case sqltoken.SQLKeyword:
+			if tok.Value.(*sqltoken.SQLWord).QuoteStyle == '`' {
+				add(detectScores{detectMySQL: 2, detectHive: 2, detectClickHouse: 1})
+			}
+		case sqltoken.DoubleColon:
+			add(detectScores{detectPostgres: 2, detectRedshift: 2})
+		case sqltoken.DollarQuotedString:
+			add(detectScores{detectPostgres: 3})
+		case sqltoken.CustomOperator:
+			if tok.Value == "<=>" {
+				add(detectScores{detectMySQL: 3})
+			}
+		case sqltoken.LParen:
+			if kindAt(i+1) == sqltoken.Plus && kindAt(i+2) == sqltoken.RParen {
+				add(detectScores{detectOracle: 4})
+			}
+		case sqltoken.Number:
+			// LIMIT offset, count
+			if keywordAt(i-1) == "LIMIT" && kindAt(i+1) == sqltoken.Comma && kindAt(i+2) == sqltoken.Number {
+				add(detectScores{detectMySQL: 3})
+			}
+		}
+
+		if keywordAt(i) == "ENGINE" && kindAt(i+1) == sqltoken.Eq {
+			switch engine := keywordAt(i + 2); {
+			case engine == "INNODB" || engine == "MYISAM":
+				add(detectScores{detectMySQL: 3})
+			case strings.HasSuffix(engine, "MERGETREE"):
+				add(detectScores{detectClickHouse: 4})
+			}
+		}
+
+	markers:
+		for _, m := range detectKeywordMarkers {
+			for j, k := range m.keywords {
+				if keywordAt(i+j) != k {
+					continue markers
+				}
+			}
+			add(m.scores)
+		}
+	}
+	return scores
+}
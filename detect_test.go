@@ -0,0 +1,94 @@
+package xsqlparser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestDetectDialect(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		expect  dialect.Dialect
+		minConf float64
+	}{
+		{
+			name:    "mysql backquote and limit",
+			in:      "SELECT `id` FROM `users` WHERE a <=> b LIMIT 10, 20",
+			expect:  &dialect.MySQLDialect{},
+			minConf: 0.6,
+		},
+		{
+			name:    "mysql engine",
+			in:      "CREATE TABLE t (id int AUTO_INCREMENT) ENGINE=InnoDB",
+			expect:  &dialect.MySQLDialect{},
+			minConf: 1,
+		},
+		{
+			name:    "postgres",
+			in:      "SELECT DISTINCT ON (a) a::text FROM t WHERE b ILIKE $$x%$$",
+			expect:  &dialect.PostgresqlDialect{},
+			minConf: 0.7,
+		},
+		{
+			name:    "oracle",
+			in:      "SELECT a.id FROM a, b WHERE a.id = b.id(+) AND ROWNUM < 10 MINUS SELECT id FROM c",
+			expect:  &dialect.OracleDialect{},
+			minConf: 1,
+		},
+		{
+			name:    "hive",
+			in:      "SELECT `c`, x FROM t LATERAL VIEW explode(arr) v AS x DISTRIBUTE BY c SORT BY x",
+			expect:  &dialect.HiveDialect{},
+			minConf: 0.8,
+		},
+		{
+			name:    "clickhouse",
+			in:      "SELECT a FROM t FINAL ARRAY JOIN arr PREWHERE b = 1",
+			expect:  &dialect.ClickHouseDialect{},
+			minConf: 1,
+		},
+		{
+			name:    "redshift",
+			in:      "UNLOAD ('select 1') TO 's3://bucket/' IAM_ROLE 'arn'",
+			expect:  &dialect.RedshiftDialect{},
+			minConf: 1,
+		},
+		{
+			name:    "no marker",
+			in:      "SELECT a FROM t WHERE b = 1",
+			expect:  &dialect.GenericSQLDialect{},
+			minConf: 0,
+		},
+		{
+			name:    "markers in strings and comments are ignored",
+			in:      "SELECT 'LATERAL VIEW' -- LIMIT 1, 2\nFROM t",
+			expect:  &dialect.GenericSQLDialect{},
+			minConf: 0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d, conf := DetectDialect(c.in)
+			if reflect.TypeOf(d) != reflect.TypeOf(c.expect) {
+				t.Errorf("must be %T but %T", c.expect, d)
+			}
+			if conf < c.minConf || conf > 1 {
+				t.Errorf("confidence must be in [%v, 1] but %v", c.minConf, conf)
+			}
+		})
+	}
+
+	t.Run("ambiguous", func(t *testing.T) {
+		d, conf := DetectDialect("SELECT a::int FROM t")
+		if _, ok := d.(*dialect.PostgresqlDialect); !ok {
+			t.Errorf("must prefer PostgreSQL on a tie but %T", d)
+		}
+		if conf != 0.5 {
+			t.Errorf("confidence must be 0.5 but %v", conf)
+		}
+	})
+}
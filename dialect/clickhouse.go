@@ -0,0 +1,34 @@
+package dialect
+
+// ClickHouseDialect is the dialect of ClickHouse.
+// Identifiers can be quoted with double quotes or backticks.
+type ClickHouseDialect struct {
+}
+
+func (*ClickHouseDialect) IsIdentifierStart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+}
+
+func (*ClickHouseDialect) IsIdentifierPart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_'
+}
+
+func (*ClickHouseDialect) IsDelimitedIdentifierStart(r rune) bool {
+	return r == '"' || r == '`'
+}
+
+var _ Dialect = &ClickHouseDialect{}
+
+// ClickHouseSyntax reports that the ClickHouse specific syntax is supported.
+func (*ClickHouseDialect) ClickHouseSyntax() bool {
+	return true
+}
+
+var _ ClickHouseSyntaxDialect = &ClickHouseDialect{}
+
+// ReservedForAlias returns the keywords following a table in FROM clause.
+func (*ClickHouseDialect) ReservedForAlias() []string {
+	return []string{"FINAL", "SAMPLE", "ARRAY"}
+}
+
+var _ AliasReservedDialect = &ClickHouseDialect{}
@@ -1,5 +1,10 @@
 package dialect
 
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
 type Dialect interface {
 	IsIdentifierStart(r rune) bool
 	IsIdentifierPart(r rune) bool
@@ -10,11 +15,12 @@ type GenericSQLDialect struct {
 }
 
 func (*GenericSQLDialect) IsIdentifierStart(r rune) bool {
-	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '@'
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '@' || (r >= utf8.RuneSelf && unicode.IsLetter(r))
 }
 
 func (*GenericSQLDialect) IsIdentifierPart(r rune) bool {
-	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '@' || r == '_'
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '@' || r == '_' ||
+		(r >= utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)))
 }
 
 func (*GenericSQLDialect) IsDelimitedIdentifierStart(r rune) bool {
@@ -22,3 +28,153 @@ func (*GenericSQLDialect) IsDelimitedIdentifierStart(r rune) bool {
 }
 
 var _ Dialect = &GenericSQLDialect{}
+
+// UnicodeEscape reports that U&"..." identifiers and U&'...' strings are supported.
+func (*GenericSQLDialect) UnicodeEscape() bool {
+	return true
+}
+
+var _ UnicodeEscapeDialect = &GenericSQLDialect{}
+
+// NamedArgumentOperators reports that both `=>` and `:=` are accepted.
+func (*GenericSQLDialect) NamedArgumentOperators() []string {
+	return []string{"=>", ":="}
+}
+
+var _ NamedArgumentDialect = &GenericSQLDialect{}
+
+// OperatorDialect is implemented by dialects which recognize operators
+// in addition to the standard ones. They are tokenized as
+// sqltoken.CustomOperator. Every prefix of an operator longer than one
+// character must be an operator as well, since the tokenizer matches
+// operators one character at a time.
+type OperatorDialect interface {
+	Dialect
+	Operators() []string
+}
+
+// StringEscapeDialect is implemented by dialects which interpret backslash
+// escape sequences in ordinary string literals (e.g. MySQL).
+// E'...' strings are always interpreted regardless of the dialect.
+type StringEscapeDialect interface {
+	Dialect
+	BackslashEscape() bool
+}
+
+// HashCommentDialect is implemented by dialects in which `#` starts a comment
+// to the end of the line (e.g. MySQL).
+type HashCommentDialect interface {
+	Dialect
+	HashComment() bool
+}
+
+// UnicodeEscapeDialect is implemented by dialects which support
+// identifiers and strings with Unicode escapes, i.e: U&"d\0061t\+000061",
+// U&'d!0061t' UESCAPE '!'.
+type UnicodeEscapeDialect interface {
+	Dialect
+	UnicodeEscape() bool
+}
+
+// DistinctOnDialect is implemented by dialects which support
+// SELECT DISTINCT ON (exprs...) (e.g. PostgreSQL).
+type DistinctOnDialect interface {
+	Dialect
+	DistinctOn() bool
+}
+
+// NamedArgumentDialect is implemented by dialects which support named
+// arguments in function calls, i.e: make_interval(days => 3).
+// NamedArgumentOperators returns the accepted operators ("=>" and/or ":=").
+type NamedArgumentDialect interface {
+	Dialect
+	NamedArgumentOperators() []string
+}
+
+// MinusDialect is implemented by dialects which accept MINUS as a synonym
+// for EXCEPT (e.g. Oracle).
+type MinusDialect interface {
+	Dialect
+	Minus() bool
+}
+
+// OuterJoinOperatorDialect is implemented by dialects which support the
+// legacy outer join operator, i.e: WHERE a.id = b.a_id(+) (e.g. Oracle).
+type OuterJoinOperatorDialect interface {
+	Dialect
+	OuterJoinOperator() bool
+}
+
+// HiveQueryDialect is implemented by dialects which support the query
+// extensions of Hive, i.e: LATERAL VIEW, DISTRIBUTE BY, SORT BY and CLUSTER BY.
+type HiveQueryDialect interface {
+	Dialect
+	HiveQuery() bool
+}
+
+// TopDialect is implemented by dialects which limit the rows of SELECT
+// with TOP instead of LIMIT, i.e: SELECT TOP 10 * FROM t (e.g. SQL Server).
+type TopDialect interface {
+	Dialect
+	Top() bool
+}
+
+// KeywordDialect is implemented by dialects which reserve keywords in
+// addition to ReservedKeywords. IsReservedKeyword reports whether keyword
+// can't be used as an identifier unless quoted.
+type KeywordDialect interface {
+	Dialect
+	IsReservedKeyword(keyword string) bool
+}
+
+// AliasReservedDialect is implemented by dialects which reserve keywords in
+// addition to ReservedForTableAlias and ReservedForColumnAlias, so that they
+// are never taken as an alias without AS.
+type AliasReservedDialect interface {
+	Dialect
+	ReservedForAlias() []string
+}
+
+// ClickHouseSyntaxDialect is implemented by dialects which support the
+// syntax of ClickHouse, i.e: FINAL, SAMPLE and ARRAY JOIN in queries,
+// Nullable(T) and LowCardinality(T) types, and ENGINE = MergeTree()
+// followed by ORDER BY, PARTITION BY, PRIMARY KEY, SAMPLE BY and SETTINGS
+// in CREATE TABLE.
+type ClickHouseSyntaxDialect interface {
+	Dialect
+	ClickHouseSyntax() bool
+}
+
+// RedshiftSyntaxDialect is implemented by dialects which support the syntax
+// of Amazon Redshift, i.e: COPY options without parentheses such as
+// `IAM_ROLE 'arn' FORMAT AS CSV` and UNLOAD statement.
+type RedshiftSyntaxDialect interface {
+	Dialect
+	RedshiftSyntax() bool
+}
+
+// IndexHintDialect is implemented by dialects which support index hints
+// following a table in FROM clause, i.e: USE INDEX (i), FORCE INDEX (i) and
+// IGNORE INDEX (i) (e.g. MySQL).
+type IndexHintDialect interface {
+	Dialect
+	IndexHints() bool
+}
+
+// OptimizerHintDialect is implemented by dialects which take a comment
+// `/*+ ... */` right after SELECT, INSERT, UPDATE or DELETE as optimizer
+// hints (e.g. MySQL and Oracle).
+type OptimizerHintDialect interface {
+	Dialect
+	OptimizerHints() bool
+}
+
+// MySQLSyntaxDialect is implemented by dialects which support the DDL
+// syntax of MySQL, i.e: display widths of integer types such as int(11) and
+// tinyint(1), ZEROFILL, CHARACTER SET, ON UPDATE and COMMENT of columns, and
+// table options AUTO_INCREMENT, [DEFAULT] CHARACTER SET, [DEFAULT] COLLATE
+// and COMMENT.
+type MySQLSyntaxDialect interface {
+	Dialect
+	MySQLSyntax() bool
+}
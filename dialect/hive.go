@@ -0,0 +1,34 @@
+package dialect
+
+// HiveDialect is the dialect of Apache Hive and Spark SQL.
+// Identifiers can be quoted with backticks.
+type HiveDialect struct {
+}
+
+func (*HiveDialect) IsIdentifierStart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+}
+
+func (*HiveDialect) IsIdentifierPart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_'
+}
+
+func (*HiveDialect) IsDelimitedIdentifierStart(r rune) bool {
+	return r == '`'
+}
+
+var _ Dialect = &HiveDialect{}
+
+// HiveQuery reports that LATERAL VIEW, DISTRIBUTE BY, SORT BY and CLUSTER BY are supported.
+func (*HiveDialect) HiveQuery() bool {
+	return true
+}
+
+var _ HiveQueryDialect = &HiveDialect{}
+
+// ReservedForAlias returns the keywords starting the Hive specific clauses.
+func (*HiveDialect) ReservedForAlias() []string {
+	return []string{"LATERAL", "DISTRIBUTE", "SORT", "CLUSTER"}
+}
+
+var _ AliasReservedDialect = &HiveDialect{}
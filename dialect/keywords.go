@@ -4,6 +4,12 @@ var Keywords map[string]struct{}
 var ReservedForTableAlias map[string]struct{}
 var ReservedForColumnAlias map[string]struct{}
 
+// ReservedKeywords are the keywords which can't be used as an identifier
+// unless quoted. The other keywords (e.g. VALUE, YEAR and LEVEL) can be
+// column or table names. Dialects may reserve more by implementing
+// KeywordDialect.
+var ReservedKeywords map[string]struct{}
+
 func init() {
 	Keywords = make(map[string]struct{})
 	Keywords[ABS] = struct{}{}
@@ -30,6 +36,7 @@ func init() {
 	Keywords[BEGIN_PARTITION] = struct{}{}
 	Keywords[BETWEEN] = struct{}{}
 	Keywords[BIGINT] = struct{}{}
+	Keywords[BIGSERIAL] = struct{}{}
 	Keywords[BINARY] = struct{}{}
 	Keywords[BLOB] = struct{}{}
 	Keywords[BOOLEAN] = struct{}{}
@@ -147,6 +154,7 @@ func init() {
 	Keywords[HOLD] = struct{}{}
 	Keywords[HOUR] = struct{}{}
 	Keywords[IDENTITY] = struct{}{}
+	Keywords[ILIKE] = struct{}{}
 	Keywords[IN] = struct{}{}
 	Keywords[INDICATOR] = struct{}{}
 	Keywords[INNER] = struct{}{}
@@ -259,9 +267,11 @@ func init() {
 	Keywords[REGR_SXY] = struct{}{}
 	Keywords[REGR_SYY] = struct{}{}
 	Keywords[RELEASE] = struct{}{}
+	Keywords[RESET] = struct{}{}
 	Keywords[RESULT] = struct{}{}
 	Keywords[RETURN] = struct{}{}
 	Keywords[RETURNS] = struct{}{}
+	Keywords[RETURNING] = struct{}{}
 	Keywords[REVOKE] = struct{}{}
 	Keywords[RIGHT] = struct{}{}
 	Keywords[ROLLBACK] = struct{}{}
@@ -276,10 +286,13 @@ func init() {
 	Keywords[SECOND] = struct{}{}
 	Keywords[SELECT] = struct{}{}
 	Keywords[SENSITIVE] = struct{}{}
+	Keywords[SERIAL] = struct{}{}
 	Keywords[SESSION_USER] = struct{}{}
 	Keywords[SET] = struct{}{}
+	Keywords[SHOW] = struct{}{}
 	Keywords[SIMILAR] = struct{}{}
 	Keywords[SMALLINT] = struct{}{}
+	Keywords[SMALLSERIAL] = struct{}{}
 	Keywords[SOME] = struct{}{}
 	Keywords[SPECIFIC] = struct{}{}
 	Keywords[SPECIFICTYPE] = struct{}{}
@@ -327,6 +340,7 @@ func init() {
 	Keywords[UNION] = struct{}{}
 	Keywords[UNIQUE] = struct{}{}
 	Keywords[UNKNOWN] = struct{}{}
+	Keywords[UNLOAD] = struct{}{}
 	Keywords[UNNEST] = struct{}{}
 	Keywords[UPDATE] = struct{}{}
 	Keywords[UPPER] = struct{}{}
@@ -372,6 +386,13 @@ func init() {
 	ReservedForTableAlias[RIGHT] = struct{}{}
 	ReservedForTableAlias[NATURAL] = struct{}{}
 	ReservedForTableAlias[USING] = struct{}{}
+	ReservedForTableAlias[LIMIT] = struct{}{}
+	ReservedForTableAlias[OFFSET] = struct{}{}
+	ReservedForTableAlias[FETCH] = struct{}{}
+	ReservedForTableAlias[FOR] = struct{}{}
+	ReservedForTableAlias[RETURNING] = struct{}{}
+	ReservedForTableAlias[WINDOW] = struct{}{}
+	ReservedForTableAlias[TABLESAMPLE] = struct{}{}
 
 	ReservedForColumnAlias = make(map[string]struct{})
 	ReservedForColumnAlias[WITH] = struct{}{}
@@ -383,6 +404,56 @@ func init() {
 	ReservedForColumnAlias[EXCEPT] = struct{}{}
 	ReservedForColumnAlias[INTERSECT] = struct{}{}
 	ReservedForColumnAlias[FROM] = struct{}{}
+	ReservedForColumnAlias[LIMIT] = struct{}{}
+	ReservedForColumnAlias[OFFSET] = struct{}{}
+	ReservedForColumnAlias[FETCH] = struct{}{}
+	ReservedForColumnAlias[FOR] = struct{}{}
+	ReservedForColumnAlias[WINDOW] = struct{}{}
+
+	ReservedKeywords = make(map[string]struct{})
+	ReservedKeywords[ALL] = struct{}{}
+	ReservedKeywords[AND] = struct{}{}
+	ReservedKeywords[AS] = struct{}{}
+	ReservedKeywords[CASE] = struct{}{}
+	ReservedKeywords[CHECK] = struct{}{}
+	ReservedKeywords[CONSTRAINT] = struct{}{}
+	ReservedKeywords[CREATE] = struct{}{}
+	ReservedKeywords[DISTINCT] = struct{}{}
+	ReservedKeywords[ELSE] = struct{}{}
+	ReservedKeywords[END] = struct{}{}
+	ReservedKeywords[EXCEPT] = struct{}{}
+	ReservedKeywords[FALSE] = struct{}{}
+	ReservedKeywords[FETCH] = struct{}{}
+	ReservedKeywords[FOR] = struct{}{}
+	ReservedKeywords[FOREIGN] = struct{}{}
+	ReservedKeywords[FROM] = struct{}{}
+	ReservedKeywords[GROUP] = struct{}{}
+	ReservedKeywords[HAVING] = struct{}{}
+	ReservedKeywords[IN] = struct{}{}
+	ReservedKeywords[INTERSECT] = struct{}{}
+	ReservedKeywords[INTO] = struct{}{}
+	ReservedKeywords[IS] = struct{}{}
+	ReservedKeywords[JOIN] = struct{}{}
+	ReservedKeywords[LIMIT] = struct{}{}
+	ReservedKeywords[NOT] = struct{}{}
+	ReservedKeywords[NULL] = struct{}{}
+	ReservedKeywords[OFFSET] = struct{}{}
+	ReservedKeywords[ON] = struct{}{}
+	ReservedKeywords[OR] = struct{}{}
+	ReservedKeywords[ORDER] = struct{}{}
+	ReservedKeywords[PRIMARY] = struct{}{}
+	ReservedKeywords[REFERENCES] = struct{}{}
+	ReservedKeywords[SELECT] = struct{}{}
+	ReservedKeywords[TABLE] = struct{}{}
+	ReservedKeywords[THEN] = struct{}{}
+	ReservedKeywords[TO] = struct{}{}
+	ReservedKeywords[TRUE] = struct{}{}
+	ReservedKeywords[UNION] = struct{}{}
+	ReservedKeywords[UNIQUE] = struct{}{}
+	ReservedKeywords[USING] = struct{}{}
+	ReservedKeywords[WHEN] = struct{}{}
+	ReservedKeywords[WHERE] = struct{}{}
+	ReservedKeywords[WITH] = struct{}{}
 }
 
 const (
@@ -410,6 +481,7 @@ const (
 	BEGIN_PARTITION                         = "BEGIN_PARTITION"
 	BETWEEN                                 = "BETWEEN"
 	BIGINT                                  = "BIGINT"
+	BIGSERIAL                               = "BIGSERIAL"
 	BINARY                                  = "BINARY"
 	BLOB                                    = "BLOB"
 	BOOLEAN                                 = "BOOLEAN"
@@ -527,6 +599,7 @@ const (
 	HOLD                                    = "HOLD"
 	HOUR                                    = "HOUR"
 	IDENTITY                                = "IDENTITY"
+	ILIKE                                   = "ILIKE"
 	IN                                      = "IN"
 	INDICATOR                               = "INDICATOR"
 	INNER                                   = "INNER"
@@ -639,9 +712,11 @@ const (
 	REGR_SXY                                = "REGR_SXY"
 	REGR_SYY                                = "REGR_SYY"
 	RELEASE                                 = "RELEASE"
+	RESET                                   = "RESET"
 	RESULT                                  = "RESULT"
 	RETURN                                  = "RETURN"
 	RETURNS                                 = "RETURNS"
+	RETURNING                               = "RETURNING"
 	REVOKE                                  = "REVOKE"
 	RIGHT                                   = "RIGHT"
 	ROLLBACK                                = "ROLLBACK"
@@ -656,10 +731,13 @@ const (
 	SECOND                                  = "SECOND"
 	SELECT                                  = "SELECT"
 	SENSITIVE                               = "SENSITIVE"
+	SERIAL                                  = "SERIAL"
 	SESSION_USER                            = "SESSION_USER"
 	SET                                     = "SET"
+	SHOW                                    = "SHOW"
 	SIMILAR                                 = "SIMILAR"
 	SMALLINT                                = "SMALLINT"
+	SMALLSERIAL                             = "SMALLSERIAL"
 	SOME                                    = "SOME"
 	SPECIFIC                                = "SPECIFIC"
 	SPECIFICTYPE                            = "SPECIFICTYPE"
@@ -707,6 +785,7 @@ const (
 	UNION                                   = "UNION"
 	UNIQUE                                  = "UNIQUE"
 	UNKNOWN                                 = "UNKNOWN"
+	UNLOAD                                  = "UNLOAD"
 	UNNEST                                  = "UNNEST"
 	UPDATE                                  = "UPDATE"
 	UPPER                                   = "UPPER"
@@ -1,5 +1,7 @@
 package dialect
 
+import "unicode/utf8"
+
 type MySQLDialect struct {
 	GenericSQLDialect
 }
@@ -8,4 +10,82 @@ func (*MySQLDialect) IsDelimitedIdentifierStart(r rune) bool {
 	return r == '"' || r == '`'
 }
 
+// IsIdentifierStart reports whether r can start an identifier.
+// MySQL allows U+0080 .. U+FFFF in unquoted identifiers.
+func (d *MySQLDialect) IsIdentifierStart(r rune) bool {
+	return d.GenericSQLDialect.IsIdentifierStart(r) || (r >= utf8.RuneSelf && r <= 0xFFFF)
+}
+
+func (d *MySQLDialect) IsIdentifierPart(r rune) bool {
+	return d.GenericSQLDialect.IsIdentifierPart(r) || (r >= utf8.RuneSelf && r <= 0xFFFF)
+}
+
+// UnicodeEscape reports that neither U&"..." nor U&'...' is a literal in MySQL.
+func (*MySQLDialect) UnicodeEscape() bool {
+	return false
+}
+
 var _ Dialect = &MySQLDialect{}
+
+// Operators returns MySQL specific operators.
+func (*MySQLDialect) Operators() []string {
+	return []string{"<=>", "->", "->>"} // NULL-safe equal and JSON column path
+}
+
+var _ OperatorDialect = &MySQLDialect{}
+
+// BackslashEscape reports that MySQL interprets backslash escapes in string literals.
+func (*MySQLDialect) BackslashEscape() bool {
+	return true
+}
+
+var _ StringEscapeDialect = &MySQLDialect{}
+
+// HashComment reports that `#` starts a comment in MySQL.
+func (*MySQLDialect) HashComment() bool {
+	return true
+}
+
+var _ HashCommentDialect = &MySQLDialect{}
+
+// IsReservedKeyword reports whether keyword is reserved in MySQL.
+func (*MySQLDialect) IsReservedKeyword(keyword string) bool {
+	if _, ok := ReservedKeywords[keyword]; ok {
+		return true
+	}
+	switch keyword {
+	case "DUAL", "INDEX", KEY:
+		return true
+	}
+	return false
+}
+
+var _ KeywordDialect = &MySQLDialect{}
+
+// IndexHints reports that USE, FORCE and IGNORE INDEX are supported.
+func (*MySQLDialect) IndexHints() bool {
+	return true
+}
+
+var _ IndexHintDialect = &MySQLDialect{}
+
+// ReservedForAlias returns the keywords starting index hints.
+func (*MySQLDialect) ReservedForAlias() []string {
+	return []string{"USE", "FORCE", "IGNORE"}
+}
+
+var _ AliasReservedDialect = &MySQLDialect{}
+
+// OptimizerHints reports that `/*+ ... */` comments are optimizer hints.
+func (*MySQLDialect) OptimizerHints() bool {
+	return true
+}
+
+var _ OptimizerHintDialect = &MySQLDialect{}
+
+// MySQLSyntax reports that the DDL syntax of MySQL is supported.
+func (*MySQLDialect) MySQLSyntax() bool {
+	return true
+}
+
+var _ MySQLSyntaxDialect = &MySQLDialect{}
@@ -0,0 +1,73 @@
+package dialect
+
+import "unicode/utf8"
+
+// OracleDialect is the dialect of Oracle Database.
+// `SELECT ... FROM dual` and ROWNUM need nothing special; they are parsed as
+// an ordinary table and identifier.
+type OracleDialect struct {
+}
+
+func (*OracleDialect) IsIdentifierStart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r >= utf8.RuneSelf
+}
+
+func (*OracleDialect) IsIdentifierPart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '$' || r == '#' || r >= utf8.RuneSelf
+}
+
+func (*OracleDialect) IsDelimitedIdentifierStart(r rune) bool {
+	return r == '"'
+}
+
+var _ Dialect = &OracleDialect{}
+
+// NamedArgumentOperators reports that `=>` is accepted.
+func (*OracleDialect) NamedArgumentOperators() []string {
+	return []string{"=>"}
+}
+
+var _ NamedArgumentDialect = &OracleDialect{}
+
+// Minus reports that MINUS is a synonym for EXCEPT.
+func (*OracleDialect) Minus() bool {
+	return true
+}
+
+var _ MinusDialect = &OracleDialect{}
+
+// OuterJoinOperator reports that the legacy `(+)` outer join operator is supported.
+func (*OracleDialect) OuterJoinOperator() bool {
+	return true
+}
+
+var _ OuterJoinOperatorDialect = &OracleDialect{}
+
+// ReservedForAlias reports that MINUS is never an alias.
+func (*OracleDialect) ReservedForAlias() []string {
+	return []string{"MINUS"}
+}
+
+var _ AliasReservedDialect = &OracleDialect{}
+
+// IsReservedKeyword reports whether keyword is reserved in Oracle,
+// where pseudo columns such as LEVEL and ROWNUM are reserved.
+func (*OracleDialect) IsReservedKeyword(keyword string) bool {
+	if _, ok := ReservedKeywords[keyword]; ok {
+		return true
+	}
+	switch keyword {
+	case CONNECT, "LEVEL", "MINUS", "ROWNUM":
+		return true
+	}
+	return false
+}
+
+var _ KeywordDialect = &OracleDialect{}
+
+// OptimizerHints reports that `/*+ ... */` comments are optimizer hints.
+func (*OracleDialect) OptimizerHints() bool {
+	return true
+}
+
+var _ OptimizerHintDialect = &OracleDialect{}
@@ -1,14 +1,18 @@
 package dialect
 
+import "unicode/utf8"
+
 type PostgresqlDialect struct {
 }
 
+// IsIdentifierStart reports whether r can start an identifier.
+// Like PostgreSQL, any non-ASCII character is allowed.
 func (*PostgresqlDialect) IsIdentifierStart(r rune) bool {
-	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || r >= utf8.RuneSelf
 }
 
 func (*PostgresqlDialect) IsIdentifierPart(r rune) bool {
-	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '$' || r == '_'
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '$' || r == '_' || r >= utf8.RuneSelf
 }
 
 func (*PostgresqlDialect) IsDelimitedIdentifierStart(r rune) bool {
@@ -16,3 +20,50 @@ func (*PostgresqlDialect) IsDelimitedIdentifierStart(r rune) bool {
 }
 
 var _ Dialect = &PostgresqlDialect{}
+
+// UnicodeEscape reports that U&"..." identifiers and U&'...' strings are supported.
+func (*PostgresqlDialect) UnicodeEscape() bool {
+	return true
+}
+
+var _ UnicodeEscapeDialect = &PostgresqlDialect{}
+
+// DistinctOn reports that SELECT DISTINCT ON (...) is supported.
+func (*PostgresqlDialect) DistinctOn() bool {
+	return true
+}
+
+var _ DistinctOnDialect = &PostgresqlDialect{}
+
+// NamedArgumentOperators reports that `=>` and the older `:=` are accepted.
+func (*PostgresqlDialect) NamedArgumentOperators() []string {
+	return []string{"=>", ":="}
+}
+
+var _ NamedArgumentDialect = &PostgresqlDialect{}
+
+// Operators returns the operators of PostgreSQL, which are the bitwise
+// XOR and the JSON operators.
+func (*PostgresqlDialect) Operators() []string {
+	return postgresOperators
+}
+
+var _ OperatorDialect = &PostgresqlDialect{}
+
+var postgresOperators = []string{"#", "->", "->>", "#>", "#>>", "@>", "<@", "?|", "?&"}
+
+// IsReservedKeyword reports whether keyword is reserved in PostgreSQL.
+func (*PostgresqlDialect) IsReservedKeyword(keyword string) bool {
+	if _, ok := ReservedKeywords[keyword]; ok {
+		return true
+	}
+	_, ok := postgresReservedKeywords[keyword]
+	return ok
+}
+
+var _ KeywordDialect = &PostgresqlDialect{}
+
+var postgresReservedKeywords = map[string]struct{}{
+	"ANALYSE": {}, "ANALYZE": {}, ASC: {}, BOTH: {}, COLLATE: {}, COLUMN: {}, DEFAULT: {}, DESC: {},
+	"DO": {}, GRANT: {}, LATERAL: {}, LEADING: {}, ONLY: {}, RETURNING: {}, SOME: {}, TRAILING: {}, WINDOW: {},
+}
@@ -0,0 +1,20 @@
+package dialect
+
+// RedshiftDialect is the dialect of Amazon Redshift, which is based on PostgreSQL.
+type RedshiftDialect struct {
+	PostgresqlDialect
+}
+
+var _ Dialect = &RedshiftDialect{}
+
+// DistinctOn reports that SELECT DISTINCT ON (...) is not supported.
+func (*RedshiftDialect) DistinctOn() bool {
+	return false
+}
+
+// RedshiftSyntax reports that Redshift style COPY options and UNLOAD are supported.
+func (*RedshiftDialect) RedshiftSyntax() bool {
+	return true
+}
+
+var _ RedshiftSyntaxDialect = &RedshiftDialect{}
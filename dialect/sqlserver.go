@@ -0,0 +1,29 @@
+package dialect
+
+import "unicode/utf8"
+
+// SQLServerDialect is the dialect of Microsoft SQL Server.
+// Identifiers can be quoted with double quotes or brackets.
+type SQLServerDialect struct {
+}
+
+func (*SQLServerDialect) IsIdentifierStart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || r == '@' || r == '#' || r >= utf8.RuneSelf
+}
+
+func (*SQLServerDialect) IsIdentifierPart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '@' || r == '#' || r == '$' || r >= utf8.RuneSelf
+}
+
+func (*SQLServerDialect) IsDelimitedIdentifierStart(r rune) bool {
+	return r == '"' || r == '['
+}
+
+var _ Dialect = &SQLServerDialect{}
+
+// Top reports that SELECT TOP n is supported.
+func (*SQLServerDialect) Top() bool {
+	return true
+}
+
+var _ TopDialect = &SQLServerDialect{}
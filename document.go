@@ -0,0 +1,298 @@
+package xsqlparser
+
+import (
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// Document is a source parsed incrementally for editors and language servers.
+// Edit re-tokenizes and re-parses only the statements overlapping the edited
+// range, and moves the positions of the following statements.
+type Document struct {
+	src    string
+	config *Parser // options applied, used to fork parsers
+	stmts  []*DocumentStmt
+}
+
+// DocumentStmt is a statement of a Document with the whitespace and comments
+// before it and the semicolon terminating it. The statements of a Document
+// cover the whole source.
+type DocumentStmt struct {
+	Offset, End int               // byte range in the source
+	From, To    sqltoken.Pos      // positions of Offset and End
+	Tokens      []*sqltoken.Token // nil if tokenizing failed
+	Stmt        sqlast.Stmt       // nil if empty or failed
+	Err         error             // error tokenizing or parsing the statement
+}
+
+// NewDocument parses src into a Document. Errors are reported in the
+// statements of the Document.
+func NewDocument(src string, dialect dialect.Dialect, opts ...ParserOption) *Document {
+	config := NewParserWithOptions(opts...)
+	config.dialect = dialect
+	d := &Document{config: config}
+	_ = d.Edit(0, 0, src)
+	return d
+}
+
+// Source returns the current source of d.
+func (d *Document) Source() string {
+	return d.src
+}
+
+// Stmts returns the statements of d in the source order. Statements not
+// affected by an edit remain the same in Stmts after it.
+func (d *Document) Stmts() []*DocumentStmt {
+	return d.stmts
+}
+
+// Edit replaces the source between the byte offsets from and to with text.
+//
+// Only the statements overlapping the range are parsed again, and more
+// following ones while the last parsed statement isn't terminated by a
+// semicolon. The positions of the rest of the statements are moved in place,
+// unless the edit adds or removes CREATE TYPE which requires them to be parsed again.
+func (d *Document) Edit(from, to int, text string) error {
+	if from < 0 || from > to || to > len(d.src) {
+		return errors.Errorf("edit range %d-%d out of source of %d bytes", from, to, len(d.src))
+	}
+	src := d.src[:from] + text + d.src[to:]
+	delta := len(text) - (to - from)
+
+	i := sort.Search(len(d.stmts), func(k int) bool { return d.stmts[k].End >= from })
+	j := sort.Search(len(d.stmts), func(k int) bool { return d.stmts[k].Offset >= to })
+	if j <= i && i < len(d.stmts) {
+		j = i + 1
+	}
+	offset, pos := 0, sqltoken.NewPos(1, 1)
+	if i < len(d.stmts) {
+		offset, pos = d.stmts[i].Offset, d.stmts[i].From
+	}
+
+	types := d.typesBefore(i)
+	var stmts []*DocumentStmt
+	var end sqltoken.Pos
+	for {
+		last := len(src)
+		if j < len(d.stmts) {
+			last = d.stmts[j].Offset + delta
+		}
+		var closed bool
+		stmts, end, closed = d.parse(src[offset:last], offset, pos, types)
+		if closed || j == len(d.stmts) {
+			break
+		}
+		j += j - i
+		if j > len(d.stmts) {
+			j = len(d.stmts)
+		}
+	}
+
+	rest := d.stmts[j:]
+	if len(rest) != 0 {
+		shiftStmts(rest, delta, d.stmts[j-1].To, end)
+		if definesTypes(d.stmts[i:j]) || definesTypes(stmts) {
+			types := d.typesBefore(i)
+			for _, s := range stmts {
+				addType(types, s.Stmt)
+			}
+			for _, s := range rest {
+				d.parseStmt(s, types)
+			}
+		}
+	}
+
+	d.src = src
+	d.stmts = append(append(d.stmts[:i:i], stmts...), rest...)
+	return nil
+}
+
+// parse parses src, which starts at offset and pos of the source.
+// It returns the statements, the position of the end of src and whether the
+// last statement is terminated by a semicolon.
+func (d *Document) parse(src string, offset int, pos sqltoken.Pos, types map[string]*sqlast.CreateTypeStmt) ([]*DocumentStmt, sqltoken.Pos, bool) {
+	tokenizer := sqltoken.NewTokenizer(strings.NewReader(src), d.config.dialect)
+	tokenizer.Line, tokenizer.Col = pos.Line, pos.Col
+
+	var tokens []*sqltoken.Token
+	var err error
+	for {
+		tok, e := tokenizer.NextToken()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			err = e
+			break
+		}
+		tok.Offset += offset
+		tokens = append(tokens, tok)
+	}
+
+	units, closed := d.split(tokens)
+	var stmts []*DocumentStmt
+	for _, u := range units {
+		s := &DocumentStmt{
+			Offset: u[0].Offset,
+			End:    u[len(u)-1].EndOffset(),
+			From:   u[0].From,
+			To:     u[len(u)-1].To,
+			Tokens: u,
+		}
+		d.parseStmt(s, types)
+		stmts = append(stmts, s)
+	}
+
+	if err != nil {
+		// the rest of src from the failed statement is left unparsed
+		if n := len(stmts); n != 0 && !closed {
+			stmts = stmts[:n-1]
+		}
+		s := &DocumentStmt{Offset: offset, End: offset + len(src), From: pos, To: tokenizer.Pos(), Err: err}
+		if n := len(stmts); n != 0 {
+			s.Offset, s.From = stmts[n-1].End, stmts[n-1].To
+		}
+		return append(stmts, s), s.To, false
+	}
+	return stmts, tokenizer.Pos(), closed
+}
+
+// split splits tokens into statements. It returns whether the last one is
+// terminated by a top-level semicolon, i.e: following tokens don't affect it.
+func (d *Document) split(tokens []*sqltoken.Token) ([][]*sqltoken.Token, bool) {
+	p := d.config.fork(tokens, 0, nil)
+	var units [][]*sqltoken.Token
+	closed := true
+	for from := 0; from < len(tokens); {
+		_, to, ordered := p.scanStatement(from)
+		closed = to < len(tokens)
+		if closed {
+			to++
+		}
+		if ordered {
+			// find the end of the inline data of COPY
+			sub := p.fork(tokens, from, nil)
+			if stmts, err := sub.parseSQL(1); err == nil && len(stmts) == 1 {
+				to, closed = int(sub.index), false
+				if idx, err := sub.tilNonWhitespace(); err == nil && tokens[idx].Kind == sqltoken.Semicolon {
+					to, closed = int(idx)+1, true
+				}
+			}
+		}
+		units = append(units, tokens[from:to])
+		from = to
+	}
+	return units, closed
+}
+
+func (d *Document) parseStmt(s *DocumentStmt, types map[string]*sqlast.CreateTypeStmt) {
+	if s.Tokens == nil {
+		// failed to tokenize
+		return
+	}
+	s.Stmt, s.Err = nil, nil
+	stmts, err := d.config.fork(s.Tokens, 0, types).parseSQL(0)
+	if err != nil {
+		s.Err = err
+		return
+	}
+	if len(stmts) != 0 {
+		s.Stmt = stmts[0]
+		addType(types, s.Stmt)
+	}
+}
+
+// typesBefore returns the types defined by the statements before d.stmts[i].
+func (d *Document) typesBefore(i int) map[string]*sqlast.CreateTypeStmt {
+	types := make(map[string]*sqlast.CreateTypeStmt)
+	for _, s := range d.stmts[:i] {
+		addType(types, s.Stmt)
+	}
+	return types
+}
+
+func addType(types map[string]*sqlast.CreateTypeStmt, stmt sqlast.Stmt) {
+	if c, ok := stmt.(*sqlast.CreateTypeStmt); ok {
+		types[strings.ToLower(c.Name.ToSQLString())] = c
+	}
+}
+
+func definesTypes(stmts []*DocumentStmt) bool {
+	for _, s := range stmts {
+		if _, ok := s.Stmt.(*sqlast.CreateTypeStmt); ok {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	posType    = reflect.TypeOf(sqltoken.Pos{})
+	customType = reflect.TypeOf(sqlast.Custom{})
+)
+
+// shiftStmts moves stmts by delta bytes, and the positions after oldEnd to
+// after newEnd.
+func shiftStmts(stmts []*DocumentStmt, delta int, oldEnd, newEnd sqltoken.Pos) {
+	if delta == 0 && oldEnd == newEnd {
+		return
+	}
+	shift := func(pos *sqltoken.Pos) {
+		if pos.Line == 0 {
+			// no position
+			return
+		}
+		if pos.Line == oldEnd.Line {
+			pos.Col += newEnd.Col - oldEnd.Col
+		}
+		pos.Line += newEnd.Line - oldEnd.Line
+	}
+	for _, s := range stmts {
+		s.Offset += delta
+		s.End += delta
+		shift(&s.From)
+		shift(&s.To)
+		for _, tok := range s.Tokens {
+			tok.Offset += delta
+			shift(&tok.From)
+			shift(&tok.To)
+		}
+		if s.Stmt != nil && oldEnd != newEnd {
+			shiftValue(reflect.ValueOf(s.Stmt), shift)
+		}
+	}
+}
+
+// shiftValue calls shift for every position in the node v.
+func shiftValue(v reflect.Value, shift func(*sqltoken.Pos)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			shiftValue(v.Elem(), shift)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			shiftValue(v.Index(i), shift)
+		}
+	case reflect.Struct:
+		if v.Type() == posType {
+			shift(v.Addr().Interface().(*sqltoken.Pos))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" || (v.Type() == customType && f.Name == "Definition") {
+				continue
+			}
+			shiftValue(v.Field(i), shift)
+		}
+	}
+}
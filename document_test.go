@@ -0,0 +1,106 @@
+package xsqlparser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestDocument_Edit(t *testing.T) {
+	src := `CREATE TABLE a (s status, n int);
+SELECT * FROM a WHERE n > 1; SELECT 2;
+-- copy
+COPY a (s, n) FROM stdin;
+on	1
+\.
+INSERT INTO a VALUES ('off', 2);
+`
+	type edit struct {
+		name     string
+		old, new string // replaces the first old with new
+	}
+	edits := []edit{
+		{name: "insert in a line", old: "n > 1", new: "n > 10"},
+		{name: "insert a line", old: "SELECT 2;", new: "SELECT 2;\n\nSELECT 3;"},
+		{name: "remove a semicolon", old: "n > 10;", new: "n > 10"},
+		{name: "restore the semicolon", old: "n > 10", new: "n > 10;"},
+		{name: "unclosed quote", old: "SELECT 3", new: "SELECT 'x"},
+		{name: "close the quote", old: "'x", new: "'x'"},
+		{name: "copy data", old: "on\t1\n", new: "on\t1\noff\t2;3\n"},
+		{name: "define type", old: "-- copy", new: "CREATE TYPE status AS ENUM ('on', 'off');"},
+		{name: "remove a line", old: "\n\n", new: "\n"},
+		{name: "error", old: "FROM a WHERE", new: "FROM WHERE"},
+		{name: "append", old: "2);\n", new: "2);\nDELETE FROM a"},
+		{name: "prepend", old: "CREATE TABLE", new: "SELECT 1;\nCREATE TABLE"},
+	}
+
+	doc := NewDocument(src, &dialect.PostgresqlDialect{})
+	for _, e := range edits {
+		t.Run(e.name, func(t *testing.T) {
+			from := strings.Index(doc.Source(), e.old)
+			if from < 0 {
+				t.Fatalf("%q is not found in %q", e.old, doc.Source())
+			}
+			if err := doc.Edit(from, from+len(e.old), e.new); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			expect := NewDocument(doc.Source(), &dialect.PostgresqlDialect{}).Stmts()
+			actual := doc.Stmts()
+			if len(expect) != len(actual) {
+				t.Fatalf("must be %d statements but %d", len(expect), len(actual))
+			}
+			for i := range expect {
+				e, a := expect[i], actual[i]
+				if e.Offset != a.Offset || e.End != a.End || e.From != a.From || e.To != a.To {
+					t.Errorf("range of %d: %d-%d %v-%v must be %d-%d %v-%v", i, a.Offset, a.End, a.From, a.To, e.Offset, e.End, e.From, e.To)
+				}
+				if (e.Err == nil) != (a.Err == nil) {
+					t.Errorf("error of %d: %v must be %v", i, a.Err, e.Err)
+				}
+				if diff := CompareWithoutMarker(e.Tokens, a.Tokens); diff != "" {
+					t.Errorf("tokens of %d: %s", i, diff)
+				}
+				if diff := CompareWithoutMarker(e.Stmt, a.Stmt); diff != "" {
+					t.Errorf("stmt of %d: %s", i, diff)
+				}
+			}
+		})
+	}
+
+	t.Run("reuse", func(t *testing.T) {
+		doc := NewDocument("SELECT 1;\nSELECT 2;\nSELECT 3;", &dialect.GenericSQLDialect{})
+		before := doc.Stmts()
+		last := before[2].Stmt
+		if err := doc.Edit(7, 8, "10"); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		after := doc.Stmts()
+		if after[1] != before[1] || after[2].Stmt != last {
+			t.Error("statements after the edit must be reused")
+		}
+		if sql := after[0].Stmt.ToSQLString(); sql != "SELECT 10" {
+			t.Errorf("must be SELECT 10 but %s", sql)
+		}
+	})
+
+	t.Run("type", func(t *testing.T) {
+		doc := NewDocument("CREATE TABLE a (s status);\nCREATE TABLE b (s status);", &dialect.PostgresqlDialect{})
+		if err := doc.Edit(27, 27, "CREATE TYPE status AS ENUM ('on');\n"); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmts := doc.Stmts()
+		def := stmts[2].Stmt.(*sqlast.CreateTableStmt).Elements[0].(*sqlast.ColumnDef).DataType.(*sqlast.Custom).Definition
+		if def != stmts[1].Stmt {
+			t.Errorf("type must be resolved but %v", def)
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		doc := NewDocument("SELECT 1", &dialect.GenericSQLDialect{})
+		if err := doc.Edit(3, 10, ""); err == nil {
+			t.Error("must be error")
+		}
+	})
+}
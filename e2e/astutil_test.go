@@ -46,6 +46,54 @@ func TestInspect(t *testing.T) {
 			name: "INSERT",
 			dir:  "insert",
 		},
+		{
+			name: "COPY",
+			dir:  "copy",
+		},
+		{
+			name: "DELETE",
+			dir:  "delete",
+		},
+		{
+			name: "CREATE FUNCTION",
+			dir:  "create_function",
+		},
+		{
+			name: "SEQUENCE",
+			dir:  "sequence",
+		},
+		{
+			name: "CREATE SCHEMA",
+			dir:  "create_schema",
+		},
+		{
+			name: "CREATE TYPE",
+			dir:  "create_type",
+		},
+		{
+			name: "TRUNCATE",
+			dir:  "truncate",
+		},
+		{
+			name: "session",
+			dir:  "session",
+		},
+		{
+			name: "merge",
+			dir:  "merge",
+		},
+		{
+			name: "prepare",
+			dir:  "prepare",
+		},
+		{
+			name: "cursor",
+			dir:  "cursor",
+		},
+		{
+			name: "ALTER OBJECT",
+			dir:  "alter_object",
+		},
 	}
 
 	for _, c := range cases {
@@ -118,6 +166,54 @@ func TestApply(t *testing.T) {
 			name: "INSERT",
 			dir:  "insert",
 		},
+		{
+			name: "COPY",
+			dir:  "copy",
+		},
+		{
+			name: "DELETE",
+			dir:  "delete",
+		},
+		{
+			name: "CREATE FUNCTION",
+			dir:  "create_function",
+		},
+		{
+			name: "SEQUENCE",
+			dir:  "sequence",
+		},
+		{
+			name: "CREATE SCHEMA",
+			dir:  "create_schema",
+		},
+		{
+			name: "CREATE TYPE",
+			dir:  "create_type",
+		},
+		{
+			name: "TRUNCATE",
+			dir:  "truncate",
+		},
+		{
+			name: "session",
+			dir:  "session",
+		},
+		{
+			name: "merge",
+			dir:  "merge",
+		},
+		{
+			name: "prepare",
+			dir:  "prepare",
+		},
+		{
+			name: "cursor",
+			dir:  "cursor",
+		},
+		{
+			name: "ALTER OBJECT",
+			dir:  "alter_object",
+		},
 	}
 
 	for _, c := range cases {
@@ -147,10 +243,18 @@ func TestApply(t *testing.T) {
 					if err != nil {
 						t.Fatalf("%+v", err)
 					}
-					sqlastutil.Apply(stmt, func(c *sqlastutil.Cursor) bool {
-						// fmt.Printf("%T\n", node)
+					expect := stmt.ToSQLString()
+					res := sqlastutil.Apply(stmt, func(c *sqlastutil.Cursor) bool {
+						// replacing every node with itself checks that each
+						// field name handed to the cursor really exists.
+						if n := c.Node(); n != nil {
+							c.Replace(n)
+						}
 						return true
 					}, nil)
+					if got := res.ToSQLString(); got != expect {
+						t.Errorf("should be \n %s but \n %s", expect, got)
+					}
 				})
 			}
 		})
@@ -0,0 +1,79 @@
+package e2e_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestClone(t *testing.T) {
+	dirs, err := ioutil.ReadDir("testdata")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		t.Run(d.Name(), func(t *testing.T) {
+			dir := fmt.Sprintf("testdata/%s/", d.Name())
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			for _, f := range files {
+				if !strings.HasSuffix(f.Name(), ".sql") {
+					continue
+				}
+				t.Run(f.Name(), func(t *testing.T) {
+					fi, err := os.Open(dir + f.Name())
+					if err != nil {
+						t.Fatalf("%+v", err)
+					}
+					defer fi.Close()
+					parser, err := xsqlparser.NewParser(fi, &dialect.GenericSQLDialect{})
+					if err != nil {
+						t.Fatalf("%+v", err)
+					}
+					orig, err := parser.ParseStatement()
+					if err != nil {
+						t.Fatalf("%+v", err)
+					}
+
+					cloned := sqlast.Clone(orig)
+
+					if diff := cmp.Diff(orig, cloned, xsqlparser.IgnoreMarker); diff != "" {
+						t.Errorf("should be same ast but diff:\n %s", diff)
+					}
+					if !sqlast.Equal(orig, cloned) {
+						t.Errorf("should be equal but diff: %v", sqlast.Diff(orig, cloned))
+					}
+					nodes := make(map[sqlast.Node]bool)
+					sqlast.Inspect(orig, func(node sqlast.Node) bool {
+						if reflect.ValueOf(node).Kind() == reflect.Ptr {
+							nodes[node] = true
+						}
+						return true
+					})
+					sqlast.Inspect(cloned, func(node sqlast.Node) bool {
+						if nodes[node] {
+							t.Errorf("%T %s is shared", node, node.ToSQLString())
+						}
+						return true
+					})
+				})
+			}
+		})
+	}
+}
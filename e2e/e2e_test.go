@@ -48,6 +48,54 @@ func TestParseQuery(t *testing.T) {
 			name: "INSERT",
 			dir:  "insert",
 		},
+		{
+			name: "COPY",
+			dir:  "copy",
+		},
+		{
+			name: "DELETE",
+			dir:  "delete",
+		},
+		{
+			name: "CREATE FUNCTION",
+			dir:  "create_function",
+		},
+		{
+			name: "SEQUENCE",
+			dir:  "sequence",
+		},
+		{
+			name: "CREATE SCHEMA",
+			dir:  "create_schema",
+		},
+		{
+			name: "CREATE TYPE",
+			dir:  "create_type",
+		},
+		{
+			name: "TRUNCATE",
+			dir:  "truncate",
+		},
+		{
+			name: "session",
+			dir:  "session",
+		},
+		{
+			name: "merge",
+			dir:  "merge",
+		},
+		{
+			name: "prepare",
+			dir:  "prepare",
+		},
+		{
+			name: "cursor",
+			dir:  "cursor",
+		},
+		{
+			name: "ALTER OBJECT",
+			dir:  "alter_object",
+		},
 	}
 
 	for _, c := range cases {
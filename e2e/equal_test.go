@@ -0,0 +1,85 @@
+package e2e_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestEqualAndDiff(t *testing.T) {
+	cases := []struct {
+		name  string
+		a, b  string
+		diffs []string
+	}{
+		{
+			name: "whitespace, case of keywords and quote style",
+			a:    "SELECT a, \"B\" FROM t WHERE c = 1",
+			b:    "select  a,\n  `B`\nfrom t\nwhere c = 1",
+		},
+		{
+			name:  "different value",
+			a:     "SELECT a FROM t WHERE c = 1",
+			b:     "SELECT a FROM t WHERE c = 2",
+			diffs: []string{"Body.WhereClause.Right: 1 => 2"},
+		},
+		{
+			name: "different nodes",
+			a:    "SELECT a, b FROM t WHERE c = 1 ORDER BY a",
+			b:    "SELECT a, x.b FROM t WHERE c IS NULL",
+			diffs: []string{
+				"Body.Projection[1].Node: b => x.b",
+				"Body.WhereClause: c = 1 => c IS NULL",
+				"OrderBy[0]: a => <nil>",
+			},
+		},
+		{
+			name:  "missing subtree",
+			a:     "SELECT a FROM t",
+			b:     "SELECT a FROM t WHERE c",
+			diffs: []string{"Body.WhereClause: <nil> => c"},
+		},
+		{
+			name:  "different list",
+			a:     "SELECT a FROM t WHERE c IN (1, 2)",
+			b:     "SELECT a FROM t WHERE c IN (1, 2, 3)",
+			diffs: []string{"Body.WhereClause.List[2]: <nil> => 3"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := parseStmt(t, c.a)
+			b := parseStmt(t, c.b)
+
+			if eq := sqlast.Equal(a, b); eq != (len(c.diffs) == 0) {
+				t.Errorf("Equal must be %v but %v", len(c.diffs) == 0, eq)
+			}
+			var diffs []string
+			for _, d := range sqlast.Diff(a, b) {
+				diffs = append(diffs, d.String())
+			}
+			if diff := cmp.Diff(c.diffs, diffs); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func parseStmt(t *testing.T, src string) sqlast.Stmt {
+	t.Helper()
+	parser, err := xsqlparser.NewParser(bytes.NewBufferString(src), &dialect.MySQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stmt, err := parser.ParseStatement()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return stmt
+}
@@ -0,0 +1,128 @@
+package e2e_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+var update = flag.Bool("update", false, "regenerate the golden files of TestGolden")
+
+// goldenDialects are the dialects of the directories in testdata/golden.
+var goldenDialects = map[string]func() dialect.Dialect{
+	"generic":    func() dialect.Dialect { return &dialect.GenericSQLDialect{} },
+	"postgresql": func() dialect.Dialect { return &dialect.PostgresqlDialect{} },
+	"mysql":      func() dialect.Dialect { return &dialect.MySQLDialect{} },
+	"clickhouse": func() dialect.Dialect { return &dialect.ClickHouseDialect{} },
+	"hive":       func() dialect.Dialect { return &dialect.HiveDialect{} },
+	"oracle":     func() dialect.Dialect { return &dialect.OracleDialect{} },
+	"redshift":   func() dialect.Dialect { return &dialect.RedshiftDialect{} },
+	"sqlserver":  func() dialect.Dialect { return &dialect.SQLServerDialect{} },
+}
+
+// TestGolden parses each testdata/golden/<dialect>/*.sql by the dialect and
+// compares the statements rendered as SQL and as trees by sqlast.Dump with
+// the .golden file next to it. Parse errors are recorded in the golden files
+// as well, so that the corpus also tracks unsupported syntax.
+//
+// To add a case, put a .sql file and run
+//
+//	go test ./e2e -run TestGolden -update
+//
+// and review the generated .golden file.
+func TestGolden(t *testing.T) {
+	dirs, err := ioutil.ReadDir("testdata/golden")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, dir := range dirs {
+		newDialect, ok := goldenDialects[dir.Name()]
+		if !ok {
+			t.Errorf("unknown dialect directory %s", dir.Name())
+			continue
+		}
+		t.Run(dir.Name(), func(t *testing.T) {
+			files, err := filepath.Glob(filepath.Join("testdata/golden", dir.Name(), "*.sql"))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			sort.Strings(files)
+			for _, file := range files {
+				file := file
+				t.Run(filepath.Base(file), func(t *testing.T) {
+					src, err := ioutil.ReadFile(file)
+					if err != nil {
+						t.Fatalf("%+v", err)
+					}
+					act := renderGolden(t, src, newDialect)
+
+					golden := strings.TrimSuffix(file, ".sql") + ".golden"
+					if *update {
+						if err := ioutil.WriteFile(golden, []byte(act), 0644); err != nil {
+							t.Fatalf("%+v", err)
+						}
+						return
+					}
+					expect, err := ioutil.ReadFile(golden)
+					if err != nil {
+						t.Fatalf("%+v (run with -update to create it)", err)
+					}
+					if act != string(expect) {
+						t.Errorf("differs from %s (run with -update to regenerate it):\n%s", golden, diff.LineDiff(string(expect), act))
+					}
+				})
+			}
+		})
+	}
+}
+
+// renderGolden returns the content of the golden file of src. The rendered
+// SQL of each statement must parse to the same tree again.
+func renderGolden(t *testing.T, src []byte, newDialect func() dialect.Dialect) string {
+	t.Helper()
+	var out strings.Builder
+	parser, err := xsqlparser.NewParser(bytes.NewBuffer(src), newDialect())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		fmt.Fprintf(&out, "error: %v\n", err)
+		return out.String()
+	}
+
+	for i, stmt := range stmts {
+		if i != 0 {
+			out.WriteString("\n")
+		}
+		sql := stmt.ToSQLString()
+		fmt.Fprintf(&out, "-- %s\n%s", sql, sqlast.Dump(stmt))
+
+		parser, err := xsqlparser.NewParser(bytes.NewBufferString(sql), newDialect())
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		reparsed, err := parser.ParseStatement()
+		if err != nil {
+			t.Errorf("rendered SQL of statement %d doesn't parse: %s: %+v", i+1, sql, err)
+			continue
+		}
+		if !sqlast.Equal(stmt, reparsed) {
+			for _, d := range sqlast.Diff(stmt, reparsed) {
+				t.Errorf("rendered SQL of statement %d parses to a different tree: %s", i+1, d)
+			}
+		}
+	}
+	return out.String()
+}
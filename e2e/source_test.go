@@ -0,0 +1,72 @@
+package e2e_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestKeepSource(t *testing.T) {
+	files, err := filepath.Glob("testdata/*/*.sql")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	for _, fname := range files {
+		t.Run(fname, func(t *testing.T) {
+			src, err := ioutil.ReadFile(fname)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			parser, err := xsqlparser.NewParser(bytes.NewBuffer(src), &dialect.GenericSQLDialect{}, xsqlparser.KeepSource())
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			file, err := parser.ParseFile()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if act := file.ToSourceString(); act != string(src) {
+				t.Errorf("should be same as source but \n%s", act)
+			}
+		})
+	}
+}
+
+func TestKeepSource_Modified(t *testing.T) {
+	src := `-- users
+SELECT  id,name
+FROM users ;
+
+/* orders */
+select * from orders where id=1;
+`
+	parser, err := xsqlparser.NewParser(bytes.NewBufferString(src), &dialect.GenericSQLDialect{}, xsqlparser.KeepSource())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	file, err := parser.ParseFile()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	sel := file.Stmts[1].(*sqlast.QueryStmt).Body.(*sqlast.SQLSelect)
+	sel.WhereClause = nil
+
+	expect := strings.Replace(src, "select * from orders where id=1", "SELECT * FROM orders", 1)
+	if act := file.ToSourceString(); act != expect {
+		t.Errorf("should be \n%s but \n%s", expect, act)
+	}
+
+	file.Stmts = file.Stmts[:1]
+	if act := file.ToSourceString(); act != "SELECT id, name FROM users" {
+		t.Errorf("must fall back to WriteTo but \n%s", act)
+	}
+}
@@ -0,0 +1 @@
+ALTER INDEX IF EXISTS public.idx_orders_user RENAME TO idx_orders_user_id;
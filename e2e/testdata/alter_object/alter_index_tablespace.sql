@@ -0,0 +1 @@
+ALTER INDEX idx_orders_user SET TABLESPACE fastspace;
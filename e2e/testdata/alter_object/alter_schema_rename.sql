@@ -0,0 +1 @@
+ALTER SCHEMA sales RENAME TO sales_old;
@@ -0,0 +1 @@
+ALTER VIEW IF EXISTS active_users OWNER TO reporting;
@@ -0,0 +1 @@
+ALTER VIEW active_users SET SCHEMA archive;
@@ -0,0 +1 @@
+COPY public.orders (id, amount) FROM '/data/orders.tsv' WITH (DELIMITER '	', NULL '');
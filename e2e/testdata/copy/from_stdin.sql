@@ -0,0 +1,4 @@
+COPY users (id, name, email) FROM STDIN;
+1	alice	alice@example.com
+2	bob	\N
+\.
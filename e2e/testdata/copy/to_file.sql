@@ -0,0 +1 @@
+COPY (SELECT id, name FROM users WHERE active = true) TO '/tmp/users.csv' WITH (FORMAT csv, HEADER, FORCE_QUOTE (name));
@@ -0,0 +1,6 @@
+CREATE FUNCTION update_modified() RETURNS trigger AS $body$
+BEGIN
+    NEW.modified = now();
+    RETURN NEW;
+END;
+$body$ LANGUAGE plpgsql SECURITY DEFINER;
@@ -0,0 +1,8 @@
+CREATE PROCEDURE insert_data(a int, b int)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    INSERT INTO tbl VALUES (a);
+    INSERT INTO tbl VALUES (b);
+END
+$$;
@@ -0,0 +1 @@
+CREATE FUNCTION active_users(since timestamp) RETURNS TABLE (id int, name text) STABLE STRICT AS 'SELECT id, name FROM users WHERE last_login > since' LANGUAGE sql;
@@ -0,0 +1,4 @@
+CREATE OR REPLACE FUNCTION add(a integer, b integer DEFAULT 1) RETURNS integer
+    LANGUAGE sql
+    IMMUTABLE
+    AS $$SELECT a + b$$;
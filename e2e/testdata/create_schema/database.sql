@@ -0,0 +1,7 @@
+CREATE DATABASE app
+    WITH OWNER = app_owner
+    ENCODING = 'UTF8'
+    LC_COLLATE = 'en_US.UTF-8'
+    LC_CTYPE = 'en_US.UTF-8'
+    TEMPLATE = template0
+    CONNECTION LIMIT = -1;
@@ -0,0 +1 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp" WITH SCHEMA public CASCADE;
@@ -0,0 +1 @@
+CREATE SCHEMA IF NOT EXISTS app AUTHORIZATION app_owner;
@@ -0,0 +1,6 @@
+CREATE TABLE documents (
+    id int PRIMARY KEY,
+    tags text[],
+    scores int[3],
+    matrix int[][]
+);
@@ -0,0 +1,4 @@
+CREATE TABLE people (
+    name text COLLATE "C" NOT NULL,
+    nickname varchar(32) COLLATE "en_US" DEFAULT 'none'
+);
@@ -0,0 +1,7 @@
+CREATE TABLE events (
+    id bigint,
+    name string,
+    payload binary,
+    attrs map<string, array<int>>,
+    owner struct<name:string, emails:array<string>>
+);
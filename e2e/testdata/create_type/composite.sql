@@ -0,0 +1 @@
+CREATE TYPE inventory_item AS (name text, supplier_id integer, price numeric(10, 2));
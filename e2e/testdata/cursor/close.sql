@@ -0,0 +1 @@
+CLOSE active_users;
@@ -0,0 +1,2 @@
+DECLARE active_users SCROLL CURSOR WITH HOLD FOR
+SELECT id, name FROM users WHERE active = true ORDER BY id;
@@ -0,0 +1 @@
+FETCH FORWARD 10 FROM active_users;
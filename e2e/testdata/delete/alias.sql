@@ -0,0 +1 @@
+DELETE FROM customers AS c WHERE c.customer_id = 1;
@@ -0,0 +1 @@
+DELETE FROM orders AS o USING customers AS c WHERE o.customer_id = c.customer_id AND c.name = 'test' RETURNING o.order_id, o.amount AS amt;
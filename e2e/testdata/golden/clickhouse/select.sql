@@ -0,0 +1 @@
+SELECT user_id, count() FROM events FINAL WHERE event_date = today() GROUP BY user_id;
@@ -0,0 +1,9 @@
+CREATE TABLE customers (
+  id int PRIMARY KEY,
+  name varchar(255) NOT NULL,
+  country char(2) DEFAULT 'JP',
+  CONSTRAINT name_check CHECK (name <> '')
+);
+ALTER TABLE customers ADD COLUMN email varchar(255);
+CREATE INDEX customers_name ON customers (name);
+DROP TABLE customers;
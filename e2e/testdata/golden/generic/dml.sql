@@ -0,0 +1,3 @@
+INSERT INTO customers (id, name) VALUES (1, 'a'), (2, 'b');
+UPDATE customers SET name = 'c' WHERE id = 1;
+DELETE FROM customers WHERE id = 2;
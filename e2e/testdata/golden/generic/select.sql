@@ -0,0 +1,8 @@
+SELECT c.id, c.name, count(*) AS orders
+FROM customers AS c
+LEFT JOIN orders AS o ON o.customer_id = c.id
+WHERE c.country IN ('JP', 'US') AND o.total BETWEEN 10 AND 100
+GROUP BY c.id, c.name
+HAVING count(*) > 1
+ORDER BY orders DESC
+LIMIT 10;
@@ -0,0 +1,4 @@
+WITH recent AS (SELECT * FROM orders WHERE created_at > '2020-01-01')
+SELECT name FROM customers WHERE EXISTS (SELECT 1 FROM recent WHERE recent.customer_id = customers.id)
+UNION ALL
+SELECT name FROM suppliers;
@@ -0,0 +1 @@
+CREATE TABLE logs (id bigint, attrs map<string, string>, tags array<string>);
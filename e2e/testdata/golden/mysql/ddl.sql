@@ -0,0 +1,20 @@
+CREATE TABLE `users` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+CREATE TABLE `orders` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `code` int(6) unsigned zerofill NOT NULL,
+  `paid` tinyint(1) NOT NULL DEFAULT 0,
+  `note` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL COMMENT 'free text',
+  `updated_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB AUTO_INCREMENT=1001 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='orders';
+
+CREATE TABLE people (
+  first_name varchar(50),
+  last_name varchar(50),
+  full_name varchar(101) AS (concat(first_name, ' ', last_name)) VIRTUAL,
+  name_len int GENERATED ALWAYS AS (char_length(first_name)) STORED
+);
@@ -0,0 +1,6 @@
+SELECT /*+ BKA(t1) NO_ICP(t2 idx_b) */ t1.a, t2.b
+FROM t1 USE INDEX (idx_a) JOIN t2 FORCE INDEX FOR JOIN (idx_b) ON t1.id = t2.id;
+SELECT a FROM t AS x IGNORE KEY FOR ORDER BY (PRIMARY, idx_c) ORDER BY a;
+INSERT /*+ SET_VAR(foreign_key_checks = OFF) */ INTO t VALUES (1);
+UPDATE /*+ MAX_EXECUTION_TIME(1000) */ t SET a = 1 WHERE b = 2;
+DELETE /*+ QB_NAME(qb1) */ FROM t WHERE a = 1;
@@ -0,0 +1,9 @@
+USE shop;
+
+SHOW FULL TABLES FROM shop LIKE 'order%';
+
+SHOW CREATE TABLE shop.orders;
+
+SHOW COLUMNS FROM orders WHERE `Key` = 'PRI';
+
+DESC orders;
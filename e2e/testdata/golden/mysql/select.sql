@@ -0,0 +1,2 @@
+SELECT `id`, `name` FROM `users` WHERE `age` > 20 LIMIT 5;
+SELECT `id` FROM `users` ORDER BY `id` LIMIT 18446744073709551615 OFFSET 10;
@@ -0,0 +1,2 @@
+SELECT id, name FROM employees WHERE ROWNUM <= 10;
+SELECT /*+ INDEX(e emp_idx) FIRST_ROWS(10) */ e.id FROM employees e;
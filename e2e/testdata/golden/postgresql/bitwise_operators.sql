@@ -0,0 +1 @@
+SELECT a # b, a & b | c, a << 1 >> 2, 'a' || 'b' || c FROM t;
@@ -0,0 +1,3 @@
+CALL transfer_funds(42, 7, amount => 100);
+
+CALL public.refresh_stats();
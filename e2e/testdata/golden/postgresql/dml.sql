@@ -0,0 +1,4 @@
+DELETE FROM counters WHERE id = 1 RETURNING id;
+INSERT INTO counters (id, n) VALUES (1, 0) RETURNING id, n AS count;
+UPDATE counters SET n = n + 1 WHERE id = 1 RETURNING *;
+SELECT * FROM generate_series(1, 3) AS s(i);
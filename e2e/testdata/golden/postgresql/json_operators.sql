@@ -0,0 +1,9 @@
+SELECT id,
+       payload -> 'user' ->> 'name' AS user_name,
+       payload #> '{address,city}' AS city,
+       payload #>> '{address,zip}' AS zip
+FROM events
+WHERE payload @> '{"type": "signup"}'
+  AND payload ? 'user'
+  AND payload -> 'tags' ?| ARRAY['new', 'beta']
+  AND payload ->> 'source' = 'web';
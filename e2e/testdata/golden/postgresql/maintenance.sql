@@ -0,0 +1,7 @@
+VACUUM (VERBOSE, ANALYZE) measurement;
+
+VACUUM FULL accounts, session_cache (k);
+
+ANALYZE public.accounts;
+
+REINDEX (VERBOSE) INDEX CONCURRENTLY session_cache_v;
@@ -0,0 +1,5 @@
+SELECT DISTINCT ON (team) team, tags[1], data::jsonb, row_number() OVER (PARTITION BY team ORDER BY score DESC)
+FROM players
+WHERE name ILIKE 'a%' AND id = ANY($1)
+ORDER BY team, score DESC;
+SELECT a.x FROM t AS a(x, y) JOIN u b(z) ON a.x = b.z;
@@ -0,0 +1,44 @@
+CREATE TABLE matrices (
+  id int,
+  tags text[],
+  scores numeric(10,2)[][],
+  board int[3][3],
+  names varchar(20) ARRAY
+);
+
+SELECT CAST(a AS int ARRAY[4]), b::numeric[] FROM matrices;
+
+CREATE TABLE accounts (
+  id bigserial PRIMARY KEY,
+  seq serial,
+  ext_id int GENERATED ALWAYS AS IDENTITY (START WITH 1000 INCREMENT BY 1),
+  legacy_id bigint GENERATED BY DEFAULT AS IDENTITY
+);
+
+CREATE TABLE line_items (
+  price numeric(10,2),
+  qty int,
+  total numeric GENERATED ALWAYS AS (price * qty) STORED
+);
+
+CREATE TABLE measurement (
+  city_id int NOT NULL,
+  logdate date NOT NULL
+) PARTITION BY RANGE (logdate);
+
+CREATE TABLE measurement_y2020 PARTITION OF measurement
+  FOR VALUES FROM ('2020-01-01') TO ('2021-01-01');
+
+CREATE TABLE measurement_y2021 PARTITION OF measurement (
+  city_id WITH OPTIONS DEFAULT 0,
+  logdate PRIMARY KEY
+) FOR VALUES FROM ('2021-01-01') TO ('2022-01-01');
+
+CREATE TABLE measurement_default PARTITION OF measurement DEFAULT;
+
+CREATE TEMPORARY TABLE session_cache (
+  k text PRIMARY KEY,
+  v text
+) WITH (fillfactor = 70) ON COMMIT DELETE ROWS TABLESPACE fast_ssd;
+
+CREATE INDEX session_cache_v ON session_cache (v) WITH (fillfactor = 90) TABLESPACE fast_ssd;
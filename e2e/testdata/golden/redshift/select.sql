@@ -0,0 +1 @@
+SELECT id, name FROM users WHERE created_at > '2020-01-01' ORDER BY id LIMIT 10;
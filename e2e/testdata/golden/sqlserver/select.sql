@@ -0,0 +1,3 @@
+SELECT TOP 10 id, name FROM [user list] ORDER BY id;
+SELECT DISTINCT TOP (5) PERCENT WITH TIES score FROM "results" ORDER BY score DESC;
+SELECT id FROM users ORDER BY id OFFSET 10 ROWS FETCH NEXT 5 ROWS ONLY;
@@ -0,0 +1,5 @@
+MERGE INTO customers AS c
+USING new_customers AS n
+ON c.id = n.id
+WHEN MATCHED AND n.deleted THEN DO NOTHING
+WHEN NOT MATCHED THEN DO NOTHING;
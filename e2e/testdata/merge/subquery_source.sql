@@ -0,0 +1,5 @@
+MERGE INTO stock s
+USING (SELECT item_id, sum(qty) AS qty FROM deliveries GROUP BY item_id) AS d
+ON s.item_id = d.item_id
+WHEN MATCHED THEN UPDATE SET qty = s.qty + d.qty
+WHEN NOT MATCHED THEN INSERT (item_id, qty) VALUES (d.item_id, d.qty);
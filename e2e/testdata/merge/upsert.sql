@@ -0,0 +1,6 @@
+MERGE INTO customers AS c
+USING new_customers AS n
+ON c.id = n.id
+WHEN MATCHED AND n.deleted THEN DELETE
+WHEN MATCHED THEN UPDATE SET name = n.name, email = n.email
+WHEN NOT MATCHED THEN INSERT (id, name, email) VALUES (n.id, n.name, n.email);
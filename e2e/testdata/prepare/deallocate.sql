@@ -0,0 +1 @@
+DEALLOCATE PREPARE find_user;
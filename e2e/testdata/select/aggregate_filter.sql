@@ -0,0 +1,7 @@
+SELECT department,
+       count(*) FILTER (WHERE salary > 1000) AS high_paid,
+       percentile_cont(0.5) WITHIN GROUP (ORDER BY salary) AS median_salary,
+       mode() WITHIN GROUP (ORDER BY title DESC) FILTER (WHERE active) AS common_title,
+       sum(salary) FILTER (WHERE active) OVER (PARTITION BY region) AS region_total
+FROM employees
+GROUP BY department, region, salary, active;
@@ -0,0 +1,8 @@
+SELECT tags[1] AS first_tag,
+       tags[2:3] AS middle_tags,
+       matrix[1][2] AS cell,
+       ARRAY[1, 2, 3] AS numbers,
+       ARRAY[[1, 2], [3, 4]] AS pairs,
+       CAST(ids AS int[]) AS int_ids
+FROM posts
+WHERE tags[1] = 'go';
@@ -0,0 +1,4 @@
+SELECT name COLLATE "de_DE" AS name
+FROM people
+WHERE name COLLATE "C" > 'm'
+ORDER BY name COLLATE "C" DESC;
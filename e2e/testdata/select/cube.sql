@@ -0,0 +1,4 @@
+SELECT region, product, channel, sum(amount) AS total
+FROM sales
+GROUP BY channel, CUBE (region, (product, channel))
+HAVING sum(amount) > 100;
@@ -0,0 +1 @@
+SELECT $$it's$$ AS a, $body$ SELECT 1; $body$ AS b FROM t;
@@ -0,0 +1 @@
+SELECT E'it\'s\n', 'a''b', N'c''d' FROM t;
@@ -0,0 +1 @@
+SELECT id, name FROM users ORDER BY created_at DESC OFFSET 20 ROWS FETCH FIRST 10 ROWS ONLY;
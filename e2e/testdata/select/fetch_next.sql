@@ -0,0 +1 @@
+SELECT id FROM users ORDER BY id LIMIT 5 FETCH NEXT ROW ONLY;
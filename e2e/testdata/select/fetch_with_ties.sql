@@ -0,0 +1 @@
+SELECT id, score FROM results ORDER BY score DESC FETCH FIRST 3 ROWS WITH TIES;
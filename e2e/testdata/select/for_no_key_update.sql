@@ -0,0 +1 @@
+SELECT a.id, b.name FROM accounts a INNER JOIN balances b ON a.id = b.account_id FOR NO KEY UPDATE OF a NOWAIT;
@@ -0,0 +1 @@
+SELECT id FROM accounts FOR SHARE;
@@ -0,0 +1 @@
+SELECT id, payload FROM jobs WHERE state = 'queued' ORDER BY id LIMIT 10 FOR UPDATE SKIP LOCKED;
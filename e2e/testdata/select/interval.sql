@@ -0,0 +1,6 @@
+SELECT id, created_at + INTERVAL '1' DAY AS expires_at
+FROM sessions
+WHERE created_at > now() - INTERVAL '2 hours'
+  AND updated_at < now() - INTERVAL '1-2' YEAR TO MONTH
+  AND duration < INTERVAL '3 4:05:06.7' DAY TO SECOND(3)
+  AND timeout < INTERVAL '1.5' SECOND(2, 3);
@@ -0,0 +1,7 @@
+SELECT n.id, t.max_usage
+FROM node AS n,
+    LATERAL (
+        SELECT max(usage)
+        FROM node_mon m
+        WHERE m.id = n.id
+    ) AS t(max_usage);
@@ -0,0 +1,5 @@
+(SELECT id FROM orders WHERE status = 'open')
+UNION
+(SELECT id FROM archived_orders)
+ORDER BY id
+OFFSET 20 LIMIT 10;
@@ -0,0 +1 @@
+SELECT make_interval(days => 3, hours => 12), format(fmt := 'x %s', 'a');
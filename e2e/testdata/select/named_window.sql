@@ -0,0 +1,7 @@
+SELECT department,
+       sum(salary) OVER w AS running_total,
+       avg(salary) OVER (w ROWS BETWEEN 1 PRECEDING AND CURRENT ROW) AS moving_avg,
+       rank() OVER ranked AS salary_rank
+FROM employees
+WINDOW w AS (PARTITION BY department ORDER BY hired_at),
+       ranked AS (PARTITION BY department ORDER BY salary DESC);
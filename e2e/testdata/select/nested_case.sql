@@ -0,0 +1 @@
+SELECT CASE status WHEN 'active' THEN CASE WHEN score > 10 THEN 'high' ELSE 'low' END ELSE 'inactive' END AS label, CASE WHEN age IS NULL THEN 0 END AS age_flag FROM users;
@@ -0,0 +1 @@
+SELECT 0xFF AS a, X'1f' AS b, B'1010' AS c, 1e10 AS d, 1.5E-3 AS e FROM t;
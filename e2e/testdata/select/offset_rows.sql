@@ -0,0 +1 @@
+SELECT id FROM users ORDER BY id OFFSET 5 ROWS;
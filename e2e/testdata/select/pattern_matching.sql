@@ -0,0 +1,9 @@
+SELECT name FROM customers
+WHERE name LIKE 'a!%%' ESCAPE '!'
+  AND name NOT ILIKE '%test%'
+  AND code SIMILAR TO '%(b|d)%'
+  AND code NOT SIMILAR TO 'x#_%' ESCAPE '#'
+  AND email ~ '^[a-z]+@'
+  AND email ~* 'EXAMPLE'
+  AND email !~ 'spam'
+  AND email !~* 'TEST';
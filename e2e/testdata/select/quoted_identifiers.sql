@@ -0,0 +1,2 @@
+SELECT "My Col", "a""b", U&"d\0061t\+000061", 名前 FROM "My Table" AS "T";
+SELECT U&'caf\00e9' AS U&"d!0061t!!" UESCAPE '!', U&'x!+000061' UESCAPE '!' FROM t;
@@ -0,0 +1,5 @@
+SELECT id, ROW(first_name, last_name) AS full_name
+FROM customers
+WHERE (country, city) = ('JP', 'Tokyo')
+  AND (id, status) IN ((1, 'active'), (2, 'pending'))
+  AND ROW(created_at, id) > ROW('2020-01-01', 100);
@@ -0,0 +1,10 @@
+SELECT EXTRACT(YEAR FROM o.created_at) AS order_year,
+       POSITION('@' IN u.email) AS at_pos,
+       SUBSTRING(u.email FROM 1 FOR 3) AS prefix,
+       SUBSTRING(u.name, 2, 4) AS middle,
+       TRIM(BOTH ' ' FROM u.name) AS trimmed,
+       TRIM(u.nickname) AS nickname,
+       OVERLAY(u.phone PLACING '****' FROM 4 FOR 4) AS masked
+FROM orders AS o
+INNER JOIN users AS u ON o.user_id = u.id
+WHERE EXTRACT(MONTH FROM o.created_at) = 12;
@@ -0,0 +1,7 @@
+SELECT first_name || ' ' || last_name AS full_name,
+       flags & 4 AS has_admin,
+       flags | 1 AS with_active,
+       flags << 2,
+       flags >> 1
+FROM users
+WHERE (flags & 8) = 0 AND 'x' || code = 'x1';
@@ -0,0 +1,5 @@
+SELECT g.n, o.item, o.idx
+FROM generate_series(1, 10) AS g(n),
+     UNNEST(ARRAY_COL) WITH ORDINALITY AS o(item, idx),
+     LATERAL jsonb_each(o.item) AS kv(key, value)
+WHERE g.n > 1;
@@ -0,0 +1,4 @@
+SELECT e.id, e.payload
+FROM events AS e TABLESAMPLE BERNOULLI(10) REPEATABLE (42)
+JOIN users u TABLESAMPLE SYSTEM(1) ON e.user_id = u.id
+WHERE e.created_at > '2020-01-01';
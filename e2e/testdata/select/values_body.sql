@@ -0,0 +1 @@
+VALUES (1, 'one'), (2, 'two') UNION ALL VALUES (3, 'three') ORDER BY 1;
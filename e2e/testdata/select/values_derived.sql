@@ -0,0 +1,2 @@
+SELECT v.id, v.name
+FROM (VALUES (1, 'one'), (2, 'two')) AS v (id, name);
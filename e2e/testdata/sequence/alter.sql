@@ -0,0 +1 @@
+ALTER SEQUENCE IF EXISTS order_id_seq RESTART WITH 1 INCREMENT BY 5 OWNED BY NONE;
@@ -0,0 +1,8 @@
+CREATE SEQUENCE IF NOT EXISTS public.order_id_seq
+    AS bigint
+    INCREMENT BY 1
+    MINVALUE 1
+    NO MAXVALUE
+    START WITH 1000
+    CACHE 20
+    NO CYCLE;
@@ -0,0 +1 @@
+CREATE TEMPORARY SEQUENCE countdown INCREMENT -1 MAXVALUE 10 START 10 CYCLE OWNED BY tasks.remaining;
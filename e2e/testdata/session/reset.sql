@@ -0,0 +1 @@
+RESET ALL;
@@ -0,0 +1 @@
+SET SESSION TIME ZONE 'Asia/Tokyo';
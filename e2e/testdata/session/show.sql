@@ -0,0 +1 @@
+SHOW statement_timeout;
@@ -0,0 +1,2 @@
+TRUNCATE sessions;
+TRUNCATE ONLY audit_log CONTINUE IDENTITY;
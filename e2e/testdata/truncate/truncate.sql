@@ -0,0 +1 @@
+TRUNCATE TABLE orders, order_items RESTART IDENTITY CASCADE;
@@ -0,0 +1,68 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestNodeVisitor(t *testing.T) {
+	stmt := parseStmt(t, "SELECT a, f(b + 1) FROM t WHERE c = 1 AND d IN (SELECT e FROM u WHERE g > 2)")
+
+	t.Run("callbacks", func(t *testing.T) {
+		var selects, exprs, others []string
+		sqlast.Walk(&sqlast.NodeVisitor{
+			SQLSelect: func(n *sqlast.SQLSelect) bool {
+				selects = append(selects, n.ToSQLString())
+				return true
+			},
+			BinaryExpr: func(n *sqlast.BinaryExpr) bool {
+				exprs = append(exprs, n.ToSQLString())
+				return true
+			},
+			Default: func(n sqlast.Node) bool {
+				if _, ok := n.(*sqlast.Operator); ok {
+					others = append(others, n.ToSQLString())
+				}
+				return true
+			},
+		}, stmt)
+
+		if diff := cmp.Diff([]string{
+			"SELECT a, f(b + 1) FROM t WHERE c = 1 AND d IN (SELECT e FROM u WHERE g > 2)",
+			"SELECT e FROM u WHERE g > 2",
+		}, selects); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+		if diff := cmp.Diff([]string{
+			"b + 1",
+			"c = 1 AND d IN (SELECT e FROM u WHERE g > 2)",
+			"c = 1",
+			"g > 2",
+		}, exprs); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+		if diff := cmp.Diff([]string{"+", "=", "AND", ">"}, others); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	})
+
+	t.Run("skip children", func(t *testing.T) {
+		var idents []string
+		sqlast.Walk(&sqlast.NodeVisitor{
+			InSubQuery: func(n *sqlast.InSubQuery) bool {
+				return false
+			},
+			Ident: func(n *sqlast.Ident) bool {
+				idents = append(idents, n.Value)
+				return true
+			},
+		}, stmt)
+
+		if diff := cmp.Diff([]string{"a", "f", "b", "t", "c"}, idents); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	})
+}
@@ -0,0 +1,752 @@
+// Package eval evaluates parsed expressions over the values of columns, e.g.
+// for filtering rows on the client side or testing CHECK constraints.
+//
+// Values are represented as nil for NULL, int64, float64, string, bool and
+// time.Time. NULL propagates as in SQL, i.e. comparisons with NULL and most
+// functions of NULL are NULL, and AND, OR and NOT follow three-valued logic.
+package eval
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// Env is the values of columns by their names. A column reference is looked
+// up by its name as written, e.g. "t.a", and then by the last part, e.g. "a".
+// Unquoted names are also looked up in lower case.
+//
+// Values may be of any integer or floating point type, string, []byte, bool,
+// time.Time or nil.
+type Env map[string]interface{}
+
+// Eval evaluates expr with the values of columns in env.
+func Eval(expr sqlast.Node, env Env) (interface{}, error) {
+	return eval(expr, env)
+}
+
+// Match reports whether expr is TRUE with env, i.e. whether the row env is
+// selected by expr as a WHERE condition.
+func Match(expr sqlast.Node, env Env) (bool, error) {
+	v, err := evalBool(expr, env)
+	if err != nil {
+		return false, err
+	}
+	return v == true, nil
+}
+
+// Check reports whether expr is not FALSE with env, i.e. whether the row env
+// satisfies expr as a CHECK constraint.
+func Check(expr sqlast.Node, env Env) (bool, error) {
+	v, err := evalBool(expr, env)
+	if err != nil {
+		return false, err
+	}
+	return v != false, nil
+}
+
+func evalBool(expr sqlast.Node, env Env) (interface{}, error) {
+	v, err := eval(expr, env)
+	if err != nil {
+		return nil, err
+	}
+	switch v.(type) {
+	case nil, bool:
+		return v, nil
+	}
+	return nil, errors.Errorf("%s is not boolean: %v", expr.ToSQLString(), v)
+}
+
+func eval(n sqlast.Node, env Env) (interface{}, error) {
+	switch e := n.(type) {
+	case *sqlast.NullValue:
+		return nil, nil
+	case *sqlast.LongValue:
+		return e.Long, nil
+	case *sqlast.DoubleValue:
+		return e.Double, nil
+	case *sqlast.NumericValue:
+		// beyond int64, approximated by float64
+		return strconv.ParseFloat(e.Numeric, 64)
+	case *sqlast.SingleQuotedString:
+		return e.String, nil
+	case *sqlast.NationalStringLiteral:
+		return e.String, nil
+	case *sqlast.DollarQuotedString:
+		return e.String, nil
+	case *sqlast.UnicodeStringLiteral:
+		return e.String, nil
+	case *sqlast.BooleanValue:
+		return e.Boolean, nil
+	case *sqlast.DateValue:
+		return e.Date, nil
+	case *sqlast.TimeValue:
+		return e.Time, nil
+	case *sqlast.DateTimeValue:
+		return e.DateTime, nil
+	case *sqlast.TimestampValue:
+		return e.Timestamp, nil
+	case *sqlast.Ident:
+		return lookup(env, []*sqlast.Ident{e})
+	case *sqlast.CompoundIdent:
+		return lookup(env, e.Idents)
+	case *sqlast.Nested:
+		return eval(e.AST, env)
+	case *sqlast.UnaryExpr:
+		return evalUnary(e, env)
+	case *sqlast.BinaryExpr:
+		return evalBinary(e, env)
+	case *sqlast.LikeEscape:
+		return evalLike(e.Like, e.Escape, env)
+	case *sqlast.IsNull:
+		v, err := eval(e.X, env)
+		return v == nil, err
+	case *sqlast.IsNotNull:
+		v, err := eval(e.X, env)
+		return v != nil, err
+	case *sqlast.Between:
+		return evalBetween(e, env)
+	case *sqlast.InList:
+		return evalInList(e, env)
+	case *sqlast.CaseExpr:
+		return evalCase(e, env)
+	case *sqlast.Cast:
+		v, err := eval(e.Expr, env)
+		if err != nil {
+			return nil, err
+		}
+		return cast(v, e.DataType)
+	case *sqlast.Function:
+		return evalFunction(e, env)
+	case *sqlast.Substring:
+		return evalSubstring(e, env)
+	case *sqlast.Trim:
+		return evalTrim(e, env)
+	}
+	return nil, errors.Errorf("unsupported expression: %s", n.ToSQLString())
+}
+
+func lookup(env Env, idents []*sqlast.Ident) (interface{}, error) {
+	var names []string
+	var full []string
+	quoted := false
+	for _, i := range idents {
+		full = append(full, i.Value)
+		quoted = quoted || i.Quoted
+	}
+	names = append(names, strings.Join(full, "."))
+	last := idents[len(idents)-1]
+	if len(idents) > 1 {
+		names = append(names, last.Value)
+	}
+	for _, name := range names {
+		if v, ok := env[name]; ok {
+			return normalize(v)
+		}
+		if !quoted {
+			if v, ok := env[strings.ToLower(name)]; ok {
+				return normalize(v)
+			}
+		}
+	}
+	return nil, errors.Errorf("unknown column: %s", names[0])
+}
+
+// normalize converts v given in Env to the representation of values.
+func normalize(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, int64, float64, string, bool, time.Time:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return float64(v), nil
+		}
+		return int64(v), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return float64(v), nil
+		}
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	case []byte:
+		return string(v), nil
+	}
+	return nil, errors.Errorf("unsupported value type %T", v)
+}
+
+func evalUnary(e *sqlast.UnaryExpr, env Env) (interface{}, error) {
+	v, err := eval(e.Expr, env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	switch e.Op.Type {
+	case sqlast.Not:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, errors.Errorf("NOT of non boolean: %v", v)
+		}
+		return !b, nil
+	case sqlast.Minus:
+		switch v := v.(type) {
+		case int64:
+			if v == math.MinInt64 {
+				return nil, errors.New("integer out of range")
+			}
+			return -v, nil
+		case float64:
+			return -v, nil
+		}
+		return nil, errors.Errorf("unary minus of non number: %v", v)
+	case sqlast.Plus:
+		switch v.(type) {
+		case int64, float64:
+			return v, nil
+		}
+		return nil, errors.Errorf("unary plus of non number: %v", v)
+	}
+	return nil, errors.Errorf("unsupported expression: %s", e.ToSQLString())
+}
+
+func evalBinary(e *sqlast.BinaryExpr, env Env) (interface{}, error) {
+	switch e.Op.Type {
+	case sqlast.And, sqlast.Or:
+		return evalLogical(e, env)
+	case sqlast.Like, sqlast.NotLike, sqlast.ILike, sqlast.NotILike,
+		sqlast.RegexMatch, sqlast.RegexIMatch, sqlast.NotRegexMatch, sqlast.NotRegexIMatch:
+		return evalLike(e, nil, env)
+	}
+
+	l, err := eval(e.Left, env)
+	if err != nil {
+		return nil, err
+	}
+	r, err := eval(e.Right, env)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	switch e.Op.Type {
+	case sqlast.Plus, sqlast.Minus, sqlast.Multiply, sqlast.Divide, sqlast.Modulus:
+		return arithmetic(e.Op.Type, l, r)
+	case sqlast.StringConcat:
+		return concat(l, r)
+	case sqlast.Eq, sqlast.NotEq, sqlast.Lt, sqlast.LtEq, sqlast.Gt, sqlast.GtEq:
+		c, err := compare(l, r)
+		if err != nil {
+			return nil, err
+		}
+		switch e.Op.Type {
+		case sqlast.Eq:
+			return c == 0, nil
+		case sqlast.NotEq:
+			return c != 0, nil
+		case sqlast.Lt:
+			return c < 0, nil
+		case sqlast.LtEq:
+			return c <= 0, nil
+		case sqlast.Gt:
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	}
+	return nil, errors.Errorf("unsupported operator: %s", e.Op.ToSQLString())
+}
+
+func evalLogical(e *sqlast.BinaryExpr, env Env) (interface{}, error) {
+	l, err := evalBool(e.Left, env)
+	if err != nil {
+		return nil, err
+	}
+	// the value which decides the result regardless of the other operand
+	decisive := e.Op.Type == sqlast.Or
+	if l == decisive {
+		return decisive, nil
+	}
+	r, err := evalBool(e.Right, env)
+	if err != nil {
+		return nil, err
+	}
+	if r == decisive {
+		return decisive, nil
+	}
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	return !decisive, nil
+}
+
+func arithmetic(op sqlast.OperatorType, l, r interface{}) (interface{}, error) {
+	a, aok := l.(int64)
+	b, bok := r.(int64)
+	if aok && bok {
+		var v int64
+		switch op {
+		case sqlast.Plus:
+			v = a + b
+			if (b > 0 && v < a) || (b < 0 && v > a) {
+				return nil, errors.New("integer out of range")
+			}
+		case sqlast.Minus:
+			v = a - b
+			if (b > 0 && v > a) || (b < 0 && v < a) {
+				return nil, errors.New("integer out of range")
+			}
+		case sqlast.Multiply:
+			v = a * b
+			if a != 0 && (v/a != b || (a == -1 && b == math.MinInt64)) {
+				return nil, errors.New("integer out of range")
+			}
+		case sqlast.Divide, sqlast.Modulus:
+			if b == 0 {
+				return nil, errors.New("division by zero")
+			}
+			if b == -1 {
+				// avoid overflow of MinInt64 / -1
+				if op == sqlast.Modulus {
+					return int64(0), nil
+				}
+				if a == math.MinInt64 {
+					return nil, errors.New("integer out of range")
+				}
+				return -a, nil
+			}
+			if op == sqlast.Divide {
+				v = a / b
+			} else {
+				v = a % b
+			}
+		}
+		return v, nil
+	}
+
+	x, xok := toFloat(l)
+	y, yok := toFloat(r)
+	if !xok || !yok {
+		return nil, errors.Errorf("arithmetic of non numbers: %v (%s), %v (%s)", l, typeName(l), r, typeName(r))
+	}
+	switch op {
+	case sqlast.Plus:
+		return x + y, nil
+	case sqlast.Minus:
+		return x - y, nil
+	case sqlast.Multiply:
+		return x * y, nil
+	}
+	if y == 0 {
+		return nil, errors.New("division by zero")
+	}
+	if op == sqlast.Divide {
+		return x / y, nil
+	}
+	return math.Mod(x, y), nil
+}
+
+// concat returns `l || r`, which requires either of them to be a string as
+// in PostgreSQL. The other is converted to a string.
+func concat(l, r interface{}) (interface{}, error) {
+	_, lok := l.(string)
+	_, rok := r.(string)
+	if !lok && !rok {
+		return nil, errors.Errorf("cannot concatenate %v (%s) and %v (%s)", l, typeName(l), r, typeName(r))
+	}
+	return toString(l) + toString(r), nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// compare returns a negative number, 0 or a positive number if l is less
+// than, equal to or greater than r, which are not NULL.
+func compare(l, r interface{}) (int, error) {
+	switch a := l.(type) {
+	case int64:
+		if b, ok := r.(int64); ok {
+			switch {
+			case a < b:
+				return -1, nil
+			case a > b:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if b, ok := r.(string); ok {
+			return strings.Compare(a, b), nil
+		}
+	case bool:
+		if b, ok := r.(bool); ok {
+			switch {
+			case a == b:
+				return 0, nil
+			case b:
+				return -1, nil
+			}
+			return 1, nil
+		}
+	case time.Time:
+		if b, ok := r.(time.Time); ok {
+			switch {
+			case a.Before(b):
+				return -1, nil
+			case a.After(b):
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	x, xok := toFloat(l)
+	y, yok := toFloat(r)
+	if !xok || !yok {
+		return 0, errors.Errorf("cannot compare %v (%s) with %v (%s)", l, typeName(l), r, typeName(r))
+	}
+	switch {
+	case x < y:
+		return -1, nil
+	case x > y:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// evalLike evaluates LIKE, ILIKE and the regular expression operators, or
+// their negations, with escape which may be nil.
+func evalLike(e *sqlast.BinaryExpr, escape sqlast.Node, env Env) (interface{}, error) {
+	args := []sqlast.Node{e.Left, e.Right}
+	if escape != nil {
+		args = append(args, escape)
+	}
+	values, err := evalStrings(args, env)
+	if err != nil || values == nil {
+		return nil, err
+	}
+	s, pattern := values[0], values[1]
+
+	var expr string
+	var negated bool
+	switch e.Op.Type {
+	case sqlast.Like, sqlast.NotLike, sqlast.ILike, sqlast.NotILike:
+		esc := '\\'
+		if escape != nil {
+			if utf8.RuneCountInString(values[2]) != 1 {
+				return nil, errors.Errorf("invalid escape string: %s", values[2])
+			}
+			esc, _ = utf8.DecodeRuneInString(values[2])
+		}
+		expr, err = likeToRegexp(pattern, esc)
+		if err != nil {
+			return nil, err
+		}
+		if e.Op.Type == sqlast.ILike || e.Op.Type == sqlast.NotILike {
+			expr = "(?i)" + expr
+		}
+		negated = e.Op.Type == sqlast.NotLike || e.Op.Type == sqlast.NotILike
+	case sqlast.RegexMatch, sqlast.NotRegexMatch:
+		expr = pattern
+		negated = e.Op.Type == sqlast.NotRegexMatch
+	case sqlast.RegexIMatch, sqlast.NotRegexIMatch:
+		expr = "(?i)" + pattern
+		negated = e.Op.Type == sqlast.NotRegexIMatch
+	default:
+		return nil, errors.Errorf("unsupported operator: %s", e.Op.ToSQLString())
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, errors.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+	return re.MatchString(s) != negated, nil
+}
+
+// likeToRegexp converts a pattern of LIKE into a regular expression matching
+// the whole string.
+func likeToRegexp(pattern string, escape rune) (string, error) {
+	var b strings.Builder
+	b.WriteString("(?s)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == escape:
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escaped {
+		return "", errors.Errorf("LIKE pattern must not end with escape character: %s", pattern)
+	}
+	b.WriteString("$")
+	return b.String(), nil
+}
+
+// evalStrings evaluates exprs as strings, returning nil if any of them is
+// NULL.
+func evalStrings(exprs []sqlast.Node, env Env) ([]string, error) {
+	var values []string
+	for _, expr := range exprs {
+		v, err := eval(expr, env)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, errors.Errorf("%s is not string: %v", expr.ToSQLString(), v)
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
+
+func evalBetween(e *sqlast.Between, env Env) (interface{}, error) {
+	x, err := eval(e.Expr, env)
+	if err != nil {
+		return nil, err
+	}
+	result := interface{}(true)
+	for i, bound := range []sqlast.Node{e.Low, e.High} {
+		v, err := eval(bound, env)
+		if err != nil {
+			return nil, err
+		}
+		if x == nil || v == nil {
+			result = nil
+			continue
+		}
+		c, err := compare(x, v)
+		if err != nil {
+			return nil, err
+		}
+		if (i == 0 && c < 0) || (i == 1 && c > 0) {
+			result = false
+			break
+		}
+	}
+	return negate(result, e.Negated), nil
+}
+
+func evalInList(e *sqlast.InList, env Env) (interface{}, error) {
+	x, err := eval(e.Expr, env)
+	if err != nil {
+		return nil, err
+	}
+	result := interface{}(false)
+	for _, n := range e.List {
+		v, err := eval(n, env)
+		if err != nil {
+			return nil, err
+		}
+		if x == nil || v == nil {
+			result = nil
+			continue
+		}
+		c, err := compare(x, v)
+		if err != nil {
+			return nil, err
+		}
+		if c == 0 {
+			result = true
+			break
+		}
+	}
+	return negate(result, e.Negated), nil
+}
+
+func negate(v interface{}, negated bool) interface{} {
+	if b, ok := v.(bool); ok && negated {
+		return !b
+	}
+	return v
+}
+
+func evalCase(e *sqlast.CaseExpr, env Env) (interface{}, error) {
+	var operand interface{}
+	if e.Operand != nil {
+		v, err := eval(e.Operand, env)
+		if err != nil {
+			return nil, err
+		}
+		operand = v
+	}
+	for i, cond := range e.Conditions {
+		var matched bool
+		if e.Operand != nil {
+			v, err := eval(cond, env)
+			if err != nil {
+				return nil, err
+			}
+			if operand != nil && v != nil {
+				c, err := compare(operand, v)
+				if err != nil {
+					return nil, err
+				}
+				matched = c == 0
+			}
+		} else {
+			v, err := evalBool(cond, env)
+			if err != nil {
+				return nil, err
+			}
+			matched = v == true
+		}
+		if matched {
+			return eval(e.Results[i], env)
+		}
+	}
+	if e.ElseResult != nil {
+		return eval(e.ElseResult, env)
+	}
+	return nil, nil
+}
+
+func cast(v interface{}, t sqlast.Type) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch t.(type) {
+	case *sqlast.SmallInt, *sqlast.Int, *sqlast.BigInt:
+		switch v := v.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			r := math.RoundToEven(v)
+			if r >= math.MaxInt64 || r < math.MinInt64 || math.IsNaN(r) {
+				return nil, errors.New("integer out of range")
+			}
+			return int64(r), nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case string:
+			i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid integer %q: %w", v, err)
+			}
+			return i, nil
+		}
+	case *sqlast.Decimal, *sqlast.Float, *sqlast.Real, *sqlast.Double:
+		switch v := v.(type) {
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid number %q: %w", v, err)
+			}
+			return f, nil
+		}
+	case *sqlast.CharType, *sqlast.VarcharType, *sqlast.Text, *sqlast.String, *sqlast.Clob:
+		return toString(v), nil
+	case *sqlast.Boolean:
+		switch v := v.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "t", "true", "y", "yes", "on", "1":
+				return true, nil
+			case "f", "false", "n", "no", "off", "0":
+				return false, nil
+			}
+			return nil, errors.Errorf("invalid boolean %q", v)
+		}
+	case *sqlast.Date, *sqlast.Timestamp:
+		switch v := v.(type) {
+		case time.Time:
+			if _, ok := t.(*sqlast.Date); ok {
+				return time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, v.Location()), nil
+			}
+			return v, nil
+		case string:
+			for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05.999999999", time.RFC3339Nano} {
+				if tm, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+					return cast(tm, t)
+				}
+			}
+			return nil, errors.Errorf("invalid time %q", v)
+		}
+	default:
+		return nil, errors.Errorf("unsupported type: %s", t.ToSQLString())
+	}
+	return nil, errors.Errorf("cannot cast %v to %s", v, t.ToSQLString())
+}
+
+// typeName returns the SQL type name of the value v for error messages.
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case int64:
+		return "bigint"
+	case float64:
+		return "double precision"
+	case string:
+		return "text"
+	case bool:
+		return "boolean"
+	case time.Time:
+		return "timestamp"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return v.Format("2006-01-02")
+		}
+		return v.Format("2006-01-02 15:04:05.999999999")
+	}
+	return fmt.Sprint(v)
+}
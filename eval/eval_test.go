@@ -0,0 +1,135 @@
+package eval
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestEval(t *testing.T) {
+	env := Env{
+		"id":      42,
+		"name":    "Alice",
+		"price":   float32(1.5),
+		"t.flag":  true,
+		"deleted": nil,
+		"created": time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	cases := []struct {
+		name   string
+		src    string
+		expect interface{}
+		err    bool
+	}{
+		{name: "arithmetic", src: "id * 2 + 7 / 2 - 10 % 4", expect: int64(85)},
+		{name: "float", src: "price * 2 + 1", expect: float64(4)},
+		{name: "unary", src: "-(id - 40)", expect: int64(-2)},
+		{name: "division by zero", src: "id / (id - 42)", err: true},
+		{name: "overflow", src: "9223372036854775807 + id", err: true},
+		{name: "comparison", src: "id >= 42 AND name < 'Bob' AND price = 1.5", expect: true},
+		{name: "qualified", src: "t.flag AND x.id = 42 AND NOT t.deleted IS NOT NULL", expect: true},
+		{name: "null", src: "deleted = 1 OR id < 0", expect: nil},
+		{name: "null or true", src: "deleted = 1 OR id > 0", expect: true},
+		{name: "null and false", src: "NOT (deleted = 1 AND id < 0)", expect: true},
+		{name: "like", src: "name LIKE 'A%e' AND name NOT LIKE 'a%' AND name ILIKE 'a_ICE'", expect: true},
+		{name: "like escape", src: "'100%' LIKE '100!%' ESCAPE '!' AND '1000' NOT LIKE '100!%' ESCAPE '!'", expect: true},
+		{name: "regexp", src: "name ~ '^A.+e$' AND name !~* '^b'", expect: true},
+		{name: "between", src: "id BETWEEN 40 AND 50 AND id NOT BETWEEN 1 AND 2", expect: true},
+		{name: "in", src: "id IN (1, 42)", expect: true},
+		{name: "in null", src: "id IN (1, NULL)", expect: nil},
+		{name: "not in", src: "name NOT IN ('Bob', 'Carol')", expect: true},
+		{name: "case", src: "CASE WHEN id < 10 THEN 'small' WHEN id < 100 THEN 'medium' ELSE 'large' END", expect: "medium"},
+		{name: "simple case", src: "CASE deleted WHEN NULL THEN 1 ELSE 2 END", expect: int64(2)},
+		{name: "case without else", src: "CASE WHEN deleted THEN 1 END", expect: nil},
+		{name: "cast", src: "CAST('12' AS int) + CAST(price AS int) + CAST(CAST(id AS text) AS bigint)", expect: int64(56)},
+		{name: "cast date", src: "CAST('2020-01-02' AS date) = created", expect: true},
+		{name: "functions", src: "concat(upper(name), '-', length(name), deleted)", expect: "ALICE-5"},
+		{name: "coalesce", src: "coalesce(deleted, nullif(id, 42), abs(-3))", expect: int64(3)},
+		{name: "round", src: "round(2.345, 2) + floor(price) + greatest(1, 5, NULL, 3)", expect: 8.35},
+		{name: "strict", src: "lower(deleted)", expect: nil},
+		{name: "substring", src: "SUBSTRING(name FROM 2 FOR 3)", expect: "lic"},
+		{name: "trim", src: "TRIM(LEADING 'x' FROM 'xxAxx')", expect: "Axx"},
+		{name: "unknown column", src: "unknown = 1", err: true},
+		{name: "quoted column", src: `"ID" = 42`, err: true},
+		{name: "type mismatch", src: "name > 1", err: true},
+		{name: "concatenation", src: "'a' || 'b' || id || price", expect: "ab421.5"},
+		{name: "concatenation of null", src: "name || deleted", expect: nil},
+		{name: "concatenation of non strings", src: "id || 1", err: true},
+		{name: "aggregate", src: "count(*) > 1", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := xsqlparser.ParseExpr(c.src, &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			act, err := Eval(expr, env)
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but %v", act)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if diff := cmp.Diff(c.expect, act); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+
+	expr, err := xsqlparser.ParseExpr("1 = '1'", &dialect.PostgresqlDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := Eval(expr, env); err == nil || !strings.Contains(err.Error(), "1 (bigint) with 1 (text)") {
+		t.Errorf("must be error with the operand types but %v", err)
+	}
+}
+
+func TestMatchAndCheck(t *testing.T) {
+	cases := []struct {
+		src   string
+		env   Env
+		match bool
+		check bool
+	}{
+		{src: "price > 0", env: Env{"price": 1}, match: true, check: true},
+		{src: "price > 0", env: Env{"price": -1}, match: false, check: false},
+		{src: "price > 0", env: Env{"price": nil}, match: false, check: true},
+	}
+
+	for _, c := range cases {
+		expr, err := xsqlparser.ParseExpr(c.src, &dialect.GenericSQLDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		match, err := Match(expr, c.env)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		check, err := Check(expr, c.env)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if match != c.match || check != c.check {
+			t.Errorf("%s with %v must be match: %t, check: %t but %t, %t", c.src, c.env, c.match, c.check, match, check)
+		}
+	}
+
+	expr, err := xsqlparser.ParseExpr("price + 1", &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err := Check(expr, Env{"price": 1}); err == nil {
+		t.Error("must be error for non boolean")
+	}
+}
@@ -0,0 +1,256 @@
+package eval
+
+import (
+	"math"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// function is a scalar function taking evaluated arguments.
+type function struct {
+	min, max int // the number of arguments, max is -1 if variadic
+	// strict functions return NULL without being called if any argument is NULL
+	strict bool
+	call   func(args []interface{}) (interface{}, error)
+}
+
+var functions = map[string]*function{
+	"lower":            {min: 1, max: 1, strict: true, call: stringFunc(strings.ToLower)},
+	"upper":            {min: 1, max: 1, strict: true, call: stringFunc(strings.ToUpper)},
+	"length":           {min: 1, max: 1, strict: true, call: length},
+	"char_length":      {min: 1, max: 1, strict: true, call: length},
+	"character_length": {min: 1, max: 1, strict: true, call: length},
+	"abs":              {min: 1, max: 1, strict: true, call: abs},
+	"round":            {min: 1, max: 2, strict: true, call: round},
+	"floor":            {min: 1, max: 1, strict: true, call: floatFunc(math.Floor)},
+	"ceil":             {min: 1, max: 1, strict: true, call: floatFunc(math.Ceil)},
+	"ceiling":          {min: 1, max: 1, strict: true, call: floatFunc(math.Ceil)},
+	"mod": {min: 2, max: 2, strict: true, call: func(args []interface{}) (interface{}, error) {
+		return arithmetic(sqlast.Modulus, args[0], args[1])
+	}},
+	"coalesce": {min: 1, max: -1, call: func(args []interface{}) (interface{}, error) {
+		for _, a := range args {
+			if a != nil {
+				return a, nil
+			}
+		}
+		return nil, nil
+	}},
+	"nullif": {min: 2, max: 2, call: func(args []interface{}) (interface{}, error) {
+		if args[0] == nil || args[1] == nil {
+			return args[0], nil
+		}
+		c, err := compare(args[0], args[1])
+		if err != nil || c == 0 {
+			return nil, err
+		}
+		return args[0], nil
+	}},
+	"concat": {min: 1, max: -1, call: func(args []interface{}) (interface{}, error) {
+		var b strings.Builder
+		for _, a := range args {
+			if a != nil {
+				b.WriteString(toString(a))
+			}
+		}
+		return b.String(), nil
+	}},
+	"greatest": {min: 1, max: -1, call: extremum(1)},
+	"least":    {min: 1, max: -1, call: extremum(-1)},
+}
+
+func evalFunction(e *sqlast.Function, env Env) (interface{}, error) {
+	name := strings.ToLower(e.Name.Idents[len(e.Name.Idents)-1].Value)
+	f, ok := functions[name]
+	if !ok || len(e.WithinGroup) != 0 || e.Filter != nil || e.Over != nil || e.OverName != nil {
+		return nil, errors.Errorf("unsupported function: %s", e.ToSQLString())
+	}
+	if len(e.Args) < f.min || (f.max >= 0 && len(e.Args) > f.max) {
+		return nil, errors.Errorf("wrong number of arguments: %s", e.ToSQLString())
+	}
+	args := make([]interface{}, len(e.Args))
+	for i, a := range e.Args {
+		v, err := eval(a, env)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil && f.strict {
+			return nil, nil
+		}
+		args[i] = v
+	}
+	v, err := f.call(args)
+	if err != nil {
+		return nil, errors.Errorf("%s failed: %w", name, err)
+	}
+	return v, nil
+}
+
+func stringFunc(f func(string) string) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, errors.Errorf("not string: %v", args[0])
+		}
+		return f(s), nil
+	}
+}
+
+func floatFunc(f func(float64) float64) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		switch v := args[0].(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return f(v), nil
+		}
+		return nil, errors.Errorf("not number: %v", args[0])
+	}
+}
+
+func length(args []interface{}) (interface{}, error) {
+	s, ok := args[0].(string)
+	if !ok {
+		return nil, errors.Errorf("not string: %v", args[0])
+	}
+	return int64(len([]rune(s))), nil
+}
+
+func abs(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case int64:
+		if v == math.MinInt64 {
+			return nil, errors.New("integer out of range")
+		}
+		if v < 0 {
+			return -v, nil
+		}
+		return v, nil
+	case float64:
+		return math.Abs(v), nil
+	}
+	return nil, errors.Errorf("not number: %v", args[0])
+}
+
+func round(args []interface{}) (interface{}, error) {
+	var digits int64
+	if len(args) == 2 {
+		d, ok := args[1].(int64)
+		if !ok {
+			return nil, errors.Errorf("not integer: %v", args[1])
+		}
+		digits = d
+	}
+	switch v := args[0].(type) {
+	case int64:
+		return v, nil
+	case float64:
+		p := math.Pow(10, float64(digits))
+		return math.Round(v*p) / p, nil
+	}
+	return nil, errors.Errorf("not number: %v", args[0])
+}
+
+// extremum returns a function returning the greatest argument if sign is 1
+// or the least one if -1, ignoring NULL.
+func extremum(sign int) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		var result interface{}
+		for _, a := range args {
+			if a == nil {
+				continue
+			}
+			if result == nil {
+				result = a
+				continue
+			}
+			c, err := compare(a, result)
+			if err != nil {
+				return nil, err
+			}
+			if c*sign > 0 {
+				result = a
+			}
+		}
+		return result, nil
+	}
+}
+
+func evalSubstring(e *sqlast.Substring, env Env) (interface{}, error) {
+	v, err := eval(e.Expr, env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return nil, errors.Errorf("substring of non string: %v", v)
+	}
+	r := []rune(s)
+
+	// start and end are 1-origin and may be out of r as in SQL
+	start, end := int64(1), int64(len(r))+1
+	if e.Start != nil {
+		n, err := evalInt(e.Start, env)
+		if err != nil || n == nil {
+			return nil, err
+		}
+		start = *n
+	}
+	if e.Length != nil {
+		n, err := evalInt(e.Length, env)
+		if err != nil || n == nil {
+			return nil, err
+		}
+		if *n < 0 {
+			return nil, errors.New("negative substring length not allowed")
+		}
+		end = start + *n
+	}
+	if start < 1 {
+		start = 1
+	}
+	if end > int64(len(r))+1 {
+		end = int64(len(r)) + 1
+	}
+	if start >= end {
+		return "", nil
+	}
+	return string(r[start-1 : end-1]), nil
+}
+
+func evalInt(n sqlast.Node, env Env) (*int64, error) {
+	v, err := eval(n, env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	i, ok := v.(int64)
+	if !ok {
+		return nil, errors.Errorf("%s is not integer: %v", n.ToSQLString(), v)
+	}
+	return &i, nil
+}
+
+func evalTrim(e *sqlast.Trim, env Env) (interface{}, error) {
+	args := []sqlast.Node{e.Expr}
+	if e.Characters != nil {
+		args = append(args, e.Characters)
+	}
+	values, err := evalStrings(args, env)
+	if err != nil || values == nil {
+		return nil, err
+	}
+	cutset := " "
+	if len(values) == 2 {
+		cutset = values[1]
+	}
+	switch e.Where {
+	case sqlast.TrimLeading:
+		return strings.TrimLeft(values[0], cutset), nil
+	case sqlast.TrimTrailing:
+		return strings.TrimRight(values[0], cutset), nil
+	}
+	return strings.Trim(values[0], cutset), nil
+}
@@ -0,0 +1,276 @@
+// Package fkgraph builds the dependency graph of tables by foreign keys from
+// CREATE TABLE statements, e.g. to order loading or truncating tables so that
+// referenced tables are loaded first and truncated last.
+package fkgraph
+
+import (
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// Graph is the tables and the foreign keys among them.
+type Graph struct {
+	Tables      []*sqlast.CreateTableStmt
+	ForeignKeys []*ForeignKey
+}
+
+// ForeignKey is an edge of Graph from a referencing table to a referenced
+// one.
+type ForeignKey struct {
+	Name      *sqlast.Ident // the name of the constraint, nil if unnamed
+	From      *sqlast.CreateTableStmt
+	Columns   []*sqlast.Ident
+	To        *sqlast.CreateTableStmt // nil if the referenced table is not in the graph
+	ToName    *sqlast.ObjectName      // the referenced table as written
+	ToColumns []*sqlast.Ident         // empty if the primary key is referenced
+}
+
+// Self reports whether fk references the table defining it.
+func (fk *ForeignKey) Self() bool {
+	return fk.To != nil && fk.To == fk.From
+}
+
+// Build returns the graph of the tables defined by stmts. CREATE TABLE
+// replaces the table of the same name, ALTER TABLE adds foreign keys by ADD
+// CONSTRAINT and ADD COLUMN, and DROP TABLE removes the table. Other
+// statements are ignored.
+//
+// Referenced tables are matched case-insensitively by their names, and an
+// unqualified name matches a qualified one if it is unique.
+func Build(stmts ...sqlast.Stmt) *Graph {
+	g := &Graph{}
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *sqlast.CreateTableStmt:
+			g.drop(s.Name)
+			g.Tables = append(g.Tables, s)
+			for _, e := range s.Elements {
+				switch e := e.(type) {
+				case *sqlast.ColumnDef:
+					g.addColumn(s, e)
+				case *sqlast.TableConstraint:
+					g.addConstraint(s, e)
+				}
+			}
+		case *sqlast.AlterTableStmt:
+			t := g.Table(s.TableName)
+			if t == nil {
+				continue
+			}
+			switch a := s.Action.(type) {
+			case *sqlast.AddColumnTableAction:
+				g.addColumn(t, a.Column)
+			case *sqlast.AddConstraintTableAction:
+				g.addConstraint(t, a.Constraint)
+			case *sqlast.DropConstraintTableAction:
+				g.removeForeignKeys(func(fk *ForeignKey) bool {
+					return fk.From == t && fk.Name != nil && strings.EqualFold(fk.Name.Value, a.Name.Value)
+				})
+			case *sqlast.RemoveColumnTableAction:
+				g.removeForeignKeys(func(fk *ForeignKey) bool {
+					if fk.From != t {
+						return false
+					}
+					for _, c := range fk.Columns {
+						if strings.EqualFold(c.Value, a.Name.Value) {
+							return true
+						}
+					}
+					return false
+				})
+			}
+		case *sqlast.DropTableStmt:
+			for _, n := range s.TableNames {
+				g.drop(n)
+			}
+		}
+	}
+
+	for _, fk := range g.ForeignKeys {
+		fk.To = g.Table(fk.ToName)
+	}
+	return g
+}
+
+func (g *Graph) addColumn(t *sqlast.CreateTableStmt, def *sqlast.ColumnDef) {
+	for _, cons := range def.Constraints {
+		if spec, ok := cons.Spec.(*sqlast.ReferencesColumnSpec); ok {
+			g.ForeignKeys = append(g.ForeignKeys, &ForeignKey{
+				Name:      cons.Name,
+				From:      t,
+				Columns:   []*sqlast.Ident{def.Name},
+				ToName:    spec.TableName,
+				ToColumns: spec.Columns,
+			})
+		}
+	}
+}
+
+func (g *Graph) addConstraint(t *sqlast.CreateTableStmt, cons *sqlast.TableConstraint) {
+	if spec, ok := cons.Spec.(*sqlast.ReferentialTableConstraint); ok {
+		g.ForeignKeys = append(g.ForeignKeys, &ForeignKey{
+			Name:      cons.Name,
+			From:      t,
+			Columns:   spec.Columns,
+			ToName:    &sqlast.ObjectName{Idents: []*sqlast.Ident{spec.KeyExpr.TableName}},
+			ToColumns: spec.KeyExpr.Columns,
+		})
+	}
+}
+
+func (g *Graph) drop(name *sqlast.ObjectName) {
+	t := g.Table(name)
+	if t == nil {
+		return
+	}
+	for i, u := range g.Tables {
+		if u == t {
+			g.Tables = append(g.Tables[:i:i], g.Tables[i+1:]...)
+			break
+		}
+	}
+	g.removeForeignKeys(func(fk *ForeignKey) bool { return fk.From == t })
+}
+
+func (g *Graph) removeForeignKeys(f func(*ForeignKey) bool) {
+	fks := g.ForeignKeys[:0:0]
+	for _, fk := range g.ForeignKeys {
+		if !f(fk) {
+			fks = append(fks, fk)
+		}
+	}
+	g.ForeignKeys = fks
+}
+
+// Table returns the table named name, or nil if g has no such table.
+func (g *Graph) Table(name *sqlast.ObjectName) *sqlast.CreateTableStmt {
+	key := tableKey(name)
+	for _, t := range g.Tables {
+		if tableKey(t.Name) == key {
+			return t
+		}
+	}
+
+	last := strings.ToLower(name.Idents[len(name.Idents)-1].Value)
+	var found *sqlast.CreateTableStmt
+	for _, t := range g.Tables {
+		if len(t.Name.Idents) != 1 && len(name.Idents) != 1 {
+			continue
+		}
+		if strings.ToLower(t.Name.Idents[len(t.Name.Idents)-1].Value) != last {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = t
+	}
+	return found
+}
+
+// References returns the foreign keys of t, i.e. the edges from t.
+func (g *Graph) References(t *sqlast.CreateTableStmt) []*ForeignKey {
+	var fks []*ForeignKey
+	for _, fk := range g.ForeignKeys {
+		if fk.From == t {
+			fks = append(fks, fk)
+		}
+	}
+	return fks
+}
+
+// ReferencedBy returns the foreign keys referencing t, i.e. the edges to t.
+func (g *Graph) ReferencedBy(t *sqlast.CreateTableStmt) []*ForeignKey {
+	var fks []*ForeignKey
+	for _, fk := range g.ForeignKeys {
+		if fk.To == t {
+			fks = append(fks, fk)
+		}
+	}
+	return fks
+}
+
+// Sort returns the tables of g in topological order, where referenced tables
+// precede the tables referencing them, e.g. the order of loading data. The
+// reverse is the order of truncating or dropping them. Tables are kept in the
+// order of g.Tables as long as possible, and self references are ignored.
+//
+// If the foreign keys make cycles, Sort returns the tables which can be
+// ordered followed by the others in the order of g.Tables, and a *CycleError.
+func (g *Graph) Sort() ([]*sqlast.CreateTableStmt, error) {
+	// the tables each table references
+	deps := make(map[*sqlast.CreateTableStmt]map[*sqlast.CreateTableStmt]bool)
+	for _, fk := range g.ForeignKeys {
+		if fk.To == nil || fk.Self() {
+			continue
+		}
+		if deps[fk.From] == nil {
+			deps[fk.From] = make(map[*sqlast.CreateTableStmt]bool)
+		}
+		deps[fk.From][fk.To] = true
+	}
+
+	var sorted []*sqlast.CreateTableStmt
+	done := make(map[*sqlast.CreateTableStmt]bool)
+	for len(sorted) < len(g.Tables) {
+		progressed := false
+		for _, t := range g.Tables {
+			if done[t] || !ready(deps[t], done) {
+				continue
+			}
+			sorted = append(sorted, t)
+			done[t] = true
+			progressed = true
+			// restart to keep the order of g.Tables
+			break
+		}
+		if !progressed {
+			break
+		}
+	}
+	if len(sorted) == len(g.Tables) {
+		return sorted, nil
+	}
+
+	err := &CycleError{}
+	for _, t := range g.Tables {
+		if !done[t] {
+			sorted = append(sorted, t)
+			err.Tables = append(err.Tables, t.Name)
+		}
+	}
+	return sorted, err
+}
+
+func ready(deps, done map[*sqlast.CreateTableStmt]bool) bool {
+	for d := range deps {
+		if !done[d] {
+			return false
+		}
+	}
+	return true
+}
+
+// CycleError is returned by Graph.Sort if the foreign keys make cycles.
+type CycleError struct {
+	// Tables are the tables which can't be ordered, i.e. the ones in cycles
+	// and the ones referencing them.
+	Tables []*sqlast.ObjectName
+}
+
+func (e *CycleError) Error() string {
+	names := make([]string, 0, len(e.Tables))
+	for _, n := range e.Tables {
+		names = append(names, n.ToSQLString())
+	}
+	return "foreign keys make cycles among " + strings.Join(names, ", ")
+}
+
+func tableKey(name *sqlast.ObjectName) string {
+	parts := make([]string, 0, len(name.Idents))
+	for _, i := range name.Idents {
+		parts = append(parts, strings.ToLower(i.Value))
+	}
+	return strings.Join(parts, ".")
+}
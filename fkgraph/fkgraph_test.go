@@ -0,0 +1,182 @@
+package fkgraph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestGraph(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		sorted []string
+		edges  []string
+		cycle  []string
+	}{
+		{
+			name: "sort",
+			src: `
+CREATE TABLE items (id int PRIMARY KEY, order_id int REFERENCES orders(id), product_id int REFERENCES public.products(id));
+CREATE TABLE orders (id int PRIMARY KEY, user_id int, CONSTRAINT orders_user FOREIGN KEY(user_id) REFERENCES Users(id));
+CREATE TABLE public.products (id int PRIMARY KEY, parent_id int REFERENCES products(id));
+CREATE TABLE users (id int PRIMARY KEY, country text REFERENCES countries(code));
+`,
+			sorted: []string{"public.products", "users", "orders", "items"},
+			edges: []string{
+				"items(order_id) -> orders(id)",
+				"items(product_id) -> public.products(id)",
+				"orders_user: orders(user_id) -> users(id)",
+				"public.products(parent_id) -> public.products(id)",
+				"users(country) -> <nil>(code)",
+			},
+		},
+		{
+			name: "alter",
+			src: `
+CREATE TABLE a (id int, b_id int);
+CREATE TABLE b (id int, c_id int REFERENCES c(id));
+CREATE TABLE c (id int);
+ALTER TABLE a ADD CONSTRAINT a_b FOREIGN KEY(b_id) REFERENCES b(id);
+ALTER TABLE c ADD COLUMN a_id int REFERENCES a(id);
+ALTER TABLE c DROP COLUMN a_id;
+ALTER TABLE b ADD CONSTRAINT b_a FOREIGN KEY(id) REFERENCES a(id);
+ALTER TABLE b DROP CONSTRAINT b_a;
+DROP TABLE c;
+`,
+			sorted: []string{"b", "a"},
+			edges: []string{
+				"b(c_id) -> <nil>(id)",
+				"a_b: a(b_id) -> b(id)",
+			},
+		},
+		{
+			name: "cycle",
+			src: `
+CREATE TABLE a (id int, b_id int REFERENCES b(id));
+CREATE TABLE b (id int, a_id int REFERENCES a(id));
+CREATE TABLE c (id int, a_id int REFERENCES a(id));
+CREATE TABLE d (id int);
+`,
+			sorted: []string{"d", "a", "b", "c"},
+			edges: []string{
+				"a(b_id) -> b(id)",
+				"b(a_id) -> a(id)",
+				"c(a_id) -> a(id)",
+			},
+			cycle: []string{"a", "b", "c"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmts, err := parser.ParseSQL()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			g := Build(stmts...)
+
+			sorted, err := g.Sort()
+			var cycle []string
+			if err != nil {
+				var cerr *CycleError
+				if !errors.As(err, &cerr) {
+					t.Fatalf("%+v", err)
+				}
+				cycle = names(cerr.Tables...)
+			}
+			if diff := cmp.Diff(c.cycle, cycle); diff != "" {
+				t.Errorf("cycle diff: %s", diff)
+			}
+			var sortedNames []string
+			for _, t := range sorted {
+				sortedNames = append(sortedNames, t.Name.ToSQLString())
+			}
+			if diff := cmp.Diff(c.sorted, sortedNames); diff != "" {
+				t.Errorf("sorted diff: %s", diff)
+			}
+
+			var edges []string
+			for _, fk := range g.ForeignKeys {
+				var e string
+				if fk.Name != nil {
+					e = fk.Name.Value + ": "
+				}
+				to := "<nil>"
+				if fk.To != nil {
+					to = fk.To.Name.ToSQLString()
+				}
+				e += fk.From.Name.ToSQLString() + "(" + join(fk.Columns) + ") -> " + to + "(" + join(fk.ToColumns) + ")"
+				edges = append(edges, e)
+			}
+			if diff := cmp.Diff(c.edges, edges); diff != "" {
+				t.Errorf("edges diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGraph_References(t *testing.T) {
+	parser, err := xsqlparser.NewParser(bytes.NewBufferString(`
+CREATE TABLE users (id int PRIMARY KEY);
+CREATE TABLE orders (id int PRIMARY KEY, user_id int REFERENCES users(id));
+CREATE TABLE reviews (id int PRIMARY KEY, user_id int REFERENCES users(id), order_id int REFERENCES orders(id));
+`), &dialect.PostgresqlDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	g := Build(stmts...)
+	users := g.Table(sqlast.NewObjectName("USERS"))
+	reviews := g.Table(sqlast.NewObjectName("public", "reviews"))
+	if users == nil || reviews == nil {
+		t.Fatal("users and reviews must be found")
+	}
+
+	var from []string
+	for _, fk := range g.ReferencedBy(users) {
+		from = append(from, fk.From.Name.ToSQLString())
+	}
+	if diff := cmp.Diff([]string{"orders", "reviews"}, from); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	var to []string
+	for _, fk := range g.References(reviews) {
+		to = append(to, fk.To.Name.ToSQLString())
+	}
+	if diff := cmp.Diff([]string{"users", "orders"}, to); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
+
+func names(names ...*sqlast.ObjectName) []string {
+	var strs []string
+	for _, n := range names {
+		strs = append(strs, n.ToSQLString())
+	}
+	return strs
+}
+
+func join(idents []*sqlast.Ident) string {
+	var s string
+	for i, id := range idents {
+		if i > 0 {
+			s += ", "
+		}
+		s += id.Value
+	}
+	return s
+}
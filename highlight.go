@@ -0,0 +1,173 @@
+package xsqlparser
+
+import (
+	"io"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// TokenClass is the semantic class of a token for syntax highlighting.
+type TokenClass int
+
+const (
+	KeywordClass TokenClass = iota
+	// IdentifierClass is a name of a table, a column, a function and so on,
+	// including keywords used as names.
+	IdentifierClass
+	StringClass
+	NumberClass
+	OperatorClass
+	// PunctuationClass is a comma, a period, a semicolon or a bracket.
+	PunctuationClass
+	CommentClass
+	// ParameterClass is a placeholder such as `?`, `$1` and `:name`.
+	ParameterClass
+)
+
+func (c TokenClass) String() string {
+	switch c {
+	case KeywordClass:
+		return "keyword"
+	case IdentifierClass:
+		return "identifier"
+	case StringClass:
+		return "string"
+	case NumberClass:
+		return "number"
+	case OperatorClass:
+		return "operator"
+	case PunctuationClass:
+		return "punctuation"
+	case CommentClass:
+		return "comment"
+	case ParameterClass:
+		return "parameter"
+	}
+	return ""
+}
+
+// HighlightToken is a token classified by Highlight.
+type HighlightToken struct {
+	Class       TokenClass
+	Offset, End int          // byte range in the source
+	From, To    sqltoken.Pos // positions of Offset and End
+}
+
+// Highlight returns the tokens of src except whitespaces with their classes,
+// so that web UIs can highlight SQL without another lexer.
+//
+// Words are classified by parsing src: words parsed as names are identifiers
+// and the others are keywords. Words in statements which fail to parse are
+// keywords if they are in dialect.Keywords and not quoted. If src fails to
+// tokenize, e.g: a string literal isn't closed, the tokens before the error
+// are returned with the error. A placeholder such as `$1` is a single
+// parameter token even though it consists of several tokens.
+func Highlight(src string, dialect dialect.Dialect) ([]*HighlightToken, error) {
+	tokenizer := sqltoken.NewTokenizer(strings.NewReader(src), dialect)
+	var tokens []*sqltoken.Token
+	var err error
+	for {
+		tok, e := tokenizer.NextToken()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			err = errors.Errorf("tokenize failed: %w", e)
+			break
+		}
+		tokens = append(tokens, tok)
+	}
+
+	// positions of the names in the parsed statements
+	idents := make(map[sqltoken.Pos]bool)
+	// start and end positions of the placeholders in the parsed statements
+	params := make(map[sqltoken.Pos]sqltoken.Pos)
+	var parsed []*DocumentStmt
+	for _, s := range NewDocument(src, dialect).Stmts() {
+		if s.Stmt == nil {
+			continue
+		}
+		parsed = append(parsed, s)
+		sqlast.Inspect(s.Stmt, func(node sqlast.Node) bool {
+			switch n := node.(type) {
+			case *sqlast.Ident:
+				if !n.Quoted && strings.HasPrefix(n.Value, ":") {
+					params[n.From] = n.To
+				} else {
+					idents[n.From] = true
+				}
+			case *sqlast.Placeholder:
+				params[n.From] = n.To
+			}
+			return true
+		})
+	}
+
+	var highlighted []*HighlightToken
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.Kind == sqltoken.Whitespace {
+			continue
+		}
+		for len(parsed) != 0 && parsed[0].End <= tok.Offset {
+			parsed = parsed[1:]
+		}
+		inParsed := len(parsed) != 0 && parsed[0].Offset <= tok.Offset
+		h := &HighlightToken{
+			Class:  classify(tok, inParsed, idents),
+			Offset: tok.Offset,
+			End:    tok.EndOffset(),
+			From:   tok.From,
+			To:     tok.To,
+		}
+		to, ok := params[tok.From]
+		if !ok && !inParsed && tok.Kind == sqltoken.Char && tok.Value.(string) == "$" &&
+			i+1 < len(tokens) && tokens[i+1].Kind == sqltoken.Number && tokens[i+1].From == tok.To {
+			to, ok = tokens[i+1].To, true
+		}
+		if ok {
+			h.Class = ParameterClass
+			for h.To != to && i+1 < len(tokens) {
+				i++
+				h.End, h.To = tokens[i].EndOffset(), tokens[i].To
+			}
+		}
+		highlighted = append(highlighted, h)
+	}
+	return highlighted, err
+}
+
+func classify(tok *sqltoken.Token, inParsed bool, idents map[sqltoken.Pos]bool) TokenClass {
+	switch tok.Kind {
+	case sqltoken.SQLKeyword:
+		word := tok.Value.(*sqltoken.SQLWord)
+		if inParsed {
+			if idents[tok.From] {
+				return IdentifierClass
+			}
+			return KeywordClass
+		}
+		if _, ok := dialect.Keywords[word.Keyword]; ok && word.QuoteStyle == 0 {
+			return KeywordClass
+		}
+		return IdentifierClass
+	case sqltoken.SingleQuotedString, sqltoken.NationalStringLiteral, sqltoken.DollarQuotedString,
+		sqltoken.HexStringLiteral, sqltoken.BitStringLiteral, sqltoken.EscapedStringLiteral,
+		sqltoken.UnicodeStringLiteral:
+		return StringClass
+	case sqltoken.Number, sqltoken.HexNumber:
+		return NumberClass
+	case sqltoken.Comma, sqltoken.Period, sqltoken.Semicolon, sqltoken.Backslash,
+		sqltoken.LParen, sqltoken.RParen, sqltoken.LBracket, sqltoken.RBracket, sqltoken.LBrace, sqltoken.RBrace:
+		return PunctuationClass
+	case sqltoken.Comment:
+		return CommentClass
+	default:
+		return OperatorClass
+	}
+}
@@ -0,0 +1,88 @@
+package xsqlparser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestHighlight(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		expect []string
+	}{
+		{
+			name: "query",
+			src:  "SELECT count(*), \"date\" FROM t -- c\nWHERE name = 'x' AND n >= 1.5;",
+			expect: []string{
+				"keyword SELECT", "identifier count", "punctuation (", "operator *", "punctuation )", "punctuation ,",
+				`identifier "date"`, "keyword FROM", "identifier t", "comment -- c",
+				"keyword WHERE", "identifier name", "operator =", "string 'x'", "keyword AND", "identifier n",
+				"operator >=", "number 1.5", "punctuation ;",
+			},
+		},
+		{
+			name: "keyword as a name",
+			src:  "CREATE TABLE t (date DATE, value int NOT NULL)",
+			expect: []string{
+				"keyword CREATE", "keyword TABLE", "identifier t", "punctuation (", "identifier date", "keyword DATE",
+				"punctuation ,", "identifier value", "keyword int", "keyword NOT", "keyword NULL", "punctuation )",
+			},
+		},
+		{
+			name: "placeholders",
+			src:  "SELECT * FROM t WHERE a = $1 AND b = ? AND c = :name",
+			expect: []string{
+				"keyword SELECT", "operator *", "keyword FROM", "identifier t", "keyword WHERE",
+				"identifier a", "operator =", "parameter $1", "keyword AND", "identifier b", "operator =", "parameter ?",
+				"keyword AND", "identifier c", "operator =", "parameter :name",
+			},
+		},
+		{
+			name: "placeholder in a syntax error",
+			src:  "SELECT * FROM WHERE a = $12",
+			expect: []string{
+				"keyword SELECT", "operator *", "keyword FROM", "keyword WHERE", "identifier a", "operator =",
+				"parameter $12",
+			},
+		},
+		{
+			name: "syntax error",
+			src:  "SELECT 1; SELECT * FROM WHERE x",
+			expect: []string{
+				"keyword SELECT", "number 1", "punctuation ;",
+				"keyword SELECT", "operator *", "keyword FROM", "keyword WHERE", "identifier x",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tokens, err := Highlight(c.src, &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			var actual []string
+			for _, tok := range tokens {
+				actual = append(actual, fmt.Sprintf("%s %s", tok.Class, c.src[tok.Offset:tok.End]))
+			}
+			if diff := cmp.Diff(c.expect, actual); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+
+	t.Run("tokenize error", func(t *testing.T) {
+		tokens, err := Highlight("SELECT 'x", &dialect.GenericSQLDialect{})
+		if err == nil {
+			t.Error("must be error")
+		}
+		if len(tokens) != 1 || tokens[0].Class != KeywordClass {
+			t.Errorf("must be SELECT but %+v", tokens)
+		}
+	})
+}
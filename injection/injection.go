@@ -0,0 +1,470 @@
+// Package injection detects constructs typical of SQL injection attacks in
+// queries, e.g: `OR 1=1` appended to a condition, for web application
+// firewalls and audit logs.
+package injection
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// Kind is the kind of a Finding.
+type Kind int
+
+const (
+	// Tautology is an always true predicate widening a condition, e.g:
+	// `OR 1=1`, `OR 'a'='a'` or `WHERE 1=1` as a whole.
+	Tautology Kind = iota
+	// StackedStatement is a statement following a string literal and a
+	// semicolon, e.g: `'x'; DROP TABLE t`.
+	StackedStatement
+	// CommentTruncation is a comment at the end of a line of SQL, which cuts
+	// off the rest of the query, e.g: `'admin'--`.
+	CommentTruncation
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Tautology:
+		return "tautology"
+	case StackedStatement:
+		return "stacked-statement"
+	case CommentTruncation:
+		return "comment-truncation"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Finding is a suspicious construct found by Detect.
+type Finding struct {
+	Kind     Kind
+	Message  string
+	Node     sqlast.Node // nil for findings on tokens, or if the statement fails to parse
+	Pos, End sqltoken.Pos
+}
+
+func (f *Finding) Error() string {
+	return fmt.Sprintf("%d:%d: %s (%s)", f.Pos.Line, f.Pos.Col, f.Message, f.Kind)
+}
+
+// Detect parses src and returns the findings in order of position.
+//
+// Statements which fail to parse are checked only on their tokens, and the
+// source which fails to tokenize, e.g. of an unbalanced quote, is checked on
+// the tokens read before the error. The error of the first broken statement
+// is returned with the findings, since an injected query is often broken.
+func Detect(src string, d dialect.Dialect) ([]*Finding, error) {
+	var findings []*Finding
+	var err error
+	stmts := xsqlparser.NewDocument(src, d).Stmts()
+	for i, s := range stmts {
+		if s.Err != nil && err == nil {
+			err = s.Err
+		}
+		if s.Tokens == nil && s.Err != nil {
+			var prev []*sqltoken.Token
+			if i > 0 {
+				prev = stmts[i-1].Tokens
+			}
+			findings = append(findings, lexical(src[s.Offset:s.End], s.From, prev, d)...)
+			continue
+		}
+		tokens := significant(s.Tokens)
+		if len(tokens) == 0 {
+			continue
+		}
+		if i > 0 && endsWithString(stmts[i-1].Tokens) {
+			f := &Finding{
+				Kind:    StackedStatement,
+				Message: "statement stacked after a string literal",
+				Node:    s.Stmt,
+				Pos:     tokens[0].From,
+				End:     tokens[len(tokens)-1].To,
+			}
+			findings = append(findings, f)
+		}
+		if f := truncation(s.Tokens); f != nil {
+			findings = append(findings, f)
+		}
+		if s.Stmt != nil {
+			findings = append(findings, tautologies(s.Stmt)...)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return sqltoken.ComparePos(findings[i].Pos, findings[j].Pos) < 0
+	})
+	return findings, err
+}
+
+// lexical checks the tokens of src, which starts at from and fails to
+// tokenize, until the error. prev is the tokens of the statement before src.
+func lexical(src string, from sqltoken.Pos, prev []*sqltoken.Token, d dialect.Dialect) []*Finding {
+	tokenizer := sqltoken.NewTokenizer(strings.NewReader(src), d)
+	tokenizer.Line, tokenizer.Col = from.Line, from.Col
+
+	var findings []*Finding
+	var stmt []*sqltoken.Token
+	flush := func() {
+		tokens := significant(stmt)
+		if len(tokens) == 0 {
+			return
+		}
+		if prev != nil && endsWithString(prev) {
+			findings = append(findings, &Finding{
+				Kind:    StackedStatement,
+				Message: "statement stacked after a string literal",
+				Pos:     tokens[0].From,
+				End:     tokens[len(tokens)-1].To,
+			})
+		}
+		if f := truncation(stmt); f != nil {
+			findings = append(findings, f)
+		}
+		findings = append(findings, lexicalTautologies(tokens)...)
+		prev, stmt = stmt, nil
+	}
+	for {
+		tok, err := tokenizer.NextToken()
+		if err != nil {
+			break
+		}
+		stmt = append(stmt, tok)
+		if tok.Kind == sqltoken.Semicolon {
+			flush()
+		}
+	}
+	flush()
+	return findings
+}
+
+// lexicalTautologies returns the always true conditions following OR, WHERE
+// or HAVING in tokens, which are a constant or a comparison of constants.
+func lexicalTautologies(tokens []*sqltoken.Token) []*Finding {
+	var findings []*Finding
+	for i, tok := range tokens {
+		if w, ok := tok.Value.(*sqltoken.SQLWord); !ok || tok.Kind != sqltoken.SQLKeyword ||
+			(w.Keyword != "OR" && w.Keyword != "WHERE" && w.Keyword != "HAVING") {
+			continue
+		}
+		cond := tokens[i+1:]
+		n, ok := lexicalCondition(cond)
+		if !ok {
+			continue
+		}
+		var s []string
+		for _, t := range cond[:n] {
+			s = append(s, t.Raw)
+		}
+		findings = append(findings, &Finding{
+			Kind:    Tautology,
+			Message: fmt.Sprintf("always true condition %s", strings.Join(s, " ")),
+			Pos:     cond[0].From,
+			End:     cond[n-1].To,
+		})
+	}
+	return findings
+}
+
+// lexicalCondition returns the number of the tokens at the head of tokens
+// which make an always true condition.
+func lexicalCondition(tokens []*sqltoken.Token) (int, bool) {
+	// the condition must be followed by the end, a keyword or a parenthesis
+	bounded := func(n int) bool {
+		return len(tokens) == n || tokens[n].Kind == sqltoken.SQLKeyword || tokens[n].Kind == sqltoken.RParen
+	}
+	if len(tokens) == 0 {
+		return 0, false
+	}
+	l, ok := tokenConstant(tokens[0])
+	if !ok {
+		return 0, false
+	}
+	if len(tokens) >= 3 {
+		if op, ok := comparison(tokens[1]); ok {
+			if r, ok := tokenConstant(tokens[2]); ok && bounded(3) && compare(op, l, r) {
+				return 3, true
+			}
+			return 0, false
+		}
+	}
+	switch v := l.(type) {
+	case bool:
+		return 1, v && bounded(1)
+	case float64:
+		return 1, v != 0 && bounded(1)
+	}
+	return 0, false
+}
+
+// tokenConstant returns the value of a literal token as a float64, a string
+// or a bool.
+func tokenConstant(tok *sqltoken.Token) (interface{}, bool) {
+	switch tok.Kind {
+	case sqltoken.Number:
+		f, err := strconv.ParseFloat(tok.Value.(string), 64)
+		return f, err == nil
+	case sqltoken.SingleQuotedString, sqltoken.NationalStringLiteral:
+		return tok.Value.(string), true
+	case sqltoken.SQLKeyword:
+		switch tok.Value.(*sqltoken.SQLWord).Keyword {
+		case "TRUE":
+			return true, true
+		case "FALSE":
+			return false, true
+		}
+	}
+	return nil, false
+}
+
+// comparison returns the operator of a comparison token.
+func comparison(tok *sqltoken.Token) (sqlast.OperatorType, bool) {
+	switch tok.Kind {
+	case sqltoken.Eq:
+		return sqlast.Eq, true
+	case sqltoken.Neq:
+		return sqlast.NotEq, true
+	case sqltoken.Gt:
+		return sqlast.Gt, true
+	case sqltoken.Lt:
+		return sqlast.Lt, true
+	case sqltoken.GtEq:
+		return sqlast.GtEq, true
+	case sqltoken.LtEq:
+		return sqlast.LtEq, true
+	case sqltoken.SQLKeyword:
+		if tok.Value.(*sqltoken.SQLWord).Keyword == "LIKE" {
+			return sqlast.Like, true
+		}
+	}
+	return 0, false
+}
+
+// significant returns tokens except whitespaces, comments and semicolons.
+func significant(tokens []*sqltoken.Token) []*sqltoken.Token {
+	var s []*sqltoken.Token
+	for _, tok := range tokens {
+		switch tok.Kind {
+		case sqltoken.Whitespace, sqltoken.Comment, sqltoken.Semicolon:
+			continue
+		}
+		s = append(s, tok)
+	}
+	return s
+}
+
+// endsWithString reports whether the statement of tokens ends with a string
+// literal followed by a semicolon.
+func endsWithString(tokens []*sqltoken.Token) bool {
+	var last []*sqltoken.Token
+	for _, tok := range tokens {
+		if tok.Kind != sqltoken.Whitespace && tok.Kind != sqltoken.Comment {
+			last = append(last, tok)
+		}
+	}
+	n := len(last)
+	if n < 2 || last[n-1].Kind != sqltoken.Semicolon {
+		return false
+	}
+	switch last[n-2].Kind {
+	case sqltoken.SingleQuotedString, sqltoken.NationalStringLiteral, sqltoken.EscapedStringLiteral,
+		sqltoken.UnicodeStringLiteral, sqltoken.DollarQuotedString, sqltoken.HexStringLiteral, sqltoken.BitStringLiteral:
+		return true
+	}
+	return false
+}
+
+// truncation reports the last token of the statement if it's a comment
+// following a token in the same line.
+func truncation(tokens []*sqltoken.Token) *Finding {
+	var comment, prev *sqltoken.Token
+	for i := len(tokens) - 1; i >= 0; i-- {
+		tok := tokens[i]
+		if tok.Kind == sqltoken.Whitespace {
+			continue
+		}
+		if comment == nil {
+			if tok.Kind != sqltoken.Comment {
+				return nil
+			}
+			comment = tok
+			continue
+		}
+		prev = tok
+		break
+	}
+	if prev == nil || prev.Kind == sqltoken.Comment || prev.To.Line != comment.From.Line {
+		return nil
+	}
+	return &Finding{
+		Kind:    CommentTruncation,
+		Message: "comment cuts off the rest of the query",
+		Pos:     comment.From,
+		End:     comment.To,
+	}
+}
+
+// tautologies returns the always true operands of OR, and the always true
+// conditions of WHERE and HAVING in stmt.
+func tautologies(stmt sqlast.Stmt) []*Finding {
+	var findings []*Finding
+	report := func(node sqlast.Node) {
+		findings = append(findings, &Finding{
+			Kind:    Tautology,
+			Message: fmt.Sprintf("always true condition %s", node.ToSQLString()),
+			Node:    node,
+			Pos:     node.Pos(),
+			End:     node.End(),
+		})
+	}
+	check := func(cond sqlast.Node) {
+		if cond != nil && alwaysTrue(cond) {
+			report(cond)
+		}
+	}
+
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		switch n := node.(type) {
+		case *sqlast.BinaryExpr:
+			if n.Op.Type == sqlast.Or {
+				check(n.Left)
+				check(n.Right)
+			}
+		case *sqlast.SQLSelect:
+			check(n.WhereClause)
+			check(n.HavingClause)
+		case *sqlast.UpdateStmt:
+			check(n.Selection)
+		case *sqlast.DeleteStmt:
+			check(n.Selection)
+		}
+		return true
+	})
+	return findings
+}
+
+// alwaysTrue reports whether cond is true regardless of the rows: a true
+// constant, a comparison of constants, a comparison of a column to itself or
+// LIKE '%'.
+func alwaysTrue(cond sqlast.Node) bool {
+	switch c := cond.(type) {
+	case *sqlast.Nested:
+		return alwaysTrue(c.AST)
+	case *sqlast.BooleanValue:
+		return c.Boolean
+	case *sqlast.LongValue:
+		// true in MySQL
+		return c.Long != 0
+	case *sqlast.BinaryExpr:
+		l, lok := constant(c.Left)
+		r, rok := constant(c.Right)
+		if lok && rok {
+			return compare(c.Op.Type, l, r)
+		}
+		// `col = col` isn't reported since it is NULL for NULL rows
+		return c.Op.Type == sqlast.Like && rok && r == "%"
+	}
+	return false
+}
+
+// constant returns the value of a literal as a float64, a string or a bool.
+func constant(node sqlast.Node) (interface{}, bool) {
+	switch v := node.(type) {
+	case *sqlast.Nested:
+		return constant(v.AST)
+	case *sqlast.LongValue:
+		return float64(v.Long), true
+	case *sqlast.DoubleValue:
+		return v.Double, true
+	case *sqlast.NumericValue:
+		f, err := strconv.ParseFloat(v.Numeric, 64)
+		return f, err == nil
+	case *sqlast.SingleQuotedString:
+		return v.String, true
+	case *sqlast.NationalStringLiteral:
+		return v.String, true
+	case *sqlast.BooleanValue:
+		return v.Boolean, true
+	}
+	return nil, false
+}
+
+// compare evaluates `l op r`. A string compared to a number is converted to
+// a number as MySQL does, e.g: '1' = 1.
+func compare(op sqlast.OperatorType, l, r interface{}) bool {
+	if s, ok := l.(string); ok {
+		if _, ok := r.(float64); ok {
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				l = f
+			}
+		}
+	}
+	if s, ok := r.(string); ok {
+		if _, ok := l.(float64); ok {
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				r = f
+			}
+		}
+	}
+
+	var cmp int
+	switch lv := l.(type) {
+	case float64:
+		rv, ok := r.(float64)
+		if !ok {
+			return false
+		}
+		if lv < rv {
+			cmp = -1
+		} else if lv > rv {
+			cmp = 1
+		}
+	case string:
+		rv, ok := r.(string)
+		if !ok {
+			return false
+		}
+		if lv < rv {
+			cmp = -1
+		} else if lv > rv {
+			cmp = 1
+		}
+	case bool:
+		rv, ok := r.(bool)
+		if !ok {
+			return false
+		}
+		// false < true
+		if !lv && rv {
+			cmp = -1
+		} else if lv && !rv {
+			cmp = 1
+		}
+	}
+
+	switch op {
+	case sqlast.Eq:
+		return cmp == 0
+	case sqlast.NotEq:
+		return cmp != 0
+	case sqlast.Gt:
+		return cmp > 0
+	case sqlast.Lt:
+		return cmp < 0
+	case sqlast.GtEq:
+		return cmp >= 0
+	case sqlast.LtEq:
+		return cmp <= 0
+	case sqlast.Like:
+		rv, ok := r.(string)
+		return ok && rv == "%"
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package injection
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		expect []string
+	}{
+		{
+			name: "tautology",
+			src:  "SELECT * FROM users WHERE name = 'x' OR 'a' = 'a' OR (2 > 1) OR id = id OR '1' = 1 OR name LIKE '%'",
+			expect: []string{
+				"1:41: always true condition 'a' = 'a' (tautology)",
+				"1:54: always true condition (2 > 1) (tautology)",
+				"1:76: always true condition '1' = 1 (tautology)",
+				"1:87: always true condition name LIKE '%' (tautology)",
+			},
+		},
+		{
+			name: "boolean comparison",
+			src:  "SELECT * FROM t WHERE a = 1 OR FALSE < TRUE OR TRUE < FALSE OR TRUE >= FALSE",
+			expect: []string{
+				"1:32: always true condition false < true (tautology)",
+				"1:64: always true condition true >= false (tautology)",
+			},
+		},
+		{
+			name: "whole condition",
+			src:  "DELETE FROM users WHERE 1 = 1; UPDATE users SET a = 1 WHERE TRUE; SELECT * FROM t WHERE 1 = 1 AND id = 2",
+			expect: []string{
+				"1:25: always true condition 1 = 1 (tautology)",
+				"1:61: always true condition true (tautology)",
+			},
+		},
+		{
+			name: "stacked statement",
+			src:  "SELECT * FROM users WHERE name = 'x'; DROP TABLE users; SELECT 1",
+			expect: []string{
+				"1:39: statement stacked after a string literal (stacked-statement)",
+			},
+		},
+		{
+			name: "comment truncation",
+			src:  "SELECT * FROM users WHERE name = 'admin' --' AND password = 'x'\n",
+			expect: []string{
+				"1:42: comment cuts off the rest of the query (comment-truncation)",
+			},
+		},
+		{
+			name:   "comment in its own line",
+			src:    "SELECT * FROM users WHERE name = 'admin'\n-- by name\n",
+			expect: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			findings, err := Detect(c.src, &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			var actual []string
+			for _, f := range findings {
+				actual = append(actual, f.Error())
+			}
+			if diff := cmp.Diff(c.expect, actual); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+
+	t.Run("syntax error", func(t *testing.T) {
+		findings, err := Detect("SELECT * FROM users WHERE id = ''; DROP TABLE users --'", &dialect.MySQLDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if len(findings) != 2 || findings[0].Kind != StackedStatement || findings[1].Kind != CommentTruncation {
+			t.Errorf("unexpected findings %v", findings)
+		}
+
+		_, err = Detect("SELECT * FROM users WHERE id = 1 OR", &dialect.GenericSQLDialect{})
+		if err == nil {
+			t.Error("must be error")
+		}
+	})
+
+	t.Run("unbalanced quote", func(t *testing.T) {
+		findings, err := Detect("SELECT * FROM users WHERE name = '' OR 1=1 AND pass = '''", &dialect.MySQLDialect{})
+		if err == nil {
+			t.Error("must be error")
+		}
+		var actual []string
+		for _, f := range findings {
+			actual = append(actual, f.Error())
+		}
+		if diff := cmp.Diff([]string{"1:40: always true condition 1 = 1 (tautology)"}, actual); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	})
+
+	t.Run("hash comment of mysql", func(t *testing.T) {
+		findings, err := Detect("SELECT * FROM users WHERE name = '' OR 'a'='a' #' AND pass = 'x'", &dialect.MySQLDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		var actual []string
+		for _, f := range findings {
+			actual = append(actual, f.Error())
+		}
+		expect := []string{
+			"1:40: always true condition 'a' = 'a' (tautology)",
+			"1:48: comment cuts off the rest of the query (comment-truncation)",
+		}
+		if diff := cmp.Diff(expect, actual); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	})
+}
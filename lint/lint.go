@@ -0,0 +1,126 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// Severity is the severity level of a Diagnostic.
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Diagnostic is a problem reported by a Rule.
+type Diagnostic struct {
+	Rule     string
+	Severity Severity
+	Message  string
+	Node     sqlast.Node
+	Pos, End sqltoken.Pos
+}
+
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("%d:%d: %s: %s (%s)", d.Pos.Line, d.Pos.Col, d.Severity, d.Message, d.Rule)
+}
+
+// ReportFunc reports a problem of node.
+type ReportFunc func(node sqlast.Node, format string, args ...interface{})
+
+// Rule checks a statement and reports problems.
+type Rule interface {
+	// Name returns the name of the rule, e.g. "select-star".
+	Name() string
+	// Severity returns the default severity of the problems.
+	Severity() Severity
+	// Check checks stmt, including its subqueries, and reports problems by report.
+	Check(stmt sqlast.Stmt, report ReportFunc)
+}
+
+// Linter checks statements with Rules.
+type Linter struct {
+	Rules []Rule
+	// Severities overrides the default severity of the rules by name.
+	Severities map[string]Severity
+}
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		&SelectStar{},
+		&ImplicitCrossJoin{},
+		&MissingWhere{},
+		&NonSargable{},
+	}
+}
+
+// New returns a Linter with rules, or the built-in rules if rules is empty.
+func New(rules ...Rule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	return &Linter{Rules: rules, Severities: make(map[string]Severity)}
+}
+
+// Lint checks stmts and returns the problems in order of position.
+func (l *Linter) Lint(stmts ...sqlast.Stmt) []*Diagnostic {
+	var diagnostics []*Diagnostic
+	for _, stmt := range stmts {
+		for _, rule := range l.Rules {
+			severity := rule.Severity()
+			if s, ok := l.Severities[rule.Name()]; ok {
+				severity = s
+			}
+			rule.Check(stmt, func(node sqlast.Node, format string, args ...interface{}) {
+				diagnostics = append(diagnostics, &Diagnostic{
+					Rule:     rule.Name(),
+					Severity: severity,
+					Message:  fmt.Sprintf(format, args...),
+					Node:     node,
+					Pos:      node.Pos(),
+					End:      node.End(),
+				})
+			})
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return sqltoken.ComparePos(diagnostics[i].Pos, diagnostics[j].Pos) < 0
+	})
+	return diagnostics
+}
+
+// LintSQL parses src and checks the statements.
+func (l *Linter) LintSQL(src io.Reader, d dialect.Dialect) ([]*Diagnostic, error) {
+	parser, err := xsqlparser.NewParser(src, d)
+	if err != nil {
+		return nil, errors.Errorf("NewParser failed: %w", err)
+	}
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		return nil, errors.Errorf("ParseSQL failed: %w", err)
+	}
+	return l.Lint(stmts...), nil
+}
@@ -0,0 +1,101 @@
+package lint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestLinter_Lint(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		expect []string
+	}{
+		{
+			name: "select star",
+			src:  "SELECT *, t.* FROM t WHERE EXISTS (SELECT * FROM u) AND a IN (SELECT * FROM v)",
+			expect: []string{
+				"1:8: warning: avoid *; list the columns explicitly (select-star)",
+				"1:11: warning: avoid t.*; list the columns explicitly (select-star)",
+				"1:70: warning: avoid *; list the columns explicitly (select-star)",
+			},
+		},
+		{
+			name: "implicit cross join",
+			src:  "SELECT a FROM t, u AS x, unnest(t.arr) AS y, LATERAL (SELECT 1) AS z",
+			expect: []string{
+				"1:18: warning: implicit cross join with u AS x; use an explicit JOIN (implicit-cross-join)",
+			},
+		},
+		{
+			name: "missing where",
+			src:  "DELETE FROM t; UPDATE t SET a = 1; DELETE FROM t WHERE a = 1",
+			expect: []string{
+				"1:1: error: DELETE without WHERE deletes all rows of t (missing-where)",
+				"1:16: error: UPDATE without WHERE updates all rows of t (missing-where)",
+			},
+		},
+		{
+			name: "non-sargable",
+			src:  "SELECT a FROM t JOIN u ON lower(t.b) = lower(u.b) WHERE a + 1 = 2 AND c = lower('X') AND d LIKE '%x' AND e LIKE 'x%' AND f(g) BETWEEN 1 AND 2",
+			expect: []string{
+				"1:27: warning: expression on column t.b can't use indexes (non-sargable)",
+				"1:57: warning: expression on column a can't use indexes (non-sargable)",
+				"1:90: warning: LIKE pattern starting with a wildcard can't use indexes (non-sargable)",
+				"1:122: warning: expression on column g can't use indexes (non-sargable)",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diagnostics, err := New().LintSQL(bytes.NewBufferString(c.src), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			var actual []string
+			for _, d := range diagnostics {
+				actual = append(actual, d.Error())
+			}
+			if diff := cmp.Diff(c.expect, actual); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+type noLimit struct{}
+
+func (*noLimit) Name() string       { return "no-limit" }
+func (*noLimit) Severity() Severity { return Info }
+func (*noLimit) Check(stmt sqlast.Stmt, report ReportFunc) {
+	if q, ok := stmt.(*sqlast.QueryStmt); ok && q.Limit == nil {
+		report(q, "query without LIMIT")
+	}
+}
+
+func TestLinter_CustomRule(t *testing.T) {
+	l := New(&noLimit{}, &SelectStar{})
+	l.Severities["select-star"] = Error
+
+	diagnostics, err := l.LintSQL(bytes.NewBufferString("SELECT * FROM t"), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var actual []string
+	for _, d := range diagnostics {
+		actual = append(actual, d.Error())
+	}
+	expect := []string{
+		"1:1: info: query without LIMIT (no-limit)",
+		"1:8: error: avoid *; list the columns explicitly (select-star)",
+	}
+	if diff := cmp.Diff(expect, actual); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
@@ -0,0 +1,224 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// SelectStar reports `*` and `t.*` in select lists, except in EXISTS subqueries.
+type SelectStar struct{}
+
+func (*SelectStar) Name() string {
+	return "select-star"
+}
+
+func (*SelectStar) Severity() Severity {
+	return Warning
+}
+
+func (*SelectStar) Check(stmt sqlast.Stmt, report ReportFunc) {
+	exists := make(map[*sqlast.SQLSelect]bool)
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		switch n := node.(type) {
+		case *sqlast.Exists:
+			// the select list of EXISTS is not used
+			if s, ok := n.Query.Body.(*sqlast.SQLSelect); ok {
+				exists[s] = true
+			}
+		case *sqlast.SQLSelect:
+			if exists[n] {
+				return true
+			}
+			for _, item := range n.Projection {
+				if isWildcard(item) {
+					report(item, "avoid %s; list the columns explicitly", item.ToSQLString())
+				}
+			}
+		}
+		return true
+	})
+}
+
+func isWildcard(item sqlast.SQLSelectItem) bool {
+	switch i := item.(type) {
+	case *sqlast.WildcardSelectItem, *sqlast.QualifiedWildcardSelectItem:
+		return true
+	case *sqlast.UnnamedSelectItem:
+		_, ok := i.Node.(*sqlast.Wildcard)
+		return ok
+	}
+	return false
+}
+
+// ImplicitCrossJoin reports tables joined by commas in FROM clauses, which
+// are cross joins unless they are joined in WHERE. Table functions and
+// LATERAL subqueries are not reported as they usually refer to the preceding tables.
+type ImplicitCrossJoin struct{}
+
+func (*ImplicitCrossJoin) Name() string {
+	return "implicit-cross-join"
+}
+
+func (*ImplicitCrossJoin) Severity() Severity {
+	return Warning
+}
+
+func (*ImplicitCrossJoin) Check(stmt sqlast.Stmt, report ReportFunc) {
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		s, ok := node.(*sqlast.SQLSelect)
+		if !ok || len(s.FromClause) < 2 {
+			return true
+		}
+		for _, ref := range s.FromClause[1:] {
+			switch r := ref.(type) {
+			case *sqlast.TableFunction, *sqlast.Unnest:
+				continue
+			case *sqlast.Derived:
+				if r.Lateral {
+					continue
+				}
+			}
+			report(ref, "implicit cross join with %s; use an explicit JOIN", ref.ToSQLString())
+		}
+		return true
+	})
+}
+
+// MissingWhere reports DELETE and UPDATE statements without WHERE clause,
+// which modify every row of the table.
+type MissingWhere struct{}
+
+func (*MissingWhere) Name() string {
+	return "missing-where"
+}
+
+func (*MissingWhere) Severity() Severity {
+	return Error
+}
+
+func (*MissingWhere) Check(stmt sqlast.Stmt, report ReportFunc) {
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		switch n := node.(type) {
+		case *sqlast.DeleteStmt:
+			if n.Selection == nil {
+				report(n, "DELETE without WHERE deletes all rows of %s", n.TableName.ToSQLString())
+			}
+		case *sqlast.UpdateStmt:
+			if n.Selection == nil {
+				report(n, "UPDATE without WHERE updates all rows of %s", n.TableName.ToSQLString())
+			}
+		}
+		return true
+	})
+}
+
+// NonSargable reports predicates in WHERE and JOIN conditions which can't
+// use indexes on the columns: comparisons of expressions of columns (e.g.
+// `lower(name) = 'x'` or `id + 1 = 2`) and LIKE patterns starting with a wildcard.
+type NonSargable struct{}
+
+func (*NonSargable) Name() string {
+	return "non-sargable"
+}
+
+func (*NonSargable) Severity() Severity {
+	return Warning
+}
+
+func (r *NonSargable) Check(stmt sqlast.Stmt, report ReportFunc) {
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		switch n := node.(type) {
+		case *sqlast.SQLSelect:
+			r.condition(n.WhereClause, report)
+		case *sqlast.JoinCondition:
+			r.condition(n.SearchCondition, report)
+		case *sqlast.UpdateStmt:
+			r.condition(n.Selection, report)
+		case *sqlast.DeleteStmt:
+			r.condition(n.Selection, report)
+		}
+		return true
+	})
+}
+
+func (r *NonSargable) condition(cond sqlast.Node, report ReportFunc) {
+	if cond == nil {
+		return
+	}
+	sqlast.Inspect(cond, func(node sqlast.Node) bool {
+		switch n := node.(type) {
+		case *sqlast.QueryStmt:
+			// checked as a statement
+			return false
+		case *sqlast.BinaryExpr:
+			switch n.Op.Type {
+			case sqlast.Eq, sqlast.Lt, sqlast.Gt, sqlast.LtEq, sqlast.GtEq:
+				if isColumn(n.Left) || isColumn(n.Right) {
+					return true
+				}
+				for _, side := range []sqlast.Node{n.Left, n.Right} {
+					if c := findColumn(side); c != nil {
+						report(side, "expression on column %s can't use indexes", c.ToSQLString())
+						break
+					}
+				}
+			case sqlast.Like, sqlast.ILike:
+				if isColumn(n.Left) && hasLeadingWildcard(n.Right) {
+					report(n, "LIKE pattern starting with a wildcard can't use indexes")
+				}
+			}
+		case *sqlast.Between:
+			r.operand(n.Expr, report)
+		case *sqlast.InList:
+			r.operand(n.Expr, report)
+		case *sqlast.InSubQuery:
+			r.operand(n.Expr, report)
+		}
+		return true
+	})
+}
+
+// operand reports expr, the operand of BETWEEN or IN, if it is an expression of a column.
+func (r *NonSargable) operand(expr sqlast.Node, report ReportFunc) {
+	if isColumn(expr) {
+		return
+	}
+	if c := findColumn(expr); c != nil {
+		report(expr, "expression on column %s can't use indexes", c.ToSQLString())
+	}
+}
+
+func isColumn(node sqlast.Node) bool {
+	switch n := node.(type) {
+	case *sqlast.Ident, *sqlast.CompoundIdent:
+		return true
+	case *sqlast.Nested:
+		return isColumn(n.AST)
+	}
+	return false
+}
+
+// findColumn returns the first column reference in node outside subqueries.
+func findColumn(node sqlast.Node) sqlast.Node {
+	var found sqlast.Node
+	sqlast.Inspect(node, func(n sqlast.Node) bool {
+		if found != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *sqlast.QueryStmt, *sqlast.ObjectName:
+			return false
+		case *sqlast.Ident, *sqlast.CompoundIdent:
+			found = n
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+func hasLeadingWildcard(pattern sqlast.Node) bool {
+	s, ok := pattern.(*sqlast.SingleQuotedString)
+	return ok && (strings.HasPrefix(s.String, "%") || strings.HasPrefix(s.String, "_"))
+}
@@ -0,0 +1,153 @@
+// Package migrate generates forward and rollback migrations of a schema diff
+// computed by the schemadiff package in the SQL of a dialect.
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/schemadiff"
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/translate"
+)
+
+// Migration is the statements migrating a schema and rolling it back.
+type Migration struct {
+	Up   []string
+	Down []string
+}
+
+// String returns the statements of m terminated by semicolons in `-- +up`
+// and `-- +down` sections.
+func (m *Migration) String() string {
+	var b strings.Builder
+	b.WriteString("-- +up\n")
+	for _, s := range m.Up {
+		b.WriteString(s + ";\n")
+	}
+	b.WriteString("\n-- +down\n")
+	for _, s := range m.Down {
+		b.WriteString(s + ";\n")
+	}
+	return b.String()
+}
+
+// Generate returns the migration applying d and the one rolling it back in
+// the SQL of the dialect to. The statements are those of Diff.Stmts
+// translated by the translate package. For MySQL, changes of a column are
+// applied by a MODIFY COLUMN with the whole definition of the column, and
+// indexes are dropped by DROP INDEX ... ON.
+//
+// Note that rolling back dropped tables and columns restores their
+// definitions but not their data.
+func Generate(d *schemadiff.Diff, to dialect.Dialect) (*Migration, error) {
+	up, err := statements(d, to)
+	if err != nil {
+		return nil, errors.Errorf("up migration failed: %w", err)
+	}
+	down, err := statements(d.Reverse(), to)
+	if err != nil {
+		return nil, errors.Errorf("down migration failed: %w", err)
+	}
+	return &Migration{Up: up, Down: down}, nil
+}
+
+func statements(d *schemadiff.Diff, to dialect.Dialect) ([]string, error) {
+	g := &generator{translator: translate.New(to)}
+	if _, ok := to.(*dialect.MySQLDialect); ok {
+		g.mysql = true
+		g.indexTables = make(map[string]*sqlast.ObjectName)
+		for _, idx := range d.DroppedIndexes {
+			if idx.IndexName != nil {
+				g.indexTables[strings.ToLower(idx.IndexName.Value)] = idx.TableName
+			}
+		}
+		g.columns = make(map[*sqlast.Ident]*sqlast.ColumnDef)
+		for _, t := range d.ChangedTables {
+			for _, c := range t.ChangedColumns {
+				g.columns[c.To.Name] = c.To
+			}
+		}
+		g.modified = make(map[*sqlast.ColumnDef]bool)
+	}
+
+	var sqls []string
+	for _, stmt := range d.Stmts() {
+		sql, err := g.stmt(stmt)
+		if err != nil {
+			return nil, err
+		}
+		if sql != "" {
+			sqls = append(sqls, sql)
+		}
+	}
+	return sqls, nil
+}
+
+type generator struct {
+	translator  *translate.Translator
+	mysql       bool
+	indexTables map[string]*sqlast.ObjectName       // tables of the dropped indexes by lower-cased name
+	columns     map[*sqlast.Ident]*sqlast.ColumnDef // changed columns by name
+	modified    map[*sqlast.ColumnDef]bool          // columns already modified by MODIFY COLUMN
+}
+
+// stmt returns the SQL of stmt, or an empty string if it's merged into
+// another statement.
+func (g *generator) stmt(stmt sqlast.Stmt) (string, error) {
+	if g.mysql {
+		switch s := stmt.(type) {
+		case *sqlast.DropIndexStmt:
+			table := g.indexTables[strings.ToLower(s.IndexNames[0].Value)]
+			return g.format("%s ON %s", s, table)
+		case *sqlast.AlterTableStmt:
+			if a, ok := s.Action.(*sqlast.AlterColumnTableAction); ok {
+				c := g.columns[a.ColumnName]
+				if g.modified[c] {
+					return "", nil
+				}
+				g.modified[c] = true
+				return g.format("ALTER TABLE %s MODIFY COLUMN %s", s.TableName, modifiedColumn(c))
+			}
+		}
+	}
+	return g.format("%s", stmt)
+}
+
+// modifiedColumn returns a copy of c for MODIFY COLUMN. The key and
+// reference constraints are removed since the table already has them, and
+// MySQL rejects a second primary key or adds a duplicated index.
+func modifiedColumn(c *sqlast.ColumnDef) *sqlast.ColumnDef {
+	clone := sqlast.Clone(c).(*sqlast.ColumnDef)
+	notNull := clone.NotNull()
+	var constraints []*sqlast.ColumnConstraint
+	for _, cons := range clone.Constraints {
+		switch cons.Spec.(type) {
+		case *sqlast.UniqueColumnSpec, *sqlast.ReferencesColumnSpec:
+			continue
+		}
+		constraints = append(constraints, cons)
+	}
+	clone.Constraints = constraints
+	// a primary key column stays NOT NULL
+	if notNull && !clone.NotNull() {
+		clone.Constraints = append(clone.Constraints, &sqlast.ColumnConstraint{Spec: &sqlast.NotNullColumnSpec{}})
+	}
+	return clone
+}
+
+// format formats the SQL of nodes translated to the dialect.
+func (g *generator) format(format string, nodes ...sqlast.Node) (string, error) {
+	args := make([]interface{}, 0, len(nodes))
+	for _, n := range nodes {
+		translated, err := g.translator.Translate(sqlast.Clone(n))
+		if err != nil {
+			return "", errors.Errorf("translate %s failed: %w", n.ToSQLString(), err)
+		}
+		args = append(args, translated.ToSQLString())
+	}
+	return fmt.Sprintf(format, args...), nil
+}
@@ -0,0 +1,124 @@
+package migrate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/schemadiff"
+)
+
+func parse(t *testing.T, src string) *schemadiff.Schema {
+	t.Helper()
+	parser, err := xsqlparser.NewParser(bytes.NewBufferString(src), &dialect.PostgresqlDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return schemadiff.NewSchema(stmts...)
+}
+
+func TestGenerate(t *testing.T) {
+	from := parse(t, `CREATE TABLE "users" (id int, name varchar(10), age int);
+CREATE INDEX users_name ON users (name);
+CREATE TABLE logs (id int);`)
+	to := parse(t, `CREATE TABLE "users" (id int, name varchar(100) NOT NULL DEFAULT 'x', email text);
+CREATE TABLE posts (id int);`)
+	d := schemadiff.Compare(from, to)
+
+	cases := []struct {
+		name    string
+		dialect dialect.Dialect
+		expect  *Migration
+	}{
+		{
+			name:    "postgres",
+			dialect: &dialect.PostgresqlDialect{},
+			expect: &Migration{
+				Up: []string{
+					"DROP INDEX users_name",
+					"CREATE TABLE posts (id int)",
+					`ALTER TABLE "users" DROP COLUMN age`,
+					`ALTER TABLE "users" ADD COLUMN email text`,
+					`ALTER TABLE "users" ALTER COLUMN name TYPE character varying(100)`,
+					`ALTER TABLE "users" ALTER COLUMN name SET NOT NULL`,
+					`ALTER TABLE "users" ALTER COLUMN name SET DEFAULT 'x'`,
+					"DROP TABLE logs",
+				},
+				Down: []string{
+					"CREATE TABLE logs (id int)",
+					`ALTER TABLE "users" DROP COLUMN email`,
+					`ALTER TABLE "users" ADD COLUMN age int`,
+					`ALTER TABLE "users" ALTER COLUMN name TYPE character varying(10)`,
+					`ALTER TABLE "users" ALTER COLUMN name DROP NOT NULL`,
+					`ALTER TABLE "users" ALTER COLUMN name DROP DEFAULT`,
+					"DROP TABLE posts",
+					"CREATE INDEX users_name ON users (name)",
+				},
+			},
+		},
+		{
+			name:    "mysql",
+			dialect: &dialect.MySQLDialect{},
+			expect: &Migration{
+				Up: []string{
+					"DROP INDEX users_name ON users",
+					"CREATE TABLE posts (id int)",
+					"ALTER TABLE `users` DROP COLUMN age",
+					"ALTER TABLE `users` ADD COLUMN email text",
+					"ALTER TABLE `users` MODIFY COLUMN name character varying(100) DEFAULT 'x' NOT NULL",
+					"DROP TABLE logs",
+				},
+				Down: []string{
+					"CREATE TABLE logs (id int)",
+					"ALTER TABLE `users` DROP COLUMN email",
+					"ALTER TABLE `users` ADD COLUMN age int",
+					"ALTER TABLE `users` MODIFY COLUMN name character varying(10)",
+					"DROP TABLE posts",
+					"CREATE INDEX users_name ON users (name)",
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m, err := Generate(d, c.dialect)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if diff := cmp.Diff(c.expect, m); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerate_KeyColumn(t *testing.T) {
+	from := parse(t, `CREATE TABLE t (id int PRIMARY KEY, code int UNIQUE REFERENCES c (id));`)
+	to := parse(t, `CREATE TABLE t (id bigint PRIMARY KEY, code bigint UNIQUE REFERENCES c (id));`)
+
+	m, err := Generate(schemadiff.Compare(from, to), &dialect.MySQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expect := &Migration{
+		Up: []string{
+			"ALTER TABLE t MODIFY COLUMN id bigint NOT NULL",
+			"ALTER TABLE t MODIFY COLUMN code bigint",
+		},
+		Down: []string{
+			"ALTER TABLE t MODIFY COLUMN id int NOT NULL",
+			"ALTER TABLE t MODIFY COLUMN code int",
+		},
+	}
+	if diff := cmp.Diff(expect, m); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
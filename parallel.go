@@ -0,0 +1,166 @@
+package xsqlparser
+
+import (
+	"sync"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// segment is a part of the tokens parsed by a forked parser.
+type segment struct {
+	parser *Parser
+	from   int
+	first  int // index of the first token of the statement, -1 if empty
+	stmts  []sqlast.Stmt
+	err    error
+}
+
+// parseSQLParallel is ParseSQL with Parallel. It splits the tokens at
+// top-level semicolons and parses each statement by a forked parser in
+// p.parallel goroutines.
+//
+// Statements which affect the following ones are parsed in order while
+// splitting: COPY, whose inline data isn't terminated by a semicolon, and
+// CREATE TYPE, which defines the types referred by the following statements.
+func (p *Parser) parseSQLParallel() ([]sqlast.Stmt, error) {
+	jobs := make(chan *segment)
+	var wg sync.WaitGroup
+	for i := 0; i < p.parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for s := range jobs {
+				s.stmts, s.err = s.parser.parseSQL(0)
+			}
+		}()
+	}
+
+	var segs []*segment
+	types := p.types // never modified after passed to forked parsers
+	from := int(p.index)
+	for from < len(p.tokens) {
+		first, to, ordered := p.scanStatement(from)
+		if first == to {
+			// make the forked parser fail on the empty statement like ParseSQL
+			to++
+		}
+		s := &segment{from: from, first: first}
+		segs = append(segs, s)
+
+		if ordered {
+			copied := make(map[string]*sqlast.CreateTypeStmt, len(types))
+			for k, v := range types {
+				copied[k] = v
+			}
+			s.parser = p.fork(p.tokens, from, copied)
+			s.stmts, s.err = s.parser.parseSQL(1)
+			if s.err != nil || len(s.stmts) == 0 {
+				break
+			}
+			types = s.parser.types
+			from = int(s.parser.index)
+			continue
+		}
+
+		s.parser = p.fork(p.tokens[:to], from, types)
+		jobs <- s
+		from = to
+	}
+	close(jobs)
+	wg.Wait()
+
+	var stmts []sqlast.Stmt
+	for _, s := range segs {
+		if p.maxStatements > 0 && len(stmts) == p.maxStatements && s.first >= 0 {
+			p.index = uint(s.first)
+			tok := p.tokens[s.first]
+			return nil, errors.Errorf("more than %d statements at %d:%d: %w", p.maxStatements, tok.From.Line, tok.From.Col, ErrLimitExceeded)
+		}
+		if s.err != nil {
+			p.index = s.parser.index
+			return nil, s.err
+		}
+		stmts = append(stmts, s.stmts...)
+		for pos, c := range s.parser.comments {
+			p.comments[pos] = c
+		}
+	}
+	p.types = types
+	p.index = uint(from)
+
+	return stmts, nil
+}
+
+// scanStatement scans the statement starting at tokens[from] after an
+// optional semicolon. It returns the index of the first token of the
+// statement or -1, the index of the semicolon terminating it or len(tokens),
+// and whether the statement must be parsed in order. The first token is the
+// terminating semicolon if the statement is empty.
+func (p *Parser) scanStatement(from int) (int, int, bool) {
+	i := from
+	for i < len(p.tokens) && (p.tokens[i].Kind == sqltoken.Whitespace || p.tokens[i].Kind == sqltoken.Comment) {
+		i++
+	}
+	if i < len(p.tokens) && p.tokens[i].Kind == sqltoken.Semicolon {
+		i++
+	}
+
+	first := -1
+	var prev string // keyword of the last token
+	var depth int
+	var ordered bool
+	for ; i < len(p.tokens); i++ {
+		tok := p.tokens[i]
+		switch tok.Kind {
+		case sqltoken.Whitespace, sqltoken.Comment:
+			continue
+		case sqltoken.LParen, sqltoken.LBracket, sqltoken.LBrace:
+			depth++
+		case sqltoken.RParen, sqltoken.RBracket, sqltoken.RBrace:
+			if depth > 0 {
+				depth--
+			}
+		case sqltoken.Semicolon:
+			if depth > 0 {
+				break
+			}
+			if first < 0 {
+				first = i
+			}
+			return first, i, ordered
+		}
+		if first < 0 {
+			first = i
+		}
+
+		var keyword string
+		if w, ok := tok.Value.(*sqltoken.SQLWord); ok && w.QuoteStyle == 0 {
+			keyword = w.Keyword
+		}
+		if keyword == "COPY" || (prev == "CREATE" && keyword == "TYPE") {
+			ordered = true
+		}
+		prev = keyword
+	}
+
+	return first, len(p.tokens), ordered
+}
+
+// fork returns a parser with the options of p, which parses tokens from
+// tokens[from]. types must not be modified while the forked parser is used.
+func (p *Parser) fork(tokens []*sqltoken.Token, from int, types map[string]*sqlast.CreateTypeStmt) *Parser {
+	sub := &Parser{
+		tokens:   tokens,
+		index:    uint(from),
+		dialect:  p.dialect,
+		types:    types,
+		maxDepth: p.maxDepth,
+	}
+	if p.parseComment {
+		ParseComment()(sub)
+	}
+	return sub
+}
@@ -3,10 +3,10 @@ package xsqlparser
 import (
 	"fmt"
 	"io"
-	"log"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 
 	errors "golang.org/x/xerrors"
 
@@ -20,6 +20,22 @@ type Parser struct {
 	index        uint
 	comments     map[sqltoken.Pos]*sqlast.CommentGroup
 	parseComment bool
+	types        map[string]*sqlast.CreateTypeStmt // types defined by CREATE TYPE, keyed by lower-cased name
+	dialect      dialect.Dialect
+	keepSource   bool
+	stmtSources  []*sqlast.StmtSource
+
+	maxDepth      int
+	maxTokens     int
+	maxStatements int
+	depth         int // current nesting depth of expressions, queries, table factors and types
+
+	poolTokens bool
+	arena      *sqltoken.TokenArena // tokens allocated from the pool, released by Release
+
+	parallel int // number of goroutines parsing statements in ParseSQL
+
+	completion *completion // candidates collected by ParseForCompletion
 }
 
 type ParserOption func(*Parser)
@@ -31,22 +47,139 @@ func ParseComment() ParserOption {
 	}
 }
 
-func NewParser(src io.Reader, dialect dialect.Dialect, opts ...ParserOption) (*Parser, error) {
-	tokenizer := sqltoken.NewTokenizer(src, dialect)
-	set, err := tokenizer.Tokenize()
-	if err != nil {
-		return nil, errors.Errorf("tokenize err failed: %w", err)
+// KeepSource makes ParseFile keep the source text in File.Source, so that
+// File.WriteSourceTo reproduces the source byte-identical except for modified statements.
+func KeepSource() ParserOption {
+	return func(p *Parser) {
+		p.keepSource = true
+	}
+}
+
+// MaxDepth limits the nesting depth of expressions, subqueries, joined
+// tables and types to n, so that deeply nested input can't exhaust the stack.
+// Parsing fails with ErrLimitExceeded beyond the limit.
+func MaxDepth(n int) ParserOption {
+	return func(p *Parser) {
+		p.maxDepth = n
+	}
+}
+
+// MaxTokens limits the number of tokens in the source including whitespace
+// and comments to n. NewParser stops tokenizing and fails with
+// ErrLimitExceeded beyond the limit.
+func MaxTokens(n int) ParserOption {
+	return func(p *Parser) {
+		p.maxTokens = n
 	}
+}
+
+// MaxStatements limits the number of statements parsed by ParseSQL and
+// ParseFile to n. They fail with ErrLimitExceeded beyond the limit.
+func MaxStatements(n int) ParserOption {
+	return func(p *Parser) {
+		p.maxStatements = n
+	}
+}
+
+// PoolTokens makes the parser allocate tokens from a pool shared by parsers.
+// Call Release after parsing to put them back, which saves allocations in
+// services parsing many queries. It's ignored with KeepSource, whose
+// File.Source refers to the tokens.
+func PoolTokens() ParserOption {
+	return func(p *Parser) {
+		p.poolTokens = true
+	}
+}
+
+// Parallel makes ParseSQL and ParseFile split the source into statements at
+// top-level semicolons and parse them in n goroutines, which speeds up
+// ingesting large scripts such as schema dumps. The statements are returned
+// in the source order. It's ignored with KeepSource.
+func Parallel(n int) ParserOption {
+	return func(p *Parser) {
+		p.parallel = n
+	}
+}
 
-	parser := &Parser{tokens: set, index: 0}
+func NewParser(src io.Reader, dialect dialect.Dialect, opts ...ParserOption) (*Parser, error) {
+	parser := &Parser{index: 0, dialect: dialect}
 
 	for _, o := range opts {
 		o(parser)
 	}
 
+	set, err := parser.tokenize(sqltoken.NewTokenizer(src, dialect))
+	if err != nil {
+		parser.Release()
+		return nil, errors.Errorf("tokenize err failed: %w", err)
+	}
+	parser.tokens = set
+
 	return parser, nil
 }
 
+func (p *Parser) tokenize(tokenizer *sqltoken.Tokenizer) ([]*sqltoken.Token, error) {
+	arena := &sqltoken.TokenArena{}
+	if p.poolTokens && !p.keepSource {
+		p.arena = sqltoken.NewTokenArena()
+		arena = p.arena
+	}
+	if p.maxTokens <= 0 {
+		return tokenizer.TokenizeIn(arena)
+	}
+
+	var set []*sqltoken.Token
+	var tok *sqltoken.Token
+	for {
+		if tok == nil {
+			tok = arena.New()
+		}
+		scanned, err := tokenizer.Scan(tok)
+		if err == io.EOF {
+			return set, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if scanned == nil {
+			continue
+		}
+		if len(set) == p.maxTokens {
+			return nil, errors.Errorf("more than %d tokens at %d:%d: %w", p.maxTokens, tok.From.Line, tok.From.Col, ErrLimitExceeded)
+		}
+		set = append(set, scanned)
+		tok = nil
+	}
+}
+
+// Release puts the tokens back to the pool with PoolTokens. The parser must
+// not be used after Release, while the parsed statements remain valid since
+// they don't refer to the tokens.
+func (p *Parser) Release() {
+	if p.arena != nil {
+		p.arena.Release()
+		p.arena = nil
+	}
+	p.tokens = nil
+	p.index = 0
+}
+
+// enter increases the nesting depth, which must be restored by leave.
+// It returns an error if the depth exceeds the limit set by MaxDepth.
+func (p *Parser) enter() error {
+	p.depth++
+	if p.maxDepth > 0 && p.depth > p.maxDepth {
+		p.depth--
+		pos := p.Pos()
+		return errors.Errorf("nesting deeper than %d at %d:%d: %w", p.maxDepth, pos.Line, pos.Col, ErrLimitExceeded)
+	}
+	return nil
+}
+
+func (p *Parser) leave() {
+	p.depth--
+}
+
 func NewParserWithOptions(opts ...ParserOption) *Parser {
 	parser := &Parser{index: 0}
 	for _, o := range opts {
@@ -55,6 +188,64 @@ func NewParserWithOptions(opts ...ParserOption) *Parser {
 	return parser
 }
 
+// ParseExpr parses src as a standalone expression such as a CHECK constraint
+// body or a filter condition. src must contain exactly one expression.
+func ParseExpr(src string, dialect dialect.Dialect) (sqlast.Node, error) {
+	p, err := NewParser(strings.NewReader(src), dialect)
+	if err != nil {
+		return nil, errors.Errorf("NewParser failed: %w", err)
+	}
+	expr, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+	if err := p.expectEOF(); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// ParseDataType parses src as a type name such as `numeric(10, 2)` or `varchar(255)[]`.
+func ParseDataType(src string, dialect dialect.Dialect) (sqlast.Type, error) {
+	p, err := NewParser(strings.NewReader(src), dialect)
+	if err != nil {
+		return nil, errors.Errorf("NewParser failed: %w", err)
+	}
+	tp, err := p.ParseDataType()
+	if err != nil {
+		return nil, errors.Errorf("ParseDataType failed: %w", err)
+	}
+	if err := p.expectEOF(); err != nil {
+		return nil, err
+	}
+	return tp, nil
+}
+
+// ParseObjectName parses src as a possibly qualified object name such as `public."Users"`.
+func ParseObjectName(src string, dialect dialect.Dialect) (_ *sqlast.ObjectName, err error) {
+	p, err := NewParser(strings.NewReader(src), dialect)
+	if err != nil {
+		return nil, errors.Errorf("NewParser failed: %w", err)
+	}
+	defer p.recoverBailout(&err)
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+	if err := p.expectEOF(); err != nil {
+		return nil, err
+	}
+	return name, nil
+}
+
+// expectEOF returns an error if any token other than whitespace and comments is left.
+func (p *Parser) expectEOF() error {
+	if tok, err := p.peekToken(); err != EOF {
+		return errors.Errorf("expected end of input but %+v", tok)
+	}
+	return nil
+}
+
 // workaround
 // FIXME: create appropriate parse function
 func (p *Parser) SetTokens(tokens []*sqltoken.Token) {
@@ -77,22 +268,48 @@ func (p *Parser) ParseFile() (*sqlast.File, error) {
 		return sqltoken.ComparePos(comments[i].Pos(), comments[j].Pos()) < 0
 	})
 
-	return &sqlast.File{
+	file := &sqlast.File{
 		Stmts:    stmts,
 		Comments: comments,
-	}, nil
+	}
+	if p.keepSource {
+		file.Source = &sqlast.Source{
+			Tokens: p.tokens,
+			Stmts:  p.stmtSources,
+		}
+	}
+
+	return file, nil
 }
 
 func (p *Parser) ParseSQL() ([]sqlast.Stmt, error) {
+	if p.parallel > 1 && !p.keepSource {
+		return p.parseSQLParallel()
+	}
+	return p.parseSQL(0)
+}
+
+// parseSQL parses statements separated by semicolons until EOF, or n
+// statements if n > 0. Then it stops before the semicolon following them.
+func (p *Parser) parseSQL(n int) ([]sqlast.Stmt, error) {
 	var stmts []sqlast.Stmt
 	var expectingDelimiter bool
 
 	for {
 		ok, _ := p.consumeToken(sqltoken.Semicolon)
 		if !ok && expectingDelimiter {
-			tok, _ := p.peekToken()
+			tok, err := p.peekToken()
+			if err == EOF {
+				break
+			}
 			return nil, errors.Errorf("expect semicolon but %+v", tok)
 		}
+		if n > 0 && len(stmts) == n {
+			if ok {
+				p.prevToken()
+			}
+			break
+		}
 
 		if p.parseComment {
 			_, err := p.nextTokenWithParseComment()
@@ -114,30 +331,64 @@ func (p *Parser) ParseSQL() ([]sqlast.Stmt, error) {
 			}
 		}
 
+		if p.maxStatements > 0 && len(stmts) == p.maxStatements {
+			tok, _ := p.peekToken()
+			return nil, errors.Errorf("more than %d statements at %d:%d: %w", p.maxStatements, tok.From.Line, tok.From.Col, ErrLimitExceeded)
+		}
+		from, _ := p.tilNonWhitespace()
 		stmt, err := p.ParseStatement()
 		if err != nil {
 			return nil, errors.Errorf("parseStatement failed: %w", err)
 		}
 		stmts = append(stmts, stmt)
-		expectingDelimiter = true
+		if p.keepSource {
+			p.addStmtSource(stmt, int(from))
+		}
+		// inline data of COPY statement is terminated by `\.` instead of semicolon
+		if c, ok := stmt.(*sqlast.CopyStmt); ok && c.InlineData {
+			expectingDelimiter = false
+		} else {
+			expectingDelimiter = true
+		}
 
 	}
 
 	return stmts, nil
 }
 
-func (p *Parser) ParseStatement() (sqlast.Stmt, error) {
+// addStmtSource records the tokens of stmt, which starts at tokens[from] and
+// ends at the last token consumed.
+func (p *Parser) addStmtSource(stmt sqlast.Stmt, from int) {
+	to := int(p.index)
+	for to > from && (p.tokens[to-1].Kind == sqltoken.Whitespace || p.tokens[to-1].Kind == sqltoken.Comment) {
+		to--
+	}
+	p.stmtSources = append(p.stmtSources, &sqlast.StmtSource{
+		Stmt: stmt,
+		From: from,
+		To:   to,
+		SQL:  stmt.ToSQLString(),
+	})
+}
+
+func (p *Parser) ParseStatement() (_ sqlast.Stmt, err error) {
+	defer p.recoverBailout(&err)
+	p.suggestStatement()
 	tok, err := p.nextToken()
 	if err != nil {
 		return nil, err
 	}
+	if tok.Kind == sqltoken.LParen {
+		p.prevToken()
+		return p.parseQuery()
+	}
 	word, ok := tok.Value.(*sqltoken.SQLWord)
 	if !ok {
 		return nil, errors.Errorf("a keyword at the beginning of statement %s", tok.Value)
 	}
 
 	switch word.Keyword {
-	case "SELECT", "WITH":
+	case "SELECT", "WITH", "VALUES":
 		p.prevToken()
 		return p.parseQuery()
 	case "CREATE":
@@ -158,6 +409,67 @@ func (p *Parser) ParseStatement() (sqlast.Stmt, error) {
 	case "DROP":
 		p.prevToken()
 		return p.parseDrop()
+	case "COPY":
+		p.prevToken()
+		return p.parseCopy()
+	case "UNLOAD":
+		if !p.redshift() {
+			return nil, errors.Errorf("UNLOAD is not supported in this dialect")
+		}
+		p.prevToken()
+		return p.parseUnload()
+	case "TRUNCATE":
+		p.prevToken()
+		return p.parseTruncate()
+	case "VACUUM":
+		return p.parseVacuum(tok)
+	case "ANALYZE", "ANALYSE":
+		return p.parseAnalyze(tok)
+	case "REINDEX":
+		return p.parseReindex(tok)
+	case "MERGE":
+		p.prevToken()
+		return p.parseMerge()
+	case "PREPARE":
+		p.prevToken()
+		return p.parsePrepare()
+	case "EXECUTE":
+		p.prevToken()
+		return p.parseExecute()
+	case "CALL":
+		p.prevToken()
+		return p.parseCall()
+	case "DEALLOCATE":
+		p.prevToken()
+		return p.parseDeallocate()
+	case "DECLARE":
+		p.prevToken()
+		return p.parseDeclareCursor()
+	case "FETCH":
+		p.prevToken()
+		return p.parseFetchCursor()
+	case "CLOSE":
+		p.prevToken()
+		return p.parseClose()
+	case "SET":
+		p.prevToken()
+		return p.parseSet()
+	case "SHOW":
+		p.prevToken()
+		return p.parseShow()
+	case "RESET":
+		p.prevToken()
+		return p.parseReset()
+	case "DESCRIBE", "DESC":
+		if !p.mySQLSyntax() {
+			return nil, errors.Errorf("%s is not supported in this dialect", word.Keyword)
+		}
+		return p.parseDescribe(tok)
+	case "USE":
+		if !p.mySQLSyntax() {
+			return nil, errors.Errorf("USE is not supported in this dialect")
+		}
+		return p.parseUse(tok)
 	case "EXPLAIN":
 		stmt, err := p.ParseStatement()
 		if err != nil {
@@ -169,7 +481,71 @@ func (p *Parser) ParseStatement() (sqlast.Stmt, error) {
 	}
 }
 
-func (p *Parser) ParseDataType() (sqlast.Type, error) {
+func (p *Parser) ParseDataType() (_ sqlast.Type, err error) {
+	defer p.recoverBailout(&err)
+	tp, err := p.parseDataType()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseArrayBounds(tp)
+}
+
+// parseArrayBounds parses the array bounds following the element type tp,
+// i.e: `[[Size]]` for each dimension, or `ARRAY[[Size]]` of the SQL standard
+// which is the same as `[[Size]]`, and returns tp nested in the array types
+// with the last dimension outermost.
+func (p *Parser) parseArrayBounds(tp sqlast.Type) (sqlast.Type, error) {
+	if ok, a, _ := p.parseKeyword("ARRAY"); ok {
+		if t, _ := p.peekToken(); t == nil || t.Kind != sqltoken.LBracket {
+			return &sqlast.Array{Ty: tp, RParen: a.To}, nil
+		}
+		arr, err := p.parseArrayBound(tp)
+		if err != nil {
+			return nil, errors.Errorf("parseArrayBound failed: %w", err)
+		}
+		return arr, nil
+	}
+
+	for {
+		if t, _ := p.peekToken(); t == nil || t.Kind != sqltoken.LBracket {
+			return tp, nil
+		}
+		arr, err := p.parseArrayBound(tp)
+		if err != nil {
+			return nil, errors.Errorf("parseArrayBound failed: %w", err)
+		}
+		tp = arr
+	}
+}
+
+// parseArrayBound parses `[[Size]]` of an array of tp.
+func (p *Parser) parseArrayBound(tp sqlast.Type) (*sqlast.Array, error) {
+	p.expectToken(sqltoken.LBracket)
+	var size *uint
+	if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.Number {
+		i, _, err := p.parseLiteralInt()
+		if err != nil {
+			return nil, errors.Errorf("invalid array size: %w", err)
+		}
+		size = sqlast.NewSize(uint(i))
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RBracket {
+		return nil, errors.Errorf("expected RBracket but %+v", r)
+	}
+	return &sqlast.Array{
+		Ty:     tp,
+		Size:   size,
+		RParen: r.To,
+	}, nil
+}
+
+func (p *Parser) parseDataType() (sqlast.Type, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	p.suggestKeywords(typeKeywords...)
 	tok, err := p.nextToken()
 	if err != nil {
 		return nil, errors.Errorf("nextToken failed: %w", err)
@@ -179,6 +555,23 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 		return nil, errors.Errorf("must be datatype name but %v", tok)
 	}
 
+	if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.Lt {
+		switch word.Keyword {
+		case "ARRAY", "MAP", "STRUCT":
+			return p.parseHiveComplexType(tok)
+		}
+	}
+
+	if p.clickHouse() {
+		switch word.Keyword {
+		case "NULLABLE", "LOWCARDINALITY":
+			return p.parseClickHouseWrapperType(tok)
+		case "STRING":
+			// type names are case sensitive in ClickHouse, so String is kept as it is
+			return &sqlast.Custom{Ty: &sqlast.ObjectName{Idents: []*sqlast.Ident{newIdent(tok)}}}, nil
+		}
+	}
+
 	switch word.Keyword {
 	case "BOOLEAN":
 		return &sqlast.Boolean{
@@ -191,22 +584,35 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 			return nil, errors.Errorf("parsePrecision failed: %w", err)
 		}
 		unsigned, pos := p.parseMyUnsigned()
-		return &sqlast.Float{Size: size, From: tok.From, To: tok.To, RParen: r, IsUnsigned: unsigned, Unsigned: pos}, nil
+		zerofill, zpos := p.parseMyZerofill()
+		return &sqlast.Float{Size: size, From: tok.From, To: tok.To, RParen: r, IsUnsigned: unsigned, Unsigned: pos, IsZerofill: zerofill, Zerofill: zpos}, nil
 	case "REAL":
 		unsigned, pos := p.parseMyUnsigned()
-		return &sqlast.Real{From: tok.From, To: tok.To, IsUnsigned: unsigned, Unsigned: pos}, nil
+		zerofill, zpos := p.parseMyZerofill()
+		return &sqlast.Real{From: tok.From, To: tok.To, IsUnsigned: unsigned, Unsigned: pos, IsZerofill: zerofill, Zerofill: zpos}, nil
 	case "DOUBLE":
 		p := p.expectKeyword("PRECISION")
 		return &sqlast.Double{From: tok.From, To: p.To}, nil
-	case "SMALLINT":
-		unsigned, pos := p.parseMyUnsigned()
-		return &sqlast.SmallInt{From: tok.From, To: tok.To, IsUnsigned: unsigned, Unsigned: pos}, nil
-	case "INTEGER", "INT":
+	case "SMALLINT", "INTEGER", "INT", "BIGINT":
+		width, r, err := p.parseMyDisplayWidth()
+		if err != nil {
+			return nil, errors.Errorf("parseMyDisplayWidth failed: %w", err)
+		}
 		unsigned, pos := p.parseMyUnsigned()
-		return &sqlast.Int{From: tok.From, To: tok.To, IsUnsigned: unsigned, Unsigned: pos}, nil
-	case "BIGINT":
-		unsigned, u, _ := p.parseKeyword("UNSIGNED")
-		return &sqlast.BigInt{From: tok.From, To: tok.To, IsUnsigned: unsigned, Unsigned: u.To}, nil
+		zerofill, zpos := p.parseMyZerofill()
+		switch word.Keyword {
+		case "SMALLINT":
+			return &sqlast.SmallInt{From: tok.From, To: tok.To, Width: width, RParen: r, IsUnsigned: unsigned, Unsigned: pos, IsZerofill: zerofill, Zerofill: zpos}, nil
+		case "BIGINT":
+			return &sqlast.BigInt{From: tok.From, To: tok.To, Width: width, RParen: r, IsUnsigned: unsigned, Unsigned: pos, IsZerofill: zerofill, Zerofill: zpos}, nil
+		}
+		return &sqlast.Int{From: tok.From, To: tok.To, Width: width, RParen: r, IsUnsigned: unsigned, Unsigned: pos, IsZerofill: zerofill, Zerofill: zpos}, nil
+	case "SMALLSERIAL":
+		return &sqlast.SmallSerial{From: tok.From, To: tok.To}, nil
+	case "SERIAL":
+		return &sqlast.Serial{From: tok.From, To: tok.To}, nil
+	case "BIGSERIAL":
+		return &sqlast.BigSerial{From: tok.From, To: tok.To}, nil
 	case "VARCHAR":
 		p, r, err := p.parseOptionalPrecision()
 		if err != nil {
@@ -254,13 +660,20 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 	case "REGCLASS":
 		return &sqlast.Regclass{}, nil
 	case "TEXT":
-		if ok, _ := p.consumeToken(sqltoken.LBracket); ok {
-			p.expectToken(sqltoken.RBracket)
-			return &sqlast.Array{
-				Ty: &sqlast.Text{},
-			}, nil
+		return &sqlast.Text{From: tok.From, To: tok.To}, nil
+	case "STRING":
+		return &sqlast.String{From: tok.From, To: tok.To}, nil
+	case "BINARY":
+		size, r, err := p.parseOptionalPrecision()
+		if err != nil {
+			return nil, errors.Errorf("parsePrecision failed: %w", err)
+		}
+		b := &sqlast.Binary{Binary: tok.From, RParen: tok.To}
+		if size != nil {
+			b.Size = *size
+			b.RParen = r
 		}
-		return &sqlast.Text{}, nil
+		return b, nil
 	case "BYTEA":
 		return &sqlast.Bytea{}, nil
 	case "NUMERIC":
@@ -268,13 +681,17 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 		if err != nil {
 			return nil, errors.Errorf("parseOptionalPrecisionScale failed: %w", err)
 		}
-		p.prevToken()
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %s", r)
+		r := tok
+		if precision != nil {
+			p.prevToken()
+			r, _ = p.nextToken()
+			if r == nil || r.Kind != sqltoken.RParen {
+				return nil, errors.Errorf("expected RParen but %s", r)
+			}
 		}
 
 		unsigned, pos := p.parseMyUnsigned()
+		zerofill, zpos := p.parseMyZerofill()
 		return &sqlast.Decimal{
 			Precision:  precision,
 			Scale:      scale,
@@ -282,6 +699,8 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 			RParen:     r.To,
 			IsUnsigned: unsigned,
 			Unsigned:   pos,
+			IsZerofill: zerofill,
+			Zerofill:   zpos,
 		}, nil
 
 	default:
@@ -290,60 +709,283 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 		if err != nil {
 			return nil, errors.Errorf("parseObjectName failed: %w", err)
 		}
-		return &sqlast.Custom{
-			Ty: typeName,
-		}, nil
+		custom := &sqlast.Custom{
+			Ty:         typeName,
+			Definition: p.types[strings.ToLower(typeName.ToSQLString())],
+		}
+		if p.mySQLSyntax() {
+			if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+				args, err := p.parseExprList()
+				if err != nil {
+					return nil, errors.Errorf("parseExprList failed: %w", err)
+				}
+				r, _ := p.nextToken()
+				if r == nil || r.Kind != sqltoken.RParen {
+					return nil, errors.Errorf("expected RParen but %+v", r)
+				}
+				custom.Args = args
+				custom.RParen = r.To
+			}
+		}
+		return custom, nil
 	}
 }
 
-func (p *Parser) ParseExpr() (sqlast.Node, error) {
-	return p.parseSubexpr(0)
-}
+// parseHiveComplexType parses the rest of `array<T>`, `map<K, V>` or `struct<name:T, ...>`.
+func (p *Parser) parseHiveComplexType(tok *sqltoken.Token) (sqlast.Type, error) {
+	p.mustNextToken() // <
+	word := tok.Value.(*sqltoken.SQLWord)
 
-func (p *Parser) parseQuery() (*sqlast.QueryStmt, error) {
-	hasCTE, _, _ := p.parseKeyword("WITH")
-	var ctes []*sqlast.CTE
-	if hasCTE {
-		cts, err := p.parseCTEList()
+	var tp sqlast.Type
+	switch word.Keyword {
+	case "ARRAY":
+		elem, err := p.ParseDataType()
 		if err != nil {
-			return nil, errors.Errorf("parseCTEList failed: %w", err)
+			return nil, errors.Errorf("ParseDataType failed: %w", err)
 		}
-		ctes = cts
+		tp = &sqlast.HiveArray{Array: tok.From, Elem: elem}
+	case "MAP":
+		key, err := p.ParseDataType()
+		if err != nil {
+			return nil, errors.Errorf("ParseDataType failed: %w", err)
+		}
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			t, _ := p.peekToken()
+			return nil, errors.Errorf("expected Comma but %+v", t)
+		}
+		value, err := p.ParseDataType()
+		if err != nil {
+			return nil, errors.Errorf("ParseDataType failed: %w", err)
+		}
+		tp = &sqlast.HiveMap{Map: tok.From, Key: key, Value: value}
+	case "STRUCT":
+		s := &sqlast.HiveStruct{Struct: tok.From}
+		for {
+			name, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			if ok, _ := p.consumeToken(sqltoken.Colon); !ok {
+				t, _ := p.peekToken()
+				return nil, errors.Errorf("expected Colon but %+v", t)
+			}
+			ftp, err := p.ParseDataType()
+			if err != nil {
+				return nil, errors.Errorf("ParseDataType failed: %w", err)
+			}
+			s.Fields = append(s.Fields, &sqlast.HiveStructField{Name: name, Type: ftp})
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+		}
+		tp = s
 	}
 
-	body, err := p.parseQueryBody(0)
+	r, err := p.parseRAngle()
 	if err != nil {
-		return nil, errors.Errorf("parseQueryBody failed: %w", err)
+		return nil, errors.Errorf("parseRAngle failed: %w", err)
 	}
-
-	var orderBy []*sqlast.OrderByExpr
-	if ok, _, _ := p.parseKeywords("ORDER", "BY"); ok {
-		o, err := p.parseOrderByExprList()
-		if err != nil {
-			return nil, errors.Errorf("parseOrderByExprList failed: %w", err)
-		}
-		orderBy = o
+	switch t := tp.(type) {
+	case *sqlast.HiveArray:
+		t.RAngle = r.To
+	case *sqlast.HiveMap:
+		t.RAngle = r.To
+	case *sqlast.HiveStruct:
+		t.RAngle = r.To
 	}
+	return tp, nil
+}
 
-	var limit *sqlast.LimitExpr
-	if ok, _, _ := p.parseKeyword("LIMIT"); ok {
-		l, err := p.parseLimit()
-		if err != nil {
-			return nil, errors.Errorf("invalid limit expression: %w", err)
-		}
-		limit = l
+// parseClickHouseWrapperType parses the rest of `Nullable(T)` or `LowCardinality(T)`.
+func (p *Parser) parseClickHouseWrapperType(tok *sqltoken.Token) (sqlast.Type, error) {
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		t, _ := p.peekToken()
+		return nil, errors.Errorf("expected LParen but %+v", t)
+	}
+	ty, err := p.ParseDataType()
+	if err != nil {
+		return nil, errors.Errorf("ParseDataType failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
 	}
 
-	return &sqlast.QueryStmt{
-		CTEs:    ctes,
-		Body:    body,
-		Limit:   limit,
-		OrderBy: orderBy,
-	}, nil
+	if tok.Value.(*sqltoken.SQLWord).Keyword == "NULLABLE" {
+		return &sqlast.CHNullable{Nullable: tok.From, Ty: ty, RParen: r.To}, nil
+	}
+	return &sqlast.CHLowCardinality{LowCardinality: tok.From, Ty: ty, RParen: r.To}, nil
 }
 
-func (p *Parser) parseQueryBody(precedence uint8) (sqlast.SQLSetExpr, error) {
-	var expr sqlast.SQLSetExpr
+// parseRAngle consumes `>` closing type parameters. `>>` closing nested
+// ones, i.e: array<array<int>>, is split into two `>` tokens.
+func (p *Parser) parseRAngle() (*sqltoken.Token, error) {
+	tok, err := p.nextToken()
+	if err != nil {
+		return nil, errors.Errorf("nextToken failed: %w", err)
+	}
+	switch tok.Kind {
+	case sqltoken.Gt:
+		return tok, nil
+	case sqltoken.ShiftRight:
+		first := &sqltoken.Token{
+			Kind:   sqltoken.Gt,
+			Value:  ">",
+			From:   tok.From,
+			To:     sqltoken.NewPos(tok.From.Line, tok.From.Col+1),
+			Raw:    ">",
+			Offset: tok.Offset,
+		}
+		second := &sqltoken.Token{
+			Kind:   sqltoken.Gt,
+			Value:  ">",
+			From:   first.To,
+			To:     tok.To,
+			Raw:    ">",
+			Offset: tok.Offset + 1,
+		}
+		i := p.index - 1
+		tokens := append([]*sqltoken.Token{}, p.tokens[:i]...)
+		tokens = append(tokens, first, second)
+		p.tokens = append(tokens, p.tokens[i+1:]...)
+		return first, nil
+	}
+	return nil, errors.Errorf("expected Gt but %+v", tok)
+}
+
+func (p *Parser) ParseExpr() (_ sqlast.Node, err error) {
+	defer p.recoverBailout(&err)
+	return p.parseSubexpr(0)
+}
+
+func (p *Parser) parseQuery() (*sqlast.QueryStmt, error) {
+	hasCTE, withTok, _ := p.parseKeyword("WITH")
+	var ctes []*sqlast.CTE
+	var with sqltoken.Pos
+	if hasCTE {
+		with = withTok.From
+		cts, err := p.parseCTEList()
+		if err != nil {
+			return nil, errors.Errorf("parseCTEList failed: %w", err)
+		}
+		ctes = cts
+	}
+
+	body, err := p.parseQueryBody(0)
+	if err != nil {
+		return nil, errors.Errorf("parseQueryBody failed: %w", err)
+	}
+
+	var orderBy []*sqlast.OrderByExpr
+	if ok, _, _ := p.parseKeywords("ORDER", "BY"); ok {
+		o, err := p.parseOrderByExprList()
+		if err != nil {
+			return nil, errors.Errorf("parseOrderByExprList failed: %w", err)
+		}
+		orderBy = o
+	}
+
+	// the locking clauses may either precede or follow LIMIT
+	locks, err := p.parseLockingClauses()
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := p.parseLimit()
+	if err != nil {
+		return nil, errors.Errorf("invalid limit expression: %w", err)
+	}
+
+	fetch, err := p.parseFetch()
+	if err != nil {
+		return nil, errors.Errorf("invalid fetch clause: %w", err)
+	}
+
+	locksFirst := len(locks) != 0 && (limit != nil || fetch != nil)
+	if len(locks) == 0 {
+		if locks, err = p.parseLockingClauses(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &sqlast.QueryStmt{
+		With:       with,
+		CTEs:       ctes,
+		Body:       body,
+		Limit:      limit,
+		Fetch:      fetch,
+		OrderBy:    orderBy,
+		Locks:      locks,
+		LocksFirst: locksFirst,
+	}, nil
+}
+
+func (p *Parser) parseLockingClauses() ([]*sqlast.LockingClause, error) {
+	var locks []*sqlast.LockingClause
+	for {
+		ok, tok, _ := p.parseKeyword("FOR")
+		if !ok {
+			return locks, nil
+		}
+		l, err := p.parseLockingClause(tok)
+		if err != nil {
+			return nil, errors.Errorf("parseLockingClause failed: %w", err)
+		}
+		locks = append(locks, l)
+	}
+}
+
+func (p *Parser) parseLockingClause(forTok *sqltoken.Token) (*sqlast.LockingClause, error) {
+	l := &sqlast.LockingClause{For: forTok.From}
+
+	if ok, t, _ := p.parseKeyword("UPDATE"); ok {
+		l.Strength = sqlast.LockUpdate
+		l.To = t.To
+	} else if ok, t, _ := p.parseKeyword("SHARE"); ok {
+		l.Strength = sqlast.LockShare
+		l.To = t.To
+	} else if ok, toks, _ := p.parseKeywords("NO", "KEY", "UPDATE"); ok {
+		l.Strength = sqlast.LockNoKeyUpdate
+		l.To = toks[2].To
+	} else if ok, toks, _ := p.parseKeywords("KEY", "SHARE"); ok {
+		l.Strength = sqlast.LockKeyShare
+		l.To = toks[1].To
+	} else {
+		t, _ := p.peekToken()
+		return nil, errors.Errorf("expected UPDATE or SHARE after FOR but %+v", t)
+	}
+
+	if ok, _, _ := p.parseKeyword("OF"); ok {
+		for {
+			n, err := p.parseObjectName()
+			if err != nil {
+				return nil, errors.Errorf("parseObjectName failed: %w", err)
+			}
+			l.Of = append(l.Of, n)
+			l.To = n.End()
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+		}
+	}
+
+	if ok, t, _ := p.parseKeyword("NOWAIT"); ok {
+		l.Wait = sqlast.LockNoWait
+		l.To = t.To
+	} else if ok, toks, _ := p.parseKeywords("SKIP", "LOCKED"); ok {
+		l.Wait = sqlast.LockSkipLocked
+		l.To = toks[1].To
+	}
+
+	return l, nil
+}
+
+func (p *Parser) parseQueryBody(precedence uint8) (sqlast.SQLSetExpr, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	var expr sqlast.SQLSetExpr
 	if ok, tok, _ := p.parseKeyword("SELECT"); ok {
 		s, err := p.parseSelect()
 		if err != nil {
@@ -351,17 +993,29 @@ func (p *Parser) parseQueryBody(precedence uint8) (sqlast.SQLSetExpr, error) {
 		}
 		s.Select = tok.From
 		expr = s
+	} else if ok, tok, _ := p.parseKeyword("VALUES"); ok {
+		rows, err := p.parseValuesRows()
+		if err != nil {
+			return nil, errors.Errorf("parseValuesRows failed: %w", err)
+		}
+		expr = &sqlast.SQLValues{
+			Values: tok.From,
+			Rows:   rows,
+		}
 	} else if ok, _ := p.consumeToken(sqltoken.LParen); ok {
 		subquery, err := p.parseQuery()
 		if err != nil {
 			return nil, errors.Errorf("parseQuery failed: %w", err)
 		}
-		p.expectToken(sqltoken.RParen)
+		if r, _ := p.nextToken(); r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
+		}
 		expr = &sqlast.QueryExpr{
 			Query: subquery,
 		}
 	} else {
-		log.Panicln("expect SELECT or subquery in the query body")
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected SELECT, VALUES or subquery in the query body but %+v", tok)
 	}
 BODY_LOOP:
 	for {
@@ -397,7 +1051,140 @@ BODY_LOOP:
 	return expr, nil
 }
 
+// parseValuesRows parses comma separated `(exprs...)` lists after VALUES.
+func (p *Parser) parseValuesRows() ([]*sqlast.RowValueExpr, error) {
+	var rows []*sqlast.RowValueExpr
+	for {
+		l, _ := p.nextToken()
+		if l == nil || l.Kind != sqltoken.LParen {
+			return nil, errors.Errorf("expected LParen but %+v", l)
+		}
+		v, err := p.parseExprList()
+		if err != nil {
+			return nil, errors.Errorf("parseExprList failed: %w", err)
+		}
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
+		}
+		rows = append(rows, &sqlast.RowValueExpr{
+			Values: v,
+			LParen: l.From,
+			RParen: r.To,
+		})
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+	return rows, nil
+}
+
+// parseOuterJoinOperator parses `(+)` after a column if the dialect supports it.
+func (p *Parser) parseOuterJoinOperator() (sqltoken.Pos, bool) {
+	if d, ok := p.dialect.(dialect.OuterJoinOperatorDialect); !ok || !d.OuterJoinOperator() {
+		return sqltoken.Pos{}, false
+	}
+	idx := p.index
+	for _, kind := range []sqltoken.Kind{sqltoken.LParen, sqltoken.Plus, sqltoken.RParen} {
+		tok, _ := p.nextToken()
+		if tok == nil || tok.Kind != kind {
+			p.index = idx
+			return sqltoken.Pos{}, false
+		}
+		if kind == sqltoken.RParen {
+			return tok.To, true
+		}
+	}
+	return sqltoken.Pos{}, false
+}
+
+// reservedKeyword reports whether keyword can't be used as an identifier
+// unless quoted.
+func (p *Parser) reservedKeyword(keyword string) bool {
+	if d, ok := p.dialect.(dialect.KeywordDialect); ok {
+		return d.IsReservedKeyword(keyword)
+	}
+	_, ok := dialect.ReservedKeywords[keyword]
+	return ok
+}
+
+// isIdentifier reports whether tok can be used as an identifier.
+func (p *Parser) isIdentifier(tok *sqltoken.Token) bool {
+	word, ok := tok.Value.(*sqltoken.SQLWord)
+	if !ok {
+		return false
+	}
+	return word.QuoteStyle != 0 || !p.reservedKeyword(word.Keyword)
+}
+
+// reservedByDialect reports whether keyword is reserved for alias by the dialect.
+func (p *Parser) reservedByDialect(keyword string) bool {
+	d, ok := p.dialect.(dialect.AliasReservedDialect)
+	if !ok {
+		return false
+	}
+	for _, k := range d.ReservedForAlias() {
+		if k == keyword {
+			return true
+		}
+	}
+	return false
+}
+
+// clickHouse reports whether the dialect supports the syntax of ClickHouse.
+func (p *Parser) clickHouse() bool {
+	d, ok := p.dialect.(dialect.ClickHouseSyntaxDialect)
+	return ok && d.ClickHouseSyntax()
+}
+
+// redshift reports whether the dialect supports the syntax of Redshift.
+func (p *Parser) redshift() bool {
+	d, ok := p.dialect.(dialect.RedshiftSyntaxDialect)
+	return ok && d.RedshiftSyntax()
+}
+
+// hiveQuery reports whether the dialect supports the query extensions of Hive.
+func (p *Parser) hiveQuery() bool {
+	d, ok := p.dialect.(dialect.HiveQueryDialect)
+	return ok && d.HiveQuery()
+}
+
+// top reports whether the dialect supports SELECT TOP n.
+func (p *Parser) top() bool {
+	d, ok := p.dialect.(dialect.TopDialect)
+	return ok && d.Top()
+}
+
+// mySQLSyntax reports whether the dialect supports the DDL syntax of MySQL.
+func (p *Parser) mySQLSyntax() bool {
+	d, ok := p.dialect.(dialect.MySQLSyntaxDialect)
+	return ok && d.MySQLSyntax()
+}
+
+// backslashEscape reports whether the dialect interprets backslash escapes in
+// string literals, which are then written escaped.
+func (p *Parser) backslashEscape() bool {
+	d, ok := p.dialect.(dialect.StringEscapeDialect)
+	return ok && d.BackslashEscape()
+}
+
+// indexHints reports whether the dialect supports index hints of MySQL.
+func (p *Parser) indexHints() bool {
+	d, ok := p.dialect.(dialect.IndexHintDialect)
+	return ok && d.IndexHints()
+}
+
+// minusIsSetOperator reports whether MINUS is a synonym for EXCEPT in the dialect.
+func (p *Parser) minusIsSetOperator() bool {
+	d, ok := p.dialect.(dialect.MinusDialect)
+	return ok && d.Minus()
+}
+
 func (p *Parser) parseSetOperator(token *sqltoken.Token) sqlast.SQLSetOperator {
+	p.suggestKeywords("UNION", "EXCEPT", "INTERSECT")
+	if p.minusIsSetOperator() {
+		p.suggestKeywords("MINUS")
+	}
 	if token == nil {
 		return nil
 	}
@@ -410,6 +1197,10 @@ func (p *Parser) parseSetOperator(token *sqltoken.Token) sqlast.SQLSetOperator {
 		return &sqlast.UnionOperator{}
 	case "EXCEPT":
 		return &sqlast.ExceptOperator{}
+	case "MINUS":
+		if p.minusIsSetOperator() {
+			return &sqlast.ExceptOperator{Minus: true}
+		}
 	case "INTERSECT":
 		return &sqlast.IntersectOperator{}
 	}
@@ -419,9 +1210,35 @@ func (p *Parser) parseSetOperator(token *sqltoken.Token) sqlast.SQLSetOperator {
 }
 
 func (p *Parser) parseSelect() (*sqlast.SQLSelect, error) {
-	distinct, _, err := p.parseKeyword("DISTINCT")
-	if err != nil {
-		return nil, errors.Errorf("parseKeyword failed: %w", err)
+	hints := p.parseOptimizerHints()
+	distinct, _, _ := p.parseKeyword("DISTINCT")
+	var distinctOn []sqlast.Node
+	if distinct {
+		if ok, on, _ := p.parseKeyword("ON"); ok {
+			if d, ok := p.dialect.(dialect.DistinctOnDialect); !ok || !d.DistinctOn() {
+				return nil, errors.Errorf("DISTINCT ON is not supported in this dialect at %s", on.From.String())
+			}
+			if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+				t, _ := p.peekToken()
+				return nil, errors.Errorf("expected LParen but %s", t)
+			}
+			var err error
+			distinctOn, err = p.parseExprList()
+			if err != nil {
+				return nil, errors.Errorf("parseExprList failed: %w", err)
+			}
+			if ok, _ := p.consumeToken(sqltoken.RParen); !ok {
+				t, _ := p.peekToken()
+				return nil, errors.Errorf("expected RParen but %s", t)
+			}
+		}
+	}
+	var top *sqlast.Top
+	if p.top() {
+		var err error
+		if top, err = p.parseTop(); err != nil {
+			return nil, errors.Errorf("parseTop failed: %w", err)
+		}
 	}
 	projection, err := p.parseSelectList()
 	if err != nil {
@@ -436,6 +1253,28 @@ func (p *Parser) parseSelect() (*sqlast.SQLSelect, error) {
 		}
 	}
 
+	var lateralViews []*sqlast.LateralView
+	for len(tableRefs) != 0 && p.hiveQuery() {
+		ok, toks, _ := p.parseKeywords("LATERAL", "VIEW")
+		if !ok {
+			break
+		}
+		l, err := p.parseLateralView(toks[0])
+		if err != nil {
+			return nil, errors.Errorf("parseLateralView failed: %w", err)
+		}
+		lateralViews = append(lateralViews, l)
+	}
+
+	var arrayJoins []*sqlast.ArrayJoin
+	for len(tableRefs) != 0 && p.clickHouse() && p.peekArrayJoin() {
+		a, err := p.parseArrayJoin()
+		if err != nil {
+			return nil, errors.Errorf("parseArrayJoin failed: %w", err)
+		}
+		arrayJoins = append(arrayJoins, a)
+	}
+
 	var selection sqlast.Node
 	if ok, _, _ := p.parseKeyword("WHERE"); ok {
 		s, err := p.ParseExpr()
@@ -447,9 +1286,9 @@ func (p *Parser) parseSelect() (*sqlast.SQLSelect, error) {
 
 	var groupBy []sqlast.Node
 	if ok, _, _ := p.parseKeywords("GROUP", "BY"); ok {
-		g, err := p.parseExprList()
+		g, err := p.parseGroupByList()
 		if err != nil {
-			return nil, errors.Errorf("parseExprList failed: %w", err)
+			return nil, errors.Errorf("parseGroupByList failed: %w", err)
 		}
 		groupBy = g
 	}
@@ -463,17 +1302,118 @@ func (p *Parser) parseSelect() (*sqlast.SQLSelect, error) {
 		having = h
 	}
 
+	var windows []*sqlast.NamedWindow
+	if ok, _, _ := p.parseKeyword("WINDOW"); ok {
+		w, err := p.parseWindowClause()
+		if err != nil {
+			return nil, errors.Errorf("parseWindowClause failed: %w", err)
+		}
+		windows = w
+	}
+
+	var distributeBy, clusterBy []sqlast.Node
+	var sortBy []*sqlast.OrderByExpr
+	if p.hiveQuery() {
+		if ok, _, _ := p.parseKeywords("DISTRIBUTE", "BY"); ok {
+			d, err := p.parseExprList()
+			if err != nil {
+				return nil, errors.Errorf("parseExprList failed: %w", err)
+			}
+			distributeBy = d
+		}
+		if ok, _, _ := p.parseKeywords("SORT", "BY"); ok {
+			o, err := p.parseOrderByExprList()
+			if err != nil {
+				return nil, errors.Errorf("parseOrderByExprList failed: %w", err)
+			}
+			sortBy = o
+		}
+		if ok, _, _ := p.parseKeywords("CLUSTER", "BY"); ok {
+			if distributeBy != nil || sortBy != nil {
+				return nil, errors.Errorf("CLUSTER BY cannot be used with DISTRIBUTE BY or SORT BY")
+			}
+			c, err := p.parseExprList()
+			if err != nil {
+				return nil, errors.Errorf("parseExprList failed: %w", err)
+			}
+			clusterBy = c
+		}
+	}
+
 	return &sqlast.SQLSelect{
+		Hints:         hints,
 		Distinct:      distinct,
+		DistinctOn:    distinctOn,
+		Top:           top,
 		Projection:    projection,
 		WhereClause:   selection,
 		FromClause:    tableRefs,
 		GroupByClause: groupBy,
 		HavingClause:  having,
+		WindowClause:  windows,
+		LateralViews:  lateralViews,
+		ArrayJoins:    arrayJoins,
+		DistributeBy:  distributeBy,
+		SortBy:        sortBy,
+		ClusterBy:     clusterBy,
 	}, nil
 
 }
 
+// peekArrayJoin reports whether `[LEFT] ARRAY JOIN` follows.
+func (p *Parser) peekArrayJoin() bool {
+	idx := p.index
+	defer func() { p.index = idx }()
+	p.parseKeyword("LEFT")
+	ok, _, _ := p.parseKeywords("ARRAY", "JOIN")
+	return ok
+}
+
+// parseArrayJoin parses `[LEFT] ARRAY JOIN Items...`.
+func (p *Parser) parseArrayJoin() (*sqlast.ArrayJoin, error) {
+	from, _ := p.peekToken()
+	left, _, _ := p.parseKeyword("LEFT")
+	if ok, toks, _ := p.parseKeywords("ARRAY", "JOIN"); !ok {
+		return nil, errors.Errorf("expected ARRAY JOIN but %+v", toks[len(toks)-1])
+	}
+	items, err := p.parseSelectList()
+	if err != nil {
+		return nil, errors.Errorf("parseSelectList failed: %w", err)
+	}
+	return &sqlast.ArrayJoin{From: from.From, Left: left, Items: items}, nil
+}
+
+// parseLateralView parses the rest of `LATERAL VIEW [OUTER] Generator [TableAlias] [AS ColumnAliases...]`.
+func (p *Parser) parseLateralView(lateral *sqltoken.Token) (*sqlast.LateralView, error) {
+	outer, _, _ := p.parseKeyword("OUTER")
+	generator, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+
+	l := &sqlast.LateralView{
+		Lateral:   lateral.From,
+		Outer:     outer,
+		Generator: generator,
+	}
+	if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.SQLKeyword && t.Value.(*sqltoken.SQLWord).Keyword != "AS" {
+		l.TableAlias = p.parseOptionalAlias(dialect.ReservedForTableAlias)
+	}
+	if ok, _, _ := p.parseKeyword("AS"); ok {
+		for {
+			ident, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			l.ColumnAliases = append(l.ColumnAliases, ident)
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+		}
+	}
+	return l, nil
+}
+
 func (p *Parser) parseSelectList() ([]sqlast.SQLSelectItem, error) {
 	var projections []sqlast.SQLSelectItem
 
@@ -505,7 +1445,8 @@ func (p *Parser) parseSelectList() ([]sqlast.SQLSelectItem, error) {
 			}
 		}
 
-		if t, _ := p.peekToken(); t.Kind == sqltoken.Comma {
+		p.suggestToken(sqltoken.Comma)
+		if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.Comma {
 			p.mustNextToken()
 		} else {
 			break
@@ -519,8 +1460,49 @@ func (p *Parser) parseCreate() (sqlast.Stmt, error) {
 	if !ok {
 		return nil, errors.Errorf("expect CREATE but %+v", t)
 	}
+	orReplace, _, _ := p.parseKeywords("OR", "REPLACE")
+	if ok, _, _ := p.parseKeyword("FUNCTION"); ok {
+		return p.parseCreateFunction(t, orReplace, false)
+	}
+	if ok, _, _ := p.parseKeyword("PROCEDURE"); ok {
+		return p.parseCreateFunction(t, orReplace, true)
+	}
+	if orReplace {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected FUNCTION or PROCEDURE after CREATE OR REPLACE but %+v", tok)
+	}
+
+	temporary := false
+	for _, k := range []string{"TEMPORARY", "TEMP"} {
+		if ok, _, _ := p.parseKeywords(k, "SEQUENCE"); ok {
+			temporary = true
+			p.prevToken()
+			break
+		}
+		if ok, _, _ := p.parseKeywords(k, "TABLE"); ok {
+			temporary = true
+			p.prevToken()
+			break
+		}
+	}
+	if ok, _, _ := p.parseKeyword("SEQUENCE"); ok {
+		return p.parseCreateSequence(t, temporary)
+	}
+	if ok, _, _ := p.parseKeyword("TYPE"); ok {
+		return p.parseCreateType(t)
+	}
+	if ok, _, _ := p.parseKeyword("SCHEMA"); ok {
+		return p.parseCreateSchema(t)
+	}
+	if ok, _, _ := p.parseKeyword("DATABASE"); ok {
+		return p.parseCreateDatabase(t)
+	}
+	if ok, _, _ := p.parseKeyword("EXTENSION"); ok {
+		return p.parseCreateExtension(t)
+	}
+
 	if ok, _, _ := p.parseKeyword("TABLE"); ok {
-		return p.parseCreateTable(t)
+		return p.parseCreateTable(t, temporary)
 	}
 
 	mok, _, _ := p.parseKeyword("MATERIALIZED")
@@ -538,37 +1520,124 @@ func (p *Parser) parseCreate() (sqlast.Stmt, error) {
 		return p.parseCreateIndex(uiok)
 	}
 
-	log.Panicln("TABLE or VIEW or UNIQUE INDEX or INDEX after create")
-
-	return nil, nil
+	tok, _ := p.peekToken()
+	return nil, errors.Errorf("expected TABLE, VIEW, INDEX or UNIQUE INDEX after CREATE but %+v", tok)
 }
 
-func (p *Parser) parseCreateTable(create *sqltoken.Token) (sqlast.Stmt, error) {
+func (p *Parser) parseCreateTable(create *sqltoken.Token, temporary bool) (sqlast.Stmt, error) {
 	notExists, _, _ := p.parseKeywords("IF", "NOT", "EXISTS")
 	name, err := p.parseObjectName()
 	if err != nil {
 		return nil, errors.Errorf("parseObjectName failed: %w", err)
 	}
 
-	elements, err := p.parseElements()
+	var parent *sqlast.ObjectName
+	if ok, _, _ := p.parseKeywords("PARTITION", "OF"); ok {
+		parent, err = p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+	}
+
+	elements, err := p.parseElements(parent != nil)
 	if err != nil {
 		return nil, errors.Errorf("parseElements failed: %w", err)
 	}
 
+	var bound sqlast.PartitionBound
+	if parent != nil {
+		bound, err = p.parsePartitionBound()
+		if err != nil {
+			return nil, errors.Errorf("parsePartitionBound failed: %w", err)
+		}
+	}
+
 	options, err := p.parseTableOptions()
 	if err != nil {
 		return nil, errors.Errorf("parseTableOptions failed: %w", err)
 	}
 
 	return &sqlast.CreateTableStmt{
-		NotExists: notExists,
-		Create:    create.From,
-		Name:      name,
-		Elements:  elements,
-		Options:   options,
+		NotExists:      notExists,
+		Create:         create.From,
+		Temporary:      temporary,
+		Name:           name,
+		Elements:       elements,
+		Options:        options,
+		PartitionOf:    parent,
+		PartitionBound: bound,
 	}, nil
 }
 
+// parsePartitionBound parses `DEFAULT`, `FOR VALUES IN (Values...)`,
+// `FOR VALUES FROM (Values...) TO (Values...)` or
+// `FOR VALUES WITH (MODULUS m, REMAINDER r)` of PARTITION OF.
+func (p *Parser) parsePartitionBound() (sqlast.PartitionBound, error) {
+	if ok, tok, _ := p.parseKeyword("DEFAULT"); ok {
+		return &sqlast.PartitionBoundDefault{From: tok.From, To: tok.To}, nil
+	}
+	ok, toks, _ := p.parseKeywords("FOR", "VALUES")
+	if !ok {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected FOR VALUES or DEFAULT but %+v", tok)
+	}
+
+	if ok, _, _ := p.parseKeyword("IN"); ok {
+		values, r, err := p.parsePartitionBoundValues()
+		if err != nil {
+			return nil, errors.Errorf("parsePartitionBoundValues failed: %w", err)
+		}
+		return &sqlast.PartitionBoundIn{For: toks[0].From, Values: values, RParen: r}, nil
+	}
+
+	if ok, _, _ := p.parseKeyword("FROM"); ok {
+		from, _, err := p.parsePartitionBoundValues()
+		if err != nil {
+			return nil, errors.Errorf("parsePartitionBoundValues failed: %w", err)
+		}
+		p.expectKeyword("TO")
+		to, r, err := p.parsePartitionBoundValues()
+		if err != nil {
+			return nil, errors.Errorf("parsePartitionBoundValues failed: %w", err)
+		}
+		return &sqlast.PartitionBoundRange{For: toks[0].From, From: from, To: to, RParen: r}, nil
+	}
+
+	p.expectKeyword("WITH")
+	p.expectToken(sqltoken.LParen)
+	p.expectKeyword("MODULUS")
+	modulus, err := p.parseSignedLong()
+	if err != nil {
+		return nil, errors.Errorf("parseSignedLong failed: %w", err)
+	}
+	p.expectToken(sqltoken.Comma)
+	p.expectKeyword("REMAINDER")
+	remainder, err := p.parseSignedLong()
+	if err != nil {
+		return nil, errors.Errorf("parseSignedLong failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
+	return &sqlast.PartitionBoundHash{For: toks[0].From, Modulus: modulus, Remainder: remainder, RParen: r.To}, nil
+}
+
+// parsePartitionBoundValues parses `(Values...)` and returns the position
+// of the RParen.
+func (p *Parser) parsePartitionBoundValues() ([]sqlast.Node, sqltoken.Pos, error) {
+	p.expectToken(sqltoken.LParen)
+	values, err := p.parseExprList()
+	if err != nil {
+		return nil, sqltoken.Pos{}, errors.Errorf("parseExprList failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, sqltoken.Pos{}, errors.Errorf("expected RParen but %+v", r)
+	}
+	return values, r.To, nil
+}
+
 func (p *Parser) parseCreateView(create *sqltoken.Token) (sqlast.Stmt, error) {
 	materialized, _, _ := p.parseKeyword("MATERIALIZED")
 	p.expectKeyword("VIEW")
@@ -591,1328 +1660,4488 @@ func (p *Parser) parseCreateView(create *sqltoken.Token) (sqlast.Stmt, error) {
 
 }
 
-func (p *Parser) parseCreateIndex(unique bool) (sqlast.Stmt, error) {
-	var indexName *sqlast.Ident
-	ok, _, _ := p.parseKeyword("ON")
-	if !ok {
-		if n, err := p.parseIdentifier(); err != nil {
-			return nil, errors.Errorf("parseIdentifier failed: %w", err)
-		} else {
-			indexName = n
-		}
-		p.expectKeyword("ON")
-	}
+var functionAttributes = [][]string{
+	{"IMMUTABLE"}, {"STABLE"}, {"VOLATILE"}, {"STRICT"}, {"LEAKPROOF"}, {"NOT", "LEAKPROOF"}, {"WINDOW"},
+	{"SECURITY", "DEFINER"}, {"SECURITY", "INVOKER"},
+	{"CALLED", "ON", "NULL", "INPUT"}, {"RETURNS", "NULL", "ON", "NULL", "INPUT"},
+	{"PARALLEL", "SAFE"}, {"PARALLEL", "UNSAFE"}, {"PARALLEL", "RESTRICTED"},
+}
 
-	tableName, err := p.parseObjectName()
+func (p *Parser) parseCreateFunction(create *sqltoken.Token, orReplace, procedure bool) (sqlast.Stmt, error) {
+	name, err := p.parseObjectName()
 	if err != nil {
 		return nil, errors.Errorf("parseObjectName failed: %w", err)
 	}
-	var methodName *sqlast.Ident
 
-	if ok, _, _ := p.parseKeyword("USING"); ok {
-		m, err := p.parseIdentifier()
-		if err != nil {
-			return nil, errors.Errorf("parseIdentifier failed: %w", err)
-		}
-		methodName = m
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected LParen but %+v", tok)
+	}
+	args, err := p.parseFunctionArgs()
+	if err != nil {
+		return nil, errors.Errorf("parseFunctionArgs failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
 	}
 
-	var columns []*sqlast.Ident
-	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
-		columns, err = p.parseColumnNames()
-		if err != nil {
-			return nil, errors.Errorf("parseColumnNames failed: %w", err)
-		}
-		p.expectToken(sqltoken.RParen)
+	stmt := &sqlast.CreateFunctionStmt{
+		Create:      create.From,
+		OrReplace:   orReplace,
+		IsProcedure: procedure,
+		Name:        name,
+		Args:        args,
+		RParen:      r.To,
 	}
 
-	var selection sqlast.Node
-	if ok, _, _ := p.parseKeyword("WHERE"); ok {
-		s, err := p.ParseExpr()
-		if err != nil {
-			return nil, errors.Errorf("ParseExpr failed: %w", err)
+	// options can be written in any order
+	for {
+		if attr, err := p.parseFunctionAttribute(); err != nil {
+			return nil, errors.Errorf("parseFunctionAttribute failed: %w", err)
+		} else if attr != nil {
+			stmt.Attributes = append(stmt.Attributes, attr)
+			continue
 		}
-		selection = s
+
+		if ok, _, _ := p.parseKeyword("RETURNS"); ok && !procedure {
+			if ok, _, _ := p.parseKeyword("TABLE"); ok {
+				if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+					tok, _ := p.peekToken()
+					return nil, errors.Errorf("expected LParen but %+v", tok)
+				}
+				cols, err := p.parseFunctionArgs()
+				if err != nil {
+					return nil, errors.Errorf("parseFunctionArgs failed: %w", err)
+				}
+				if ok, _ := p.consumeToken(sqltoken.RParen); !ok {
+					tok, _ := p.peekToken()
+					return nil, errors.Errorf("expected RParen but %+v", tok)
+				}
+				stmt.ReturnsTable = cols
+				continue
+			}
+			setOf, _, _ := p.parseKeyword("SETOF")
+			tp, err := p.ParseDataType()
+			if err != nil {
+				return nil, errors.Errorf("ParseDataType failed: %w", err)
+			}
+			stmt.Returns = tp
+			stmt.ReturnsSetOf = setOf
+			continue
+		} else if ok {
+			return nil, errors.Errorf("RETURNS is not allowed for PROCEDURE %s", name.ToSQLString())
+		}
+
+		if ok, _, _ := p.parseKeyword("LANGUAGE"); ok {
+			lang, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			stmt.Language = lang
+			continue
+		}
+
+		if ok, _, _ := p.parseKeyword("AS"); ok {
+			tok, _ := p.peekToken()
+			if tok == nil || (tok.Kind != sqltoken.SingleQuotedString && tok.Kind != sqltoken.DollarQuotedString &&
+				tok.Kind != sqltoken.EscapedStringLiteral) {
+				return nil, errors.Errorf("expected function body but %+v", tok)
+			}
+			body, err := p.parseSQLValue()
+			if err != nil {
+				return nil, errors.Errorf("parseSQLValue failed: %w", err)
+			}
+			stmt.Body = body
+			continue
+		}
+
+		break
 	}
 
-	return &sqlast.CreateIndexStmt{
-		IsUnique:    unique,
-		IndexName:   indexName,
-		TableName:   tableName,
-		MethodName:  methodName,
-		ColumnNames: columns,
-		Selection:   selection,
-	}, nil
+	return stmt, nil
 }
 
-func (p *Parser) parseElements() ([]sqlast.TableElement, error) {
-	var elements []sqlast.TableElement
-	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
-		return elements, nil
+// parseFunctionArgs parses the comma separated arguments of CREATE FUNCTION until RParen.
+func (p *Parser) parseFunctionArgs() ([]*sqlast.FunctionArg, error) {
+	var args []*sqlast.FunctionArg
+	if tok, _ := p.peekToken(); tok != nil && tok.Kind == sqltoken.RParen {
+		return args, nil
 	}
 
 	for {
-		tok, _ := p.nextToken()
-		if tok == nil || tok.Kind != sqltoken.SQLKeyword {
-			return nil, errors.Errorf("parse error after column def %+v", tok)
+		from, _ := p.peekToken()
+		if from == nil {
+			return nil, errors.Errorf("unexpected EOF in function arguments")
+		}
+		arg := &sqlast.FunctionArg{From: from.From}
+		for _, m := range []string{"IN", "OUT", "INOUT", "VARIADIC"} {
+			if ok, _, _ := p.parseKeyword(m); ok {
+				arg.Mode = m
+				break
+			}
 		}
 
-		word := tok.Value.(*sqltoken.SQLWord)
-		switch word.Keyword {
-		case "CONSTRAINT", "PRIMARY", "CHECK", "FOREIGN", "UNIQUE":
-			p.prevToken()
-			constraints, err := p.parseTableConstraints()
+		// the name is optional, so try `Type` first and then `Name Type`
+		idx := p.index
+		if tp, err := p.ParseDataType(); err == nil && p.endOfFunctionArg() {
+			arg.Type = tp
+		} else {
+			p.index = idx
+			name, err := p.parseIdentifier()
 			if err != nil {
-				return nil, errors.Errorf("parseTableConstraints failed: %w", err)
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
 			}
-			elements = append(elements, constraints)
-
-		default:
-			p.prevToken()
-			def, err := p.parseColumnDef()
+			tp, err := p.ParseDataType()
 			if err != nil {
-				return nil, errors.Errorf("parseColumnDef failed: %w", err)
+				return nil, errors.Errorf("ParseDataType failed: %w", err)
 			}
+			arg.Name = name
+			arg.Type = tp
+		}
 
-			elements = append(elements, def)
+		ok, _, _ := p.parseKeyword("DEFAULT")
+		if eq, _ := p.consumeToken(sqltoken.Eq); ok || eq {
+			def, err := p.ParseExpr()
+			if err != nil {
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+			arg.Default = def
 		}
+		args = append(args, arg)
 
-		t, _ := p.nextToken()
-		if t == nil || (t.Kind != sqltoken.Comma && t.Kind != sqltoken.RParen) {
-			log.Panicf("Expected ',' or ')' after column definition but %v", t)
-		} else if t.Kind == sqltoken.RParen {
-			break
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			return args, nil
 		}
 	}
+}
 
-	return elements, nil
+func (p *Parser) endOfFunctionArg() bool {
+	tok, _ := p.peekToken()
+	if tok == nil {
+		return false
+	}
+	switch tok.Kind {
+	case sqltoken.Comma, sqltoken.RParen, sqltoken.Eq:
+		return true
+	}
+	ok, _, _ := p.parseKeyword("DEFAULT")
+	if ok {
+		p.prevToken()
+	}
+	return ok
 }
 
-func (p *Parser) parseColumnDef() (*sqlast.ColumnDef, error) {
-	tok := p.mustNextToken()
-	columnName := tok.Value.(*sqltoken.SQLWord)
+func (p *Parser) parseFunctionAttribute() (*sqlast.FunctionAttribute, error) {
+	for _, attr := range functionAttributes {
+		if ok, toks, _ := p.parseKeywords(attr...); ok {
+			return &sqlast.FunctionAttribute{
+				From: toks[0].From,
+				To:   toks[len(toks)-1].To,
+				Name: strings.Join(attr, " "),
+			}, nil
+		}
+	}
 
-	dataType, err := p.ParseDataType()
-	if err != nil {
-		return nil, errors.Errorf("ParseDataType failed: %w", err)
+	for _, attr := range []string{"COST", "ROWS"} {
+		if ok, tok, _ := p.parseKeyword(attr); ok {
+			v, err := p.parseSQLValue()
+			if err != nil {
+				return nil, errors.Errorf("parseSQLValue failed: %w", err)
+			}
+			return &sqlast.FunctionAttribute{
+				From:  tok.From,
+				To:    tok.To,
+				Name:  attr,
+				Value: v,
+			}, nil
+		}
 	}
 
-	def, specs, decorates, err := p.parseColumnDefinition()
+	return nil, nil
+}
+
+func (p *Parser) parseCreateType(create *sqltoken.Token) (sqlast.Stmt, error) {
+	name, err := p.parseObjectName()
 	if err != nil {
-		return nil, errors.Errorf("parseColumnDefinition: %w", err)
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+	stmt := &sqlast.CreateTypeStmt{
+		Create: create.From,
+		Name:   name,
 	}
 
-	return &sqlast.ColumnDef{
-		Constraints: specs,
-		Name: &sqlast.Ident{
-			From:  tok.From,
-			To:    tok.To,
-			Value: columnName.String(),
-		},
-		MyDataTypeDecoration: decorates,
-		DataType:             dataType,
-		Default:              def,
-	}, nil
-}
+	if ok, _, _ := p.parseKeyword("AS"); ok {
+		def, err := p.parseTypeDefinition()
+		if err != nil {
+			return nil, errors.Errorf("parseTypeDefinition failed: %w", err)
+		}
+		stmt.Definition = def
+	}
 
-func (p *Parser) parseTableConstraints() (*sqlast.TableConstraint, error) {
-	tok, _ := p.peekToken()
-	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
-		return nil, errors.Errorf("parse error after column def")
+	if p.types == nil {
+		p.types = make(map[string]*sqlast.CreateTypeStmt)
 	}
+	p.types[strings.ToLower(name.ToSQLString())] = stmt
 
-	word, ok := tok.Value.(*sqltoken.SQLWord)
+	return stmt, nil
+}
 
-	var constraintPos sqltoken.Pos
-	var name *sqlast.Ident
-	if ok && word.Keyword == "CONSTRAINT" {
-		constraintPos = tok.From
-		p.mustNextToken()
-		i, err := p.parseIdentifier()
-		if err != nil {
-			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+func (p *Parser) parseTypeDefinition() (sqlast.TypeDefinition, error) {
+	if ok, enum, _ := p.parseKeyword("ENUM"); ok {
+		if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+			tok, _ := p.peekToken()
+			return nil, errors.Errorf("expected LParen but %+v", tok)
+		}
+		def := &sqlast.EnumTypeDefinition{Enum: enum.From}
+		for {
+			tok, _ := p.nextToken()
+			if tok == nil {
+				return nil, errors.Errorf("unexpected EOF in ENUM labels")
+			}
+			if tok.Kind == sqltoken.RParen && len(def.Labels) == 0 {
+				def.RParen = tok.To
+				return def, nil
+			}
+			if tok.Kind != sqltoken.SingleQuotedString {
+				return nil, errors.Errorf("expected enum label but %+v", tok)
+			}
+			def.Labels = append(def.Labels, &sqlast.SingleQuotedString{
+				From:   tok.From,
+				To:     tok.To,
+				String: tok.Value.(string),
+			})
+
+			tok, _ = p.nextToken()
+			if tok != nil && tok.Kind == sqltoken.RParen {
+				def.RParen = tok.To
+				return def, nil
+			}
+			if tok == nil || tok.Kind != sqltoken.Comma {
+				return nil, errors.Errorf("expected Comma or RParen but %+v", tok)
+			}
 		}
-		name = i
 	}
 
-	tok, _ = p.peekToken()
+	if ok, rng, _ := p.parseKeyword("RANGE"); ok {
+		if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+			tok, _ := p.peekToken()
+			return nil, errors.Errorf("expected LParen but %+v", tok)
+		}
+		def := &sqlast.RangeTypeDefinition{Range: rng.From}
+		for {
+			name, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			if ok, _ := p.consumeToken(sqltoken.Eq); !ok {
+				tok, _ := p.peekToken()
+				return nil, errors.Errorf("expected Eq but %+v", tok)
+			}
+			var value sqlast.Node
+			if strings.EqualFold(name.Value, "SUBTYPE") {
+				value, err = p.ParseDataType()
+			} else {
+				value, err = p.parseObjectName()
+			}
+			if err != nil {
+				return nil, errors.Errorf("invalid value of %s: %w", name.Value, err)
+			}
+			def.Options = append(def.Options, &sqlast.TypeOption{Name: name, Value: value})
 
-	var spec sqlast.TableConstraintSpec
-	word = tok.Value.(*sqltoken.SQLWord)
-	switch word.Keyword {
-	case "UNIQUE":
-		p.mustNextToken()
-		if _, _, err := p.parseKeyword("KEY"); err != nil {
-			return nil, errors.Errorf("parseKeyword failed: %w", err)
+			tok, _ := p.nextToken()
+			if tok != nil && tok.Kind == sqltoken.RParen {
+				def.RParen = tok.To
+				return def, nil
+			}
+			if tok == nil || tok.Kind != sqltoken.Comma {
+				return nil, errors.Errorf("expected Comma or RParen but %+v", tok)
+			}
 		}
-		p.expectToken(sqltoken.LParen)
-		columns, err := p.parseColumnNames()
+	}
+
+	l, _ := p.nextToken()
+	if l == nil || l.Kind != sqltoken.LParen {
+		return nil, errors.Errorf("expected ENUM, RANGE or LParen but %+v", l)
+	}
+	def := &sqlast.CompositeTypeDefinition{LParen: l.From}
+	for {
+		tok, _ := p.peekToken()
+		if tok != nil && tok.Kind == sqltoken.RParen && len(def.Attributes) == 0 {
+			p.mustNextToken()
+			def.RParen = tok.To
+			return def, nil
+		}
+		if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+			return nil, errors.Errorf("expected attribute name but %+v", tok)
+		}
+		attr, err := p.parseColumnDef()
 		if err != nil {
-			return nil, errors.Errorf("parseColumnNames failed: %w", err)
+			return nil, errors.Errorf("parseColumnDef failed: %w", err)
 		}
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %+v", r)
+		def.Attributes = append(def.Attributes, attr)
+
+		tok, _ = p.nextToken()
+		if tok != nil && tok.Kind == sqltoken.RParen {
+			def.RParen = tok.To
+			return def, nil
 		}
-		spec = &sqlast.UniqueTableConstraint{
-			Unique:  tok.From,
-			RParen:  r.To,
-			Columns: columns,
+		if tok == nil || tok.Kind != sqltoken.Comma {
+			return nil, errors.Errorf("expected Comma or RParen but %+v", tok)
 		}
-	case "PRIMARY":
-		p.mustNextToken()
-		p.expectKeyword("KEY")
-		p.expectToken(sqltoken.LParen)
-		columns, err := p.parseColumnNames()
-		if err != nil {
-			return nil, errors.Errorf("parseColumnNames failed: %w", err)
-		}
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %+v", r)
-		}
-		spec = &sqlast.UniqueTableConstraint{
-			Primary:   tok.From,
-			RParen:    r.To,
-			IsPrimary: true,
-			Columns:   columns,
-		}
-	case "FOREIGN":
-		p.mustNextToken()
-		p.expectKeyword("KEY")
-		p.expectToken(sqltoken.LParen)
-		columns, err := p.parseColumnNames()
-		if err != nil {
-			return nil, errors.Errorf("parseColumnNames failed: %w", err)
-		}
-		p.expectToken(sqltoken.RParen)
-		p.expectKeyword("REFERENCES")
+	}
+}
 
-		t, _ := p.nextToken()
-		w := t.Value.(*sqltoken.SQLWord)
-		p.expectToken(sqltoken.LParen)
-		refcolumns, err := p.parseColumnNames()
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %+v", r)
-		}
-		keys := &sqlast.ReferenceKeyExpr{
-			TableName: &sqlast.Ident{
-				From:  t.From,
-				To:    t.To,
-				Value: w.String(),
-			},
-			Columns: refcolumns,
-			RParen:  r.To,
-		}
+func (p *Parser) parseCreateSchema(create *sqltoken.Token) (sqlast.Stmt, error) {
+	notExists, _, _ := p.parseKeywords("IF", "NOT", "EXISTS")
+	stmt := &sqlast.CreateSchemaStmt{
+		Create:    create.From,
+		NotExists: notExists,
+	}
 
-		spec = &sqlast.ReferentialTableConstraint{
-			Foreign: tok.From,
-			Columns: columns,
-			KeyExpr: keys,
-		}
-	case "CHECK":
-		p.mustNextToken()
-		p.expectToken(sqltoken.LParen)
-		expr, err := p.ParseExpr()
+	if ok, _, _ := p.parseKeyword("AUTHORIZATION"); !ok {
+		name, err := p.parseObjectName()
 		if err != nil {
-			return nil, errors.Errorf("ParseExpr failed: %w", err)
-		}
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %+v", r)
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
 		}
-		spec = &sqlast.CheckTableConstraint{
-			Expr:   expr,
-			Check:  tok.From,
-			RParen: r.To,
+		stmt.Name = name
+		if ok, _, _ := p.parseKeyword("AUTHORIZATION"); !ok {
+			return stmt, nil
 		}
-	default:
-		return nil, errors.Errorf("unknown table constraint: %v", word)
 	}
 
-	return &sqlast.TableConstraint{
-		Name:       name,
-		Spec:       spec,
-		Constraint: constraintPos,
-	}, nil
+	auth, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	stmt.Authorization = auth
+
+	return stmt, nil
 }
 
-// TODO rethink mysql create table AST
-func (p *Parser) parseColumnDefinition() (sqlast.Node, []*sqlast.ColumnConstraint, []sqlast.MyDataTypeDecoration, error) {
-	var specs []*sqlast.ColumnConstraint
-	var def sqlast.Node
-	var decorates []sqlast.MyDataTypeDecoration
+var databaseOptions = [][]string{
+	// PostgreSQL
+	{"OWNER"}, {"TEMPLATE"}, {"ENCODING"}, {"LOCALE"}, {"LC_COLLATE"}, {"LC_CTYPE"}, {"TABLESPACE"},
+	{"ALLOW_CONNECTIONS"}, {"CONNECTION", "LIMIT"}, {"IS_TEMPLATE"}, {"STRATEGY"},
+	{"ICU_LOCALE"}, {"LOCALE_PROVIDER"}, {"COLLATION_VERSION"}, {"OID"},
+	// MySQL
+	{"DEFAULT", "CHARACTER", "SET"}, {"CHARACTER", "SET"}, {"DEFAULT", "CHARSET"}, {"CHARSET"},
+	{"DEFAULT", "COLLATE"}, {"COLLATE"}, {"DEFAULT", "ENCRYPTION"}, {"ENCRYPTION"},
+}
+
+func (p *Parser) parseCreateDatabase(create *sqltoken.Token) (sqlast.Stmt, error) {
+	notExists, _, _ := p.parseKeywords("IF", "NOT", "EXISTS")
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+	with, _, _ := p.parseKeyword("WITH")
+
+	stmt := &sqlast.CreateDatabaseStmt{
+		Create:    create.From,
+		NotExists: notExists,
+		Name:      name,
+		With:      with,
+	}
 
-COLUMN_DEF_LOOP:
 	for {
-		t, _ := p.peekToken()
-		if t == nil || t.Kind != sqltoken.SQLKeyword {
-			break
+		option, err := p.parseDatabaseOption()
+		if err != nil {
+			return nil, errors.Errorf("parseDatabaseOption failed: %w", err)
+		}
+		if option == nil {
+			return stmt, nil
 		}
+		stmt.Options = append(stmt.Options, option)
+	}
+}
 
-		word := t.Value.(*sqltoken.SQLWord)
+func (p *Parser) parseDatabaseOption() (*sqlast.DatabaseOption, error) {
+	for _, option := range databaseOptions {
+		ok, toks, _ := p.parseKeywords(option...)
+		if !ok {
+			continue
+		}
+		eq, _ := p.consumeToken(sqltoken.Eq)
 
-		switch word.Keyword {
-		case "DEFAULT":
-			if ok, _, _ := p.parseKeyword("DEFAULT"); ok {
-				d, err := p.parseDefaultExpr(0)
-				if err != nil {
-					return nil, nil, nil, errors.Errorf("parseDefaultExpr failed: %w", err)
-				}
-				def = d
-				continue
+		tok, _ := p.peekToken()
+		if tok == nil {
+			return nil, errors.Errorf("expected value of %s but EOF", strings.Join(option, " "))
+		}
+		var value sqlast.Node
+		if word, ok := tok.Value.(*sqltoken.SQLWord); ok && word.Keyword != "TRUE" && word.Keyword != "FALSE" {
+			p.mustNextToken()
+			value = newIdent(tok)
+		} else if tok.Kind == sqltoken.Minus {
+			v, err := p.parseSignedLong()
+			if err != nil {
+				return nil, errors.Errorf("parseSignedLong failed: %w", err)
 			}
-		case "CONSTRAINT", "NOT", "UNIQUE", "PRIMARY", "REFERENCES", "CHECK":
-			s, err := p.parseColumnConstraints()
+			value = v
+		} else {
+			v, err := p.parseSQLValue()
 			if err != nil {
-				return nil, nil, nil, errors.Errorf("parseColumnConstraints failed: %w", err)
+				return nil, errors.Errorf("parseSQLValue failed: %w", err)
 			}
-			specs = s
-		case "AUTO_INCREMENT":
-			p.mustNextToken()
-			decorates = append(decorates, &sqlast.AutoIncrement{
-				Auto:      t.From,
-				Increment: t.To,
-			})
-		default:
-			break COLUMN_DEF_LOOP
+			value = v
 		}
+
+		return &sqlast.DatabaseOption{
+			From:  toks[0].From,
+			Name:  strings.Join(option, " "),
+			Equal: eq,
+			Value: value,
+		}, nil
 	}
-	return def, specs, decorates, nil
+	return nil, nil
 }
 
-func (p *Parser) parseColumnConstraints() ([]*sqlast.ColumnConstraint, error) {
-	var constraints []*sqlast.ColumnConstraint
+func (p *Parser) parseCreateExtension(create *sqltoken.Token) (sqlast.Stmt, error) {
+	notExists, _, _ := p.parseKeywords("IF", "NOT", "EXISTS")
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	with, _, _ := p.parseKeyword("WITH")
 
-CONSTRAINT_LOOP:
-	for {
-		tok, err := p.peekToken()
-		if tok == nil {
-			break CONSTRAINT_LOOP
-		}
+	stmt := &sqlast.CreateExtensionStmt{
+		Create:    create.From,
+		NotExists: notExists,
+		Name:      name,
+		With:      with,
+	}
+
+	if ok, _, _ := p.parseKeyword("SCHEMA"); ok {
+		schema, err := p.parseIdentifier()
 		if err != nil {
-			return nil, errors.Errorf("peekToken failed: %w", err)
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
 		}
-		word, ok := tok.Value.(*sqltoken.SQLWord)
+		stmt.Schema = schema
+	}
 
-		var name *sqlast.Ident
-		if ok && word.Keyword == "CONSTRAINT" {
-			p.mustNextToken()
-			i, err := p.parseIdentifier()
+	if ok, _, _ := p.parseKeyword("VERSION"); ok {
+		tok, _ := p.peekToken()
+		if tok != nil && tok.Kind == sqltoken.SQLKeyword {
+			v, err := p.parseIdentifier()
 			if err != nil {
 				return nil, errors.Errorf("parseIdentifier failed: %w", err)
 			}
-			name = i
+			stmt.Version = v
+		} else {
+			v, err := p.parseSQLValue()
+			if err != nil {
+				return nil, errors.Errorf("parseSQLValue failed: %w", err)
+			}
+			stmt.Version = v
 		}
+	}
 
-		tok, _ = p.peekToken()
-		if tok.Kind != sqltoken.SQLKeyword {
-			break
-		}
+	if ok, tok, _ := p.parseKeyword("CASCADE"); ok {
+		stmt.Cascade = true
+		stmt.CascadeTo = tok.To
+	}
 
-		var spec sqlast.ColumnConstraintSpec
+	return stmt, nil
+}
 
-		word = tok.Value.(*sqltoken.SQLWord)
-		switch word.Keyword {
-		case "NOT":
-			p.mustNextToken()
-			ok, ntok, _ := p.parseKeyword("NULL")
-			if !ok {
-				return nil, errors.Errorf("expected NULL but +%v", ntok)
-			}
-			spec = &sqlast.NotNullColumnSpec{
-				Not:  tok.From,
-				Null: ntok.To,
-			}
-		case "UNIQUE":
-			p.mustNextToken()
-			spec = &sqlast.UniqueColumnSpec{
-				Unique: tok.From,
+func (p *Parser) parseCreateSequence(create *sqltoken.Token, temporary bool) (sqlast.Stmt, error) {
+	notExists, _, _ := p.parseKeywords("IF", "NOT", "EXISTS")
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+
+	options, err := p.parseSequenceOptions(false)
+	if err != nil {
+		return nil, errors.Errorf("parseSequenceOptions failed: %w", err)
+	}
+
+	return &sqlast.CreateSequenceStmt{
+		Create:    create.From,
+		Temporary: temporary,
+		NotExists: notExists,
+		Name:      name,
+		Options:   options,
+	}, nil
+}
+
+func (p *Parser) parseAlterSequence(alter *sqltoken.Token) (sqlast.Stmt, error) {
+	ifExists, _, _ := p.parseKeywords("IF", "EXISTS")
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+
+	options, err := p.parseSequenceOptions(true)
+	if err != nil {
+		return nil, errors.Errorf("parseSequenceOptions failed: %w", err)
+	}
+	if len(options) == 0 {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected sequence option but %+v", tok)
+	}
+
+	return &sqlast.AlterSequenceStmt{
+		Alter:    alter.From,
+		IfExists: ifExists,
+		Name:     name,
+		Options:  options,
+	}, nil
+}
+
+// parseSequenceOptions parses options of CREATE SEQUENCE, or ALTER SEQUENCE if alter is true.
+func (p *Parser) parseSequenceOptions(alter bool) ([]sqlast.SequenceOption, error) {
+	var options []sqlast.SequenceOption
+
+	for {
+		if ok, tok, _ := p.parseKeyword("AS"); ok {
+			tp, err := p.ParseDataType()
+			if err != nil {
+				return nil, errors.Errorf("ParseDataType failed: %w", err)
 			}
-		case "PRIMARY":
-			p.mustNextToken()
-			ok, ktok, _ := p.parseKeyword("KEY")
-			if !ok {
-				return nil, errors.Errorf("expected KEY but +%v", ktok)
+			options = append(options, &sqlast.SequenceDataType{As: tok.From, DataType: tp})
+			continue
+		}
+
+		if ok, tok, _ := p.parseKeyword("INCREMENT"); ok {
+			by, _, _ := p.parseKeyword("BY")
+			v, err := p.parseSignedLong()
+			if err != nil {
+				return nil, errors.Errorf("parseSignedLong failed: %w", err)
 			}
-			spec = &sqlast.UniqueColumnSpec{IsPrimaryKey: true, Primary: tok.From, Key: ktok.To}
-		case "REFERENCES":
-			p.mustNextToken()
-			tname, err := p.parseObjectName()
+			options = append(options, &sqlast.SequenceIncrement{Increment: tok.From, By: by, Value: v})
+			continue
+		}
+
+		if ok, toks, _ := p.parseKeywords("NO", "MINVALUE"); ok {
+			options = append(options, &sqlast.SequenceMinValue{From: toks[0].From, To: toks[1].To})
+			continue
+		}
+		if ok, tok, _ := p.parseKeyword("MINVALUE"); ok {
+			v, err := p.parseSignedLong()
 			if err != nil {
-				return nil, errors.Errorf("parseObjectName failed: %w", err)
+				return nil, errors.Errorf("parseSignedLong failed: %w", err)
 			}
-			p.expectToken(sqltoken.LParen)
-			columns, err := p.parseColumnNames()
+			options = append(options, &sqlast.SequenceMinValue{From: tok.From, To: tok.To, Value: v})
+			continue
+		}
+
+		if ok, toks, _ := p.parseKeywords("NO", "MAXVALUE"); ok {
+			options = append(options, &sqlast.SequenceMaxValue{From: toks[0].From, To: toks[1].To})
+			continue
+		}
+		if ok, tok, _ := p.parseKeyword("MAXVALUE"); ok {
+			v, err := p.parseSignedLong()
 			if err != nil {
-				return nil, errors.Errorf("parseColumnNames failed: %w", err)
+				return nil, errors.Errorf("parseSignedLong failed: %w", err)
 			}
-			r, _ := p.nextToken()
-			if r.Kind != sqltoken.RParen {
-				return nil, errors.Errorf("expected RParen but %+v", r)
+			options = append(options, &sqlast.SequenceMaxValue{From: tok.From, To: tok.To, Value: v})
+			continue
+		}
+
+		if ok, tok, _ := p.parseKeyword("START"); ok {
+			with, _, _ := p.parseKeyword("WITH")
+			v, err := p.parseSignedLong()
+			if err != nil {
+				return nil, errors.Errorf("parseSignedLong failed: %w", err)
 			}
-			spec = &sqlast.ReferencesColumnSpec{
-				TableName:  tname,
-				Columns:    columns,
-				References: tok.From,
-				RParen:     r.To,
+			options = append(options, &sqlast.SequenceStart{Start: tok.From, With: with, Value: v})
+			continue
+		}
+
+		if ok, tok, _ := p.parseKeyword("RESTART"); ok && alter {
+			restart := &sqlast.SequenceRestart{Restart: tok.From, To: tok.To}
+			with, _, _ := p.parseKeyword("WITH")
+			if t, _ := p.peekToken(); with || (t != nil && (t.Kind == sqltoken.Number || t.Kind == sqltoken.Minus)) {
+				v, err := p.parseSignedLong()
+				if err != nil {
+					return nil, errors.Errorf("parseSignedLong failed: %w", err)
+				}
+				restart.With = with
+				restart.Value = v
 			}
-		case "CHECK":
-			p.mustNextToken()
-			p.expectToken(sqltoken.LParen)
-			expr, err := p.ParseExpr()
+			options = append(options, restart)
+			continue
+		} else if ok {
+			return nil, errors.Errorf("RESTART is only allowed in ALTER SEQUENCE")
+		}
+
+		if ok, tok, _ := p.parseKeyword("CACHE"); ok {
+			v, err := p.parseSignedLong()
 			if err != nil {
-				return nil, errors.Errorf("ParseExpr failed: %w", err)
-			}
-			r, _ := p.nextToken()
-			if r.Kind != sqltoken.RParen {
-				return nil, errors.Errorf("expected RParen but %+v", r)
+				return nil, errors.Errorf("parseSignedLong failed: %w", err)
 			}
-			spec = &sqlast.CheckColumnSpec{
-				Check:  tok.From,
-				Expr:   expr,
-				RParen: r.To,
+			options = append(options, &sqlast.SequenceCache{Cache: tok.From, Value: v})
+			continue
+		}
+
+		if ok, toks, _ := p.parseKeywords("NO", "CYCLE"); ok {
+			options = append(options, &sqlast.SequenceCycle{From: toks[0].From, To: toks[1].To, No: true})
+			continue
+		}
+		if ok, tok, _ := p.parseKeyword("CYCLE"); ok {
+			options = append(options, &sqlast.SequenceCycle{From: tok.From, To: tok.To})
+			continue
+		}
+
+		if ok, toks, _ := p.parseKeywords("OWNED", "BY"); ok {
+			owned := &sqlast.SequenceOwnedBy{Owned: toks[0].From}
+			if ok, none, _ := p.parseKeyword("NONE"); ok {
+				owned.To = none.To
+			} else {
+				column, err := p.parseObjectName()
+				if err != nil {
+					return nil, errors.Errorf("parseObjectName failed: %w", err)
+				}
+				owned.Column = column
 			}
-		default:
-			break CONSTRAINT_LOOP
+			options = append(options, owned)
+			continue
 		}
 
-		constraints = append(constraints, &sqlast.ColumnConstraint{
-			Name: name,
-			Spec: spec,
-		})
+		return options, nil
+	}
+}
 
+// parseSignedLong parses an integer literal with an optional minus sign.
+func (p *Parser) parseSignedLong() (*sqlast.LongValue, error) {
+	tok, _ := p.nextToken()
+	if tok == nil {
+		return nil, errors.Errorf("expected number but EOF")
 	}
-	return constraints, nil
+	from := tok.From
+	sign := ""
+	if tok.Kind == sqltoken.Minus {
+		sign = "-"
+		tok, _ = p.nextToken()
+	}
+	if tok == nil || tok.Kind != sqltoken.Number {
+		return nil, errors.Errorf("expected number but %+v", tok)
+	}
+	i, err := strconv.ParseInt(sign+tok.Value.(string), 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("strconv.ParseInt failed: %w", err)
+	}
+	return &sqlast.LongValue{From: from, To: tok.To, Long: i}, nil
 }
 
-func (p *Parser) parseTableOptions() ([]sqlast.TableOption, error) {
-	var opts []sqlast.TableOption
+func (p *Parser) parseCreateIndex(unique bool) (sqlast.Stmt, error) {
+	var indexName *sqlast.Ident
+	ok, _, _ := p.parseKeyword("ON")
+	if !ok {
+		if n, err := p.parseIdentifier(); err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		} else {
+			indexName = n
+		}
+		p.expectKeyword("ON")
+	}
 
-	for {
-		tok, err := p.peekToken()
-		if err == EOF {
-			return opts, nil
-		} else if err != nil {
-			return nil, err
+	tableName, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+	var methodName *sqlast.Ident
+
+	if ok, _, _ := p.parseKeyword("USING"); ok {
+		m, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
 		}
+		methodName = m
+	}
 
-		if tok.Kind == sqltoken.Comma {
-			tok = p.mustNextToken()
+	var columns []*sqlast.Ident
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		columns, err = p.parseColumnNames()
+		if err != nil {
+			return nil, errors.Errorf("parseColumnNames failed: %w", err)
 		}
-		if tok.Kind != sqltoken.SQLKeyword {
-			break
+		p.expectToken(sqltoken.RParen)
+	}
+
+	var with *sqlast.WithOptions
+	if ok, tok, _ := p.parseKeyword("WITH"); ok {
+		with, err = p.parseWithOptions(tok)
+		if err != nil {
+			return nil, errors.Errorf("parseWithOptions failed: %w", err)
 		}
-		opt, err := p.parseTableOption()
+	}
+
+	var tablespace *sqlast.Tablespace
+	if ok, tok, _ := p.parseKeyword("TABLESPACE"); ok {
+		tablespace, err = p.parseTablespace(tok)
 		if err != nil {
-			p.Debug()
-			return nil, errors.Errorf("parseTableOption failed: %w", err)
+			return nil, errors.Errorf("parseTablespace failed: %w", err)
 		}
-		opts = append(opts, opt)
 	}
 
-	return opts, nil
+	var selection sqlast.Node
+	if ok, _, _ := p.parseKeyword("WHERE"); ok {
+		s, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		selection = s
+	}
+
+	return &sqlast.CreateIndexStmt{
+		IsUnique:    unique,
+		IndexName:   indexName,
+		TableName:   tableName,
+		MethodName:  methodName,
+		ColumnNames: columns,
+		With:        with,
+		Tablespace:  tablespace,
+		Selection:   selection,
+	}, nil
 }
 
-func (p *Parser) parseTableOption() (sqlast.TableOption, error) {
-	tok, _ := p.peekToken()
-	if tok.Kind != sqltoken.SQLKeyword {
-		return nil, errors.Errorf("must be SQLKeyword but: %v", tok)
+// parseElements parses `(Elements...)` of CREATE TABLE. Columns have no data
+// type if partition, i.e: of PARTITION OF.
+func (p *Parser) parseElements(partition bool) ([]sqlast.TableElement, error) {
+	var elements []sqlast.TableElement
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		return elements, nil
 	}
-	word, _ := tok.Value.(*sqltoken.SQLWord)
 
-	p.mustNextToken()
-	switch word.Keyword {
-	case "ENGINE":
-		opt := &sqlast.MyEngine{
-			Engine: tok.From,
+	for {
+		tok, _ := p.nextToken()
+		if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+			return nil, errors.Errorf("parse error after column def %+v", tok)
 		}
-		t, _ := p.peekToken()
-		if t.Kind == sqltoken.Eq {
-			opt.Equal = true
-			p.mustNextToken()
-			t, _ = p.peekToken()
+
+		word := tok.Value.(*sqltoken.SQLWord)
+		switch word.Keyword {
+		case "CONSTRAINT", "PRIMARY", "CHECK", "FOREIGN", "UNIQUE":
+			p.prevToken()
+			constraints, err := p.parseTableConstraints()
+			if err != nil {
+				return nil, errors.Errorf("parseTableConstraints failed: %w", err)
+			}
+			elements = append(elements, constraints)
+
+		default:
+			p.prevToken()
+			if partition {
+				col, err := p.parsePartitionColumn()
+				if err != nil {
+					return nil, errors.Errorf("parsePartitionColumn failed: %w", err)
+				}
+				elements = append(elements, col)
+				break
+			}
+			def, err := p.parseColumnDef()
+			if err != nil {
+				return nil, errors.Errorf("parseColumnDef failed: %w", err)
+			}
+
+			elements = append(elements, def)
 		}
 
-		if t.Kind != sqltoken.SQLKeyword {
-			return nil, errors.Errorf("expected '=' or 'engine_name' but: %v", t)
+		t, _ := p.nextToken()
+		if t == nil || (t.Kind != sqltoken.Comma && t.Kind != sqltoken.RParen) {
+			return nil, errors.Errorf("expected ',' or ')' after column definition but %+v", t)
+		} else if t.Kind == sqltoken.RParen {
+			break
 		}
-		name, _ := p.parseIdentifier()
-		opt.Name = name
-		return opt, nil
-	case "DEFAULT":
-		opt := &sqlast.MyCharset{
-			IsDefault: true,
-			Default:   tok.From,
+	}
+
+	return elements, nil
+}
+
+func (p *Parser) parseColumnDef() (*sqlast.ColumnDef, error) {
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+
+	dataType, err := p.ParseDataType()
+	if err != nil {
+		return nil, errors.Errorf("ParseDataType failed: %w", err)
+	}
+
+	var charset *sqlast.Ident
+	if p.mySQLSyntax() {
+		ok, _, _ := p.parseKeywords("CHARACTER", "SET")
+		if !ok {
+			ok, _, _ = p.parseKeyword("CHARSET")
 		}
-		ok, t, err := p.parseKeyword("CHARSET")
-		if !ok || err != nil {
-			return nil, errors.Errorf("expected CHARSET but: %v", t)
+		if ok {
+			charset, err = p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
 		}
-		opt.Charset = t.From
+	}
 
-		t, _ = p.peekToken()
-		if t.Kind == sqltoken.Eq {
-			opt.Equal = true
-			p.mustNextToken()
-			t, _ = p.peekToken()
+	var collation *sqlast.ObjectName
+	if ok, _, _ := p.parseKeyword("COLLATE"); ok {
+		collation, err = p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+	}
+
+	def, specs, decorates, err := p.parseColumnDefinition()
+	if err != nil {
+		return nil, errors.Errorf("parseColumnDefinition: %w", err)
+	}
+
+	return &sqlast.ColumnDef{
+		Constraints:          specs,
+		Name:                 name,
+		MyDataTypeDecoration: decorates,
+		DataType:             dataType,
+		Charset:              charset,
+		Collation:            collation,
+		Default:              def,
+	}, nil
+}
+
+// parsePartitionColumn parses `Name [WITH OPTIONS] [Constraints...]` of
+// PARTITION OF.
+func (p *Parser) parsePartitionColumn() (*sqlast.PartitionColumn, error) {
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	col := &sqlast.PartitionColumn{Name: name}
+	if ok, toks, _ := p.parseKeywords("WITH", "OPTIONS"); ok {
+		col.WithOptions = true
+		col.Options = toks[1].To
+	}
+
+	def, specs, decorates, err := p.parseColumnDefinition()
+	if err != nil {
+		return nil, errors.Errorf("parseColumnDefinition: %w", err)
+	}
+	if len(decorates) != 0 {
+		return nil, errors.Errorf("unexpected %+v in column of PARTITION OF", decorates[0])
+	}
+	col.Default = def
+	col.Constraints = specs
+	return col, nil
+}
+
+func (p *Parser) parseTableConstraints() (*sqlast.TableConstraint, error) {
+	tok, _ := p.peekToken()
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		return nil, errors.Errorf("parse error after column def")
+	}
+
+	word, ok := tok.Value.(*sqltoken.SQLWord)
+
+	var constraintPos sqltoken.Pos
+	var name *sqlast.Ident
+	if ok && word.Keyword == "CONSTRAINT" {
+		constraintPos = tok.From
+		p.mustNextToken()
+		i, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		name = i
+	}
+
+	tok, _ = p.peekToken()
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		return nil, errors.Errorf("expected table constraint but %v", tok)
+	}
+
+	var spec sqlast.TableConstraintSpec
+	word = tok.Value.(*sqltoken.SQLWord)
+	switch word.Keyword {
+	case "UNIQUE":
+		p.mustNextToken()
+		if _, _, err := p.parseKeyword("KEY"); err != nil {
+			return nil, errors.Errorf("parseKeyword failed: %w", err)
+		}
+		p.expectToken(sqltoken.LParen)
+		columns, err := p.parseColumnNames()
+		if err != nil {
+			return nil, errors.Errorf("parseColumnNames failed: %w", err)
+		}
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
+		}
+		spec = &sqlast.UniqueTableConstraint{
+			Unique:  tok.From,
+			RParen:  r.To,
+			Columns: columns,
+		}
+	case "PRIMARY":
+		p.mustNextToken()
+		p.expectKeyword("KEY")
+		p.expectToken(sqltoken.LParen)
+		columns, err := p.parseColumnNames()
+		if err != nil {
+			return nil, errors.Errorf("parseColumnNames failed: %w", err)
+		}
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
+		}
+		spec = &sqlast.UniqueTableConstraint{
+			Primary:   tok.From,
+			RParen:    r.To,
+			IsPrimary: true,
+			Columns:   columns,
+		}
+	case "FOREIGN":
+		p.mustNextToken()
+		p.expectKeyword("KEY")
+		p.expectToken(sqltoken.LParen)
+		columns, err := p.parseColumnNames()
+		if err != nil {
+			return nil, errors.Errorf("parseColumnNames failed: %w", err)
+		}
+		p.expectToken(sqltoken.RParen)
+		p.expectKeyword("REFERENCES")
+
+		table, err := p.parseWord()
+		if err != nil {
+			return nil, errors.Errorf("parseWord failed: %w", err)
+		}
+		p.expectToken(sqltoken.LParen)
+		refcolumns, err := p.parseColumnNames()
+		if err != nil {
+			return nil, errors.Errorf("parseColumnNames failed: %w", err)
+		}
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
+		}
+		keys := &sqlast.ReferenceKeyExpr{
+			TableName: table,
+			Columns:   refcolumns,
+			RParen:    r.To,
+		}
+
+		spec = &sqlast.ReferentialTableConstraint{
+			Foreign: tok.From,
+			Columns: columns,
+			KeyExpr: keys,
+		}
+	case "CHECK":
+		p.mustNextToken()
+		p.expectToken(sqltoken.LParen)
+		expr, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
+		}
+		spec = &sqlast.CheckTableConstraint{
+			Expr:   expr,
+			Check:  tok.From,
+			RParen: r.To,
+		}
+	default:
+		return nil, errors.Errorf("unknown table constraint: %v", word)
+	}
+
+	return &sqlast.TableConstraint{
+		Name:       name,
+		Spec:       spec,
+		Constraint: constraintPos,
+	}, nil
+}
+
+// TODO rethink mysql create table AST
+func (p *Parser) parseColumnDefinition() (sqlast.Node, []*sqlast.ColumnConstraint, []sqlast.MyDataTypeDecoration, error) {
+	var specs []*sqlast.ColumnConstraint
+	var def sqlast.Node
+	var decorates []sqlast.MyDataTypeDecoration
+
+COLUMN_DEF_LOOP:
+	for {
+		t, _ := p.peekToken()
+		if t == nil || t.Kind != sqltoken.SQLKeyword {
+			break
+		}
+
+		word := t.Value.(*sqltoken.SQLWord)
+
+		switch word.Keyword {
+		case "DEFAULT":
+			if ok, _, _ := p.parseKeyword("DEFAULT"); ok {
+				d, err := p.parseDefaultExpr(0)
+				if err != nil {
+					return nil, nil, nil, errors.Errorf("parseDefaultExpr failed: %w", err)
+				}
+				def = d
+				continue
+			}
+		case "CONSTRAINT", "NOT", "UNIQUE", "PRIMARY", "REFERENCES", "CHECK", "GENERATED", "AS":
+			if word.Keyword == "AS" && !p.mySQLSyntax() {
+				break COLUMN_DEF_LOOP
+			}
+			s, err := p.parseColumnConstraints()
+			if err != nil {
+				return nil, nil, nil, errors.Errorf("parseColumnConstraints failed: %w", err)
+			}
+			specs = append(specs, s...)
+		case "AUTO_INCREMENT":
+			p.mustNextToken()
+			decorates = append(decorates, &sqlast.AutoIncrement{
+				Auto:      t.From,
+				Increment: t.To,
+			})
+		case "ON":
+			if !p.mySQLSyntax() {
+				break COLUMN_DEF_LOOP
+			}
+			if ok, _, _ := p.parseKeywords("ON", "UPDATE"); !ok {
+				break COLUMN_DEF_LOOP
+			}
+			e, err := p.parseDefaultExpr(0)
+			if err != nil {
+				return nil, nil, nil, errors.Errorf("parseDefaultExpr failed: %w", err)
+			}
+			decorates = append(decorates, &sqlast.MyOnUpdate{On: t.From, Expr: e})
+		case "COMMENT":
+			if !p.mySQLSyntax() {
+				break COLUMN_DEF_LOOP
+			}
+			p.mustNextToken()
+			c, err := p.parseSingleQuotedString()
+			if err != nil {
+				return nil, nil, nil, errors.Errorf("parseSingleQuotedString failed: %w", err)
+			}
+			decorates = append(decorates, &sqlast.MyColumnComment{From: t.From, Comment: c})
+		default:
+			break COLUMN_DEF_LOOP
+		}
+	}
+	return def, specs, decorates, nil
+}
+
+func (p *Parser) parseColumnConstraints() ([]*sqlast.ColumnConstraint, error) {
+	var constraints []*sqlast.ColumnConstraint
+
+CONSTRAINT_LOOP:
+	for {
+		tok, err := p.peekToken()
+		if tok == nil {
+			break CONSTRAINT_LOOP
+		}
+		if err != nil {
+			return nil, errors.Errorf("peekToken failed: %w", err)
+		}
+		word, ok := tok.Value.(*sqltoken.SQLWord)
+
+		var name *sqlast.Ident
+		if ok && word.Keyword == "CONSTRAINT" {
+			p.mustNextToken()
+			i, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			name = i
+		}
+
+		tok, _ = p.peekToken()
+		if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+			break
+		}
+
+		var spec sqlast.ColumnConstraintSpec
+
+		word = tok.Value.(*sqltoken.SQLWord)
+		switch word.Keyword {
+		case "NOT":
+			p.mustNextToken()
+			ok, ntok, _ := p.parseKeyword("NULL")
+			if !ok {
+				return nil, errors.Errorf("expected NULL but +%v", ntok)
+			}
+			spec = &sqlast.NotNullColumnSpec{
+				Not:  tok.From,
+				Null: ntok.To,
+			}
+		case "UNIQUE":
+			p.mustNextToken()
+			spec = &sqlast.UniqueColumnSpec{
+				Unique: tok.From,
+			}
+		case "PRIMARY":
+			p.mustNextToken()
+			ok, ktok, _ := p.parseKeyword("KEY")
+			if !ok {
+				return nil, errors.Errorf("expected KEY but +%v", ktok)
+			}
+			spec = &sqlast.UniqueColumnSpec{IsPrimaryKey: true, Primary: tok.From, Key: ktok.To}
+		case "REFERENCES":
+			p.mustNextToken()
+			tname, err := p.parseObjectName()
+			if err != nil {
+				return nil, errors.Errorf("parseObjectName failed: %w", err)
+			}
+			p.expectToken(sqltoken.LParen)
+			columns, err := p.parseColumnNames()
+			if err != nil {
+				return nil, errors.Errorf("parseColumnNames failed: %w", err)
+			}
+			r, _ := p.nextToken()
+			if r == nil || r.Kind != sqltoken.RParen {
+				return nil, errors.Errorf("expected RParen but %+v", r)
+			}
+			spec = &sqlast.ReferencesColumnSpec{
+				TableName:  tname,
+				Columns:    columns,
+				References: tok.From,
+				RParen:     r.To,
+			}
+		case "CHECK":
+			p.mustNextToken()
+			p.expectToken(sqltoken.LParen)
+			expr, err := p.ParseExpr()
+			if err != nil {
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+			r, _ := p.nextToken()
+			if r == nil || r.Kind != sqltoken.RParen {
+				return nil, errors.Errorf("expected RParen but %+v", r)
+			}
+			spec = &sqlast.CheckColumnSpec{
+				Check:  tok.From,
+				Expr:   expr,
+				RParen: r.To,
+			}
+		case "GENERATED":
+			p.mustNextToken()
+			s, err := p.parseGeneratedColumnSpec(tok)
+			if err != nil {
+				return nil, errors.Errorf("parseGeneratedColumnSpec failed: %w", err)
+			}
+			spec = s
+		case "AS":
+			if !p.mySQLSyntax() {
+				break CONSTRAINT_LOOP
+			}
+			p.mustNextToken()
+			s, err := p.parseGenerationExpr(&sqlast.GeneratedColumnSpec{From: tok.From})
+			if err != nil {
+				return nil, errors.Errorf("parseGenerationExpr failed: %w", err)
+			}
+			spec = s
+		default:
+			break CONSTRAINT_LOOP
+		}
+
+		constraints = append(constraints, &sqlast.ColumnConstraint{
+			Name: name,
+			Spec: spec,
+		})
+
+	}
+	return constraints, nil
+}
+
+// parseGeneratedColumnSpec parses the rest of
+// `GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY [(Options...)]` or
+// `GENERATED ALWAYS AS (Expr) [STORED | VIRTUAL]`.
+func (p *Parser) parseGeneratedColumnSpec(generated *sqltoken.Token) (sqlast.ColumnConstraintSpec, error) {
+	spec := &sqlast.IdentityColumnSpec{Generated: generated.From}
+	if ok, _, _ := p.parseKeyword("ALWAYS"); ok {
+		spec.Always = true
+	} else if ok, _, _ := p.parseKeywords("BY", "DEFAULT"); !ok {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected ALWAYS or BY DEFAULT but %+v", tok)
+	}
+	p.expectKeyword("AS")
+	if t, _ := p.peekToken(); spec.Always && t != nil && t.Kind == sqltoken.LParen {
+		return p.parseGenerationExpr(&sqlast.GeneratedColumnSpec{From: generated.From, Always: true})
+	}
+	spec.Identity = p.expectKeyword("IDENTITY").To
+
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		return spec, nil
+	}
+	options, err := p.parseSequenceOptions(false)
+	if err != nil {
+		return nil, errors.Errorf("parseSequenceOptions failed: %w", err)
+	}
+	if len(options) == 0 {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected sequence option but %+v", tok)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
+	spec.Options = options
+	spec.RParen = r.To
+	return spec, nil
+}
+
+// parseGenerationExpr parses `(Expr) [STORED | VIRTUAL]` of spec.
+func (p *Parser) parseGenerationExpr(spec *sqlast.GeneratedColumnSpec) (*sqlast.GeneratedColumnSpec, error) {
+	p.expectToken(sqltoken.LParen)
+	expr, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
+	spec.Expr = expr
+	spec.RParen = r.To
+
+	if ok, tok, _ := p.parseKeyword("STORED"); ok {
+		spec.Storage = sqlast.GeneratedStored
+		spec.To = tok.To
+	} else if ok, tok, _ := p.parseKeyword("VIRTUAL"); ok {
+		spec.Storage = sqlast.GeneratedVirtual
+		spec.To = tok.To
+	}
+	return spec, nil
+}
+
+func (p *Parser) parseTableOptions() ([]sqlast.TableOption, error) {
+	var opts []sqlast.TableOption
+
+	for {
+		tok, err := p.peekToken()
+		if err == EOF {
+			return opts, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if tok.Kind == sqltoken.Comma {
+			p.mustNextToken()
+			if tok, _ = p.peekToken(); tok == nil {
+				return nil, errors.Errorf("expected table option after Comma but EOF")
+			}
+		}
+		if tok.Kind != sqltoken.SQLKeyword {
+			break
+		}
+		opt, err := p.parseTableOption()
+		if err != nil {
+			return nil, errors.Errorf("parseTableOption failed: %w", err)
+		}
+		opts = append(opts, opt)
+	}
+
+	return opts, nil
+}
+
+func (p *Parser) parseTableOption() (sqlast.TableOption, error) {
+	tok, _ := p.peekToken()
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		return nil, errors.Errorf("must be SQLKeyword but: %v", tok)
+	}
+	word, _ := tok.Value.(*sqltoken.SQLWord)
+
+	p.mustNextToken()
+	if p.clickHouse() {
+		switch word.Keyword {
+		case "ENGINE", "ORDER", "PARTITION", "PRIMARY", "SAMPLE", "SETTINGS":
+			return p.parseClickHouseTableOption(tok)
+		}
+	}
+	switch word.Keyword {
+	case "ENGINE":
+		opt := &sqlast.MyEngine{
+			Engine: tok.From,
+		}
+		t, _ := p.peekToken()
+		if t != nil && t.Kind == sqltoken.Eq {
+			opt.Equal = true
+			p.mustNextToken()
+			t, _ = p.peekToken()
+		}
+
+		if t == nil || t.Kind != sqltoken.SQLKeyword {
+			return nil, errors.Errorf("expected '=' or 'engine_name' but: %v", t)
+		}
+		name, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		opt.Name = name
+		return opt, nil
+	case "DEFAULT":
+		if p.mySQLSyntax() {
+			if ok, t, _ := p.parseKeyword("COLLATE"); ok {
+				return p.parseMyCollate(tok, t)
+			}
+			if ok, toks, _ := p.parseKeywords("CHARACTER", "SET"); ok {
+				return p.parseMyCharset(tok, toks[0])
+			}
+		}
+		ok, t, err := p.parseKeyword("CHARSET")
+		if !ok || err != nil {
+			return nil, errors.Errorf("expected CHARSET but: %v", t)
+		}
+		return p.parseMyCharset(tok, t)
+	case "CHARSET":
+		return p.parseMyCharset(nil, tok)
+	case "PARTITION":
+		return p.parsePartitionBy(tok)
+	case "WITH":
+		return p.parseWithOptions(tok)
+	case "TABLESPACE":
+		return p.parseTablespace(tok)
+	case "ON":
+		return p.parseOnCommit(tok)
+	}
+
+	if p.mySQLSyntax() {
+		switch word.Keyword {
+		case "CHARACTER":
+			p.expectKeyword("SET")
+			return p.parseMyCharset(nil, tok)
+		case "COLLATE":
+			return p.parseMyCollate(nil, tok)
+		case "AUTO_INCREMENT":
+			opt := &sqlast.MyAutoIncrement{AutoIncrement: tok.From}
+			if ok, _ := p.consumeToken(sqltoken.Eq); ok {
+				opt.Equal = true
+			}
+			n, t, err := p.parseLiteralInt()
+			if err != nil {
+				return nil, errors.Errorf("parseLiteralInt failed: %w", err)
+			}
+			opt.Value = &sqlast.LongValue{From: t.From, To: t.To, Long: int64(n)}
+			return opt, nil
+		case "COMMENT":
+			opt := &sqlast.MyTableComment{From: tok.From}
+			if ok, _ := p.consumeToken(sqltoken.Eq); ok {
+				opt.Equal = true
+			}
+			c, err := p.parseSingleQuotedString()
+			if err != nil {
+				return nil, errors.Errorf("parseSingleQuotedString failed: %w", err)
+			}
+			opt.Comment = c
+			return opt, nil
+		}
+	}
+	return nil, errors.Errorf("unsupported Table Options: %v", word)
+}
+
+// parseMyCharset parses the rest of `[DEFAULT] {CHARSET|CHARACTER SET} [=] Name`.
+// def is nil without DEFAULT.
+func (p *Parser) parseMyCharset(def, charset *sqltoken.Token) (sqlast.TableOption, error) {
+	opt := &sqlast.MyCharset{
+		Charset: charset.From,
+	}
+	if def != nil {
+		opt.IsDefault = true
+		opt.Default = def.From
+	}
+
+	t, _ := p.peekToken()
+	if t != nil && t.Kind == sqltoken.Eq {
+		opt.Equal = true
+		p.mustNextToken()
+		t, _ = p.peekToken()
+	}
+
+	if t == nil || t.Kind != sqltoken.SQLKeyword {
+		return nil, errors.Errorf("expected '=' or 'charset_name' but: %v", t)
+	}
+
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	opt.Name = name
+
+	return opt, nil
+}
+
+// parseMyCollate parses the rest of `[DEFAULT] COLLATE [=] Name`. def is nil
+// without DEFAULT.
+func (p *Parser) parseMyCollate(def, collate *sqltoken.Token) (sqlast.TableOption, error) {
+	opt := &sqlast.MyCollate{
+		Collate: collate.From,
+	}
+	if def != nil {
+		opt.IsDefault = true
+		opt.Default = def.From
+	}
+	if ok, _ := p.consumeToken(sqltoken.Eq); ok {
+		opt.Equal = true
+	}
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	opt.Name = name
+	return opt, nil
+}
+
+// parseWithOptions parses the rest of `WITH (Key [= Value], ...)`.
+func (p *Parser) parseWithOptions(with *sqltoken.Token) (*sqlast.WithOptions, error) {
+	p.expectToken(sqltoken.LParen)
+	opt := &sqlast.WithOptions{With: with.From}
+	for {
+		key, err := p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+		kv := &sqlast.KeyValueOption{Key: key}
+		if ok, _ := p.consumeToken(sqltoken.Eq); ok {
+			v, err := p.ParseExpr()
+			if err != nil {
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+			kv.Value = v
+		}
+		opt.Options = append(opt.Options, kv)
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
+	opt.RParen = r.To
+	return opt, nil
+}
+
+// parseTablespace parses the rest of `TABLESPACE Name`.
+func (p *Parser) parseTablespace(tablespace *sqltoken.Token) (*sqlast.Tablespace, error) {
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	return &sqlast.Tablespace{Tablespace: tablespace.From, Name: name}, nil
+}
+
+// parseOnCommit parses the rest of `ON COMMIT { PRESERVE ROWS | DELETE ROWS | DROP }`.
+func (p *Parser) parseOnCommit(on *sqltoken.Token) (*sqlast.OnCommit, error) {
+	p.expectKeyword("COMMIT")
+	opt := &sqlast.OnCommit{On: on.From}
+	if ok, toks, _ := p.parseKeywords("PRESERVE", "ROWS"); ok {
+		opt.Action, opt.To = sqlast.OnCommitPreserveRows, toks[1].To
+	} else if ok, toks, _ := p.parseKeywords("DELETE", "ROWS"); ok {
+		opt.Action, opt.To = sqlast.OnCommitDeleteRows, toks[1].To
+	} else if ok, tok, _ := p.parseKeyword("DROP"); ok {
+		opt.Action, opt.To = sqlast.OnCommitDrop, tok.To
+	} else {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected PRESERVE ROWS, DELETE ROWS or DROP but %+v", tok)
+	}
+	return opt, nil
+}
+
+// parsePartitionBy parses the rest of `PARTITION BY { RANGE | LIST | HASH } (Keys...)`.
+func (p *Parser) parsePartitionBy(partition *sqltoken.Token) (*sqlast.PartitionBy, error) {
+	p.expectKeyword("BY")
+	opt := &sqlast.PartitionBy{Partition: partition.From}
+	if ok, _, _ := p.parseKeyword("RANGE"); ok {
+		opt.Strategy = sqlast.PartitionByRange
+	} else if ok, _, _ := p.parseKeyword("LIST"); ok {
+		opt.Strategy = sqlast.PartitionByList
+	} else if ok, _, _ := p.parseKeyword("HASH"); ok {
+		opt.Strategy = sqlast.PartitionByHash
+	} else {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected RANGE, LIST or HASH but %+v", tok)
+	}
+
+	p.expectToken(sqltoken.LParen)
+	keys, err := p.parseExprList()
+	if err != nil {
+		return nil, errors.Errorf("parseExprList failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
+	opt.Keys = keys
+	opt.RParen = r.To
+	return opt, nil
+}
+
+// parseClickHouseTableOption parses the rest of ClickHouse table options:
+// `ENGINE = Name[(Args...)]`, `ORDER BY Expr`, `PARTITION BY Expr`,
+// `PRIMARY KEY Expr`, `SAMPLE BY Expr` and `SETTINGS name = value, ...`.
+func (p *Parser) parseClickHouseTableOption(tok *sqltoken.Token) (sqlast.TableOption, error) {
+	word := tok.Value.(*sqltoken.SQLWord)
+
+	switch word.Keyword {
+	case "ENGINE":
+		if ok, _ := p.consumeToken(sqltoken.Eq); !ok {
+			t, _ := p.peekToken()
+			return nil, errors.Errorf("expected Eq but %+v", t)
+		}
+		name, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		opt := &sqlast.CHEngine{Engine: tok.From, Name: name}
+		if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+			opt.HasArgs = true
+			if t, _ := p.peekToken(); t != nil && t.Kind != sqltoken.RParen {
+				args, err := p.parseExprList()
+				if err != nil {
+					return nil, errors.Errorf("parseExprList failed: %w", err)
+				}
+				opt.Args = args
+			}
+			r, _ := p.nextToken()
+			if r == nil || r.Kind != sqltoken.RParen {
+				return nil, errors.Errorf("expected RParen but %+v", r)
+			}
+			opt.RParen = r.To
+		}
+		return opt, nil
+	case "SETTINGS":
+		assignments, err := p.parseAssignments()
+		if err != nil {
+			return nil, errors.Errorf("parseAssignments failed: %w", err)
+		}
+		return &sqlast.CHSettings{Settings: tok.From, Assignments: assignments}, nil
+	}
+
+	var kind sqlast.CHTableKeyKind
+	second := "BY"
+	switch word.Keyword {
+	case "ORDER":
+		kind = sqlast.CHOrderBy
+	case "PARTITION":
+		kind = sqlast.CHPartitionBy
+	case "PRIMARY":
+		kind, second = sqlast.CHPrimaryKey, "KEY"
+	case "SAMPLE":
+		kind = sqlast.CHSampleBy
+	}
+	if ok, t, _ := p.parseKeyword(second); !ok {
+		return nil, errors.Errorf("expected %s but %+v", second, t)
+	}
+	expr, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+	return &sqlast.CHTableKey{Kind: kind, From: tok.From, Expr: expr}, nil
+}
+
+func (p *Parser) parseDelete() (sqlast.Stmt, error) {
+	ok, d, _ := p.parseKeyword("DELETE")
+	if !ok {
+		return nil, errors.Errorf("expect DELETE but %+v", d)
+	}
+	hints := p.parseOptimizerHints()
+
+	p.expectKeyword("FROM")
+	tableName, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+	alias := p.parseOptionalAlias(dialect.ReservedForTableAlias)
+
+	var using []sqlast.TableReference
+	if ok, _, _ := p.parseKeyword("USING"); ok {
+		using, err = p.parseFromClause()
+		if err != nil {
+			return nil, errors.Errorf("parseFromClause failed: %w", err)
+		}
+	}
+
+	var selection sqlast.Node
+	if ok, _, _ := p.parseKeyword("WHERE"); ok {
+		selection, err = p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+	}
+
+	returning, err := p.parseReturning()
+	if err != nil {
+		return nil, errors.Errorf("parseReturning failed: %w", err)
+	}
+
+	return &sqlast.DeleteStmt{
+		Delete:    d.From,
+		Hints:     hints,
+		TableName: tableName,
+		Alias:     alias,
+		Using:     using,
+		Selection: selection,
+		Returning: returning,
+	}, nil
+}
+
+func (p *Parser) parseCopy() (sqlast.Stmt, error) {
+	ok, c, _ := p.parseKeyword("COPY")
+	if !ok {
+		return nil, errors.Errorf("expect COPY but %+v", c)
+	}
+
+	stmt := &sqlast.CopyStmt{Copy: c.From}
+
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		q, err := p.parseQuery()
+		if err != nil {
+			return nil, errors.Errorf("parseQuery failed: %w", err)
+		}
+		p.expectToken(sqltoken.RParen)
+		stmt.Query = q
+	} else {
+		tableName, err := p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+		stmt.TableName = tableName
+
+		if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+			columns, err := p.parseColumnNames()
+			if err != nil {
+				return nil, errors.Errorf("parseColumnNames failed: %w", err)
+			}
+			p.expectToken(sqltoken.RParen)
+			stmt.Columns = columns
+		}
+	}
+
+	if ok, _, _ := p.parseKeyword("TO"); ok {
+		stmt.Direction = sqlast.CopyTo
+	} else if ok, t, _ := p.parseKeyword("FROM"); !ok {
+		return nil, errors.Errorf("expected FROM or TO but %+v", t)
+	} else if stmt.Query != nil {
+		return nil, errors.Errorf("COPY (query) FROM is not allowed")
+	}
+
+	target, err := p.nextToken()
+	if err != nil {
+		return nil, errors.Errorf("nextToken failed: %w", err)
+	}
+	stmt.CopyEnd = target.To
+	switch {
+	case target.Kind == sqltoken.SingleQuotedString:
+		stmt.Filename = &sqlast.SingleQuotedString{
+			From:   target.From,
+			To:     target.To,
+			String: target.Value.(string),
+		}
+	case target.Kind == sqltoken.SQLKeyword && stmt.Direction == sqlast.CopyFrom &&
+		strings.EqualFold(target.Value.(*sqltoken.SQLWord).Value, "STDIN"):
+	case target.Kind == sqltoken.SQLKeyword && stmt.Direction == sqlast.CopyTo &&
+		strings.EqualFold(target.Value.(*sqltoken.SQLWord).Value, "STDOUT"):
+	default:
+		return nil, errors.Errorf("expected filename, STDIN or STDOUT but %+v", target)
+	}
+
+	if p.redshift() {
+		if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.SQLKeyword && !strings.EqualFold(t.Value.(*sqltoken.SQLWord).Value, "WITH") {
+			options, err := p.parseBareCopyOptions()
+			if err != nil {
+				return nil, errors.Errorf("parseBareCopyOptions failed: %w", err)
+			}
+			stmt.Options = options
+			stmt.BareOptions = true
+			stmt.CopyEnd = options[len(options)-1].End()
+			return stmt, nil
+		}
+	}
+
+	withOk, _, _ := p.parseKeyword("WITH")
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		for {
+			o, err := p.parseCopyOption()
+			if err != nil {
+				return nil, errors.Errorf("parseCopyOption failed: %w", err)
+			}
+			stmt.Options = append(stmt.Options, o)
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+		}
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
+		}
+		stmt.CopyEnd = r.To
+	} else if withOk {
+		t, _ := p.peekToken()
+		return nil, errors.Errorf("expected copy options after WITH but %+v", t)
+	}
+
+	if stmt.Direction == sqlast.CopyFrom && stmt.Filename == nil {
+		if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.Semicolon {
+			p.mustNextToken()
+			rows, end, err := p.parseCopyData()
+			if err != nil {
+				return nil, errors.Errorf("parseCopyData failed: %w", err)
+			}
+			stmt.InlineData = true
+			stmt.Rows = rows
+			stmt.CopyEnd = end
+		}
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseCopyOption() (*sqlast.CopyOption, error) {
+	name, err := p.parseWord()
+	if err != nil {
+		return nil, errors.Errorf("parseWord failed: %w", err)
+	}
+
+	t, _ := p.peekToken()
+	if t == nil || t.Kind == sqltoken.Comma || t.Kind == sqltoken.RParen {
+		return &sqlast.CopyOption{Name: name}, nil
+	}
+
+	if t.Kind == sqltoken.LParen {
+		value, err := p.parseCopyOptionList()
+		if err != nil {
+			return nil, errors.Errorf("parseCopyOptionList failed: %w", err)
+		}
+		return &sqlast.CopyOption{Name: name, Value: value}, nil
+	}
+
+	value, err := p.parsePrefix()
+	if err != nil {
+		return nil, errors.Errorf("parsePrefix failed: %w", err)
+	}
+
+	return &sqlast.CopyOption{Name: name, Value: value}, nil
+}
+
+// parseCopyOptionList parses `(exprs...)` as a value of a COPY option.
+func (p *Parser) parseCopyOptionList() (*sqlast.RowValueExpr, error) {
+	l, _ := p.nextToken()
+	if l == nil || l.Kind != sqltoken.LParen {
+		return nil, errors.Errorf("expected LParen but %+v", l)
+	}
+	columns, err := p.parseExprList()
+	if err != nil {
+		return nil, errors.Errorf("parseExprList failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
+	return &sqlast.RowValueExpr{
+		Values: columns,
+		LParen: l.From,
+		RParen: r.To,
+	}, nil
+}
+
+// keywordValuedCopyOptions are the Redshift COPY and UNLOAD options which take
+// a keyword as a value without AS, i.e: `FORMAT CSV`, `COMPUPDATE OFF`.
+var keywordValuedCopyOptions = map[string]struct{}{
+	"FORMAT":     {},
+	"COMPUPDATE": {},
+	"STATUPDATE": {},
+	"PARALLEL":   {},
+	"IAM_ROLE":   {},
+	"ENCODING":   {},
+}
+
+// parseBareCopyOptions parses Redshift style COPY and UNLOAD options which
+// are separated by spaces, i.e: `IAM_ROLE 'arn' FORMAT AS CSV IGNOREHEADER 1 GZIP`.
+func (p *Parser) parseBareCopyOptions() ([]*sqlast.CopyOption, error) {
+	var options []*sqlast.CopyOption
+
+	for {
+		t, _ := p.peekToken()
+		if t == nil || t.Kind != sqltoken.SQLKeyword {
+			break
+		}
+		name, err := p.parseWord()
+		if err != nil {
+			return nil, errors.Errorf("parseWord failed: %w", err)
+		}
+		o := &sqlast.CopyOption{Name: name}
+		o.As, _, _ = p.parseKeyword("AS")
+
+		t, _ = p.peekToken()
+		switch {
+		case t == nil:
+		case t.Kind == sqltoken.SingleQuotedString || t.Kind == sqltoken.Number:
+			value, err := p.parsePrefix()
+			if err != nil {
+				return nil, errors.Errorf("parsePrefix failed: %w", err)
+			}
+			o.Value = value
+		case t.Kind == sqltoken.LParen:
+			value, err := p.parseCopyOptionList()
+			if err != nil {
+				return nil, errors.Errorf("parseCopyOptionList failed: %w", err)
+			}
+			o.Value = value
+		case t.Kind == sqltoken.SQLKeyword && (o.As || containsStr(keywordValuedCopyOptions, strings.ToUpper(name.Value))):
+			value, err := p.parseWord()
+			if err != nil {
+				return nil, errors.Errorf("parseWord failed: %w", err)
+			}
+			o.Value = value
+		}
+		if o.As && o.Value == nil {
+			return nil, errors.Errorf("expected a value after %s AS but %+v", name.Value, t)
+		}
+		options = append(options, o)
+	}
+
+	if len(options) == 0 {
+		t, _ := p.peekToken()
+		return nil, errors.Errorf("expected copy option but %+v", t)
+	}
+	return options, nil
+}
+
+func (p *Parser) parseSingleQuotedString() (*sqlast.SingleQuotedString, error) {
+	tok, _ := p.nextToken()
+	if tok == nil || tok.Kind != sqltoken.SingleQuotedString {
+		return nil, errors.Errorf("expected single quoted string but %+v", tok)
+	}
+	return &sqlast.SingleQuotedString{
+		From:            tok.From,
+		To:              tok.To,
+		String:          tok.Value.(string),
+		BackslashEscape: p.backslashEscape(),
+	}, nil
+}
+
+// parseUnload parses `UNLOAD ('query') TO 'path' Options...` of Redshift.
+func (p *Parser) parseUnload() (sqlast.Stmt, error) {
+	ok, u, _ := p.parseKeyword("UNLOAD")
+	if !ok {
+		return nil, errors.Errorf("expect UNLOAD but %+v", u)
+	}
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		t, _ := p.peekToken()
+		return nil, errors.Errorf("expected LParen but %+v", t)
+	}
+	query, err := p.parseSingleQuotedString()
+	if err != nil {
+		return nil, errors.Errorf("parseSingleQuotedString failed: %w", err)
+	}
+	if r, _ := p.nextToken(); r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
+	if ok, t, _ := p.parseKeyword("TO"); !ok {
+		return nil, errors.Errorf("expected TO but %+v", t)
+	}
+	to, err := p.parseSingleQuotedString()
+	if err != nil {
+		return nil, errors.Errorf("parseSingleQuotedString failed: %w", err)
+	}
+	options, err := p.parseBareCopyOptions()
+	if err != nil {
+		return nil, errors.Errorf("parseBareCopyOptions failed: %w", err)
+	}
+
+	return &sqlast.UnloadStmt{
+		Unload:  u.From,
+		Query:   query,
+		To:      to,
+		Options: options,
+	}, nil
+}
+
+// parseCopyData reads tab separated rows from raw tokens until `\.`.
+// Whitespace tokens must be kept by the tokenizer to restore the rows.
+func (p *Parser) parseCopyData() ([][]*string, sqltoken.Pos, error) {
+	var rows [][]*string
+	var row []*string
+	var cell strings.Builder
+	var hasCell, isNull bool
+
+	flushCell := func() {
+		if isNull {
+			row = append(row, nil)
+		} else {
+			v := cell.String()
+			row = append(row, &v)
+		}
+		cell.Reset()
+		hasCell, isNull = false, false
+	}
+
+	for ; p.index < uint(len(p.tokens)); p.index++ {
+		tok := p.tokens[p.index]
+		switch tok.Kind {
+		case sqltoken.Whitespace:
+			switch tok.Value {
+			case "\t":
+				flushCell()
+			case "\n":
+				if hasCell || len(row) != 0 {
+					flushCell()
+					rows = append(rows, row)
+					row = nil
+				}
+			default:
+				cell.WriteString(tok.Value.(string))
+				hasCell = true
+			}
+		case sqltoken.Backslash:
+			if int(p.index)+1 < len(p.tokens) {
+				next := p.tokens[p.index+1]
+				if next.Kind == sqltoken.Period && !hasCell && len(row) == 0 {
+					p.index += 2
+					return rows, next.To, nil
+				}
+				if w, ok := next.Value.(*sqltoken.SQLWord); ok && w.Value == "N" && !hasCell {
+					p.index++
+					hasCell, isNull = true, true
+					continue
+				}
+			}
+			cell.WriteString("\\")
+			hasCell = true
+		case sqltoken.SingleQuotedString:
+			cell.WriteString("'" + strings.ReplaceAll(tok.Value.(string), "'", "''") + "'")
+			hasCell = true
+		case sqltoken.NationalStringLiteral:
+			cell.WriteString("N'" + strings.ReplaceAll(tok.Value.(string), "'", "''") + "'")
+			hasCell = true
+		case sqltoken.EscapedStringLiteral, sqltoken.DollarQuotedString, sqltoken.UnicodeStringLiteral:
+			cell.WriteString(tok.Raw)
+			hasCell = true
+		default:
+			cell.WriteString(fmt.Sprint(tok.Value))
+			hasCell = true
+		}
+	}
+
+	return nil, sqltoken.Pos{}, errors.Errorf("COPY data is not terminated by \\.")
+}
+
+func (p *Parser) parseUpdate() (sqlast.Stmt, error) {
+	ok, u, _ := p.parseKeyword("UPDATE")
+	if !ok {
+		return nil, errors.Errorf("expect UPDATE but %+v", ok)
+	}
+	hints := p.parseOptimizerHints()
+	tableName, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+	p.expectKeyword("SET")
+
+	assignments, err := p.parseAssignments()
+	if err != nil {
+		return nil, errors.Errorf("parseAssignments failed: %w", err)
+	}
+
+	var selection sqlast.Node
+	if ok, _, _ := p.parseKeyword("WHERE"); ok {
+		selection, err = p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+	}
+
+	returning, err := p.parseReturning()
+	if err != nil {
+		return nil, errors.Errorf("parseReturning failed: %w", err)
+	}
+
+	return &sqlast.UpdateStmt{
+		Update:      u.From,
+		Hints:       hints,
+		TableName:   tableName,
+		Assignments: assignments,
+		Selection:   selection,
+		Returning:   returning,
+	}, nil
+
+}
+
+// parseReturning parses the optional `RETURNING select_list` of INSERT,
+// UPDATE and DELETE.
+func (p *Parser) parseReturning() ([]sqlast.SQLSelectItem, error) {
+	if ok, _, _ := p.parseKeyword("RETURNING"); !ok {
+		return nil, nil
+	}
+	returning, err := p.parseSelectList()
+	if err != nil {
+		return nil, errors.Errorf("parseSelectList failed: %w", err)
+	}
+	return returning, nil
+}
+
+func (p *Parser) parseAssignments() ([]*sqlast.Assignment, error) {
+	var assignments []*sqlast.Assignment
+
+	for {
+		p.suggestIdentifier()
+		tok, _ := p.nextToken()
+		if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+			return nil, errors.Errorf("should be sqlkeyword but %v", tok)
+		}
+
+		p.expectToken(sqltoken.Eq)
+
+		val, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+
+		assignments = append(assignments, &sqlast.Assignment{
+			ID:    newIdent(tok),
+			Value: val,
+		})
+
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+
+	return assignments, nil
+}
+
+func (p *Parser) parseInsert() (sqlast.Stmt, error) {
+	ok, i, _ := p.parseKeyword("INSERT")
+	if !ok {
+		return nil, errors.Errorf("expected INSERT but %+v", i)
+	}
+	hints := p.parseOptimizerHints()
+
+	p.expectKeyword("INTO")
+	tableName, err := p.parseObjectName()
+
+	if err != nil {
+		return nil, errors.Errorf("invalid table name: %w", err)
+	}
+	var columns []*sqlast.Ident
+
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		columns, err = p.parseColumnNames()
+		if err != nil {
+			return nil, errors.Errorf("invalid column names: %w", err)
+		}
+		p.expectToken(sqltoken.RParen)
+	}
+
+	var insertSrc sqlast.InsertSource
+	if ok, _, _ := p.parseKeyword("VALUES"); !ok {
+		q, err := p.parseQuery()
+		if err != nil {
+			return nil, errors.Errorf("invalid select source: expected query: %w", err)
+		}
+		insertSrc = &sqlast.SubQuerySource{
+			SubQuery: q,
+		}
+	} else {
+		rows, err := p.parseValuesRows()
+		if err != nil {
+			return nil, errors.Errorf("invalid insert value assign: %w", err)
+		}
+		insertSrc = &sqlast.ConstructorSource{
+			Rows: rows,
+		}
+	}
+
+	var assigns []*sqlast.Assignment
+	if ok, _, _ := p.parseKeywords("ON", "DUPLICATE", "KEY", "UPDATE"); ok {
+		assignments, err := p.parseAssignments()
+		if err != nil {
+			return nil, errors.Errorf("invalid DUPLICATE KEY UPDATE assignments: %w", err)
+		}
+		assigns = assignments
+	}
+
+	returning, err := p.parseReturning()
+	if err != nil {
+		return nil, errors.Errorf("parseReturning failed: %w", err)
+	}
+
+	return &sqlast.InsertStmt{
+		Insert:            i.From,
+		Hints:             hints,
+		TableName:         tableName,
+		Columns:           columns,
+		Source:            insertSrc,
+		UpdateAssignments: assigns,
+		Returning:         returning,
+	}, nil
+}
+
+func (p *Parser) parseAlter() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("ALTER")
+	if !ok {
+		return nil, errors.Errorf("expected ALTER but %s", tok)
+	}
+
+	if ok, _, _ := p.parseKeyword("SEQUENCE"); ok {
+		return p.parseAlterSequence(tok)
+	}
+
+	if ok, _, _ := p.parseKeyword("INDEX"); ok {
+		ifExists, _, _ := p.parseKeywords("IF", "EXISTS")
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+		action, err := p.parseAlterObjectAction("RENAME", "TABLESPACE")
+		if err != nil {
+			return nil, errors.Errorf("parseAlterObjectAction failed: %w", err)
+		}
+		return &sqlast.AlterIndexStmt{Alter: tok.From, IfExists: ifExists, Name: name, Action: action}, nil
+	}
+
+	if ok, _, _ := p.parseKeyword("VIEW"); ok {
+		ifExists, _, _ := p.parseKeywords("IF", "EXISTS")
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+		action, err := p.parseAlterObjectAction("RENAME", "OWNER", "SCHEMA")
+		if err != nil {
+			return nil, errors.Errorf("parseAlterObjectAction failed: %w", err)
+		}
+		return &sqlast.AlterViewStmt{Alter: tok.From, IfExists: ifExists, Name: name, Action: action}, nil
+	}
+
+	if ok, _, _ := p.parseKeyword("SCHEMA"); ok {
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+		action, err := p.parseAlterObjectAction("RENAME", "OWNER")
+		if err != nil {
+			return nil, errors.Errorf("parseAlterObjectAction failed: %w", err)
+		}
+		return &sqlast.AlterSchemaStmt{Alter: tok.From, Name: name, Action: action}, nil
+	}
+
+	p.expectKeyword("TABLE")
+
+	tableName, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+
+	if ok, toks, _ := p.parseKeywords("ADD", "COLUMN"); ok {
+		columnDef, err := p.parseColumnDef()
+		if err != nil {
+			return nil, errors.Errorf("parseColumnDef failed: %w", err)
+		}
+
+		return &sqlast.AlterTableStmt{
+			TableName: tableName,
+			Alter:     tok.From,
+			Action: &sqlast.AddColumnTableAction{
+				Add:    toks[0].From,
+				Column: columnDef,
+			},
+		}, nil
+	}
+
+	if ok, add, _ := p.parseKeyword("ADD"); ok {
+		constraint, err := p.parseTableConstraints()
+		if err != nil {
+			return nil, errors.Errorf("parseTableConstraints failed: %w", err)
+		}
+
+		return &sqlast.AlterTableStmt{
+			TableName: tableName,
+			Alter:     tok.From,
+			Action: &sqlast.AddConstraintTableAction{
+				Add:        add.From,
+				Constraint: constraint,
+			},
+		}, nil
+	}
+
+	if ok, toks, _ := p.parseKeywords("DROP", "CONSTRAINT"); ok {
+		constraintName, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		var caspos sqltoken.Pos
+		cascade, t, _ := p.parseKeyword("CASCADE")
+		if cascade {
+			caspos = t.To
+		}
+
+		return &sqlast.AlterTableStmt{
+			TableName: tableName,
+			Alter:     tok.From,
+			Action: &sqlast.DropConstraintTableAction{
+				Drop:       toks[0].From,
+				Name:       constraintName,
+				Cascade:    cascade,
+				CascadePos: caspos,
+			},
+		}, nil
+	}
+
+	if ok, toks, _ := p.parseKeywords("DROP", "COLUMN"); ok {
+		constraintName, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		var caspos sqltoken.Pos
+		cascade, t, _ := p.parseKeyword("CASCADE")
+		if cascade {
+			caspos = t.To
+		}
+
+		return &sqlast.AlterTableStmt{
+			TableName: tableName,
+			Alter:     tok.From,
+			Action: &sqlast.RemoveColumnTableAction{
+				Name:       constraintName,
+				Drop:       toks[0].From,
+				Cascade:    cascade,
+				CascadePos: caspos,
+			},
+		}, nil
+	}
+
+	if ok, toks, _ := p.parseKeywords("ALTER", "COLUMN"); ok {
+		action, err := p.parseAlterColumn(toks[0])
+		if err != nil {
+			return nil, errors.Errorf("parseAlterColumn failed: %w", err)
+		}
+
+		return &sqlast.AlterTableStmt{
+			Alter:     tok.From,
+			TableName: tableName,
+			Action:    action,
+		}, nil
+
+	}
+
+	t, _ := p.peekToken()
+	return nil, errors.Errorf("unknown alter operation %v", t)
+}
+
+// parseAlterObjectAction parses an action of ALTER INDEX / VIEW / SCHEMA.
+// allowed is the set of the actions the object accepts, named by their
+// distinctive keyword: RENAME, TABLESPACE, OWNER and SCHEMA.
+func (p *Parser) parseAlterObjectAction(allowed ...string) (sqlast.AlterObjectAction, error) {
+	isAllowed := func(action string) bool {
+		for _, a := range allowed {
+			if a == action {
+				return true
+			}
+		}
+		return false
+	}
+
+	if isAllowed("RENAME") {
+		if ok, toks, _ := p.parseKeywords("RENAME", "TO"); ok {
+			ident, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			return &sqlast.RenameToAction{Rename: toks[0].From, NewName: ident}, nil
+		}
+	}
+
+	if isAllowed("TABLESPACE") {
+		if ok, toks, _ := p.parseKeywords("SET", "TABLESPACE"); ok {
+			ident, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			return &sqlast.SetTablespaceAction{Set: toks[0].From, Tablespace: ident}, nil
+		}
+	}
+
+	if isAllowed("OWNER") {
+		if ok, toks, _ := p.parseKeywords("OWNER", "TO"); ok {
+			ident, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			return &sqlast.OwnerToAction{OwnerPos: toks[0].From, Owner: ident}, nil
+		}
+	}
+
+	if isAllowed("SCHEMA") {
+		if ok, toks, _ := p.parseKeywords("SET", "SCHEMA"); ok {
+			ident, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			return &sqlast.SetSchemaAction{Set: toks[0].From, Schema: ident}, nil
+		}
+	}
+
+	t, _ := p.peekToken()
+	return nil, errors.Errorf("unknown alter operation %v", t)
+}
+
+func (p *Parser) parseDrop() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("DROP")
+	if !ok {
+		return nil, errors.Errorf("expected DROP but %s", tok)
+	}
+
+	ok, _, _ = p.parseKeyword("TABLE")
+
+	if !ok {
+		p.expectKeyword("INDEX")
+		idents, err := p.parseColumnNames()
+		if err != nil {
+			return nil, errors.Errorf("parseColumnNames of DROP INDEX failed: %w", err)
+		}
+
+		return &sqlast.DropIndexStmt{
+			Drop:       tok.From,
+			IndexNames: idents,
+		}, nil
+	}
+	exists, _, _ := p.parseKeywords("IF", "EXISTS")
+	tableName, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+
+	var caspos sqltoken.Pos
+	cascade, t, _ := p.parseKeyword("CASCADE")
+	if cascade {
+		caspos = t.To
+	}
+
+	return &sqlast.DropTableStmt{
+		Drop:       tok.From,
+		TableNames: []*sqlast.ObjectName{tableName},
+		Cascade:    cascade,
+		IfExists:   exists,
+		CascadePos: caspos,
+	}, nil
+}
+
+// parseVacuum parses the rest of `VACUUM [(Options...) | Options...] [Tables...]`.
+func (p *Parser) parseVacuum(vacuum *sqltoken.Token) (sqlast.Stmt, error) {
+	stmt := &sqlast.VacuumStmt{Vacuum: vacuum.From, To: vacuum.To}
+	if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.LParen {
+		options, r, err := p.parseMaintenanceOptions()
+		if err != nil {
+			return nil, errors.Errorf("parseMaintenanceOptions failed: %w", err)
+		}
+		stmt.Parenthesized, stmt.Options, stmt.RParen = true, options, r
+	} else {
+		stmt.Options = p.parseMaintenanceKeywords("FULL", "FREEZE", "VERBOSE", "ANALYZE", "ANALYSE")
+	}
+
+	tables, err := p.parseMaintenanceTables()
+	if err != nil {
+		return nil, errors.Errorf("parseMaintenanceTables failed: %w", err)
+	}
+	stmt.Tables = tables
+	return stmt, nil
+}
+
+// parseAnalyze parses the rest of `ANALYZE [(Options...) | VERBOSE] [Tables...]`.
+func (p *Parser) parseAnalyze(analyze *sqltoken.Token) (sqlast.Stmt, error) {
+	stmt := &sqlast.AnalyzeStmt{Analyze: analyze.From, To: analyze.To}
+	if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.LParen {
+		options, r, err := p.parseMaintenanceOptions()
+		if err != nil {
+			return nil, errors.Errorf("parseMaintenanceOptions failed: %w", err)
+		}
+		stmt.Parenthesized, stmt.Options, stmt.RParen = true, options, r
+	} else {
+		stmt.Options = p.parseMaintenanceKeywords("VERBOSE")
+	}
+
+	tables, err := p.parseMaintenanceTables()
+	if err != nil {
+		return nil, errors.Errorf("parseMaintenanceTables failed: %w", err)
+	}
+	stmt.Tables = tables
+	return stmt, nil
+}
+
+var reindexKinds = []struct {
+	keyword string
+	kind    sqlast.ReindexKind
+}{
+	{"INDEX", sqlast.ReindexIndex},
+	{"TABLE", sqlast.ReindexTable},
+	{"SCHEMA", sqlast.ReindexSchema},
+	{"DATABASE", sqlast.ReindexDatabase},
+	{"SYSTEM", sqlast.ReindexSystem},
+}
+
+// parseReindex parses the rest of
+// `REINDEX [(Options...)] { INDEX | TABLE | SCHEMA | DATABASE | SYSTEM } [CONCURRENTLY] [Name]`.
+func (p *Parser) parseReindex(reindex *sqltoken.Token) (sqlast.Stmt, error) {
+	stmt := &sqlast.ReindexStmt{Reindex: reindex.From}
+	if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.LParen {
+		options, _, err := p.parseMaintenanceOptions()
+		if err != nil {
+			return nil, errors.Errorf("parseMaintenanceOptions failed: %w", err)
+		}
+		stmt.Options = options
+	}
+
+	found := false
+	for _, k := range reindexKinds {
+		if ok, tok, _ := p.parseKeyword(k.keyword); ok {
+			stmt.Kind, stmt.KindTo, found = k.kind, tok.To, true
+			break
+		}
+	}
+	if !found {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected INDEX, TABLE, SCHEMA, DATABASE or SYSTEM but %+v", tok)
+	}
+	if ok, tok, _ := p.parseKeyword("CONCURRENTLY"); ok {
+		stmt.Concurrently, stmt.ConcurrentTo = true, tok.To
+	}
+
+	// the name of the current database can be omitted
+	if t, _ := p.peekToken(); t == nil || t.Kind == sqltoken.Semicolon {
+		if stmt.Kind != sqlast.ReindexDatabase && stmt.Kind != sqlast.ReindexSystem {
+			return nil, errors.Errorf("expected name of %s but %+v", stmt.Kind.ToSQLString(), t)
+		}
+		return stmt, nil
+	}
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+	stmt.Name = name
+	return stmt, nil
+}
+
+// parseMaintenanceOptions parses `(Name [Value], ...)` of VACUUM, ANALYZE
+// and REINDEX, and returns the position of the RParen.
+func (p *Parser) parseMaintenanceOptions() ([]*sqlast.MaintenanceOption, sqltoken.Pos, error) {
+	p.expectToken(sqltoken.LParen)
+	var options []*sqlast.MaintenanceOption
+	for {
+		// option names such as ANALYZE may be reserved
+		tok, _ := p.nextToken()
+		if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+			return nil, sqltoken.Pos{}, errors.Errorf("expected option name but %+v", tok)
+		}
+		opt := &sqlast.MaintenanceOption{Name: newIdent(tok)}
+		if t, _ := p.peekToken(); t != nil && t.Kind != sqltoken.Comma && t.Kind != sqltoken.RParen {
+			if _, ok := t.Value.(*sqltoken.SQLWord); ok {
+				// ON is reserved, e.g. INDEX_CLEANUP ON
+				p.mustNextToken()
+				opt.Value = newIdent(t)
+			} else {
+				v, err := p.ParseExpr()
+				if err != nil {
+					return nil, sqltoken.Pos{}, errors.Errorf("ParseExpr failed: %w", err)
+				}
+				opt.Value = v
+			}
+		}
+		options = append(options, opt)
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, sqltoken.Pos{}, errors.Errorf("expected RParen but %+v", r)
+	}
+	return options, r.To, nil
+}
+
+// parseMaintenanceKeywords parses the options of the old syntax, which are
+// the keywords in the order of keywords.
+func (p *Parser) parseMaintenanceKeywords(keywords ...string) []*sqlast.MaintenanceOption {
+	var options []*sqlast.MaintenanceOption
+	for _, k := range keywords {
+		if ok, tok, _ := p.parseKeyword(k); ok {
+			options = append(options, &sqlast.MaintenanceOption{Name: newIdent(tok)})
+		}
+	}
+	return options
+}
+
+// parseMaintenanceTables parses `Name [(Columns...)], ...` of VACUUM and ANALYZE.
+func (p *Parser) parseMaintenanceTables() ([]*sqlast.MaintenanceTable, error) {
+	if t, _ := p.peekToken(); t == nil || t.Kind == sqltoken.Semicolon {
+		return nil, nil
+	}
+	var tables []*sqlast.MaintenanceTable
+	for {
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+		table := &sqlast.MaintenanceTable{Name: name}
+		if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+			columns, err := p.parseColumnNames()
+			if err != nil {
+				return nil, errors.Errorf("parseColumnNames failed: %w", err)
+			}
+			r, _ := p.nextToken()
+			if r == nil || r.Kind != sqltoken.RParen {
+				return nil, errors.Errorf("expected RParen but %+v", r)
+			}
+			table.Columns = columns
+			table.RParen = r.To
+		}
+		tables = append(tables, table)
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			return tables, nil
+		}
+	}
+}
+
+func (p *Parser) parseTruncate() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("TRUNCATE")
+	if !ok {
+		return nil, errors.Errorf("expected TRUNCATE but %s", tok)
+	}
+	table, _, _ := p.parseKeyword("TABLE")
+	only, _, _ := p.parseKeyword("ONLY")
+
+	stmt := &sqlast.TruncateStmt{
+		Truncate: tok.From,
+		Table:    table,
+		Only:     only,
+	}
+
+	for {
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+		stmt.TableNames = append(stmt.TableNames, name)
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+
+	if ok, toks, _ := p.parseKeywords("RESTART", "IDENTITY"); ok {
+		stmt.Identity = sqlast.RestartIdentity
+		stmt.To = toks[1].To
+	} else if ok, toks, _ := p.parseKeywords("CONTINUE", "IDENTITY"); ok {
+		stmt.Identity = sqlast.ContinueIdentity
+		stmt.To = toks[1].To
+	}
+
+	if ok, t, _ := p.parseKeyword("CASCADE"); ok {
+		stmt.Behavior = sqlast.Cascade
+		stmt.To = t.To
+	} else if ok, t, _ := p.parseKeyword("RESTRICT"); ok {
+		stmt.Behavior = sqlast.Restrict
+		stmt.To = t.To
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseSet() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("SET")
+	if !ok {
+		return nil, errors.Errorf("expected SET but %s", tok)
+	}
+
+	var scope string
+	for _, s := range []string{"SESSION", "LOCAL", "GLOBAL"} {
+		if ok, _, _ := p.parseKeyword(s); ok {
+			scope = s
+			break
+		}
+	}
+
+	if ok, _, _ := p.parseKeywords("TIME", "ZONE"); ok {
+		value, err := p.parseSetValue()
+		if err != nil {
+			return nil, errors.Errorf("parseSetValue failed: %w", err)
+		}
+		return &sqlast.SetTimeZoneStmt{
+			Set:   tok.From,
+			Scope: scope,
+			Value: value,
+		}, nil
+	}
+
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+
+	eq, _ := p.consumeToken(sqltoken.Eq)
+	if !eq {
+		if ok, t, _ := p.parseKeyword("TO"); !ok {
+			return nil, errors.Errorf("expected = or TO but %s", t)
+		}
+	}
+
+	stmt := &sqlast.SetStmt{
+		Set:   tok.From,
+		Scope: scope,
+		Name:  name,
+		Equal: eq,
+	}
+
+	for {
+		v, err := p.parseSetValue()
+		if err != nil {
+			return nil, errors.Errorf("parseSetValue failed: %w", err)
+		}
+		stmt.Values = append(stmt.Values, v)
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+
+	return stmt, nil
+}
+
+// parseSetValue parses a value of SET. Bare words such as DEFAULT, ON
+// or a schema name are returned as identifiers.
+func (p *Parser) parseSetValue() (sqlast.Node, error) {
+	tok, err := p.peekToken()
+	if err != nil {
+		return nil, errors.Errorf("peekToken failed: %w", err)
+	}
+	if tok.Kind == sqltoken.SQLKeyword {
+		idx := p.index
+		p.mustNextToken()
+		next, _ := p.peekToken()
+		if next == nil || (next.Kind != sqltoken.Period && next.Kind != sqltoken.LParen) {
+			return newIdent(tok), nil
+		}
+		p.index = idx
+	}
+	return p.ParseExpr()
+}
+
+func (p *Parser) parseShow() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("SHOW")
+	if !ok {
+		return nil, errors.Errorf("expected SHOW but %s", tok)
+	}
+
+	if ok, all, _ := p.parseKeyword("ALL"); ok {
+		return &sqlast.ShowStmt{
+			Show: tok.From,
+			To:   all.To,
+		}, nil
+	}
+
+	if p.mySQLSyntax() {
+		if stmt, err := p.parseMySQLShow(tok); stmt != nil || err != nil {
+			return stmt, err
+		}
+	}
+
+	name, err := p.parseShowName()
+	if err != nil {
+		return nil, errors.Errorf("parseShowName failed: %w", err)
+	}
+	return &sqlast.ShowStmt{
+		Show: tok.From,
+		Name: name,
+	}, nil
+}
+
+// parseMySQLShow parses the introspection statements of MySQL following SHOW.
+// It returns nil without consuming tokens if the statement is a SHOW of
+// a variable.
+func (p *Parser) parseMySQLShow(show *sqltoken.Token) (sqlast.Stmt, error) {
+	idx := p.index
+	if ok, _, _ := p.parseKeywords("CREATE", "TABLE"); ok {
+		table, err := p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+		return &sqlast.ShowCreateTableStmt{
+			Show:  show.From,
+			Table: table,
+		}, nil
+	}
+
+	full, _, _ := p.parseKeyword("FULL")
+	tok, _ := p.nextToken()
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		p.index = idx
+		return nil, nil
+	}
+
+	switch tok.Value.(*sqltoken.SQLWord).Keyword {
+	case "TABLES":
+		stmt := &sqlast.ShowTablesStmt{
+			Show: show.From,
+			Full: full,
+			To:   tok.To,
+		}
+		if p.parseFromOrIn() {
+			db, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			stmt.Database = db
+		}
+		like, where, err := p.parseShowFilter()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Like = like
+		stmt.Where = where
+		return stmt, nil
+	case "COLUMNS", "FIELDS":
+		if !p.parseFromOrIn() {
+			t, _ := p.peekToken()
+			return nil, errors.Errorf("expected FROM or IN but %+v", t)
+		}
+		table, err := p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+		stmt := &sqlast.ShowColumnsStmt{
+			Show:  show.From,
+			Full:  full,
+			Table: table,
+		}
+		if p.parseFromOrIn() {
+			db, err := p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			stmt.Database = db
+		}
+		like, where, err := p.parseShowFilter()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Like = like
+		stmt.Where = where
+		return stmt, nil
+	}
+
+	p.index = idx
+	return nil, nil
+}
+
+func (p *Parser) parseFromOrIn() bool {
+	if ok, _, _ := p.parseKeyword("FROM"); ok {
+		return true
+	}
+	ok, _, _ := p.parseKeyword("IN")
+	return ok
+}
+
+// parseShowFilter parses the optional `LIKE 'pattern'` or `WHERE expr`
+// of MySQL SHOW statements.
+func (p *Parser) parseShowFilter() (*sqlast.SingleQuotedString, sqlast.Node, error) {
+	if ok, _, _ := p.parseKeyword("LIKE"); ok {
+		like, err := p.parseSingleQuotedString()
+		if err != nil {
+			return nil, nil, errors.Errorf("parseSingleQuotedString failed: %w", err)
+		}
+		return like, nil, nil
+	}
+	if ok, _, _ := p.parseKeyword("WHERE"); ok {
+		where, err := p.ParseExpr()
+		if err != nil {
+			return nil, nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		return nil, where, nil
+	}
+	return nil, nil, nil
+}
+
+// parseDescribe parses `{DESCRIBE | DESC} Table [Column]` of MySQL
+// following the keyword tok.
+func (p *Parser) parseDescribe(tok *sqltoken.Token) (sqlast.Stmt, error) {
+	table, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+	stmt := &sqlast.DescribeStmt{
+		Describe: tok.From,
+		Table:    table,
+	}
+	if n, _ := p.peekToken(); n != nil && n.Kind == sqltoken.SQLKeyword {
+		column, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		stmt.Column = column
+	}
+	return stmt, nil
+}
+
+// parseUse parses `USE Database` of MySQL following the keyword tok.
+func (p *Parser) parseUse(tok *sqltoken.Token) (sqlast.Stmt, error) {
+	db, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	return &sqlast.UseStmt{
+		Use:      tok.From,
+		Database: db,
+	}, nil
+}
+
+func (p *Parser) parseReset() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("RESET")
+	if !ok {
+		return nil, errors.Errorf("expected RESET but %s", tok)
+	}
+
+	if ok, all, _ := p.parseKeyword("ALL"); ok {
+		return &sqlast.ResetStmt{
+			Reset: tok.From,
+			To:    all.To,
+		}, nil
+	}
+
+	name, err := p.parseShowName()
+	if err != nil {
+		return nil, errors.Errorf("parseShowName failed: %w", err)
+	}
+	return &sqlast.ResetStmt{
+		Reset: tok.From,
+		Name:  name,
+	}, nil
+}
+
+// parseShowName parses the name of a configuration parameter.
+// `TIME ZONE` is accepted as a single name.
+func (p *Parser) parseShowName() (*sqlast.ObjectName, error) {
+	if ok, toks, _ := p.parseKeywords("TIME", "ZONE"); ok {
+		return &sqlast.ObjectName{
+			Idents: []*sqlast.Ident{{
+				Value: "TIME ZONE",
+				From:  toks[0].From,
+				To:    toks[1].To,
+			}},
+		}, nil
+	}
+	return p.parseObjectName()
+}
+
+func (p *Parser) parsePrepare() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("PREPARE")
+	if !ok {
+		return nil, errors.Errorf("expected PREPARE but %s", tok)
+	}
+
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+
+	var dataTypes []sqlast.Type
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		for {
+			t, err := p.ParseDataType()
+			if err != nil {
+				return nil, errors.Errorf("ParseDataType failed: %w", err)
+			}
+			dataTypes = append(dataTypes, t)
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+		}
+		if ok, _ := p.consumeToken(sqltoken.RParen); !ok {
+			t, _ := p.peekToken()
+			return nil, errors.Errorf("expected RParen but %s", t)
+		}
+	}
+
+	if ok, t, _ := p.parseKeyword("AS"); !ok {
+		return nil, errors.Errorf("expected AS but %s", t)
+	}
+
+	stmt, err := p.ParseStatement()
+	if err != nil {
+		return nil, errors.Errorf("ParseStatement failed: %w", err)
+	}
+
+	return &sqlast.PrepareStmt{
+		Prepare:   tok.From,
+		Name:      name,
+		DataTypes: dataTypes,
+		Stmt:      stmt,
+	}, nil
+}
+
+func (p *Parser) parseExecute() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("EXECUTE")
+	if !ok {
+		return nil, errors.Errorf("expected EXECUTE but %s", tok)
+	}
+
+	// `EXECUTE procedure` may execute a prepared statement named procedure,
+	// so it is EXECUTE PROCEDURE only if a name follows.
+	if ok, _, _ := p.parseKeyword("PROCEDURE"); ok {
+		if n, _ := p.peekToken(); n != nil && n.Kind == sqltoken.SQLKeyword {
+			return p.parseCallProcedure(tok, true)
+		}
+		p.prevToken()
+	}
+
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+
+	stmt := &sqlast.ExecuteStmt{
+		Execute: tok.From,
+		Name:    name,
+	}
+
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		if r, _ := p.peekToken(); r == nil || r.Kind != sqltoken.RParen {
+			args, err := p.parseExprList()
+			if err != nil {
+				return nil, errors.Errorf("parseExprList failed: %w", err)
+			}
+			stmt.Args = args
+		}
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
+		}
+		stmt.RParen = r.To
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseCall() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("CALL")
+	if !ok {
+		return nil, errors.Errorf("expected CALL but %s", tok)
+	}
+	return p.parseCallProcedure(tok, false)
+}
+
+// parseCallProcedure parses `Name[(Args...)]` of CALL, or of EXECUTE
+// PROCEDURE if executeProcedure is true. tok is the first keyword.
+func (p *Parser) parseCallProcedure(tok *sqltoken.Token, executeProcedure bool) (sqlast.Stmt, error) {
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+
+	stmt := &sqlast.CallStmt{
+		Call:             tok.From,
+		ExecuteProcedure: executeProcedure,
+		Name:             name,
+	}
+
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		if !p.mySQLSyntax() {
+			t, _ := p.peekToken()
+			return nil, errors.Errorf("expected LParen but %+v", t)
+		}
+		return stmt, nil
+	}
+	args, err := p.parseOptionalArgs()
+	if err != nil {
+		return nil, errors.Errorf("parseOptionalArgs failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
+	stmt.Args = args
+	stmt.RParen = r.To
+
+	return stmt, nil
+}
+
+func (p *Parser) parseDeallocate() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("DEALLOCATE")
+	if !ok {
+		return nil, errors.Errorf("expected DEALLOCATE but %s", tok)
+	}
+	prepare, _, _ := p.parseKeyword("PREPARE")
+
+	stmt := &sqlast.DeallocateStmt{
+		Deallocate: tok.From,
+		Prepare:    prepare,
+	}
+
+	if ok, all, _ := p.parseKeyword("ALL"); ok {
+		stmt.To = all.To
+		return stmt, nil
+	}
+
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	stmt.Name = name
+
+	return stmt, nil
+}
+
+func (p *Parser) parseDeclareCursor() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("DECLARE")
+	if !ok {
+		return nil, errors.Errorf("expected DECLARE but %s", tok)
+	}
+
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+
+	stmt := &sqlast.DeclareCursorStmt{
+		Declare: tok.From,
+		Name:    name,
+	}
+	stmt.Binary, _, _ = p.parseKeyword("BINARY")
+	stmt.Insensitive, _, _ = p.parseKeyword("INSENSITIVE")
+	if ok, _, _ := p.parseKeyword("SCROLL"); ok {
+		stmt.Scroll = sqlast.Scroll
+	} else if ok, _, _ := p.parseKeywords("NO", "SCROLL"); ok {
+		stmt.Scroll = sqlast.NoScroll
+	}
+
+	if ok, t, _ := p.parseKeyword("CURSOR"); !ok {
+		return nil, errors.Errorf("expected CURSOR but %s", t)
+	}
+
+	if ok, _, _ := p.parseKeywords("WITH", "HOLD"); ok {
+		stmt.Hold = sqlast.WithHold
+	} else if ok, _, _ := p.parseKeywords("WITHOUT", "HOLD"); ok {
+		stmt.Hold = sqlast.WithoutHold
+	}
+
+	if ok, t, _ := p.parseKeyword("FOR"); !ok {
+		return nil, errors.Errorf("expected FOR but %s", t)
+	}
+
+	q, err := p.parseQuery()
+	if err != nil {
+		return nil, errors.Errorf("parseQuery failed: %w", err)
+	}
+	stmt.Query = q
+
+	return stmt, nil
+}
+
+var fetchDirections = map[string]sqlast.FetchDirection{
+	"NEXT":     sqlast.FetchNext,
+	"PRIOR":    sqlast.FetchPrior,
+	"FIRST":    sqlast.FetchFirst,
+	"LAST":     sqlast.FetchLast,
+	"ABSOLUTE": sqlast.FetchAbsolute,
+	"RELATIVE": sqlast.FetchRelative,
+	"ALL":      sqlast.FetchAll,
+	"FORWARD":  sqlast.FetchForward,
+	"BACKWARD": sqlast.FetchBackward,
+}
+
+func (p *Parser) parseFetchCursor() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("FETCH")
+	if !ok {
+		return nil, errors.Errorf("expected FETCH but %s", tok)
+	}
+
+	stmt := &sqlast.FetchStmt{
+		Fetch: tok.From,
+	}
+
+	next, err := p.peekToken()
+	if err != nil {
+		return nil, errors.Errorf("peekToken failed: %w", err)
+	}
+	switch next.Kind {
+	case sqltoken.Number, sqltoken.Minus:
+		count, err := p.parseSignedLong()
+		if err != nil {
+			return nil, errors.Errorf("parseSignedLong failed: %w", err)
+		}
+		stmt.Direction = sqlast.FetchCount
+		stmt.Count = count
+	case sqltoken.SQLKeyword:
+		word := next.Value.(*sqltoken.SQLWord)
+		dir, ok := fetchDirections[strings.ToUpper(word.Value)]
+		if !ok || word.QuoteStyle != 0 {
+			break
+		}
+		p.mustNextToken()
+		stmt.Direction = dir
+
+		switch dir {
+		case sqlast.FetchAbsolute, sqlast.FetchRelative:
+			count, err := p.parseSignedLong()
+			if err != nil {
+				return nil, errors.Errorf("parseSignedLong failed: %w", err)
+			}
+			stmt.Count = count
+		case sqlast.FetchForward, sqlast.FetchBackward:
+			if ok, _, _ := p.parseKeyword("ALL"); ok {
+				stmt.All = true
+			} else if t, _ := p.peekToken(); t != nil && (t.Kind == sqltoken.Number || t.Kind == sqltoken.Minus) {
+				count, err := p.parseSignedLong()
+				if err != nil {
+					return nil, errors.Errorf("parseSignedLong failed: %w", err)
+				}
+				stmt.Count = count
+			}
+		}
+	}
+
+	if ok, _, _ := p.parseKeyword("FROM"); !ok {
+		p.parseKeyword("IN")
+	}
+
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	stmt.Name = name
+
+	return stmt, nil
+}
+
+func (p *Parser) parseClose() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("CLOSE")
+	if !ok {
+		return nil, errors.Errorf("expected CLOSE but %s", tok)
+	}
+
+	stmt := &sqlast.CloseStmt{
+		Close: tok.From,
+	}
+
+	if ok, all, _ := p.parseKeyword("ALL"); ok {
+		stmt.To = all.To
+		return stmt, nil
+	}
+
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	stmt.Name = name
+
+	return stmt, nil
+}
+
+func (p *Parser) parseMerge() (sqlast.Stmt, error) {
+	ok, m, _ := p.parseKeyword("MERGE")
+	if !ok {
+		return nil, errors.Errorf("expected MERGE but %s", m)
+	}
+	if ok, tok, _ := p.parseKeyword("INTO"); !ok {
+		return nil, errors.Errorf("expected INTO but %s", tok)
+	}
+
+	target, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+	alias := p.parseOptionalAlias(dialect.ReservedForTableAlias)
+
+	if ok, tok, _ := p.parseKeyword("USING"); !ok {
+		return nil, errors.Errorf("expected USING but %s", tok)
+	}
+	source, err := p.parseTableFactor()
+	if err != nil {
+		return nil, errors.Errorf("parseTableFactor failed: %w", err)
+	}
+
+	if ok, tok, _ := p.parseKeyword("ON"); !ok {
+		return nil, errors.Errorf("expected ON but %s", tok)
+	}
+	on, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+
+	stmt := &sqlast.MergeStmt{
+		Merge:  m.From,
+		Target: target,
+		Alias:  alias,
+		Source: source,
+		On:     on,
+	}
+
+	for {
+		ok, when, _ := p.parseKeyword("WHEN")
+		if !ok {
+			break
+		}
+		clause, err := p.parseMergeWhenClause(when)
+		if err != nil {
+			return nil, errors.Errorf("parseMergeWhenClause failed: %w", err)
+		}
+		stmt.Clauses = append(stmt.Clauses, clause)
+	}
+
+	if len(stmt.Clauses) == 0 {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected WHEN but %s", tok)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseMergeWhenClause(when *sqltoken.Token) (*sqlast.MergeWhenClause, error) {
+	notMatched, _, _ := p.parseKeyword("NOT")
+	if ok, tok, _ := p.parseKeyword("MATCHED"); !ok {
+		return nil, errors.Errorf("expected MATCHED but %s", tok)
+	}
+
+	clause := &sqlast.MergeWhenClause{
+		When:       when.From,
+		NotMatched: notMatched,
+	}
+
+	if ok, _, _ := p.parseKeyword("AND"); ok {
+		cond, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		clause.Condition = cond
+	}
+
+	if ok, tok, _ := p.parseKeyword("THEN"); !ok {
+		return nil, errors.Errorf("expected THEN but %s", tok)
+	}
+
+	if ok, toks, _ := p.parseKeywords("DO", "NOTHING"); ok {
+		clause.Action = &sqlast.MergeDoNothing{
+			Do: toks[0].From,
+			To: toks[1].To,
+		}
+		return clause, nil
+	}
+
+	tok, err := p.nextToken()
+	if err != nil {
+		return nil, errors.Errorf("nextToken failed: %w", err)
+	}
+	word, ok := tok.Value.(*sqltoken.SQLWord)
+	if !ok {
+		return nil, errors.Errorf("expected UPDATE, DELETE, INSERT or DO NOTHING but %s", tok)
+	}
+
+	switch word.Keyword {
+	case "UPDATE":
+		if notMatched {
+			return nil, errors.Errorf("UPDATE is not allowed in WHEN NOT MATCHED")
+		}
+		if ok, t, _ := p.parseKeyword("SET"); !ok {
+			return nil, errors.Errorf("expected SET but %s", t)
+		}
+		assignments, err := p.parseAssignments()
+		if err != nil {
+			return nil, errors.Errorf("parseAssignments failed: %w", err)
+		}
+		clause.Action = &sqlast.MergeUpdate{
+			Update:      tok.From,
+			Assignments: assignments,
+		}
+	case "DELETE":
+		if notMatched {
+			return nil, errors.Errorf("DELETE is not allowed in WHEN NOT MATCHED")
+		}
+		clause.Action = &sqlast.MergeDelete{
+			Delete: tok.From,
+			To:     tok.To,
+		}
+	case "INSERT":
+		if !notMatched {
+			return nil, errors.Errorf("INSERT is only allowed in WHEN NOT MATCHED")
+		}
+		insert, err := p.parseMergeInsert(tok)
+		if err != nil {
+			return nil, errors.Errorf("parseMergeInsert failed: %w", err)
+		}
+		clause.Action = insert
+	default:
+		return nil, errors.Errorf("expected UPDATE, DELETE, INSERT or DO NOTHING but %s", tok)
+	}
+
+	return clause, nil
+}
+
+func (p *Parser) parseMergeInsert(insert *sqltoken.Token) (*sqlast.MergeInsert, error) {
+	action := &sqlast.MergeInsert{
+		Insert: insert.From,
+	}
+
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		columns, err := p.parseColumnNames()
+		if err != nil {
+			return nil, errors.Errorf("parseColumnNames failed: %w", err)
+		}
+		if ok, _ := p.consumeToken(sqltoken.RParen); !ok {
+			tok, _ := p.peekToken()
+			return nil, errors.Errorf("expected RParen but %s", tok)
+		}
+		action.Columns = columns
+	}
+
+	if ok, toks, _ := p.parseKeywords("DEFAULT", "VALUES"); ok {
+		action.To = toks[1].To
+		return action, nil
+	}
+
+	if ok, tok, _ := p.parseKeyword("VALUES"); !ok {
+		return nil, errors.Errorf("expected VALUES but %s", tok)
+	}
+	l, _ := p.nextToken()
+	if l == nil || l.Kind != sqltoken.LParen {
+		return nil, errors.Errorf("expected LParen but %+v", l)
+	}
+	values, err := p.parseExprList()
+	if err != nil {
+		return nil, errors.Errorf("parseExprList failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
+	action.Values = &sqlast.RowValueExpr{
+		Values: values,
+		LParen: l.From,
+		RParen: r.To,
+	}
+
+	return action, nil
+}
+
+func (p *Parser) parseAlterColumn(alt *sqltoken.Token) (*sqlast.AlterColumnTableAction, error) {
+	columnName, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+
+	tok := p.mustNextToken()
+	if tok.Kind != sqltoken.SQLKeyword {
+		return nil, errors.Errorf("must be SQLKeyword but: %v", tok)
+	}
+
+	word := tok.Value.(*sqltoken.SQLWord)
+
+	switch word.Keyword {
+	case "SET":
+		if ok, _, _ := p.parseKeyword("DEFAULT"); ok {
+			def, err := p.parseDefaultExpr(0)
+			if err != nil {
+				return nil, errors.Errorf("parseDefaultExpr failed: %w", err)
+			}
+			return &sqlast.AlterColumnTableAction{
+				ColumnName: columnName,
+				Alter:      alt.From,
+				Action: &sqlast.SetDefaultColumnAction{
+					Set:     tok.From,
+					Default: def,
+				},
+			}, nil
 		}
-
-		if t.Kind != sqltoken.SQLKeyword {
-			return nil, errors.Errorf("expected '=' or 'charset_name' but: %v", t)
+		if ok, toks, _ := p.parseKeywords("NOT", "NULL"); ok {
+			return &sqlast.AlterColumnTableAction{
+				ColumnName: columnName,
+				Alter:      alt.From,
+				Action: &sqlast.PGSetNotNullColumnAction{
+					Set:  tok.From,
+					Null: toks[1].To,
+				},
+			}, nil
 		}
 
-		name, _ := p.parseIdentifier()
-
-		opt.Name = name
-
-		return opt, nil
-	case "CHARSET":
-		opt := &sqlast.MyCharset{
-			Charset: tok.From,
+		return nil, errors.Errorf("unknown SET action")
+	case "DROP":
+		if ok, deftok, _ := p.parseKeyword("DEFAULT"); ok {
+			return &sqlast.AlterColumnTableAction{
+				ColumnName: columnName,
+				Alter:      alt.From,
+				Action: &sqlast.DropDefaultColumnAction{
+					Drop:    tok.From,
+					Default: deftok.To,
+				},
+			}, nil
 		}
-		t, _ := p.peekToken()
-		if t.Kind == sqltoken.Eq {
-			opt.Equal = true
-			p.mustNextToken()
-			t, _ = p.peekToken()
+		if ok, toks, _ := p.parseKeywords("NOT", "NULL"); ok {
+			return &sqlast.AlterColumnTableAction{
+				ColumnName: columnName,
+				Alter:      alt.From,
+				Action: &sqlast.PGDropNotNullColumnAction{
+					Drop: tok.From,
+					Null: toks[1].To,
+				},
+			}, nil
 		}
-
-		if t.Kind != sqltoken.SQLKeyword {
-			return nil, errors.Errorf("expected '=' or 'charset_name' but: %v", t)
+		return nil, errors.Errorf("unknown DROP action")
+	case "TYPE":
+		tp, err := p.ParseDataType()
+		if err != nil {
+			return nil, errors.Errorf("ParseDataType failed: %w", err)
 		}
 
-		name, _ := p.parseIdentifier()
-		opt.Name = name
-
-		return opt, nil
+		return &sqlast.AlterColumnTableAction{
+			ColumnName: columnName,
+			Alter:      alt.From,
+			Action: &sqlast.PGAlterDataTypeColumnAction{
+				Type:     tok.From,
+				DataType: tp,
+			},
+		}, nil
 	default:
-		return nil, errors.Errorf("unsupported Table Options: %v", word)
+		return nil, errors.Errorf("unknown alter column action %v", word)
 	}
 }
 
-func (p *Parser) parseDelete() (sqlast.Stmt, error) {
-	ok, d, _ := p.parseKeyword("DELETE")
-	if !ok {
-		return nil, errors.Errorf("expect DELETE but %+v", d)
-	}
-
-	p.expectKeyword("FROM")
-	tableName, err := p.parseObjectName()
+func (p *Parser) parseDefaultExpr(precedence uint) (sqlast.Node, error) {
+	expr, err := p.parsePrefix()
 	if err != nil {
-		return nil, errors.Errorf("parseObjectName failed: %w", err)
+		return nil, errors.Errorf("parsePrefix failed: %w", err)
 	}
+	for {
+		tok, _ := p.peekToken()
+		if tok != nil && tok.Kind == sqltoken.SQLKeyword {
+			w := tok.Value.(*sqltoken.SQLWord)
+			if w.Keyword == "NOT" || w.Keyword == "NULL" {
+				break
+			}
+		}
 
-	var selection sqlast.Node
-	if ok, _, _ := p.parseKeyword("WHERE"); ok {
-		selection, err = p.ParseExpr()
+		nextPrecedence, err := p.getNextPrecedence()
 		if err != nil {
-			return nil, errors.Errorf("ParseExpr failed: %w", err)
+			return nil, errors.Errorf("getNextPrecedence failed: %w", err)
+		}
+		if precedence >= nextPrecedence {
+			break
+		}
+		expr, err = p.parseInfix(expr, nextPrecedence)
+		if err != nil {
+			return nil, errors.Errorf("parseInfix failed: %w", err)
 		}
 	}
-
-	return &sqlast.DeleteStmt{
-		Delete:    d.From,
-		TableName: tableName,
-		Selection: selection,
-	}, nil
+	return expr, nil
 }
 
-func (p *Parser) parseUpdate() (sqlast.Stmt, error) {
-	ok, u, _ := p.parseKeyword("UPDATE")
-	if !ok {
-		return nil, errors.Errorf("expect UPDATE but %+v", ok)
-	}
-	tableName, err := p.parseObjectName()
-	if err != nil {
-		return nil, errors.Errorf("parseObjectName failed: %w", err)
-	}
-	p.expectKeyword("SET")
-
-	assignments, err := p.parseAssignments()
-	if err != nil {
-		return nil, errors.Errorf("parseAssignments failed: %w", err)
-	}
+func (p *Parser) parseOptionalAlias(reservedKeywords map[string]struct{}) *sqlast.Ident {
+	afterAs, _, _ := p.parseKeyword("AS")
+	maybeAlias, _ := p.nextToken()
 
-	var selection sqlast.Node
-	if ok, _, _ := p.parseKeyword("WHERE"); ok {
-		selection, err = p.ParseExpr()
-		if err != nil {
-			return nil, errors.Errorf("ParseExpr failed: %w", err)
+	if maybeAlias == nil {
+		if afterAs {
+			p.bail(errors.Errorf("expected an identifier after AS but EOF"))
 		}
+		return nil
 	}
 
-	return &sqlast.UpdateStmt{
-		Update:      u.From,
-		TableName:   tableName,
-		Assignments: assignments,
-		Selection:   selection,
-	}, nil
+	if maybeAlias.Kind == sqltoken.SQLKeyword {
 
+		word := maybeAlias.Value.(*sqltoken.SQLWord)
+		reserved := containsStr(reservedKeywords, word.Keyword) || p.reservedByDialect(word.Keyword)
+		if afterAs || !reserved {
+			return newIdent(maybeAlias)
+		}
+	}
+	if afterAs {
+		p.bail(errors.Errorf("expected an identifier after AS but %+v", maybeAlias))
+	}
+	p.prevToken()
+	return nil
 }
 
-func (p *Parser) parseAssignments() ([]*sqlast.Assignment, error) {
-	var assignments []*sqlast.Assignment
+func (p *Parser) parseCTEList() ([]*sqlast.CTE, error) {
+	var ctes []*sqlast.CTE
 
 	for {
-		tok, _ := p.nextToken()
-		if tok.Kind != sqltoken.SQLKeyword {
-			return nil, errors.Errorf("should be sqlkeyword but %v", tok)
+		alias, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
 		}
-
-		word := tok.Value.(*sqltoken.SQLWord)
-
-		p.expectToken(sqltoken.Eq)
-
-		val, err := p.ParseExpr()
+		p.expectKeyword("AS")
+		p.expectToken(sqltoken.LParen)
+		q, err := p.parseQuery()
 		if err != nil {
-			return nil, errors.Errorf("ParseExpr failed: %w", err)
+			return nil, errors.Errorf("parseQuery failed: %w", err)
 		}
-
-		assignments = append(assignments, &sqlast.Assignment{
-			ID:    sqlast.NewIdentWithPos(word.String(), tok.From, tok.To),
-			Value: val,
+		ctes = append(ctes, &sqlast.CTE{
+			Alias: alias,
+			Query: q,
 		})
-
+		p.expectToken(sqltoken.RParen)
 		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
 			break
 		}
 	}
-
-	return assignments, nil
+	return ctes, nil
 }
 
-func (p *Parser) parseInsert() (sqlast.Stmt, error) {
-	ok, i, _ := p.parseKeyword("INSERT")
-	if !ok {
-		return nil, errors.Errorf("expected INSERT but %+v", i)
-	}
-
-	p.expectKeyword("INTO")
-	tableName, err := p.parseObjectName()
+func (p *Parser) parseFromClause() ([]sqlast.TableReference, error) {
+	var res []sqlast.TableReference
 
+	table, err := p.parseTableReference()
 	if err != nil {
-		return nil, errors.Errorf("invalid table name: %w", err)
+		return nil, errors.Errorf("parseTable failed: %w", err)
 	}
-	var columns []*sqlast.Ident
 
-	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
-		columns, err = p.parseColumnNames()
+	res = append(res, table)
+
+	for {
+		ok, _ := p.consumeToken(sqltoken.Comma)
+		if !ok {
+			break
+		}
+		table, err := p.parseTableReference()
 		if err != nil {
-			return nil, errors.Errorf("invalid column names: %w", err)
+			return nil, errors.Errorf("parseTable failed: %w", err)
 		}
-		p.expectToken(sqltoken.RParen)
+		res = append(res, table)
 	}
 
-	var insertSrc sqlast.InsertSource
-	if ok, _, _ := p.parseKeyword("VALUES"); !ok {
-		q, err := p.parseQuery()
+	return res, nil
+}
+
+func (p *Parser) parseTableReference() (sqlast.TableReference, error) {
+	leftElem, err := p.parseTableFactor()
+	if err != nil {
+		return nil, errors.Errorf("parse joined table left element failed: %w", err)
+	}
+
+	e := sqlast.TableReference(leftElem)
+
+	for {
+		right, err := p.parseTableReferenceRight()
 		if err != nil {
-			return nil, errors.Errorf("invalid select source: expected query: %w", err)
+			return nil, errors.Errorf("parse table reference right failed: %w", err)
 		}
-		insertSrc = &sqlast.SubQuerySource{
-			SubQuery: q,
+
+		if right == nil {
+			break
 		}
-	} else {
-		var constSrc sqlast.ConstructorSource
-		for {
-			l, _ := p.nextToken()
-			if l.Kind != sqltoken.LParen {
-				return nil, errors.Errorf("expected LParen but %+v", l)
-			}
-			v, err := p.parseExprList()
-			if err != nil {
-				return nil, errors.Errorf("invalid insert value assign: %w", err)
-			}
-			r, _ := p.nextToken()
-			if r.Kind != sqltoken.RParen {
-				return nil, errors.Errorf("expected RParen but %+v", r)
+
+		switch rtp := right.(type) {
+		case *sqlast.NaturalJoin:
+			rtp.LeftElement = &sqlast.TableJoinElement{
+				Ref: e,
 			}
-			constSrc.Rows = append(constSrc.Rows, &sqlast.RowValueExpr{
-				Values: v,
-				LParen: l.From,
-				RParen: r.To,
-			})
-			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
-				break
+			e = rtp
+		case *sqlast.CrossJoin:
+			rtp.Reference = leftElem
+			e = rtp
+		case *sqlast.QualifiedJoin:
+			rtp.LeftElement = &sqlast.TableJoinElement{
+				Ref: e,
 			}
+			e = rtp
+		default:
+			return nil, errors.Errorf("unknown join")
 		}
-
-		insertSrc = &constSrc
-	}
-
-	var assigns []*sqlast.Assignment
-	if ok, _, _ := p.parseKeywords("ON", "DUPLICATE", "KEY", "UPDATE"); ok {
-		assignments, err := p.parseAssignments()
-		if err != nil {
-			return nil, errors.Errorf("invalid DUPLICATE KEY UPDATE assignments: %w", err)
-		}
-		assigns = assignments
 	}
 
-	return &sqlast.InsertStmt{
-		Insert:            i.From,
-		TableName:         tableName,
-		Columns:           columns,
-		Source:            insertSrc,
-		UpdateAssignments: assigns,
-	}, nil
+	return e, nil
 }
 
-func (p *Parser) parseAlter() (sqlast.Stmt, error) {
-	ok, tok, _ := p.parseKeyword("ALTER")
-	if !ok {
-		return nil, errors.Errorf("expected ALTER but %s", tok)
+func (p *Parser) parseTableReferenceRight() (sqlast.TableReference, error) {
+	if ok, _ := p.consumeToken(sqltoken.Comma); ok {
+		p.prevToken()
+		return nil, nil
+	}
+	p.suggestKeywords(joinKeywords...)
+	if p.clickHouse() && p.peekArrayJoin() {
+		return nil, nil
 	}
 
-	p.expectKeyword("TABLE")
-
-	tableName, err := p.parseObjectName()
+	tok, err := p.nextToken()
 	if err != nil {
-		return nil, errors.Errorf("parseObjectName failed: %w", err)
+		return nil, nil
+	}
+	word, ok := tok.Value.(*sqltoken.SQLWord)
+	if !ok {
+		p.prevToken()
+		return nil, nil
 	}
 
-	if ok, toks, _ := p.parseKeywords("ADD", "COLUMN"); ok {
-		columnDef, err := p.parseColumnDef()
+	switch word.Keyword {
+	case "NATURAL":
+		tp, err := p.parseJoinType()
 		if err != nil {
-			return nil, errors.Errorf("parseColumnDef failed: %w", err)
+			return nil, errors.Errorf("parse natural join type failed: %w", err)
+		}
+		p.expectKeyword("JOIN")
+		rightElem, err := p.parseTableReference()
+		if err != nil {
+			return nil, errors.Errorf("parse natural join right element failed: %w", err)
 		}
 
-		return &sqlast.AlterTableStmt{
-			TableName: tableName,
-			Alter:     tok.From,
-			Action: &sqlast.AddColumnTableAction{
-				Add:    toks[0].From,
-				Column: columnDef,
+		return &sqlast.NaturalJoin{
+			Type: tp,
+			RightElement: &sqlast.TableJoinElement{
+				Ref: rightElem,
 			},
 		}, nil
-	}
-
-	if ok, add, _ := p.parseKeyword("ADD"); ok {
-		constraint, err := p.parseTableConstraints()
+	case "CROSS":
+		p.expectKeyword("JOIN")
+		rightElem, err := p.parseTableFactor()
 		if err != nil {
-			return nil, errors.Errorf("parseTableConstraints failed: %w", err)
+			return nil, errors.Errorf("parse cross join right element failed: %w", err)
 		}
 
-		return &sqlast.AlterTableStmt{
-			TableName: tableName,
-			Alter:     tok.From,
-			Action: &sqlast.AddConstraintTableAction{
-				Add:        add.From,
-				Constraint: constraint,
-			},
+		return &sqlast.CrossJoin{
+			Factor: rightElem,
 		}, nil
-	}
-
-	if ok, toks, _ := p.parseKeywords("DROP", "CONSTRAINT"); ok {
-		constraintName, err := p.parseIdentifier()
+	case "INNER":
+		p.expectKeyword("JOIN")
+		ref, err := p.parseTableReference()
 		if err != nil {
-			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			return nil, errors.Errorf("parse inner join right elem filed: %w", err)
 		}
-		var caspos sqltoken.Pos
-		cascade, t, _ := p.parseKeyword("CASCADE")
-		if cascade {
-			caspos = t.To
+		spec, err := p.parseJoinSpec()
+		if err != nil {
+			return nil, errors.Errorf("parse inner join spec filed: %w", err)
 		}
 
-		return &sqlast.AlterTableStmt{
-			TableName: tableName,
-			Alter:     tok.From,
-			Action: &sqlast.DropConstraintTableAction{
-				Drop:       toks[0].From,
-				Name:       constraintName,
-				Cascade:    cascade,
-				CascadePos: caspos,
+		return &sqlast.QualifiedJoin{
+			RightElement: &sqlast.TableJoinElement{
+				Ref: ref,
 			},
+			Spec: spec,
+			Type: &sqlast.JoinType{Condition: sqlast.INNER},
 		}, nil
-	}
-
-	if ok, toks, _ := p.parseKeywords("DROP", "COLUMN"); ok {
-		constraintName, err := p.parseIdentifier()
+	case "LEFT", "RIGHT", "FULL", "JOIN":
+		p.prevToken()
+		tp, err := p.parseJoinType()
 		if err != nil {
-			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+			return nil, errors.Errorf("parse qualified join type failed: %w", err)
 		}
-		var caspos sqltoken.Pos
-		cascade, t, _ := p.parseKeyword("CASCADE")
-		if cascade {
-			caspos = t.To
+		p.expectKeyword("JOIN")
+		ref, err := p.parseTableReference()
+		if err != nil {
+			return nil, errors.Errorf("parse qualified join right elem failed: %w", err)
 		}
 
-		return &sqlast.AlterTableStmt{
-			TableName: tableName,
-			Alter:     tok.From,
-			Action: &sqlast.RemoveColumnTableAction{
-				Name:       constraintName,
-				Drop:       toks[0].From,
-				Cascade:    cascade,
-				CascadePos: caspos,
-			},
-		}, nil
-	}
-
-	if ok, toks, _ := p.parseKeywords("ALTER", "COLUMN"); ok {
-		action, err := p.parseAlterColumn(toks[0])
+		spec, err := p.parseJoinSpec()
 		if err != nil {
-			return nil, errors.Errorf("parseAlterColumn failed: %w", err)
+			return nil, errors.Errorf("parse qualified join spec failed: %w", err)
 		}
 
-		return &sqlast.AlterTableStmt{
-			Alter:     tok.From,
-			TableName: tableName,
-			Action:    action,
+		return &sqlast.QualifiedJoin{
+			RightElement: &sqlast.TableJoinElement{
+				Ref: ref,
+			},
+			Type: tp,
+			Spec: spec,
 		}, nil
 
+	default:
+		p.prevToken()
+		return nil, nil
 	}
-
-	t, _ := p.peekToken()
-	return nil, errors.Errorf("unknown alter operation %v", t)
 }
 
-func (p *Parser) parseDrop() (sqlast.Stmt, error) {
-	ok, tok, _ := p.parseKeyword("DROP")
+func (p *Parser) parseJoinType() (*sqlast.JoinType, error) {
+	tok, err := p.nextToken()
+	if err != nil {
+		return nil, errors.Errorf("nextToken failed: %w", err)
+	}
+	word, ok := tok.Value.(*sqltoken.SQLWord)
 	if !ok {
-		return nil, errors.Errorf("expected DROP but %s", tok)
+		return nil, errors.Errorf("unknown join type %v", tok)
 	}
 
-	ok, _, _ = p.parseKeyword("TABLE")
+	switch word.Keyword {
+	case "INNER":
+		return &sqlast.JoinType{
+			Condition: sqlast.INNER,
+			From:      tok.From,
+			To:        tok.To,
+		}, nil
+	case "LEFT":
+		outer, _, _ := p.parseKeyword("OUTER")
+		if outer {
+			return &sqlast.JoinType{
+				Condition: sqlast.LEFTOUTER,
+				From:      tok.From,
+				To:        tok.To,
+			}, nil
+		}
+		return &sqlast.JoinType{
+			Condition: sqlast.LEFT,
+			From:      tok.From,
+			To:        tok.To,
+		}, nil
+	case "RIGHT":
+		outer, _, _ := p.parseKeyword("OUTER")
+		if outer {
+			return &sqlast.JoinType{Condition: sqlast.RIGHTOUTER}, nil
+		}
+		return &sqlast.JoinType{Condition: sqlast.RIGHT}, nil
+	case "FULL":
+		outer, _, _ := p.parseKeyword("OUTER")
+		if outer {
+			return &sqlast.JoinType{Condition: sqlast.FULLOUTER}, nil
+		}
+		return &sqlast.JoinType{Condition: sqlast.FULL}, nil
+	case "JOIN":
+		p.prevToken()
+		return &sqlast.JoinType{Condition: sqlast.IMPLICIT}, nil
+	default:
+		return nil, errors.Errorf("unknown join type: %v", word)
+	}
+}
+
+func (p *Parser) parseJoinSpec() (sqlast.JoinSpec, error) {
+	if ok, tok, _ := p.parseKeyword("ON"); ok {
+		expr, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("parse join condition failed: %w", err)
+		}
+		return &sqlast.JoinCondition{
+			SearchCondition: expr,
+			On:              tok.From,
+		}, nil
+	}
 
+	ok, _, _ := p.parseKeyword("USING")
 	if !ok {
-		p.expectKeyword("INDEX")
-		idents, err := p.parseColumnNames()
+		tok, _ := p.nextToken()
+		return nil, errors.Errorf("unknown join spec need USING or ON but: %v", tok)
+	}
+
+	p.expectToken(sqltoken.LParen)
+	idents, err := p.parseListOfIds(sqltoken.Comma)
+	if err != nil {
+		return nil, errors.Errorf("parse named columns join list failed: %w", err)
+	}
+	p.expectToken(sqltoken.RParen)
+
+	return &sqlast.NamedColumnsJoin{
+		ColumnList: idents,
+	}, nil
+}
+
+func (p *Parser) parseTableFactor() (sqlast.TableFactor, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	isLateral, lateralTok, _ := p.parseKeyword("LATERAL")
+	if lparen, _ := p.peekToken(); lparen != nil && lparen.Kind == sqltoken.LParen {
+		p.mustNextToken()
+		subquery, err := p.parseQuery()
 		if err != nil {
-			return nil, errors.Errorf("parseColumnNames of DROP INDEX failed: %w", err)
+			return nil, errors.Errorf("parseQuery failed: %w", err)
+		}
+		rparen, _ := p.nextToken()
+		if rparen == nil || rparen.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", rparen)
 		}
+		alias, columns, columnsRParen, err := p.parseTableAlias()
+		if err != nil {
+			return nil, errors.Errorf("parseTableAlias failed: %w", err)
+		}
+		d := &sqlast.Derived{
+			Lateral:       isLateral,
+			LParen:        lparen.From,
+			RParen:        rparen.To,
+			SubQuery:      subquery,
+			Alias:         alias,
+			Columns:       columns,
+			ColumnsRParen: columnsRParen,
+		}
+		if isLateral {
+			d.LateralPos = lateralTok.From
+		}
+		return d, nil
+	}
 
-		return &sqlast.DropIndexStmt{
-			Drop:       tok.From,
-			IndexNames: idents,
-		}, nil
+	if !isLateral {
+		if ok, unnestTok, _ := p.parseKeyword("UNNEST"); ok {
+			if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.LParen {
+				return p.parseUnnest(unnestTok)
+			}
+			p.prevToken()
+		}
 	}
-	exists, _, _ := p.parseKeywords("IF", "EXISTS")
-	tableName, err := p.parseObjectName()
+
+	name, err := p.parseObjectName()
 	if err != nil {
 		return nil, errors.Errorf("parseObjectName failed: %w", err)
 	}
 
-	var caspos sqltoken.Pos
-	cascade, t, _ := p.parseKeyword("CASCADE")
-	if cascade {
-		caspos = t.To
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		args, err := p.parseOptionalArgs()
+		if err != nil {
+			return nil, errors.Errorf("parseOptionalArgs failed: %w", err)
+		}
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
+		}
+		alias, columns, columnsRParen, err := p.parseTableAlias()
+		if err != nil {
+			return nil, errors.Errorf("parseTableAlias failed: %w", err)
+		}
+		t := &sqlast.TableFunction{
+			Lateral: isLateral,
+			Function: &sqlast.Function{
+				Name:       name,
+				Args:       args,
+				ArgsRParen: r.To,
+			},
+			Alias:         alias,
+			Columns:       columns,
+			ColumnsRParen: columnsRParen,
+		}
+		if isLateral {
+			t.LateralPos = lateralTok.From
+		}
+		return t, nil
+	} else if isLateral {
+		t, _ := p.nextToken()
+		return nil, errors.Errorf("after lateral expected subquery or function but %+v", t)
 	}
 
-	return &sqlast.DropTableStmt{
-		Drop:       tok.From,
-		TableNames: []*sqlast.ObjectName{tableName},
-		Cascade:    cascade,
-		IfExists:   exists,
-		CascadePos: caspos,
-	}, nil
-}
-
-func (p *Parser) parseAlterColumn(alt *sqltoken.Token) (*sqlast.AlterColumnTableAction, error) {
-	columnName, err := p.parseIdentifier()
+	alias, columns, columnsRParen, err := p.parseTableAlias()
 	if err != nil {
-		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		return nil, errors.Errorf("parseTableAlias failed: %w", err)
 	}
 
-	tok := p.mustNextToken()
-	if tok.Kind != sqltoken.SQLKeyword {
-		return nil, errors.Errorf("must be SQLKeyword but: %v", tok)
+	table := &sqlast.Table{
+		Name:          name,
+		Alias:         alias,
+		Columns:       columns,
+		ColumnsRParen: columnsRParen,
 	}
-
-	word := tok.Value.(*sqltoken.SQLWord)
-
-	switch word.Keyword {
-	case "SET":
-		if ok, _, _ := p.parseKeyword("DEFAULT"); ok {
-			def, err := p.parseDefaultExpr(0)
+	if p.indexHints() {
+		hints, err := p.parseIndexHints()
+		if err != nil {
+			return nil, errors.Errorf("parseIndexHints failed: %w", err)
+		}
+		table.IndexHints = hints
+	}
+	if p.clickHouse() {
+		if ok, tok, _ := p.parseKeyword("FINAL"); ok {
+			table.Final = true
+			table.FinalPos = tok.To
+		}
+		if ok, tok, _ := p.parseKeyword("SAMPLE"); ok {
+			s, err := p.parseClickHouseSample(tok)
 			if err != nil {
-				return nil, errors.Errorf("parseDefaultExpr failed: %w", err)
+				return nil, errors.Errorf("parseClickHouseSample failed: %w", err)
 			}
-			return &sqlast.AlterColumnTableAction{
-				ColumnName: columnName,
-				Alter:      alt.From,
-				Action: &sqlast.SetDefaultColumnAction{
-					Set:     tok.From,
-					Default: def,
-				},
-			}, nil
-		}
-		if ok, toks, _ := p.parseKeywords("NOT", "NULL"); ok {
-			return &sqlast.AlterColumnTableAction{
-				ColumnName: columnName,
-				Alter:      alt.From,
-				Action: &sqlast.PGSetNotNullColumnAction{
-					Set:  tok.From,
-					Null: toks[1].To,
-				},
-			}, nil
+			table.CHSample = s
 		}
+	}
 
-		return nil, errors.Errorf("unknown SET action")
-	case "DROP":
-		if ok, deftok, _ := p.parseKeyword("DEFAULT"); ok {
-			return &sqlast.AlterColumnTableAction{
-				ColumnName: columnName,
-				Alter:      alt.From,
-				Action: &sqlast.DropDefaultColumnAction{
-					Drop:    tok.From,
-					Default: deftok.To,
-				},
-			}, nil
-		}
-		if ok, toks, _ := p.parseKeywords("NOT", "NULL"); ok {
-			return &sqlast.AlterColumnTableAction{
-				ColumnName: columnName,
-				Alter:      alt.From,
-				Action: &sqlast.PGDropNotNullColumnAction{
-					Drop: tok.From,
-					Null: toks[1].To,
-				},
-			}, nil
-		}
-		return nil, errors.Errorf("unknown DROP action")
-	case "TYPE":
-		tp, err := p.ParseDataType()
+	var sample *sqlast.TableSample
+	if ok, tok, _ := p.parseKeyword("TABLESAMPLE"); ok {
+		s, err := p.parseTableSample(tok)
 		if err != nil {
-			return nil, errors.Errorf("ParseDataType failed: %w", err)
+			return nil, errors.Errorf("parseTableSample failed: %w", err)
 		}
+		sample = s
+	}
 
-		return &sqlast.AlterColumnTableAction{
-			ColumnName: columnName,
-			Alter:      alt.From,
-			Action: &sqlast.PGAlterDataTypeColumnAction{
-				Type:     tok.From,
-				DataType: tp,
-			},
-		}, nil
-	default:
-		return nil, errors.Errorf("unknown alter column action %v", word)
+	var withHints []sqlast.Node
+	if ok, _, _ := p.parseKeyword("WITH"); ok {
+		if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+			h, err := p.parseExprList()
+			if err != nil {
+				return nil, errors.Errorf("parseExprList failed: %w", err)
+			}
+			withHints = h
+			p.expectToken(sqltoken.RParen)
+		} else {
+			p.prevToken()
+		}
 	}
+
+	table.WithHints = withHints
+	table.Sample = sample
+	return table, nil
+
 }
 
-func (p *Parser) parseDefaultExpr(precedence uint) (sqlast.Node, error) {
-	expr, err := p.parsePrefix()
-	if err != nil {
-		return nil, errors.Errorf("parsePrefix failed: %w", err)
-	}
+// parseIndexHints parses the index hints following a table, i.e:
+// `{USE|FORCE|IGNORE} {INDEX|KEY} [FOR {JOIN|ORDER BY|GROUP BY}] (Indexes...)`.
+func (p *Parser) parseIndexHints() ([]*sqlast.IndexHint, error) {
+	var hints []*sqlast.IndexHint
 	for {
-		tok, _ := p.peekToken()
-		if tok != nil && tok.Kind == sqltoken.SQLKeyword {
-			w := tok.Value.(*sqltoken.SQLWord)
-			if w.Keyword == "NOT" || w.Keyword == "NULL" {
-				break
-			}
+		tok, err := p.peekToken()
+		if err != nil {
+			return hints, nil
+		}
+		word, ok := tok.Value.(*sqltoken.SQLWord)
+		if !ok || word.QuoteStyle != 0 {
+			return hints, nil
 		}
+		h := &sqlast.IndexHint{From: tok.From}
+		switch word.Keyword {
+		case "USE":
+			h.Action = sqlast.UseIndex
+		case "FORCE":
+			h.Action = sqlast.ForceIndex
+		case "IGNORE":
+			h.Action = sqlast.IgnoreIndex
+		default:
+			return hints, nil
+		}
+		p.mustNextToken()
 
-		nextPrecedence, err := p.getNextPrecedence()
-		if err != nil {
-			return nil, errors.Errorf("getNextPrecedence failed: %w", err)
+		if ok, _, _ := p.parseKeyword("KEY"); ok {
+			h.Key = true
+		} else {
+			p.expectKeyword("INDEX")
+		}
+		if ok, _, _ := p.parseKeyword("FOR"); ok {
+			if ok, _, _ := p.parseKeyword("JOIN"); ok {
+				h.For = sqlast.IndexHintForJoin
+			} else if ok, _, _ := p.parseKeywords("ORDER", "BY"); ok {
+				h.For = sqlast.IndexHintForOrderBy
+			} else if ok, _, _ := p.parseKeywords("GROUP", "BY"); ok {
+				h.For = sqlast.IndexHintForGroupBy
+			} else {
+				t, _ := p.peekToken()
+				return nil, errors.Errorf("expected JOIN, ORDER BY or GROUP BY but %+v", t)
+			}
 		}
-		if precedence >= nextPrecedence {
-			break
+
+		p.expectToken(sqltoken.LParen)
+		for {
+			if tok, _ := p.peekToken(); tok != nil && tok.Kind == sqltoken.RParen && len(h.Indexes) == 0 {
+				if h.Action != sqlast.UseIndex {
+					return nil, errors.Errorf("index list of %s INDEX must not be empty at %s", h.Action.ToSQLString(), tok.From.String())
+				}
+				break
+			}
+			// PRIMARY is the name of the primary key
+			if ok, tok, _ := p.parseKeyword("PRIMARY"); ok {
+				h.Indexes = append(h.Indexes, newIdent(tok))
+			} else {
+				index, err := p.parseIdentifier()
+				if err != nil {
+					return nil, errors.Errorf("parseIdentifier failed: %w", err)
+				}
+				h.Indexes = append(h.Indexes, index)
+			}
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
 		}
-		expr, err = p.parseInfix(expr, nextPrecedence)
-		if err != nil {
-			return nil, errors.Errorf("parseInfix failed: %w")
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
 		}
+		h.RParen = r.To
+		hints = append(hints, h)
 	}
-	return expr, nil
 }
 
-func (p *Parser) parseOptionalAlias(reservedKeywords map[string]struct{}) *sqlast.Ident {
-	afterAs, _, _ := p.parseKeyword("AS")
-	maybeAlias, _ := p.nextToken()
-
-	if maybeAlias == nil {
+// parseOptimizerHints parses a comment `/*+ ... */` right after SELECT,
+// INSERT, UPDATE or DELETE as optimizer hints if the dialect supports them.
+// It returns nil if there is no such comment, or if the comment isn't a list
+// of hints, which is left as an ordinary comment.
+func (p *Parser) parseOptimizerHints() *sqlast.OptimizerHints {
+	d, ok := p.dialect.(dialect.OptimizerHintDialect)
+	if !ok || !d.OptimizerHints() {
 		return nil
 	}
-
-	if maybeAlias.Kind == sqltoken.SQLKeyword {
-
-		word := maybeAlias.Value.(*sqltoken.SQLWord)
-		if afterAs || !containsStr(reservedKeywords, word.Keyword) {
-			return &sqlast.Ident{
-				Value: word.String(),
-				From:  maybeAlias.From,
-				To:    maybeAlias.To,
-			}
+	for i := p.index; i < uint(len(p.tokens)); i++ {
+		tok := p.tokens[i]
+		if tok.Kind == sqltoken.Whitespace {
+			continue
 		}
+		if tok.Kind != sqltoken.Comment || !strings.HasPrefix(tok.Raw, "/*+") {
+			return nil
+		}
+		hints := splitOptimizerHints(tok)
+		if hints != nil {
+			// the hints are not a comment anymore
+			p.index = i + 1
+		}
+		return hints
 	}
-	if afterAs {
-		log.Panicf("expected an identifier after AS")
-	}
-	p.prevToken()
 	return nil
 }
 
-func (p *Parser) parseCTEList() ([]*sqlast.CTE, error) {
-	var ctes []*sqlast.CTE
+// splitOptimizerHints splits the comment `/*+ ... */` into hints
+// `Name[(Args...)]`. It returns nil if the comment has anything else.
+func splitOptimizerHints(comment *sqltoken.Token) *sqlast.OptimizerHints {
+	src := []rune(strings.TrimSuffix(comment.Raw, "*/"))
+	pos := make([]sqltoken.Pos, len(src)+1)
+	cur := comment.From
+	for i, r := range src {
+		pos[i] = cur
+		if r == '\n' {
+			cur.Line++
+			cur.Col = 1
+		} else {
+			cur.Col++
+		}
+	}
+	pos[len(src)] = cur
 
+	hints := &sqlast.OptimizerHints{From: comment.From, To: comment.To}
+	i := len("/*+")
 	for {
-		alias, err := p.parseIdentifier()
-		if err != nil {
-			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		for i < len(src) && unicode.IsSpace(src[i]) {
+			i++
 		}
-		p.expectKeyword("AS")
-		p.expectToken(sqltoken.LParen)
-		q, err := p.parseQuery()
-		if err != nil {
-			return nil, errors.Errorf("parseQuery failed: %w", err)
+		if i == len(src) {
+			return hints
 		}
-		ctes = append(ctes, &sqlast.CTE{
-			Alias: alias,
-			Query: q,
-		})
-		p.expectToken(sqltoken.RParen)
-		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
-			break
+		if src[i] != '_' && !unicode.IsLetter(src[i]) {
+			return nil
+		}
+		start := i
+		for i < len(src) && (src[i] == '_' || src[i] == '$' || unicode.IsLetter(src[i]) || unicode.IsDigit(src[i])) {
+			i++
+		}
+		h := &sqlast.OptimizerHint{From: pos[start], To: pos[i], Name: string(src[start:i])}
+
+		j := i
+		for j < len(src) && unicode.IsSpace(src[j]) {
+			j++
+		}
+		if j < len(src) && src[j] == '(' {
+			args, end, ok := splitOptimizerHintArgs(src, j+1)
+			if !ok {
+				return nil
+			}
+			h.Args = args
+			h.To = pos[end]
+			i = end
 		}
+		hints.Hints = append(hints.Hints, h)
 	}
-	return ctes, nil
 }
 
-func (p *Parser) parseFromClause() ([]sqlast.TableReference, error) {
-	var res []sqlast.TableReference
+// splitOptimizerHintArgs splits the arguments of a hint starting at src[i]
+// by commas outside of parentheses and quotes, and returns them with the
+// index just after the closing parenthesis.
+func splitOptimizerHintArgs(src []rune, i int) ([]string, int, bool) {
+	args := []string{}
+	start := i
+	depth := 0
+	var quote rune
+	for ; i < len(src); i++ {
+		r := src[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case r == ')':
+			if arg := strings.TrimSpace(string(src[start:i])); arg != "" || len(args) != 0 {
+				args = append(args, arg)
+			}
+			return args, i + 1, true
+		case r == ',' && depth == 0:
+			args = append(args, strings.TrimSpace(string(src[start:i])))
+			start = i + 1
+		}
+	}
+	return nil, 0, false
+}
 
-	table, err := p.parseTableReference()
+// parseClickHouseSample parses the rest of `SAMPLE Ratio [OFFSET Offset]`.
+func (p *Parser) parseClickHouseSample(sample *sqltoken.Token) (*sqlast.CHSample, error) {
+	ratio, err := p.ParseExpr()
 	if err != nil {
-		return nil, errors.Errorf("parseTable failed: %w", err)
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
 	}
-
-	res = append(res, table)
-
-	for {
-		ok, _ := p.consumeToken(sqltoken.Comma)
-		if !ok {
-			break
-		}
-		table, err := p.parseTableReference()
+	s := &sqlast.CHSample{Sample: sample.From, Ratio: ratio}
+	if ok, _, _ := p.parseKeyword("OFFSET"); ok {
+		offset, err := p.ParseExpr()
 		if err != nil {
-			return nil, errors.Errorf("parseTable failed: %w", err)
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
 		}
-		res = append(res, table)
+		s.Offset = offset
 	}
-
-	return res, nil
+	return s, nil
 }
 
-func (p *Parser) parseTableReference() (sqlast.TableReference, error) {
-	leftElem, err := p.parseTableFactor()
+// parseTableSample parses `Method(Args...) [REPEATABLE (Seed)]` after TABLESAMPLE
+func (p *Parser) parseTableSample(tablesample *sqltoken.Token) (*sqlast.TableSample, error) {
+	method, err := p.parseIdentifier()
 	if err != nil {
-		return nil, errors.Errorf("parse joined table left element failed: %w", err)
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		tok, _ := p.peekToken()
+		return nil, errors.Errorf("expected LParen but %+v", tok)
+	}
+	args, err := p.parseExprList()
+	if err != nil {
+		return nil, errors.Errorf("parseExprList failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
 	}
 
-	e := sqlast.TableReference(leftElem)
+	sample := &sqlast.TableSample{
+		TableSample: tablesample.From,
+		Method:      method,
+		Args:        args,
+		RParen:      r.To,
+	}
 
-	for {
-		right, err := p.parseTableReferenceRight()
+	if ok, _, _ := p.parseKeyword("REPEATABLE"); ok {
+		if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+			tok, _ := p.peekToken()
+			return nil, errors.Errorf("expected LParen but %+v", tok)
+		}
+		seed, err := p.ParseExpr()
 		if err != nil {
-			return nil, errors.Errorf("parse table reference right failed: %w", err)
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
 		}
+		sample.Repeatable = seed
+		sample.RepeatableRParen = r.To
+	}
+
+	return sample, nil
+}
+
+func (p *Parser) parseUnnest(unnestTok *sqltoken.Token) (*sqlast.Unnest, error) {
+	p.expectToken(sqltoken.LParen)
+	exprs, err := p.parseExprList()
+	if err != nil {
+		return nil, errors.Errorf("parseExprList failed: %w", err)
+	}
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
 
-		if right == nil {
-			break
-		}
+	u := &sqlast.Unnest{
+		Unnest: unnestTok.From,
+		Exprs:  exprs,
+		RParen: r.To,
+	}
 
-		switch rtp := right.(type) {
-		case *sqlast.NaturalJoin:
-			rtp.LeftElement = &sqlast.TableJoinElement{
-				Ref: e,
-			}
-			e = rtp
-		case *sqlast.CrossJoin:
-			rtp.Reference = leftElem
-			e = rtp
-		case *sqlast.QualifiedJoin:
-			rtp.LeftElement = &sqlast.TableJoinElement{
-				Ref: e,
-			}
-			e = rtp
-		default:
-			return nil, errors.Errorf("unknown join")
+	if ok, _, _ := p.parseKeyword("WITH"); ok {
+		ok, ordTok, _ := p.parseKeyword("ORDINALITY")
+		if !ok {
+			return nil, errors.Errorf("expected ORDINALITY after WITH but %+v", ordTok)
 		}
+		u.WithOrdinality = true
+		u.Ordinality = ordTok.To
 	}
 
-	return e, nil
+	alias, columns, columnsRParen, err := p.parseTableAlias()
+	if err != nil {
+		return nil, errors.Errorf("parseTableAlias failed: %w", err)
+	}
+	u.Alias = alias
+	u.Columns = columns
+	u.ColumnsRParen = columnsRParen
+
+	return u, nil
 }
 
-func (p *Parser) parseTableReferenceRight() (sqlast.TableReference, error) {
-	if ok, _ := p.consumeToken(sqltoken.Comma); ok {
-		p.prevToken()
-		return nil, nil
+// parseTableAlias parses `[AS] alias [(column, ...)]`
+func (p *Parser) parseTableAlias() (*sqlast.Ident, []*sqlast.Ident, sqltoken.Pos, error) {
+	alias := p.parseOptionalAlias(dialect.ReservedForTableAlias)
+	if alias == nil {
+		return nil, nil, sqltoken.Pos{}, nil
 	}
 
-	tok, err := p.nextToken()
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		return alias, nil, sqltoken.Pos{}, nil
+	}
+
+	columns, err := p.parseColumnNames()
 	if err != nil {
-		return nil, nil
+		return nil, nil, sqltoken.Pos{}, errors.Errorf("parseColumnNames failed: %w", err)
 	}
-	word, ok := tok.Value.(*sqltoken.SQLWord)
-	if !ok {
-		p.prevToken()
-		return nil, nil
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, nil, sqltoken.Pos{}, errors.Errorf("expected RParen but %+v", r)
 	}
 
-	switch word.Keyword {
-	case "NATURAL":
-		tp, err := p.parseJoinType()
-		if err != nil {
-			return nil, errors.Errorf("parse natural join type failed: %w", err)
-		}
-		p.expectKeyword("JOIN")
-		rightElem, err := p.parseTableReference()
-		if err != nil {
-			return nil, errors.Errorf("parse natural join right element failed: %w", err)
-		}
+	return alias, columns, r.To, nil
+}
 
-		return &sqlast.NaturalJoin{
-			Type: tp,
-			RightElement: &sqlast.TableJoinElement{
-				Ref: rightElem,
-			},
-		}, nil
-	case "CROSS":
-		p.expectKeyword("JOIN")
-		rightElem, err := p.parseTableFactor()
-		if err != nil {
-			return nil, errors.Errorf("parse cross join right element failed: %w", err)
+// parseLimit parses `LIMIT {n | ALL} [OFFSET n [ROW | ROWS]]`, or
+// `OFFSET n [ROW | ROWS] [LIMIT {n | ALL}]` which PostgreSQL also accepts.
+// It returns nil if neither LIMIT nor OFFSET is found.
+func (p *Parser) parseLimit() (*sqlast.LimitExpr, error) {
+	if ok, tok, _ := p.parseKeyword("LIMIT"); ok {
+		l := &sqlast.LimitExpr{Limit: tok.From}
+		if err := p.parseLimitValue(l); err != nil {
+			return nil, err
 		}
-
-		return &sqlast.CrossJoin{
-			Factor: rightElem,
-		}, nil
-	case "INNER":
-		p.expectKeyword("JOIN")
-		ref, err := p.parseTableReference()
-		if err != nil {
-			return nil, errors.Errorf("parse inner join right elem filed: %w", err)
+		if ok, _, _ := p.parseKeyword("OFFSET"); ok {
+			if err := p.parseOffsetValue(l); err != nil {
+				return nil, err
+			}
 		}
-		spec, err := p.parseJoinSpec()
-		if err != nil {
-			return nil, errors.Errorf("parse inner join spec filed: %w", err)
+		return l, nil
+	}
+
+	ok, tok, _ := p.parseKeyword("OFFSET")
+	if !ok {
+		return nil, nil
+	}
+	l := &sqlast.LimitExpr{Limit: tok.From}
+	if err := p.parseOffsetValue(l); err != nil {
+		return nil, err
+	}
+	if ok, _, _ := p.parseKeyword("LIMIT"); ok {
+		if err := p.parseLimitValue(l); err != nil {
+			return nil, err
 		}
+	}
+	return l, nil
+}
 
-		return &sqlast.QualifiedJoin{
-			RightElement: &sqlast.TableJoinElement{
-				Ref: ref,
-			},
-			Spec: spec,
-			Type: &sqlast.JoinType{Condition: sqlast.INNER},
-		}, nil
-	case "LEFT", "RIGHT", "FULL", "JOIN":
-		p.prevToken()
-		tp, err := p.parseJoinType()
-		if err != nil {
-			return nil, errors.Errorf("parse qualified join type failed: %w", err)
+// parseLimitValue parses `{n | ALL}` after LIMIT into l. n may exceed the
+// range of int64, e.g: 18446744073709551615 of MySQL, or be a placeholder.
+func (p *Parser) parseLimitValue(l *sqlast.LimitExpr) error {
+	if ok, tok, _ := p.parseKeyword("ALL"); ok {
+		l.All = true
+		l.AllPos = tok.To
+		l.To = tok.To
+		return nil
+	}
+	tok, _ := p.nextToken()
+	if tok != nil {
+		if ph := p.parsePlaceholder(tok); ph != nil {
+			l.LimitValue = ph
+			l.To = ph.To
+			return nil
 		}
-		p.expectKeyword("JOIN")
-		ref, err := p.parseTableReference()
-		if err != nil {
-			return nil, errors.Errorf("parse qualified join right elem failed: %w", err)
+	}
+	if tok == nil || tok.Kind != sqltoken.Number {
+		return errors.Errorf("invalid limit value: expect literal int but %v", tok)
+	}
+	str := tok.Value.(string)
+	if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+		l.LimitValue = &sqlast.LongValue{
+			Long: i,
+			From: tok.From,
+			To:   tok.To,
 		}
-
-		spec, err := p.parseJoinSpec()
-		if err != nil {
-			return nil, errors.Errorf("parse qualified join spec failed: %w", err)
+	} else if u, uerr := strconv.ParseUint(str, 10, 64); uerr == nil {
+		l.LimitValue = &sqlast.UnsignedLongValue{
+			Long: u,
+			From: tok.From,
+			To:   tok.To,
 		}
+	} else {
+		return errors.Errorf("invalid limit value %s at %+v: %w", str, tok.From, err)
+	}
+	l.To = tok.To
+	return nil
+}
 
-		return &sqlast.QualifiedJoin{
-			RightElement: &sqlast.TableJoinElement{
-				Ref: ref,
-			},
-			Type: tp,
-			Spec: spec,
-		}, nil
+// parseOffsetValue parses `n [ROW | ROWS]` after OFFSET into l. n may be a
+// placeholder.
+func (p *Parser) parseOffsetValue(l *sqlast.LimitExpr) error {
+	o, err := p.parseCount()
+	if err != nil {
+		return errors.Errorf("invalid offset value: %w", err)
+	}
+	l.OffsetValue = o
+	l.To = o.End()
+	if unit, t := p.parseFetchUnit(); unit != sqlast.FetchUnitNone {
+		l.OffsetUnit = unit
+		l.To = t.To
+	}
+	return nil
+}
 
-	default:
-		p.prevToken()
-		return nil, nil
+// parseFetchUnit parses ROW or ROWS if exists.
+func (p *Parser) parseFetchUnit() (sqlast.FetchUnit, *sqltoken.Token) {
+	if ok, t, _ := p.parseKeyword("ROWS"); ok {
+		return sqlast.FetchUnitRows, t
+	}
+	if ok, t, _ := p.parseKeyword("ROW"); ok {
+		return sqlast.FetchUnitRow, t
 	}
+	return sqlast.FetchUnitNone, nil
 }
 
-func (p *Parser) parseJoinType() (*sqlast.JoinType, error) {
-	tok, _ := p.nextToken()
-	word, ok := tok.Value.(*sqltoken.SQLWord)
+// parseFetch parses `FETCH {FIRST | NEXT} [n] {ROW | ROWS} {ONLY | WITH TIES}`.
+// It returns nil if FETCH is not found.
+func (p *Parser) parseFetch() (*sqlast.Fetch, error) {
+	ok, tok, _ := p.parseKeyword("FETCH")
 	if !ok {
-		return nil, errors.Errorf("unknown join type %v", tok)
+		return nil, nil
 	}
+	f := &sqlast.Fetch{Fetch: tok.From}
 
-	switch word.Keyword {
-	case "INNER":
-		return &sqlast.JoinType{
-			Condition: sqlast.INNER,
-			From:      tok.From,
-			To:        tok.To,
-		}, nil
-	case "LEFT":
-		outer, _, _ := p.parseKeyword("OUTER")
-		if outer {
-			return &sqlast.JoinType{
-				Condition: sqlast.LEFTOUTER,
-				From:      tok.From,
-				To:        tok.To,
-			}, nil
-		}
-		return &sqlast.JoinType{
-			Condition: sqlast.LEFT,
-			From:      tok.From,
-			To:        tok.To,
-		}, nil
-	case "RIGHT":
-		outer, _, _ := p.parseKeyword("OUTER")
-		if outer {
-			return &sqlast.JoinType{Condition: sqlast.RIGHTOUTER}, nil
-		}
-		return &sqlast.JoinType{Condition: sqlast.RIGHT}, nil
-	case "FULL":
-		outer, _, _ := p.parseKeyword("OUTER")
-		if outer {
-			return &sqlast.JoinType{Condition: sqlast.FULLOUTER}, nil
-		}
-		return &sqlast.JoinType{Condition: sqlast.FULL}, nil
-	case "JOIN":
-		p.prevToken()
-		return &sqlast.JoinType{Condition: sqlast.IMPLICIT}, nil
-	default:
-		return nil, errors.Errorf("unknown join type: %v", word)
+	if ok, _, _ := p.parseKeyword("NEXT"); ok {
+		f.Next = true
+	} else if ok, t, _ := p.parseKeyword("FIRST"); !ok {
+		return nil, errors.Errorf("expected FIRST or NEXT after FETCH but %+v", t)
 	}
-}
 
-func (p *Parser) parseJoinSpec() (sqlast.JoinSpec, error) {
-	if ok, tok, _ := p.parseKeyword("ON"); ok {
-		expr, err := p.ParseExpr()
+	if t, _ := p.peekToken(); t != nil && t.Kind != sqltoken.SQLKeyword {
+		c, err := p.parseCount()
 		if err != nil {
-			return nil, errors.Errorf("parse join condition failed: %w", err)
+			return nil, errors.Errorf("invalid fetch value: %w", err)
 		}
-		return &sqlast.JoinCondition{
-			SearchCondition: expr,
-			On:              tok.From,
-		}, nil
+		f.Count = c
 	}
 
-	ok, _, _ := p.parseKeyword("USING")
-	if !ok {
-		tok, _ := p.nextToken()
-		return nil, errors.Errorf("unknown join spec need USING or ON but: %v", tok)
+	if f.Unit, _ = p.parseFetchUnit(); f.Unit == sqlast.FetchUnitNone {
+		t, _ := p.peekToken()
+		return nil, errors.Errorf("expected ROW or ROWS but %+v", t)
 	}
 
-	p.expectToken(sqltoken.LParen)
-	idents, err := p.parseListOfIds(sqltoken.Comma)
-	if err != nil {
-		return nil, errors.Errorf("parse named columns join list failed: %w", err)
+	if ok, t, _ := p.parseKeyword("ONLY"); ok {
+		f.To = t.To
+	} else if ok, _, _ := p.parseKeyword("WITH"); ok {
+		ok, t, _ := p.parseKeyword("TIES")
+		if !ok {
+			return nil, errors.Errorf("expected TIES after WITH but %+v", t)
+		}
+		f.WithTies = true
+		f.To = t.To
+	} else {
+		t, _ := p.peekToken()
+		return nil, errors.Errorf("expected ONLY or WITH TIES but %+v", t)
 	}
-	p.expectToken(sqltoken.RParen)
 
-	return &sqlast.NamedColumnsJoin{
-		ColumnList: idents,
-	}, nil
+	return f, nil
 }
 
-func (p *Parser) parseTableFactor() (sqlast.TableFactor, error) {
-	isLateral, _, _ := p.parseKeyword("LATERAL")
-	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
-		subquery, err := p.parseQuery()
-		if err != nil {
-			return nil, errors.Errorf("parseQuery failed: %w", err)
-		}
-		p.expectToken(sqltoken.RParen)
-		alias := p.parseOptionalAlias(dialect.ReservedForTableAlias)
-		return &sqlast.Derived{
-			Lateral:  isLateral,
-			SubQuery: subquery,
-			Alias:    alias,
-		}, nil
-	} else if isLateral && !ok {
-		t, _ := p.nextToken()
-		return nil, errors.Errorf("after lateral expected %s but %+v", sqltoken.LParen, t)
+// parseTop parses `TOP {n | (n)} [PERCENT] [WITH TIES]` of SQL Server if exists.
+func (p *Parser) parseTop() (*sqlast.Top, error) {
+	ok, tok, _ := p.parseKeyword("TOP")
+	if !ok {
+		return nil, nil
 	}
-
-	name, err := p.parseObjectName()
+	top := &sqlast.Top{Top: tok.From}
+	top.Parens, _ = p.consumeToken(sqltoken.LParen)
+	c, err := p.parseCount()
 	if err != nil {
-		return nil, errors.Errorf("parseObjectName failed: %w", err)
+		return nil, errors.Errorf("invalid top value: %w", err)
 	}
-	var args []sqlast.Node
-	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
-		a, err := p.parseOptionalArgs()
-		if err != nil {
-			return nil, errors.Errorf("parseOptionalArgs failed: %w", err)
+	top.Count = c
+	top.To = c.End()
+	if top.Parens {
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
 		}
-		args = a
+		top.To = r.To
 	}
-	alias := p.parseOptionalAlias(dialect.ReservedForTableAlias)
-
-	var withHints []sqlast.Node
-	if ok, _, _ := p.parseKeyword("WITH"); ok {
-		if ok, _ := p.consumeToken(sqltoken.LParen); ok {
-			h, err := p.parseExprList()
-			if err != nil {
-				return nil, errors.Errorf("parseExprList failed: %w", err)
-			}
-			withHints = h
-			p.expectToken(sqltoken.RParen)
-		} else {
-			p.prevToken()
-		}
+	if ok, t, _ := p.parseKeyword("PERCENT"); ok {
+		top.Percent = true
+		top.To = t.To
 	}
-
-	return &sqlast.Table{
-		Name:      name,
-		Args:      args,
-		Alias:     alias,
-		WithHints: withHints,
-	}, nil
-
+	if ok, toks, _ := p.parseKeywords("WITH", "TIES"); ok {
+		top.WithTies = true
+		top.To = toks[1].To
+	}
+	return top, nil
 }
 
-func (p *Parser) parseLimit() (*sqlast.LimitExpr, error) {
-	if ok, _, _ := p.parseKeyword("ALL"); ok {
-		return &sqlast.LimitExpr{All: true}, nil
+// newIdent makes an identifier from tok, which must hold a *sqltoken.SQLWord.
+func newIdent(tok *sqltoken.Token) *sqlast.Ident {
+	word := tok.Value.(*sqltoken.SQLWord)
+	return &sqlast.Ident{
+		Value:         word.Value,
+		Quoted:        word.QuoteStyle != 0,
+		QuoteStyle:    word.QuoteStyle,
+		UnicodeEscape: word.UnicodeEscape,
+		From:          tok.From,
+		To:            tok.To,
 	}
+}
 
-	i, tok, err := p.parseLiteralInt()
+func (p *Parser) parseIdentifier() (*sqlast.Ident, error) {
+	p.suggestIdentifier()
+	tok, err := p.nextToken()
 	if err != nil {
-		return nil, errors.Errorf("invalid limit value: %w", err)
+		return nil, errors.Errorf("nextToken failed: %w", err)
+	}
+	if tok.Kind == sqltoken.Colon {
+		if ident := p.parseNamedPlaceholder(tok); ident != nil {
+			return ident, nil
+		}
+	}
+	if _, ok := tok.Value.(*sqltoken.SQLWord); !ok {
+		return nil, errors.Errorf("expected identifier but %+v", tok)
+	}
+	if !p.isIdentifier(tok) {
+		return nil, errors.Errorf("expected identifier but reserved keyword %+v", tok)
 	}
 
-	var offset *sqlast.LongValue
-	if ok, tok, _ := p.parseKeyword("OFFSET"); ok {
-		o, _, err := p.parseLiteralInt()
-		if err != nil {
-			return nil, errors.Errorf("invalid offset value: %w", err)
+	return newIdent(tok), nil
+}
+
+// parsePlaceholder parses a placeholder `?`, `$1` or `:name` which starts
+// with tok, or returns nil if tok doesn't start a placeholder.
+func (p *Parser) parsePlaceholder(tok *sqltoken.Token) *sqlast.Placeholder {
+	switch tok.Kind {
+	case sqltoken.Question:
+		return &sqlast.Placeholder{
+			Value: "?",
+			From:  tok.From,
+			To:    tok.To,
 		}
-		offset = &sqlast.LongValue{
-			Long: int64(o),
-			From: tok.From,
-			To:   tok.To,
+	case sqltoken.Char:
+		// `$1` style placeholders
+		if tok.Value.(string) == "$" {
+			if n, _ := p.peekToken(); n != nil && n.Kind == sqltoken.Number && n.From == tok.To {
+				p.mustNextToken()
+				return &sqlast.Placeholder{
+					Value: "$" + n.Value.(string),
+					From:  tok.From,
+					To:    n.To,
+				}
+			}
+		}
+	case sqltoken.Colon:
+		// `:name` style placeholders
+		if ident := p.parseNamedPlaceholder(tok); ident != nil {
+			return &sqlast.Placeholder{
+				Value: ident.Value,
+				From:  ident.From,
+				To:    ident.To,
+			}
 		}
 	}
+	return nil
+}
 
-	return &sqlast.LimitExpr{
-		LimitValue: &sqlast.LongValue{
-			Long: int64(i),
-			From: tok.From,
-			To:   tok.To,
-		},
-		OffsetValue: offset,
-	}, nil
+// parseNamedPlaceholder parses the name of a `:name` placeholder after colon
+// and returns the identifier `:name`, or nil if colon isn't followed by a
+// name immediately. The identifier is to be substituted by
+// sqlastutil.Substitute.
+func (p *Parser) parseNamedPlaceholder(colon *sqltoken.Token) *sqlast.Ident {
+	n, _ := p.peekToken()
+	if n == nil || n.Kind != sqltoken.SQLKeyword || n.From != colon.To {
+		return nil
+	}
+	word := n.Value.(*sqltoken.SQLWord)
+	if word.QuoteStyle != 0 {
+		return nil
+	}
+	p.mustNextToken()
+	return &sqlast.Ident{Value: ":" + word.Value, From: colon.From, To: n.To}
 }
 
-func (p *Parser) parseIdentifier() (*sqlast.Ident, error) {
+// parseWord parses a word including reserved keywords, which is used for
+// names of options such as `NULL` in `COPY ... WITH (NULL 'x')`.
+func (p *Parser) parseWord() (*sqlast.Ident, error) {
 	tok, err := p.nextToken()
 	if err != nil {
 		return nil, errors.Errorf("nextToken failed: %w", err)
 	}
-	word, ok := tok.Value.(*sqltoken.SQLWord)
-	if !ok {
-		return nil, errors.Errorf("expected identifier but %+v", tok)
+	if _, ok := tok.Value.(*sqltoken.SQLWord); !ok {
+		return nil, errors.Errorf("expected word but %+v", tok)
 	}
 
-	return &sqlast.Ident{
-		From:  tok.From,
-		To:    tok.To,
-		Value: word.String(),
-	}, nil
+	return newIdent(tok), nil
 }
 
 func (p *Parser) parseExprList() ([]sqlast.Node, error) {
@@ -1934,17 +6163,120 @@ func (p *Parser) parseExprList() ([]sqlast.Node, error) {
 	return exprList, nil
 }
 
+func (p *Parser) parseGroupByList() ([]sqlast.Node, error) {
+	var list []sqlast.Node
+
+	for {
+		e, err := p.parseGroupingElement(false)
+		if err != nil {
+			return nil, errors.Errorf("parseGroupingElement failed: %w", err)
+		}
+		list = append(list, e)
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+
+	return list, nil
+}
+
+// parseGroupingElement parses an element of GROUP BY clause.
+// If inSet is true, parenthesized list such as `(a, b)` is parsed as RowValueExpr.
+func (p *Parser) parseGroupingElement(inSet bool) (sqlast.Node, error) {
+	tok, err := p.peekToken()
+	if err != nil {
+		return nil, errors.Errorf("peekToken failed: %w", err)
+	}
+
+	if word, ok := tok.Value.(*sqltoken.SQLWord); ok && word.QuoteStyle == 0 {
+		switch strings.ToUpper(word.Value) {
+		case "GROUPING":
+			if ok, _, _ := p.parseKeywords("GROUPING", "SETS"); ok {
+				p.expectToken(sqltoken.LParen)
+				sets, r, err := p.parseGroupingSetList()
+				if err != nil {
+					return nil, errors.Errorf("parseGroupingSetList failed: %w", err)
+				}
+				return &sqlast.GroupingSets{Grouping: tok.From, Sets: sets, RParen: r}, nil
+			}
+		case "ROLLUP", "CUBE":
+			p.mustNextToken()
+			if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+				p.prevToken()
+				break
+			}
+			sets, r, err := p.parseGroupingSetList()
+			if err != nil {
+				return nil, errors.Errorf("parseGroupingSetList failed: %w", err)
+			}
+			if strings.EqualFold(word.Value, "ROLLUP") {
+				return &sqlast.Rollup{Rollup: tok.From, Sets: sets, RParen: r}, nil
+			}
+			return &sqlast.Cube{Cube: tok.From, Sets: sets, RParen: r}, nil
+		}
+	}
+
+	if tok.Kind == sqltoken.LParen {
+		p.mustNextToken()
+		if r, _ := p.peekToken(); r != nil && r.Kind == sqltoken.RParen {
+			p.mustNextToken()
+			return &sqlast.RowValueExpr{LParen: tok.From, RParen: r.To}, nil
+		}
+		if inSet {
+			values, err := p.parseExprList()
+			if err != nil {
+				return nil, errors.Errorf("parseExprList failed: %w", err)
+			}
+			r, _ := p.nextToken()
+			if r == nil || r.Kind != sqltoken.RParen {
+				return nil, errors.Errorf("expected RParen but %+v", r)
+			}
+			return &sqlast.RowValueExpr{Values: values, LParen: tok.From, RParen: r.To}, nil
+		}
+		p.prevToken()
+	}
+
+	return p.ParseExpr()
+}
+
+// parseGroupingSetList parses elements of GROUPING SETS, ROLLUP and CUBE after LParen
+func (p *Parser) parseGroupingSetList() ([]sqlast.Node, sqltoken.Pos, error) {
+	var sets []sqlast.Node
+	for {
+		e, err := p.parseGroupingElement(true)
+		if err != nil {
+			return nil, sqltoken.Pos{}, errors.Errorf("parseGroupingElement failed: %w", err)
+		}
+		sets = append(sets, e)
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, sqltoken.Pos{}, errors.Errorf("expected RParen but %+v", r)
+	}
+
+	return sets, r.To, nil
+}
+
 func (p *Parser) parseColumnNames() ([]*sqlast.Ident, error) {
 	return p.parseListOfIds(sqltoken.Comma)
 }
 
 func (p *Parser) parseSubexpr(precedence uint) (sqlast.Node, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
 	expr, err := p.parsePrefix()
 	if err != nil {
 		return nil, errors.Errorf("parsePrefix failed: %w", err)
 	}
 
 	for {
+		p.suggestKeywords(infixKeywords...)
 		nextPrecedence, err := p.getNextPrecedence()
 		if err != nil {
 			return nil, errors.Errorf("getNextPrecedence failed: %w", err)
@@ -1992,6 +6324,44 @@ func (p *Parser) parseInfix(expr sqlast.Node, precedence uint) (sqlast.Node, err
 		operator = sqlast.Modulus
 	case sqltoken.Div:
 		operator = sqlast.Divide
+	case sqltoken.Arrow:
+		operator = sqlast.JSONGet
+	case sqltoken.LongArrow:
+		operator = sqlast.JSONGetText
+	case sqltoken.HashArrow:
+		operator = sqlast.JSONPathGet
+	case sqltoken.HashLongArrow:
+		operator = sqlast.JSONPathGetText
+	case sqltoken.AtArrow:
+		operator = sqlast.Contains
+	case sqltoken.ArrowAt:
+		operator = sqlast.ContainedBy
+	case sqltoken.Question:
+		operator = sqlast.JSONExists
+	case sqltoken.QuestionPipe:
+		operator = sqlast.JSONExistsAny
+	case sqltoken.QuestionAnd:
+		operator = sqlast.JSONExistsAll
+	case sqltoken.Tilde:
+		operator = sqlast.RegexMatch
+	case sqltoken.TildeAsterisk:
+		operator = sqlast.RegexIMatch
+	case sqltoken.ExclamationMarkTilde:
+		operator = sqlast.NotRegexMatch
+	case sqltoken.ExclamationMarkTildeAsterisk:
+		operator = sqlast.NotRegexIMatch
+	case sqltoken.StringConcat:
+		operator = sqlast.StringConcat
+	case sqltoken.Ampersand:
+		operator = sqlast.BitwiseAnd
+	case sqltoken.Pipe:
+		operator = sqlast.BitwiseOr
+	case sqltoken.Sharp:
+		operator = sqlast.BitwiseXor
+	case sqltoken.ShiftLeft:
+		operator = sqlast.ShiftLeft
+	case sqltoken.ShiftRight:
+		operator = sqlast.ShiftRight
 	case sqltoken.SQLKeyword:
 		word := tok.Value.(*sqltoken.SQLWord)
 		switch word.Keyword {
@@ -2001,10 +6371,20 @@ func (p *Parser) parseInfix(expr sqlast.Node, precedence uint) (sqlast.Node, err
 			operator = sqlast.Or
 		case "LIKE":
 			operator = sqlast.Like
+		case "ILIKE":
+			operator = sqlast.ILike
+		case "SIMILAR":
+			if ok, t, _ := p.parseKeyword("TO"); !ok {
+				return nil, errors.Errorf("expected TO but %s", t)
+			}
+			operator = sqlast.SimilarTo
 		case "NOT":
-			ok, _, _ := p.parseKeyword("LIKE")
-			if ok {
+			if ok, _, _ := p.parseKeyword("LIKE"); ok {
 				operator = sqlast.NotLike
+			} else if ok, _, _ := p.parseKeyword("ILIKE"); ok {
+				operator = sqlast.NotILike
+			} else if ok, _, _ := p.parseKeywords("SIMILAR", "TO"); ok {
+				operator = sqlast.NotSimilarTo
 			}
 		}
 	}
@@ -2015,11 +6395,27 @@ func (p *Parser) parseInfix(expr sqlast.Node, precedence uint) (sqlast.Node, err
 			return nil, errors.Errorf("parseSubexpr failed: %w", err)
 		}
 
-		return &sqlast.BinaryExpr{
+		bin := &sqlast.BinaryExpr{
 			Left:  expr,
 			Op:    &sqlast.Operator{Type: operator, From: tok.From, To: tok.To},
 			Right: right,
-		}, nil
+		}
+
+		switch operator {
+		case sqlast.Like, sqlast.NotLike, sqlast.ILike, sqlast.NotILike, sqlast.SimilarTo, sqlast.NotSimilarTo:
+			if ok, _, _ := p.parseKeyword("ESCAPE"); ok {
+				escape, err := p.parseSubexpr(precedence)
+				if err != nil {
+					return nil, errors.Errorf("parseSubexpr failed: %w", err)
+				}
+				return &sqlast.LikeEscape{
+					Like:   bin,
+					Escape: escape,
+				}, nil
+			}
+		}
+
+		return bin, nil
 	}
 
 	if tok.Kind == sqltoken.SQLKeyword {
@@ -2038,6 +6434,15 @@ func (p *Parser) parseInfix(expr sqlast.Node, precedence uint) (sqlast.Node, err
 				}, nil
 			}
 			return nil, errors.Errorf("NULL or NOT NULL after IS")
+		case "COLLATE":
+			collation, err := p.parseObjectName()
+			if err != nil {
+				return nil, errors.Errorf("parseObjectName failed: %w", err)
+			}
+			return &sqlast.Collate{
+				Expr:      expr,
+				Collation: collation,
+			}, nil
 		case "NOT", "IN", "BETWEEN":
 			p.prevToken()
 			negated, _, _ := p.parseKeyword("NOT")
@@ -2054,8 +6459,90 @@ func (p *Parser) parseInfix(expr sqlast.Node, precedence uint) (sqlast.Node, err
 		return p.parsePGCast(expr)
 	}
 
-	log.Panicf("no infix parser for sqltoken %+v", tok)
-	return nil, nil
+	if tok.Kind == sqltoken.LBracket {
+		return p.parseSubscript(expr)
+	}
+
+	return nil, errors.Errorf("no infix parser for sqltoken %+v", tok)
+}
+
+// parseSubscript parses `[Index]` or `[Lower:Upper]` after LBracket
+func (p *Parser) parseSubscript(expr sqlast.Node) (sqlast.Node, error) {
+	s := &sqlast.Subscript{Expr: expr}
+
+	if t, _ := p.peekToken(); t != nil && t.Kind != sqltoken.Colon {
+		index, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		s.Index = index
+	}
+
+	if ok, _ := p.consumeToken(sqltoken.Colon); ok {
+		s.IsSlice = true
+		if t, _ := p.peekToken(); t != nil && t.Kind != sqltoken.RBracket {
+			upper, err := p.ParseExpr()
+			if err != nil {
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+			s.Upper = upper
+		}
+	}
+
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RBracket {
+		return nil, errors.Errorf("expected RBracket but %+v", r)
+	}
+	s.RBracket = r.To
+
+	return s, nil
+}
+
+// parseArrayConstructor parses `ARRAY[...]`, or `[...]` inside of the constructor
+func (p *Parser) parseArrayConstructor(bare bool) (sqlast.Node, error) {
+	a := &sqlast.ArrayConstructor{Bare: bare}
+	if !bare {
+		ok, tok, _ := p.parseKeyword("ARRAY")
+		if !ok {
+			return nil, errors.Errorf("expected ARRAY but %+v", tok)
+		}
+		a.Array = tok.From
+	}
+
+	l, _ := p.nextToken()
+	if l == nil || l.Kind != sqltoken.LBracket {
+		return nil, errors.Errorf("expected LBracket but %+v", l)
+	}
+	if bare {
+		a.Array = l.From
+	}
+
+	if r, _ := p.peekToken(); r == nil || r.Kind != sqltoken.RBracket {
+		for {
+			var elem sqlast.Node
+			var err error
+			if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.LBracket {
+				elem, err = p.parseArrayConstructor(true)
+			} else {
+				elem, err = p.ParseExpr()
+			}
+			if err != nil {
+				return nil, errors.Errorf("parse array element failed: %w", err)
+			}
+			a.Elements = append(a.Elements, elem)
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+		}
+	}
+
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RBracket {
+		return nil, errors.Errorf("expected RBracket but %+v", r)
+	}
+	a.RBracket = r.To
+
+	return a, nil
 }
 
 // TODO position
@@ -2074,15 +6561,16 @@ func (p *Parser) parseIn(expr sqlast.Node, negated bool) (sqlast.Node, error) {
 	p.expectToken(sqltoken.LParen)
 	sok, _, _ := p.parseKeyword("SELECT")
 	wok, _, _ := p.parseKeyword("WITH")
+	vok, _, _ := p.parseKeyword("VALUES")
 	var inop sqlast.Node
-	if sok || wok {
+	if sok || wok || vok {
 		p.prevToken()
 		q, err := p.parseQuery()
 		if err != nil {
 			return nil, errors.Errorf("parseQuery failed: %w", err)
 		}
 		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
+		if r == nil || r.Kind != sqltoken.RParen {
 			return nil, errors.Errorf("expected RParen but %+v", r)
 		}
 		inop = &sqlast.InSubQuery{
@@ -2097,7 +6585,7 @@ func (p *Parser) parseIn(expr sqlast.Node, negated bool) (sqlast.Node, error) {
 			return nil, errors.Errorf("parseExprList failed: %w", err)
 		}
 		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
+		if r == nil || r.Kind != sqltoken.RParen {
 			return nil, errors.Errorf("expected RParen but %+v", r)
 		}
 		inop = &sqlast.InList{
@@ -2155,25 +6643,38 @@ func (p *Parser) getPrecedence(ts *sqltoken.Token) uint {
 			return 20
 		case "BETWEEN":
 			return 20
-		case "LIKE":
+		case "LIKE", "ILIKE", "SIMILAR":
 			return 20
+		case "COLLATE":
+			return 45
 		default:
 			return 0
 		}
 	case sqltoken.Eq, sqltoken.Lt, sqltoken.LtEq, sqltoken.Neq, sqltoken.Gt, sqltoken.GtEq:
 		return 20
+	case sqltoken.Arrow, sqltoken.LongArrow, sqltoken.HashArrow, sqltoken.HashLongArrow,
+		sqltoken.AtArrow, sqltoken.ArrowAt, sqltoken.Question, sqltoken.QuestionPipe, sqltoken.QuestionAnd:
+		return 25
+	// like Postgres, the other operators bind tighter than comparisons but looser than `+`
+	case sqltoken.StringConcat, sqltoken.Ampersand, sqltoken.Pipe, sqltoken.Sharp, sqltoken.ShiftLeft, sqltoken.ShiftRight:
+		return 25
+	case sqltoken.Tilde, sqltoken.TildeAsterisk, sqltoken.ExclamationMarkTilde, sqltoken.ExclamationMarkTildeAsterisk:
+		return 20
 	case sqltoken.Plus, sqltoken.Minus:
 		return 30
 	case sqltoken.Mult, sqltoken.Div, sqltoken.Mod:
 		return 40
 	case sqltoken.DoubleColon:
 		return 50
+	case sqltoken.LBracket:
+		return 60
 	default:
 		return 0
 	}
 }
 
 func (p *Parser) parsePrefix() (sqlast.Node, error) {
+	p.suggestExpression()
 	tok, err := p.nextToken()
 	if err != nil {
 		return nil, errors.Errorf("nextToken error: %w", err)
@@ -2204,6 +6705,13 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 				return nil, errors.Errorf("parseCastExpression failed: %w", err)
 			}
 			return ast, nil
+		case "INTERVAL":
+			p.prevToken()
+			ast, err := p.parseIntervalExpression()
+			if err != nil {
+				return nil, errors.Errorf("parseIntervalExpression failed: %w", err)
+			}
+			return ast, nil
 		case "EXISTS":
 			p.prevToken()
 			ast, err := p.parseExistsExpression(nil)
@@ -2236,16 +6744,39 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 				Op:   &sqlast.Operator{Type: sqlast.Not},
 				Expr: expr,
 			}, nil
+		case "EXTRACT", "POSITION", "SUBSTRING", "TRIM", "OVERLAY":
+			if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.LParen {
+				p.prevToken()
+				ast, err := p.parseSpecialFunction(word.Keyword)
+				if err != nil {
+					return nil, errors.Errorf("parseSpecialFunction failed: %w", err)
+				}
+				return ast, nil
+			}
+			fallthrough
 		default:
 			t, _ := p.peekToken()
+			if word.Keyword == "ARRAY" && t != nil && t.Kind == sqltoken.LBracket {
+				p.prevToken()
+				ast, err := p.parseArrayConstructor(false)
+				if err != nil {
+					return nil, errors.Errorf("parseArrayConstructor failed: %w", err)
+				}
+				return ast, nil
+			}
+			if word.Keyword == "ROW" && t != nil && t.Kind == sqltoken.LParen {
+				p.mustNextToken()
+				ast, err := p.parseRowExpr(tok)
+				if err != nil {
+					return nil, errors.Errorf("parseRowExpr failed: %w", err)
+				}
+				return ast, nil
+			}
 			if t == nil || (t.Kind != sqltoken.LParen && t.Kind != sqltoken.Period) {
-				return &sqlast.Ident{Value: word.String(),
-					From: tok.From,
-					To:   tok.To,
-				}, nil
+				return newIdent(tok), nil
 			}
 			idParts := []*sqlast.Ident{
-				{Value: word.String(), From: tok.From, To: tok.To},
+				newIdent(tok),
 			}
 			endWithWildcard := false
 
@@ -2253,17 +6784,14 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 				if ok, _ := p.consumeToken(sqltoken.Period); !ok {
 					break
 				}
+				p.suggestIdentifier()
 				n, err := p.nextToken()
 				if err != nil {
 					return nil, errors.Errorf("nextToken failed: %w", err)
 				}
 
 				if n.Kind == sqltoken.SQLKeyword {
-					w := n.Value.(*sqltoken.SQLWord)
-					idParts = append(idParts, &sqlast.Ident{Value: w.String(),
-						From: n.From,
-						To:   n.To,
-					})
+					idParts = append(idParts, newIdent(n))
 					continue
 				}
 				if n.Kind == sqltoken.Mult {
@@ -2280,6 +6808,17 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 				}, nil
 			}
 
+			if rparen, ok := p.parseOuterJoinOperator(); ok {
+				var column sqlast.Node = &sqlast.CompoundIdent{Idents: idParts}
+				if len(idParts) == 1 {
+					column = idParts[0]
+				}
+				return &sqlast.OuterJoinColumn{
+					Column: column,
+					RParen: rparen,
+				}, nil
+			}
+
 			if ok, _ := p.consumeToken(sqltoken.LParen); ok {
 				p.prevToken()
 				name := &sqlast.ObjectName{
@@ -2322,27 +6861,41 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 			Op:   &sqlast.Operator{Type: sqlast.Minus, From: tok.From, To: tok.To},
 			Expr: expr,
 		}, nil
-	case sqltoken.Number, sqltoken.SingleQuotedString, sqltoken.NationalStringLiteral:
+	case sqltoken.Number, sqltoken.SingleQuotedString, sqltoken.NationalStringLiteral, sqltoken.DollarQuotedString,
+		sqltoken.EscapedStringLiteral, sqltoken.UnicodeStringLiteral, sqltoken.HexStringLiteral, sqltoken.HexNumber, sqltoken.BitStringLiteral:
 		p.prevToken()
 		v, err := p.parseSQLValue()
 		if err != nil {
-			return nil, errors.Errorf("parseSQLValue failed", err)
+			return nil, errors.Errorf("parseSQLValue failed: %w", err)
 		}
 		return v, nil
+	case sqltoken.Question:
+		return p.parsePlaceholder(tok), nil
+	case sqltoken.Char:
+		if ph := p.parsePlaceholder(tok); ph != nil {
+			return ph, nil
+		}
+		return nil, errors.Errorf("unexpected character %s", tok.Value)
+	case sqltoken.Colon:
+		if ph := p.parsePlaceholder(tok); ph != nil {
+			return ph, nil
+		}
+		return nil, errors.Errorf("unexpected colon")
 	case sqltoken.LParen:
 		sok, _, _ := p.parseKeyword("SELECT")
 		wok, _, _ := p.parseKeyword("WITH")
+		vok, _, _ := p.parseKeyword("VALUES")
 
 		var ast sqlast.Node
 
-		if sok || wok {
+		if sok || wok || vok {
 			p.prevToken()
 			expr, err := p.parseQuery()
 			if err != nil {
 				return nil, errors.Errorf("parseQuery failed: %w", err)
 			}
 			r, _ := p.nextToken()
-			if r.Kind != sqltoken.RParen {
+			if r == nil || r.Kind != sqltoken.RParen {
 				return nil, errors.Errorf("expected RParen but %+v", r)
 			}
 			ast = &sqlast.SubQuery{
@@ -2351,24 +6904,55 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 				Query:  expr,
 			}
 		} else {
-			expr, err := p.ParseExpr()
+			values, err := p.parseExprList()
 			if err != nil {
-				return nil, errors.Errorf("parseQuery failed: %w", err)
+				return nil, errors.Errorf("parseExprList failed: %w", err)
 			}
 			r, _ := p.nextToken()
-			if r.Kind != sqltoken.RParen {
+			if r == nil || r.Kind != sqltoken.RParen {
 				return nil, errors.Errorf("expected RParen but %+v", r)
 			}
-			ast = &sqlast.Nested{
-				LParen: tok.From,
-				RParen: r.To,
-				AST:    expr,
+			if len(values) > 1 {
+				ast = &sqlast.SQLRowExpr{
+					Row:      tok.From,
+					Implicit: true,
+					Values:   values,
+					RParen:   r.To,
+				}
+			} else {
+				ast = &sqlast.Nested{
+					LParen: tok.From,
+					RParen: r.To,
+					AST:    values[0],
+				}
 			}
 		}
 		return ast, nil
 	}
-	log.Panicf("prefix parser expected a keyword but hit EOF")
-	return nil, nil
+	return nil, errors.Errorf("prefix parser expected a keyword but hit EOF")
+}
+
+// parseRowExpr parses `ROW(Values...)` after LParen
+func (p *Parser) parseRowExpr(row *sqltoken.Token) (sqlast.Node, error) {
+	var values []sqlast.Node
+	if t, _ := p.peekToken(); t != nil && t.Kind != sqltoken.RParen {
+		list, err := p.parseExprList()
+		if err != nil {
+			return nil, errors.Errorf("parseExprList failed: %w", err)
+		}
+		values = list
+	}
+
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expected RParen but %+v", r)
+	}
+
+	return &sqlast.SQLRowExpr{
+		Row:    row.From,
+		Values: values,
+		RParen: r.To,
+	}, nil
 }
 
 func (p *Parser) parseFunction(name *sqlast.ObjectName) (sqlast.Node, error) {
@@ -2379,75 +6963,142 @@ func (p *Parser) parseFunction(name *sqlast.ObjectName) (sqlast.Node, error) {
 	}
 
 	r, _ := p.nextToken()
-	if r.Kind != sqltoken.RParen {
+	if r == nil || r.Kind != sqltoken.RParen {
 		return nil, errors.Errorf("expected RParen but %+v", r)
 	}
 
-	var over *sqlast.WindowSpec
-	if ok, _, _ := p.parseKeyword("OVER"); ok {
-		p.expectToken(sqltoken.LParen)
-
-		var partitionBy []sqlast.Node
-		var partition sqltoken.Pos
+	f := &sqlast.Function{
+		Name:       name,
+		Args:       args,
+		ArgsRParen: r.To,
+	}
 
-		ok, ptok, _ := p.parseKeyword("PARTITION")
-		if ok {
-			p.expectKeyword("BY")
+	if ok, _, _ := p.parseKeywords("WITHIN", "GROUP"); ok {
+		if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+			tok, _ := p.peekToken()
+			return nil, errors.Errorf("expected LParen but %+v", tok)
+		}
+		if ok, tok, _ := p.parseKeywords("ORDER", "BY"); !ok {
+			return nil, errors.Errorf("expected ORDER BY but %+v", tok)
+		}
+		orderBy, err := p.parseOrderByExprList()
+		if err != nil {
+			return nil, errors.Errorf("parseOrderByExprList failed: %w", err)
+		}
+		r, _ := p.nextToken()
+		if r == nil || r.Kind != sqltoken.RParen {
+			return nil, errors.Errorf("expected RParen but %+v", r)
+		}
+		f.WithinGroup = orderBy
+		f.WithinGroupRParen = r.To
+	}
 
-			el, err := p.parseExprList()
+	// FILTER is not reserved, so it is an aggregate filter only if `(WHERE` follows
+	if ok, _, _ := p.parseKeyword("FILTER"); ok {
+		if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+			p.prevToken()
+		} else if ok, _, _ := p.parseKeyword("WHERE"); !ok {
+			p.prevToken()
+			p.prevToken()
+		} else {
+			filter, err := p.ParseExpr()
 			if err != nil {
-				return nil, errors.Errorf("parseExprList failed: %w", err)
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+			r, _ := p.nextToken()
+			if r == nil || r.Kind != sqltoken.RParen {
+				return nil, errors.Errorf("expected RParen but %+v", r)
 			}
-			partitionBy = el
-			partition = ptok.From
+			f.Filter = filter
+			f.FilterRParen = r.To
 		}
+	}
 
-		var orderBy []*sqlast.OrderByExpr
-		var order sqltoken.Pos
-		ok, otok, _ := p.parseKeyword("ORDER")
-		if ok {
-			p.expectKeyword("BY")
-			el, err := p.parseOrderByExprList()
+	if ok, _, _ := p.parseKeyword("OVER"); ok {
+		if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+			name, err := p.parseIdentifier()
 			if err != nil {
-				return nil, errors.Errorf("parseOrderByExprList failed: %w", err)
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
 			}
-			orderBy = el
-			order = otok.From
+			f.OverName = name
+			return f, nil
 		}
-
-		windowFrame, err := p.parseWindowFrame()
+		spec, r, err := p.parseWindowSpec()
 		if err != nil {
-			return nil, errors.Errorf("parseWindowFrame failed: %w", err)
+			return nil, errors.Errorf("parseWindowSpec failed: %w", err)
 		}
+		f.Over = spec
+		f.OverRparen = r
+	}
+
+	return f, nil
+}
+
+func (p *Parser) parseOptionalArgs() ([]sqlast.Node, error) {
+	if ok, _ := p.consumeToken(sqltoken.RParen); ok {
+		p.prevToken()
+		return nil, nil
+	}
 
-		over = &sqlast.WindowSpec{
-			PartitionBy:  partitionBy,
-			OrderBy:      orderBy,
-			WindowsFrame: windowFrame,
-			Partition:    partition,
-			Order:        order,
+	var args []sqlast.Node
+	for {
+		arg, err := p.parseNamedArg()
+		if err != nil {
+			return nil, errors.Errorf("parseNamedArg failed: %w", err)
+		}
+		if arg == nil {
+			expr, err := p.ParseExpr()
+			if err != nil {
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+			args = append(args, expr)
+		} else {
+			args = append(args, arg)
+		}
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
 		}
 	}
-
-	return &sqlast.Function{
-		Name:       name,
-		Args:       args,
-		Over:       over,
-		ArgsRParen: r.To,
-	}, nil
+	return args, nil
 }
 
-func (p *Parser) parseOptionalArgs() ([]sqlast.Node, error) {
-	if ok, _ := p.consumeToken(sqltoken.RParen); ok {
-		p.prevToken()
+// parseNamedArg parses `Name => Value` or `Name := Value` if the dialect
+// accepts the operator. It returns nil without consuming tokens
+// if the next argument is not a named one.
+func (p *Parser) parseNamedArg() (*sqlast.NamedArg, error) {
+	d, ok := p.dialect.(dialect.NamedArgumentDialect)
+	if !ok {
 		return nil, nil
-	} else {
-		as, err := p.parseExprList()
-		if err != nil {
-			return nil, errors.Errorf("parseExprList failed: %w", err)
+	}
+
+	idx := p.index
+	name, _ := p.nextToken()
+	op, _ := p.nextToken()
+	if name == nil || op == nil || name.Kind != sqltoken.SQLKeyword || (op.Kind != sqltoken.RArrow && op.Kind != sqltoken.ColonEq) {
+		p.index = idx
+		return nil, nil
+	}
+
+	supported := false
+	for _, o := range d.NamedArgumentOperators() {
+		if o == op.Value.(string) {
+			supported = true
 		}
-		return as, nil
 	}
+	if !supported {
+		return nil, errors.Errorf("named argument operator %s is not supported in this dialect at %s", op.Value, op.From.String())
+	}
+
+	value, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+
+	return &sqlast.NamedArg{
+		Name:    newIdent(name),
+		ColonEq: op.Kind == sqltoken.ColonEq,
+		Value:   value,
+	}, nil
 }
 
 func (p *Parser) parseOrderByExprList() ([]*sqlast.OrderByExpr, error) {
@@ -2483,10 +7134,96 @@ func (p *Parser) parseOrderByExprList() ([]*sqlast.OrderByExpr, error) {
 	return exprList, nil
 }
 
+// parseWindowSpec parses `[Name] [PARTITION BY ...] [ORDER BY ...] [Frame])` after LParen
+// and returns the position of RParen.
+func (p *Parser) parseWindowSpec() (*sqlast.WindowSpec, sqltoken.Pos, error) {
+	spec := &sqlast.WindowSpec{}
+
+	if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.SQLKeyword {
+		switch t.Value.(*sqltoken.SQLWord).Keyword {
+		case "PARTITION", "ORDER", "ROWS", "RANGE", "GROUPS":
+		default:
+			name, err := p.parseIdentifier()
+			if err != nil {
+				return nil, sqltoken.Pos{}, errors.Errorf("parseIdentifier failed: %w", err)
+			}
+			spec.Name = name
+		}
+	}
+
+	if ok, ptok, _ := p.parseKeyword("PARTITION"); ok {
+		if ok, tok, _ := p.parseKeyword("BY"); !ok {
+			return nil, sqltoken.Pos{}, errors.Errorf("expected BY but %+v", tok)
+		}
+		el, err := p.parseExprList()
+		if err != nil {
+			return nil, sqltoken.Pos{}, errors.Errorf("parseExprList failed: %w", err)
+		}
+		spec.PartitionBy = el
+		spec.Partition = ptok.From
+	}
+
+	if ok, otok, _ := p.parseKeyword("ORDER"); ok {
+		if ok, tok, _ := p.parseKeyword("BY"); !ok {
+			return nil, sqltoken.Pos{}, errors.Errorf("expected BY but %+v", tok)
+		}
+		el, err := p.parseOrderByExprList()
+		if err != nil {
+			return nil, sqltoken.Pos{}, errors.Errorf("parseOrderByExprList failed: %w", err)
+		}
+		spec.OrderBy = el
+		spec.Order = otok.From
+	}
+
+	windowFrame, err := p.parseWindowFrame()
+	if err != nil {
+		return nil, sqltoken.Pos{}, errors.Errorf("parseWindowFrame failed: %w", err)
+	}
+	spec.WindowsFrame = windowFrame
+
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, sqltoken.Pos{}, errors.Errorf("expected RParen but %+v", r)
+	}
+
+	return spec, r.To, nil
+}
+
+// parseWindowClause parses `Name AS (Spec), ...` after WINDOW
+func (p *Parser) parseWindowClause() ([]*sqlast.NamedWindow, error) {
+	var windows []*sqlast.NamedWindow
+	for {
+		name, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		if ok, tok, _ := p.parseKeyword("AS"); !ok {
+			return nil, errors.Errorf("expected AS but %+v", tok)
+		}
+		if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+			tok, _ := p.peekToken()
+			return nil, errors.Errorf("expected LParen but %+v", tok)
+		}
+		spec, r, err := p.parseWindowSpec()
+		if err != nil {
+			return nil, errors.Errorf("parseWindowSpec failed: %w", err)
+		}
+		windows = append(windows, &sqlast.NamedWindow{
+			Name:   name,
+			Spec:   spec,
+			RParen: r,
+		})
+
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			return windows, nil
+		}
+	}
+}
+
 func (p *Parser) parseWindowFrame() (*sqlast.WindowFrame, error) {
 	var windowFrame *sqlast.WindowFrame
 	t, _ := p.peekToken()
-	if t.Kind == sqltoken.SQLKeyword {
+	if t != nil && t.Kind == sqltoken.SQLKeyword {
 		w := t.Value.(*sqltoken.SQLWord)
 		var u sqlast.WindowFrameUnit
 
@@ -2525,7 +7262,6 @@ func (p *Parser) parseWindowFrame() (*sqlast.WindowFrame, error) {
 		}
 	}
 
-	p.expectToken(sqltoken.RParen)
 	return windowFrame, nil
 }
 
@@ -2560,8 +7296,8 @@ func (p *Parser) parseWindowFrameBound() (sqlast.SQLWindowFrameBound, error) {
 	if ok, _, _ := p.parseKeyword("FOLLOWING"); ok {
 		return &sqlast.Following{Bound: rows}, nil
 	}
-	log.Panicln("expected PRECEDING or FOLLOWING")
-	return nil, nil
+	tok, _ := p.peekToken()
+	return nil, errors.Errorf("expected PRECEDING or FOLLOWING but %+v", tok)
 }
 
 func (p *Parser) parseObjectName() (*sqlast.ObjectName, error) {
@@ -2611,7 +7347,7 @@ func (p *Parser) parseValue() (sqlast.Node, error) {
 		}
 	case sqltoken.Number:
 		num := tok.Value.(string)
-		if strings.Contains(num, ".") {
+		if strings.ContainsAny(num, ".eE") {
 			f, err := strconv.ParseFloat(num, 64)
 			if err != nil {
 				return nil, errors.Errorf("parseFloat failed %s", num)
@@ -2622,9 +7358,17 @@ func (p *Parser) parseValue() (sqlast.Node, error) {
 				Double: f,
 			}, nil
 		} else {
-			i, _ := strconv.Atoi(num)
+			i, err := strconv.ParseInt(num, 10, 64)
+			if err != nil {
+				// beyond the range of int64, which is still a valid numeric
+				return &sqlast.NumericValue{
+					From:    tok.From,
+					To:      tok.To,
+					Numeric: num,
+				}, nil
+			}
 			return &sqlast.LongValue{
-				Long: int64(i),
+				Long: i,
 				From: tok.From,
 				To:   tok.To,
 			}, nil
@@ -2632,16 +7376,53 @@ func (p *Parser) parseValue() (sqlast.Node, error) {
 	case sqltoken.SingleQuotedString:
 		str := tok.Value.(string)
 		return &sqlast.SingleQuotedString{
-			From:   tok.From,
-			To:     tok.To,
-			String: str,
+			From:            tok.From,
+			To:              tok.To,
+			String:          str,
+			BackslashEscape: p.backslashEscape(),
 		}, nil
 	case sqltoken.NationalStringLiteral:
 		str := tok.Value.(string)
 		return &sqlast.NationalStringLiteral{
-			String: str,
+			String:          str,
+			From:            tok.From,
+			To:              tok.To,
+			BackslashEscape: p.backslashEscape(),
+		}, nil
+	case sqltoken.EscapedStringLiteral:
+		return &sqlast.EscapedStringLiteral{
+			From:   tok.From,
+			To:     tok.To,
+			String: tok.Value.(string),
+		}, nil
+	case sqltoken.UnicodeStringLiteral:
+		v := tok.Value.(*sqltoken.UnicodeStringValue)
+		return &sqlast.UnicodeStringLiteral{
+			From:   tok.From,
+			To:     tok.To,
+			String: v.Value,
+			Escape: v.Escape,
+		}, nil
+	case sqltoken.DollarQuotedString:
+		v := tok.Value.(*sqltoken.DollarQuotedValue)
+		return &sqlast.DollarQuotedString{
+			From:   tok.From,
+			To:     tok.To,
+			String: v.Value,
+			Tag:    v.Tag,
+		}, nil
+	case sqltoken.HexStringLiteral, sqltoken.HexNumber:
+		return &sqlast.HexValue{
 			From:   tok.From,
 			To:     tok.To,
+			Digits: tok.Value.(string),
+			Quoted: tok.Kind == sqltoken.HexStringLiteral,
+		}, nil
+	case sqltoken.BitStringLiteral:
+		return &sqlast.BitStringLiteral{
+			From:   tok.From,
+			To:     tok.To,
+			String: tok.Value.(string),
 		}, nil
 	default:
 		return nil, errors.Errorf("unexpected sqltoken %v", tok)
@@ -2657,7 +7438,7 @@ func (p *Parser) parseOptionalPrecision() (*uint, sqltoken.Pos, error) {
 		}
 		tok, _ := p.nextToken()
 
-		if tok.Kind != sqltoken.RParen {
+		if tok == nil || tok.Kind != sqltoken.RParen {
 			return nil, sqltoken.Pos{}, errors.Errorf("expected RParen but %s", tok)
 		}
 		i := uint(n)
@@ -2691,8 +7472,8 @@ func (p *Parser) parseOptionalPrecisionScale() (*uint, *uint, error) {
 
 func (p *Parser) parseLiteralInt() (int, *sqltoken.Token, error) {
 	tok, _ := p.nextToken()
-	if tok.Kind != sqltoken.Number {
-		return 0, nil, errors.Errorf("expect literal int but %v", tok.Kind)
+	if tok == nil || tok.Kind != sqltoken.Number {
+		return 0, nil, errors.Errorf("expect literal int but %v", tok)
 	}
 	istr := tok.Value.(string)
 	i, err := strconv.Atoi(istr)
@@ -2703,27 +7484,51 @@ func (p *Parser) parseLiteralInt() (int, *sqltoken.Token, error) {
 	return i, tok, nil
 }
 
+// parseCount parses a literal int or a placeholder, which is the count of
+// rows of OFFSET, FETCH and TOP.
+func (p *Parser) parseCount() (sqlast.LimitCount, error) {
+	if tok, _ := p.nextToken(); tok != nil {
+		if ph := p.parsePlaceholder(tok); ph != nil {
+			return ph, nil
+		}
+		p.prevToken()
+	}
+	i, tok, err := p.parseLiteralInt()
+	if err != nil {
+		return nil, errors.Errorf("parseLiteralInt failed: %w", err)
+	}
+	return &sqlast.LongValue{
+		Long: int64(i),
+		From: tok.From,
+		To:   tok.To,
+	}, nil
+}
+
 func (p *Parser) parseListOfIds(separator sqltoken.Kind) ([]*sqlast.Ident, error) {
 	var idents []*sqlast.Ident
 	expectIdentifier := true
 
 	for {
+		if expectIdentifier {
+			p.suggestIdentifier()
+		}
 		tok, _ := p.nextToken()
 		if tok == nil {
 			break
 		}
-		if tok.Kind == sqltoken.SQLKeyword && expectIdentifier {
+		if tok.Kind == sqltoken.SQLKeyword && expectIdentifier && p.isIdentifier(tok) {
 			expectIdentifier = false
-			word := tok.Value.(*sqltoken.SQLWord)
-			idents = append(idents, &sqlast.Ident{
-				Value: word.String(),
-				From:  tok.From,
-				To:    tok.To,
-			})
+			idents = append(idents, newIdent(tok))
 			continue
 		} else if tok.Kind == separator && !expectIdentifier {
 			expectIdentifier = true
 			continue
+		} else if tok.Kind == sqltoken.Colon && expectIdentifier {
+			if ident := p.parseNamedPlaceholder(tok); ident != nil {
+				expectIdentifier = false
+				idents = append(idents, ident)
+				continue
+			}
 		}
 		p.prevToken()
 		break
@@ -2749,7 +7554,9 @@ func (p *Parser) parseCaseExpression() (sqlast.Node, error) {
 			return nil, errors.Errorf("ParseExpr failed: %w", err)
 		}
 		operand = expr
-		p.expectKeyword("WHEN")
+		if ok, tok, _ := p.parseKeyword("WHEN"); !ok {
+			return nil, errors.Errorf("expected WHEN keyword but %s", tok)
+		}
 	}
 
 	var conditions []sqlast.Node
@@ -2761,7 +7568,9 @@ func (p *Parser) parseCaseExpression() (sqlast.Node, error) {
 			return nil, errors.Errorf("ParseExpr failed: %w", err)
 		}
 		conditions = append(conditions, expr)
-		p.expectKeyword("THEN")
+		if ok, tok, _ := p.parseKeyword("THEN"); !ok {
+			return nil, errors.Errorf("expected THEN keyword but %s", tok)
+		}
 		result, err := p.ParseExpr()
 		if err != nil {
 			return nil, errors.Errorf("ParseExpr failed: %w", err)
@@ -2812,7 +7621,7 @@ func (p *Parser) parseCastExpression() (sqlast.Node, error) {
 		return nil, errors.Errorf("ParseDataType")
 	}
 	r, _ := p.nextToken()
-	if r.Kind != sqltoken.RParen {
+	if r == nil || r.Kind != sqltoken.RParen {
 		return nil, errors.Errorf("expect RParen but %+v", r)
 	}
 
@@ -2824,6 +7633,259 @@ func (p *Parser) parseCastExpression() (sqlast.Node, error) {
 	}, nil
 }
 
+// parseSpecialFunction parses functions whose arguments are separated by keywords
+// such as EXTRACT(YEAR FROM x) or SUBSTRING(x FROM 1 FOR 2)
+func (p *Parser) parseSpecialFunction(name string) (sqlast.Node, error) {
+	tok, err := p.nextToken()
+	if err != nil {
+		return nil, errors.Errorf("nextToken failed: %w", err)
+	}
+	p.expectToken(sqltoken.LParen)
+
+	var node sqlast.Node
+	switch name {
+	case "EXTRACT":
+		f, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		p.expectKeyword("FROM")
+		expr, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		node = &sqlast.Extract{Field: f, Expr: expr, Extract: tok.From}
+	case "POSITION":
+		// parse substring with IN's precedence so that IN is not consumed as an infix operator
+		sub, err := p.parseSubexpr(20)
+		if err != nil {
+			return nil, errors.Errorf("parseSubexpr failed: %w", err)
+		}
+		p.expectKeyword("IN")
+		str, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		node = &sqlast.Position{Substring: sub, String: str, Position: tok.From}
+	case "SUBSTRING":
+		expr, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		s := &sqlast.Substring{Expr: expr, Substring: tok.From}
+		if ok, _ := p.consumeToken(sqltoken.Comma); ok {
+			s.CommaSyntax = true
+			if s.Start, err = p.ParseExpr(); err != nil {
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+			if ok, _ := p.consumeToken(sqltoken.Comma); ok {
+				if s.Length, err = p.ParseExpr(); err != nil {
+					return nil, errors.Errorf("ParseExpr failed: %w", err)
+				}
+			}
+		} else {
+			if ok, _, _ := p.parseKeyword("FROM"); ok {
+				if s.Start, err = p.ParseExpr(); err != nil {
+					return nil, errors.Errorf("ParseExpr failed: %w", err)
+				}
+			}
+			if ok, _, _ := p.parseKeyword("FOR"); ok {
+				if s.Length, err = p.ParseExpr(); err != nil {
+					return nil, errors.Errorf("ParseExpr failed: %w", err)
+				}
+			}
+		}
+		node = s
+	case "TRIM":
+		t := &sqlast.Trim{Trim: tok.From}
+		if ok, _, _ := p.parseKeyword("BOTH"); ok {
+			t.Where = sqlast.TrimBoth
+		} else if ok, _, _ := p.parseKeyword("LEADING"); ok {
+			t.Where = sqlast.TrimLeading
+		} else if ok, _, _ := p.parseKeyword("TRAILING"); ok {
+			t.Where = sqlast.TrimTrailing
+		}
+
+		if ok, _, _ := p.parseKeyword("FROM"); !ok {
+			first, err := p.ParseExpr()
+			if err != nil {
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+			if ok, _, _ := p.parseKeyword("FROM"); ok {
+				t.Characters = first
+			} else if t.Where != sqlast.NoTrimWhere {
+				return nil, errors.Errorf("expected FROM after TRIM characters")
+			} else {
+				t.Expr = first
+			}
+		}
+		if t.Expr == nil {
+			if t.Expr, err = p.ParseExpr(); err != nil {
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+		}
+		node = t
+	case "OVERLAY":
+		o := &sqlast.Overlay{Overlay: tok.From}
+		if o.Expr, err = p.ParseExpr(); err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		p.expectKeyword("PLACING")
+		if o.Placing, err = p.ParseExpr(); err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		p.expectKeyword("FROM")
+		if o.Start, err = p.ParseExpr(); err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		if ok, _, _ := p.parseKeyword("FOR"); ok {
+			if o.Length, err = p.ParseExpr(); err != nil {
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+		}
+		node = o
+	default:
+		return nil, errors.Errorf("unknown special function %s", name)
+	}
+
+	r, _ := p.nextToken()
+	if r == nil || r.Kind != sqltoken.RParen {
+		return nil, errors.Errorf("expect RParen but %+v", r)
+	}
+
+	switch n := node.(type) {
+	case *sqlast.Extract:
+		n.RParen = r.To
+	case *sqlast.Position:
+		n.RParen = r.To
+	case *sqlast.Substring:
+		n.RParen = r.To
+	case *sqlast.Trim:
+		n.RParen = r.To
+	case *sqlast.Overlay:
+		n.RParen = r.To
+	}
+
+	return node, nil
+}
+
+func (p *Parser) parseIntervalExpression() (sqlast.Node, error) {
+	ok, tok, _ := p.parseKeyword("INTERVAL")
+	if !ok {
+		return nil, errors.Errorf("expected INTERVAL but %+v", tok)
+	}
+
+	literal, err := p.parsePrefix()
+	if err != nil {
+		return nil, errors.Errorf("parsePrefix failed: %w", err)
+	}
+
+	interval := &sqlast.IntervalValue{
+		Interval: tok.From,
+		To:       literal.End(),
+		Literal:  literal,
+	}
+
+	field, ftok := p.parseDateTimeField()
+	if field == sqlast.NoDateTimeField {
+		return interval, nil
+	}
+	interval.LeadingField = field
+	interval.To = ftok.To
+
+	if field == sqlast.SecondField {
+		// SECOND(p, f) has the precision of fractional seconds as well
+		precision, frac, r, err := p.parseSecondPrecision()
+		if err != nil {
+			return nil, errors.Errorf("parseSecondPrecision failed: %w", err)
+		}
+		if precision != nil {
+			interval.LeadingPrecision = precision
+			interval.FractionalSecondsPrecision = frac
+			interval.To = r
+		}
+		return interval, nil
+	}
+
+	precision, r, err := p.parseOptionalPrecision()
+	if err != nil {
+		return nil, errors.Errorf("parseOptionalPrecision failed: %w", err)
+	}
+	if precision != nil {
+		interval.LeadingPrecision = precision
+		interval.To = r
+	}
+
+	if ok, _, _ := p.parseKeyword("TO"); !ok {
+		return interval, nil
+	}
+
+	last, ltok := p.parseDateTimeField()
+	if last == sqlast.NoDateTimeField {
+		return nil, errors.Errorf("expected date time field after TO but %+v", ltok)
+	}
+	interval.LastField = last
+	interval.To = ltok.To
+
+	if last == sqlast.SecondField {
+		precision, r, err := p.parseOptionalPrecision()
+		if err != nil {
+			return nil, errors.Errorf("parseOptionalPrecision failed: %w", err)
+		}
+		if precision != nil {
+			interval.FractionalSecondsPrecision = precision
+			interval.To = r
+		}
+	}
+
+	return interval, nil
+}
+
+// parseSecondPrecision parses `[(p [, f])]` after the leading SECOND of INTERVAL.
+func (p *Parser) parseSecondPrecision() (*uint, *uint, sqltoken.Pos, error) {
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		return nil, nil, sqltoken.Pos{}, nil
+	}
+	n, _, err := p.parseLiteralInt()
+	if err != nil {
+		return nil, nil, sqltoken.Pos{}, errors.Errorf("parseLiteralInt failed: %w", err)
+	}
+	precision := uint(n)
+	var frac *uint
+	if ok, _ := p.consumeToken(sqltoken.Comma); ok {
+		f, _, err := p.parseLiteralInt()
+		if err != nil {
+			return nil, nil, sqltoken.Pos{}, errors.Errorf("parseLiteralInt failed: %w", err)
+		}
+		uf := uint(f)
+		frac = &uf
+	}
+	tok, _ := p.nextToken()
+	if tok == nil || tok.Kind != sqltoken.RParen {
+		return nil, nil, sqltoken.Pos{}, errors.Errorf("expected RParen but %s", tok)
+	}
+	return &precision, frac, tok.To, nil
+}
+
+func (p *Parser) parseDateTimeField() (sqlast.DateTimeField, *sqltoken.Token) {
+	tok, err := p.peekToken()
+	if err != nil {
+		return sqlast.NoDateTimeField, nil
+	}
+	word, ok := tok.Value.(*sqltoken.SQLWord)
+	if !ok || word.QuoteStyle != 0 {
+		return sqlast.NoDateTimeField, tok
+	}
+
+	var f sqlast.DateTimeField
+	field, ok := f.FromStr(word.Keyword)
+	if !ok {
+		return sqlast.NoDateTimeField, tok
+	}
+	p.mustNextToken()
+	return field, tok
+}
+
 func (p *Parser) parseExistsExpression(negatedTok *sqltoken.Token) (sqlast.Node, error) {
 	ok, tok, _ := p.parseKeyword("EXISTS")
 	if !ok {
@@ -2837,7 +7899,7 @@ func (p *Parser) parseExistsExpression(negatedTok *sqltoken.Token) (sqlast.Node,
 	}
 
 	r, _ := p.nextToken()
-	if r.Kind != sqltoken.RParen {
+	if r == nil || r.Kind != sqltoken.RParen {
 		return nil, errors.Errorf("expect RParen but %+v", r)
 	}
 
@@ -2866,14 +7928,54 @@ func (p *Parser) parseMyUnsigned() (bool, sqltoken.Pos) {
 	return false, sqltoken.Pos{}
 }
 
+// parseMyZerofill parses ZEROFILL of MySQL numeric types.
+func (p *Parser) parseMyZerofill() (bool, sqltoken.Pos) {
+	if !p.mySQLSyntax() {
+		return false, sqltoken.Pos{}
+	}
+	if ok, z, _ := p.parseKeyword("ZEROFILL"); ok {
+		return ok, z.To
+	}
+
+	return false, sqltoken.Pos{}
+}
+
+// parseMyDisplayWidth parses the display width of MySQL integer types, e.g. `(11)` of int(11).
+func (p *Parser) parseMyDisplayWidth() (*uint, sqltoken.Pos, error) {
+	if !p.mySQLSyntax() {
+		return nil, sqltoken.Pos{}, nil
+	}
+	return p.parseOptionalPrecision()
+}
+
+// bailout is the panic value to abort parsing from the functions which can't
+// return an error, such as expectKeyword. It's recovered by the exported
+// methods with recoverBailout.
+type bailout struct {
+	err error
+}
+
+func (p *Parser) bail(err error) {
+	panic(bailout{err: err})
+}
+
+// recoverBailout sets the error of bailout to err. Any other panic is
+// propagated.
+func (p *Parser) recoverBailout(err *error) {
+	if r := recover(); r != nil {
+		b, ok := r.(bailout)
+		if !ok {
+			panic(r)
+		}
+		*err = b.err
+	}
+}
+
 func (p *Parser) expectKeyword(expected string) *sqltoken.Token {
 	ok, tok, err := p.parseKeyword(expected)
 	if err != nil || !ok {
-		for i := 0; i < int(p.index); i++ {
-			fmt.Printf("%v", p.tokens[i].Value)
-		}
-		fmt.Println()
-		log.Panicf("should be expected keyword: %s err: %v", expected, err)
+		tok, _ := p.peekToken()
+		p.bail(errors.Errorf("expected keyword %s but %+v", expected, tok))
 	}
 
 	return tok
@@ -2883,16 +7985,12 @@ func (p *Parser) expectToken(expected sqltoken.Kind) {
 	ok, err := p.consumeToken(expected)
 	if err != nil || !ok {
 		tok, _ := p.peekToken()
-
-		for i := 0; i < int(p.index); i++ {
-			fmt.Printf("%v", p.tokens[i].Value)
-		}
-		fmt.Println()
-		log.Panicf("should be %s sqltoken, but %+v,  err: %+v", expected, tok, err)
+		p.bail(errors.Errorf("expected %s but %+v", expected, tok))
 	}
 }
 
 func (p *Parser) consumeToken(expected sqltoken.Kind) (bool, error) {
+	p.suggestToken(expected)
 	tok, err := p.peekToken()
 	if err != nil {
 		return false, err
@@ -2911,7 +8009,7 @@ func (p *Parser) consumeToken(expected sqltoken.Kind) (bool, error) {
 func (p *Parser) mustNextToken() *sqltoken.Token {
 	tok, err := p.nextToken()
 	if err != nil {
-		log.Panicf("%+v", err)
+		p.bail(errors.Errorf("unexpected end of input: %w", err))
 	}
 
 	return tok
@@ -3027,6 +8125,10 @@ func (p *Parser) nextTokenWithParseComment() (*sqltoken.Token, error) {
 
 var EOF = errors.New("tokens are already consumed")
 
+// ErrLimitExceeded is wrapped by the error returned when the input exceeds
+// a limit set by MaxDepth, MaxTokens or MaxStatements.
+var ErrLimitExceeded = errors.New("parser limit exceeded")
+
 func (p *Parser) nextTokenNoSkip() (*sqltoken.Token, error) {
 	if p.index < uint(len(p.tokens)) {
 		p.index += 1
@@ -3077,6 +8179,11 @@ func (p *Parser) tilNonWhitespace() (uint, error) {
 }
 
 func (p *Parser) parseKeywords(keywords ...string) (bool, []*sqltoken.Token, error) {
+	if p.atCursor() {
+		// suggest the whole sequence instead of the first keyword
+		p.suggestKeywords(strings.Join(keywords, " "))
+		return false, []*sqltoken.Token{nil}, nil
+	}
 	idx := p.index
 
 	var toks []*sqltoken.Token
@@ -3093,6 +8200,7 @@ func (p *Parser) parseKeywords(keywords ...string) (bool, []*sqltoken.Token, err
 }
 
 func (p *Parser) parseKeyword(expected string) (bool, *sqltoken.Token, error) {
+	p.suggestKeywords(expected)
 	tok, err := p.peekToken()
 	if err != nil {
 		return false, nil, errors.Errorf("parseKeyword %s failed: %w", expected, err)
@@ -3103,13 +8211,30 @@ func (p *Parser) parseKeyword(expected string) (bool, *sqltoken.Token, error) {
 		return false, tok, nil
 	}
 
-	if strings.EqualFold(word.Value, expected) {
+	if word.QuoteStyle == 0 && strings.EqualFold(word.Value, expected) {
 		p.mustNextToken()
 		return true, tok, nil
 	}
 	return false, tok, nil
 }
 
+// Pos returns the first position of the last token consumed by the parser.
+// After ParseStatement or ParseSQL fails, it points at (or right before)
+// the token which caused the error.
+func (p *Parser) Pos() sqltoken.Pos {
+	for i := int(p.index) - 1; i >= 0; i-- {
+		if i >= len(p.tokens) {
+			continue
+		}
+		tok := p.tokens[i]
+		if tok.Kind == sqltoken.Whitespace || tok.Kind == sqltoken.Comment {
+			continue
+		}
+		return tok.From
+	}
+	return sqltoken.NewPos(1, 1)
+}
+
 func (p *Parser) Debug() {
 	for i := 0; i < int(p.index); i++ {
 		fmt.Printf("%v", p.tokens[i].Value)
@@ -0,0 +1,71 @@
+//go:build go1.18
+// +build go1.18
+
+package xsqlparser
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+var fuzzDialects = []dialect.Dialect{
+	&dialect.GenericSQLDialect{},
+	&dialect.PostgresqlDialect{},
+	&dialect.MySQLDialect{},
+	&dialect.OracleDialect{},
+	&dialect.RedshiftDialect{},
+	&dialect.HiveDialect{},
+	&dialect.ClickHouseDialect{},
+}
+
+// FuzzParseSQL checks the parser returns an error instead of panicking on any
+// input, and Parallel doesn't change the result.
+func FuzzParseSQL(f *testing.F) {
+	files, err := filepath.Glob("e2e/testdata/*/*.sql")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(src))
+	}
+	f.Add("SELECT a FROM t WHERE b = 1;")
+	f.Add("CREATE TABLE t (id int PRIMARY KEY);")
+
+	f.Fuzz(func(t *testing.T, src string) {
+		for _, d := range fuzzDialects {
+			parser, err := NewParser(bytes.NewBufferString(src), d, ParseComment())
+			if err != nil {
+				continue
+			}
+			stmts, err := parser.ParseSQL()
+
+			parallel, perr := NewParser(bytes.NewBufferString(src), d, ParseComment(), Parallel(2))
+			if perr != nil {
+				t.Fatalf("%+v", perr)
+			}
+			pstmts, perr := parallel.ParseSQL()
+			if (err == nil) != (perr == nil) {
+				t.Fatalf("sequential: %v, parallel: %v", err, perr)
+			}
+			if err != nil {
+				continue
+			}
+			if len(stmts) != len(pstmts) {
+				t.Fatalf("sequential: %d statements, parallel: %d statements", len(stmts), len(pstmts))
+			}
+			for i, stmt := range stmts {
+				if sql := stmt.ToSQLString(); sql != pstmts[i].ToSQLString() {
+					t.Fatalf("sequential: %s, parallel: %s", sql, pstmts[i].ToSQLString())
+				}
+			}
+		}
+	})
+}
@@ -2,9 +2,15 @@ package xsqlparser
 
 import (
 	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	errors "golang.org/x/xerrors"
 
 	"github.com/akito0107/xsqlparser/dialect"
 	"github.com/akito0107/xsqlparser/sqlast"
@@ -494,11 +500,13 @@ ORDER BY product_units LIMIT 100`,
 						},
 					},
 					Limit: &sqlast.LimitExpr{
+						Limit: sqltoken.NewPos(4, 24),
 						LimitValue: &sqlast.LongValue{
 							From: sqltoken.NewPos(4, 30),
 							To:   sqltoken.NewPos(4, 33),
 							Long: 100,
 						},
+						To: sqltoken.NewPos(4, 33),
 					},
 				},
 			},
@@ -511,6 +519,7 @@ FROM orders
 WHERE region IN (SELECT region FROM top_regions)
 GROUP BY region, product`,
 				out: &sqlast.QueryStmt{
+					With: sqltoken.NewPos(1, 1),
 					CTEs: []*sqlast.CTE{
 						{
 							Alias: &sqlast.Ident{
@@ -908,143 +917,145 @@ FROM user WHERE id BETWEEN 1 AND 2`,
 					},
 				},
 			},
-		}
-
-		for _, c := range cases {
-
-			t.Run(c.name, func(t *testing.T) {
-				if c.skip {
-					t.Skip()
-				}
-				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
-				if err != nil {
-					t.Fatal(err)
-				}
-				ast, err := parser.ParseStatement()
-				if err != nil {
-					t.Fatal(err)
-				}
-
-				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
-					t.Errorf("diff %s", diff)
-				}
-			})
-		}
-	})
-
-	t.Run("create", func(t *testing.T) {
-		cases := []struct {
-			name string
-			in   string
-			out  sqlast.Stmt
-			skip bool
-		}{
 			{
-				name: "create table",
-				in: `
-CREATE TABLE persons (
- person_id UUID PRIMARY KEY NOT NULL,
- first_name varchar(255) UNIQUE,
- last_name character varying(255) NOT NULL,
- created_at timestamp DEFAULT CURRENT_TIMESTAMP NOT NULL
-)`,
-				out: &sqlast.CreateTableStmt{
-					Create: sqltoken.NewPos(2, 1),
-					Name: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							{
-								Value: "persons",
-								From:  sqltoken.NewPos(2, 14),
-								To:    sqltoken.NewPos(2, 21),
+				name: "table function",
+				in:   "SELECT n FROM generate_series(1, 3) AS g(n)",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: sqlast.NewIdentWithPos("n", sqltoken.NewPos(1, 8), sqltoken.NewPos(1, 9)),
 							},
 						},
-					},
-					Elements: []sqlast.TableElement{
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "person_id",
-								From:  sqltoken.NewPos(3, 2),
-								To:    sqltoken.NewPos(3, 11),
-							},
-							DataType: &sqlast.UUID{
-								From: sqltoken.NewPos(3, 12),
-								To:   sqltoken.NewPos(3, 16),
-							},
-							Constraints: []*sqlast.ColumnConstraint{
-								{
-									Spec: &sqlast.UniqueColumnSpec{
-										IsPrimaryKey: true,
-										Primary:      sqltoken.NewPos(3, 17),
-										Key:          sqltoken.NewPos(3, 28),
+						FromClause: []sqlast.TableReference{
+							&sqlast.TableFunction{
+								Function: &sqlast.Function{
+									Name: &sqlast.ObjectName{
+										Idents: []*sqlast.Ident{
+											sqlast.NewIdentWithPos(
+												"generate_series",
+												sqltoken.NewPos(1, 15),
+												sqltoken.NewPos(1, 30),
+											),
+										},
 									},
-								},
-								{
-									Spec: &sqlast.NotNullColumnSpec{
-										Not:  sqltoken.NewPos(3, 29),
-										Null: sqltoken.NewPos(3, 37),
+									Args: []sqlast.Node{
+										&sqlast.LongValue{
+											Long: int64(1),
+											From: sqltoken.NewPos(1, 31),
+											To:   sqltoken.NewPos(1, 32),
+										},
+										&sqlast.LongValue{
+											Long: int64(3),
+											From: sqltoken.NewPos(1, 34),
+											To:   sqltoken.NewPos(1, 35),
+										},
 									},
+									ArgsRParen: sqltoken.NewPos(1, 36),
 								},
+								Alias: sqlast.NewIdentWithPos("g", sqltoken.NewPos(1, 40), sqltoken.NewPos(1, 41)),
+								Columns: []*sqlast.Ident{
+									sqlast.NewIdentWithPos("n", sqltoken.NewPos(1, 42), sqltoken.NewPos(1, 43)),
+								},
+								ColumnsRParen: sqltoken.NewPos(1, 44),
 							},
 						},
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "first_name",
-								From:  sqltoken.NewPos(4, 2),
-								To:    sqltoken.NewPos(4, 12),
-							},
-							DataType: &sqlast.VarcharType{
-								Size:      sqlast.NewSize(255),
-								Character: sqltoken.NewPos(4, 13),
-								RParen:    sqltoken.NewPos(4, 25),
-							},
-							Constraints: []*sqlast.ColumnConstraint{
-								{
-									Spec: &sqlast.UniqueColumnSpec{
-										Unique: sqltoken.NewPos(4, 26),
+					},
+				},
+			},
+			{
+				name: "nested case",
+				in:   "SELECT CASE a WHEN 1 THEN CASE WHEN b THEN 'x' END END",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.CaseExpr{
+									Case:    sqltoken.NewPos(1, 8),
+									CaseEnd: sqltoken.NewPos(1, 55),
+									Operand: sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 14)),
+									Conditions: []sqlast.Node{
+										&sqlast.LongValue{
+											Long: int64(1),
+											From: sqltoken.NewPos(1, 20),
+											To:   sqltoken.NewPos(1, 21),
+										},
+									},
+									Results: []sqlast.Node{
+										&sqlast.CaseExpr{
+											Case:    sqltoken.NewPos(1, 27),
+											CaseEnd: sqltoken.NewPos(1, 51),
+											Conditions: []sqlast.Node{
+												sqlast.NewIdentWithPos("b", sqltoken.NewPos(1, 37), sqltoken.NewPos(1, 38)),
+											},
+											Results: []sqlast.Node{
+												&sqlast.SingleQuotedString{
+													From:   sqltoken.NewPos(1, 44),
+													To:     sqltoken.NewPos(1, 47),
+													String: "x",
+												},
+											},
+										},
 									},
 								},
 							},
 						},
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "last_name",
-								From:  sqltoken.NewPos(5, 2),
-								To:    sqltoken.NewPos(5, 11),
-							},
-							DataType: &sqlast.VarcharType{
-								Size:      sqlast.NewSize(255),
-								Character: sqltoken.NewPos(5, 12),
-								Varying:   sqltoken.NewPos(5, 29),
-								RParen:    sqltoken.NewPos(5, 34),
-							},
-							Constraints: []*sqlast.ColumnConstraint{
-								{
-									Spec: &sqlast.NotNullColumnSpec{
-										Not:  sqltoken.NewPos(5, 35),
-										Null: sqltoken.NewPos(5, 43),
+					},
+				},
+			},
+			{
+				name: "named arguments",
+				in:   "SELECT f(a => 1)",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Function{
+									Name: &sqlast.ObjectName{
+										Idents: []*sqlast.Ident{
+											sqlast.NewIdentWithPos("f", sqltoken.NewPos(1, 8), sqltoken.NewPos(1, 9)),
+										},
+									},
+									Args: []sqlast.Node{
+										&sqlast.NamedArg{
+											Name: sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 10), sqltoken.NewPos(1, 11)),
+											Value: &sqlast.LongValue{
+												From: sqltoken.NewPos(1, 15),
+												To:   sqltoken.NewPos(1, 16),
+												Long: 1,
+											},
+										},
 									},
+									ArgsRParen: sqltoken.NewPos(1, 17),
 								},
 							},
 						},
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "created_at",
-								From:  sqltoken.NewPos(6, 2),
-								To:    sqltoken.NewPos(6, 12),
-							},
-							DataType: &sqlast.Timestamp{
-								Timestamp: sqltoken.NewPos(6, 13),
-							},
-							Default: &sqlast.Ident{
-								Value: "CURRENT_TIMESTAMP",
-								From:  sqltoken.NewPos(6, 31),
-								To:    sqltoken.NewPos(6, 48),
-							},
-							Constraints: []*sqlast.ColumnConstraint{
-								{
-									Spec: &sqlast.NotNullColumnSpec{
-										Not:  sqltoken.NewPos(6, 49),
-										Null: sqltoken.NewPos(6, 57),
+					},
+				},
+			},
+			{
+				name: "collate",
+				in:   `SELECT a COLLATE "C"`,
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Collate{
+									Expr: sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 8), sqltoken.NewPos(1, 9)),
+									Collation: &sqlast.ObjectName{
+										Idents: []*sqlast.Ident{
+											{
+												Value:      "C",
+												Quoted:     true,
+												QuoteStyle: '"',
+												From:       sqltoken.NewPos(1, 18),
+												To:         sqltoken.NewPos(1, 21),
+											},
+										},
 									},
 								},
 							},
@@ -1053,457 +1064,445 @@ CREATE TABLE persons (
 				},
 			},
 			{
-				name: "with case",
-				in: `CREATE TABLE persons (
-person_id int PRIMARY KEY NOT NULL,
-last_name character varying(255) NOT NULL,
-test_id int NOT NULL REFERENCES test(id1),
-email character varying(255) UNIQUE NOT NULL,
-age int NOT NULL CHECK(age > 0 AND age < 100),
-created_at timestamp DEFAULT CURRENT_TIMESTAMP NOT NULL
-)`,
-				out: &sqlast.CreateTableStmt{
-					Create: sqltoken.NewPos(1, 1),
-					Name: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							{
-								Value: "persons",
-								From:  sqltoken.NewPos(1, 14),
-								To:    sqltoken.NewPos(1, 21),
+				name: "like escape",
+				in:   `SELECT a LIKE 'x' ESCAPE '!'`,
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.LikeEscape{
+									Like: &sqlast.BinaryExpr{
+										Left: sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 8), sqltoken.NewPos(1, 9)),
+										Op: &sqlast.Operator{
+											Type: sqlast.Like,
+											From: sqltoken.NewPos(1, 10),
+											To:   sqltoken.NewPos(1, 14),
+										},
+										Right: &sqlast.SingleQuotedString{
+											From:   sqltoken.NewPos(1, 15),
+											To:     sqltoken.NewPos(1, 18),
+											String: "x",
+										},
+									},
+									Escape: &sqlast.SingleQuotedString{
+										From:   sqltoken.NewPos(1, 26),
+										To:     sqltoken.NewPos(1, 29),
+										String: "!",
+									},
+								},
 							},
 						},
 					},
-					Elements: []sqlast.TableElement{
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "person_id",
-								From:  sqltoken.NewPos(2, 1),
-								To:    sqltoken.NewPos(2, 10),
-							},
-							DataType: &sqlast.Int{
-								From: sqltoken.NewPos(2, 11),
-								To:   sqltoken.NewPos(2, 14),
-							},
-							Constraints: []*sqlast.ColumnConstraint{
-								{
-									Spec: &sqlast.UniqueColumnSpec{
-										IsPrimaryKey: true,
-										Primary:      sqltoken.NewPos(2, 15),
-										Key:          sqltoken.NewPos(2, 26),
+				},
+			},
+			{
+				name: "regex match",
+				in:   `SELECT a !~* 'x'`,
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.BinaryExpr{
+									Left: sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 8), sqltoken.NewPos(1, 9)),
+									Op: &sqlast.Operator{
+										Type: sqlast.NotRegexIMatch,
+										From: sqltoken.NewPos(1, 10),
+										To:   sqltoken.NewPos(1, 13),
 									},
-								},
-								{
-									Spec: &sqlast.NotNullColumnSpec{
-										Not:  sqltoken.NewPos(2, 27),
-										Null: sqltoken.NewPos(2, 35),
+									Right: &sqlast.SingleQuotedString{
+										From:   sqltoken.NewPos(1, 14),
+										To:     sqltoken.NewPos(1, 17),
+										String: "x",
 									},
 								},
 							},
 						},
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "last_name",
-								From:  sqltoken.NewPos(3, 1),
-								To:    sqltoken.NewPos(3, 10),
-							},
-							DataType: &sqlast.VarcharType{
-								Size:      sqlast.NewSize(255),
-								Character: sqltoken.NewPos(3, 11),
-								Varying:   sqltoken.NewPos(3, 28),
-								RParen:    sqltoken.NewPos(3, 33),
-							},
-							Constraints: []*sqlast.ColumnConstraint{
-								{
-									Spec: &sqlast.NotNullColumnSpec{
-										Not:  sqltoken.NewPos(3, 34),
-										Null: sqltoken.NewPos(3, 42),
+					},
+				},
+			},
+			{
+				name: "row comparison",
+				in:   "SELECT (a, b) = ROW(1, 2)",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.BinaryExpr{
+									Left: &sqlast.SQLRowExpr{
+										Row:      sqltoken.NewPos(1, 8),
+										Implicit: true,
+										Values: []sqlast.Node{
+											sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 9), sqltoken.NewPos(1, 10)),
+											sqlast.NewIdentWithPos("b", sqltoken.NewPos(1, 12), sqltoken.NewPos(1, 13)),
+										},
+										RParen: sqltoken.NewPos(1, 14),
 									},
-								},
-							},
-						},
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "test_id",
-								From:  sqltoken.NewPos(4, 1),
-								To:    sqltoken.NewPos(4, 8),
-							},
-							DataType: &sqlast.Int{
-								From: sqltoken.NewPos(4, 9),
-								To:   sqltoken.NewPos(4, 12),
-							},
-							Constraints: []*sqlast.ColumnConstraint{
-								{
-									Spec: &sqlast.NotNullColumnSpec{
-										Not:  sqltoken.NewPos(4, 13),
-										Null: sqltoken.NewPos(4, 21),
+									Op: &sqlast.Operator{
+										Type: sqlast.Eq,
+										From: sqltoken.NewPos(1, 15),
+										To:   sqltoken.NewPos(1, 16),
 									},
-								},
-								{
-									Spec: &sqlast.ReferencesColumnSpec{
-										References: sqltoken.NewPos(4, 22),
-										RParen:     sqltoken.NewPos(4, 42),
-										TableName: &sqlast.ObjectName{
-											Idents: []*sqlast.Ident{
-												{
-													Value: "test",
-													From:  sqltoken.NewPos(4, 33),
-													To:    sqltoken.NewPos(4, 37),
-												},
+									Right: &sqlast.SQLRowExpr{
+										Row: sqltoken.NewPos(1, 17),
+										Values: []sqlast.Node{
+											&sqlast.LongValue{
+												From: sqltoken.NewPos(1, 21),
+												To:   sqltoken.NewPos(1, 22),
+												Long: 1,
 											},
-										},
-										Columns: []*sqlast.Ident{
-											&sqlast.Ident{
-												Value: "id1",
-												From:  sqltoken.NewPos(4, 38),
-												To:    sqltoken.NewPos(4, 41),
+											&sqlast.LongValue{
+												From: sqltoken.NewPos(1, 24),
+												To:   sqltoken.NewPos(1, 25),
+												Long: 2,
 											},
 										},
+										RParen: sqltoken.NewPos(1, 26),
 									},
 								},
 							},
 						},
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "email",
-								From:  sqltoken.NewPos(5, 1),
-								To:    sqltoken.NewPos(5, 6),
-							},
-							DataType: &sqlast.VarcharType{
-								Size:      sqlast.NewSize(255),
-								Character: sqltoken.NewPos(5, 7),
-								Varying:   sqltoken.NewPos(5, 24),
-								RParen:    sqltoken.NewPos(5, 29),
-							},
-							Constraints: []*sqlast.ColumnConstraint{
-								{
-									Spec: &sqlast.UniqueColumnSpec{
-										Unique: sqltoken.NewPos(5, 30),
+					},
+				},
+			},
+			{
+				name: "aggregate filter",
+				in:   "SELECT count(x) FILTER (WHERE y)",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Function{
+									Name: &sqlast.ObjectName{
+										Idents: []*sqlast.Ident{
+											sqlast.NewIdentWithPos("count", sqltoken.NewPos(1, 8), sqltoken.NewPos(1, 13)),
+										},
 									},
-								},
-								{
-									Spec: &sqlast.NotNullColumnSpec{
-										Not:  sqltoken.NewPos(5, 37),
-										Null: sqltoken.NewPos(5, 45),
+									Args: []sqlast.Node{
+										sqlast.NewIdentWithPos("x", sqltoken.NewPos(1, 14), sqltoken.NewPos(1, 15)),
 									},
+									ArgsRParen:   sqltoken.NewPos(1, 16),
+									Filter:       sqlast.NewIdentWithPos("y", sqltoken.NewPos(1, 31), sqltoken.NewPos(1, 32)),
+									FilterRParen: sqltoken.NewPos(1, 33),
 								},
 							},
 						},
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "age",
-								From:  sqltoken.NewPos(6, 1),
-								To:    sqltoken.NewPos(6, 4),
-							},
-							DataType: &sqlast.Int{
-								From: sqltoken.NewPos(6, 5),
-								To:   sqltoken.NewPos(6, 8),
-							},
-							Constraints: []*sqlast.ColumnConstraint{
-								{
-									Spec: &sqlast.NotNullColumnSpec{
-										Not:  sqltoken.NewPos(6, 9),
-										Null: sqltoken.NewPos(6, 17),
+					},
+				},
+			},
+			{
+				name: "within group",
+				in:   "SELECT mode() WITHIN GROUP (ORDER BY x)",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Function{
+									Name: &sqlast.ObjectName{
+										Idents: []*sqlast.Ident{
+											sqlast.NewIdentWithPos("mode", sqltoken.NewPos(1, 8), sqltoken.NewPos(1, 12)),
+										},
 									},
-								},
-								{
-									Spec: &sqlast.CheckColumnSpec{
-										Check:  sqltoken.NewPos(6, 18),
-										RParen: sqltoken.NewPos(6, 46),
-										Expr: &sqlast.BinaryExpr{
-											Op: &sqlast.Operator{
-												Type: sqlast.And,
-												From: sqltoken.NewPos(6, 32),
-												To:   sqltoken.NewPos(6, 35),
-											},
-											Left: &sqlast.BinaryExpr{
-												Op: &sqlast.Operator{
-													Type: sqlast.Gt,
-													From: sqltoken.NewPos(6, 28),
-													To:   sqltoken.NewPos(6, 29),
-												},
-												Left: &sqlast.Ident{
-													Value: "age",
-													From:  sqltoken.NewPos(6, 24),
-													To:    sqltoken.NewPos(6, 27),
-												},
-												Right: &sqlast.LongValue{
-													From: sqltoken.NewPos(6, 30),
-													To:   sqltoken.NewPos(6, 31),
-													Long: 0,
-												},
-											},
-											Right: &sqlast.BinaryExpr{
-												Op: &sqlast.Operator{
-													Type: sqlast.Lt,
-													From: sqltoken.NewPos(6, 40),
-													To:   sqltoken.NewPos(6, 41),
-												},
-												Left: &sqlast.Ident{
-													Value: "age",
-													From:  sqltoken.NewPos(6, 36),
-													To:    sqltoken.NewPos(6, 39),
-												},
-												Right: &sqlast.LongValue{
-													From: sqltoken.NewPos(6, 42),
-													To:   sqltoken.NewPos(6, 45),
-													Long: 100,
-												},
-											},
+									ArgsRParen: sqltoken.NewPos(1, 14),
+									WithinGroup: []*sqlast.OrderByExpr{
+										{
+											Expr: sqlast.NewIdentWithPos("x", sqltoken.NewPos(1, 38), sqltoken.NewPos(1, 39)),
 										},
 									},
+									WithinGroupRParen: sqltoken.NewPos(1, 40),
 								},
 							},
 						},
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "created_at",
-								From:  sqltoken.NewPos(7, 1),
-								To:    sqltoken.NewPos(7, 11),
-							},
-							DataType: &sqlast.Timestamp{
-								Timestamp: sqltoken.NewPos(7, 12),
-							},
-							Default: &sqlast.Ident{
-								Value: "CURRENT_TIMESTAMP",
-								From:  sqltoken.NewPos(7, 30),
-								To:    sqltoken.NewPos(7, 47),
+					},
+				},
+			},
+			{
+				name: "named window",
+				in:   "SELECT f() OVER w WINDOW w AS (ORDER BY x)",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Function{
+									Name: &sqlast.ObjectName{
+										Idents: []*sqlast.Ident{
+											sqlast.NewIdentWithPos("f", sqltoken.NewPos(1, 8), sqltoken.NewPos(1, 9)),
+										},
+									},
+									ArgsRParen: sqltoken.NewPos(1, 11),
+									OverName:   sqlast.NewIdentWithPos("w", sqltoken.NewPos(1, 17), sqltoken.NewPos(1, 18)),
+								},
 							},
-							Constraints: []*sqlast.ColumnConstraint{
-								{
-									Spec: &sqlast.NotNullColumnSpec{
-										Not:  sqltoken.NewPos(7, 48),
-										Null: sqltoken.NewPos(7, 56),
+						},
+						WindowClause: []*sqlast.NamedWindow{
+							{
+								Name: sqlast.NewIdentWithPos("w", sqltoken.NewPos(1, 26), sqltoken.NewPos(1, 27)),
+								Spec: &sqlast.WindowSpec{
+									OrderBy: []*sqlast.OrderByExpr{
+										{
+											Expr: sqlast.NewIdentWithPos("x", sqltoken.NewPos(1, 41), sqltoken.NewPos(1, 42)),
+										},
 									},
+									Order: sqltoken.NewPos(1, 32),
 								},
+								RParen: sqltoken.NewPos(1, 43),
 							},
 						},
 					},
 				},
 			},
 			{
-				name: "with table constraint",
-				in: `CREATE TABLE persons (
-person_id int,
-CONSTRAINT production UNIQUE(test_column),
-PRIMARY KEY(person_id),
-CHECK(id > 100),
-FOREIGN KEY(test_id) REFERENCES other_table(col1, col2)
-)`,
-				out: &sqlast.CreateTableStmt{
-					Create: sqltoken.NewPos(1, 1),
-					Name: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							{
-								Value: "persons",
-								From:  sqltoken.NewPos(1, 14),
-								To:    sqltoken.NewPos(1, 21),
+				name: "tablesample",
+				in:   "SELECT * FROM t TABLESAMPLE SYSTEM(1) REPEATABLE (2)",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Wildcard{
+									Wildcard: sqltoken.NewPos(1, 8),
+								},
 							},
 						},
-					},
-					Elements: []sqlast.TableElement{
-						&sqlast.ColumnDef{
-							Name: &sqlast.Ident{
-								Value: "person_id",
-								From:  sqltoken.NewPos(2, 1),
-								To:    sqltoken.NewPos(2, 10),
+						FromClause: []sqlast.TableReference{
+							&sqlast.Table{
+								Name: &sqlast.ObjectName{
+									Idents: []*sqlast.Ident{
+										sqlast.NewIdentWithPos("t", sqltoken.NewPos(1, 15), sqltoken.NewPos(1, 16)),
+									},
+								},
+								Sample: &sqlast.TableSample{
+									TableSample: sqltoken.NewPos(1, 17),
+									Method:      sqlast.NewIdentWithPos("SYSTEM", sqltoken.NewPos(1, 29), sqltoken.NewPos(1, 35)),
+									Args: []sqlast.Node{
+										&sqlast.LongValue{
+											From: sqltoken.NewPos(1, 36),
+											To:   sqltoken.NewPos(1, 37),
+											Long: 1,
+										},
+									},
+									RParen: sqltoken.NewPos(1, 38),
+									Repeatable: &sqlast.LongValue{
+										From: sqltoken.NewPos(1, 51),
+										To:   sqltoken.NewPos(1, 52),
+										Long: 2,
+									},
+									RepeatableRParen: sqltoken.NewPos(1, 53),
+								},
 							},
-							DataType: &sqlast.Int{
-								From: sqltoken.NewPos(2, 11),
-								To:   sqltoken.NewPos(2, 14),
+						},
+					},
+				},
+			},
+			{
+				name: "offset before limit",
+				in:   "SELECT * OFFSET 5 LIMIT 10",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Wildcard{
+									Wildcard: sqltoken.NewPos(1, 8),
+								},
 							},
 						},
-						&sqlast.TableConstraint{
-							Constraint: sqltoken.NewPos(3, 1),
-							Name: &sqlast.Ident{
-								Value: "production",
-								From:  sqltoken.NewPos(3, 12),
-								To:    sqltoken.NewPos(3, 22),
-							},
-							Spec: &sqlast.UniqueTableConstraint{
-								Unique: sqltoken.NewPos(3, 23),
-								RParen: sqltoken.NewPos(3, 42),
-								Columns: []*sqlast.Ident{&sqlast.Ident{
-									Value: "test_column",
-									From:  sqltoken.NewPos(3, 30),
-									To:    sqltoken.NewPos(3, 41),
-								}},
-							},
+					},
+					Limit: &sqlast.LimitExpr{
+						Limit: sqltoken.NewPos(1, 10),
+						LimitValue: &sqlast.LongValue{
+							From: sqltoken.NewPos(1, 25),
+							To:   sqltoken.NewPos(1, 27),
+							Long: 10,
 						},
-						&sqlast.TableConstraint{
-							Spec: &sqlast.UniqueTableConstraint{
-								Primary: sqltoken.NewPos(4, 1),
-								RParen:  sqltoken.NewPos(4, 23),
-								Columns: []*sqlast.Ident{&sqlast.Ident{
-									Value: "person_id",
-									From:  sqltoken.NewPos(4, 13),
-									To:    sqltoken.NewPos(4, 22),
-								}},
-								IsPrimary: true,
+						OffsetValue: &sqlast.LongValue{
+							From: sqltoken.NewPos(1, 17),
+							To:   sqltoken.NewPos(1, 18),
+							Long: 5,
+						},
+						To: sqltoken.NewPos(1, 27),
+					},
+				},
+			},
+			{
+				name: "limit and fetch",
+				in:   "SELECT * LIMIT 5 FETCH FIRST 1 ROW ONLY",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Wildcard{
+									Wildcard: sqltoken.NewPos(1, 8),
+								},
 							},
 						},
-						&sqlast.TableConstraint{
-							Spec: &sqlast.CheckTableConstraint{
-								Check:  sqltoken.NewPos(5, 1),
-								RParen: sqltoken.NewPos(5, 16),
-								Expr: &sqlast.BinaryExpr{
-									Left: &sqlast.Ident{
-										Value: "id",
-										From:  sqltoken.NewPos(5, 7),
-										To:    sqltoken.NewPos(5, 9),
-									},
-									Op: &sqlast.Operator{
-										Type: sqlast.Gt,
-										From: sqltoken.NewPos(5, 10),
-										To:   sqltoken.NewPos(5, 11),
-									},
-									Right: &sqlast.LongValue{
-										From: sqltoken.NewPos(5, 12),
-										To:   sqltoken.NewPos(5, 15),
-										Long: 100,
-									},
+					},
+					Limit: &sqlast.LimitExpr{
+						Limit: sqltoken.NewPos(1, 10),
+						LimitValue: &sqlast.LongValue{
+							From: sqltoken.NewPos(1, 16),
+							To:   sqltoken.NewPos(1, 17),
+							Long: 5,
+						},
+						To: sqltoken.NewPos(1, 17),
+					},
+					Fetch: &sqlast.Fetch{
+						Fetch: sqltoken.NewPos(1, 18),
+						Count: &sqlast.LongValue{
+							From: sqltoken.NewPos(1, 30),
+							To:   sqltoken.NewPos(1, 31),
+							Long: 1,
+						},
+						Unit: sqlast.FetchUnitRow,
+						To:   sqltoken.NewPos(1, 40),
+					},
+				},
+			},
+			{
+				name: "placeholders in limit",
+				in:   "SELECT * LIMIT $1 OFFSET ?",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Wildcard{
+									Wildcard: sqltoken.NewPos(1, 8),
 								},
 							},
 						},
-						&sqlast.TableConstraint{
-							Spec: &sqlast.ReferentialTableConstraint{
-								Foreign: sqltoken.NewPos(6, 1),
-								Columns: []*sqlast.Ident{&sqlast.Ident{
-									Value: "test_id",
-									From:  sqltoken.NewPos(6, 13),
-									To:    sqltoken.NewPos(6, 20),
-								}},
-								KeyExpr: &sqlast.ReferenceKeyExpr{
-									TableName: &sqlast.Ident{
-										Value: "other_table",
-										From:  sqltoken.NewPos(6, 33),
-										To:    sqltoken.NewPos(6, 44),
-									},
-									Columns: []*sqlast.Ident{
-										&sqlast.Ident{
-											Value: "col1",
-											From:  sqltoken.NewPos(6, 45),
-											To:    sqltoken.NewPos(6, 49),
-										},
-										&sqlast.Ident{
-											Value: "col2",
-											From:  sqltoken.NewPos(6, 51),
-											To:    sqltoken.NewPos(6, 55),
+					},
+					Limit: &sqlast.LimitExpr{
+						Limit: sqltoken.NewPos(1, 10),
+						LimitValue: &sqlast.Placeholder{
+							Value: "$1",
+							From:  sqltoken.NewPos(1, 16),
+							To:    sqltoken.NewPos(1, 18),
+						},
+						OffsetValue: &sqlast.Placeholder{
+							Value: "?",
+							From:  sqltoken.NewPos(1, 26),
+							To:    sqltoken.NewPos(1, 27),
+						},
+						To: sqltoken.NewPos(1, 27),
+					},
+				},
+			},
+			{
+				name: "parenthesized query",
+				in:   "(SELECT 1) LIMIT ALL OFFSET 2",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.QueryExpr{
+						Query: &sqlast.QueryStmt{
+							Body: &sqlast.SQLSelect{
+								Select: sqltoken.NewPos(1, 2),
+								Projection: []sqlast.SQLSelectItem{
+									&sqlast.UnnamedSelectItem{
+										Node: &sqlast.LongValue{
+											From: sqltoken.NewPos(1, 9),
+											To:   sqltoken.NewPos(1, 10),
+											Long: 1,
 										},
 									},
-									RParen: sqltoken.NewPos(6, 56),
 								},
 							},
 						},
 					},
+					Limit: &sqlast.LimitExpr{
+						Limit:  sqltoken.NewPos(1, 12),
+						All:    true,
+						AllPos: sqltoken.NewPos(1, 21),
+						OffsetValue: &sqlast.LongValue{
+							From: sqltoken.NewPos(1, 29),
+							To:   sqltoken.NewPos(1, 30),
+							Long: 2,
+						},
+						To: sqltoken.NewPos(1, 30),
+					},
 				},
 			},
 			{
-				name: "create view",
-				in:   "CREATE VIEW comedies AS SELECT * FROM films WHERE kind = 'Comedy'",
-				out: &sqlast.CreateViewStmt{
-					Create: sqltoken.NewPos(1, 1),
-					Name: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							{
-								Value: "comedies",
-								From:  sqltoken.NewPos(1, 13),
-								To:    sqltoken.NewPos(1, 21),
+				name: "values in from",
+				in:   "SELECT * FROM (VALUES (1)) AS t(a)",
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Wildcard{
+									Wildcard: sqltoken.NewPos(1, 8),
+								},
 							},
 						},
-					},
-					Query: &sqlast.QueryStmt{
-						Body: &sqlast.SQLSelect{
-							Select: sqltoken.NewPos(1, 25),
-							Projection: []sqlast.SQLSelectItem{
-								&sqlast.UnnamedSelectItem{Node: &sqlast.Wildcard{
-									Wildcard: sqltoken.NewPos(1, 32),
-								}}},
-							FromClause: []sqlast.TableReference{
-								&sqlast.Table{
-									Name: &sqlast.ObjectName{
-										Idents: []*sqlast.Ident{
+						FromClause: []sqlast.TableReference{
+							&sqlast.Derived{
+								LParen: sqltoken.NewPos(1, 15),
+								RParen: sqltoken.NewPos(1, 27),
+								SubQuery: &sqlast.QueryStmt{
+									Body: &sqlast.SQLValues{
+										Values: sqltoken.NewPos(1, 16),
+										Rows: []*sqlast.RowValueExpr{
 											{
-												Value: "films",
-												From:  sqltoken.NewPos(1, 39),
-												To:    sqltoken.NewPos(1, 44),
+												LParen: sqltoken.NewPos(1, 23),
+												RParen: sqltoken.NewPos(1, 26),
+												Values: []sqlast.Node{
+													&sqlast.LongValue{
+														From: sqltoken.NewPos(1, 24),
+														To:   sqltoken.NewPos(1, 25),
+														Long: 1,
+													},
+												},
 											},
 										},
 									},
 								},
-							},
-							WhereClause: &sqlast.BinaryExpr{
-								Op: &sqlast.Operator{
-									Type: sqlast.Eq,
-									From: sqltoken.NewPos(1, 56),
-									To:   sqltoken.NewPos(1, 57),
-								},
-								Left: sqlast.NewIdentWithPos("kind", sqltoken.NewPos(1, 51), sqltoken.NewPos(1, 55)),
-								Right: &sqlast.SingleQuotedString{
-									From:   sqltoken.NewPos(1, 58),
-									To:     sqltoken.NewPos(1, 66),
-									String: "Comedy",
+								Alias: sqlast.NewIdentWithPos("t", sqltoken.NewPos(1, 31), sqltoken.NewPos(1, 32)),
+								Columns: []*sqlast.Ident{
+									sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 33), sqltoken.NewPos(1, 34)),
 								},
+								ColumnsRParen: sqltoken.NewPos(1, 35),
 							},
 						},
 					},
 				},
 			},
-		}
-
-		for _, c := range cases {
-
-			t.Run(c.name, func(t *testing.T) {
-				if c.skip {
-					t.Skip()
-				}
-				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
-				if err != nil {
-					t.Fatal(err)
-				}
-				ast, err := parser.ParseStatement()
-				if err != nil {
-					t.Fatalf("%+v", err)
-				}
-
-				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
-					t.Errorf("diff %s", diff)
-				}
-			})
-		}
-	})
-
-	t.Run("delete", func(t *testing.T) {
-		cases := []struct {
-			name string
-			in   string
-			out  sqlast.Stmt
-			skip bool
-		}{
 			{
-				in:   "DELETE FROM customers WHERE customer_id = 1",
-				name: "simple case",
-				out: &sqlast.DeleteStmt{
-					Delete: sqltoken.NewPos(1, 1),
-					TableName: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							{
-								Value: "customers",
-								From:  sqltoken.NewPos(1, 13),
-								To:    sqltoken.NewPos(1, 22),
+				name: "quoted identifiers",
+				in:   `SELECT "from" FROM "My ""Table"""`,
+				out: &sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(1, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: &sqlast.Ident{
+									Value:      "from",
+									Quoted:     true,
+									QuoteStyle: '"',
+									From:       sqltoken.NewPos(1, 8),
+									To:         sqltoken.NewPos(1, 14),
+								},
 							},
 						},
-					},
-					Selection: &sqlast.BinaryExpr{
-						Op: &sqlast.Operator{
-							Type: sqlast.Eq,
-							From: sqltoken.NewPos(1, 41),
-							To:   sqltoken.NewPos(1, 42),
-						},
-						Left: sqlast.NewIdentWithPos("customer_id", sqltoken.NewPos(1, 29), sqltoken.NewPos(1, 40)),
-						Right: &sqlast.LongValue{
-							From: sqltoken.NewPos(1, 43),
-							To:   sqltoken.NewPos(1, 44),
-							Long: 1,
+						FromClause: []sqlast.TableReference{
+							&sqlast.Table{
+								Name: &sqlast.ObjectName{
+									Idents: []*sqlast.Ident{
+										{
+											Value:      `My "Table"`,
+											Quoted:     true,
+											QuoteStyle: '"',
+											From:       sqltoken.NewPos(1, 20),
+											To:         sqltoken.NewPos(1, 34),
+										},
+									},
+								},
+							},
 						},
 					},
 				},
@@ -1532,7 +1531,7 @@ FOREIGN KEY(test_id) REFERENCES other_table(col1, col2)
 		}
 	})
 
-	t.Run("insert", func(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
 		cases := []struct {
 			name string
 			in   string
@@ -1540,183 +1539,112 @@ FOREIGN KEY(test_id) REFERENCES other_table(col1, col2)
 			skip bool
 		}{
 			{
-				in:   "INSERT INTO customers (customer_name, contract_name) VALUES('Cardinal', 'Tom B. Erichsen')",
-				name: "simple case",
-				out: &sqlast.InsertStmt{
-					Insert: sqltoken.NewPos(1, 1),
-					TableName: &sqlast.ObjectName{
+				name: "create table",
+				in: `
+CREATE TABLE persons (
+ person_id UUID PRIMARY KEY NOT NULL,
+ first_name varchar(255) UNIQUE,
+ last_name character varying(255) NOT NULL,
+ created_at timestamp DEFAULT CURRENT_TIMESTAMP NOT NULL
+)`,
+				out: &sqlast.CreateTableStmt{
+					Create: sqltoken.NewPos(2, 1),
+					Name: &sqlast.ObjectName{
 						Idents: []*sqlast.Ident{
-							sqlast.NewIdentWithPos("customers", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 22)),
-						},
-					},
-					Columns: []*sqlast.Ident{
-						sqlast.NewIdentWithPos("customer_name", sqltoken.NewPos(1, 24), sqltoken.NewPos(1, 37)),
-						sqlast.NewIdentWithPos("contract_name", sqltoken.NewPos(1, 39), sqltoken.NewPos(1, 52)),
-					},
-					Source: &sqlast.ConstructorSource{
-						Rows: []*sqlast.RowValueExpr{
 							{
-								LParen: sqltoken.NewPos(1, 60),
-								RParen: sqltoken.NewPos(1, 91),
-								Values: []sqlast.Node{
-									&sqlast.SingleQuotedString{
-										From:   sqltoken.NewPos(1, 61),
-										To:     sqltoken.NewPos(1, 71),
-										String: "Cardinal",
-									},
-									&sqlast.SingleQuotedString{
-										From:   sqltoken.NewPos(1, 73),
-										To:     sqltoken.NewPos(1, 90),
-										String: "Tom B. Erichsen",
-									},
-								},
+								Value: "persons",
+								From:  sqltoken.NewPos(2, 14),
+								To:    sqltoken.NewPos(2, 21),
 							},
 						},
 					},
-				},
-			},
-			{
-				name: "multi record case",
-				in: `INSERT INTO customers (customer_name, contract_name) VALUES
-('Cardinal', 'Tom B. Erichsen'),
-('Cardinal', 'Tom B. Erichsen')`,
-				out: &sqlast.InsertStmt{
-					Insert: sqltoken.NewPos(1, 1),
-					TableName: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							{
-								Value: "customers",
-								From:  sqltoken.NewPos(1, 13),
-								To:    sqltoken.NewPos(1, 22),
+					Elements: []sqlast.TableElement{
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "person_id",
+								From:  sqltoken.NewPos(3, 2),
+								To:    sqltoken.NewPos(3, 11),
 							},
-						},
-					},
-					Columns: []*sqlast.Ident{
-						sqlast.NewIdentWithPos("customer_name", sqltoken.NewPos(1, 24), sqltoken.NewPos(1, 37)),
-						sqlast.NewIdentWithPos("contract_name", sqltoken.NewPos(1, 39), sqltoken.NewPos(1, 52)),
-					},
-					Source: &sqlast.ConstructorSource{
-						Rows: []*sqlast.RowValueExpr{
-							{
-								LParen: sqltoken.NewPos(2, 1),
-								RParen: sqltoken.NewPos(2, 32),
-								Values: []sqlast.Node{
-									&sqlast.SingleQuotedString{
-										From:   sqltoken.NewPos(2, 2),
-										To:     sqltoken.NewPos(2, 12),
-										String: "Cardinal",
+							DataType: &sqlast.UUID{
+								From: sqltoken.NewPos(3, 12),
+								To:   sqltoken.NewPos(3, 16),
+							},
+							Constraints: []*sqlast.ColumnConstraint{
+								{
+									Spec: &sqlast.UniqueColumnSpec{
+										IsPrimaryKey: true,
+										Primary:      sqltoken.NewPos(3, 17),
+										Key:          sqltoken.NewPos(3, 28),
 									},
-									&sqlast.SingleQuotedString{
-										From:   sqltoken.NewPos(2, 14),
-										To:     sqltoken.NewPos(2, 31),
-										String: "Tom B. Erichsen",
+								},
+								{
+									Spec: &sqlast.NotNullColumnSpec{
+										Not:  sqltoken.NewPos(3, 29),
+										Null: sqltoken.NewPos(3, 37),
 									},
 								},
 							},
-							{
-								LParen: sqltoken.NewPos(3, 1),
-								RParen: sqltoken.NewPos(3, 32),
-								Values: []sqlast.Node{
-									&sqlast.SingleQuotedString{
-										From:   sqltoken.NewPos(3, 2),
-										To:     sqltoken.NewPos(3, 12),
-										String: "Cardinal",
-									},
-									&sqlast.SingleQuotedString{
-										From:   sqltoken.NewPos(3, 14),
-										To:     sqltoken.NewPos(3, 31),
-										String: "Tom B. Erichsen",
+						},
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "first_name",
+								From:  sqltoken.NewPos(4, 2),
+								To:    sqltoken.NewPos(4, 12),
+							},
+							DataType: &sqlast.VarcharType{
+								Size:      sqlast.NewSize(255),
+								Character: sqltoken.NewPos(4, 13),
+								RParen:    sqltoken.NewPos(4, 25),
+							},
+							Constraints: []*sqlast.ColumnConstraint{
+								{
+									Spec: &sqlast.UniqueColumnSpec{
+										Unique: sqltoken.NewPos(4, 26),
 									},
 								},
 							},
 						},
-					},
-				},
-			},
-		}
-
-		for _, c := range cases {
-
-			t.Run(c.name, func(t *testing.T) {
-				if c.skip {
-					t.Skip()
-				}
-				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
-				if err != nil {
-					t.Fatal(err)
-				}
-				ast, err := parser.ParseStatement()
-				if err != nil {
-					t.Fatal(err)
-				}
-
-				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
-					t.Errorf("diff %s", diff)
-				}
-			})
-		}
-	})
-
-	t.Run("alter", func(t *testing.T) {
-		cases := []struct {
-			name string
-			in   string
-			out  sqlast.Stmt
-			skip bool
-		}{
-			{
-				name: "add column",
-				in: `
-ALTER TABLE customers
-ADD COLUMN email character varying(255)`,
-				out: &sqlast.AlterTableStmt{
-					Alter: sqltoken.NewPos(2, 1),
-					TableName: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							sqlast.NewIdentWithPos("customers", sqltoken.NewPos(2, 13), sqltoken.NewPos(2, 22)),
-						},
-					},
-					Action: &sqlast.AddColumnTableAction{
-						Add: sqltoken.NewPos(3, 1),
-						Column: &sqlast.ColumnDef{
-							Name: sqlast.NewIdentWithPos("email", sqltoken.NewPos(3, 12), sqltoken.NewPos(3, 17)),
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "last_name",
+								From:  sqltoken.NewPos(5, 2),
+								To:    sqltoken.NewPos(5, 11),
+							},
 							DataType: &sqlast.VarcharType{
 								Size:      sqlast.NewSize(255),
-								Character: sqltoken.NewPos(3, 18),
-								Varying:   sqltoken.NewPos(3, 35),
-								RParen:    sqltoken.NewPos(3, 40),
+								Character: sqltoken.NewPos(5, 12),
+								Varying:   sqltoken.NewPos(5, 29),
+								RParen:    sqltoken.NewPos(5, 34),
 							},
-						},
-					},
-				},
-			},
-			{
-				name: "add constraint",
-				in: `
-ALTER TABLE products
-ADD FOREIGN KEY(test_id) REFERENCES other_table(col1, col2)`,
-				out: &sqlast.AlterTableStmt{
-					Alter: sqltoken.NewPos(2, 1),
-					TableName: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							sqlast.NewIdentWithPos("products", sqltoken.NewPos(2, 13), sqltoken.NewPos(2, 21)),
-						},
-					},
-					Action: &sqlast.AddConstraintTableAction{
-						Add: sqltoken.NewPos(3, 1),
-						Constraint: &sqlast.TableConstraint{
-							Spec: &sqlast.ReferentialTableConstraint{
-								Foreign: sqltoken.NewPos(3, 5),
-								Columns: []*sqlast.Ident{
-									sqlast.NewIdentWithPos("test_id", sqltoken.NewPos(3, 17), sqltoken.NewPos(3, 24)),
+							Constraints: []*sqlast.ColumnConstraint{
+								{
+									Spec: &sqlast.NotNullColumnSpec{
+										Not:  sqltoken.NewPos(5, 35),
+										Null: sqltoken.NewPos(5, 43),
+									},
 								},
-								KeyExpr: &sqlast.ReferenceKeyExpr{
-									TableName: sqlast.NewIdentWithPos("other_table", sqltoken.NewPos(3, 37), sqltoken.NewPos(3, 48)),
-									Columns: []*sqlast.Ident{
-										sqlast.NewIdentWithPos("col1", sqltoken.NewPos(3, 49), sqltoken.NewPos(3, 53)),
-										sqlast.NewIdentWithPos("col2", sqltoken.NewPos(3, 55), sqltoken.NewPos(3, 59)),
+							},
+						},
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "created_at",
+								From:  sqltoken.NewPos(6, 2),
+								To:    sqltoken.NewPos(6, 12),
+							},
+							DataType: &sqlast.Timestamp{
+								Timestamp: sqltoken.NewPos(6, 13),
+							},
+							Default: &sqlast.Ident{
+								Value: "CURRENT_TIMESTAMP",
+								From:  sqltoken.NewPos(6, 31),
+								To:    sqltoken.NewPos(6, 48),
+							},
+							Constraints: []*sqlast.ColumnConstraint{
+								{
+									Spec: &sqlast.NotNullColumnSpec{
+										Not:  sqltoken.NewPos(6, 49),
+										Null: sqltoken.NewPos(6, 57),
 									},
-									RParen: sqltoken.NewPos(3, 60),
 								},
 							},
 						},
@@ -1724,220 +1652,2973 @@ ADD FOREIGN KEY(test_id) REFERENCES other_table(col1, col2)`,
 				},
 			},
 			{
-				name: "drop constraint",
-				in: `ALTER TABLE products
-DROP CONSTRAINT fk CASCADE`,
-				out: &sqlast.AlterTableStmt{
-					Alter: sqltoken.NewPos(1, 1),
-					TableName: &sqlast.ObjectName{
+				name: "with case",
+				in: `CREATE TABLE persons (
+person_id int PRIMARY KEY NOT NULL,
+last_name character varying(255) NOT NULL,
+test_id int NOT NULL REFERENCES test(id1),
+email character varying(255) UNIQUE NOT NULL,
+age int NOT NULL CHECK(age > 0 AND age < 100),
+created_at timestamp DEFAULT CURRENT_TIMESTAMP NOT NULL
+)`,
+				out: &sqlast.CreateTableStmt{
+					Create: sqltoken.NewPos(1, 1),
+					Name: &sqlast.ObjectName{
 						Idents: []*sqlast.Ident{
-							sqlast.NewIdentWithPos("products", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 21)),
+							{
+								Value: "persons",
+								From:  sqltoken.NewPos(1, 14),
+								To:    sqltoken.NewPos(1, 21),
+							},
 						},
 					},
-					Action: &sqlast.DropConstraintTableAction{
-						Drop:       sqltoken.NewPos(2, 1),
-						Name:       sqlast.NewIdentWithPos("fk", sqltoken.NewPos(2, 17), sqltoken.NewPos(2, 19)),
-						Cascade:    true,
-						CascadePos: sqltoken.NewPos(2, 27),
-					},
-				},
+					Elements: []sqlast.TableElement{
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "person_id",
+								From:  sqltoken.NewPos(2, 1),
+								To:    sqltoken.NewPos(2, 10),
+							},
+							DataType: &sqlast.Int{
+								From: sqltoken.NewPos(2, 11),
+								To:   sqltoken.NewPos(2, 14),
+							},
+							Constraints: []*sqlast.ColumnConstraint{
+								{
+									Spec: &sqlast.UniqueColumnSpec{
+										IsPrimaryKey: true,
+										Primary:      sqltoken.NewPos(2, 15),
+										Key:          sqltoken.NewPos(2, 26),
+									},
+								},
+								{
+									Spec: &sqlast.NotNullColumnSpec{
+										Not:  sqltoken.NewPos(2, 27),
+										Null: sqltoken.NewPos(2, 35),
+									},
+								},
+							},
+						},
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "last_name",
+								From:  sqltoken.NewPos(3, 1),
+								To:    sqltoken.NewPos(3, 10),
+							},
+							DataType: &sqlast.VarcharType{
+								Size:      sqlast.NewSize(255),
+								Character: sqltoken.NewPos(3, 11),
+								Varying:   sqltoken.NewPos(3, 28),
+								RParen:    sqltoken.NewPos(3, 33),
+							},
+							Constraints: []*sqlast.ColumnConstraint{
+								{
+									Spec: &sqlast.NotNullColumnSpec{
+										Not:  sqltoken.NewPos(3, 34),
+										Null: sqltoken.NewPos(3, 42),
+									},
+								},
+							},
+						},
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "test_id",
+								From:  sqltoken.NewPos(4, 1),
+								To:    sqltoken.NewPos(4, 8),
+							},
+							DataType: &sqlast.Int{
+								From: sqltoken.NewPos(4, 9),
+								To:   sqltoken.NewPos(4, 12),
+							},
+							Constraints: []*sqlast.ColumnConstraint{
+								{
+									Spec: &sqlast.NotNullColumnSpec{
+										Not:  sqltoken.NewPos(4, 13),
+										Null: sqltoken.NewPos(4, 21),
+									},
+								},
+								{
+									Spec: &sqlast.ReferencesColumnSpec{
+										References: sqltoken.NewPos(4, 22),
+										RParen:     sqltoken.NewPos(4, 42),
+										TableName: &sqlast.ObjectName{
+											Idents: []*sqlast.Ident{
+												{
+													Value: "test",
+													From:  sqltoken.NewPos(4, 33),
+													To:    sqltoken.NewPos(4, 37),
+												},
+											},
+										},
+										Columns: []*sqlast.Ident{
+											&sqlast.Ident{
+												Value: "id1",
+												From:  sqltoken.NewPos(4, 38),
+												To:    sqltoken.NewPos(4, 41),
+											},
+										},
+									},
+								},
+							},
+						},
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "email",
+								From:  sqltoken.NewPos(5, 1),
+								To:    sqltoken.NewPos(5, 6),
+							},
+							DataType: &sqlast.VarcharType{
+								Size:      sqlast.NewSize(255),
+								Character: sqltoken.NewPos(5, 7),
+								Varying:   sqltoken.NewPos(5, 24),
+								RParen:    sqltoken.NewPos(5, 29),
+							},
+							Constraints: []*sqlast.ColumnConstraint{
+								{
+									Spec: &sqlast.UniqueColumnSpec{
+										Unique: sqltoken.NewPos(5, 30),
+									},
+								},
+								{
+									Spec: &sqlast.NotNullColumnSpec{
+										Not:  sqltoken.NewPos(5, 37),
+										Null: sqltoken.NewPos(5, 45),
+									},
+								},
+							},
+						},
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "age",
+								From:  sqltoken.NewPos(6, 1),
+								To:    sqltoken.NewPos(6, 4),
+							},
+							DataType: &sqlast.Int{
+								From: sqltoken.NewPos(6, 5),
+								To:   sqltoken.NewPos(6, 8),
+							},
+							Constraints: []*sqlast.ColumnConstraint{
+								{
+									Spec: &sqlast.NotNullColumnSpec{
+										Not:  sqltoken.NewPos(6, 9),
+										Null: sqltoken.NewPos(6, 17),
+									},
+								},
+								{
+									Spec: &sqlast.CheckColumnSpec{
+										Check:  sqltoken.NewPos(6, 18),
+										RParen: sqltoken.NewPos(6, 46),
+										Expr: &sqlast.BinaryExpr{
+											Op: &sqlast.Operator{
+												Type: sqlast.And,
+												From: sqltoken.NewPos(6, 32),
+												To:   sqltoken.NewPos(6, 35),
+											},
+											Left: &sqlast.BinaryExpr{
+												Op: &sqlast.Operator{
+													Type: sqlast.Gt,
+													From: sqltoken.NewPos(6, 28),
+													To:   sqltoken.NewPos(6, 29),
+												},
+												Left: &sqlast.Ident{
+													Value: "age",
+													From:  sqltoken.NewPos(6, 24),
+													To:    sqltoken.NewPos(6, 27),
+												},
+												Right: &sqlast.LongValue{
+													From: sqltoken.NewPos(6, 30),
+													To:   sqltoken.NewPos(6, 31),
+													Long: 0,
+												},
+											},
+											Right: &sqlast.BinaryExpr{
+												Op: &sqlast.Operator{
+													Type: sqlast.Lt,
+													From: sqltoken.NewPos(6, 40),
+													To:   sqltoken.NewPos(6, 41),
+												},
+												Left: &sqlast.Ident{
+													Value: "age",
+													From:  sqltoken.NewPos(6, 36),
+													To:    sqltoken.NewPos(6, 39),
+												},
+												Right: &sqlast.LongValue{
+													From: sqltoken.NewPos(6, 42),
+													To:   sqltoken.NewPos(6, 45),
+													Long: 100,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "created_at",
+								From:  sqltoken.NewPos(7, 1),
+								To:    sqltoken.NewPos(7, 11),
+							},
+							DataType: &sqlast.Timestamp{
+								Timestamp: sqltoken.NewPos(7, 12),
+							},
+							Default: &sqlast.Ident{
+								Value: "CURRENT_TIMESTAMP",
+								From:  sqltoken.NewPos(7, 30),
+								To:    sqltoken.NewPos(7, 47),
+							},
+							Constraints: []*sqlast.ColumnConstraint{
+								{
+									Spec: &sqlast.NotNullColumnSpec{
+										Not:  sqltoken.NewPos(7, 48),
+										Null: sqltoken.NewPos(7, 56),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "with table constraint",
+				in: `CREATE TABLE persons (
+person_id int,
+CONSTRAINT production UNIQUE(test_column),
+PRIMARY KEY(person_id),
+CHECK(id > 100),
+FOREIGN KEY(test_id) REFERENCES other_table(col1, col2)
+)`,
+				out: &sqlast.CreateTableStmt{
+					Create: sqltoken.NewPos(1, 1),
+					Name: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							{
+								Value: "persons",
+								From:  sqltoken.NewPos(1, 14),
+								To:    sqltoken.NewPos(1, 21),
+							},
+						},
+					},
+					Elements: []sqlast.TableElement{
+						&sqlast.ColumnDef{
+							Name: &sqlast.Ident{
+								Value: "person_id",
+								From:  sqltoken.NewPos(2, 1),
+								To:    sqltoken.NewPos(2, 10),
+							},
+							DataType: &sqlast.Int{
+								From: sqltoken.NewPos(2, 11),
+								To:   sqltoken.NewPos(2, 14),
+							},
+						},
+						&sqlast.TableConstraint{
+							Constraint: sqltoken.NewPos(3, 1),
+							Name: &sqlast.Ident{
+								Value: "production",
+								From:  sqltoken.NewPos(3, 12),
+								To:    sqltoken.NewPos(3, 22),
+							},
+							Spec: &sqlast.UniqueTableConstraint{
+								Unique: sqltoken.NewPos(3, 23),
+								RParen: sqltoken.NewPos(3, 42),
+								Columns: []*sqlast.Ident{&sqlast.Ident{
+									Value: "test_column",
+									From:  sqltoken.NewPos(3, 30),
+									To:    sqltoken.NewPos(3, 41),
+								}},
+							},
+						},
+						&sqlast.TableConstraint{
+							Spec: &sqlast.UniqueTableConstraint{
+								Primary: sqltoken.NewPos(4, 1),
+								RParen:  sqltoken.NewPos(4, 23),
+								Columns: []*sqlast.Ident{&sqlast.Ident{
+									Value: "person_id",
+									From:  sqltoken.NewPos(4, 13),
+									To:    sqltoken.NewPos(4, 22),
+								}},
+								IsPrimary: true,
+							},
+						},
+						&sqlast.TableConstraint{
+							Spec: &sqlast.CheckTableConstraint{
+								Check:  sqltoken.NewPos(5, 1),
+								RParen: sqltoken.NewPos(5, 16),
+								Expr: &sqlast.BinaryExpr{
+									Left: &sqlast.Ident{
+										Value: "id",
+										From:  sqltoken.NewPos(5, 7),
+										To:    sqltoken.NewPos(5, 9),
+									},
+									Op: &sqlast.Operator{
+										Type: sqlast.Gt,
+										From: sqltoken.NewPos(5, 10),
+										To:   sqltoken.NewPos(5, 11),
+									},
+									Right: &sqlast.LongValue{
+										From: sqltoken.NewPos(5, 12),
+										To:   sqltoken.NewPos(5, 15),
+										Long: 100,
+									},
+								},
+							},
+						},
+						&sqlast.TableConstraint{
+							Spec: &sqlast.ReferentialTableConstraint{
+								Foreign: sqltoken.NewPos(6, 1),
+								Columns: []*sqlast.Ident{&sqlast.Ident{
+									Value: "test_id",
+									From:  sqltoken.NewPos(6, 13),
+									To:    sqltoken.NewPos(6, 20),
+								}},
+								KeyExpr: &sqlast.ReferenceKeyExpr{
+									TableName: &sqlast.Ident{
+										Value: "other_table",
+										From:  sqltoken.NewPos(6, 33),
+										To:    sqltoken.NewPos(6, 44),
+									},
+									Columns: []*sqlast.Ident{
+										&sqlast.Ident{
+											Value: "col1",
+											From:  sqltoken.NewPos(6, 45),
+											To:    sqltoken.NewPos(6, 49),
+										},
+										&sqlast.Ident{
+											Value: "col2",
+											From:  sqltoken.NewPos(6, 51),
+											To:    sqltoken.NewPos(6, 55),
+										},
+									},
+									RParen: sqltoken.NewPos(6, 56),
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "create view",
+				in:   "CREATE VIEW comedies AS SELECT * FROM films WHERE kind = 'Comedy'",
+				out: &sqlast.CreateViewStmt{
+					Create: sqltoken.NewPos(1, 1),
+					Name: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							{
+								Value: "comedies",
+								From:  sqltoken.NewPos(1, 13),
+								To:    sqltoken.NewPos(1, 21),
+							},
+						},
+					},
+					Query: &sqlast.QueryStmt{
+						Body: &sqlast.SQLSelect{
+							Select: sqltoken.NewPos(1, 25),
+							Projection: []sqlast.SQLSelectItem{
+								&sqlast.UnnamedSelectItem{Node: &sqlast.Wildcard{
+									Wildcard: sqltoken.NewPos(1, 32),
+								}}},
+							FromClause: []sqlast.TableReference{
+								&sqlast.Table{
+									Name: &sqlast.ObjectName{
+										Idents: []*sqlast.Ident{
+											{
+												Value: "films",
+												From:  sqltoken.NewPos(1, 39),
+												To:    sqltoken.NewPos(1, 44),
+											},
+										},
+									},
+								},
+							},
+							WhereClause: &sqlast.BinaryExpr{
+								Op: &sqlast.Operator{
+									Type: sqlast.Eq,
+									From: sqltoken.NewPos(1, 56),
+									To:   sqltoken.NewPos(1, 57),
+								},
+								Left: sqlast.NewIdentWithPos("kind", sqltoken.NewPos(1, 51), sqltoken.NewPos(1, 55)),
+								Right: &sqlast.SingleQuotedString{
+									From:   sqltoken.NewPos(1, 58),
+									To:     sqltoken.NewPos(1, 66),
+									String: "Comedy",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "create function",
+				in:   "CREATE OR REPLACE FUNCTION add(a int) RETURNS int LANGUAGE sql AS 'SELECT a'",
+				out: &sqlast.CreateFunctionStmt{
+					Create:    sqltoken.NewPos(1, 1),
+					OrReplace: true,
+					Name: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("add", sqltoken.NewPos(1, 28), sqltoken.NewPos(1, 31)),
+						},
+					},
+					Args: []*sqlast.FunctionArg{
+						{
+							From: sqltoken.NewPos(1, 32),
+							Name: sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 32), sqltoken.NewPos(1, 33)),
+							Type: &sqlast.Int{From: sqltoken.NewPos(1, 34), To: sqltoken.NewPos(1, 37)},
+						},
+					},
+					RParen:   sqltoken.NewPos(1, 38),
+					Returns:  &sqlast.Int{From: sqltoken.NewPos(1, 47), To: sqltoken.NewPos(1, 50)},
+					Language: sqlast.NewIdentWithPos("sql", sqltoken.NewPos(1, 60), sqltoken.NewPos(1, 63)),
+					Body: &sqlast.SingleQuotedString{
+						From:   sqltoken.NewPos(1, 67),
+						To:     sqltoken.NewPos(1, 77),
+						String: "SELECT a",
+					},
+				},
+			},
+			{
+				name: "create sequence",
+				in:   "CREATE SEQUENCE s INCREMENT BY -1 NO CYCLE",
+				out: &sqlast.CreateSequenceStmt{
+					Create: sqltoken.NewPos(1, 1),
+					Name: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("s", sqltoken.NewPos(1, 17), sqltoken.NewPos(1, 18)),
+						},
+					},
+					Options: []sqlast.SequenceOption{
+						&sqlast.SequenceIncrement{
+							Increment: sqltoken.NewPos(1, 19),
+							By:        true,
+							Value: &sqlast.LongValue{
+								From: sqltoken.NewPos(1, 32),
+								To:   sqltoken.NewPos(1, 34),
+								Long: -1,
+							},
+						},
+						&sqlast.SequenceCycle{
+							From: sqltoken.NewPos(1, 35),
+							To:   sqltoken.NewPos(1, 43),
+							No:   true,
+						},
+					},
+				},
+			},
+			{
+				name: "create schema",
+				in:   "CREATE SCHEMA AUTHORIZATION joe",
+				out: &sqlast.CreateSchemaStmt{
+					Create:        sqltoken.NewPos(1, 1),
+					Authorization: sqlast.NewIdentWithPos("joe", sqltoken.NewPos(1, 29), sqltoken.NewPos(1, 32)),
+				},
+			},
+			{
+				name: "create extension",
+				in:   "CREATE EXTENSION IF NOT EXISTS hstore CASCADE",
+				out: &sqlast.CreateExtensionStmt{
+					Create:    sqltoken.NewPos(1, 1),
+					NotExists: true,
+					Name:      sqlast.NewIdentWithPos("hstore", sqltoken.NewPos(1, 32), sqltoken.NewPos(1, 38)),
+					Cascade:   true,
+					CascadeTo: sqltoken.NewPos(1, 46),
+				},
+			},
+		}
+
+		for _, c := range cases {
+
+			t.Run(c.name, func(t *testing.T) {
+				if c.skip {
+					t.Skip()
+				}
+				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ast, err := parser.ParseStatement()
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+
+				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		cases := []struct {
+			name string
+			in   string
+			out  sqlast.Stmt
+		}{
+			{
+				name: "multiple tables",
+				in:   "TRUNCATE TABLE a, b RESTART IDENTITY CASCADE",
+				out: &sqlast.TruncateStmt{
+					Truncate: sqltoken.NewPos(1, 1),
+					Table:    true,
+					TableNames: []*sqlast.ObjectName{
+						{
+							Idents: []*sqlast.Ident{
+								sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 16), sqltoken.NewPos(1, 17)),
+							},
+						},
+						{
+							Idents: []*sqlast.Ident{
+								sqlast.NewIdentWithPos("b", sqltoken.NewPos(1, 19), sqltoken.NewPos(1, 20)),
+							},
+						},
+					},
+					Identity: sqlast.RestartIdentity,
+					Behavior: sqlast.Cascade,
+					To:       sqltoken.NewPos(1, 45),
+				},
+			},
+			{
+				name: "only",
+				in:   "TRUNCATE TABLE ONLY a",
+				out: &sqlast.TruncateStmt{
+					Truncate: sqltoken.NewPos(1, 1),
+					Table:    true,
+					Only:     true,
+					TableNames: []*sqlast.ObjectName{
+						{
+							Idents: []*sqlast.Ident{
+								sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 21), sqltoken.NewPos(1, 22)),
+							},
+						},
+					},
+				},
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ast, err := parser.ParseStatement()
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+
+				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("session", func(t *testing.T) {
+		cases := []struct {
+			name string
+			in   string
+			out  sqlast.Stmt
+		}{
+			{
+				name: "set to list",
+				in:   `SET search_path TO public, "$user"`,
+				out: &sqlast.SetStmt{
+					Set: sqltoken.NewPos(1, 1),
+					Name: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("search_path", sqltoken.NewPos(1, 5), sqltoken.NewPos(1, 16)),
+						},
+					},
+					Values: []sqlast.Node{
+						sqlast.NewIdentWithPos("public", sqltoken.NewPos(1, 20), sqltoken.NewPos(1, 26)),
+						&sqlast.Ident{
+							Value:      "$user",
+							Quoted:     true,
+							QuoteStyle: '"',
+							From:       sqltoken.NewPos(1, 28),
+							To:         sqltoken.NewPos(1, 35),
+						},
+					},
+				},
+			},
+			{
+				name: "set local",
+				in:   "SET LOCAL statement_timeout = 5000",
+				out: &sqlast.SetStmt{
+					Set:   sqltoken.NewPos(1, 1),
+					Scope: "LOCAL",
+					Name: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("statement_timeout", sqltoken.NewPos(1, 11), sqltoken.NewPos(1, 28)),
+						},
+					},
+					Equal: true,
+					Values: []sqlast.Node{
+						&sqlast.LongValue{
+							From: sqltoken.NewPos(1, 31),
+							To:   sqltoken.NewPos(1, 35),
+							Long: 5000,
+						},
+					},
+				},
+			},
+			{
+				name: "set time zone",
+				in:   "SET TIME ZONE 'UTC'",
+				out: &sqlast.SetTimeZoneStmt{
+					Set: sqltoken.NewPos(1, 1),
+					Value: &sqlast.SingleQuotedString{
+						From:   sqltoken.NewPos(1, 15),
+						To:     sqltoken.NewPos(1, 20),
+						String: "UTC",
+					},
+				},
+			},
+			{
+				name: "show all",
+				in:   "SHOW ALL",
+				out: &sqlast.ShowStmt{
+					Show: sqltoken.NewPos(1, 1),
+					To:   sqltoken.NewPos(1, 9),
+				},
+			},
+			{
+				name: "reset",
+				in:   "RESET work_mem",
+				out: &sqlast.ResetStmt{
+					Reset: sqltoken.NewPos(1, 1),
+					Name: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("work_mem", sqltoken.NewPos(1, 7), sqltoken.NewPos(1, 15)),
+						},
+					},
+				},
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ast, err := parser.ParseStatement()
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+
+				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("prepare", func(t *testing.T) {
+		cases := []struct {
+			name string
+			in   string
+			out  sqlast.Stmt
+		}{
+			{
+				name: "prepare",
+				in:   "PREPARE q (int) AS SELECT $1",
+				out: &sqlast.PrepareStmt{
+					Prepare: sqltoken.NewPos(1, 1),
+					Name:    sqlast.NewIdentWithPos("q", sqltoken.NewPos(1, 9), sqltoken.NewPos(1, 10)),
+					DataTypes: []sqlast.Type{
+						&sqlast.Int{
+							From: sqltoken.NewPos(1, 12),
+							To:   sqltoken.NewPos(1, 15),
+						},
+					},
+					Stmt: &sqlast.QueryStmt{
+						Body: &sqlast.SQLSelect{
+							Select: sqltoken.NewPos(1, 20),
+							Projection: []sqlast.SQLSelectItem{
+								&sqlast.UnnamedSelectItem{
+									Node: &sqlast.Placeholder{
+										Value: "$1",
+										From:  sqltoken.NewPos(1, 27),
+										To:    sqltoken.NewPos(1, 29),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "execute",
+				in:   "EXECUTE q(1, ?)",
+				out: &sqlast.ExecuteStmt{
+					Execute: sqltoken.NewPos(1, 1),
+					Name:    sqlast.NewIdentWithPos("q", sqltoken.NewPos(1, 9), sqltoken.NewPos(1, 10)),
+					Args: []sqlast.Node{
+						&sqlast.LongValue{
+							From: sqltoken.NewPos(1, 11),
+							To:   sqltoken.NewPos(1, 12),
+							Long: 1,
+						},
+						&sqlast.Placeholder{
+							Value: "?",
+							From:  sqltoken.NewPos(1, 14),
+							To:    sqltoken.NewPos(1, 15),
+						},
+					},
+					RParen: sqltoken.NewPos(1, 16),
+				},
+			},
+			{
+				name: "execute with named placeholder",
+				in:   "EXECUTE q(:id)",
+				out: &sqlast.ExecuteStmt{
+					Execute: sqltoken.NewPos(1, 1),
+					Name:    sqlast.NewIdentWithPos("q", sqltoken.NewPos(1, 9), sqltoken.NewPos(1, 10)),
+					Args: []sqlast.Node{
+						&sqlast.Placeholder{
+							Value: ":id",
+							From:  sqltoken.NewPos(1, 11),
+							To:    sqltoken.NewPos(1, 14),
+						},
+					},
+					RParen: sqltoken.NewPos(1, 15),
+				},
+			},
+			{
+				name: "deallocate all",
+				in:   "DEALLOCATE ALL",
+				out: &sqlast.DeallocateStmt{
+					Deallocate: sqltoken.NewPos(1, 1),
+					To:         sqltoken.NewPos(1, 15),
+				},
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ast, err := parser.ParseStatement()
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+
+				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		cases := []struct {
+			name string
+			in   string
+			out  sqlast.Stmt
+		}{
+			{
+				name: "declare",
+				in:   "DECLARE c NO SCROLL CURSOR WITH HOLD FOR SELECT a",
+				out: &sqlast.DeclareCursorStmt{
+					Declare: sqltoken.NewPos(1, 1),
+					Name:    sqlast.NewIdentWithPos("c", sqltoken.NewPos(1, 9), sqltoken.NewPos(1, 10)),
+					Scroll:  sqlast.NoScroll,
+					Hold:    sqlast.WithHold,
+					Query: &sqlast.QueryStmt{
+						Body: &sqlast.SQLSelect{
+							Select: sqltoken.NewPos(1, 42),
+							Projection: []sqlast.SQLSelectItem{
+								&sqlast.UnnamedSelectItem{
+									Node: sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 49), sqltoken.NewPos(1, 50)),
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "fetch absolute",
+				in:   "FETCH ABSOLUTE -2 FROM c",
+				out: &sqlast.FetchStmt{
+					Fetch:     sqltoken.NewPos(1, 1),
+					Direction: sqlast.FetchAbsolute,
+					Count: &sqlast.LongValue{
+						From: sqltoken.NewPos(1, 16),
+						To:   sqltoken.NewPos(1, 18),
+						Long: -2,
+					},
+					Name: sqlast.NewIdentWithPos("c", sqltoken.NewPos(1, 24), sqltoken.NewPos(1, 25)),
+				},
+			},
+			{
+				name: "close all",
+				in:   "CLOSE ALL",
+				out: &sqlast.CloseStmt{
+					Close: sqltoken.NewPos(1, 1),
+					To:    sqltoken.NewPos(1, 10),
+				},
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ast, err := parser.ParseStatement()
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+
+				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		cases := []struct {
+			name string
+			in   string
+			out  sqlast.Stmt
+		}{
+			{
+				name: "matched delete",
+				in:   "MERGE INTO t USING s ON a = b WHEN MATCHED THEN DELETE",
+				out: &sqlast.MergeStmt{
+					Merge: sqltoken.NewPos(1, 1),
+					Target: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("t", sqltoken.NewPos(1, 12), sqltoken.NewPos(1, 13)),
+						},
+					},
+					Source: &sqlast.Table{
+						Name: &sqlast.ObjectName{
+							Idents: []*sqlast.Ident{
+								sqlast.NewIdentWithPos("s", sqltoken.NewPos(1, 20), sqltoken.NewPos(1, 21)),
+							},
+						},
+					},
+					On: &sqlast.BinaryExpr{
+						Left: sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 25), sqltoken.NewPos(1, 26)),
+						Op: &sqlast.Operator{
+							Type: sqlast.Eq,
+							From: sqltoken.NewPos(1, 27),
+							To:   sqltoken.NewPos(1, 28),
+						},
+						Right: sqlast.NewIdentWithPos("b", sqltoken.NewPos(1, 29), sqltoken.NewPos(1, 30)),
+					},
+					Clauses: []*sqlast.MergeWhenClause{
+						{
+							When: sqltoken.NewPos(1, 31),
+							Action: &sqlast.MergeDelete{
+								Delete: sqltoken.NewPos(1, 49),
+								To:     sqltoken.NewPos(1, 55),
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "matched do nothing",
+				in:   "MERGE INTO t USING s ON a = b WHEN MATCHED THEN DO NOTHING",
+				out: &sqlast.MergeStmt{
+					Merge: sqltoken.NewPos(1, 1),
+					Target: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("t", sqltoken.NewPos(1, 12), sqltoken.NewPos(1, 13)),
+						},
+					},
+					Source: &sqlast.Table{
+						Name: &sqlast.ObjectName{
+							Idents: []*sqlast.Ident{
+								sqlast.NewIdentWithPos("s", sqltoken.NewPos(1, 20), sqltoken.NewPos(1, 21)),
+							},
+						},
+					},
+					On: &sqlast.BinaryExpr{
+						Left: sqlast.NewIdentWithPos("a", sqltoken.NewPos(1, 25), sqltoken.NewPos(1, 26)),
+						Op: &sqlast.Operator{
+							Type: sqlast.Eq,
+							From: sqltoken.NewPos(1, 27),
+							To:   sqltoken.NewPos(1, 28),
+						},
+						Right: sqlast.NewIdentWithPos("b", sqltoken.NewPos(1, 29), sqltoken.NewPos(1, 30)),
+					},
+					Clauses: []*sqlast.MergeWhenClause{
+						{
+							When: sqltoken.NewPos(1, 31),
+							Action: &sqlast.MergeDoNothing{
+								Do: sqltoken.NewPos(1, 49),
+								To: sqltoken.NewPos(1, 59),
+							},
+						},
+					},
+				},
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ast, err := parser.ParseStatement()
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+
+				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		cases := []struct {
+			name string
+			in   string
+			out  sqlast.Stmt
+			skip bool
+		}{
+			{
+				in:   "DELETE FROM customers WHERE customer_id = 1",
+				name: "simple case",
+				out: &sqlast.DeleteStmt{
+					Delete: sqltoken.NewPos(1, 1),
+					TableName: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							{
+								Value: "customers",
+								From:  sqltoken.NewPos(1, 13),
+								To:    sqltoken.NewPos(1, 22),
+							},
+						},
+					},
+					Selection: &sqlast.BinaryExpr{
+						Op: &sqlast.Operator{
+							Type: sqlast.Eq,
+							From: sqltoken.NewPos(1, 41),
+							To:   sqltoken.NewPos(1, 42),
+						},
+						Left: sqlast.NewIdentWithPos("customer_id", sqltoken.NewPos(1, 29), sqltoken.NewPos(1, 40)),
+						Right: &sqlast.LongValue{
+							From: sqltoken.NewPos(1, 43),
+							To:   sqltoken.NewPos(1, 44),
+							Long: 1,
+						},
+					},
+				},
+			},
+		}
+
+		for _, c := range cases {
+
+			t.Run(c.name, func(t *testing.T) {
+				if c.skip {
+					t.Skip()
+				}
+				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ast, err := parser.ParseStatement()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		cases := []struct {
+			name string
+			in   string
+			out  sqlast.Stmt
+			skip bool
+		}{
+			{
+				in:   "INSERT INTO customers (customer_name, contract_name) VALUES('Cardinal', 'Tom B. Erichsen')",
+				name: "simple case",
+				out: &sqlast.InsertStmt{
+					Insert: sqltoken.NewPos(1, 1),
+					TableName: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("customers", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 22)),
+						},
+					},
+					Columns: []*sqlast.Ident{
+						sqlast.NewIdentWithPos("customer_name", sqltoken.NewPos(1, 24), sqltoken.NewPos(1, 37)),
+						sqlast.NewIdentWithPos("contract_name", sqltoken.NewPos(1, 39), sqltoken.NewPos(1, 52)),
+					},
+					Source: &sqlast.ConstructorSource{
+						Rows: []*sqlast.RowValueExpr{
+							{
+								LParen: sqltoken.NewPos(1, 60),
+								RParen: sqltoken.NewPos(1, 91),
+								Values: []sqlast.Node{
+									&sqlast.SingleQuotedString{
+										From:   sqltoken.NewPos(1, 61),
+										To:     sqltoken.NewPos(1, 71),
+										String: "Cardinal",
+									},
+									&sqlast.SingleQuotedString{
+										From:   sqltoken.NewPos(1, 73),
+										To:     sqltoken.NewPos(1, 90),
+										String: "Tom B. Erichsen",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "multi record case",
+				in: `INSERT INTO customers (customer_name, contract_name) VALUES
+('Cardinal', 'Tom B. Erichsen'),
+('Cardinal', 'Tom B. Erichsen')`,
+				out: &sqlast.InsertStmt{
+					Insert: sqltoken.NewPos(1, 1),
+					TableName: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							{
+								Value: "customers",
+								From:  sqltoken.NewPos(1, 13),
+								To:    sqltoken.NewPos(1, 22),
+							},
+						},
+					},
+					Columns: []*sqlast.Ident{
+						sqlast.NewIdentWithPos("customer_name", sqltoken.NewPos(1, 24), sqltoken.NewPos(1, 37)),
+						sqlast.NewIdentWithPos("contract_name", sqltoken.NewPos(1, 39), sqltoken.NewPos(1, 52)),
+					},
+					Source: &sqlast.ConstructorSource{
+						Rows: []*sqlast.RowValueExpr{
+							{
+								LParen: sqltoken.NewPos(2, 1),
+								RParen: sqltoken.NewPos(2, 32),
+								Values: []sqlast.Node{
+									&sqlast.SingleQuotedString{
+										From:   sqltoken.NewPos(2, 2),
+										To:     sqltoken.NewPos(2, 12),
+										String: "Cardinal",
+									},
+									&sqlast.SingleQuotedString{
+										From:   sqltoken.NewPos(2, 14),
+										To:     sqltoken.NewPos(2, 31),
+										String: "Tom B. Erichsen",
+									},
+								},
+							},
+							{
+								LParen: sqltoken.NewPos(3, 1),
+								RParen: sqltoken.NewPos(3, 32),
+								Values: []sqlast.Node{
+									&sqlast.SingleQuotedString{
+										From:   sqltoken.NewPos(3, 2),
+										To:     sqltoken.NewPos(3, 12),
+										String: "Cardinal",
+									},
+									&sqlast.SingleQuotedString{
+										From:   sqltoken.NewPos(3, 14),
+										To:     sqltoken.NewPos(3, 31),
+										String: "Tom B. Erichsen",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		for _, c := range cases {
+
+			t.Run(c.name, func(t *testing.T) {
+				if c.skip {
+					t.Skip()
+				}
+				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ast, err := parser.ParseStatement()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("alter", func(t *testing.T) {
+		cases := []struct {
+			name string
+			in   string
+			out  sqlast.Stmt
+			skip bool
+		}{
+			{
+				name: "add column",
+				in: `
+ALTER TABLE customers
+ADD COLUMN email character varying(255)`,
+				out: &sqlast.AlterTableStmt{
+					Alter: sqltoken.NewPos(2, 1),
+					TableName: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("customers", sqltoken.NewPos(2, 13), sqltoken.NewPos(2, 22)),
+						},
+					},
+					Action: &sqlast.AddColumnTableAction{
+						Add: sqltoken.NewPos(3, 1),
+						Column: &sqlast.ColumnDef{
+							Name: sqlast.NewIdentWithPos("email", sqltoken.NewPos(3, 12), sqltoken.NewPos(3, 17)),
+							DataType: &sqlast.VarcharType{
+								Size:      sqlast.NewSize(255),
+								Character: sqltoken.NewPos(3, 18),
+								Varying:   sqltoken.NewPos(3, 35),
+								RParen:    sqltoken.NewPos(3, 40),
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "add constraint",
+				in: `
+ALTER TABLE products
+ADD FOREIGN KEY(test_id) REFERENCES other_table(col1, col2)`,
+				out: &sqlast.AlterTableStmt{
+					Alter: sqltoken.NewPos(2, 1),
+					TableName: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("products", sqltoken.NewPos(2, 13), sqltoken.NewPos(2, 21)),
+						},
+					},
+					Action: &sqlast.AddConstraintTableAction{
+						Add: sqltoken.NewPos(3, 1),
+						Constraint: &sqlast.TableConstraint{
+							Spec: &sqlast.ReferentialTableConstraint{
+								Foreign: sqltoken.NewPos(3, 5),
+								Columns: []*sqlast.Ident{
+									sqlast.NewIdentWithPos("test_id", sqltoken.NewPos(3, 17), sqltoken.NewPos(3, 24)),
+								},
+								KeyExpr: &sqlast.ReferenceKeyExpr{
+									TableName: sqlast.NewIdentWithPos("other_table", sqltoken.NewPos(3, 37), sqltoken.NewPos(3, 48)),
+									Columns: []*sqlast.Ident{
+										sqlast.NewIdentWithPos("col1", sqltoken.NewPos(3, 49), sqltoken.NewPos(3, 53)),
+										sqlast.NewIdentWithPos("col2", sqltoken.NewPos(3, 55), sqltoken.NewPos(3, 59)),
+									},
+									RParen: sqltoken.NewPos(3, 60),
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "drop constraint",
+				in: `ALTER TABLE products
+DROP CONSTRAINT fk CASCADE`,
+				out: &sqlast.AlterTableStmt{
+					Alter: sqltoken.NewPos(1, 1),
+					TableName: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("products", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 21)),
+						},
+					},
+					Action: &sqlast.DropConstraintTableAction{
+						Drop:       sqltoken.NewPos(2, 1),
+						Name:       sqlast.NewIdentWithPos("fk", sqltoken.NewPos(2, 17), sqltoken.NewPos(2, 19)),
+						Cascade:    true,
+						CascadePos: sqltoken.NewPos(2, 27),
+					},
+				},
+			},
+			{
+				name: "remove column",
+				in: `ALTER TABLE products
+DROP COLUMN description CASCADE`,
+				out: &sqlast.AlterTableStmt{
+					Alter: sqltoken.NewPos(1, 1),
+					TableName: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("products", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 21)),
+						},
+					},
+					Action: &sqlast.RemoveColumnTableAction{
+						Drop:       sqltoken.NewPos(2, 1),
+						Name:       sqlast.NewIdentWithPos("description", sqltoken.NewPos(2, 13), sqltoken.NewPos(2, 24)),
+						Cascade:    true,
+						CascadePos: sqltoken.NewPos(2, 32),
+					},
+				},
+			},
+			{
+				name: "alter column",
+				in: `ALTER TABLE products
+ALTER COLUMN created_at SET DEFAULT current_timestamp`,
+				out: &sqlast.AlterTableStmt{
+					Alter: sqltoken.NewPos(1, 1),
+					TableName: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("products", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 21)),
+						},
+					},
+					Action: &sqlast.AlterColumnTableAction{
+						Alter:      sqltoken.NewPos(2, 1),
+						ColumnName: sqlast.NewIdentWithPos("created_at", sqltoken.NewPos(2, 14), sqltoken.NewPos(2, 24)),
+						Action: &sqlast.SetDefaultColumnAction{
+							Set:     sqltoken.NewPos(2, 25),
+							Default: sqlast.NewIdentWithPos("current_timestamp", sqltoken.NewPos(2, 37), sqltoken.NewPos(2, 54)),
+						},
+					},
+				},
+			},
+			{
+				name: "pg change type",
+				in: `ALTER TABLE products
+ALTER COLUMN number TYPE numeric(255,10)`,
+				out: &sqlast.AlterTableStmt{
+					Alter: sqltoken.NewPos(1, 1),
+					TableName: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("products", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 21)),
+						},
+					},
+					Action: &sqlast.AlterColumnTableAction{
+						Alter:      sqltoken.NewPos(2, 1),
+						ColumnName: sqlast.NewIdentWithPos("number", sqltoken.NewPos(2, 14), sqltoken.NewPos(2, 20)),
+						Action: &sqlast.PGAlterDataTypeColumnAction{
+							Type: sqltoken.NewPos(2, 21),
+							DataType: &sqlast.Decimal{
+								Scale:     sqlast.NewSize(10),
+								Precision: sqlast.NewSize(255),
+								Numeric:   sqltoken.NewPos(2, 26),
+								RParen:    sqltoken.NewPos(2, 41),
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "alter index",
+				in:   "ALTER INDEX IF EXISTS idx RENAME TO idx2",
+				out: &sqlast.AlterIndexStmt{
+					Alter:    sqltoken.NewPos(1, 1),
+					IfExists: true,
+					Name: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("idx", sqltoken.NewPos(1, 23), sqltoken.NewPos(1, 26)),
+						},
+					},
+					Action: &sqlast.RenameToAction{
+						Rename:  sqltoken.NewPos(1, 27),
+						NewName: sqlast.NewIdentWithPos("idx2", sqltoken.NewPos(1, 37), sqltoken.NewPos(1, 41)),
+					},
+				},
+			},
+			{
+				name: "alter view",
+				in:   "ALTER VIEW v SET SCHEMA archive",
+				out: &sqlast.AlterViewStmt{
+					Alter: sqltoken.NewPos(1, 1),
+					Name: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("v", sqltoken.NewPos(1, 12), sqltoken.NewPos(1, 13)),
+						},
+					},
+					Action: &sqlast.SetSchemaAction{
+						Set:    sqltoken.NewPos(1, 14),
+						Schema: sqlast.NewIdentWithPos("archive", sqltoken.NewPos(1, 25), sqltoken.NewPos(1, 32)),
+					},
+				},
+			},
+			{
+				name: "alter schema",
+				in:   "ALTER SCHEMA sales OWNER TO alice",
+				out: &sqlast.AlterSchemaStmt{
+					Alter: sqltoken.NewPos(1, 1),
+					Name: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							sqlast.NewIdentWithPos("sales", sqltoken.NewPos(1, 14), sqltoken.NewPos(1, 19)),
+						},
+					},
+					Action: &sqlast.OwnerToAction{
+						OwnerPos: sqltoken.NewPos(1, 20),
+						Owner:    sqlast.NewIdentWithPos("alice", sqltoken.NewPos(1, 29), sqltoken.NewPos(1, 34)),
+					},
+				},
+			},
+		}
+
+		for _, c := range cases {
+
+			t.Run(c.name, func(t *testing.T) {
+				if c.skip {
+					t.Skip()
+				}
+				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ast, err := parser.ParseStatement()
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+
+				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		cases := []struct {
+			name string
+			in   string
+			out  sqlast.Stmt
+			skip bool
+		}{
+			{
+				name: "simple case",
+				in:   "UPDATE customers SET contract_name = 'Alfred Schmidt', city = 'Frankfurt' WHERE customer_id = 1",
+				out: &sqlast.UpdateStmt{
+					Update: sqltoken.NewPos(1, 1),
+					TableName: &sqlast.ObjectName{
+						Idents: []*sqlast.Ident{
+							{
+								Value: "customers",
+								From:  sqltoken.NewPos(1, 8),
+								To:    sqltoken.NewPos(1, 17),
+							},
+						},
+					},
+					Assignments: []*sqlast.Assignment{
+						{
+							ID: sqlast.NewIdentWithPos("contract_name", sqltoken.NewPos(1, 22), sqltoken.NewPos(1, 35)),
+							Value: &sqlast.SingleQuotedString{
+								From:   sqltoken.NewPos(1, 38),
+								To:     sqltoken.NewPos(1, 54),
+								String: "Alfred Schmidt",
+							},
+						},
+						{
+							ID:    sqlast.NewIdentWithPos("city", sqltoken.NewPos(1, 56), sqltoken.NewPos(1, 60)),
+							Value: &sqlast.SingleQuotedString{String: "Frankfurt", From: sqltoken.NewPos(1, 63), To: sqltoken.NewPos(1, 74)},
+						},
+					},
+					Selection: &sqlast.BinaryExpr{
+						Op:   &sqlast.Operator{Type: sqlast.Eq, From: sqltoken.NewPos(1, 93), To: sqltoken.NewPos(1, 94)},
+						Left: sqlast.NewIdentWithPos("customer_id", sqltoken.NewPos(1, 81), sqltoken.NewPos(1, 92)),
+						Right: &sqlast.LongValue{
+							From: sqltoken.NewPos(1, 95),
+							To:   sqltoken.NewPos(1, 96),
+							Long: 1,
+						},
+					},
+				},
+			},
+		}
+
+		for _, c := range cases {
+
+			t.Run(c.name, func(t *testing.T) {
+				if c.skip {
+					t.Skip()
+				}
+				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				ast, err := parser.ParseStatement()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			})
+		}
+	})
+}
+
+func TestParser_ParseSQL(t *testing.T) {
+	in := `
+create table account (
+    account_id serial primary key,
+    name varchar(255) not null,
+    email varchar(255) unique not null,
+    age smallint not null,
+    registered_at timestamp with time zone default current_timestamp
+);
+
+create table category (
+    category_id serial primary key,
+    name varchar(255) not null
+);
+
+create table item (
+    item_id serial primary key,
+    price int not null,
+    name varchar(255) not null,
+    category_id int references category(category_id),
+    created_at timestamp with time zone default current_timestamp
+);
+`
+	parser, err := NewParser(bytes.NewBufferString(in), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if len(stmts) != 3 {
+		t.Fatal("must be 3 stmts")
+	}
+}
+
+func TestParser_ParseSQLWithoutTrailingSemicolon(t *testing.T) {
+	parser, err := NewParser(bytes.NewBufferString("SELECT 1; SELECT 2\n"), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	if len(stmts) != 2 {
+		t.Fatal("must be 2 stmts")
+	}
+}
+
+func TestParser_CreateTypeResolution(t *testing.T) {
+	in := `CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');
+CREATE TABLE person (name text, current_mood mood, other unknown_type);`
+	parser, err := NewParser(bytes.NewBufferString(in), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	createType := stmts[0].(*sqlast.CreateTypeStmt)
+	enum, ok := createType.Definition.(*sqlast.EnumTypeDefinition)
+	if !ok || len(enum.Labels) != 3 {
+		t.Fatalf("must be enum with 3 labels but %+v", createType.Definition)
+	}
+
+	table := stmts[1].(*sqlast.CreateTableStmt)
+	mood := table.Elements[1].(*sqlast.ColumnDef).DataType.(*sqlast.Custom)
+	if mood.Definition != createType {
+		t.Errorf("mood must be resolved to CREATE TYPE but %+v", mood.Definition)
+	}
+	unknown := table.Elements[2].(*sqlast.ColumnDef).DataType.(*sqlast.Custom)
+	if unknown.Definition != nil {
+		t.Errorf("unknown_type must not be resolved but %+v", unknown.Definition)
+	}
+}
+
+func TestParser_DistinctOn(t *testing.T) {
+	in := "SELECT DISTINCT ON (customer_id, date_trunc('day', created_at)) customer_id, total FROM orders ORDER BY customer_id, created_at DESC"
+
+	t.Run("postgres", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString(in), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		sel := stmt.(*sqlast.QueryStmt).Body.(*sqlast.SQLSelect)
+		if !sel.Distinct || len(sel.DistinctOn) != 2 {
+			t.Errorf("must have 2 DISTINCT ON expressions but %+v", sel.DistinctOn)
+		}
+		if out := stmt.ToSQLString(); out != in {
+			t.Errorf("must be %s but %s", in, out)
+		}
+	})
+
+	t.Run("generic", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString(in), &dialect.GenericSQLDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if _, err := parser.ParseStatement(); err == nil {
+			t.Error("DISTINCT ON must be rejected outside PostgreSQL")
+		}
+	})
+}
+
+func TestParser_Oracle(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "dual and rownum",
+			in:   "SELECT sysdate FROM dual WHERE ROWNUM <= 10",
+			out:  "SELECT sysdate FROM dual WHERE ROWNUM <= 10",
+		},
+		{
+			name: "outer join operator",
+			in:   "SELECT e.name FROM emp e, dept d WHERE e.dept_id = d.id(+) AND d.flag(+) = 1",
+			out:  "SELECT e.name FROM emp AS e, dept AS d WHERE e.dept_id = d.id(+) AND d.flag(+) = 1",
+		},
+		{
+			name: "minus",
+			in:   "SELECT id FROM a MINUS SELECT id FROM b",
+			out:  "SELECT id FROM a MINUS SELECT id FROM b",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.OracleDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("outer join column", func(t *testing.T) {
+		expr, err := ParseExpr("d.id(+)", &dialect.OracleDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		expect := &sqlast.OuterJoinColumn{
+			Column: &sqlast.CompoundIdent{
+				Idents: []*sqlast.Ident{
+					sqlast.NewIdentWithPos("d", sqltoken.NewPos(1, 1), sqltoken.NewPos(1, 2)),
+					sqlast.NewIdentWithPos("id", sqltoken.NewPos(1, 3), sqltoken.NewPos(1, 5)),
+				},
+			},
+			RParen: sqltoken.NewPos(1, 8),
+		}
+		if diff := CompareWithoutMarker(expect, expr); diff != "" {
+			t.Errorf("diff %s", diff)
+		}
+	})
+
+	t.Run("generic", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("SELECT id FROM a MINUS SELECT id FROM b"), &dialect.GenericSQLDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if out := stmt.ToSQLString(); out != "SELECT id FROM a AS MINUS" {
+			t.Errorf("MINUS must be an alias outside Oracle but %s", out)
+		}
+	})
+}
+
+func TestParser_Hive(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "lateral view",
+			in:   "SELECT id, item FROM orders LATERAL VIEW explode(items) t AS item LATERAL VIEW OUTER posexplode(tags) p AS pos, tag WHERE id > 1",
+			out:  "SELECT id, item FROM orders LATERAL VIEW explode(items) t AS item LATERAL VIEW OUTER posexplode(tags) p AS pos, tag WHERE id > 1",
+		},
+		{
+			name: "distribute by and sort by",
+			in:   "SELECT a, b FROM t DISTRIBUTE BY a SORT BY b DESC",
+			out:  "SELECT a, b FROM t DISTRIBUTE BY a SORT BY b DESC",
+		},
+		{
+			name: "cluster by",
+			in:   "SELECT a FROM t CLUSTER BY a, b",
+			out:  "SELECT a FROM t CLUSTER BY a, b",
+		},
+		{
+			name: "backtick",
+			in:   "SELECT `user id` FROM `db`.`users`",
+			out:  "SELECT `user id` FROM `db`.`users`",
+		},
+		{
+			name: "complex types",
+			in:   "CREATE TABLE t (s STRING, m MAP<STRING, ARRAY<INT>>, r STRUCT<a:INT, b:BINARY>)",
+			out:  "CREATE TABLE t (s string, m map<string, array<int>>, r struct<a:int, b:binary>)",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.HiveDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("cluster by with sort by", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("SELECT a FROM t SORT BY a CLUSTER BY a"), &dialect.HiveDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if _, err := parser.ParseStatement(); err == nil {
+			t.Error("CLUSTER BY must not be used with SORT BY")
+		}
+	})
+}
+
+func TestParser_ClickHouse(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "table engine",
+			in: `CREATE TABLE hits (d Date, user_id UInt64, url String, referer Nullable(String), os LowCardinality(String))
+ENGINE = MergeTree()
+PARTITION BY toYYYYMM(d)
+ORDER BY (user_id, d)
+SAMPLE BY user_id
+SETTINGS index_granularity = 8192`,
+			out: "CREATE TABLE hits (d date, user_id UInt64, url String, referer Nullable(String), os LowCardinality(String)) ENGINE = MergeTree() PARTITION BY toYYYYMM(d) ORDER BY (user_id, d) SAMPLE BY user_id SETTINGS index_granularity = 8192",
+		},
+		{
+			name: "engine without args",
+			in:   "CREATE TABLE t (a Int32) ENGINE = Memory",
+			out:  "CREATE TABLE t (a Int32) ENGINE = Memory",
+		},
+		{
+			name: "final and sample",
+			in:   "SELECT count(*) FROM hits AS h FINAL SAMPLE 1 / 10 OFFSET 1 / 2 WHERE user_id > 1",
+			out:  "SELECT count(*) FROM hits AS h FINAL SAMPLE 1 / 10 OFFSET 1 / 2 WHERE user_id > 1",
+		},
+		{
+			name: "array join",
+			in:   "SELECT s, x FROM t LEFT ARRAY JOIN arr AS x, nested.y ARRAY JOIN tags WHERE x > 1",
+			out:  "SELECT s, x FROM t LEFT ARRAY JOIN arr AS x, nested.y ARRAY JOIN tags WHERE x > 1",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.ClickHouseDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+}
+
+func TestParser_Redshift(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "copy from s3",
+			in:   `COPY sales FROM 's3://bucket/sales/' IAM_ROLE 'arn:aws:iam::123:role/r' FORMAT AS CSV DELIMITER ',' IGNOREHEADER 1 GZIP NULL AS '\N' COMPUPDATE OFF`,
+			out:  `COPY sales FROM 's3://bucket/sales/' IAM_ROLE 'arn:aws:iam::123:role/r' FORMAT AS CSV DELIMITER ',' IGNOREHEADER 1 GZIP NULL AS '\N' COMPUPDATE OFF`,
+		},
+		{
+			name: "copy with credentials",
+			in:   "COPY events FROM 's3://bucket/events' CREDENTIALS 'aws_access_key_id=a;aws_secret_access_key=b' JSON 'auto'",
+			out:  "COPY events FROM 's3://bucket/events' CREDENTIALS 'aws_access_key_id=a;aws_secret_access_key=b' JSON 'auto'",
+		},
+		{
+			name: "copy with parenthesized options",
+			in:   "COPY t FROM STDIN WITH (FORMAT csv)",
+			out:  "COPY t FROM STDIN WITH (FORMAT csv)",
+		},
+		{
+			name: "unload",
+			in:   "UNLOAD ('select * from venue where state = ''NV''') TO 's3://bucket/unload/' IAM_ROLE default FORMAT PARQUET PARALLEL OFF",
+			out:  "UNLOAD ('select * from venue where state = ''NV''') TO 's3://bucket/unload/' IAM_ROLE default FORMAT PARQUET PARALLEL OFF",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.RedshiftDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("unload in postgres", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("UNLOAD ('select 1') TO 's3://bucket/'"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if _, err := parser.ParseStatement(); err == nil {
+			t.Error("UNLOAD must be rejected outside Redshift")
+		}
+	})
+}
+
+func TestParser_MySQLHints(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "index hints",
+			in:   "SELECT a FROM t1 AS x USE INDEX FOR ORDER BY (i1, PRIMARY) IGNORE KEY (i2) JOIN t2 FORCE INDEX FOR JOIN (i3) ON x.a = t2.a",
+			out:  "SELECT a FROM t1 AS x USE INDEX FOR ORDER BY (i1, PRIMARY) IGNORE KEY (i2) JOIN t2 FORCE INDEX FOR JOIN (i3) ON x.a = t2.a",
+		},
+		{
+			name: "empty use index",
+			in:   "SELECT a FROM t USE INDEX () WHERE a = 1",
+			out:  "SELECT a FROM t USE INDEX () WHERE a = 1",
+		},
+		{
+			name: "optimizer hints",
+			in:   "SELECT /*+ BKA(t1) NO_ICP(t2 i)\n INDEX(t1  idx1,idx2) SET_VAR(sort_buffer_size = 16M) */ DISTINCT a FROM t1",
+			out:  "SELECT /*+ BKA(t1) NO_ICP(t2 i) INDEX(t1  idx1, idx2) SET_VAR(sort_buffer_size = 16M) */ DISTINCT a FROM t1",
+		},
+		{
+			name: "insert",
+			in:   "INSERT /*+ MAX_EXECUTION_TIME(1000) */ INTO t VALUES (1)",
+			out:  "INSERT /*+ MAX_EXECUTION_TIME(1000) */ INTO t VALUES (1)",
+		},
+		{
+			name: "update",
+			in:   "UPDATE /*+ NO_MERGE */ t SET a = 1",
+			out:  "UPDATE /*+ NO_MERGE */ t SET a = 1",
+		},
+		{
+			name: "delete",
+			in:   "DELETE /*+ QB_NAME(qb1) */ FROM t WHERE a = 1",
+			out:  "DELETE /*+ QB_NAME(qb1) */ FROM t WHERE a = 1",
+		},
+		{
+			name: "comment which is not hints",
+			in:   "SELECT /*+ use the index. */ a FROM t",
+			out:  "SELECT a FROM t",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.MySQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("structure", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("SELECT /*+ BKA(t1)\n  INDEX(t1 i, j) */ a FROM t IGNORE KEY FOR GROUP BY (i)"), &dialect.MySQLDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		sel := stmt.(*sqlast.QueryStmt).Body.(*sqlast.SQLSelect)
+		expectHints := &sqlast.OptimizerHints{
+			From: sqltoken.NewPos(1, 8),
+			To:   sqltoken.NewPos(2, 20),
+			Hints: []*sqlast.OptimizerHint{
+				{From: sqltoken.NewPos(1, 12), To: sqltoken.NewPos(1, 19), Name: "BKA", Args: []string{"t1"}},
+				{From: sqltoken.NewPos(2, 3), To: sqltoken.NewPos(2, 17), Name: "INDEX", Args: []string{"t1 i", "j"}},
+			},
+		}
+		if diff := cmp.Diff(expectHints, sel.Hints); diff != "" {
+			t.Errorf("diff %s", diff)
+		}
+		expectIndexHints := []*sqlast.IndexHint{
+			{
+				From:    sqltoken.NewPos(2, 30),
+				Action:  sqlast.IgnoreIndex,
+				Key:     true,
+				For:     sqlast.IndexHintForGroupBy,
+				Indexes: []*sqlast.Ident{sqlast.NewIdentWithPos("i", sqltoken.NewPos(2, 55), sqltoken.NewPos(2, 56))},
+				RParen:  sqltoken.NewPos(2, 57),
+			},
+		}
+		if diff := cmp.Diff(expectIndexHints, sel.FromClause[0].(*sqlast.Table).IndexHints); diff != "" {
+			t.Errorf("diff %s", diff)
+		}
+	})
+
+	t.Run("hints in postgres", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("SELECT /*+ SeqScan(t) */ a FROM t"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if out := stmt.ToSQLString(); out != "SELECT a FROM t" {
+			t.Errorf("hints must be comments but %s", out)
+		}
+	})
+}
+
+func TestParser_MySQLStrings(t *testing.T) {
+	in := `SELECT 'a\\b', 'it\'s', 'x\ny', N'\\', 'a\%' FROM t WHERE c = 'q''r'`
+	out := `SELECT 'a\\b', 'it''s', 'x\ny', N'\\', 'a\\%' FROM t WHERE c = 'q''r'`
+	values := []string{`a\b`, "it's", "x\ny", `\`, `a\%`, "q'r"}
+
+	parse := func(t *testing.T, src string) sqlast.Stmt {
+		t.Helper()
+		parser, err := NewParser(bytes.NewBufferString(src), &dialect.MySQLDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return stmt
+	}
+	strings := func(stmt sqlast.Stmt) []string {
+		var s []string
+		sqlast.Inspect(stmt, func(n sqlast.Node) bool {
+			switch n := n.(type) {
+			case *sqlast.SingleQuotedString:
+				s = append(s, n.String)
+			case *sqlast.NationalStringLiteral:
+				s = append(s, n.String)
+			}
+			return true
+		})
+		return s
+	}
+
+	stmt := parse(t, in)
+	if d := cmp.Diff(values, strings(stmt)); d != "" {
+		t.Errorf("diff %s", d)
+	}
+	if act := stmt.ToSQLString(); act != out {
+		t.Errorf("must be %s but %s", out, act)
+	}
+	if d := cmp.Diff(values, strings(parse(t, out))); d != "" {
+		t.Errorf("values must be kept by the round trip: %s", d)
+	}
+}
+
+func TestParser_MySQLDDL(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "integer display width and zerofill",
+			in:   "CREATE TABLE t (a int(11) unsigned zerofill, b bigint(20) UNSIGNED, c smallint ZEROFILL, d tinyint(1), e numeric(10,2) unsigned zerofill)",
+			out:  "CREATE TABLE t (a int(11) unsigned zerofill, b bigint(20) unsigned, c smallint zerofill, d tinyint(1), e numeric(10,2) unsigned zerofill)",
+		},
+		{
+			name: "column attributes",
+			in:   "CREATE TABLE t (a varchar(10) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT 'x', b timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP, c varchar(10) CHARSET latin1)",
+			out:  "CREATE TABLE t (a character varying(10) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin COMMENT 'x' NOT NULL, b timestamp DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NOT NULL, c character varying(10) CHARACTER SET latin1)",
+		},
+		{
+			name: "constraints around attributes",
+			in:   "CREATE TABLE t (id int NOT NULL AUTO_INCREMENT PRIMARY KEY)",
+			out:  "CREATE TABLE t (id int AUTO_INCREMENT NOT NULL PRIMARY KEY)",
+		},
+		{
+			name: "table options",
+			in:   "CREATE TABLE t (a int) ENGINE=InnoDB AUTO_INCREMENT=10 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='tbl'",
+			out:  "CREATE TABLE t (a int) ENGINE = InnoDB AUTO_INCREMENT = 10 DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci COMMENT = 'tbl'",
+		},
+		{
+			name: "table options separated by commas",
+			in:   "CREATE TABLE t (a int) ENGINE InnoDB, DEFAULT CHARACTER SET utf8, DEFAULT COLLATE utf8_bin, AUTO_INCREMENT 5, COMMENT 'x'",
+			out:  "CREATE TABLE t (a int) ENGINE InnoDB DEFAULT CHARSET utf8 DEFAULT COLLATE utf8_bin AUTO_INCREMENT 5 COMMENT 'x'",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.MySQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	for _, in := range []string{
+		"CREATE TABLE t (a int(11))",
+		"CREATE TABLE t (a int) COMMENT 'x'",
+	} {
+		t.Run("postgres "+in, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(in), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if _, err := parser.ParseSQL(); err == nil {
+				t.Error("MySQL syntax must be rejected in PostgreSQL")
+			}
+		})
+	}
+}
+
+func TestParser_IdentityColumns(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "serial types",
+			in:   "CREATE TABLE t (a SERIAL PRIMARY KEY, b bigserial, c smallserial NOT NULL)",
+			out:  "CREATE TABLE t (a serial PRIMARY KEY, b bigserial, c smallserial NOT NULL)",
+		},
+		{
+			name: "always",
+			in:   "CREATE TABLE t (id int GENERATED ALWAYS AS IDENTITY PRIMARY KEY)",
+			out:  "CREATE TABLE t (id int GENERATED ALWAYS AS IDENTITY PRIMARY KEY)",
+		},
+		{
+			name: "by default with options",
+			in:   "CREATE TABLE t (id bigint CONSTRAINT t_id GENERATED BY DEFAULT AS IDENTITY (START WITH 100 INCREMENT 10 MINVALUE 1 NO MAXVALUE CACHE 20 CYCLE))",
+			out:  "CREATE TABLE t (id bigint CONSTRAINT t_id GENERATED BY DEFAULT AS IDENTITY (START WITH 100 INCREMENT 10 MINVALUE 1 NO MAXVALUE CACHE 20 CYCLE))",
+		},
+		{
+			name: "add column",
+			in:   "ALTER TABLE t ADD COLUMN id int GENERATED BY DEFAULT AS IDENTITY",
+			out:  "ALTER TABLE t ADD COLUMN id int GENERATED BY DEFAULT AS IDENTITY",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("structure", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("CREATE TABLE t (id int GENERATED ALWAYS AS IDENTITY (START 5))"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		col := stmt.(*sqlast.CreateTableStmt).Elements[0].(*sqlast.ColumnDef)
+		spec, ok := col.Constraints[0].Spec.(*sqlast.IdentityColumnSpec)
+		if !ok {
+			t.Fatalf("must be IdentityColumnSpec but %T", col.Constraints[0].Spec)
+		}
+		if !spec.Always || len(spec.Options) != 1 {
+			t.Errorf("unexpected spec %+v", spec)
+		}
+		if col.End() != spec.RParen || spec.RParen != (sqltoken.Pos{Line: 1, Col: 62}) {
+			t.Errorf("must end at the RParen but %+v", col.End())
+		}
+	})
+}
+
+func TestParser_GeneratedColumns(t *testing.T) {
+	cases := []struct {
+		name    string
+		dialect dialect.Dialect
+		in      string
+		out     string
+	}{
+		{
+			name:    "postgres stored",
+			dialect: &dialect.PostgresqlDialect{},
+			in:      "CREATE TABLE t (a int, b int GENERATED ALWAYS AS (a * 2) STORED NOT NULL)",
+			out:     "CREATE TABLE t (a int, b int GENERATED ALWAYS AS (a * 2) STORED NOT NULL)",
+		},
+		{
+			name:    "mysql virtual",
+			dialect: &dialect.MySQLDialect{},
+			in:      "CREATE TABLE t (a int, b varchar(20) GENERATED ALWAYS AS (concat(a, 'x')) VIRTUAL, c int GENERATED ALWAYS AS (a + 1))",
+			out:     "CREATE TABLE t (a int, b character varying(20) GENERATED ALWAYS AS (concat(a, 'x')) VIRTUAL, c int GENERATED ALWAYS AS (a + 1))",
+		},
+		{
+			name:    "mysql without GENERATED ALWAYS",
+			dialect: &dialect.MySQLDialect{},
+			in:      "CREATE TABLE t (a int, b int AS (a + 1) STORED UNIQUE)",
+			out:     "CREATE TABLE t (a int, b int AS (a + 1) STORED UNIQUE)",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), c.dialect)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("expression", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("CREATE TABLE t (price int, qty int, total int GENERATED ALWAYS AS (price * qty) STORED)"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		col := stmt.(*sqlast.CreateTableStmt).Elements[2].(*sqlast.ColumnDef)
+		spec, ok := col.Constraints[0].Spec.(*sqlast.GeneratedColumnSpec)
+		if !ok {
+			t.Fatalf("must be GeneratedColumnSpec but %T", col.Constraints[0].Spec)
+		}
+		if spec.Storage != sqlast.GeneratedStored || col.End() != spec.To {
+			t.Errorf("unexpected spec %+v", spec)
+		}
+		var idents []string
+		sqlast.Inspect(spec, func(n sqlast.Node) bool {
+			if i, ok := n.(*sqlast.Ident); ok {
+				idents = append(idents, i.Value)
+			}
+			return true
+		})
+		if diff := cmp.Diff([]string{"price", "qty"}, idents); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	})
+
+	t.Run("postgres AS", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("CREATE TABLE t (a int, b int AS (a + 1))"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if _, err := parser.ParseSQL(); err == nil {
+			t.Error("AS without GENERATED ALWAYS must be rejected in PostgreSQL")
+		}
+	})
+}
+
+func TestParser_Partitioning(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "partition by range",
+			in:   "CREATE TABLE measurement (city_id int NOT NULL, logdate date NOT NULL) PARTITION BY RANGE (logdate)",
+			out:  "CREATE TABLE measurement (city_id int NOT NULL, logdate date NOT NULL) PARTITION BY RANGE (logdate)",
+		},
+		{
+			name: "partition by list",
+			in:   "CREATE TABLE t (a int, b text) partition by list (lower(b))",
+			out:  "CREATE TABLE t (a int, b text) PARTITION BY LIST (lower(b))",
+		},
+		{
+			name: "partition by hash",
+			in:   "CREATE TABLE h (a int, b int) PARTITION BY HASH (a, b)",
+			out:  "CREATE TABLE h (a int, b int) PARTITION BY HASH (a, b)",
+		},
+		{
+			name: "range bound",
+			in:   "CREATE TABLE m_2020 PARTITION OF measurement FOR VALUES FROM ('2020-01-01') TO ('2021-01-01')",
+			out:  "CREATE TABLE m_2020 PARTITION OF measurement FOR VALUES FROM ('2020-01-01') TO ('2021-01-01')",
+		},
+		{
+			name: "range bound with MINVALUE and constraints",
+			in:   "CREATE TABLE m_low PARTITION OF measurement (CONSTRAINT city_positive CHECK(city_id > 0)) FOR VALUES FROM (MINVALUE, MINVALUE) TO (10, MAXVALUE)",
+			out:  "CREATE TABLE m_low PARTITION OF measurement (CONSTRAINT city_positive CHECK(city_id > 0)) FOR VALUES FROM (MINVALUE, MINVALUE) TO (10, MAXVALUE)",
+		},
+		{
+			name: "columns without data type",
+			in:   "CREATE TABLE m1 PARTITION OF m (id PRIMARY KEY, v WITH OPTIONS DEFAULT 0 NOT NULL, CONSTRAINT v_positive CHECK(v > 0)) FOR VALUES IN (1)",
+			out:  "CREATE TABLE m1 PARTITION OF m (id PRIMARY KEY, v WITH OPTIONS DEFAULT 0 NOT NULL, CONSTRAINT v_positive CHECK(v > 0)) FOR VALUES IN (1)",
+		},
+		{
+			name: "list bound with sub partitions",
+			in:   "CREATE TABLE cities_ab PARTITION OF cities FOR VALUES IN ('a', 'b') PARTITION BY RANGE (population)",
+			out:  "CREATE TABLE cities_ab PARTITION OF cities FOR VALUES IN ('a', 'b') PARTITION BY RANGE (population)",
+		},
+		{
+			name: "hash bound",
+			in:   "CREATE TABLE orders_0 PARTITION OF orders FOR VALUES WITH (MODULUS 4, REMAINDER 0)",
+			out:  "CREATE TABLE orders_0 PARTITION OF orders FOR VALUES WITH (MODULUS 4, REMAINDER 0)",
+		},
+		{
+			name: "default",
+			in:   "CREATE TABLE IF NOT EXISTS cities_other PARTITION OF cities DEFAULT",
+			out:  "CREATE TABLE IF NOT EXISTS cities_other PARTITION OF cities DEFAULT",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("structure", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("CREATE TABLE o PARTITION OF orders FOR VALUES WITH (MODULUS 4, REMAINDER 3)"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		create := stmt.(*sqlast.CreateTableStmt)
+		if create.PartitionOf.ToSQLString() != "orders" || len(create.Elements) != 0 {
+			t.Errorf("unexpected statement %+v", create)
+		}
+		bound, ok := create.PartitionBound.(*sqlast.PartitionBoundHash)
+		if !ok {
+			t.Fatalf("must be PartitionBoundHash but %T", create.PartitionBound)
+		}
+		if bound.Modulus.Long != 4 || bound.Remainder.Long != 3 {
+			t.Errorf("unexpected bound %+v", bound)
+		}
+		if create.End() != (sqltoken.Pos{Line: 1, Col: 76}) {
+			t.Errorf("must end at the RParen but %+v", create.End())
+		}
+	})
+}
+
+func TestParser_StorageOptions(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "with and tablespace",
+			in:   "CREATE TABLE t (a int) WITH (fillfactor=70, toast.autovacuum_enabled = false) TABLESPACE fast",
+			out:  "CREATE TABLE t (a int) WITH (fillfactor = 70, toast.autovacuum_enabled = false) TABLESPACE fast",
+		},
+		{
+			name: "temporary table on commit",
+			in:   "CREATE TEMP TABLE t (a int) ON COMMIT DELETE ROWS",
+			out:  "CREATE TEMPORARY TABLE t (a int) ON COMMIT DELETE ROWS",
+		},
+		{
+			name: "on commit drop after partition by",
+			in:   "CREATE TEMPORARY TABLE t (a int) PARTITION BY LIST (a) ON COMMIT DROP",
+			out:  "CREATE TEMPORARY TABLE t (a int) PARTITION BY LIST (a) ON COMMIT DROP",
+		},
+		{
+			name: "option without value",
+			in:   "CREATE TABLE t (a int) WITH (oids)",
+			out:  "CREATE TABLE t (a int) WITH (oids)",
+		},
+		{
+			name: "index",
+			in:   "CREATE UNIQUE INDEX i ON t USING btree (a, b) WITH (fillfactor = 90, deduplicate_items = off) TABLESPACE idx WHERE a > 0",
+			out:  "CREATE UNIQUE INDEX i ON t USING btree (a, b) WITH (fillfactor = 90, deduplicate_items = off) TABLESPACE idx WHERE a > 0",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("structure", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("CREATE TABLE t (a int) WITH (fillfactor = 70)"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		with, ok := stmt.(*sqlast.CreateTableStmt).Options[0].(*sqlast.WithOptions)
+		if !ok {
+			t.Fatalf("must be WithOptions but %T", stmt.(*sqlast.CreateTableStmt).Options[0])
+		}
+		if len(with.Options) != 1 || with.Options[0].Key.ToSQLString() != "fillfactor" || with.Options[0].Value.(*sqlast.LongValue).Long != 70 {
+			t.Errorf("unexpected options %+v", with.Options)
+		}
+		if stmt.End() != (sqltoken.Pos{Line: 1, Col: 46}) {
+			t.Errorf("must end at the RParen but %+v", stmt.End())
+		}
+	})
+}
+
+func TestParser_Maintenance(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{name: "vacuum", in: "VACUUM", out: "VACUUM"},
+		{
+			name: "vacuum with keywords",
+			in:   "vacuum full verbose analyze users, public.orders (id, total)",
+			out:  "VACUUM full verbose analyze users, public.orders (id, total)",
+		},
+		{
+			name: "vacuum with options",
+			in:   "VACUUM (VERBOSE, ANALYZE, INDEX_CLEANUP ON, PARALLEL 4, FULL false) t",
+			out:  "VACUUM (VERBOSE, ANALYZE, INDEX_CLEANUP ON, PARALLEL 4, FULL false) t",
+		},
+		{name: "analyze", in: "ANALYZE VERBOSE t (a, b)", out: "ANALYZE VERBOSE t (a, b)"},
+		{name: "analyse", in: "ANALYSE (SKIP_LOCKED) a, b", out: "ANALYZE (SKIP_LOCKED) a, b"},
+		{name: "reindex index", in: "REINDEX INDEX CONCURRENTLY idx", out: "REINDEX INDEX CONCURRENTLY idx"},
+		{name: "reindex with options", in: "REINDEX (VERBOSE, TABLESPACE fast) TABLE public.t", out: "REINDEX (VERBOSE, TABLESPACE fast) TABLE public.t"},
+		{name: "reindex current database", in: "REINDEX DATABASE", out: "REINDEX DATABASE"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmts, err := parser.ParseSQL()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if len(stmts) != 1 {
+				t.Fatalf("must be 1 statement but %d", len(stmts))
+			}
+			if out := stmts[0].ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("structure", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("VACUUM (PARALLEL 4) t (a); ANALYZE"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmts, err := parser.ParseSQL()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		vacuum := stmts[0].(*sqlast.VacuumStmt)
+		if !vacuum.Parenthesized || vacuum.Options[0].Name.Value != "PARALLEL" || vacuum.Options[0].Value.(*sqlast.LongValue).Long != 4 {
+			t.Errorf("unexpected options %+v", vacuum.Options)
+		}
+		if vacuum.Tables[0].Columns[0].Value != "a" || vacuum.End() != (sqltoken.Pos{Line: 1, Col: 26}) {
+			t.Errorf("unexpected tables %+v", vacuum.Tables)
+		}
+		analyze := stmts[1].(*sqlast.AnalyzeStmt)
+		if len(analyze.Tables) != 0 || analyze.End() != (sqltoken.Pos{Line: 1, Col: 35}) {
+			t.Errorf("unexpected statement %+v", analyze)
+		}
+	})
+}
+
+func TestParser_Call(t *testing.T) {
+	cases := []struct {
+		name    string
+		dialect dialect.Dialect
+		in      string
+		out     string
+	}{
+		{name: "no args", dialect: &dialect.PostgresqlDialect{}, in: "call p()", out: "CALL p()"},
+		{
+			name:    "expression args",
+			dialect: &dialect.PostgresqlDialect{},
+			in:      "CALL public.transfer(1, 2 + 3, amount => 100)",
+			out:     "CALL public.transfer(1, 2 + 3, amount => 100)",
+		},
+		{name: "execute procedure", dialect: &dialect.PostgresqlDialect{}, in: "EXECUTE PROCEDURE audit('x')", out: "EXECUTE PROCEDURE audit('x')"},
+		{name: "prepared statement named procedure", dialect: &dialect.PostgresqlDialect{}, in: "EXECUTE procedure(1)", out: "EXECUTE procedure(1)"},
+		{name: "mysql without parentheses", dialect: &dialect.MySQLDialect{}, in: "CALL p", out: "CALL p()"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), c.dialect)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("structure", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("CALL s.p(a, 1)"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		call := stmt.(*sqlast.CallStmt)
+		if call.ExecuteProcedure || len(call.Name.Idents) != 2 || len(call.Args) != 2 {
+			t.Errorf("unexpected statement %+v", call)
+		}
+		if call.Pos() != (sqltoken.Pos{Line: 1, Col: 1}) || call.End() != (sqltoken.Pos{Line: 1, Col: 15}) {
+			t.Errorf("unexpected position %v-%v", call.Pos(), call.End())
+		}
+	})
+}
+
+func TestParser_MySQLIntrospection(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{name: "show tables", in: "show full tables from shop like 'ord%'", out: "SHOW FULL TABLES FROM shop LIKE 'ord%'"},
+		{name: "show tables where", in: "SHOW TABLES IN shop WHERE Tables_in_shop = 'x'", out: "SHOW TABLES FROM shop WHERE Tables_in_shop = 'x'"},
+		{name: "show create table", in: "SHOW CREATE TABLE shop.orders", out: "SHOW CREATE TABLE shop.orders"},
+		{name: "show columns", in: "SHOW COLUMNS FROM orders LIKE 'id%'", out: "SHOW COLUMNS FROM orders LIKE 'id%'"},
+		{name: "show fields", in: "SHOW FULL FIELDS IN orders FROM shop", out: "SHOW FULL COLUMNS FROM orders FROM shop"},
+		{name: "show variable", in: "SHOW autocommit", out: "SHOW autocommit"},
+		{name: "describe", in: "describe shop.orders id", out: "DESCRIBE shop.orders id"},
+		{name: "desc", in: "DESC orders", out: "DESCRIBE orders"},
+		{name: "use", in: "USE shop", out: "USE shop"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.MySQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if out := stmt.ToSQLString(); out != c.out {
+				t.Errorf("must be %s but %s", c.out, out)
+			}
+		})
+	}
+
+	t.Run("structure", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("SHOW COLUMNS FROM t FROM db"), &dialect.MySQLDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		show := stmt.(*sqlast.ShowColumnsStmt)
+		if show.Table.ToSQLString() != "t" || show.Database.Value != "db" {
+			t.Errorf("unexpected statement %+v", show)
+		}
+		if show.End() != (sqltoken.Pos{Line: 1, Col: 28}) {
+			t.Errorf("unexpected end %v", show.End())
+		}
+	})
+
+	t.Run("other dialects", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("SHOW TABLES"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if _, ok := stmt.(*sqlast.ShowStmt); !ok {
+			t.Errorf("must be ShowStmt but %T", stmt)
+		}
+		parser, err = NewParser(bytes.NewBufferString("USE shop"), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if _, err := parser.ParseStatement(); err == nil {
+			t.Error("USE must be an error")
+		}
+	})
+}
+
+func TestParser_Keywords(t *testing.T) {
+	t.Run("non-reserved keywords as identifiers", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			dialect dialect.Dialect
+			in      string
+		}{
+			{
+				name:    "generic",
+				dialect: &dialect.GenericSQLDialect{},
+				in:      "CREATE TABLE value (level int, year int, key int)",
 			},
 			{
-				name: "remove column",
-				in: `ALTER TABLE products
-DROP COLUMN description CASCADE`,
-				out: &sqlast.AlterTableStmt{
-					Alter: sqltoken.NewPos(1, 1),
-					TableName: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							sqlast.NewIdentWithPos("products", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 21)),
-						},
-					},
-					Action: &sqlast.RemoveColumnTableAction{
-						Drop:       sqltoken.NewPos(2, 1),
-						Name:       sqlast.NewIdentWithPos("description", sqltoken.NewPos(2, 13), sqltoken.NewPos(2, 24)),
-						Cascade:    true,
-						CascadePos: sqltoken.NewPos(2, 32),
-					},
-				},
+				name:    "postgres",
+				dialect: &dialect.PostgresqlDialect{},
+				in:      "INSERT INTO t (value, year, level) VALUES (1, 2, 3)",
 			},
 			{
-				name: "alter column",
-				in: `ALTER TABLE products
-ALTER COLUMN created_at SET DEFAULT current_timestamp`,
-				out: &sqlast.AlterTableStmt{
-					Alter: sqltoken.NewPos(1, 1),
-					TableName: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							sqlast.NewIdentWithPos("products", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 21)),
-						},
-					},
-					Action: &sqlast.AlterColumnTableAction{
-						Alter:      sqltoken.NewPos(2, 1),
-						ColumnName: sqlast.NewIdentWithPos("created_at", sqltoken.NewPos(2, 14), sqltoken.NewPos(2, 24)),
-						Action: &sqlast.SetDefaultColumnAction{
-							Set:     sqltoken.NewPos(2, 25),
-							Default: sqlast.NewIdentWithPos("current_timestamp", sqltoken.NewPos(2, 37), sqltoken.NewPos(2, 54)),
-						},
-					},
-				},
+				name:    "mysql",
+				dialect: &dialect.MySQLDialect{},
+				in:      "CREATE TABLE t (level int, desc int)",
 			},
 			{
-				name: "pg change type",
-				in: `ALTER TABLE products
-ALTER COLUMN number TYPE numeric(255,10)`,
-				out: &sqlast.AlterTableStmt{
-					Alter: sqltoken.NewPos(1, 1),
-					TableName: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							sqlast.NewIdentWithPos("products", sqltoken.NewPos(1, 13), sqltoken.NewPos(1, 21)),
-						},
-					},
-					Action: &sqlast.AlterColumnTableAction{
-						Alter:      sqltoken.NewPos(2, 1),
-						ColumnName: sqlast.NewIdentWithPos("number", sqltoken.NewPos(2, 14), sqltoken.NewPos(2, 20)),
-						Action: &sqlast.PGAlterDataTypeColumnAction{
-							Type: sqltoken.NewPos(2, 21),
-							DataType: &sqlast.Decimal{
-								Scale:     sqlast.NewSize(10),
-								Precision: sqlast.NewSize(255),
-								Numeric:   sqltoken.NewPos(2, 26),
-								RParen:    sqltoken.NewPos(2, 41),
-							},
-						},
-					},
-				},
+				name:    "quoted reserved keyword",
+				dialect: &dialect.GenericSQLDialect{},
+				in:      `CREATE TABLE "select" ("from" int)`,
 			},
 		}
 
 		for _, c := range cases {
-
 			t.Run(c.name, func(t *testing.T) {
-				if c.skip {
-					t.Skip()
-				}
-				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+				parser, err := NewParser(bytes.NewBufferString(c.in), c.dialect)
 				if err != nil {
-					t.Fatal(err)
+					t.Fatalf("%+v", err)
 				}
-				ast, err := parser.ParseStatement()
+				stmt, err := parser.ParseStatement()
 				if err != nil {
 					t.Fatalf("%+v", err)
 				}
-
-				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
-					t.Errorf("diff %s", diff)
+				if out := stmt.ToSQLString(); out != c.in {
+					t.Errorf("must be %s but %s", c.in, out)
 				}
 			})
 		}
 	})
 
-	t.Run("update", func(t *testing.T) {
+	t.Run("reserved keywords", func(t *testing.T) {
 		cases := []struct {
-			name string
-			in   string
-			out  sqlast.Stmt
-			skip bool
+			name    string
+			dialect dialect.Dialect
+			in      string
 		}{
 			{
-				name: "simple case",
-				in:   "UPDATE customers SET contract_name = 'Alfred Schmidt', city = 'Frankfurt' WHERE customer_id = 1",
-				out: &sqlast.UpdateStmt{
-					Update: sqltoken.NewPos(1, 1),
-					TableName: &sqlast.ObjectName{
-						Idents: []*sqlast.Ident{
-							{
-								Value: "customers",
-								From:  sqltoken.NewPos(1, 8),
-								To:    sqltoken.NewPos(1, 17),
-							},
-						},
-					},
-					Assignments: []*sqlast.Assignment{
-						{
-							ID: sqlast.NewIdentWithPos("contract_name", sqltoken.NewPos(1, 22), sqltoken.NewPos(1, 35)),
-							Value: &sqlast.SingleQuotedString{
-								From:   sqltoken.NewPos(1, 38),
-								To:     sqltoken.NewPos(1, 54),
-								String: "Alfred Schmidt",
-							},
-						},
-						{
-							ID:    sqlast.NewIdentWithPos("city", sqltoken.NewPos(1, 56), sqltoken.NewPos(1, 60)),
-							Value: &sqlast.SingleQuotedString{String: "Frankfurt", From: sqltoken.NewPos(1, 63), To: sqltoken.NewPos(1, 74)},
-						},
-					},
-					Selection: &sqlast.BinaryExpr{
-						Op:   &sqlast.Operator{Type: sqlast.Eq, From: sqltoken.NewPos(1, 93), To: sqltoken.NewPos(1, 94)},
-						Left: sqlast.NewIdentWithPos("customer_id", sqltoken.NewPos(1, 81), sqltoken.NewPos(1, 92)),
-						Right: &sqlast.LongValue{
-							From: sqltoken.NewPos(1, 95),
-							To:   sqltoken.NewPos(1, 96),
-							Long: 1,
-						},
-					},
-				},
+				name:    "table name",
+				dialect: &dialect.GenericSQLDialect{},
+				in:      "CREATE TABLE select (a int)",
+			},
+			{
+				name:    "column list",
+				dialect: &dialect.GenericSQLDialect{},
+				in:      "INSERT INTO t (a, from) VALUES (1, 2)",
+			},
+			{
+				name:    "postgres",
+				dialect: &dialect.PostgresqlDialect{},
+				in:      "CREATE TABLE t (desc int)",
+			},
+			{
+				name:    "mysql",
+				dialect: &dialect.MySQLDialect{},
+				in:      "CREATE TABLE t (key int)",
+			},
+			{
+				name:    "oracle",
+				dialect: &dialect.OracleDialect{},
+				in:      "CREATE TABLE t (level int)",
 			},
 		}
 
 		for _, c := range cases {
-
 			t.Run(c.name, func(t *testing.T) {
-				if c.skip {
-					t.Skip()
-				}
-				parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
-				if err != nil {
-					t.Fatal(err)
-				}
-				ast, err := parser.ParseStatement()
+				parser, err := NewParser(bytes.NewBufferString(c.in), c.dialect)
 				if err != nil {
-					t.Fatal(err)
+					t.Fatalf("%+v", err)
 				}
-
-				if diff := CompareWithoutMarker(c.out, ast); diff != "" {
-					t.Errorf("diff %s", diff)
+				if _, err := parser.ParseStatement(); err == nil {
+					t.Errorf("%s must be rejected", c.in)
 				}
 			})
 		}
 	})
 }
 
-func TestParser_ParseSQL(t *testing.T) {
-	in := `
-create table account (
-    account_id serial primary key,
-    name varchar(255) not null,
-    email varchar(255) unique not null,
-    age smallint not null,
-    registered_at timestamp with time zone default current_timestamp
-);
+func TestParseExpr(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+		err  bool
+	}{
+		{
+			name: "check constraint",
+			in:   "price > 0 AND discount <= price",
+			out:  "price > 0 AND discount <= price",
+		},
+		{
+			name: "surrounding whitespace and comment",
+			in:   "  lower(name) LIKE 'a%' -- filter\n",
+			out:  "lower(name) LIKE 'a%'",
+		},
+		{
+			name: "float literals",
+			in:   "1e10 + 1e20 + 1.5e-3 + 1e-7 + .5 + 100.00",
+			out:  "10000000000.0 + 100000000000000000000.0 + 0.0015 + 1e-07 + 0.5 + 100.0",
+		},
+		{
+			name: "interval second precision",
+			in:   "INTERVAL '1.5' SECOND(2, 3) + INTERVAL '1' SECOND(2) + INTERVAL '1' DAY TO SECOND(3)",
+			out:  "INTERVAL '1.5' SECOND(2, 3) + INTERVAL '1' SECOND(2) + INTERVAL '1' DAY TO SECOND(3)",
+		},
+		{
+			name: "integer beyond int64",
+			in:   "99999999999999999999 + -99999999999999999999",
+			out:  "99999999999999999999 + - 99999999999999999999",
+		},
+		{
+			name: "number with two dots",
+			in:   "1.2.3",
+			err:  true,
+		},
+		{
+			name: "trailing tokens",
+			in:   "a = 1 b",
+			err:  true,
+		},
+		{
+			name: "statement",
+			in:   "a = 1; SELECT 1",
+			err:  true,
+		},
+		{
+			name: "empty",
+			in:   "",
+			err:  true,
+		},
+	}
 
-create table category (
-    category_id serial primary key,
-    name varchar(255) not null
-);
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExpr(c.in, &dialect.GenericSQLDialect{})
+			if c.err {
+				if err == nil {
+					t.Errorf("must be error but %s", expr.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := expr.ToSQLString(); act != c.out {
+				t.Errorf("must be %s but %s", c.out, act)
+			}
+		})
+	}
+}
 
-create table item (
-    item_id serial primary key,
-    price int not null,
-    name varchar(255) not null,
-    category_id int references category(category_id),
-    created_at timestamp with time zone default current_timestamp
-);
-`
-	parser, err := NewParser(bytes.NewBufferString(in), &dialect.GenericSQLDialect{})
+func TestParseDataType(t *testing.T) {
+	cases := []struct {
+		in  string
+		out string
+		err bool
+	}{
+		{in: "numeric(10,2)", out: "numeric(10,2)"},
+		{in: "varchar(255)[]", out: "character varying(255)[]"},
+		{in: "int[]", out: "int[]"},
+		{in: "numeric", out: "numeric"},
+		{in: "numeric[]", out: "numeric[]"},
+		{in: "numeric(10,2)[][]", out: "numeric(10,2)[][]"},
+		{in: "text[3][4]", out: "text[3][4]"},
+		{in: "timestamp with time zone[]", out: "timestamp with time zone[]"},
+		{in: "int ARRAY", out: "int[]"},
+		{in: "int ARRAY[4]", out: "int[4]"},
+		{in: "int[", err: true},
+		{in: "int[a]", err: true},
+		{in: "int ARRAY[4][]", err: true},
+		{in: "timestamp with time zone", out: "timestamp with time zone"},
+		{in: "STRING", out: "string"},
+		{in: "binary", out: "binary"},
+		{in: "MAP<STRING,ARRAY<INT>>", out: "map<string, array<int>>"},
+		{in: "struct<a:int, b:array<struct<c:string>>>", out: "struct<a:int, b:array<struct<c:string>>>"},
+		{in: "array<int", err: true},
+		{in: "int x", err: true},
+		{in: "", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			tp, err := ParseDataType(c.in, &dialect.PostgresqlDialect{})
+			if c.err {
+				if err == nil {
+					t.Errorf("must be error but %s", tp.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := tp.ToSQLString(); act != c.out {
+				t.Errorf("must be %s but %s", c.out, act)
+			}
+		})
+	}
+}
+
+func TestParseObjectName(t *testing.T) {
+	cases := []struct {
+		in     string
+		idents []string
+		err    bool
+	}{
+		{in: "users", idents: []string{"users"}},
+		{in: `public."Users"`, idents: []string{"public", "Users"}},
+		{in: " db . s . t ", idents: []string{"db", "s", "t"}},
+		{in: "users u", err: true},
+		{in: "a.", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			name, err := ParseObjectName(c.in, &dialect.GenericSQLDialect{})
+			if c.err {
+				if err == nil {
+					t.Errorf("must be error but %s", name.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			var idents []string
+			for _, i := range name.Idents {
+				idents = append(idents, i.Value)
+			}
+			if d := cmp.Diff(c.idents, idents); d != "" {
+				t.Errorf("diff %s", d)
+			}
+		})
+	}
+}
+
+func TestParser_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+	}{
+		{name: "missing keyword", in: "CREATE VIEW v SELECT 1"},
+		{name: "missing token", in: "SELECT count(a FROM t"},
+		{name: "unexpected EOF", in: "SELECT CAST(a AS"},
+		{name: "unknown object after CREATE", in: "CREATE FOO x"},
+		{name: "alias after AS", in: "SELECT a AS"},
+		{name: "window frame bound", in: "SELECT sum(a) OVER (ROWS 1) FROM t"},
+		{name: "references without table", in: "CREATE TABLE t (a int, FOREIGN KEY (a) REFERENCES 0 (a))"},
+		{name: "engine name", in: "CREATE TABLE t (a int) ENGINE = SELECT"},
+		{name: "identity without ALWAYS", in: "CREATE TABLE t (id int GENERATED AS IDENTITY)"},
+		{name: "empty identity options", in: "CREATE TABLE t (id int GENERATED ALWAYS AS IDENTITY ())"},
+		{name: "generated by default expression", in: "CREATE TABLE t (a int GENERATED BY DEFAULT AS (1))"},
+		{name: "partition strategy", in: "CREATE TABLE t (a int) PARTITION BY KEY (a)"},
+		{name: "partition without bound", in: "CREATE TABLE t PARTITION OF m"},
+		{name: "hash bound without remainder", in: "CREATE TABLE t PARTITION OF m FOR VALUES WITH (MODULUS 4)"},
+		{name: "on commit action", in: "CREATE TEMP TABLE t (a int) ON COMMIT KEEP ROWS"},
+		{name: "empty with options", in: "CREATE TABLE t (a int) WITH ()"},
+		{name: "empty vacuum options", in: "VACUUM () t"},
+		{name: "unclosed call args", in: "CALL p(1"},
+		{name: "show columns without from", in: "SHOW COLUMNS orders"},
+		{name: "show tables like identifier", in: "SHOW TABLES LIKE orders"},
+		{name: "use without database", in: "USE"},
+		{name: "call without name", in: "CALL (1)"},
+		{name: "reindex table without name", in: "REINDEX TABLE"},
+		{name: "reindex kind", in: "REINDEX VIEW v"},
+		{name: "empty force index", in: "SELECT a FROM t FORCE INDEX ()"},
+		{name: "index hint scope", in: "SELECT a FROM t USE INDEX FOR WHERE (i)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.MySQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if stmts, err := parser.ParseSQL(); err == nil {
+				t.Errorf("must be error but %v", stmts)
+			}
+		})
+	}
+}
+
+func TestParser_Limits(t *testing.T) {
+	nested := func(n int) string {
+		return "SELECT " + strings.Repeat("(", n) + "1" + strings.Repeat(")", n)
+	}
+
+	cases := []struct {
+		name string
+		in   string
+		opts []ParserOption
+		err  string
+	}{
+		{name: "depth", in: nested(9), opts: []ParserOption{MaxDepth(10)}, err: "nesting deeper than 10 at 1:16"},
+		{name: "depth within limit", in: nested(8), opts: []ParserOption{MaxDepth(10)}},
+		{name: "depth of subqueries", in: "SELECT * FROM (SELECT * FROM (SELECT 1) a) b", opts: []ParserOption{MaxDepth(4)}, err: "nesting deeper than 4"},
+		{name: "depth of types", in: "CREATE TABLE t (a array<array<int>>)", opts: []ParserOption{MaxDepth(2)}, err: "nesting deeper than 2"},
+		{name: "no depth limit", in: nested(1000)},
+		{name: "tokens", in: "SELECT a FROM t", opts: []ParserOption{MaxTokens(6)}, err: "more than 6 tokens at 1:15"},
+		{name: "tokens within limit", in: "SELECT a FROM t", opts: []ParserOption{MaxTokens(7)}},
+		{name: "statements", in: "SELECT 1; SELECT 2;\nSELECT 3", opts: []ParserOption{MaxStatements(2)}, err: "more than 2 statements at 2:1"},
+		{name: "statements within limit", in: "SELECT 1; SELECT 2;", opts: []ParserOption{MaxStatements(2)}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.HiveDialect{}, c.opts...)
+			if err == nil {
+				_, err = parser.ParseSQL()
+			}
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("must be error")
+			}
+			if !errors.Is(err, ErrLimitExceeded) {
+				t.Errorf("must be ErrLimitExceeded but %v", err)
+			}
+			if !strings.Contains(err.Error(), c.err) {
+				t.Errorf("must contain %q but %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestParser_PoolTokens(t *testing.T) {
+	src := "SELECT a, b FROM t WHERE c = 'x'; -- comment\nDELETE FROM t WHERE id IN (1, 2, 3)"
+	for i := 0; i < 3; i++ {
+		parser, err := NewParser(bytes.NewBufferString(src), &dialect.GenericSQLDialect{}, PoolTokens(), ParseComment())
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		file, err := parser.ParseFile()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		parser.Release()
+
+		var sqls []string
+		for _, stmt := range file.Stmts {
+			sqls = append(sqls, stmt.ToSQLString())
+		}
+		if diff := cmp.Diff([]string{
+			"SELECT a, b FROM t WHERE c = 'x'",
+			"DELETE FROM t WHERE id IN (1, 2, 3)",
+		}, sqls); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+		if len(file.Comments) != 1 || file.Comments[0].List[0].Text != " comment" {
+			t.Errorf("unexpected comments %+v", file.Comments)
+		}
+	}
+}
+
+func TestParser_Parallel(t *testing.T) {
+	type result struct {
+		SQLs     []string
+		Comments []string
+		Err      string
+		Pos      sqltoken.Pos
+	}
+	parse := func(t *testing.T, src string, opts ...ParserOption) result {
+		t.Helper()
+		parser, err := NewParser(bytes.NewBufferString(src), &dialect.PostgresqlDialect{}, opts...)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		var r result
+		file, err := parser.ParseFile()
+		if err != nil {
+			r.Err = err.Error()
+			r.Pos = parser.Pos()
+			return r
+		}
+		for _, stmt := range file.Stmts {
+			r.SQLs = append(r.SQLs, stmt.ToSQLString())
+		}
+		for _, c := range file.Comments {
+			r.Comments = append(r.Comments, c.List[0].Text)
+		}
+		return r
+	}
+
+	files, err := filepath.Glob("e2e/testdata/*/*.sql")
 	if err != nil {
-		t.Fatalf("%+v", err)
+		t.Fatal(err)
+	}
+	srcs := map[string]string{
+		"empty statement":     "SELECT 1;;SELECT 2",
+		"missing semicolon":   "SELECT 1; SELECT 2 SELECT 3; SELECT 4",
+		"copy without data":   "COPY t TO 'f' SELECT 1",
+		"semicolon in parens": "SELECT (1; SELECT 2",
+		"types": `CREATE TABLE a (s status);
+CREATE TYPE status AS ENUM ('on', 'off');
+CREATE TABLE b (s status);`,
+		"copy": `SELECT 1; -- first
+COPY t (a, b) FROM stdin;
+1	x;y
+\.
+-- second
+SELECT 2;
+COPY t TO 'f';
+SELECT 3;`,
+	}
+	for _, f := range files {
+		src, err := ioutil.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		srcs[f] = string(src)
 	}
 
-	stmts, err := parser.ParseSQL()
+	for name, src := range srcs {
+		t.Run(name, func(t *testing.T) {
+			expect := parse(t, src, ParseComment())
+			actual := parse(t, src, ParseComment(), Parallel(4))
+			if diff := cmp.Diff(expect, actual); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+		})
+	}
+
+	t.Run("types", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString(srcs["types"]), &dialect.PostgresqlDialect{}, Parallel(4))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmts, err := parser.ParseSQL()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if d := stmts[0].(*sqlast.CreateTableStmt).Elements[0].(*sqlast.ColumnDef).DataType.(*sqlast.Custom).Definition; d != nil {
+			t.Errorf("type defined later must not be resolved but %v", d)
+		}
+		if d := stmts[2].(*sqlast.CreateTableStmt).Elements[0].(*sqlast.ColumnDef).DataType.(*sqlast.Custom).Definition; d != stmts[1] {
+			t.Errorf("type must be resolved but %v", d)
+		}
+	})
+
+	t.Run("max statements", func(t *testing.T) {
+		src := "SELECT 1; SELECT 2; SELECT (3"
+		expect := parse(t, src, MaxStatements(2))
+		actual := parse(t, src, MaxStatements(2), Parallel(4))
+		if diff := cmp.Diff(expect, actual); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	})
+}
+
+func TestParser_Pos(t *testing.T) {
+	parser, err := NewParser(bytes.NewBufferString("SELECT a\nFROM t WHERE CASE b WHEN 1 'x' END"), &dialect.GenericSQLDialect{})
 	if err != nil {
 		t.Fatalf("%+v", err)
 	}
 
-	if len(stmts) != 3 {
-		t.Fatal("must be 3 stmts")
+	if _, err := parser.ParseStatement(); err == nil {
+		t.Fatal("must be error")
+	}
+
+	if d := cmp.Diff(sqltoken.NewPos(2, 26), parser.Pos()); d != "" {
+		t.Errorf("diff %s", d)
 	}
 }
 
@@ -2109,3 +4790,74 @@ select 1 from test; /*lll*/ --mmm
 	}
 
 }
+
+// benchWorkloads returns the TPC-H queries in testdata/bench and a big INSERT.
+func benchWorkloads(b *testing.B) map[string]string {
+	files, err := filepath.Glob("testdata/bench/*.sql")
+	if err != nil {
+		b.Fatal(err)
+	}
+	workloads := make(map[string]string)
+	for _, f := range files {
+		src, err := ioutil.ReadFile(f)
+		if err != nil {
+			b.Fatal(err)
+		}
+		workloads[strings.TrimSuffix(filepath.Base(f), ".sql")] = string(src)
+	}
+
+	var insert strings.Builder
+	insert.WriteString("INSERT INTO users (id, name, email, score, created_at) VALUES\n")
+	for i := 0; i < 1000; i++ {
+		if i != 0 {
+			insert.WriteString(",\n")
+		}
+		fmt.Fprintf(&insert, "(%d, 'user %d', 'user%d@example.com', %d.5, '2020-01-01 00:00:00')", i, i, i, i)
+	}
+	insert.WriteString(";\n")
+	workloads["big_insert"] = insert.String()
+	return workloads
+}
+
+func BenchmarkParser_ParseSQL(b *testing.B) {
+	for name, src := range benchWorkloads(b) {
+		src := src
+		b.Run(name, func(b *testing.B) {
+			benchmarkParseSQL(b, src)
+		})
+		b.Run(name+"/pooled", func(b *testing.B) {
+			benchmarkParseSQL(b, src, PoolTokens())
+		})
+	}
+}
+
+func BenchmarkParser_ParseSQLParallel(b *testing.B) {
+	var dump strings.Builder
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(&dump, "CREATE TABLE t%d (id serial PRIMARY KEY, name varchar(255) NOT NULL, score numeric(10, 2) DEFAULT 0, CHECK (score >= 0));\n", i)
+		fmt.Fprintf(&dump, "CREATE INDEX t%d_name ON t%d (name);\n", i, i)
+	}
+	src := dump.String()
+
+	b.Run("sequential", func(b *testing.B) {
+		benchmarkParseSQL(b, src)
+	})
+	b.Run("parallel", func(b *testing.B) {
+		benchmarkParseSQL(b, src, Parallel(runtime.GOMAXPROCS(0)))
+	})
+}
+
+func benchmarkParseSQL(b *testing.B, src string, opts ...ParserOption) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(src)))
+	for i := 0; i < b.N; i++ {
+		parser, err := NewParser(strings.NewReader(src), &dialect.GenericSQLDialect{}, opts...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := parser.ParseSQL(); err != nil {
+			b.Fatal(err)
+		}
+		parser.Release()
+	}
+}
@@ -0,0 +1,343 @@
+// Package schemadiff computes the structural difference of two schemas
+// defined by CREATE TABLE and CREATE INDEX statements, and the statements
+// migrating the former to the latter.
+package schemadiff
+
+import (
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// Schema is a set of tables and indexes keyed by their lower-cased names.
+type Schema struct {
+	Tables  []*sqlast.CreateTableStmt
+	Indexes []*sqlast.CreateIndexStmt
+}
+
+// NewSchema returns the schema defined by stmts. CREATE TABLE and CREATE
+// INDEX replace the definitions of the same names and DROP TABLE and DROP
+// INDEX remove them. Other statements are ignored.
+func NewSchema(stmts ...sqlast.Stmt) *Schema {
+	s := &Schema{}
+	for _, stmt := range stmts {
+		switch st := stmt.(type) {
+		case *sqlast.CreateTableStmt:
+			s.dropTable(tableKey(st.Name))
+			s.Tables = append(s.Tables, st)
+		case *sqlast.CreateIndexStmt:
+			s.dropIndex(indexKey(st))
+			s.Indexes = append(s.Indexes, st)
+		case *sqlast.DropTableStmt:
+			for _, n := range st.TableNames {
+				s.dropTable(tableKey(n))
+			}
+		case *sqlast.DropIndexStmt:
+			for _, n := range st.IndexNames {
+				s.dropIndex(strings.ToLower(n.Value))
+			}
+		}
+	}
+	return s
+}
+
+func (s *Schema) dropTable(key string) {
+	for i, t := range s.Tables {
+		if tableKey(t.Name) == key {
+			s.Tables = append(s.Tables[:i:i], s.Tables[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Schema) dropIndex(key string) {
+	for i, idx := range s.Indexes {
+		if indexKey(idx) == key {
+			s.Indexes = append(s.Indexes[:i:i], s.Indexes[i+1:]...)
+			return
+		}
+	}
+}
+
+// Diff is the difference from a schema to another. Renamed tables and
+// columns are reported as dropped and added.
+type Diff struct {
+	AddedTables    []*sqlast.CreateTableStmt
+	DroppedTables  []*sqlast.CreateTableStmt
+	ChangedTables  []*TableDiff
+	AddedIndexes   []*sqlast.CreateIndexStmt
+	DroppedIndexes []*sqlast.CreateIndexStmt // including changed ones, which are added again
+}
+
+// TableDiff is the difference of a table in both schemas.
+type TableDiff struct {
+	From, To           *sqlast.CreateTableStmt
+	AddedColumns       []*sqlast.ColumnDef
+	DroppedColumns     []*sqlast.ColumnDef
+	ChangedColumns     []*ColumnDiff
+	AddedConstraints   []*sqlast.TableConstraint
+	DroppedConstraints []*sqlast.TableConstraint // including changed ones, which are added again
+}
+
+// ColumnDiff is the difference of a column in both schemas.
+type ColumnDiff struct {
+	From, To    *sqlast.ColumnDef
+	Type        bool // the data type or the collation changed
+	NotNull     bool // NOT NULL (or PRIMARY KEY) was added or removed
+	Default     bool // the default value changed
+	Constraints bool // the other column constraints, e.g. UNIQUE or CHECK, changed
+}
+
+// Empty reports whether the schemas are the same.
+func (d *Diff) Empty() bool {
+	return len(d.AddedTables) == 0 && len(d.DroppedTables) == 0 && len(d.ChangedTables) == 0 &&
+		len(d.AddedIndexes) == 0 && len(d.DroppedIndexes) == 0
+}
+
+// Reverse returns the difference from the schema to to the schema from of d,
+// which rolls back d.
+func (d *Diff) Reverse() *Diff {
+	r := &Diff{
+		AddedTables:    d.DroppedTables,
+		DroppedTables:  d.AddedTables,
+		AddedIndexes:   d.DroppedIndexes,
+		DroppedIndexes: d.AddedIndexes,
+	}
+	for _, t := range d.ChangedTables {
+		rt := &TableDiff{
+			From:               t.To,
+			To:                 t.From,
+			AddedColumns:       t.DroppedColumns,
+			DroppedColumns:     t.AddedColumns,
+			AddedConstraints:   t.DroppedConstraints,
+			DroppedConstraints: t.AddedConstraints,
+		}
+		for _, c := range t.ChangedColumns {
+			rc := *c
+			rc.From, rc.To = c.To, c.From
+			rt.ChangedColumns = append(rt.ChangedColumns, &rc)
+		}
+		r.ChangedTables = append(r.ChangedTables, rt)
+	}
+	return r
+}
+
+// Compare returns the difference from the schema from to the schema to.
+func Compare(from, to *Schema) *Diff {
+	d := &Diff{}
+	for _, t := range to.Tables {
+		old := from.table(tableKey(t.Name))
+		if old == nil {
+			d.AddedTables = append(d.AddedTables, t)
+			continue
+		}
+		if td := compareTables(old, t); td != nil {
+			d.ChangedTables = append(d.ChangedTables, td)
+		}
+	}
+	for _, t := range from.Tables {
+		if to.table(tableKey(t.Name)) == nil {
+			d.DroppedTables = append(d.DroppedTables, t)
+		}
+	}
+
+	for _, idx := range to.Indexes {
+		old := from.index(indexKey(idx))
+		if old != nil && sqlast.Equal(old, idx) {
+			continue
+		}
+		if old != nil {
+			d.DroppedIndexes = append(d.DroppedIndexes, old)
+		}
+		d.AddedIndexes = append(d.AddedIndexes, idx)
+	}
+	for _, idx := range from.Indexes {
+		if to.index(indexKey(idx)) == nil {
+			d.DroppedIndexes = append(d.DroppedIndexes, idx)
+		}
+	}
+	return d
+}
+
+func (s *Schema) table(key string) *sqlast.CreateTableStmt {
+	for _, t := range s.Tables {
+		if tableKey(t.Name) == key {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Schema) index(key string) *sqlast.CreateIndexStmt {
+	for _, idx := range s.Indexes {
+		if indexKey(idx) == key {
+			return idx
+		}
+	}
+	return nil
+}
+
+// compareTables returns the difference of the tables, or nil if they have
+// the same columns and constraints.
+func compareTables(from, to *sqlast.CreateTableStmt) *TableDiff {
+	d := &TableDiff{From: from, To: to}
+	fromCols, fromCons := elements(from)
+	toCols, toCons := elements(to)
+
+	for _, c := range toCols {
+		old := findColumn(fromCols, c.Name.Value)
+		if old == nil {
+			d.AddedColumns = append(d.AddedColumns, c)
+			continue
+		}
+		if cd := compareColumns(old, c); cd != nil {
+			d.ChangedColumns = append(d.ChangedColumns, cd)
+		}
+	}
+	for _, c := range fromCols {
+		if findColumn(toCols, c.Name.Value) == nil {
+			d.DroppedColumns = append(d.DroppedColumns, c)
+		}
+	}
+
+	for _, c := range toCons {
+		old := findConstraint(fromCons, c)
+		if old != nil && sqlast.Equal(old, c) {
+			continue
+		}
+		if old != nil {
+			d.DroppedConstraints = append(d.DroppedConstraints, old)
+		}
+		d.AddedConstraints = append(d.AddedConstraints, c)
+	}
+	for _, c := range fromCons {
+		if findConstraint(toCons, c) == nil {
+			d.DroppedConstraints = append(d.DroppedConstraints, c)
+		}
+	}
+
+	if len(d.AddedColumns) == 0 && len(d.DroppedColumns) == 0 && len(d.ChangedColumns) == 0 &&
+		len(d.AddedConstraints) == 0 && len(d.DroppedConstraints) == 0 {
+		return nil
+	}
+	return d
+}
+
+func elements(t *sqlast.CreateTableStmt) ([]*sqlast.ColumnDef, []*sqlast.TableConstraint) {
+	var cols []*sqlast.ColumnDef
+	var cons []*sqlast.TableConstraint
+	for _, e := range t.Elements {
+		switch el := e.(type) {
+		case *sqlast.ColumnDef:
+			cols = append(cols, el)
+		case *sqlast.TableConstraint:
+			cons = append(cons, el)
+		}
+	}
+	return cols, cons
+}
+
+func findColumn(cols []*sqlast.ColumnDef, name string) *sqlast.ColumnDef {
+	for _, c := range cols {
+		if strings.EqualFold(c.Name.Value, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// findConstraint returns the constraint of the same name as c, or the same
+// constraint if c has no name.
+func findConstraint(cons []*sqlast.TableConstraint, c *sqlast.TableConstraint) *sqlast.TableConstraint {
+	for _, o := range cons {
+		if c.Name != nil && o.Name != nil && strings.EqualFold(c.Name.Value, o.Name.Value) {
+			return o
+		}
+		if c.Name == nil && o.Name == nil && sqlast.Equal(c, o) {
+			return o
+		}
+	}
+	return nil
+}
+
+func compareColumns(from, to *sqlast.ColumnDef) *ColumnDiff {
+	d := &ColumnDiff{
+		From:        from,
+		To:          to,
+		Type:        !sqlast.Equal(from.DataType, to.DataType) || !equalNames(from.Collation, to.Collation),
+		NotNull:     notNull(from) != notNull(to),
+		Default:     !equalNodes(from.Default, to.Default),
+		Constraints: !equalConstraints(otherConstraints(from), otherConstraints(to)),
+	}
+	if !d.Type && !d.NotNull && !d.Default && !d.Constraints {
+		return nil
+	}
+	return d
+}
+
+func notNull(c *sqlast.ColumnDef) bool {
+	for _, cons := range c.Constraints {
+		switch spec := cons.Spec.(type) {
+		case *sqlast.NotNullColumnSpec:
+			return true
+		case *sqlast.UniqueColumnSpec:
+			if spec.IsPrimaryKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// otherConstraints returns the constraints of c except NOT NULL.
+func otherConstraints(c *sqlast.ColumnDef) []*sqlast.ColumnConstraint {
+	var cons []*sqlast.ColumnConstraint
+	for _, cc := range c.Constraints {
+		if _, ok := cc.Spec.(*sqlast.NotNullColumnSpec); !ok {
+			cons = append(cons, cc)
+		}
+	}
+	return cons
+}
+
+func equalConstraints(a, b []*sqlast.ColumnConstraint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sqlast.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalNodes(a, b sqlast.Node) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return sqlast.Equal(a, b)
+}
+
+func equalNames(a, b *sqlast.ObjectName) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return tableKey(a) == tableKey(b)
+}
+
+func tableKey(name *sqlast.ObjectName) string {
+	parts := make([]string, 0, len(name.Idents))
+	for _, i := range name.Idents {
+		parts = append(parts, strings.ToLower(i.Value))
+	}
+	return strings.Join(parts, ".")
+}
+
+// indexKey returns the lower-cased name of idx, or its definition if it has no name.
+func indexKey(idx *sqlast.CreateIndexStmt) string {
+	if idx.IndexName != nil {
+		return strings.ToLower(idx.IndexName.Value)
+	}
+	return idx.ToSQLString()
+}
@@ -0,0 +1,138 @@
+package schemadiff
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func parse(t *testing.T, src string) *Schema {
+	t.Helper()
+	parser, err := xsqlparser.NewParser(bytes.NewBufferString(src), &dialect.PostgresqlDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return NewSchema(stmts...)
+}
+
+const from = `
+CREATE TABLE users (
+	id int PRIMARY KEY,
+	name varchar(10),
+	email text NOT NULL,
+	status int DEFAULT 0,
+	CONSTRAINT email_unique UNIQUE (email)
+);
+CREATE TABLE logs (id int);
+CREATE TABLE tmp (id int);
+DROP TABLE tmp;
+CREATE INDEX users_name ON users (name);
+CREATE INDEX users_email ON users (email);
+`
+
+const to = `
+CREATE TABLE USERS (
+	id int PRIMARY KEY,
+	name varchar(100) NOT NULL,
+	status int,
+	created_at timestamp,
+	CONSTRAINT email_unique UNIQUE (name),
+	CHECK (status > 0)
+);
+CREATE TABLE posts (id int, user_id int REFERENCES users (id));
+CREATE INDEX users_name ON users (name, status);
+CREATE INDEX ON posts (user_id);
+`
+
+func TestCompare(t *testing.T) {
+	d := Compare(parse(t, from), parse(t, to))
+
+	names := func(stmts []*sqlast.CreateTableStmt) []string {
+		var n []string
+		for _, s := range stmts {
+			n = append(n, s.Name.ToSQLString())
+		}
+		return n
+	}
+	if diff := cmp.Diff([]string{"posts"}, names(d.AddedTables)); diff != "" {
+		t.Errorf("added tables: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"logs"}, names(d.DroppedTables)); diff != "" {
+		t.Errorf("dropped tables: %s", diff)
+	}
+	if len(d.ChangedTables) != 1 {
+		t.Fatalf("must be 1 changed table but %d", len(d.ChangedTables))
+	}
+
+	td := d.ChangedTables[0]
+	var columns []string
+	for _, c := range td.AddedColumns {
+		columns = append(columns, "+"+c.Name.Value)
+	}
+	for _, c := range td.DroppedColumns {
+		columns = append(columns, "-"+c.Name.Value)
+	}
+	if diff := cmp.Diff([]string{"+created_at", "-email"}, columns); diff != "" {
+		t.Errorf("columns: %s", diff)
+	}
+	var changed []string
+	for _, c := range td.ChangedColumns {
+		changed = append(changed, fmt.Sprintf("%s type=%t not-null=%t default=%t constraints=%t", c.To.Name.Value, c.Type, c.NotNull, c.Default, c.Constraints))
+	}
+	expectChanged := []string{
+		"name type=true not-null=true default=false constraints=false",
+		"status type=false not-null=false default=true constraints=false",
+	}
+	if diff := cmp.Diff(expectChanged, changed); diff != "" {
+		t.Errorf("changed columns: %s", diff)
+	}
+	if len(td.AddedConstraints) != 2 || len(td.DroppedConstraints) != 1 {
+		t.Errorf("must be 2 added and 1 dropped constraints but %d and %d", len(td.AddedConstraints), len(td.DroppedConstraints))
+	}
+
+	var stmts []string
+	for _, s := range d.Stmts() {
+		stmts = append(stmts, s.ToSQLString())
+	}
+	expect := []string{
+		"DROP INDEX users_name",
+		"DROP INDEX users_email",
+		"CREATE TABLE posts (id int, user_id int REFERENCES users(id))",
+		"ALTER TABLE USERS DROP CONSTRAINT email_unique",
+		"ALTER TABLE USERS DROP COLUMN email",
+		"ALTER TABLE USERS ADD COLUMN created_at timestamp",
+		"ALTER TABLE USERS ALTER COLUMN name TYPE character varying(100)",
+		"ALTER TABLE USERS ALTER COLUMN name SET NOT NULL",
+		"ALTER TABLE USERS ALTER COLUMN status DROP DEFAULT",
+		"ALTER TABLE USERS ADD CONSTRAINT email_unique UNIQUE(name)",
+		"ALTER TABLE USERS ADD CHECK(status > 0)",
+		"DROP TABLE logs",
+		"CREATE INDEX users_name ON users (name, status)",
+		"CREATE INDEX ON posts (user_id)",
+	}
+	if diff := cmp.Diff(expect, stmts); diff != "" {
+		t.Errorf("stmts: %s", diff)
+	}
+
+	r := d.Reverse()
+	if diff := cmp.Diff([]string{"logs"}, names(r.AddedTables)); diff != "" {
+		t.Errorf("reversed added tables: %s", diff)
+	}
+	if c := r.ChangedTables[0].ChangedColumns[0]; c.From.Name.Value != "name" || c.To.DataType.ToSQLString() != "character varying(10)" {
+		t.Errorf("reversed column must be name varchar(10) but %s", c.To.ToSQLString())
+	}
+
+	if !Compare(parse(t, to), parse(t, to)).Empty() {
+		t.Error("diff of the same schemas must be empty")
+	}
+}
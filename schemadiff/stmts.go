@@ -0,0 +1,82 @@
+package schemadiff
+
+import (
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// Stmts returns the statements migrating the schema from to the schema to
+// of Compare in PostgreSQL syntax: DROP INDEX, CREATE TABLE, ALTER TABLE,
+// DROP TABLE and CREATE INDEX in this order.
+//
+// Changes of column constraints other than NOT NULL, and dropping
+// constraints or indexes without names aren't migrated, since they can't be
+// referred to by ALTER TABLE.
+func (d *Diff) Stmts() []sqlast.Stmt {
+	var stmts []sqlast.Stmt
+	for _, idx := range d.DroppedIndexes {
+		if idx.IndexName != nil {
+			stmts = append(stmts, &sqlast.DropIndexStmt{IndexNames: []*sqlast.Ident{idx.IndexName}})
+		}
+	}
+	for _, t := range d.AddedTables {
+		stmts = append(stmts, t)
+	}
+	for _, t := range d.ChangedTables {
+		stmts = append(stmts, t.Stmts()...)
+	}
+	for _, t := range d.DroppedTables {
+		stmts = append(stmts, &sqlast.DropTableStmt{TableNames: []*sqlast.ObjectName{t.Name}})
+	}
+	for _, idx := range d.AddedIndexes {
+		stmts = append(stmts, idx)
+	}
+	return stmts
+}
+
+// Stmts returns the ALTER TABLE statements migrating the table of d: DROP
+// CONSTRAINT, DROP COLUMN, ADD COLUMN, ALTER COLUMN and ADD CONSTRAINT in
+// this order.
+func (d *TableDiff) Stmts() []sqlast.Stmt {
+	var stmts []sqlast.Stmt
+	alter := func(action sqlast.AlterTableAction) {
+		stmts = append(stmts, &sqlast.AlterTableStmt{TableName: d.To.Name, Action: action})
+	}
+	alterColumn := func(c *sqlast.ColumnDef, action sqlast.AlterColumnAction) {
+		alter(&sqlast.AlterColumnTableAction{ColumnName: c.Name, Action: action})
+	}
+
+	for _, c := range d.DroppedConstraints {
+		if c.Name != nil {
+			alter(&sqlast.DropConstraintTableAction{Name: c.Name})
+		}
+	}
+	for _, c := range d.DroppedColumns {
+		alter(&sqlast.RemoveColumnTableAction{Name: c.Name})
+	}
+	for _, c := range d.AddedColumns {
+		alter(&sqlast.AddColumnTableAction{Column: c})
+	}
+	for _, c := range d.ChangedColumns {
+		if c.Type {
+			alterColumn(c.To, &sqlast.PGAlterDataTypeColumnAction{DataType: c.To.DataType})
+		}
+		if c.NotNull {
+			if notNull(c.To) {
+				alterColumn(c.To, &sqlast.PGSetNotNullColumnAction{})
+			} else {
+				alterColumn(c.To, &sqlast.PGDropNotNullColumnAction{})
+			}
+		}
+		if c.Default {
+			if c.To.Default != nil {
+				alterColumn(c.To, &sqlast.SetDefaultColumnAction{Default: c.To.Default})
+			} else {
+				alterColumn(c.To, &sqlast.DropDefaultColumnAction{})
+			}
+		}
+	}
+	for _, c := range d.AddedConstraints {
+		alter(&sqlast.AddConstraintTableAction{Constraint: c})
+	}
+	return stmts
+}
@@ -0,0 +1,181 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// `ALTER INDEX [IF EXISTS] Name Action`
+type AlterIndexStmt struct {
+	stmt
+	Alter    sqltoken.Pos
+	IfExists bool
+	Name     *ObjectName
+	Action   AlterObjectAction
+}
+
+func (a *AlterIndexStmt) Pos() sqltoken.Pos {
+	return a.Alter
+}
+
+func (a *AlterIndexStmt) End() sqltoken.Pos {
+	return a.Action.End()
+}
+
+func (a *AlterIndexStmt) ToSQLString() string {
+	return toSQLString(a)
+}
+
+func (a *AlterIndexStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("ALTER INDEX ")).If(a.IfExists, []byte("IF EXISTS ")).Node(a.Name).Space().Node(a.Action)
+	return sw.End()
+}
+
+// `ALTER VIEW [IF EXISTS] Name Action`
+type AlterViewStmt struct {
+	stmt
+	Alter    sqltoken.Pos
+	IfExists bool
+	Name     *ObjectName
+	Action   AlterObjectAction
+}
+
+func (a *AlterViewStmt) Pos() sqltoken.Pos {
+	return a.Alter
+}
+
+func (a *AlterViewStmt) End() sqltoken.Pos {
+	return a.Action.End()
+}
+
+func (a *AlterViewStmt) ToSQLString() string {
+	return toSQLString(a)
+}
+
+func (a *AlterViewStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("ALTER VIEW ")).If(a.IfExists, []byte("IF EXISTS ")).Node(a.Name).Space().Node(a.Action)
+	return sw.End()
+}
+
+// `ALTER SCHEMA Name Action`
+type AlterSchemaStmt struct {
+	stmt
+	Alter  sqltoken.Pos
+	Name   *ObjectName
+	Action AlterObjectAction
+}
+
+func (a *AlterSchemaStmt) Pos() sqltoken.Pos {
+	return a.Alter
+}
+
+func (a *AlterSchemaStmt) End() sqltoken.Pos {
+	return a.Action.End()
+}
+
+func (a *AlterSchemaStmt) ToSQLString() string {
+	return toSQLString(a)
+}
+
+func (a *AlterSchemaStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("ALTER SCHEMA ")).Node(a.Name).Space().Node(a.Action)
+	return sw.End()
+}
+
+//go:generate genmark -t AlterObjectAction -e Node
+
+// `RENAME TO NewName`
+type RenameToAction struct {
+	alterObjectAction
+	Rename  sqltoken.Pos
+	NewName *Ident
+}
+
+func (r *RenameToAction) Pos() sqltoken.Pos {
+	return r.Rename
+}
+
+func (r *RenameToAction) End() sqltoken.Pos {
+	return r.NewName.End()
+}
+
+func (r *RenameToAction) ToSQLString() string {
+	return toSQLString(r)
+}
+
+func (r *RenameToAction) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("RENAME TO ")).Node(r.NewName).End()
+}
+
+// `SET TABLESPACE Tablespace`
+type SetTablespaceAction struct {
+	alterObjectAction
+	Set        sqltoken.Pos
+	Tablespace *Ident
+}
+
+func (s *SetTablespaceAction) Pos() sqltoken.Pos {
+	return s.Set
+}
+
+func (s *SetTablespaceAction) End() sqltoken.Pos {
+	return s.Tablespace.End()
+}
+
+func (s *SetTablespaceAction) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SetTablespaceAction) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("SET TABLESPACE ")).Node(s.Tablespace).End()
+}
+
+// `OWNER TO Owner`
+type OwnerToAction struct {
+	alterObjectAction
+	OwnerPos sqltoken.Pos
+	Owner    *Ident
+}
+
+func (o *OwnerToAction) Pos() sqltoken.Pos {
+	return o.OwnerPos
+}
+
+func (o *OwnerToAction) End() sqltoken.Pos {
+	return o.Owner.End()
+}
+
+func (o *OwnerToAction) ToSQLString() string {
+	return toSQLString(o)
+}
+
+func (o *OwnerToAction) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("OWNER TO ")).Node(o.Owner).End()
+}
+
+// `SET SCHEMA Schema`
+type SetSchemaAction struct {
+	alterObjectAction
+	Set    sqltoken.Pos
+	Schema *Ident
+}
+
+func (s *SetSchemaAction) Pos() sqltoken.Pos {
+	return s.Set
+}
+
+func (s *SetSchemaAction) End() sqltoken.Pos {
+	return s.Schema.End()
+}
+
+func (s *SetSchemaAction) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SetSchemaAction) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("SET SCHEMA ")).Node(s.Schema).End()
+}
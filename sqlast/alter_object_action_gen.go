@@ -0,0 +1,11 @@
+package sqlast
+
+// Code generated by genmark. DO NOT EDIT.
+
+type AlterObjectAction interface {
+	alterObjectActionMarker()
+	Node
+}
+type alterObjectAction struct{}
+
+func (alterObjectAction) alterObjectActionMarker() {}
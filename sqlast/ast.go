@@ -25,6 +25,7 @@ type Node interface {
 type File struct {
 	Stmts    []Stmt
 	Comments []*CommentGroup
+	Source   *Source // set if parsed with xsqlparser.KeepSource
 }
 
 func (f *File) End() sqltoken.Pos {
@@ -61,9 +62,16 @@ func (f *File) WriteTo(w io.Writer) (int64, error) {
 }
 
 // Identifier
+// Ident is an identifier. Value of a quoted identifier (e.g. "My Table")
+// is stored without quotes and Quoted is set so that it is quoted again
+// when written out.
 type Ident struct {
-	Value    string
-	From, To sqltoken.Pos
+	Value      string
+	Quoted     bool
+	QuoteStyle rune // '"', '`' or '['. '"' is used if Quoted and QuoteStyle is 0.
+	// escape character of U&"..." (Postgres), 0 for the other quotes
+	UnicodeEscape rune
+	From, To      sqltoken.Pos
 }
 
 func NewIdent(str string) *Ident {
@@ -79,7 +87,20 @@ func NewIdentWithPos(str string, from, to sqltoken.Pos) *Ident {
 }
 
 func (s *Ident) ToSQLString() string {
-	return s.Value
+	return s.String()
+}
+
+// String returns the identifier as written in SQL, with quotes if s is Quoted.
+func (s *Ident) String() string {
+	if !s.Quoted {
+		return s.Value
+	}
+	style := s.QuoteStyle
+	if style == 0 {
+		style = '"'
+	}
+	w := sqltoken.SQLWord{Value: s.Value, QuoteStyle: style, UnicodeEscape: s.UnicodeEscape}
+	return w.String()
 }
 
 func (s *Ident) Pos() sqltoken.Pos {
@@ -91,11 +112,11 @@ func (s *Ident) End() sqltoken.Pos {
 }
 
 func (s *Ident) WriteTo(w io.Writer) (int64, error) {
-	return writeSingleString(w, s.Value)
+	return writeSingleString(w, s.String())
 }
 
 func (s *Ident) WriteStringTo(w io.StringWriter) (int64, error) {
-	n, err := w.WriteString(s.Value)
+	n, err := w.WriteString(s.String())
 	return int64(n), err
 }
 
@@ -123,6 +144,30 @@ func (s *Wildcard) WriteTo(w io.Writer) (int64, error) {
 	return writeSingleBytes(w, wildcardBytes)
 }
 
+// Placeholder is a bind parameter such as `?` or `$1`, or a named placeholder
+// such as `:name` to be substituted by sqlastutil.Substitute.
+type Placeholder struct {
+	limitCount
+	Value    string
+	From, To sqltoken.Pos
+}
+
+func (s *Placeholder) Pos() sqltoken.Pos {
+	return s.From
+}
+
+func (s *Placeholder) End() sqltoken.Pos {
+	return s.To
+}
+
+func (s *Placeholder) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Placeholder) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleString(w, s.Value)
+}
+
 // `table.*`, schema.table.*
 type QualifiedWildcard struct {
 	Idents []*Ident
@@ -327,6 +372,50 @@ func (s *BinaryExpr) WriteTo(w io.Writer) (int64, error) {
 	return sw.End()
 }
 
+// `Like ESCAPE Escape`, where Like is a LIKE, ILIKE or SIMILAR TO BinaryExpr (or its negation)
+type LikeEscape struct {
+	Like   *BinaryExpr
+	Escape Node
+}
+
+func (l *LikeEscape) Pos() sqltoken.Pos {
+	return l.Like.Pos()
+}
+
+func (l *LikeEscape) End() sqltoken.Pos {
+	return l.Escape.End()
+}
+
+func (l *LikeEscape) ToSQLString() string {
+	return toSQLString(l)
+}
+
+func (l *LikeEscape) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Node(l.Like).Bytes([]byte(" ESCAPE ")).Node(l.Escape).End()
+}
+
+// `Expr COLLATE Collation`
+type Collate struct {
+	Expr      Node
+	Collation *ObjectName
+}
+
+func (c *Collate) Pos() sqltoken.Pos {
+	return c.Expr.Pos()
+}
+
+func (c *Collate) End() sqltoken.Pos {
+	return c.Collation.End()
+}
+
+func (c *Collate) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *Collate) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Node(c.Expr).Bytes([]byte(" COLLATE ")).Node(c.Collation).End()
+}
+
 // `CAST(Expr AS DataType)`
 type Cast struct {
 	Expr     Node
@@ -356,6 +445,283 @@ func (s *Cast) WriteTo(w io.Writer) (int64, error) {
 		End()
 }
 
+// `EXTRACT(Field FROM Expr)`
+type Extract struct {
+	Field   *Ident
+	Expr    Node
+	Extract sqltoken.Pos // first position of EXTRACT keyword
+	RParen  sqltoken.Pos
+}
+
+func (s *Extract) Pos() sqltoken.Pos {
+	return s.Extract
+}
+
+func (s *Extract) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Extract) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Extract) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).
+		Bytes([]byte("EXTRACT")).
+		LParen().Node(s.Field).Bytes(fromBytes).Node(s.Expr).RParen().
+		End()
+}
+
+// `POSITION(Substring IN String)`
+type Position struct {
+	Substring Node
+	String    Node
+	Position  sqltoken.Pos // first position of POSITION keyword
+	RParen    sqltoken.Pos
+}
+
+func (s *Position) Pos() sqltoken.Pos {
+	return s.Position
+}
+
+func (s *Position) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Position) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Position) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).
+		Bytes([]byte("POSITION")).
+		LParen().Node(s.Substring).Bytes([]byte(" IN ")).Node(s.String).RParen().
+		End()
+}
+
+// `SUBSTRING(Expr [FROM Start] [FOR Length])` or `SUBSTRING(Expr, Start [, Length])`
+type Substring struct {
+	Expr        Node
+	Start       Node
+	Length      Node
+	CommaSyntax bool         // arguments are separated by commas
+	Substring   sqltoken.Pos // first position of SUBSTRING keyword
+	RParen      sqltoken.Pos
+}
+
+func (s *Substring) Pos() sqltoken.Pos {
+	return s.Substring
+}
+
+func (s *Substring) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Substring) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Substring) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("SUBSTRING")).LParen().Node(s.Expr)
+	if s.CommaSyntax {
+		if s.Start != nil {
+			sw.Bytes([]byte(", ")).Node(s.Start)
+		}
+		if s.Length != nil {
+			sw.Bytes([]byte(", ")).Node(s.Length)
+		}
+	} else {
+		if s.Start != nil {
+			sw.Bytes(fromBytes).Node(s.Start)
+		}
+		if s.Length != nil {
+			sw.Bytes([]byte(" FOR ")).Node(s.Length)
+		}
+	}
+	return sw.RParen().End()
+}
+
+// `TRIM([BOTH | LEADING | TRAILING] [Characters] [FROM] Expr)`
+type Trim struct {
+	Where      TrimWhereField
+	Characters Node
+	Expr       Node
+	Trim       sqltoken.Pos // first position of TRIM keyword
+	RParen     sqltoken.Pos
+}
+
+func (s *Trim) Pos() sqltoken.Pos {
+	return s.Trim
+}
+
+func (s *Trim) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Trim) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Trim) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("TRIM")).LParen()
+	if s.Where != NoTrimWhere {
+		sw.Bytes([]byte(s.Where.ToSQLString())).Space()
+	}
+	if s.Characters != nil {
+		sw.Node(s.Characters).Space()
+	}
+	if s.Where != NoTrimWhere || s.Characters != nil {
+		sw.Bytes([]byte("FROM "))
+	}
+	return sw.Node(s.Expr).RParen().End()
+}
+
+type TrimWhereField int
+
+const (
+	NoTrimWhere TrimWhereField = iota
+	TrimBoth
+	TrimLeading
+	TrimTrailing
+)
+
+func (t TrimWhereField) ToSQLString() string {
+	switch t {
+	case TrimBoth:
+		return "BOTH"
+	case TrimLeading:
+		return "LEADING"
+	case TrimTrailing:
+		return "TRAILING"
+	}
+	return ""
+}
+
+// `OVERLAY(Expr PLACING Placing FROM Start [FOR Length])`
+type Overlay struct {
+	Expr    Node
+	Placing Node
+	Start   Node
+	Length  Node
+	Overlay sqltoken.Pos // first position of OVERLAY keyword
+	RParen  sqltoken.Pos
+}
+
+func (s *Overlay) Pos() sqltoken.Pos {
+	return s.Overlay
+}
+
+func (s *Overlay) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Overlay) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Overlay) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("OVERLAY")).LParen().Node(s.Expr).
+		Bytes([]byte(" PLACING ")).Node(s.Placing).
+		Bytes(fromBytes).Node(s.Start)
+	if s.Length != nil {
+		sw.Bytes([]byte(" FOR ")).Node(s.Length)
+	}
+	return sw.RParen().End()
+}
+
+// `Expr[Index]` or `Expr[Index:Upper]`
+type Subscript struct {
+	Expr     Node
+	Index    Node // lower bound if IsSlice is true (nil if omitted)
+	Upper    Node // upper bound of the slice (nil if omitted)
+	IsSlice  bool
+	RBracket sqltoken.Pos
+}
+
+func (s *Subscript) Pos() sqltoken.Pos {
+	return s.Expr.Pos()
+}
+
+func (s *Subscript) End() sqltoken.Pos {
+	return s.RBracket
+}
+
+func (s *Subscript) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Subscript) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Node(s.Expr).Bytes([]byte("["))
+	if s.Index != nil {
+		sw.Node(s.Index)
+	}
+	if s.IsSlice {
+		sw.Bytes([]byte(":"))
+		if s.Upper != nil {
+			sw.Node(s.Upper)
+		}
+	}
+	return sw.Bytes([]byte("]")).End()
+}
+
+// `ARRAY[Elements...]`
+// nested array such as `[1, 2]` in `ARRAY[[1, 2], [3, 4]]` is represented with Bare
+type ArrayConstructor struct {
+	Array    sqltoken.Pos // first position of ARRAY keyword, or LBracket if Bare is true
+	Bare     bool         // written without ARRAY keyword
+	Elements []Node
+	RBracket sqltoken.Pos
+}
+
+func (a *ArrayConstructor) Pos() sqltoken.Pos {
+	return a.Array
+}
+
+func (a *ArrayConstructor) End() sqltoken.Pos {
+	return a.RBracket
+}
+
+func (a *ArrayConstructor) ToSQLString() string {
+	return toSQLString(a)
+}
+
+func (a *ArrayConstructor) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.If(!a.Bare, []byte("ARRAY"))
+	return sw.Bytes([]byte("[")).Nodes(a.Elements).Bytes([]byte("]")).End()
+}
+
+// `ROW(Values...)` or `(Values...)`
+// a row written without ROW keyword (Implicit) has at least two values, otherwise it is Nested
+type SQLRowExpr struct {
+	Row      sqltoken.Pos // first position of ROW keyword, or LParen if Implicit is true
+	Implicit bool         // written without ROW keyword
+	Values   []Node
+	RParen   sqltoken.Pos
+}
+
+func (r *SQLRowExpr) Pos() sqltoken.Pos {
+	return r.Row
+}
+
+func (r *SQLRowExpr) End() sqltoken.Pos {
+	return r.RParen
+}
+
+func (r *SQLRowExpr) ToSQLString() string {
+	return toSQLString(r)
+}
+
+func (r *SQLRowExpr) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.If(!r.Implicit, []byte("ROW"))
+	return sw.LParen().Nodes(r.Values).RParen().End()
+}
+
 // (AST)
 type Nested struct {
 	AST            Node
@@ -378,6 +744,28 @@ func (s *Nested) WriteTo(w io.Writer) (int64, error) {
 	return newSQLWriter(w).LParen().Node(s.AST).RParen().End()
 }
 
+// Column(+), the legacy outer join operator of Oracle
+type OuterJoinColumn struct {
+	Column Node // *Ident or *CompoundIdent
+	RParen sqltoken.Pos
+}
+
+func (o *OuterJoinColumn) Pos() sqltoken.Pos {
+	return o.Column.Pos()
+}
+
+func (o *OuterJoinColumn) End() sqltoken.Pos {
+	return o.RParen
+}
+
+func (o *OuterJoinColumn) ToSQLString() string {
+	return toSQLString(o)
+}
+
+func (o *OuterJoinColumn) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Node(o.Column).Bytes([]byte("(+)")).End()
+}
+
 // Op Expr
 type UnaryExpr struct {
 	From sqltoken.Pos // first position of Op
@@ -402,12 +790,18 @@ func (s *UnaryExpr) WriteTo(w io.Writer) (int64, error) {
 }
 
 // Name(Args...) [OVER (Over)]
+// `Name(Args...) [WITHIN GROUP (ORDER BY WithinGroup...)] [FILTER (WHERE Filter)] [OVER {(Over) | OverName}]`
 type Function struct {
-	Name       *ObjectName // Function Name
-	Args       []Node
-	ArgsRParen sqltoken.Pos // function args RParen position
-	Over       *WindowSpec
-	OverRparen sqltoken.Pos // Over RParen position (if Over is not nil)
+	Name              *ObjectName // Function Name
+	Args              []Node
+	ArgsRParen        sqltoken.Pos // function args RParen position
+	WithinGroup       []*OrderByExpr
+	WithinGroupRParen sqltoken.Pos // WITHIN GROUP RParen position (if WithinGroup is not empty)
+	Filter            Node
+	FilterRParen      sqltoken.Pos // FILTER RParen position (if Filter is not nil)
+	Over              *WindowSpec
+	OverRparen        sqltoken.Pos // Over RParen position (if Over is not nil)
+	OverName          *Ident       // `OVER OverName` referring to a window in WINDOW clause
 }
 
 func (s *Function) Pos() sqltoken.Pos {
@@ -415,10 +809,17 @@ func (s *Function) Pos() sqltoken.Pos {
 }
 
 func (s *Function) End() sqltoken.Pos {
-	if s.Over == nil {
-		return s.ArgsRParen
+	switch {
+	case s.OverName != nil:
+		return s.OverName.End()
+	case s.Over != nil:
+		return s.OverRparen
+	case s.Filter != nil:
+		return s.FilterRParen
+	case len(s.WithinGroup) != 0:
+		return s.WithinGroupRParen
 	}
-	return s.OverRparen
+	return s.ArgsRParen
 }
 
 func (s *Function) ToSQLString() string {
@@ -428,12 +829,56 @@ func (s *Function) ToSQLString() string {
 func (s *Function) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	sw.Node(s.Name).LParen().Nodes(s.Args).RParen()
+	if len(s.WithinGroup) != 0 {
+		sw.Bytes([]byte(" WITHIN GROUP (ORDER BY "))
+		for i, o := range s.WithinGroup {
+			sw.JoinComma(i, o)
+		}
+		sw.RParen()
+	}
+	if s.Filter != nil {
+		sw.Bytes([]byte(" FILTER (WHERE ")).Node(s.Filter).RParen()
+	}
 	if s.Over != nil {
 		sw.Bytes([]byte(" OVER ")).LParen().Node(s.Over).RParen()
 	}
+	if s.OverName != nil {
+		sw.Bytes([]byte(" OVER ")).Node(s.OverName)
+	}
 	return sw.End()
 }
 
+// NamedArg is a named argument of a function call, `Name => Value`
+// or `Name := Value` (ColonEq is true).
+type NamedArg struct {
+	Name    *Ident
+	ColonEq bool
+	Value   Node
+}
+
+func (n *NamedArg) Pos() sqltoken.Pos {
+	return n.Name.Pos()
+}
+
+func (n *NamedArg) End() sqltoken.Pos {
+	return n.Value.End()
+}
+
+func (n *NamedArg) ToSQLString() string {
+	return toSQLString(n)
+}
+
+func (n *NamedArg) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Node(n.Name)
+	if n.ColonEq {
+		sw.Bytes([]byte(" := "))
+	} else {
+		sw.Bytes([]byte(" => "))
+	}
+	return sw.Node(n.Value).End()
+}
+
 // CASE [Operand] WHEN Conditions... THEN Results... [ELSE ElseResult] END
 type CaseExpr struct {
 	Case       sqltoken.Pos // first position of CASE keyword
@@ -558,7 +1003,10 @@ func (s *ObjectName) WriteTo(w io.Writer) (int64, error) {
 	return newSQLWriter(w).Idents(s.Idents, dotBytes).End()
 }
 
+// `[Name] [PARTITION BY PartitionBy...] [ORDER BY OrderBy...] [WindowsFrame]`
+// Name refers to a window in WINDOW clause whose definition is inherited.
 type WindowSpec struct {
+	Name             *Ident
 	PartitionBy      []Node
 	OrderBy          []*OrderByExpr
 	WindowsFrame     *WindowFrame
@@ -566,6 +1014,9 @@ type WindowSpec struct {
 }
 
 func (s *WindowSpec) Pos() sqltoken.Pos {
+	if s.Name != nil {
+		return s.Name.Pos()
+	}
 	if len(s.PartitionBy) != 0 {
 		return s.Partition
 	}
@@ -585,7 +1036,11 @@ func (s *WindowSpec) End() sqltoken.Pos {
 		return s.OrderBy[len(s.OrderBy)-1].End()
 	}
 
-	return s.PartitionBy[len(s.PartitionBy)-1].End()
+	if len(s.PartitionBy) != 0 {
+		return s.PartitionBy[len(s.PartitionBy)-1].End()
+	}
+
+	return s.Name.End()
 }
 
 func (s *WindowSpec) ToSQLString() string {
@@ -595,8 +1050,16 @@ func (s *WindowSpec) ToSQLString() string {
 func (s *WindowSpec) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	space := false
-	if len(s.PartitionBy) != 0 {
+	if s.Name != nil {
 		space = true
+		sw.Node(s.Name)
+	}
+	if len(s.PartitionBy) != 0 {
+		if space {
+			sw.Space()
+		} else {
+			space = true
+		}
 		sw.Bytes([]byte("PARTITION BY ")).Nodes(s.PartitionBy)
 	}
 	if len(s.OrderBy) != 0 {
@@ -619,6 +1082,29 @@ func (s *WindowSpec) WriteTo(w io.Writer) (int64, error) {
 	return sw.End()
 }
 
+// `Name AS (Spec)` in WINDOW clause
+type NamedWindow struct {
+	Name   *Ident
+	Spec   *WindowSpec
+	RParen sqltoken.Pos
+}
+
+func (n *NamedWindow) Pos() sqltoken.Pos {
+	return n.Name.Pos()
+}
+
+func (n *NamedWindow) End() sqltoken.Pos {
+	return n.RParen
+}
+
+func (n *NamedWindow) ToSQLString() string {
+	return toSQLString(n)
+}
+
+func (n *NamedWindow) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Node(n.Name).As().LParen().Node(n.Spec).RParen().End()
+}
+
 type WindowFrame struct {
 	Units      *WindowFrameUnit
 	StartBound SQLWindowFrameBound
@@ -0,0 +1,44 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// `CALL Name[(Args...)]` or `EXECUTE PROCEDURE Name[(Args...)]`.
+// The parentheses may be omitted in MySQL, in which case RParen is
+// the zero value. Args may contain NamedArg.
+type CallStmt struct {
+	stmt
+	Call             sqltoken.Pos
+	ExecuteProcedure bool
+	Name             *ObjectName
+	Args             []Node
+	RParen           sqltoken.Pos
+}
+
+func (c *CallStmt) Pos() sqltoken.Pos {
+	return c.Call
+}
+
+func (c *CallStmt) End() sqltoken.Pos {
+	if c.RParen == (sqltoken.Pos{}) {
+		return c.Name.End()
+	}
+	return c.RParen
+}
+
+func (c *CallStmt) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CallStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	if c.ExecuteProcedure {
+		sw.Bytes([]byte("EXECUTE PROCEDURE "))
+	} else {
+		sw.Bytes([]byte("CALL "))
+	}
+	return sw.Node(c.Name).LParen().Nodes(c.Args).RParen().End()
+}
@@ -0,0 +1,61 @@
+package sqlast
+
+import (
+	"reflect"
+)
+
+var customType = reflect.TypeOf(Custom{})
+
+// Clone returns a deep copy of node, which shares no nodes with node so that
+// either of them can be modified independently. References to nodes outside
+// the tree, i.e. Custom.Definition, are not copied.
+func Clone(node Node) Node {
+	if node == nil {
+		return nil
+	}
+	return cloneValue(reflect.ValueOf(node)).Interface().(Node)
+}
+
+func cloneValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(cloneValue(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(cloneValue(v.Elem()))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" {
+				// unexported fields (markers) have nothing to copy
+				continue
+			}
+			if v.Type() == customType && f.Name == "Definition" {
+				continue
+			}
+			c.Field(i).Set(cloneValue(v.Field(i)))
+		}
+		return c
+	}
+	return v
+}
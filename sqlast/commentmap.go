@@ -142,7 +142,13 @@ func NewCommentMap(file *File) CommentMap {
 
 		switch q.(type) {
 		// Stmts
-		case *QueryStmt, *InsertStmt, *UpdateStmt, *DeleteStmt, *CreateViewStmt, *CreateTableStmt, *AlterTableStmt, *DropTableStmt, *CreateIndexStmt, *DropIndexStmt, *ExplainStmt:
+		case *QueryStmt, *InsertStmt, *UpdateStmt, *DeleteStmt, *CreateViewStmt, *CreateTableStmt, *AlterTableStmt, *DropTableStmt, *CreateIndexStmt, *DropIndexStmt, *ExplainStmt, *CopyStmt, *CreateFunctionStmt, *CreateSequenceStmt, *AlterSequenceStmt,
+			*AlterIndexStmt, *AlterViewStmt, *AlterSchemaStmt, *UnloadStmt,
+			*CreateSchemaStmt, *CreateDatabaseStmt, *CreateExtensionStmt, *CreateTypeStmt, *TruncateStmt, *MergeStmt,
+			*VacuumStmt, *AnalyzeStmt, *ReindexStmt,
+			*PrepareStmt, *ExecuteStmt, *CallStmt, *DeallocateStmt, *DeclareCursorStmt, *FetchStmt, *CloseStmt,
+			*SetStmt, *SetTimeZoneStmt, *ShowStmt, *ResetStmt,
+			*ShowTablesStmt, *ShowColumnsStmt, *ShowCreateTableStmt, *DescribeStmt, *UseStmt:
 			stack.push(q)
 		// table element
 		case *ColumnDef, *TableConstraint:
@@ -0,0 +1,92 @@
+package sqlast
+
+import "strings"
+
+// CheckConstraint is a CHECK constraint of a table or of a column.
+type CheckConstraint struct {
+	Name   *Ident     // nil if unnamed
+	Column *ColumnDef // the column the constraint is defined on, or nil for a table constraint
+	Expr   Node
+}
+
+// Columns returns the column definitions of c in order.
+func (c *CreateTableStmt) Columns() []*ColumnDef {
+	var columns []*ColumnDef
+	for _, e := range c.Elements {
+		if def, ok := e.(*ColumnDef); ok {
+			columns = append(columns, def)
+		}
+	}
+	return columns
+}
+
+// Column returns the definition of the column named name, which is compared
+// case-insensitively, or nil if c has no such column.
+func (c *CreateTableStmt) Column(name string) *ColumnDef {
+	for _, def := range c.Columns() {
+		if strings.EqualFold(def.Name.Value, name) {
+			return def
+		}
+	}
+	return nil
+}
+
+// Defaults returns the DEFAULT expressions of the columns of c by the names
+// of the columns. Columns without DEFAULT are not included.
+func (c *CreateTableStmt) Defaults() map[string]Node {
+	defaults := make(map[string]Node)
+	for _, def := range c.Columns() {
+		if def.Default != nil {
+			defaults[def.Name.Value] = def.Default
+		}
+	}
+	return defaults
+}
+
+// Checks returns the CHECK constraints of c, both of the columns and of the
+// table, in order of appearance.
+func (c *CreateTableStmt) Checks() []*CheckConstraint {
+	var checks []*CheckConstraint
+	for _, e := range c.Elements {
+		switch e := e.(type) {
+		case *ColumnDef:
+			checks = append(checks, e.Checks()...)
+		case *TableConstraint:
+			if check, ok := e.Spec.(*CheckTableConstraint); ok {
+				checks = append(checks, &CheckConstraint{Name: e.Name, Expr: check.Expr})
+			}
+		}
+	}
+	return checks
+}
+
+// Checks returns the CHECK constraints of c in order.
+func (c *ColumnDef) Checks() []*CheckConstraint {
+	var checks []*CheckConstraint
+	for _, cons := range c.Constraints {
+		if check, ok := cons.Spec.(*CheckColumnSpec); ok {
+			checks = append(checks, &CheckConstraint{Name: cons.Name, Column: c, Expr: check.Expr})
+		}
+	}
+	return checks
+}
+
+// NotNull reports whether c has NOT NULL or PRIMARY KEY constraint, or is
+// a serial or identity column, which can't be NULL either.
+func (c *ColumnDef) NotNull() bool {
+	switch c.DataType.(type) {
+	case *SmallSerial, *Serial, *BigSerial:
+		return true
+	}
+	for _, cons := range c.Constraints {
+		switch spec := cons.Spec.(type) {
+		case *NotNullColumnSpec, *IdentityColumnSpec:
+			return true
+		case *UniqueColumnSpec:
+			if spec.IsPrimaryKey {
+				return true
+			}
+		}
+	}
+	return false
+}
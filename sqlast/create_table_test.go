@@ -0,0 +1,74 @@
+package sqlast
+
+import "testing"
+
+func TestCreateTableStmt_Constraints(t *testing.T) {
+	price := &ColumnDef{
+		Name:     NewIdent("price"),
+		DataType: &Int{},
+		Default:  NewLongValue(0),
+		Constraints: []*ColumnConstraint{
+			{Spec: &NotNullColumnSpec{}},
+			{
+				Name: NewIdent("price_positive"),
+				Spec: &CheckColumnSpec{Expr: &BinaryExpr{Left: NewIdent("price"), Op: &Operator{Type: Gt}, Right: NewLongValue(0)}},
+			},
+		},
+	}
+	stmt := &CreateTableStmt{
+		Name: NewObjectName("items"),
+		Elements: []TableElement{
+			&ColumnDef{
+				Name:        NewIdent("id"),
+				DataType:    &Int{},
+				Constraints: []*ColumnConstraint{{Spec: &UniqueColumnSpec{IsPrimaryKey: true}}},
+			},
+			price,
+			&ColumnDef{Name: NewIdent("note"), DataType: &Text{}},
+			&TableConstraint{
+				Spec: &CheckTableConstraint{Expr: &BinaryExpr{Left: NewIdent("price"), Op: &Operator{Type: Lt}, Right: NewLongValue(1000)}},
+			},
+		},
+	}
+
+	if c := stmt.Column("PRICE"); c != price {
+		t.Errorf("must be price but %v", c)
+	}
+	if c := stmt.Column("unknown"); c != nil {
+		t.Errorf("must be nil but %v", c)
+	}
+	if len(stmt.Columns()) != 3 {
+		t.Errorf("must have 3 columns but %d", len(stmt.Columns()))
+	}
+
+	defaults := stmt.Defaults()
+	if len(defaults) != 1 || defaults["price"].ToSQLString() != "0" {
+		t.Errorf("unexpected defaults %v", defaults)
+	}
+
+	for i, c := range stmt.Columns() {
+		if expect := i != 2; c.NotNull() != expect {
+			t.Errorf("NotNull of %s must be %t", c.Name.Value, expect)
+		}
+	}
+
+	for _, c := range []*ColumnDef{
+		{Name: NewIdent("serial"), DataType: &Serial{}},
+		{Name: NewIdent("identity"), DataType: &Int{}, Constraints: []*ColumnConstraint{{Spec: &IdentityColumnSpec{Always: true}}}},
+	} {
+		if !c.NotNull() {
+			t.Errorf("%s column must be NOT NULL", c.Name.Value)
+		}
+	}
+
+	checks := stmt.Checks()
+	if len(checks) != 2 {
+		t.Fatalf("must have 2 checks but %d", len(checks))
+	}
+	if checks[0].Name.Value != "price_positive" || checks[0].Column != price || checks[0].Expr.ToSQLString() != "price > 0" {
+		t.Errorf("unexpected check %+v", checks[0])
+	}
+	if checks[1].Name != nil || checks[1].Column != nil || checks[1].Expr.ToSQLString() != "price < 1000" {
+		t.Errorf("unexpected check %+v", checks[1])
+	}
+}
@@ -0,0 +1,175 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// `DECLARE Name [BINARY] [INSENSITIVE] [[NO] SCROLL] CURSOR [{WITH | WITHOUT} HOLD] FOR Query`
+type DeclareCursorStmt struct {
+	stmt
+	Declare     sqltoken.Pos
+	Name        *Ident
+	Binary      bool
+	Insensitive bool
+	Scroll      CursorScroll
+	Hold        CursorHold
+	Query       *QueryStmt
+}
+
+func (d *DeclareCursorStmt) Pos() sqltoken.Pos {
+	return d.Declare
+}
+
+func (d *DeclareCursorStmt) End() sqltoken.Pos {
+	return d.Query.End()
+}
+
+func (d *DeclareCursorStmt) ToSQLString() string {
+	return toSQLString(d)
+}
+
+func (d *DeclareCursorStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("DECLARE ")).Node(d.Name).Space()
+	sw.If(d.Binary, []byte("BINARY ")).If(d.Insensitive, []byte("INSENSITIVE "))
+	switch d.Scroll {
+	case Scroll:
+		sw.Bytes([]byte("SCROLL "))
+	case NoScroll:
+		sw.Bytes([]byte("NO SCROLL "))
+	}
+	sw.Bytes([]byte("CURSOR "))
+	switch d.Hold {
+	case WithHold:
+		sw.Bytes([]byte("WITH HOLD "))
+	case WithoutHold:
+		sw.Bytes([]byte("WITHOUT HOLD "))
+	}
+	return sw.Bytes([]byte("FOR ")).Node(d.Query).End()
+}
+
+// CursorScroll is the `[NO] SCROLL` option of DECLARE CURSOR.
+type CursorScroll int
+
+const (
+	CursorScrollDefault CursorScroll = iota
+	Scroll
+	NoScroll
+)
+
+// CursorHold is the `{WITH | WITHOUT} HOLD` option of DECLARE CURSOR.
+type CursorHold int
+
+const (
+	CursorHoldDefault CursorHold = iota
+	WithHold
+	WithoutHold
+)
+
+// `FETCH [Direction] [FROM] Name`
+type FetchStmt struct {
+	stmt
+	Fetch     sqltoken.Pos
+	Direction FetchDirection
+	Count     *LongValue // ABSOLUTE, RELATIVE, FORWARD, BACKWARD and FetchCount
+	All       bool       // FORWARD ALL or BACKWARD ALL
+	Name      *Ident
+}
+
+func (f *FetchStmt) Pos() sqltoken.Pos {
+	return f.Fetch
+}
+
+func (f *FetchStmt) End() sqltoken.Pos {
+	return f.Name.End()
+}
+
+func (f *FetchStmt) ToSQLString() string {
+	return toSQLString(f)
+}
+
+func (f *FetchStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("FETCH "))
+	switch f.Direction {
+	case FetchNext:
+		sw.Bytes([]byte("NEXT "))
+	case FetchPrior:
+		sw.Bytes([]byte("PRIOR "))
+	case FetchFirst:
+		sw.Bytes([]byte("FIRST "))
+	case FetchLast:
+		sw.Bytes([]byte("LAST "))
+	case FetchAbsolute:
+		sw.Bytes([]byte("ABSOLUTE ")).Node(f.Count).Space()
+	case FetchRelative:
+		sw.Bytes([]byte("RELATIVE ")).Node(f.Count).Space()
+	case FetchCount:
+		sw.Node(f.Count).Space()
+	case FetchAll:
+		sw.Bytes([]byte("ALL "))
+	case FetchForward, FetchBackward:
+		if f.Direction == FetchForward {
+			sw.Bytes([]byte("FORWARD "))
+		} else {
+			sw.Bytes([]byte("BACKWARD "))
+		}
+		if f.All {
+			sw.Bytes([]byte("ALL "))
+		} else if f.Count != nil {
+			sw.Node(f.Count).Space()
+		}
+	}
+	return sw.Bytes([]byte("FROM ")).Node(f.Name).End()
+}
+
+// FetchDirection is the direction clause of FETCH.
+type FetchDirection int
+
+const (
+	FetchDirectionDefault FetchDirection = iota
+	FetchNext
+	FetchPrior
+	FetchFirst
+	FetchLast
+	FetchAbsolute
+	FetchRelative
+	FetchCount
+	FetchAll
+	FetchForward
+	FetchBackward
+)
+
+// `CLOSE {Name | ALL}` (Name is nil for ALL)
+type CloseStmt struct {
+	stmt
+	Close sqltoken.Pos
+	Name  *Ident
+	To    sqltoken.Pos // end of ALL
+}
+
+func (c *CloseStmt) Pos() sqltoken.Pos {
+	return c.Close
+}
+
+func (c *CloseStmt) End() sqltoken.Pos {
+	if c.Name != nil {
+		return c.Name.End()
+	}
+	return c.To
+}
+
+func (c *CloseStmt) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CloseStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("CLOSE "))
+	if c.Name == nil {
+		return sw.Bytes([]byte("ALL")).End()
+	}
+	return sw.Node(c.Name).End()
+}
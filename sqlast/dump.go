@@ -0,0 +1,242 @@
+package sqlast
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Dump returns the tree of node as an indented text for debugging, in the
+// manner of go/ast.Print. Each line shows a field of the parent, the type and
+// the position of a node, e.g. for `SELECT a FROM t WHERE b = 1`:
+//
+//	*QueryStmt 1:1-1:28
+//	  Body: *SQLSelect 1:1-1:28
+//	    Projection[0]: *UnnamedSelectItem 1:8-1:9
+//	      Node: *Ident 1:8-1:9 "a"
+//	    FromClause[0]: *Table 1:15-1:16
+//	      Name: *ObjectName 1:15-1:16
+//	        Idents[0]: *Ident 1:15-1:16 "t"
+//	    WhereClause: *BinaryExpr 1:23-1:28
+//	      Left: *Ident 1:23-1:24 "b"
+//	      ...
+//
+// Nodes without children, such as identifiers and literals, are shown with
+// their SQL, and the other nodes with the fields which are neither nodes nor
+// zero values, e.g. `Distinct=true`. nil and empty fields are omitted, and
+// positions are omitted if they are unknown.
+func Dump(node Node) string {
+	d := &dumper{}
+	d.node("", 0, reflect.ValueOf(node))
+	return d.buf.String()
+}
+
+// Fdump writes Dump(node) to w.
+func Fdump(w io.Writer, node Node) error {
+	_, err := io.WriteString(w, Dump(node))
+	return err
+}
+
+type dumper struct {
+	buf bytes.Buffer
+}
+
+func (d *dumper) line(label string, depth int, format string, args ...interface{}) {
+	d.buf.WriteString(strings.Repeat("  ", depth))
+	if label != "" {
+		d.buf.WriteString(label + ": ")
+	}
+	fmt.Fprintf(&d.buf, format, args...)
+	d.buf.WriteByte('\n')
+}
+
+// node writes v, which is a node or a struct containing nodes, labeled by
+// the field of the parent.
+func (d *dumper) node(label string, depth int, v reflect.Value) {
+	if !v.IsValid() {
+		d.line(label, depth, "nil")
+		return
+	}
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			d.line(label, depth, "nil")
+			return
+		}
+		if v.Kind() == reflect.Ptr && v.Type().Implements(nodeType) {
+			break
+		}
+		v = v.Elem()
+	}
+
+	header := typeName(v.Type())
+	s := v
+	if v.Kind() == reflect.Ptr {
+		n := v.Interface().(Node)
+		if r := nodeRange(n); r != "" {
+			header += " " + r
+		}
+		s = v.Elem()
+		if !hasChildren(s) {
+			d.line(label, depth, "%s %q", header, nodeSQL(n))
+			return
+		}
+	}
+
+	var children []int
+	for i := 0; i < s.NumField(); i++ {
+		f := s.Type().Field(i)
+		if !dumped(s.Type(), f) {
+			continue
+		}
+		fv := s.Field(i)
+		if containsNodes(fv.Type()) {
+			children = append(children, i)
+			continue
+		}
+		if scalar, ok := scalarString(fv); ok {
+			header += fmt.Sprintf(" %s=%s", f.Name, scalar)
+		}
+	}
+	d.line(label, depth, "%s", header)
+
+	for _, i := range children {
+		name := s.Type().Field(i).Name
+		fv := s.Field(i)
+		if fv.Kind() == reflect.Slice {
+			for j := 0; j < fv.Len(); j++ {
+				d.node(fmt.Sprintf("%s[%d]", name, j), depth+1, fv.Index(j))
+			}
+			continue
+		}
+		if (fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface) && fv.IsNil() {
+			continue
+		}
+		d.node(name, depth+1, fv)
+	}
+}
+
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		return "*" + t.Elem().Name()
+	}
+	return t.Name()
+}
+
+// nodeRange returns the position of n as `line:col-line:col`, or "" if it is
+// unknown.
+func nodeRange(n Node) (r string) {
+	// Pos and End of nodes built by hand may dereference missing children
+	defer func() {
+		if recover() != nil {
+			r = ""
+		}
+	}()
+	from, to := n.Pos(), n.End()
+	if from.Line == 0 && to.Line == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d-%d:%d", from.Line, from.Col, to.Line, to.Col)
+}
+
+// dumped reports whether the field f of a struct of type t is dumped.
+func dumped(t reflect.Type, f reflect.StructField) bool {
+	if f.PkgPath != "" || f.Type == posType {
+		return false
+	}
+	// the definition is outside the tree
+	return t != customType || f.Name != "Definition"
+}
+
+// containsNodes reports whether values of t may hold nodes.
+func containsNodes(t reflect.Type) bool {
+	return containsNodesSeen(t, make(map[reflect.Type]bool))
+}
+
+func containsNodesSeen(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t.Implements(nodeType) {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return containsNodesSeen(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if dumped(t, f) && containsNodesSeen(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasChildren reports whether the struct v holds any node.
+func hasChildren(v reflect.Value) bool {
+	for i := 0; i < v.NumField(); i++ {
+		if dumped(v.Type(), v.Type().Field(i)) && holdsNode(v.Field(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+func holdsNode(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return false
+		}
+		if v.Type().Implements(nodeType) {
+			return true
+		}
+		return holdsNode(v.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if holdsNode(v.Index(i)) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		if reflect.PtrTo(v.Type()).Implements(nodeType) {
+			return true
+		}
+		return hasChildren(v)
+	}
+	return false
+}
+
+// scalarString returns v formatted if it isn't a zero value.
+func scalarString(v reflect.Value) (string, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		// a pointer to false, e.g. OrderByExpr.ASC, is meaningful
+		v = v.Elem()
+		if v.Kind() == reflect.Bool {
+			return fmt.Sprint(v.Bool()), true
+		}
+	}
+	switch v.Kind() {
+	case reflect.String:
+		if v.Len() == 0 {
+			return "", false
+		}
+		return fmt.Sprintf("%q", v.String()), true
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return "", false
+		}
+		return fmt.Sprintf("%q", v.Interface()), true
+	}
+	if v.IsZero() {
+		return "", false
+	}
+	return fmt.Sprint(v.Interface()), true
+}
@@ -0,0 +1,68 @@
+package sqlast
+
+import (
+	"testing"
+
+	"github.com/andreyvit/diff"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+func TestDump(t *testing.T) {
+	asc := false
+	cases := []struct {
+		name string
+		in   Node
+		out  string
+	}{
+		{
+			name: "select",
+			in: &QueryStmt{
+				Body: &SQLSelect{
+					Distinct: true,
+					Projection: []SQLSelectItem{
+						&UnnamedSelectItem{Node: NewIdentWithPos("a", sqltoken.NewPos(1, 17), sqltoken.NewPos(1, 18))},
+					},
+					FromClause: []TableReference{
+						&Table{Name: NewObjectName("t")},
+					},
+					WhereClause: &BinaryExpr{
+						Left:  NewIdent("a"),
+						Op:    &Operator{Type: Gt},
+						Right: NewLongValue(1),
+					},
+				},
+				OrderBy: []*OrderByExpr{
+					{Expr: NewIdent("a"), ASC: &asc},
+				},
+			},
+			out: `*QueryStmt
+  Body: *SQLSelect Distinct=true
+    Projection[0]: *UnnamedSelectItem 1:17-1:18
+      Node: *Ident 1:17-1:18 "a"
+    FromClause[0]: *Table
+      Name: *ObjectName
+        Idents[0]: *Ident "t"
+    WhereClause: *BinaryExpr
+      Left: *Ident "a"
+      Op: *Operator ">"
+      Right: *LongValue "1"
+  OrderBy[0]: *OrderByExpr ASC=false
+    Expr: *Ident "a"
+`,
+		},
+		{
+			name: "leaf",
+			in:   &Ident{Value: "My Table", Quoted: true},
+			out:  `*Ident "\"My Table\""` + "\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if act := Dump(c.in); act != c.out {
+				t.Errorf("must be same but diff: %s", diff.LineDiff(c.out, act))
+			}
+		})
+	}
+}
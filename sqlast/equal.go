@@ -0,0 +1,155 @@
+package sqlast
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+var (
+	nodeType = reflect.TypeOf((*Node)(nil)).Elem()
+	posType  = reflect.TypeOf(sqltoken.Pos{})
+	identTy  = reflect.TypeOf(Ident{})
+)
+
+// Difference is a pair of differing subtrees found by Diff.
+type Difference struct {
+	// Path is the path to the subtrees from the roots with field names and
+	// indices, e.g. "Body.WhereClause.Right" or "Body.Projection[1]".
+	// It is empty for the roots.
+	Path string
+	A, B Node // nil if the subtree is missing on the side
+}
+
+func (d *Difference) String() string {
+	return fmt.Sprintf("%s: %s => %s", d.Path, nodeSQL(d.A), nodeSQL(d.B))
+}
+
+func nodeSQL(n Node) string {
+	if n == nil {
+		return "<nil>"
+	}
+	return n.ToSQLString()
+}
+
+// Equal reports whether a and b are the same tree, ignoring positions and
+// the quote style of identifiers.
+func Equal(a, b Node) bool {
+	d := &differ{stopAtFirst: true}
+	d.node("", a, b)
+	return len(d.diffs) == 0
+}
+
+// Diff returns the smallest subtrees of a and b which differ, ignoring
+// positions and the quote style of identifiers as Equal does. If only a
+// field of a node which is not a node itself (e.g. Ident.Value) differs,
+// the node is reported.
+func Diff(a, b Node) []*Difference {
+	d := &differ{}
+	d.node("", a, b)
+	return d.diffs
+}
+
+type differ struct {
+	stopAtFirst bool
+	diffs       []*Difference
+}
+
+func (d *differ) done() bool {
+	return d.stopAtFirst && len(d.diffs) != 0
+}
+
+func (d *differ) report(path string, a, b Node) {
+	if n := len(d.diffs); n != 0 && d.diffs[n-1].Path == path {
+		return
+	}
+	d.diffs = append(d.diffs, &Difference{Path: path, A: a, B: b})
+}
+
+func (d *differ) node(path string, a, b Node) {
+	d.value(path, path, a, b, reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+// value compares x and y at path, which are values in the nodes a and b at nodePath.
+func (d *differ) value(path, nodePath string, a, b Node, x, y reflect.Value) {
+	if d.done() {
+		return
+	}
+	if !x.IsValid() || !y.IsValid() {
+		if x.IsValid() != y.IsValid() {
+			d.report(path, asNode(x), asNode(y))
+		}
+		return
+	}
+	if x.Type() != y.Type() {
+		d.report(path, asNode(x), asNode(y))
+		return
+	}
+
+	switch x.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if x.IsNil() || y.IsNil() {
+			if x.IsNil() != y.IsNil() {
+				if x.Type().Implements(nodeType) {
+					d.report(path, asNode(x), asNode(y))
+				} else {
+					d.report(nodePath, a, b)
+				}
+			}
+			return
+		}
+		if x.Type().Implements(nodeType) && x.Kind() == reflect.Ptr {
+			a, b, nodePath = x.Interface().(Node), y.Interface().(Node), path
+		}
+		d.value(path, nodePath, a, b, x.Elem(), y.Elem())
+	case reflect.Slice:
+		if x.Len() != y.Len() && !x.Type().Elem().Implements(nodeType) {
+			d.report(nodePath, a, b)
+			return
+		}
+		// elements only in either of lists of nodes are reported as missing subtrees
+		for i := 0; i < x.Len() || i < y.Len(); i++ {
+			var xi, yi reflect.Value
+			if i < x.Len() {
+				xi = x.Index(i)
+			}
+			if i < y.Len() {
+				yi = y.Index(i)
+			}
+			d.value(fmt.Sprintf("%s[%d]", path, i), nodePath, a, b, xi, yi)
+		}
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			f := x.Type().Field(i)
+			if f.PkgPath != "" || f.Type == posType {
+				continue
+			}
+			if x.Type() == customType && f.Name == "Definition" {
+				continue
+			}
+			if x.Type() == identTy && f.Name == "QuoteStyle" {
+				continue
+			}
+			p := f.Name
+			if path != "" {
+				p = path + "." + f.Name
+			}
+			d.value(p, nodePath, a, b, x.Field(i), y.Field(i))
+		}
+	default:
+		if x.Interface() != y.Interface() {
+			d.report(nodePath, a, b)
+		}
+	}
+}
+
+func asNode(v reflect.Value) Node {
+	if !v.IsValid() || !v.Type().Implements(nodeType) {
+		return nil
+	}
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil
+	}
+	return v.Interface().(Node)
+}
@@ -0,0 +1,69 @@
+package sqlast
+
+import (
+	"io"
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// OptimizerHints is an optimizer hint comment `/*+ Hints... */` right after
+// SELECT, INSERT, UPDATE or DELETE (e.g. MySQL and Oracle).
+type OptimizerHints struct {
+	From  sqltoken.Pos // first position of `/*+`
+	To    sqltoken.Pos // position just after `*/`
+	Hints []*OptimizerHint
+}
+
+func (o *OptimizerHints) Pos() sqltoken.Pos {
+	return o.From
+}
+
+func (o *OptimizerHints) End() sqltoken.Pos {
+	return o.To
+}
+
+func (o *OptimizerHints) ToSQLString() string {
+	return toSQLString(o)
+}
+
+func (o *OptimizerHints) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("/*+"))
+	for _, h := range o.Hints {
+		sw.Space().Node(h)
+	}
+	return sw.Bytes([]byte(" */")).End()
+}
+
+// OptimizerHint is a hint `Name[(Args...)]` in OptimizerHints. Args are
+// the arguments separated by commas without surrounding whitespace, e.g.
+// "t1 idx1" and "idx2" of `INDEX(t1 idx1, idx2)`, and nil if the hint has
+// no parentheses such as `ALL_ROWS`.
+type OptimizerHint struct {
+	From sqltoken.Pos
+	To   sqltoken.Pos
+	Name string
+	Args []string
+}
+
+func (o *OptimizerHint) Pos() sqltoken.Pos {
+	return o.From
+}
+
+func (o *OptimizerHint) End() sqltoken.Pos {
+	return o.To
+}
+
+func (o *OptimizerHint) ToSQLString() string {
+	return toSQLString(o)
+}
+
+func (o *OptimizerHint) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte(o.Name))
+	if o.Args != nil {
+		sw.LParen().Bytes([]byte(strings.Join(o.Args, ", "))).RParen()
+	}
+	return sw.End()
+}
@@ -0,0 +1,178 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// MySQL `SHOW [FULL] TABLES [{FROM | IN} Database] [LIKE Like | WHERE Where]`
+type ShowTablesStmt struct {
+	stmt
+	Show     sqltoken.Pos
+	Full     bool
+	To       sqltoken.Pos // end of TABLES
+	Database *Ident
+	Like     *SingleQuotedString
+	Where    Node
+}
+
+func (s *ShowTablesStmt) Pos() sqltoken.Pos {
+	return s.Show
+}
+
+func (s *ShowTablesStmt) End() sqltoken.Pos {
+	switch {
+	case s.Where != nil:
+		return s.Where.End()
+	case s.Like != nil:
+		return s.Like.End()
+	case s.Database != nil:
+		return s.Database.End()
+	}
+	return s.To
+}
+
+func (s *ShowTablesStmt) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *ShowTablesStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("SHOW ")).If(s.Full, []byte("FULL ")).Bytes([]byte("TABLES"))
+	if s.Database != nil {
+		sw.Bytes([]byte(" FROM ")).Node(s.Database)
+	}
+	writeShowFilter(sw, s.Like, s.Where)
+	return sw.End()
+}
+
+// MySQL `SHOW [FULL] {COLUMNS | FIELDS} {FROM | IN} Table [{FROM | IN} Database]
+// [LIKE Like | WHERE Where]`. FIELDS is written as COLUMNS.
+type ShowColumnsStmt struct {
+	stmt
+	Show     sqltoken.Pos
+	Full     bool
+	Table    *ObjectName
+	Database *Ident
+	Like     *SingleQuotedString
+	Where    Node
+}
+
+func (s *ShowColumnsStmt) Pos() sqltoken.Pos {
+	return s.Show
+}
+
+func (s *ShowColumnsStmt) End() sqltoken.Pos {
+	switch {
+	case s.Where != nil:
+		return s.Where.End()
+	case s.Like != nil:
+		return s.Like.End()
+	case s.Database != nil:
+		return s.Database.End()
+	}
+	return s.Table.End()
+}
+
+func (s *ShowColumnsStmt) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *ShowColumnsStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("SHOW ")).If(s.Full, []byte("FULL ")).Bytes([]byte("COLUMNS FROM ")).Node(s.Table)
+	if s.Database != nil {
+		sw.Bytes([]byte(" FROM ")).Node(s.Database)
+	}
+	writeShowFilter(sw, s.Like, s.Where)
+	return sw.End()
+}
+
+func writeShowFilter(sw *sqlWriter, like *SingleQuotedString, where Node) {
+	if like != nil {
+		sw.Bytes([]byte(" LIKE ")).Node(like)
+	}
+	if where != nil {
+		sw.Bytes([]byte(" WHERE ")).Node(where)
+	}
+}
+
+// MySQL `SHOW CREATE TABLE Table`
+type ShowCreateTableStmt struct {
+	stmt
+	Show  sqltoken.Pos
+	Table *ObjectName
+}
+
+func (s *ShowCreateTableStmt) Pos() sqltoken.Pos {
+	return s.Show
+}
+
+func (s *ShowCreateTableStmt) End() sqltoken.Pos {
+	return s.Table.End()
+}
+
+func (s *ShowCreateTableStmt) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *ShowCreateTableStmt) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("SHOW CREATE TABLE ")).Node(s.Table).End()
+}
+
+// MySQL `{DESCRIBE | DESC} Table [Column]`. DESC is written as DESCRIBE.
+type DescribeStmt struct {
+	stmt
+	Describe sqltoken.Pos
+	Table    *ObjectName
+	Column   *Ident
+}
+
+func (d *DescribeStmt) Pos() sqltoken.Pos {
+	return d.Describe
+}
+
+func (d *DescribeStmt) End() sqltoken.Pos {
+	if d.Column != nil {
+		return d.Column.End()
+	}
+	return d.Table.End()
+}
+
+func (d *DescribeStmt) ToSQLString() string {
+	return toSQLString(d)
+}
+
+func (d *DescribeStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("DESCRIBE ")).Node(d.Table)
+	if d.Column != nil {
+		sw.Space().Node(d.Column)
+	}
+	return sw.End()
+}
+
+// MySQL `USE Database`
+type UseStmt struct {
+	stmt
+	sessionStmt
+	Use      sqltoken.Pos
+	Database *Ident
+}
+
+func (u *UseStmt) Pos() sqltoken.Pos {
+	return u.Use
+}
+
+func (u *UseStmt) End() sqltoken.Pos {
+	return u.Database.End()
+}
+
+func (u *UseStmt) ToSQLString() string {
+	return toSQLString(u)
+}
+
+func (u *UseStmt) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("USE ")).Node(u.Database).End()
+}
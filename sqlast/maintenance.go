@@ -0,0 +1,234 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// MaintenanceOption is an option of VACUUM, ANALYZE and REINDEX,
+// `Name [Value]` such as `VERBOSE`, `PARALLEL 4` or `INDEX_CLEANUP OFF`.
+// Value is nil if omitted. The options are written in parentheses, or as
+// the keywords such as `FULL VERBOSE` in the old syntax of VACUUM and ANALYZE.
+type MaintenanceOption struct {
+	Name  *Ident
+	Value Node
+}
+
+func (m *MaintenanceOption) Pos() sqltoken.Pos {
+	return m.Name.Pos()
+}
+
+func (m *MaintenanceOption) End() sqltoken.Pos {
+	if m.Value != nil {
+		return m.Value.End()
+	}
+	return m.Name.End()
+}
+
+func (m *MaintenanceOption) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MaintenanceOption) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Node(m.Name)
+	if m.Value != nil {
+		sw.Space().Node(m.Value)
+	}
+	return sw.End()
+}
+
+// `Name [(Columns...)]` of VACUUM and ANALYZE
+type MaintenanceTable struct {
+	Name    *ObjectName
+	Columns []*Ident
+	RParen  sqltoken.Pos
+}
+
+func (m *MaintenanceTable) Pos() sqltoken.Pos {
+	return m.Name.Pos()
+}
+
+func (m *MaintenanceTable) End() sqltoken.Pos {
+	if len(m.Columns) != 0 {
+		return m.RParen
+	}
+	return m.Name.End()
+}
+
+func (m *MaintenanceTable) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MaintenanceTable) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Node(m.Name)
+	if len(m.Columns) != 0 {
+		sw.Space().LParen().Idents(m.Columns, []byte(", ")).RParen()
+	}
+	return sw.End()
+}
+
+// `VACUUM [(Options...) | Options...] [Tables...]`
+type VacuumStmt struct {
+	stmt
+	Vacuum, To    sqltoken.Pos // To is the end of VACUUM
+	Parenthesized bool
+	Options       []*MaintenanceOption
+	RParen        sqltoken.Pos // end of the parenthesized options
+	Tables        []*MaintenanceTable
+}
+
+func (v *VacuumStmt) Pos() sqltoken.Pos {
+	return v.Vacuum
+}
+
+func (v *VacuumStmt) End() sqltoken.Pos {
+	return maintenanceEnd(v.To, v.Parenthesized, v.Options, v.RParen, v.Tables)
+}
+
+func (v *VacuumStmt) ToSQLString() string {
+	return toSQLString(v)
+}
+
+func (v *VacuumStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("VACUUM"))
+	writeMaintenanceOptions(sw, v.Parenthesized, v.Options)
+	writeMaintenanceTables(sw, v.Tables)
+	return sw.End()
+}
+
+// `ANALYZE [(Options...) | VERBOSE] [Tables...]`
+type AnalyzeStmt struct {
+	stmt
+	Analyze, To   sqltoken.Pos // To is the end of ANALYZE
+	Parenthesized bool
+	Options       []*MaintenanceOption
+	RParen        sqltoken.Pos // end of the parenthesized options
+	Tables        []*MaintenanceTable
+}
+
+func (a *AnalyzeStmt) Pos() sqltoken.Pos {
+	return a.Analyze
+}
+
+func (a *AnalyzeStmt) End() sqltoken.Pos {
+	return maintenanceEnd(a.To, a.Parenthesized, a.Options, a.RParen, a.Tables)
+}
+
+func (a *AnalyzeStmt) ToSQLString() string {
+	return toSQLString(a)
+}
+
+func (a *AnalyzeStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("ANALYZE"))
+	writeMaintenanceOptions(sw, a.Parenthesized, a.Options)
+	writeMaintenanceTables(sw, a.Tables)
+	return sw.End()
+}
+
+func writeMaintenanceOptions(sw *sqlWriter, parenthesized bool, options []*MaintenanceOption) {
+	if !parenthesized {
+		for _, o := range options {
+			sw.Space().Node(o)
+		}
+		return
+	}
+	sw.Bytes([]byte(" ("))
+	for i, o := range options {
+		sw.JoinComma(i, o)
+	}
+	sw.RParen()
+}
+
+func writeMaintenanceTables(sw *sqlWriter, tables []*MaintenanceTable) {
+	for i, t := range tables {
+		if i == 0 {
+			sw.Space()
+		}
+		sw.JoinComma(i, t)
+	}
+}
+
+func maintenanceEnd(keyword sqltoken.Pos, parenthesized bool, options []*MaintenanceOption, rparen sqltoken.Pos, tables []*MaintenanceTable) sqltoken.Pos {
+	switch {
+	case len(tables) != 0:
+		return tables[len(tables)-1].End()
+	case parenthesized:
+		return rparen
+	case len(options) != 0:
+		return options[len(options)-1].End()
+	}
+	return keyword
+}
+
+type ReindexKind int
+
+const (
+	ReindexIndex ReindexKind = iota
+	ReindexTable
+	ReindexSchema
+	ReindexDatabase
+	ReindexSystem
+)
+
+func (r ReindexKind) ToSQLString() string {
+	switch r {
+	case ReindexTable:
+		return "TABLE"
+	case ReindexSchema:
+		return "SCHEMA"
+	case ReindexDatabase:
+		return "DATABASE"
+	case ReindexSystem:
+		return "SYSTEM"
+	}
+	return "INDEX"
+}
+
+// `REINDEX [(Options...)] { INDEX | TABLE | SCHEMA | DATABASE | SYSTEM } [CONCURRENTLY] [Name]`.
+// Name is nil only for DATABASE and SYSTEM.
+type ReindexStmt struct {
+	stmt
+	Reindex      sqltoken.Pos
+	Options      []*MaintenanceOption // always parenthesized
+	Kind         ReindexKind
+	KindTo       sqltoken.Pos // end of the kind keyword
+	Concurrently bool
+	ConcurrentTo sqltoken.Pos
+	Name         *ObjectName
+}
+
+func (r *ReindexStmt) Pos() sqltoken.Pos {
+	return r.Reindex
+}
+
+func (r *ReindexStmt) End() sqltoken.Pos {
+	if r.Name != nil {
+		return r.Name.End()
+	}
+	if r.Concurrently {
+		return r.ConcurrentTo
+	}
+	return r.KindTo
+}
+
+func (r *ReindexStmt) ToSQLString() string {
+	return toSQLString(r)
+}
+
+func (r *ReindexStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("REINDEX"))
+	if len(r.Options) != 0 {
+		writeMaintenanceOptions(sw, true, r.Options)
+	}
+	sw.Space().Bytes([]byte(r.Kind.ToSQLString())).If(r.Concurrently, []byte(" CONCURRENTLY"))
+	if r.Name != nil {
+		sw.Space().Node(r.Name)
+	}
+	return sw.End()
+}
@@ -0,0 +1,183 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// `MERGE INTO Target [[AS] Alias] USING Source ON On Clauses...`
+type MergeStmt struct {
+	stmt
+	Merge   sqltoken.Pos
+	Target  *ObjectName
+	Alias   *Ident
+	Source  TableFactor
+	On      Node
+	Clauses []*MergeWhenClause
+}
+
+func (m *MergeStmt) Pos() sqltoken.Pos {
+	return m.Merge
+}
+
+func (m *MergeStmt) End() sqltoken.Pos {
+	return m.Clauses[len(m.Clauses)-1].End()
+}
+
+func (m *MergeStmt) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MergeStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("MERGE INTO ")).Node(m.Target)
+	if m.Alias != nil {
+		sw.As().Node(m.Alias)
+	}
+	sw.Bytes([]byte(" USING ")).Node(m.Source).Bytes([]byte(" ON ")).Node(m.On)
+	for _, c := range m.Clauses {
+		sw.Space().Node(c)
+	}
+	return sw.End()
+}
+
+// `WHEN [NOT] MATCHED [AND Condition] THEN Action`
+type MergeWhenClause struct {
+	When       sqltoken.Pos
+	NotMatched bool
+	Condition  Node
+	Action     MergeAction
+}
+
+func (m *MergeWhenClause) Pos() sqltoken.Pos {
+	return m.When
+}
+
+func (m *MergeWhenClause) End() sqltoken.Pos {
+	return m.Action.End()
+}
+
+func (m *MergeWhenClause) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MergeWhenClause) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("WHEN ")).If(m.NotMatched, []byte("NOT ")).Bytes([]byte("MATCHED"))
+	if m.Condition != nil {
+		sw.Bytes([]byte(" AND ")).Node(m.Condition)
+	}
+	return sw.Bytes([]byte(" THEN ")).Node(m.Action).End()
+}
+
+//go:generate genmark -t MergeAction -e Node
+
+// `UPDATE SET Assignments...`
+type MergeUpdate struct {
+	mergeAction
+	Update      sqltoken.Pos
+	Assignments []*Assignment
+}
+
+func (m *MergeUpdate) Pos() sqltoken.Pos {
+	return m.Update
+}
+
+func (m *MergeUpdate) End() sqltoken.Pos {
+	return m.Assignments[len(m.Assignments)-1].End()
+}
+
+func (m *MergeUpdate) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MergeUpdate) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("UPDATE SET "))
+	for i, a := range m.Assignments {
+		sw.JoinComma(i, a)
+	}
+	return sw.End()
+}
+
+// `DELETE`
+type MergeDelete struct {
+	mergeAction
+	Delete, To sqltoken.Pos
+}
+
+func (m *MergeDelete) Pos() sqltoken.Pos {
+	return m.Delete
+}
+
+func (m *MergeDelete) End() sqltoken.Pos {
+	return m.To
+}
+
+func (m *MergeDelete) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MergeDelete) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("DELETE"))
+}
+
+// `DO NOTHING`
+type MergeDoNothing struct {
+	mergeAction
+	Do, To sqltoken.Pos
+}
+
+func (m *MergeDoNothing) Pos() sqltoken.Pos {
+	return m.Do
+}
+
+func (m *MergeDoNothing) End() sqltoken.Pos {
+	return m.To
+}
+
+func (m *MergeDoNothing) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MergeDoNothing) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("DO NOTHING"))
+}
+
+// `INSERT [(Columns...)] {VALUES Values | DEFAULT VALUES}`
+// Values is nil for DEFAULT VALUES.
+type MergeInsert struct {
+	mergeAction
+	Insert  sqltoken.Pos
+	Columns []*Ident
+	Values  *RowValueExpr
+	To      sqltoken.Pos // end of DEFAULT VALUES
+}
+
+func (m *MergeInsert) Pos() sqltoken.Pos {
+	return m.Insert
+}
+
+func (m *MergeInsert) End() sqltoken.Pos {
+	if m.Values != nil {
+		return m.Values.End()
+	}
+	return m.To
+}
+
+func (m *MergeInsert) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MergeInsert) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("INSERT "))
+	if len(m.Columns) != 0 {
+		sw.LParen().Idents(m.Columns, []byte(", ")).RParen().Space()
+	}
+	if m.Values == nil {
+		return sw.Bytes([]byte("DEFAULT VALUES")).End()
+	}
+	return sw.Bytes([]byte("VALUES ")).Node(m.Values).End()
+}
@@ -0,0 +1,1302 @@
+package sqlast
+
+// Code generated by genvisitor. DO NOT EDIT.
+
+// NodeVisitor is a Visitor calling the callback for the type of each node.
+// Every callback is optional. If a callback returns false, the children of the
+// node are not visited. Default is called for nodes without their callback.
+//
+//	Walk(&NodeVisitor{
+//		BinaryExpr: func(n *BinaryExpr) bool { ...; return true },
+//	}, stmt)
+type NodeVisitor struct {
+	Default                     func(node Node) bool
+	AddColumnTableAction        func(node *AddColumnTableAction) bool
+	AddConstraintTableAction    func(node *AddConstraintTableAction) bool
+	AliasSelectItem             func(node *AliasSelectItem) bool
+	AlterColumnTableAction      func(node *AlterColumnTableAction) bool
+	AlterIndexStmt              func(node *AlterIndexStmt) bool
+	AlterSchemaStmt             func(node *AlterSchemaStmt) bool
+	AlterSequenceStmt           func(node *AlterSequenceStmt) bool
+	AlterTableStmt              func(node *AlterTableStmt) bool
+	AlterViewStmt               func(node *AlterViewStmt) bool
+	AnalyzeStmt                 func(node *AnalyzeStmt) bool
+	Array                       func(node *Array) bool
+	ArrayConstructor            func(node *ArrayConstructor) bool
+	ArrayJoin                   func(node *ArrayJoin) bool
+	Assignment                  func(node *Assignment) bool
+	AutoIncrement               func(node *AutoIncrement) bool
+	Between                     func(node *Between) bool
+	BigInt                      func(node *BigInt) bool
+	BigSerial                   func(node *BigSerial) bool
+	Binary                      func(node *Binary) bool
+	BinaryExpr                  func(node *BinaryExpr) bool
+	BitStringLiteral            func(node *BitStringLiteral) bool
+	Blob                        func(node *Blob) bool
+	Boolean                     func(node *Boolean) bool
+	BooleanValue                func(node *BooleanValue) bool
+	Bytea                       func(node *Bytea) bool
+	CHEngine                    func(node *CHEngine) bool
+	CHLowCardinality            func(node *CHLowCardinality) bool
+	CHNullable                  func(node *CHNullable) bool
+	CHSample                    func(node *CHSample) bool
+	CHSettings                  func(node *CHSettings) bool
+	CHTableKey                  func(node *CHTableKey) bool
+	CTE                         func(node *CTE) bool
+	CallStmt                    func(node *CallStmt) bool
+	CaseExpr                    func(node *CaseExpr) bool
+	Cast                        func(node *Cast) bool
+	CharType                    func(node *CharType) bool
+	CheckColumnSpec             func(node *CheckColumnSpec) bool
+	CheckTableConstraint        func(node *CheckTableConstraint) bool
+	Clob                        func(node *Clob) bool
+	CloseStmt                   func(node *CloseStmt) bool
+	Collate                     func(node *Collate) bool
+	ColumnConstraint            func(node *ColumnConstraint) bool
+	ColumnDef                   func(node *ColumnDef) bool
+	Comment                     func(node *Comment) bool
+	CommentGroup                func(node *CommentGroup) bool
+	CompositeTypeDefinition     func(node *CompositeTypeDefinition) bool
+	CompoundIdent               func(node *CompoundIdent) bool
+	ConstructorSource           func(node *ConstructorSource) bool
+	CopyOption                  func(node *CopyOption) bool
+	CopyStmt                    func(node *CopyStmt) bool
+	CreateDatabaseStmt          func(node *CreateDatabaseStmt) bool
+	CreateExtensionStmt         func(node *CreateExtensionStmt) bool
+	CreateFunctionStmt          func(node *CreateFunctionStmt) bool
+	CreateIndexStmt             func(node *CreateIndexStmt) bool
+	CreateSchemaStmt            func(node *CreateSchemaStmt) bool
+	CreateSequenceStmt          func(node *CreateSequenceStmt) bool
+	CreateTableStmt             func(node *CreateTableStmt) bool
+	CreateTypeStmt              func(node *CreateTypeStmt) bool
+	CreateViewStmt              func(node *CreateViewStmt) bool
+	CrossJoin                   func(node *CrossJoin) bool
+	Cube                        func(node *Cube) bool
+	CurrentRow                  func(node *CurrentRow) bool
+	Custom                      func(node *Custom) bool
+	DatabaseOption              func(node *DatabaseOption) bool
+	Date                        func(node *Date) bool
+	DateTimeValue               func(node *DateTimeValue) bool
+	DateValue                   func(node *DateValue) bool
+	DeallocateStmt              func(node *DeallocateStmt) bool
+	Decimal                     func(node *Decimal) bool
+	DeclareCursorStmt           func(node *DeclareCursorStmt) bool
+	DeleteStmt                  func(node *DeleteStmt) bool
+	Derived                     func(node *Derived) bool
+	DescribeStmt                func(node *DescribeStmt) bool
+	DollarQuotedString          func(node *DollarQuotedString) bool
+	Double                      func(node *Double) bool
+	DoubleValue                 func(node *DoubleValue) bool
+	DropConstraintTableAction   func(node *DropConstraintTableAction) bool
+	DropDefaultColumnAction     func(node *DropDefaultColumnAction) bool
+	DropIndexStmt               func(node *DropIndexStmt) bool
+	DropTableStmt               func(node *DropTableStmt) bool
+	EnumTypeDefinition          func(node *EnumTypeDefinition) bool
+	EscapedStringLiteral        func(node *EscapedStringLiteral) bool
+	ExceptOperator              func(node *ExceptOperator) bool
+	ExecuteStmt                 func(node *ExecuteStmt) bool
+	Exists                      func(node *Exists) bool
+	ExplainStmt                 func(node *ExplainStmt) bool
+	Extract                     func(node *Extract) bool
+	Fetch                       func(node *Fetch) bool
+	FetchStmt                   func(node *FetchStmt) bool
+	File                        func(node *File) bool
+	Float                       func(node *Float) bool
+	Following                   func(node *Following) bool
+	Function                    func(node *Function) bool
+	FunctionArg                 func(node *FunctionArg) bool
+	FunctionAttribute           func(node *FunctionAttribute) bool
+	GeneratedColumnSpec         func(node *GeneratedColumnSpec) bool
+	GroupingSets                func(node *GroupingSets) bool
+	HexValue                    func(node *HexValue) bool
+	HiveArray                   func(node *HiveArray) bool
+	HiveMap                     func(node *HiveMap) bool
+	HiveStruct                  func(node *HiveStruct) bool
+	Ident                       func(node *Ident) bool
+	IdentityColumnSpec          func(node *IdentityColumnSpec) bool
+	InList                      func(node *InList) bool
+	InSubQuery                  func(node *InSubQuery) bool
+	IndexHint                   func(node *IndexHint) bool
+	InsertStmt                  func(node *InsertStmt) bool
+	Int                         func(node *Int) bool
+	IntersectOperator           func(node *IntersectOperator) bool
+	IntervalValue               func(node *IntervalValue) bool
+	IsNotNull                   func(node *IsNotNull) bool
+	IsNull                      func(node *IsNull) bool
+	JoinCondition               func(node *JoinCondition) bool
+	JoinType                    func(node *JoinType) bool
+	KeyValueOption              func(node *KeyValueOption) bool
+	LateralView                 func(node *LateralView) bool
+	LikeEscape                  func(node *LikeEscape) bool
+	LimitExpr                   func(node *LimitExpr) bool
+	LockingClause               func(node *LockingClause) bool
+	LongValue                   func(node *LongValue) bool
+	MaintenanceOption           func(node *MaintenanceOption) bool
+	MaintenanceTable            func(node *MaintenanceTable) bool
+	MergeDelete                 func(node *MergeDelete) bool
+	MergeDoNothing              func(node *MergeDoNothing) bool
+	MergeInsert                 func(node *MergeInsert) bool
+	MergeStmt                   func(node *MergeStmt) bool
+	MergeUpdate                 func(node *MergeUpdate) bool
+	MergeWhenClause             func(node *MergeWhenClause) bool
+	MyAutoIncrement             func(node *MyAutoIncrement) bool
+	MyCharset                   func(node *MyCharset) bool
+	MyCollate                   func(node *MyCollate) bool
+	MyColumnComment             func(node *MyColumnComment) bool
+	MyEngine                    func(node *MyEngine) bool
+	MyOnUpdate                  func(node *MyOnUpdate) bool
+	MyTableComment              func(node *MyTableComment) bool
+	NamedArg                    func(node *NamedArg) bool
+	NamedColumnsJoin            func(node *NamedColumnsJoin) bool
+	NamedWindow                 func(node *NamedWindow) bool
+	NationalStringLiteral       func(node *NationalStringLiteral) bool
+	NaturalJoin                 func(node *NaturalJoin) bool
+	Nested                      func(node *Nested) bool
+	NotNullColumnSpec           func(node *NotNullColumnSpec) bool
+	NullValue                   func(node *NullValue) bool
+	NumericValue                func(node *NumericValue) bool
+	ObjectName                  func(node *ObjectName) bool
+	OnCommit                    func(node *OnCommit) bool
+	Operator                    func(node *Operator) bool
+	OptimizerHint               func(node *OptimizerHint) bool
+	OptimizerHints              func(node *OptimizerHints) bool
+	OrderByExpr                 func(node *OrderByExpr) bool
+	OuterJoinColumn             func(node *OuterJoinColumn) bool
+	Overlay                     func(node *Overlay) bool
+	OwnerToAction               func(node *OwnerToAction) bool
+	PGAlterDataTypeColumnAction func(node *PGAlterDataTypeColumnAction) bool
+	PGDropNotNullColumnAction   func(node *PGDropNotNullColumnAction) bool
+	PGSetNotNullColumnAction    func(node *PGSetNotNullColumnAction) bool
+	PartitionBoundDefault       func(node *PartitionBoundDefault) bool
+	PartitionBoundHash          func(node *PartitionBoundHash) bool
+	PartitionBoundIn            func(node *PartitionBoundIn) bool
+	PartitionBoundRange         func(node *PartitionBoundRange) bool
+	PartitionBy                 func(node *PartitionBy) bool
+	PartitionColumn             func(node *PartitionColumn) bool
+	PartitionedJoinTable        func(node *PartitionedJoinTable) bool
+	Placeholder                 func(node *Placeholder) bool
+	Position                    func(node *Position) bool
+	Preceding                   func(node *Preceding) bool
+	PrepareStmt                 func(node *PrepareStmt) bool
+	QualifiedJoin               func(node *QualifiedJoin) bool
+	QualifiedWildcard           func(node *QualifiedWildcard) bool
+	QualifiedWildcardSelectItem func(node *QualifiedWildcardSelectItem) bool
+	QueryExpr                   func(node *QueryExpr) bool
+	QueryStmt                   func(node *QueryStmt) bool
+	RangeTypeDefinition         func(node *RangeTypeDefinition) bool
+	Real                        func(node *Real) bool
+	ReferenceKeyExpr            func(node *ReferenceKeyExpr) bool
+	ReferencesColumnSpec        func(node *ReferencesColumnSpec) bool
+	ReferentialTableConstraint  func(node *ReferentialTableConstraint) bool
+	Regclass                    func(node *Regclass) bool
+	ReindexStmt                 func(node *ReindexStmt) bool
+	RemoveColumnTableAction     func(node *RemoveColumnTableAction) bool
+	RenameToAction              func(node *RenameToAction) bool
+	ResetStmt                   func(node *ResetStmt) bool
+	Rollup                      func(node *Rollup) bool
+	RowValueExpr                func(node *RowValueExpr) bool
+	SQLRowExpr                  func(node *SQLRowExpr) bool
+	SQLSelect                   func(node *SQLSelect) bool
+	SQLValues                   func(node *SQLValues) bool
+	SelectExpr                  func(node *SelectExpr) bool
+	SequenceCache               func(node *SequenceCache) bool
+	SequenceCycle               func(node *SequenceCycle) bool
+	SequenceDataType            func(node *SequenceDataType) bool
+	SequenceIncrement           func(node *SequenceIncrement) bool
+	SequenceMaxValue            func(node *SequenceMaxValue) bool
+	SequenceMinValue            func(node *SequenceMinValue) bool
+	SequenceOwnedBy             func(node *SequenceOwnedBy) bool
+	SequenceRestart             func(node *SequenceRestart) bool
+	SequenceStart               func(node *SequenceStart) bool
+	Serial                      func(node *Serial) bool
+	SetDefaultColumnAction      func(node *SetDefaultColumnAction) bool
+	SetOperationExpr            func(node *SetOperationExpr) bool
+	SetSchemaAction             func(node *SetSchemaAction) bool
+	SetStmt                     func(node *SetStmt) bool
+	SetTablespaceAction         func(node *SetTablespaceAction) bool
+	SetTimeZoneStmt             func(node *SetTimeZoneStmt) bool
+	ShowColumnsStmt             func(node *ShowColumnsStmt) bool
+	ShowCreateTableStmt         func(node *ShowCreateTableStmt) bool
+	ShowStmt                    func(node *ShowStmt) bool
+	ShowTablesStmt              func(node *ShowTablesStmt) bool
+	SingleQuotedString          func(node *SingleQuotedString) bool
+	SmallInt                    func(node *SmallInt) bool
+	SmallSerial                 func(node *SmallSerial) bool
+	String                      func(node *String) bool
+	SubQuery                    func(node *SubQuery) bool
+	SubQuerySource              func(node *SubQuerySource) bool
+	Subscript                   func(node *Subscript) bool
+	Substring                   func(node *Substring) bool
+	Table                       func(node *Table) bool
+	TableConstraint             func(node *TableConstraint) bool
+	TableFunction               func(node *TableFunction) bool
+	TableJoinElement            func(node *TableJoinElement) bool
+	TableSample                 func(node *TableSample) bool
+	Tablespace                  func(node *Tablespace) bool
+	Text                        func(node *Text) bool
+	Time                        func(node *Time) bool
+	TimeValue                   func(node *TimeValue) bool
+	Timestamp                   func(node *Timestamp) bool
+	TimestampValue              func(node *TimestampValue) bool
+	Top                         func(node *Top) bool
+	Trim                        func(node *Trim) bool
+	TruncateStmt                func(node *TruncateStmt) bool
+	TypeOption                  func(node *TypeOption) bool
+	UUID                        func(node *UUID) bool
+	UnaryExpr                   func(node *UnaryExpr) bool
+	UnboundedFollowing          func(node *UnboundedFollowing) bool
+	UnboundedPreceding          func(node *UnboundedPreceding) bool
+	UnicodeStringLiteral        func(node *UnicodeStringLiteral) bool
+	UnionOperator               func(node *UnionOperator) bool
+	UniqueColumnSpec            func(node *UniqueColumnSpec) bool
+	UniqueTableConstraint       func(node *UniqueTableConstraint) bool
+	UnloadStmt                  func(node *UnloadStmt) bool
+	UnnamedSelectItem           func(node *UnnamedSelectItem) bool
+	Unnest                      func(node *Unnest) bool
+	UnsignedLongValue           func(node *UnsignedLongValue) bool
+	UpdateStmt                  func(node *UpdateStmt) bool
+	UseStmt                     func(node *UseStmt) bool
+	VacuumStmt                  func(node *VacuumStmt) bool
+	Varbinary                   func(node *Varbinary) bool
+	VarcharType                 func(node *VarcharType) bool
+	Wildcard                    func(node *Wildcard) bool
+	WildcardSelectItem          func(node *WildcardSelectItem) bool
+	WindowFrame                 func(node *WindowFrame) bool
+	WindowFrameUnit             func(node *WindowFrameUnit) bool
+	WindowSpec                  func(node *WindowSpec) bool
+	WithOptions                 func(node *WithOptions) bool
+}
+
+func (v *NodeVisitor) Visit(node Node) Visitor {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *AddColumnTableAction:
+		if v.AddColumnTableAction != nil {
+			return v.descend(v.AddColumnTableAction(n))
+		}
+	case *AddConstraintTableAction:
+		if v.AddConstraintTableAction != nil {
+			return v.descend(v.AddConstraintTableAction(n))
+		}
+	case *AliasSelectItem:
+		if v.AliasSelectItem != nil {
+			return v.descend(v.AliasSelectItem(n))
+		}
+	case *AlterColumnTableAction:
+		if v.AlterColumnTableAction != nil {
+			return v.descend(v.AlterColumnTableAction(n))
+		}
+	case *AlterIndexStmt:
+		if v.AlterIndexStmt != nil {
+			return v.descend(v.AlterIndexStmt(n))
+		}
+	case *AlterSchemaStmt:
+		if v.AlterSchemaStmt != nil {
+			return v.descend(v.AlterSchemaStmt(n))
+		}
+	case *AlterSequenceStmt:
+		if v.AlterSequenceStmt != nil {
+			return v.descend(v.AlterSequenceStmt(n))
+		}
+	case *AlterTableStmt:
+		if v.AlterTableStmt != nil {
+			return v.descend(v.AlterTableStmt(n))
+		}
+	case *AlterViewStmt:
+		if v.AlterViewStmt != nil {
+			return v.descend(v.AlterViewStmt(n))
+		}
+	case *AnalyzeStmt:
+		if v.AnalyzeStmt != nil {
+			return v.descend(v.AnalyzeStmt(n))
+		}
+	case *Array:
+		if v.Array != nil {
+			return v.descend(v.Array(n))
+		}
+	case *ArrayConstructor:
+		if v.ArrayConstructor != nil {
+			return v.descend(v.ArrayConstructor(n))
+		}
+	case *ArrayJoin:
+		if v.ArrayJoin != nil {
+			return v.descend(v.ArrayJoin(n))
+		}
+	case *Assignment:
+		if v.Assignment != nil {
+			return v.descend(v.Assignment(n))
+		}
+	case *AutoIncrement:
+		if v.AutoIncrement != nil {
+			return v.descend(v.AutoIncrement(n))
+		}
+	case *Between:
+		if v.Between != nil {
+			return v.descend(v.Between(n))
+		}
+	case *BigInt:
+		if v.BigInt != nil {
+			return v.descend(v.BigInt(n))
+		}
+	case *BigSerial:
+		if v.BigSerial != nil {
+			return v.descend(v.BigSerial(n))
+		}
+	case *Binary:
+		if v.Binary != nil {
+			return v.descend(v.Binary(n))
+		}
+	case *BinaryExpr:
+		if v.BinaryExpr != nil {
+			return v.descend(v.BinaryExpr(n))
+		}
+	case *BitStringLiteral:
+		if v.BitStringLiteral != nil {
+			return v.descend(v.BitStringLiteral(n))
+		}
+	case *Blob:
+		if v.Blob != nil {
+			return v.descend(v.Blob(n))
+		}
+	case *Boolean:
+		if v.Boolean != nil {
+			return v.descend(v.Boolean(n))
+		}
+	case *BooleanValue:
+		if v.BooleanValue != nil {
+			return v.descend(v.BooleanValue(n))
+		}
+	case *Bytea:
+		if v.Bytea != nil {
+			return v.descend(v.Bytea(n))
+		}
+	case *CHEngine:
+		if v.CHEngine != nil {
+			return v.descend(v.CHEngine(n))
+		}
+	case *CHLowCardinality:
+		if v.CHLowCardinality != nil {
+			return v.descend(v.CHLowCardinality(n))
+		}
+	case *CHNullable:
+		if v.CHNullable != nil {
+			return v.descend(v.CHNullable(n))
+		}
+	case *CHSample:
+		if v.CHSample != nil {
+			return v.descend(v.CHSample(n))
+		}
+	case *CHSettings:
+		if v.CHSettings != nil {
+			return v.descend(v.CHSettings(n))
+		}
+	case *CHTableKey:
+		if v.CHTableKey != nil {
+			return v.descend(v.CHTableKey(n))
+		}
+	case *CTE:
+		if v.CTE != nil {
+			return v.descend(v.CTE(n))
+		}
+	case *CallStmt:
+		if v.CallStmt != nil {
+			return v.descend(v.CallStmt(n))
+		}
+	case *CaseExpr:
+		if v.CaseExpr != nil {
+			return v.descend(v.CaseExpr(n))
+		}
+	case *Cast:
+		if v.Cast != nil {
+			return v.descend(v.Cast(n))
+		}
+	case *CharType:
+		if v.CharType != nil {
+			return v.descend(v.CharType(n))
+		}
+	case *CheckColumnSpec:
+		if v.CheckColumnSpec != nil {
+			return v.descend(v.CheckColumnSpec(n))
+		}
+	case *CheckTableConstraint:
+		if v.CheckTableConstraint != nil {
+			return v.descend(v.CheckTableConstraint(n))
+		}
+	case *Clob:
+		if v.Clob != nil {
+			return v.descend(v.Clob(n))
+		}
+	case *CloseStmt:
+		if v.CloseStmt != nil {
+			return v.descend(v.CloseStmt(n))
+		}
+	case *Collate:
+		if v.Collate != nil {
+			return v.descend(v.Collate(n))
+		}
+	case *ColumnConstraint:
+		if v.ColumnConstraint != nil {
+			return v.descend(v.ColumnConstraint(n))
+		}
+	case *ColumnDef:
+		if v.ColumnDef != nil {
+			return v.descend(v.ColumnDef(n))
+		}
+	case *Comment:
+		if v.Comment != nil {
+			return v.descend(v.Comment(n))
+		}
+	case *CommentGroup:
+		if v.CommentGroup != nil {
+			return v.descend(v.CommentGroup(n))
+		}
+	case *CompositeTypeDefinition:
+		if v.CompositeTypeDefinition != nil {
+			return v.descend(v.CompositeTypeDefinition(n))
+		}
+	case *CompoundIdent:
+		if v.CompoundIdent != nil {
+			return v.descend(v.CompoundIdent(n))
+		}
+	case *ConstructorSource:
+		if v.ConstructorSource != nil {
+			return v.descend(v.ConstructorSource(n))
+		}
+	case *CopyOption:
+		if v.CopyOption != nil {
+			return v.descend(v.CopyOption(n))
+		}
+	case *CopyStmt:
+		if v.CopyStmt != nil {
+			return v.descend(v.CopyStmt(n))
+		}
+	case *CreateDatabaseStmt:
+		if v.CreateDatabaseStmt != nil {
+			return v.descend(v.CreateDatabaseStmt(n))
+		}
+	case *CreateExtensionStmt:
+		if v.CreateExtensionStmt != nil {
+			return v.descend(v.CreateExtensionStmt(n))
+		}
+	case *CreateFunctionStmt:
+		if v.CreateFunctionStmt != nil {
+			return v.descend(v.CreateFunctionStmt(n))
+		}
+	case *CreateIndexStmt:
+		if v.CreateIndexStmt != nil {
+			return v.descend(v.CreateIndexStmt(n))
+		}
+	case *CreateSchemaStmt:
+		if v.CreateSchemaStmt != nil {
+			return v.descend(v.CreateSchemaStmt(n))
+		}
+	case *CreateSequenceStmt:
+		if v.CreateSequenceStmt != nil {
+			return v.descend(v.CreateSequenceStmt(n))
+		}
+	case *CreateTableStmt:
+		if v.CreateTableStmt != nil {
+			return v.descend(v.CreateTableStmt(n))
+		}
+	case *CreateTypeStmt:
+		if v.CreateTypeStmt != nil {
+			return v.descend(v.CreateTypeStmt(n))
+		}
+	case *CreateViewStmt:
+		if v.CreateViewStmt != nil {
+			return v.descend(v.CreateViewStmt(n))
+		}
+	case *CrossJoin:
+		if v.CrossJoin != nil {
+			return v.descend(v.CrossJoin(n))
+		}
+	case *Cube:
+		if v.Cube != nil {
+			return v.descend(v.Cube(n))
+		}
+	case *CurrentRow:
+		if v.CurrentRow != nil {
+			return v.descend(v.CurrentRow(n))
+		}
+	case *Custom:
+		if v.Custom != nil {
+			return v.descend(v.Custom(n))
+		}
+	case *DatabaseOption:
+		if v.DatabaseOption != nil {
+			return v.descend(v.DatabaseOption(n))
+		}
+	case *Date:
+		if v.Date != nil {
+			return v.descend(v.Date(n))
+		}
+	case *DateTimeValue:
+		if v.DateTimeValue != nil {
+			return v.descend(v.DateTimeValue(n))
+		}
+	case *DateValue:
+		if v.DateValue != nil {
+			return v.descend(v.DateValue(n))
+		}
+	case *DeallocateStmt:
+		if v.DeallocateStmt != nil {
+			return v.descend(v.DeallocateStmt(n))
+		}
+	case *Decimal:
+		if v.Decimal != nil {
+			return v.descend(v.Decimal(n))
+		}
+	case *DeclareCursorStmt:
+		if v.DeclareCursorStmt != nil {
+			return v.descend(v.DeclareCursorStmt(n))
+		}
+	case *DeleteStmt:
+		if v.DeleteStmt != nil {
+			return v.descend(v.DeleteStmt(n))
+		}
+	case *Derived:
+		if v.Derived != nil {
+			return v.descend(v.Derived(n))
+		}
+	case *DescribeStmt:
+		if v.DescribeStmt != nil {
+			return v.descend(v.DescribeStmt(n))
+		}
+	case *DollarQuotedString:
+		if v.DollarQuotedString != nil {
+			return v.descend(v.DollarQuotedString(n))
+		}
+	case *Double:
+		if v.Double != nil {
+			return v.descend(v.Double(n))
+		}
+	case *DoubleValue:
+		if v.DoubleValue != nil {
+			return v.descend(v.DoubleValue(n))
+		}
+	case *DropConstraintTableAction:
+		if v.DropConstraintTableAction != nil {
+			return v.descend(v.DropConstraintTableAction(n))
+		}
+	case *DropDefaultColumnAction:
+		if v.DropDefaultColumnAction != nil {
+			return v.descend(v.DropDefaultColumnAction(n))
+		}
+	case *DropIndexStmt:
+		if v.DropIndexStmt != nil {
+			return v.descend(v.DropIndexStmt(n))
+		}
+	case *DropTableStmt:
+		if v.DropTableStmt != nil {
+			return v.descend(v.DropTableStmt(n))
+		}
+	case *EnumTypeDefinition:
+		if v.EnumTypeDefinition != nil {
+			return v.descend(v.EnumTypeDefinition(n))
+		}
+	case *EscapedStringLiteral:
+		if v.EscapedStringLiteral != nil {
+			return v.descend(v.EscapedStringLiteral(n))
+		}
+	case *ExceptOperator:
+		if v.ExceptOperator != nil {
+			return v.descend(v.ExceptOperator(n))
+		}
+	case *ExecuteStmt:
+		if v.ExecuteStmt != nil {
+			return v.descend(v.ExecuteStmt(n))
+		}
+	case *Exists:
+		if v.Exists != nil {
+			return v.descend(v.Exists(n))
+		}
+	case *ExplainStmt:
+		if v.ExplainStmt != nil {
+			return v.descend(v.ExplainStmt(n))
+		}
+	case *Extract:
+		if v.Extract != nil {
+			return v.descend(v.Extract(n))
+		}
+	case *Fetch:
+		if v.Fetch != nil {
+			return v.descend(v.Fetch(n))
+		}
+	case *FetchStmt:
+		if v.FetchStmt != nil {
+			return v.descend(v.FetchStmt(n))
+		}
+	case *File:
+		if v.File != nil {
+			return v.descend(v.File(n))
+		}
+	case *Float:
+		if v.Float != nil {
+			return v.descend(v.Float(n))
+		}
+	case *Following:
+		if v.Following != nil {
+			return v.descend(v.Following(n))
+		}
+	case *Function:
+		if v.Function != nil {
+			return v.descend(v.Function(n))
+		}
+	case *FunctionArg:
+		if v.FunctionArg != nil {
+			return v.descend(v.FunctionArg(n))
+		}
+	case *FunctionAttribute:
+		if v.FunctionAttribute != nil {
+			return v.descend(v.FunctionAttribute(n))
+		}
+	case *GeneratedColumnSpec:
+		if v.GeneratedColumnSpec != nil {
+			return v.descend(v.GeneratedColumnSpec(n))
+		}
+	case *GroupingSets:
+		if v.GroupingSets != nil {
+			return v.descend(v.GroupingSets(n))
+		}
+	case *HexValue:
+		if v.HexValue != nil {
+			return v.descend(v.HexValue(n))
+		}
+	case *HiveArray:
+		if v.HiveArray != nil {
+			return v.descend(v.HiveArray(n))
+		}
+	case *HiveMap:
+		if v.HiveMap != nil {
+			return v.descend(v.HiveMap(n))
+		}
+	case *HiveStruct:
+		if v.HiveStruct != nil {
+			return v.descend(v.HiveStruct(n))
+		}
+	case *Ident:
+		if v.Ident != nil {
+			return v.descend(v.Ident(n))
+		}
+	case *IdentityColumnSpec:
+		if v.IdentityColumnSpec != nil {
+			return v.descend(v.IdentityColumnSpec(n))
+		}
+	case *InList:
+		if v.InList != nil {
+			return v.descend(v.InList(n))
+		}
+	case *InSubQuery:
+		if v.InSubQuery != nil {
+			return v.descend(v.InSubQuery(n))
+		}
+	case *IndexHint:
+		if v.IndexHint != nil {
+			return v.descend(v.IndexHint(n))
+		}
+	case *InsertStmt:
+		if v.InsertStmt != nil {
+			return v.descend(v.InsertStmt(n))
+		}
+	case *Int:
+		if v.Int != nil {
+			return v.descend(v.Int(n))
+		}
+	case *IntersectOperator:
+		if v.IntersectOperator != nil {
+			return v.descend(v.IntersectOperator(n))
+		}
+	case *IntervalValue:
+		if v.IntervalValue != nil {
+			return v.descend(v.IntervalValue(n))
+		}
+	case *IsNotNull:
+		if v.IsNotNull != nil {
+			return v.descend(v.IsNotNull(n))
+		}
+	case *IsNull:
+		if v.IsNull != nil {
+			return v.descend(v.IsNull(n))
+		}
+	case *JoinCondition:
+		if v.JoinCondition != nil {
+			return v.descend(v.JoinCondition(n))
+		}
+	case *JoinType:
+		if v.JoinType != nil {
+			return v.descend(v.JoinType(n))
+		}
+	case *KeyValueOption:
+		if v.KeyValueOption != nil {
+			return v.descend(v.KeyValueOption(n))
+		}
+	case *LateralView:
+		if v.LateralView != nil {
+			return v.descend(v.LateralView(n))
+		}
+	case *LikeEscape:
+		if v.LikeEscape != nil {
+			return v.descend(v.LikeEscape(n))
+		}
+	case *LimitExpr:
+		if v.LimitExpr != nil {
+			return v.descend(v.LimitExpr(n))
+		}
+	case *LockingClause:
+		if v.LockingClause != nil {
+			return v.descend(v.LockingClause(n))
+		}
+	case *LongValue:
+		if v.LongValue != nil {
+			return v.descend(v.LongValue(n))
+		}
+	case *MaintenanceOption:
+		if v.MaintenanceOption != nil {
+			return v.descend(v.MaintenanceOption(n))
+		}
+	case *MaintenanceTable:
+		if v.MaintenanceTable != nil {
+			return v.descend(v.MaintenanceTable(n))
+		}
+	case *MergeDelete:
+		if v.MergeDelete != nil {
+			return v.descend(v.MergeDelete(n))
+		}
+	case *MergeDoNothing:
+		if v.MergeDoNothing != nil {
+			return v.descend(v.MergeDoNothing(n))
+		}
+	case *MergeInsert:
+		if v.MergeInsert != nil {
+			return v.descend(v.MergeInsert(n))
+		}
+	case *MergeStmt:
+		if v.MergeStmt != nil {
+			return v.descend(v.MergeStmt(n))
+		}
+	case *MergeUpdate:
+		if v.MergeUpdate != nil {
+			return v.descend(v.MergeUpdate(n))
+		}
+	case *MergeWhenClause:
+		if v.MergeWhenClause != nil {
+			return v.descend(v.MergeWhenClause(n))
+		}
+	case *MyAutoIncrement:
+		if v.MyAutoIncrement != nil {
+			return v.descend(v.MyAutoIncrement(n))
+		}
+	case *MyCharset:
+		if v.MyCharset != nil {
+			return v.descend(v.MyCharset(n))
+		}
+	case *MyCollate:
+		if v.MyCollate != nil {
+			return v.descend(v.MyCollate(n))
+		}
+	case *MyColumnComment:
+		if v.MyColumnComment != nil {
+			return v.descend(v.MyColumnComment(n))
+		}
+	case *MyEngine:
+		if v.MyEngine != nil {
+			return v.descend(v.MyEngine(n))
+		}
+	case *MyOnUpdate:
+		if v.MyOnUpdate != nil {
+			return v.descend(v.MyOnUpdate(n))
+		}
+	case *MyTableComment:
+		if v.MyTableComment != nil {
+			return v.descend(v.MyTableComment(n))
+		}
+	case *NamedArg:
+		if v.NamedArg != nil {
+			return v.descend(v.NamedArg(n))
+		}
+	case *NamedColumnsJoin:
+		if v.NamedColumnsJoin != nil {
+			return v.descend(v.NamedColumnsJoin(n))
+		}
+	case *NamedWindow:
+		if v.NamedWindow != nil {
+			return v.descend(v.NamedWindow(n))
+		}
+	case *NationalStringLiteral:
+		if v.NationalStringLiteral != nil {
+			return v.descend(v.NationalStringLiteral(n))
+		}
+	case *NaturalJoin:
+		if v.NaturalJoin != nil {
+			return v.descend(v.NaturalJoin(n))
+		}
+	case *Nested:
+		if v.Nested != nil {
+			return v.descend(v.Nested(n))
+		}
+	case *NotNullColumnSpec:
+		if v.NotNullColumnSpec != nil {
+			return v.descend(v.NotNullColumnSpec(n))
+		}
+	case *NullValue:
+		if v.NullValue != nil {
+			return v.descend(v.NullValue(n))
+		}
+	case *NumericValue:
+		if v.NumericValue != nil {
+			return v.descend(v.NumericValue(n))
+		}
+	case *ObjectName:
+		if v.ObjectName != nil {
+			return v.descend(v.ObjectName(n))
+		}
+	case *OnCommit:
+		if v.OnCommit != nil {
+			return v.descend(v.OnCommit(n))
+		}
+	case *Operator:
+		if v.Operator != nil {
+			return v.descend(v.Operator(n))
+		}
+	case *OptimizerHint:
+		if v.OptimizerHint != nil {
+			return v.descend(v.OptimizerHint(n))
+		}
+	case *OptimizerHints:
+		if v.OptimizerHints != nil {
+			return v.descend(v.OptimizerHints(n))
+		}
+	case *OrderByExpr:
+		if v.OrderByExpr != nil {
+			return v.descend(v.OrderByExpr(n))
+		}
+	case *OuterJoinColumn:
+		if v.OuterJoinColumn != nil {
+			return v.descend(v.OuterJoinColumn(n))
+		}
+	case *Overlay:
+		if v.Overlay != nil {
+			return v.descend(v.Overlay(n))
+		}
+	case *OwnerToAction:
+		if v.OwnerToAction != nil {
+			return v.descend(v.OwnerToAction(n))
+		}
+	case *PGAlterDataTypeColumnAction:
+		if v.PGAlterDataTypeColumnAction != nil {
+			return v.descend(v.PGAlterDataTypeColumnAction(n))
+		}
+	case *PGDropNotNullColumnAction:
+		if v.PGDropNotNullColumnAction != nil {
+			return v.descend(v.PGDropNotNullColumnAction(n))
+		}
+	case *PGSetNotNullColumnAction:
+		if v.PGSetNotNullColumnAction != nil {
+			return v.descend(v.PGSetNotNullColumnAction(n))
+		}
+	case *PartitionBoundDefault:
+		if v.PartitionBoundDefault != nil {
+			return v.descend(v.PartitionBoundDefault(n))
+		}
+	case *PartitionBoundHash:
+		if v.PartitionBoundHash != nil {
+			return v.descend(v.PartitionBoundHash(n))
+		}
+	case *PartitionBoundIn:
+		if v.PartitionBoundIn != nil {
+			return v.descend(v.PartitionBoundIn(n))
+		}
+	case *PartitionBoundRange:
+		if v.PartitionBoundRange != nil {
+			return v.descend(v.PartitionBoundRange(n))
+		}
+	case *PartitionBy:
+		if v.PartitionBy != nil {
+			return v.descend(v.PartitionBy(n))
+		}
+	case *PartitionColumn:
+		if v.PartitionColumn != nil {
+			return v.descend(v.PartitionColumn(n))
+		}
+	case *PartitionedJoinTable:
+		if v.PartitionedJoinTable != nil {
+			return v.descend(v.PartitionedJoinTable(n))
+		}
+	case *Placeholder:
+		if v.Placeholder != nil {
+			return v.descend(v.Placeholder(n))
+		}
+	case *Position:
+		if v.Position != nil {
+			return v.descend(v.Position(n))
+		}
+	case *Preceding:
+		if v.Preceding != nil {
+			return v.descend(v.Preceding(n))
+		}
+	case *PrepareStmt:
+		if v.PrepareStmt != nil {
+			return v.descend(v.PrepareStmt(n))
+		}
+	case *QualifiedJoin:
+		if v.QualifiedJoin != nil {
+			return v.descend(v.QualifiedJoin(n))
+		}
+	case *QualifiedWildcard:
+		if v.QualifiedWildcard != nil {
+			return v.descend(v.QualifiedWildcard(n))
+		}
+	case *QualifiedWildcardSelectItem:
+		if v.QualifiedWildcardSelectItem != nil {
+			return v.descend(v.QualifiedWildcardSelectItem(n))
+		}
+	case *QueryExpr:
+		if v.QueryExpr != nil {
+			return v.descend(v.QueryExpr(n))
+		}
+	case *QueryStmt:
+		if v.QueryStmt != nil {
+			return v.descend(v.QueryStmt(n))
+		}
+	case *RangeTypeDefinition:
+		if v.RangeTypeDefinition != nil {
+			return v.descend(v.RangeTypeDefinition(n))
+		}
+	case *Real:
+		if v.Real != nil {
+			return v.descend(v.Real(n))
+		}
+	case *ReferenceKeyExpr:
+		if v.ReferenceKeyExpr != nil {
+			return v.descend(v.ReferenceKeyExpr(n))
+		}
+	case *ReferencesColumnSpec:
+		if v.ReferencesColumnSpec != nil {
+			return v.descend(v.ReferencesColumnSpec(n))
+		}
+	case *ReferentialTableConstraint:
+		if v.ReferentialTableConstraint != nil {
+			return v.descend(v.ReferentialTableConstraint(n))
+		}
+	case *Regclass:
+		if v.Regclass != nil {
+			return v.descend(v.Regclass(n))
+		}
+	case *ReindexStmt:
+		if v.ReindexStmt != nil {
+			return v.descend(v.ReindexStmt(n))
+		}
+	case *RemoveColumnTableAction:
+		if v.RemoveColumnTableAction != nil {
+			return v.descend(v.RemoveColumnTableAction(n))
+		}
+	case *RenameToAction:
+		if v.RenameToAction != nil {
+			return v.descend(v.RenameToAction(n))
+		}
+	case *ResetStmt:
+		if v.ResetStmt != nil {
+			return v.descend(v.ResetStmt(n))
+		}
+	case *Rollup:
+		if v.Rollup != nil {
+			return v.descend(v.Rollup(n))
+		}
+	case *RowValueExpr:
+		if v.RowValueExpr != nil {
+			return v.descend(v.RowValueExpr(n))
+		}
+	case *SQLRowExpr:
+		if v.SQLRowExpr != nil {
+			return v.descend(v.SQLRowExpr(n))
+		}
+	case *SQLSelect:
+		if v.SQLSelect != nil {
+			return v.descend(v.SQLSelect(n))
+		}
+	case *SQLValues:
+		if v.SQLValues != nil {
+			return v.descend(v.SQLValues(n))
+		}
+	case *SelectExpr:
+		if v.SelectExpr != nil {
+			return v.descend(v.SelectExpr(n))
+		}
+	case *SequenceCache:
+		if v.SequenceCache != nil {
+			return v.descend(v.SequenceCache(n))
+		}
+	case *SequenceCycle:
+		if v.SequenceCycle != nil {
+			return v.descend(v.SequenceCycle(n))
+		}
+	case *SequenceDataType:
+		if v.SequenceDataType != nil {
+			return v.descend(v.SequenceDataType(n))
+		}
+	case *SequenceIncrement:
+		if v.SequenceIncrement != nil {
+			return v.descend(v.SequenceIncrement(n))
+		}
+	case *SequenceMaxValue:
+		if v.SequenceMaxValue != nil {
+			return v.descend(v.SequenceMaxValue(n))
+		}
+	case *SequenceMinValue:
+		if v.SequenceMinValue != nil {
+			return v.descend(v.SequenceMinValue(n))
+		}
+	case *SequenceOwnedBy:
+		if v.SequenceOwnedBy != nil {
+			return v.descend(v.SequenceOwnedBy(n))
+		}
+	case *SequenceRestart:
+		if v.SequenceRestart != nil {
+			return v.descend(v.SequenceRestart(n))
+		}
+	case *SequenceStart:
+		if v.SequenceStart != nil {
+			return v.descend(v.SequenceStart(n))
+		}
+	case *Serial:
+		if v.Serial != nil {
+			return v.descend(v.Serial(n))
+		}
+	case *SetDefaultColumnAction:
+		if v.SetDefaultColumnAction != nil {
+			return v.descend(v.SetDefaultColumnAction(n))
+		}
+	case *SetOperationExpr:
+		if v.SetOperationExpr != nil {
+			return v.descend(v.SetOperationExpr(n))
+		}
+	case *SetSchemaAction:
+		if v.SetSchemaAction != nil {
+			return v.descend(v.SetSchemaAction(n))
+		}
+	case *SetStmt:
+		if v.SetStmt != nil {
+			return v.descend(v.SetStmt(n))
+		}
+	case *SetTablespaceAction:
+		if v.SetTablespaceAction != nil {
+			return v.descend(v.SetTablespaceAction(n))
+		}
+	case *SetTimeZoneStmt:
+		if v.SetTimeZoneStmt != nil {
+			return v.descend(v.SetTimeZoneStmt(n))
+		}
+	case *ShowColumnsStmt:
+		if v.ShowColumnsStmt != nil {
+			return v.descend(v.ShowColumnsStmt(n))
+		}
+	case *ShowCreateTableStmt:
+		if v.ShowCreateTableStmt != nil {
+			return v.descend(v.ShowCreateTableStmt(n))
+		}
+	case *ShowStmt:
+		if v.ShowStmt != nil {
+			return v.descend(v.ShowStmt(n))
+		}
+	case *ShowTablesStmt:
+		if v.ShowTablesStmt != nil {
+			return v.descend(v.ShowTablesStmt(n))
+		}
+	case *SingleQuotedString:
+		if v.SingleQuotedString != nil {
+			return v.descend(v.SingleQuotedString(n))
+		}
+	case *SmallInt:
+		if v.SmallInt != nil {
+			return v.descend(v.SmallInt(n))
+		}
+	case *SmallSerial:
+		if v.SmallSerial != nil {
+			return v.descend(v.SmallSerial(n))
+		}
+	case *String:
+		if v.String != nil {
+			return v.descend(v.String(n))
+		}
+	case *SubQuery:
+		if v.SubQuery != nil {
+			return v.descend(v.SubQuery(n))
+		}
+	case *SubQuerySource:
+		if v.SubQuerySource != nil {
+			return v.descend(v.SubQuerySource(n))
+		}
+	case *Subscript:
+		if v.Subscript != nil {
+			return v.descend(v.Subscript(n))
+		}
+	case *Substring:
+		if v.Substring != nil {
+			return v.descend(v.Substring(n))
+		}
+	case *Table:
+		if v.Table != nil {
+			return v.descend(v.Table(n))
+		}
+	case *TableConstraint:
+		if v.TableConstraint != nil {
+			return v.descend(v.TableConstraint(n))
+		}
+	case *TableFunction:
+		if v.TableFunction != nil {
+			return v.descend(v.TableFunction(n))
+		}
+	case *TableJoinElement:
+		if v.TableJoinElement != nil {
+			return v.descend(v.TableJoinElement(n))
+		}
+	case *TableSample:
+		if v.TableSample != nil {
+			return v.descend(v.TableSample(n))
+		}
+	case *Tablespace:
+		if v.Tablespace != nil {
+			return v.descend(v.Tablespace(n))
+		}
+	case *Text:
+		if v.Text != nil {
+			return v.descend(v.Text(n))
+		}
+	case *Time:
+		if v.Time != nil {
+			return v.descend(v.Time(n))
+		}
+	case *TimeValue:
+		if v.TimeValue != nil {
+			return v.descend(v.TimeValue(n))
+		}
+	case *Timestamp:
+		if v.Timestamp != nil {
+			return v.descend(v.Timestamp(n))
+		}
+	case *TimestampValue:
+		if v.TimestampValue != nil {
+			return v.descend(v.TimestampValue(n))
+		}
+	case *Top:
+		if v.Top != nil {
+			return v.descend(v.Top(n))
+		}
+	case *Trim:
+		if v.Trim != nil {
+			return v.descend(v.Trim(n))
+		}
+	case *TruncateStmt:
+		if v.TruncateStmt != nil {
+			return v.descend(v.TruncateStmt(n))
+		}
+	case *TypeOption:
+		if v.TypeOption != nil {
+			return v.descend(v.TypeOption(n))
+		}
+	case *UUID:
+		if v.UUID != nil {
+			return v.descend(v.UUID(n))
+		}
+	case *UnaryExpr:
+		if v.UnaryExpr != nil {
+			return v.descend(v.UnaryExpr(n))
+		}
+	case *UnboundedFollowing:
+		if v.UnboundedFollowing != nil {
+			return v.descend(v.UnboundedFollowing(n))
+		}
+	case *UnboundedPreceding:
+		if v.UnboundedPreceding != nil {
+			return v.descend(v.UnboundedPreceding(n))
+		}
+	case *UnicodeStringLiteral:
+		if v.UnicodeStringLiteral != nil {
+			return v.descend(v.UnicodeStringLiteral(n))
+		}
+	case *UnionOperator:
+		if v.UnionOperator != nil {
+			return v.descend(v.UnionOperator(n))
+		}
+	case *UniqueColumnSpec:
+		if v.UniqueColumnSpec != nil {
+			return v.descend(v.UniqueColumnSpec(n))
+		}
+	case *UniqueTableConstraint:
+		if v.UniqueTableConstraint != nil {
+			return v.descend(v.UniqueTableConstraint(n))
+		}
+	case *UnloadStmt:
+		if v.UnloadStmt != nil {
+			return v.descend(v.UnloadStmt(n))
+		}
+	case *UnnamedSelectItem:
+		if v.UnnamedSelectItem != nil {
+			return v.descend(v.UnnamedSelectItem(n))
+		}
+	case *Unnest:
+		if v.Unnest != nil {
+			return v.descend(v.Unnest(n))
+		}
+	case *UnsignedLongValue:
+		if v.UnsignedLongValue != nil {
+			return v.descend(v.UnsignedLongValue(n))
+		}
+	case *UpdateStmt:
+		if v.UpdateStmt != nil {
+			return v.descend(v.UpdateStmt(n))
+		}
+	case *UseStmt:
+		if v.UseStmt != nil {
+			return v.descend(v.UseStmt(n))
+		}
+	case *VacuumStmt:
+		if v.VacuumStmt != nil {
+			return v.descend(v.VacuumStmt(n))
+		}
+	case *Varbinary:
+		if v.Varbinary != nil {
+			return v.descend(v.Varbinary(n))
+		}
+	case *VarcharType:
+		if v.VarcharType != nil {
+			return v.descend(v.VarcharType(n))
+		}
+	case *Wildcard:
+		if v.Wildcard != nil {
+			return v.descend(v.Wildcard(n))
+		}
+	case *WildcardSelectItem:
+		if v.WildcardSelectItem != nil {
+			return v.descend(v.WildcardSelectItem(n))
+		}
+	case *WindowFrame:
+		if v.WindowFrame != nil {
+			return v.descend(v.WindowFrame(n))
+		}
+	case *WindowFrameUnit:
+		if v.WindowFrameUnit != nil {
+			return v.descend(v.WindowFrameUnit(n))
+		}
+	case *WindowSpec:
+		if v.WindowSpec != nil {
+			return v.descend(v.WindowSpec(n))
+		}
+	case *WithOptions:
+		if v.WithOptions != nil {
+			return v.descend(v.WithOptions(n))
+		}
+	}
+	if v.Default != nil {
+		return v.descend(v.Default(node))
+	}
+	return v
+}
+
+func (v *NodeVisitor) descend(ok bool) Visitor {
+	if !ok {
+		return nil
+	}
+	return v
+}
@@ -38,6 +38,29 @@ const (
 	Not
 	Like
 	NotLike
+	JSONGet         // ->
+	JSONGetText     // ->>
+	JSONPathGet     // #>
+	JSONPathGetText // #>>
+	Contains        // @>
+	ContainedBy     // <@
+	JSONExists      // ?
+	JSONExistsAny   // ?|
+	JSONExistsAll   // ?&
+	ILike
+	NotILike
+	SimilarTo
+	NotSimilarTo
+	RegexMatch     // ~
+	RegexIMatch    // ~*
+	NotRegexMatch  // !~
+	NotRegexIMatch // !~*
+	StringConcat   // ||
+	BitwiseAnd     // &
+	BitwiseOr      // |
+	BitwiseXor     // #
+	ShiftLeft      // <<
+	ShiftRight     // >>
 	None
 )
 
@@ -75,6 +98,52 @@ func (o *Operator) ToSQLString() string {
 		return "LIKE"
 	case NotLike:
 		return "NOT LIKE"
+	case JSONGet:
+		return "->"
+	case JSONGetText:
+		return "->>"
+	case JSONPathGet:
+		return "#>"
+	case JSONPathGetText:
+		return "#>>"
+	case Contains:
+		return "@>"
+	case ContainedBy:
+		return "<@"
+	case JSONExists:
+		return "?"
+	case JSONExistsAny:
+		return "?|"
+	case JSONExistsAll:
+		return "?&"
+	case ILike:
+		return "ILIKE"
+	case NotILike:
+		return "NOT ILIKE"
+	case SimilarTo:
+		return "SIMILAR TO"
+	case NotSimilarTo:
+		return "NOT SIMILAR TO"
+	case RegexMatch:
+		return "~"
+	case RegexIMatch:
+		return "~*"
+	case NotRegexMatch:
+		return "!~"
+	case NotRegexIMatch:
+		return "!~*"
+	case StringConcat:
+		return "||"
+	case BitwiseAnd:
+		return "&"
+	case BitwiseOr:
+		return "|"
+	case BitwiseXor:
+		return "#"
+	case ShiftLeft:
+		return "<<"
+	case ShiftRight:
+		return ">>"
 	}
 	return ""
 }
@@ -113,6 +182,52 @@ func (o *Operator) WriteTo(w io.Writer) (int64, error) {
 		return writeSingleBytes(w, []byte("LIKE"))
 	case NotLike:
 		return writeSingleBytes(w, []byte("NOT LIKE"))
+	case JSONGet:
+		return writeSingleBytes(w, []byte("->"))
+	case JSONGetText:
+		return writeSingleBytes(w, []byte("->>"))
+	case JSONPathGet:
+		return writeSingleBytes(w, []byte("#>"))
+	case JSONPathGetText:
+		return writeSingleBytes(w, []byte("#>>"))
+	case Contains:
+		return writeSingleBytes(w, []byte("@>"))
+	case ContainedBy:
+		return writeSingleBytes(w, []byte("<@"))
+	case JSONExists:
+		return writeSingleBytes(w, []byte("?"))
+	case JSONExistsAny:
+		return writeSingleBytes(w, []byte("?|"))
+	case JSONExistsAll:
+		return writeSingleBytes(w, []byte("?&"))
+	case ILike:
+		return writeSingleBytes(w, []byte("ILIKE"))
+	case NotILike:
+		return writeSingleBytes(w, []byte("NOT ILIKE"))
+	case SimilarTo:
+		return writeSingleBytes(w, []byte("SIMILAR TO"))
+	case NotSimilarTo:
+		return writeSingleBytes(w, []byte("NOT SIMILAR TO"))
+	case RegexMatch:
+		return writeSingleBytes(w, []byte("~"))
+	case RegexIMatch:
+		return writeSingleBytes(w, []byte("~*"))
+	case NotRegexMatch:
+		return writeSingleBytes(w, []byte("!~"))
+	case NotRegexIMatch:
+		return writeSingleBytes(w, []byte("!~*"))
+	case StringConcat:
+		return writeSingleBytes(w, []byte("||"))
+	case BitwiseAnd:
+		return writeSingleBytes(w, []byte("&"))
+	case BitwiseOr:
+		return writeSingleBytes(w, []byte("|"))
+	case BitwiseXor:
+		return writeSingleBytes(w, []byte("#"))
+	case ShiftLeft:
+		return writeSingleBytes(w, []byte("<<"))
+	case ShiftRight:
+		return writeSingleBytes(w, []byte(">>"))
 	}
 	return 0, nil
 }
\ No newline at end of file
@@ -0,0 +1,156 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+type PartitionStrategy int
+
+const (
+	PartitionByRange PartitionStrategy = iota
+	PartitionByList
+	PartitionByHash
+)
+
+func (p PartitionStrategy) ToSQLString() string {
+	switch p {
+	case PartitionByList:
+		return "LIST"
+	case PartitionByHash:
+		return "HASH"
+	}
+	return "RANGE"
+}
+
+// Postgres `PARTITION BY { RANGE | LIST | HASH } (Keys...)`. Keys are
+// columns or expressions.
+type PartitionBy struct {
+	tableOption
+	Partition sqltoken.Pos
+	Strategy  PartitionStrategy
+	Keys      []Node
+	RParen    sqltoken.Pos
+}
+
+func (p *PartitionBy) ToSQLString() string {
+	return toSQLString(p)
+}
+
+func (p *PartitionBy) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("PARTITION BY ")).Bytes([]byte(p.Strategy.ToSQLString()))
+	return sw.Bytes([]byte(" (")).Nodes(p.Keys).RParen().End()
+}
+
+func (p *PartitionBy) Pos() sqltoken.Pos {
+	return p.Partition
+}
+
+func (p *PartitionBy) End() sqltoken.Pos {
+	return p.RParen
+}
+
+//go:generate genmark -t PartitionBound -e Node
+
+// `FOR VALUES IN (Values...)` of a list partition
+type PartitionBoundIn struct {
+	partitionBound
+	For    sqltoken.Pos
+	Values []Node
+	RParen sqltoken.Pos
+}
+
+func (p *PartitionBoundIn) ToSQLString() string {
+	return toSQLString(p)
+}
+
+func (p *PartitionBoundIn) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("FOR VALUES IN (")).Nodes(p.Values).RParen().End()
+}
+
+func (p *PartitionBoundIn) Pos() sqltoken.Pos {
+	return p.For
+}
+
+func (p *PartitionBoundIn) End() sqltoken.Pos {
+	return p.RParen
+}
+
+// `FOR VALUES FROM (From...) TO (To...)` of a range partition. The values
+// may be MINVALUE or MAXVALUE, which are parsed as identifiers.
+type PartitionBoundRange struct {
+	partitionBound
+	For    sqltoken.Pos
+	From   []Node
+	To     []Node
+	RParen sqltoken.Pos
+}
+
+func (p *PartitionBoundRange) ToSQLString() string {
+	return toSQLString(p)
+}
+
+func (p *PartitionBoundRange) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("FOR VALUES FROM (")).Nodes(p.From).RParen()
+	return sw.Bytes([]byte(" TO (")).Nodes(p.To).RParen().End()
+}
+
+func (p *PartitionBoundRange) Pos() sqltoken.Pos {
+	return p.For
+}
+
+func (p *PartitionBoundRange) End() sqltoken.Pos {
+	return p.RParen
+}
+
+// `FOR VALUES WITH (MODULUS Modulus, REMAINDER Remainder)` of a hash partition
+type PartitionBoundHash struct {
+	partitionBound
+	For       sqltoken.Pos
+	Modulus   *LongValue
+	Remainder *LongValue
+	RParen    sqltoken.Pos
+}
+
+func (p *PartitionBoundHash) ToSQLString() string {
+	return toSQLString(p)
+}
+
+func (p *PartitionBoundHash) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("FOR VALUES WITH (MODULUS ")).Node(p.Modulus)
+	return sw.Bytes([]byte(", REMAINDER ")).Node(p.Remainder).RParen().End()
+}
+
+func (p *PartitionBoundHash) Pos() sqltoken.Pos {
+	return p.For
+}
+
+func (p *PartitionBoundHash) End() sqltoken.Pos {
+	return p.RParen
+}
+
+// `DEFAULT` partition
+type PartitionBoundDefault struct {
+	partitionBound
+	From, To sqltoken.Pos
+}
+
+func (*PartitionBoundDefault) ToSQLString() string {
+	return "DEFAULT"
+}
+
+func (*PartitionBoundDefault) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("DEFAULT"))
+}
+
+func (p *PartitionBoundDefault) Pos() sqltoken.Pos {
+	return p.From
+}
+
+func (p *PartitionBoundDefault) End() sqltoken.Pos {
+	return p.To
+}
@@ -0,0 +1,111 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// `PREPARE Name [(DataTypes...)] AS Stmt`
+type PrepareStmt struct {
+	stmt
+	Prepare   sqltoken.Pos
+	Name      *Ident
+	DataTypes []Type
+	Stmt      Stmt
+}
+
+func (p *PrepareStmt) Pos() sqltoken.Pos {
+	return p.Prepare
+}
+
+func (p *PrepareStmt) End() sqltoken.Pos {
+	return p.Stmt.End()
+}
+
+func (p *PrepareStmt) ToSQLString() string {
+	return toSQLString(p)
+}
+
+func (p *PrepareStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("PREPARE ")).Node(p.Name)
+	if len(p.DataTypes) != 0 {
+		sw.Space().LParen()
+		for i, t := range p.DataTypes {
+			sw.JoinComma(i, t)
+		}
+		sw.RParen()
+	}
+	return sw.As().Node(p.Stmt).End()
+}
+
+// `EXECUTE Name [(Args...)]`
+type ExecuteStmt struct {
+	stmt
+	Execute sqltoken.Pos
+	Name    *Ident
+	Args    []Node
+	RParen  sqltoken.Pos
+}
+
+func (e *ExecuteStmt) Pos() sqltoken.Pos {
+	return e.Execute
+}
+
+func (e *ExecuteStmt) End() sqltoken.Pos {
+	if len(e.Args) != 0 {
+		return e.RParen
+	}
+	return e.Name.End()
+}
+
+func (e *ExecuteStmt) ToSQLString() string {
+	return toSQLString(e)
+}
+
+func (e *ExecuteStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("EXECUTE ")).Node(e.Name)
+	if len(e.Args) != 0 {
+		sw.LParen()
+		for i, a := range e.Args {
+			sw.JoinComma(i, a)
+		}
+		sw.RParen()
+	}
+	return sw.End()
+}
+
+// `DEALLOCATE [PREPARE] {Name | ALL}` (Name is nil for ALL)
+type DeallocateStmt struct {
+	stmt
+	Deallocate sqltoken.Pos
+	Prepare    bool
+	Name       *Ident
+	To         sqltoken.Pos // end of ALL
+}
+
+func (d *DeallocateStmt) Pos() sqltoken.Pos {
+	return d.Deallocate
+}
+
+func (d *DeallocateStmt) End() sqltoken.Pos {
+	if d.Name != nil {
+		return d.Name.End()
+	}
+	return d.To
+}
+
+func (d *DeallocateStmt) ToSQLString() string {
+	return toSQLString(d)
+}
+
+func (d *DeallocateStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("DEALLOCATE ")).If(d.Prepare, []byte("PREPARE "))
+	if d.Name == nil {
+		return sw.Bytes([]byte("ALL")).End()
+	}
+	return sw.Node(d.Name).End()
+}
@@ -15,6 +15,11 @@ type QueryStmt struct {
 	Body    SQLSetExpr
 	OrderBy []*OrderByExpr
 	Limit   *LimitExpr
+	Fetch   *Fetch
+	Locks   []*LockingClause
+	// LocksFirst is true if Locks precede Limit and Fetch, i.e:
+	// SELECT ... FOR UPDATE LIMIT 1
+	LocksFirst bool
 }
 
 func (q *QueryStmt) Pos() sqltoken.Pos {
@@ -26,6 +31,14 @@ func (q *QueryStmt) Pos() sqltoken.Pos {
 }
 
 func (q *QueryStmt) End() sqltoken.Pos {
+	if len(q.Locks) != 0 && (!q.LocksFirst || (q.Limit == nil && q.Fetch == nil)) {
+		return q.Locks[len(q.Locks)-1].End()
+	}
+
+	if q.Fetch != nil {
+		return q.Fetch.End()
+	}
+
 	if q.Limit != nil {
 		return q.Limit.End()
 	}
@@ -59,12 +72,100 @@ func (q *QueryStmt) WriteTo(w io.Writer) (int64, error) {
 			sw.JoinComma(i, col)
 		}
 	}
+	if q.LocksFirst {
+		for _, l := range q.Locks {
+			sw.Space().Node(l)
+		}
+	}
 	if q.Limit != nil {
 		sw.Space().Node(q.Limit)
 	}
+	if q.Fetch != nil {
+		sw.Space().Node(q.Fetch)
+	}
+	if !q.LocksFirst {
+		for _, l := range q.Locks {
+			sw.Space().Node(l)
+		}
+	}
+	return sw.End()
+}
+
+// `FOR {UPDATE | NO KEY UPDATE | SHARE | KEY SHARE} [OF Of...] [NOWAIT | SKIP LOCKED]`
+type LockingClause struct {
+	For      sqltoken.Pos // first position of FOR keyword
+	Strength LockStrength
+	Of       []*ObjectName
+	Wait     LockWait
+	To       sqltoken.Pos // last position of the clause
+}
+
+func (l *LockingClause) Pos() sqltoken.Pos {
+	return l.For
+}
+
+func (l *LockingClause) End() sqltoken.Pos {
+	return l.To
+}
+
+func (l *LockingClause) ToSQLString() string {
+	return toSQLString(l)
+}
+
+func (l *LockingClause) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("FOR ")).Bytes([]byte(l.Strength.ToSQLString()))
+	if len(l.Of) != 0 {
+		sw.Bytes([]byte(" OF "))
+		for i, o := range l.Of {
+			sw.JoinComma(i, o)
+		}
+	}
+	if l.Wait != LockWaitDefault {
+		sw.Space().Bytes([]byte(l.Wait.ToSQLString()))
+	}
 	return sw.End()
 }
 
+type LockStrength int
+
+const (
+	LockUpdate LockStrength = iota
+	LockNoKeyUpdate
+	LockShare
+	LockKeyShare
+)
+
+func (l LockStrength) ToSQLString() string {
+	switch l {
+	case LockNoKeyUpdate:
+		return "NO KEY UPDATE"
+	case LockShare:
+		return "SHARE"
+	case LockKeyShare:
+		return "KEY SHARE"
+	}
+	return "UPDATE"
+}
+
+type LockWait int
+
+const (
+	LockWaitDefault LockWait = iota
+	LockNoWait
+	LockSkipLocked
+)
+
+func (l LockWait) ToSQLString() string {
+	switch l {
+	case LockNoWait:
+		return "NOWAIT"
+	case LockSkipLocked:
+		return "SKIP LOCKED"
+	}
+	return ""
+}
+
 // CTE
 type CTE struct {
 	Alias  *Ident
@@ -189,6 +290,7 @@ func (u *UnionOperator) WriteTo(w io.Writer) (int64, error) {
 type ExceptOperator struct {
 	sqlSetOperator
 	From, To sqltoken.Pos
+	Minus    bool // written as MINUS (Oracle)
 }
 
 func (e *ExceptOperator) Pos() sqltoken.Pos {
@@ -199,12 +301,15 @@ func (e *ExceptOperator) End() sqltoken.Pos {
 	return e.To
 }
 
-func (*ExceptOperator) ToSQLString() string {
+func (e *ExceptOperator) ToSQLString() string {
+	if e.Minus {
+		return "MINUS"
+	}
 	return "EXCEPT"
 }
 
 func (e *ExceptOperator) WriteTo(w io.Writer) (n int64, err error) {
-	return writeSingleBytes(w, []byte("EXCEPT"))
+	return writeSingleBytes(w, []byte(e.ToSQLString()))
 }
 
 type IntersectOperator struct {
@@ -230,13 +335,22 @@ func (i *IntersectOperator) WriteTo(w io.Writer) (n int64, err error) {
 
 type SQLSelect struct {
 	sqlSetExpr
+	Hints         *OptimizerHints
 	Distinct      bool
+	DistinctOn    []Node // PostgreSQL only, `DISTINCT ON (DistinctOn...)`
+	Top           *Top   // SQL Server only
 	Projection    []SQLSelectItem
 	FromClause    []TableReference
 	WhereClause   Node
 	GroupByClause []Node
 	HavingClause  Node
-	Select        sqltoken.Pos // first position of SELECT
+	WindowClause  []*NamedWindow
+	LateralViews  []*LateralView // Hive only, follows FromClause
+	ArrayJoins    []*ArrayJoin   // ClickHouse only, follows FromClause
+	DistributeBy  []Node         // Hive only
+	SortBy        []*OrderByExpr // Hive only
+	ClusterBy     []Node         // Hive only
+	Select        sqltoken.Pos   // first position of SELECT
 }
 
 func (s *SQLSelect) Pos() sqltoken.Pos {
@@ -244,6 +358,22 @@ func (s *SQLSelect) Pos() sqltoken.Pos {
 }
 
 func (s *SQLSelect) End() sqltoken.Pos {
+	if len(s.ClusterBy) != 0 {
+		return s.ClusterBy[len(s.ClusterBy)-1].End()
+	}
+
+	if len(s.SortBy) != 0 {
+		return s.SortBy[len(s.SortBy)-1].End()
+	}
+
+	if len(s.DistributeBy) != 0 {
+		return s.DistributeBy[len(s.DistributeBy)-1].End()
+	}
+
+	if len(s.WindowClause) != 0 {
+		return s.WindowClause[len(s.WindowClause)-1].End()
+	}
+
 	if s.HavingClause != nil {
 		return s.HavingClause.End()
 	}
@@ -256,6 +386,14 @@ func (s *SQLSelect) End() sqltoken.Pos {
 		return s.WhereClause.End()
 	}
 
+	if len(s.ArrayJoins) != 0 {
+		return s.ArrayJoins[len(s.ArrayJoins)-1].End()
+	}
+
+	if len(s.LateralViews) != 0 {
+		return s.LateralViews[len(s.LateralViews)-1].End()
+	}
+
 	if len(s.FromClause) != 0 {
 		return s.FromClause[len(s.FromClause)-1].End()
 	}
@@ -270,9 +408,18 @@ func (s *SQLSelect) ToSQLString() string {
 func (s *SQLSelect) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	sw.Bytes(selectBytes)
+	if s.Hints != nil {
+		sw.Node(s.Hints).Space()
+	}
 	if s.Distinct {
 		sw.Bytes([]byte("DISTINCT "))
 	}
+	if len(s.DistinctOn) != 0 {
+		sw.Bytes([]byte("ON ")).LParen().Nodes(s.DistinctOn).RParen().Space()
+	}
+	if s.Top != nil {
+		sw.Node(s.Top).Space()
+	}
 	for i, projection := range s.Projection {
 		sw.JoinComma(i, projection)
 	}
@@ -282,6 +429,12 @@ func (s *SQLSelect) WriteTo(w io.Writer) (int64, error) {
 			sw.JoinComma(i, from)
 		}
 	}
+	for _, l := range s.LateralViews {
+		sw.Space().Node(l)
+	}
+	for _, a := range s.ArrayJoins {
+		sw.Space().Node(a)
+	}
 	if s.WhereClause != nil {
 		sw.Bytes(whereBytes)
 		if sw.Err() == nil {
@@ -294,9 +447,192 @@ func (s *SQLSelect) WriteTo(w io.Writer) (int64, error) {
 	if s.HavingClause != nil {
 		sw.Bytes([]byte(" HAVING ")).Node(s.HavingClause)
 	}
+	if len(s.WindowClause) != 0 {
+		sw.Bytes([]byte(" WINDOW "))
+		for i, window := range s.WindowClause {
+			sw.JoinComma(i, window)
+		}
+	}
+	if len(s.DistributeBy) != 0 {
+		sw.Bytes([]byte(" DISTRIBUTE BY ")).Nodes(s.DistributeBy)
+	}
+	if len(s.SortBy) != 0 {
+		sw.Bytes([]byte(" SORT BY "))
+		for i, o := range s.SortBy {
+			sw.JoinComma(i, o)
+		}
+	}
+	if len(s.ClusterBy) != 0 {
+		sw.Bytes([]byte(" CLUSTER BY ")).Nodes(s.ClusterBy)
+	}
+	return sw.End()
+}
+
+// `[LEFT] ARRAY JOIN Items...` (ClickHouse)
+type ArrayJoin struct {
+	From  sqltoken.Pos // first position of LEFT or ARRAY
+	Left  bool
+	Items []SQLSelectItem
+}
+
+func (a *ArrayJoin) Pos() sqltoken.Pos {
+	return a.From
+}
+
+func (a *ArrayJoin) End() sqltoken.Pos {
+	return a.Items[len(a.Items)-1].End()
+}
+
+func (a *ArrayJoin) ToSQLString() string {
+	return toSQLString(a)
+}
+
+func (a *ArrayJoin) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.If(a.Left, []byte("LEFT ")).Bytes([]byte("ARRAY JOIN "))
+	for i, item := range a.Items {
+		sw.JoinComma(i, item)
+	}
+	return sw.End()
+}
+
+// `LATERAL VIEW [OUTER] Generator [TableAlias] [AS ColumnAliases...]` (Hive)
+type LateralView struct {
+	Lateral       sqltoken.Pos
+	Outer         bool
+	Generator     Node
+	TableAlias    *Ident
+	ColumnAliases []*Ident
+}
+
+func (l *LateralView) Pos() sqltoken.Pos {
+	return l.Lateral
+}
+
+func (l *LateralView) End() sqltoken.Pos {
+	if len(l.ColumnAliases) != 0 {
+		return l.ColumnAliases[len(l.ColumnAliases)-1].End()
+	}
+	if l.TableAlias != nil {
+		return l.TableAlias.End()
+	}
+	return l.Generator.End()
+}
+
+func (l *LateralView) ToSQLString() string {
+	return toSQLString(l)
+}
+
+func (l *LateralView) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("LATERAL VIEW ")).If(l.Outer, []byte("OUTER ")).Node(l.Generator)
+	if l.TableAlias != nil {
+		sw.Space().Node(l.TableAlias)
+	}
+	if len(l.ColumnAliases) != 0 {
+		sw.As().Idents(l.ColumnAliases, []byte(", "))
+	}
+	return sw.End()
+}
+
+// `VALUES Rows...` used as a query body
+type SQLValues struct {
+	sqlSetExpr
+	Values sqltoken.Pos
+	Rows   []*RowValueExpr
+}
+
+func (s *SQLValues) Pos() sqltoken.Pos {
+	return s.Values
+}
+
+func (s *SQLValues) End() sqltoken.Pos {
+	return s.Rows[len(s.Rows)-1].End()
+}
+
+func (s *SQLValues) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SQLValues) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("VALUES "))
+	for i, row := range s.Rows {
+		sw.JoinComma(i, row)
+	}
 	return sw.End()
 }
 
+// `GROUPING SETS (Sets...)`
+// each element of Sets is an expression, or RowValueExpr for parenthesized list such as `(a, b)` and `()`
+type GroupingSets struct {
+	Grouping sqltoken.Pos // first position of GROUPING keyword
+	Sets     []Node
+	RParen   sqltoken.Pos
+}
+
+func (g *GroupingSets) Pos() sqltoken.Pos {
+	return g.Grouping
+}
+
+func (g *GroupingSets) End() sqltoken.Pos {
+	return g.RParen
+}
+
+func (g *GroupingSets) ToSQLString() string {
+	return toSQLString(g)
+}
+
+func (g *GroupingSets) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("GROUPING SETS ")).LParen().Nodes(g.Sets).RParen().End()
+}
+
+// `ROLLUP (Sets...)`
+type Rollup struct {
+	Rollup sqltoken.Pos // first position of ROLLUP keyword
+	Sets   []Node
+	RParen sqltoken.Pos
+}
+
+func (r *Rollup) Pos() sqltoken.Pos {
+	return r.Rollup
+}
+
+func (r *Rollup) End() sqltoken.Pos {
+	return r.RParen
+}
+
+func (r *Rollup) ToSQLString() string {
+	return toSQLString(r)
+}
+
+func (r *Rollup) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("ROLLUP ")).LParen().Nodes(r.Sets).RParen().End()
+}
+
+// `CUBE (Sets...)`
+type Cube struct {
+	Cube   sqltoken.Pos // first position of CUBE keyword
+	Sets   []Node
+	RParen sqltoken.Pos
+}
+
+func (c *Cube) Pos() sqltoken.Pos {
+	return c.Cube
+}
+
+func (c *Cube) End() sqltoken.Pos {
+	return c.RParen
+}
+
+func (c *Cube) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *Cube) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("CUBE ")).LParen().Nodes(c.Sets).RParen().End()
+}
+
 //go:generate genmark -t TableReference -e Node
 
 //go:generate genmark -t TableFactor -e TableReference
@@ -307,10 +643,15 @@ type Table struct {
 	tableReference
 	Name            *ObjectName
 	Alias           *Ident
-	Args            []Node
-	ArgsRParen      sqltoken.Pos
+	Columns         []*Ident     // column aliases, `AS Alias(Columns...)`
+	ColumnsRParen   sqltoken.Pos // RParen position of column aliases (if Columns is not empty)
 	WithHints       []Node
 	WithHintsRParen sqltoken.Pos
+	Sample          *TableSample
+	IndexHints      []*IndexHint // MySQL only, follows Alias
+	Final           bool         // ClickHouse only
+	FinalPos        sqltoken.Pos // position of FINAL if Final is true
+	CHSample        *CHSample    // ClickHouse only
 }
 
 func (t *Table) Pos() sqltoken.Pos {
@@ -322,12 +663,28 @@ func (t *Table) End() sqltoken.Pos {
 		return t.WithHintsRParen
 	}
 
-	if t.Alias != nil {
-		return t.Alias.End()
+	if t.Sample != nil {
+		return t.Sample.End()
+	}
+
+	if t.CHSample != nil {
+		return t.CHSample.End()
+	}
+
+	if t.Final {
+		return t.FinalPos
 	}
 
-	if len(t.Args) != 0 {
-		return t.ArgsRParen
+	if len(t.IndexHints) != 0 {
+		return t.IndexHints[len(t.IndexHints)-1].End()
+	}
+
+	if len(t.Columns) != 0 {
+		return t.ColumnsRParen
+	}
+
+	if t.Alias != nil {
+		return t.Alias.End()
 	}
 
 	return t.Name.End()
@@ -340,11 +697,16 @@ func (t *Table) ToSQLString() string {
 func (t *Table) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	sw.Node(t.Name)
-	if len(t.Args) != 0 {
-		sw.LParen().Nodes(t.Args).RParen()
+	writeTableAlias(sw, t.Alias, t.Columns)
+	for _, h := range t.IndexHints {
+		sw.Space().Node(h)
 	}
-	if t.Alias != nil {
-		sw.As().Node(t.Alias)
+	sw.If(t.Final, []byte(" FINAL"))
+	if t.CHSample != nil {
+		sw.Space().Node(t.CHSample)
+	}
+	if t.Sample != nil {
+		sw.Space().Node(t.Sample)
 	}
 	if len(t.WithHints) != 0 {
 		sw.Bytes([]byte(" WITH ")).LParen().Nodes(t.WithHints).RParen()
@@ -352,15 +714,158 @@ func (t *Table) WriteTo(w io.Writer) (int64, error) {
 	return sw.End()
 }
 
+// ClickHouse `SAMPLE Ratio [OFFSET Offset]`
+type CHSample struct {
+	Sample sqltoken.Pos
+	Ratio  Node
+	Offset Node
+}
+
+func (c *CHSample) Pos() sqltoken.Pos {
+	return c.Sample
+}
+
+func (c *CHSample) End() sqltoken.Pos {
+	if c.Offset != nil {
+		return c.Offset.End()
+	}
+	return c.Ratio.End()
+}
+
+func (c *CHSample) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CHSample) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("SAMPLE ")).Node(c.Ratio)
+	if c.Offset != nil {
+		sw.Bytes([]byte(" OFFSET ")).Node(c.Offset)
+	}
+	return sw.End()
+}
+
+// `TABLESAMPLE Method(Args...) [REPEATABLE (Repeatable)]`
+type TableSample struct {
+	TableSample      sqltoken.Pos
+	Method           *Ident // BERNOULLI, SYSTEM or an extension method
+	Args             []Node
+	RParen           sqltoken.Pos
+	Repeatable       Node // seed
+	RepeatableRParen sqltoken.Pos
+}
+
+func (t *TableSample) Pos() sqltoken.Pos {
+	return t.TableSample
+}
+
+func (t *TableSample) End() sqltoken.Pos {
+	if t.Repeatable != nil {
+		return t.RepeatableRParen
+	}
+	return t.RParen
+}
+
+func (t *TableSample) ToSQLString() string {
+	return toSQLString(t)
+}
+
+func (t *TableSample) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("TABLESAMPLE ")).Node(t.Method).LParen().Nodes(t.Args).RParen()
+	if t.Repeatable != nil {
+		sw.Bytes([]byte(" REPEATABLE (")).Node(t.Repeatable).RParen()
+	}
+	return sw.End()
+}
+
+// MySQL `{USE|FORCE|IGNORE} {INDEX|KEY} [FOR {JOIN|ORDER BY|GROUP BY}] (Indexes...)`
+// following a table. Indexes may be empty only for USE.
+type IndexHint struct {
+	From    sqltoken.Pos
+	Action  IndexHintAction
+	Key     bool // KEY instead of INDEX
+	For     IndexHintScope
+	Indexes []*Ident
+	RParen  sqltoken.Pos
+}
+
+func (i *IndexHint) Pos() sqltoken.Pos {
+	return i.From
+}
+
+func (i *IndexHint) End() sqltoken.Pos {
+	return i.RParen
+}
+
+func (i *IndexHint) ToSQLString() string {
+	return toSQLString(i)
+}
+
+func (i *IndexHint) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte(i.Action.ToSQLString()))
+	if i.Key {
+		sw.Bytes([]byte(" KEY"))
+	} else {
+		sw.Bytes([]byte(" INDEX"))
+	}
+	if i.For != IndexHintForAll {
+		sw.Bytes([]byte(" FOR ")).Bytes([]byte(i.For.ToSQLString()))
+	}
+	return sw.Space().LParen().Idents(i.Indexes, []byte(", ")).RParen().End()
+}
+
+type IndexHintAction int
+
+const (
+	UseIndex IndexHintAction = iota
+	ForceIndex
+	IgnoreIndex
+)
+
+func (i IndexHintAction) ToSQLString() string {
+	switch i {
+	case ForceIndex:
+		return "FORCE"
+	case IgnoreIndex:
+		return "IGNORE"
+	}
+	return "USE"
+}
+
+type IndexHintScope int
+
+const (
+	IndexHintForAll IndexHintScope = iota // without FOR
+	IndexHintForJoin
+	IndexHintForOrderBy
+	IndexHintForGroupBy
+)
+
+func (i IndexHintScope) ToSQLString() string {
+	switch i {
+	case IndexHintForJoin:
+		return "JOIN"
+	case IndexHintForOrderBy:
+		return "ORDER BY"
+	case IndexHintForGroupBy:
+		return "GROUP BY"
+	}
+	return ""
+}
+
 type Derived struct {
 	tableFactor
 	tableReference
-	Lateral    bool
-	LateralPos sqltoken.Pos // last position of LATERAL keyword if Lateral is true
-	LParen     sqltoken.Pos
-	RParen     sqltoken.Pos
-	SubQuery   *QueryStmt
-	Alias      *Ident
+	Lateral       bool
+	LateralPos    sqltoken.Pos // first position of LATERAL keyword if Lateral is true
+	LParen        sqltoken.Pos
+	RParen        sqltoken.Pos
+	SubQuery      *QueryStmt
+	Alias         *Ident
+	Columns       []*Ident     // column aliases, `AS Alias(Columns...)`
+	ColumnsRParen sqltoken.Pos // RParen position of column aliases (if Columns is not empty)
 }
 
 func (d *Derived) Pos() sqltoken.Pos {
@@ -371,11 +876,15 @@ func (d *Derived) Pos() sqltoken.Pos {
 }
 
 func (d *Derived) End() sqltoken.Pos {
+	if len(d.Columns) != 0 {
+		return d.ColumnsRParen
+	}
+
 	if d.Alias != nil {
 		return d.Alias.End()
 	}
 
-	return d.LParen
+	return d.RParen
 }
 
 func (d *Derived) ToSQLString() string {
@@ -386,12 +895,109 @@ func (d *Derived) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	sw.If(d.Lateral, []byte("LATERAL "))
 	sw.LParen().Node(d.SubQuery).RParen()
-	if d.Alias != nil {
-		sw.As().Node(d.Alias)
+	writeTableAlias(sw, d.Alias, d.Columns)
+	return sw.End()
+}
+
+// table-valued function in FROM clause, e.g. `[LATERAL] generate_series(1, 10) AS g(n)`
+type TableFunction struct {
+	tableFactor
+	tableReference
+	Lateral       bool
+	LateralPos    sqltoken.Pos // first position of LATERAL keyword if Lateral is true
+	Function      *Function
+	Alias         *Ident
+	Columns       []*Ident     // column aliases, `AS Alias(Columns...)`
+	ColumnsRParen sqltoken.Pos // RParen position of column aliases (if Columns is not empty)
+}
+
+func (t *TableFunction) Pos() sqltoken.Pos {
+	if t.Lateral {
+		return t.LateralPos
+	}
+	return t.Function.Pos()
+}
+
+func (t *TableFunction) End() sqltoken.Pos {
+	if len(t.Columns) != 0 {
+		return t.ColumnsRParen
+	}
+
+	if t.Alias != nil {
+		return t.Alias.End()
+	}
+
+	return t.Function.End()
+}
+
+func (t *TableFunction) ToSQLString() string {
+	return toSQLString(t)
+}
+
+func (t *TableFunction) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.If(t.Lateral, []byte("LATERAL "))
+	sw.Node(t.Function)
+	writeTableAlias(sw, t.Alias, t.Columns)
+	return sw.End()
+}
+
+// `UNNEST(Exprs...) [WITH ORDINALITY] [AS Alias(Columns...)]`
+type Unnest struct {
+	tableFactor
+	tableReference
+	Unnest         sqltoken.Pos // first position of UNNEST keyword
+	Exprs          []Node
+	RParen         sqltoken.Pos
+	WithOrdinality bool
+	Ordinality     sqltoken.Pos // last position of ORDINALITY keyword if WithOrdinality is true
+	Alias          *Ident
+	Columns        []*Ident     // column aliases, `AS Alias(Columns...)`
+	ColumnsRParen  sqltoken.Pos // RParen position of column aliases (if Columns is not empty)
+}
+
+func (u *Unnest) Pos() sqltoken.Pos {
+	return u.Unnest
+}
+
+func (u *Unnest) End() sqltoken.Pos {
+	if len(u.Columns) != 0 {
+		return u.ColumnsRParen
+	}
+
+	if u.Alias != nil {
+		return u.Alias.End()
+	}
+
+	if u.WithOrdinality {
+		return u.Ordinality
 	}
+
+	return u.RParen
+}
+
+func (u *Unnest) ToSQLString() string {
+	return toSQLString(u)
+}
+
+func (u *Unnest) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("UNNEST")).LParen().Nodes(u.Exprs).RParen()
+	sw.If(u.WithOrdinality, []byte(" WITH ORDINALITY"))
+	writeTableAlias(sw, u.Alias, u.Columns)
 	return sw.End()
 }
 
+func writeTableAlias(sw *sqlWriter, alias *Ident, columns []*Ident) {
+	if alias == nil {
+		return
+	}
+	sw.As().Node(alias)
+	if len(columns) != 0 {
+		sw.LParen().Idents(columns, []byte(", ")).RParen()
+	}
+}
+
 //go:generate genmark -t SQLSelectItem -e Node
 
 type UnnamedSelectItem struct {
@@ -752,13 +1358,19 @@ func (o *OrderByExpr) WriteTo(w io.Writer) (int64, error) {
 	return sw.End()
 }
 
-// LIMIT [ALL | LimitValue ] [ OFFSET OffsetValue]
+//go:generate genmark -t LimitCount -e Node
+
+// `LIMIT {LimitValue | ALL} [OFFSET OffsetValue [ROW | ROWS]]`. LIMIT is
+// omitted if LimitValue is nil and All is false, i.e: `OFFSET 10 ROWS` before
+// FETCH. `OFFSET n LIMIT m` of PostgreSQL is written as `LIMIT m OFFSET n`.
 type LimitExpr struct {
 	All         bool
 	AllPos      sqltoken.Pos // ALL keyword position if All is true
-	Limit       sqltoken.Pos // Limit keyword position
-	LimitValue  *LongValue
-	OffsetValue *LongValue
+	Limit       sqltoken.Pos // first position of LIMIT keyword, or OFFSET keyword if it comes first
+	LimitValue  LimitCount   // *LongValue, *UnsignedLongValue beyond the range of int64, or *Placeholder
+	OffsetValue LimitCount   // *LongValue or *Placeholder
+	OffsetUnit  FetchUnit    // ROW or ROWS after OffsetValue
+	To          sqltoken.Pos // last position of the clause
 }
 
 func (l *LimitExpr) Pos() sqltoken.Pos {
@@ -766,14 +1378,18 @@ func (l *LimitExpr) Pos() sqltoken.Pos {
 }
 
 func (l *LimitExpr) End() sqltoken.Pos {
-	if l.All {
-		return l.AllPos
+	if l.To != (sqltoken.Pos{}) {
+		return l.To
 	}
 
 	if l.OffsetValue != nil {
-		return l.OffsetValue.To
+		return l.OffsetValue.End()
+	}
+
+	if l.All {
+		return l.AllPos
 	}
-	return l.LimitValue.To
+	return l.LimitValue.End()
 }
 
 func (l *LimitExpr) ToSQLString() string {
@@ -782,14 +1398,119 @@ func (l *LimitExpr) ToSQLString() string {
 
 func (l *LimitExpr) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Bytes([]byte("LIMIT "))
-	if l.All {
-		sw.Bytes([]byte("ALL"))
-	} else {
-		sw.Node(l.LimitValue)
+	hasLimit := l.All || l.LimitValue != nil
+	if hasLimit {
+		sw.Bytes([]byte("LIMIT "))
+		if l.All {
+			sw.Bytes([]byte("ALL"))
+		} else {
+			sw.Node(l.LimitValue)
+		}
 	}
 	if l.OffsetValue != nil {
-		sw.Bytes([]byte(" OFFSET ")).Node(l.OffsetValue)
+		sw.If(hasLimit, spaceBytes).Bytes([]byte("OFFSET ")).Node(l.OffsetValue)
+		if l.OffsetUnit != FetchUnitNone {
+			sw.Space().Bytes([]byte(l.OffsetUnit.ToSQLString()))
+		}
+	}
+	return sw.End()
+}
+
+// FetchUnit is the ROW or ROWS keyword after the count of OFFSET and FETCH.
+type FetchUnit int
+
+const (
+	FetchUnitNone FetchUnit = iota // omitted
+	FetchUnitRow
+	FetchUnitRows
+)
+
+func (f FetchUnit) ToSQLString() string {
+	switch f {
+	case FetchUnitRow:
+		return "ROW"
+	case FetchUnitRows:
+		return "ROWS"
+	}
+	return ""
+}
+
+// ANSI fetch clause `FETCH {FIRST | NEXT} [Count] {ROW | ROWS} {ONLY | WITH TIES}`.
+// Count is nil if omitted, which means 1.
+type Fetch struct {
+	Fetch    sqltoken.Pos // first position of FETCH keyword
+	Next     bool         // NEXT is written instead of FIRST
+	Count    LimitCount   // *LongValue or *Placeholder
+	Unit     FetchUnit    // ROW or ROWS
+	WithTies bool
+	To       sqltoken.Pos // last position of ONLY or TIES
+}
+
+func (f *Fetch) Pos() sqltoken.Pos {
+	return f.Fetch
+}
+
+func (f *Fetch) End() sqltoken.Pos {
+	return f.To
+}
+
+func (f *Fetch) ToSQLString() string {
+	return toSQLString(f)
+}
+
+func (f *Fetch) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	if f.Next {
+		sw.Bytes([]byte("FETCH NEXT "))
+	} else {
+		sw.Bytes([]byte("FETCH FIRST "))
+	}
+	if f.Count != nil {
+		sw.Node(f.Count).Space()
+	}
+	unit := f.Unit
+	if unit == FetchUnitNone {
+		unit = FetchUnitRows
+	}
+	sw.Bytes([]byte(unit.ToSQLString()))
+	if f.WithTies {
+		return sw.Bytes([]byte(" WITH TIES")).End()
+	}
+	return sw.Bytes([]byte(" ONLY")).End()
+}
+
+// SQL Server `TOP Count [PERCENT] [WITH TIES]` following SELECT [DISTINCT].
+// Count is written in parentheses if Parens is true, i.e: TOP (10).
+type Top struct {
+	Top      sqltoken.Pos // first position of TOP keyword
+	Count    LimitCount   // *LongValue or *Placeholder
+	Parens   bool
+	Percent  bool
+	WithTies bool
+	To       sqltoken.Pos // last position of the clause
+}
+
+func (t *Top) Pos() sqltoken.Pos {
+	return t.Top
+}
+
+func (t *Top) End() sqltoken.Pos {
+	return t.To
+}
+
+func (t *Top) ToSQLString() string {
+	return toSQLString(t)
+}
+
+func (t *Top) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("TOP "))
+	if t.Parens {
+		sw.LParen().Node(t.Count).RParen()
+	} else {
+		sw.Node(t.Count)
 	}
+	sw.If(t.Percent, []byte(" PERCENT"))
+	sw.If(t.WithTies, []byte(" WITH TIES"))
 	return sw.End()
 }
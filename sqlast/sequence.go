@@ -0,0 +1,316 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// `CREATE [TEMPORARY] SEQUENCE [IF NOT EXISTS] Name [Options...]`
+type CreateSequenceStmt struct {
+	stmt
+	Create    sqltoken.Pos
+	Temporary bool
+	NotExists bool
+	Name      *ObjectName
+	Options   []SequenceOption
+}
+
+func (c *CreateSequenceStmt) Pos() sqltoken.Pos {
+	return c.Create
+}
+
+func (c *CreateSequenceStmt) End() sqltoken.Pos {
+	if len(c.Options) != 0 {
+		return c.Options[len(c.Options)-1].End()
+	}
+	return c.Name.End()
+}
+
+func (c *CreateSequenceStmt) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CreateSequenceStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("CREATE ")).If(c.Temporary, []byte("TEMPORARY ")).Bytes([]byte("SEQUENCE "))
+	sw.If(c.NotExists, []byte("IF NOT EXISTS ")).Node(c.Name)
+	for _, o := range c.Options {
+		sw.Space().Node(o)
+	}
+	return sw.End()
+}
+
+// `ALTER SEQUENCE [IF EXISTS] Name Options...`
+type AlterSequenceStmt struct {
+	stmt
+	Alter    sqltoken.Pos
+	IfExists bool
+	Name     *ObjectName
+	Options  []SequenceOption
+}
+
+func (a *AlterSequenceStmt) Pos() sqltoken.Pos {
+	return a.Alter
+}
+
+func (a *AlterSequenceStmt) End() sqltoken.Pos {
+	return a.Options[len(a.Options)-1].End()
+}
+
+func (a *AlterSequenceStmt) ToSQLString() string {
+	return toSQLString(a)
+}
+
+func (a *AlterSequenceStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("ALTER SEQUENCE ")).If(a.IfExists, []byte("IF EXISTS ")).Node(a.Name)
+	for _, o := range a.Options {
+		sw.Space().Node(o)
+	}
+	return sw.End()
+}
+
+//go:generate genmark -t SequenceOption -e Node
+
+// `AS DataType`
+type SequenceDataType struct {
+	sequenceOption
+	As       sqltoken.Pos
+	DataType Type
+}
+
+func (s *SequenceDataType) Pos() sqltoken.Pos {
+	return s.As
+}
+
+func (s *SequenceDataType) End() sqltoken.Pos {
+	return s.DataType.End()
+}
+
+func (s *SequenceDataType) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SequenceDataType) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("AS ")).Node(s.DataType).End()
+}
+
+// `INCREMENT [BY] Value`
+type SequenceIncrement struct {
+	sequenceOption
+	Increment sqltoken.Pos
+	By        bool
+	Value     *LongValue
+}
+
+func (s *SequenceIncrement) Pos() sqltoken.Pos {
+	return s.Increment
+}
+
+func (s *SequenceIncrement) End() sqltoken.Pos {
+	return s.Value.End()
+}
+
+func (s *SequenceIncrement) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SequenceIncrement) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("INCREMENT ")).If(s.By, []byte("BY ")).Node(s.Value).End()
+}
+
+// `MINVALUE Value` or `NO MINVALUE` (Value is nil)
+type SequenceMinValue struct {
+	sequenceOption
+	From, To sqltoken.Pos
+	Value    *LongValue
+}
+
+func (s *SequenceMinValue) Pos() sqltoken.Pos {
+	return s.From
+}
+
+func (s *SequenceMinValue) End() sqltoken.Pos {
+	if s.Value != nil {
+		return s.Value.End()
+	}
+	return s.To
+}
+
+func (s *SequenceMinValue) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SequenceMinValue) WriteTo(w io.Writer) (int64, error) {
+	if s.Value == nil {
+		return writeSingleBytes(w, []byte("NO MINVALUE"))
+	}
+	return newSQLWriter(w).Bytes([]byte("MINVALUE ")).Node(s.Value).End()
+}
+
+// `MAXVALUE Value` or `NO MAXVALUE` (Value is nil)
+type SequenceMaxValue struct {
+	sequenceOption
+	From, To sqltoken.Pos
+	Value    *LongValue
+}
+
+func (s *SequenceMaxValue) Pos() sqltoken.Pos {
+	return s.From
+}
+
+func (s *SequenceMaxValue) End() sqltoken.Pos {
+	if s.Value != nil {
+		return s.Value.End()
+	}
+	return s.To
+}
+
+func (s *SequenceMaxValue) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SequenceMaxValue) WriteTo(w io.Writer) (int64, error) {
+	if s.Value == nil {
+		return writeSingleBytes(w, []byte("NO MAXVALUE"))
+	}
+	return newSQLWriter(w).Bytes([]byte("MAXVALUE ")).Node(s.Value).End()
+}
+
+// `START [WITH] Value`
+type SequenceStart struct {
+	sequenceOption
+	Start sqltoken.Pos
+	With  bool
+	Value *LongValue
+}
+
+func (s *SequenceStart) Pos() sqltoken.Pos {
+	return s.Start
+}
+
+func (s *SequenceStart) End() sqltoken.Pos {
+	return s.Value.End()
+}
+
+func (s *SequenceStart) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SequenceStart) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("START ")).If(s.With, []byte("WITH ")).Node(s.Value).End()
+}
+
+// `RESTART [[WITH] Value]` (ALTER SEQUENCE only)
+type SequenceRestart struct {
+	sequenceOption
+	Restart sqltoken.Pos
+	To      sqltoken.Pos
+	With    bool
+	Value   *LongValue
+}
+
+func (s *SequenceRestart) Pos() sqltoken.Pos {
+	return s.Restart
+}
+
+func (s *SequenceRestart) End() sqltoken.Pos {
+	if s.Value != nil {
+		return s.Value.End()
+	}
+	return s.To
+}
+
+func (s *SequenceRestart) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SequenceRestart) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("RESTART"))
+	if s.Value != nil {
+		sw.Space().If(s.With, []byte("WITH ")).Node(s.Value)
+	}
+	return sw.End()
+}
+
+// `CACHE Value`
+type SequenceCache struct {
+	sequenceOption
+	Cache sqltoken.Pos
+	Value *LongValue
+}
+
+func (s *SequenceCache) Pos() sqltoken.Pos {
+	return s.Cache
+}
+
+func (s *SequenceCache) End() sqltoken.Pos {
+	return s.Value.End()
+}
+
+func (s *SequenceCache) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SequenceCache) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("CACHE ")).Node(s.Value).End()
+}
+
+// `[NO] CYCLE`
+type SequenceCycle struct {
+	sequenceOption
+	From, To sqltoken.Pos
+	No       bool
+}
+
+func (s *SequenceCycle) Pos() sqltoken.Pos {
+	return s.From
+}
+
+func (s *SequenceCycle) End() sqltoken.Pos {
+	return s.To
+}
+
+func (s *SequenceCycle) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SequenceCycle) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).If(s.No, []byte("NO ")).Bytes([]byte("CYCLE")).End()
+}
+
+// `OWNED BY {Column | NONE}` (Column is nil for NONE)
+type SequenceOwnedBy struct {
+	sequenceOption
+	Owned  sqltoken.Pos
+	To     sqltoken.Pos
+	Column *ObjectName
+}
+
+func (s *SequenceOwnedBy) Pos() sqltoken.Pos {
+	return s.Owned
+}
+
+func (s *SequenceOwnedBy) End() sqltoken.Pos {
+	if s.Column != nil {
+		return s.Column.End()
+	}
+	return s.To
+}
+
+func (s *SequenceOwnedBy) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SequenceOwnedBy) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("OWNED BY "))
+	if s.Column == nil {
+		sw.Bytes([]byte("NONE"))
+	} else {
+		sw.Node(s.Column)
+	}
+	return sw.End()
+}
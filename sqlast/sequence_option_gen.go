@@ -0,0 +1,11 @@
+package sqlast
+
+// Code generated by genmark. DO NOT EDIT.
+
+type SequenceOption interface {
+	sequenceOptionMarker()
+	Node
+}
+type sequenceOption struct{}
+
+func (sequenceOption) sequenceOptionMarker() {}
@@ -0,0 +1,147 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+//go:generate genmark -t SessionStmt -e Stmt
+
+// `SET [Scope] Name {= | TO} Values...`
+// Scope is one of SESSION, LOCAL and GLOBAL or empty.
+type SetStmt struct {
+	stmt
+	sessionStmt
+	Set    sqltoken.Pos
+	Scope  string
+	Name   *ObjectName
+	Equal  bool // `=` is used instead of TO
+	Values []Node
+}
+
+func (s *SetStmt) Pos() sqltoken.Pos {
+	return s.Set
+}
+
+func (s *SetStmt) End() sqltoken.Pos {
+	return s.Values[len(s.Values)-1].End()
+}
+
+func (s *SetStmt) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SetStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("SET "))
+	if s.Scope != "" {
+		sw.Bytes([]byte(s.Scope)).Space()
+	}
+	sw.Node(s.Name)
+	if s.Equal {
+		sw.Bytes([]byte(" = "))
+	} else {
+		sw.Bytes([]byte(" TO "))
+	}
+	for i, v := range s.Values {
+		sw.JoinComma(i, v)
+	}
+	return sw.End()
+}
+
+// `SET [Scope] TIME ZONE Value`
+type SetTimeZoneStmt struct {
+	stmt
+	sessionStmt
+	Set   sqltoken.Pos
+	Scope string
+	Value Node
+}
+
+func (s *SetTimeZoneStmt) Pos() sqltoken.Pos {
+	return s.Set
+}
+
+func (s *SetTimeZoneStmt) End() sqltoken.Pos {
+	return s.Value.End()
+}
+
+func (s *SetTimeZoneStmt) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SetTimeZoneStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("SET "))
+	if s.Scope != "" {
+		sw.Bytes([]byte(s.Scope)).Space()
+	}
+	return sw.Bytes([]byte("TIME ZONE ")).Node(s.Value).End()
+}
+
+// `SHOW {Name | ALL}` (Name is nil for ALL)
+type ShowStmt struct {
+	stmt
+	sessionStmt
+	Show sqltoken.Pos
+	Name *ObjectName
+	To   sqltoken.Pos // end of ALL
+}
+
+func (s *ShowStmt) Pos() sqltoken.Pos {
+	return s.Show
+}
+
+func (s *ShowStmt) End() sqltoken.Pos {
+	if s.Name != nil {
+		return s.Name.End()
+	}
+	return s.To
+}
+
+func (s *ShowStmt) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *ShowStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("SHOW "))
+	if s.Name == nil {
+		return sw.Bytes([]byte("ALL")).End()
+	}
+	return sw.Node(s.Name).End()
+}
+
+// `RESET {Name | ALL}` (Name is nil for ALL)
+type ResetStmt struct {
+	stmt
+	sessionStmt
+	Reset sqltoken.Pos
+	Name  *ObjectName
+	To    sqltoken.Pos // end of ALL
+}
+
+func (r *ResetStmt) Pos() sqltoken.Pos {
+	return r.Reset
+}
+
+func (r *ResetStmt) End() sqltoken.Pos {
+	if r.Name != nil {
+		return r.Name.End()
+	}
+	return r.To
+}
+
+func (r *ResetStmt) ToSQLString() string {
+	return toSQLString(r)
+}
+
+func (r *ResetStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("RESET "))
+	if r.Name == nil {
+		return sw.Bytes([]byte("ALL")).End()
+	}
+	return sw.Node(r.Name).End()
+}
@@ -0,0 +1,75 @@
+package sqlast
+
+import (
+	"io"
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// Source is the source text of a File, kept when it is parsed with xsqlparser.KeepSource.
+type Source struct {
+	Tokens []*sqltoken.Token // every token of the source including whitespace and comments
+	Stmts  []*StmtSource     // in the same order as File.Stmts when parsed
+}
+
+// StmtSource is the range of a statement in Source.Tokens.
+type StmtSource struct {
+	Stmt     Stmt
+	From, To int    // Tokens[From:To] is the statement without surrounding whitespace, comments and semicolon
+	SQL      string // Stmt.ToSQLString() when parsed, used to detect modification
+}
+
+// WriteSourceTo writes f keeping the original text. Statements which are
+// not modified since parsed are written as they are in the source, and the
+// whitespace, comments and semicolons between statements are preserved, so
+// an unmodified File is written byte-identical to its source. Modified
+// statements are written with WriteTo in place.
+//
+// If f has no Source, or statements have been added, removed or reordered,
+// f is written with WriteTo.
+func (f *File) WriteSourceTo(w io.Writer) (int64, error) {
+	if !f.sourceAvailable() {
+		return f.WriteTo(w)
+	}
+
+	sw := newSQLWriter(w)
+	tokens := f.Source.Tokens
+	writeTokens := func(from, to int) {
+		for _, tok := range tokens[from:to] {
+			sw.Bytes([]byte(tok.Raw))
+		}
+	}
+
+	prev := 0
+	for _, s := range f.Source.Stmts {
+		writeTokens(prev, s.From)
+		if sql := s.Stmt.ToSQLString(); sql != s.SQL {
+			sw.Bytes([]byte(sql))
+		} else {
+			writeTokens(s.From, s.To)
+		}
+		prev = s.To
+	}
+	writeTokens(prev, len(tokens))
+	return sw.End()
+}
+
+// ToSourceString returns f written by WriteSourceTo.
+func (f *File) ToSourceString() string {
+	var b strings.Builder
+	_, _ = f.WriteSourceTo(&b)
+	return b.String()
+}
+
+func (f *File) sourceAvailable() bool {
+	if f.Source == nil || len(f.Source.Stmts) != len(f.Stmts) {
+		return false
+	}
+	for i, s := range f.Source.Stmts {
+		if s.Stmt != f.Stmts[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -13,10 +13,12 @@ import (
 type InsertStmt struct {
 	stmt
 	Insert            sqltoken.Pos // first position of INSERT keyword
+	Hints             *OptimizerHints
 	TableName         *ObjectName
 	Columns           []*Ident
 	Source            InsertSource  // Insert Source [SubQuery or Constructor]
 	UpdateAssignments []*Assignment // MySQL only (ON DUPLICATED KEYS)
+	Returning         []SQLSelectItem
 }
 
 func (i *InsertStmt) Pos() sqltoken.Pos {
@@ -24,6 +26,10 @@ func (i *InsertStmt) Pos() sqltoken.Pos {
 }
 
 func (i *InsertStmt) End() sqltoken.Pos {
+	if len(i.Returning) != 0 {
+		return i.Returning[len(i.Returning)-1].End()
+	}
+
 	if len(i.UpdateAssignments) != 0 {
 		return i.UpdateAssignments[len(i.UpdateAssignments)-1].End()
 	}
@@ -37,7 +43,11 @@ func (i *InsertStmt) ToSQLString() string {
 
 func (i *InsertStmt) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Bytes([]byte("INSERT INTO ")).Node(i.TableName).Space()
+	sw.Bytes([]byte("INSERT "))
+	if i.Hints != nil {
+		sw.Node(i.Hints).Space()
+	}
+	sw.Bytes([]byte("INTO ")).Node(i.TableName).Space()
 	if len(i.Columns) != 0 {
 		sw.LParen().Idents(i.Columns, []byte(", ")).RParen().Space()
 	}
@@ -48,6 +58,12 @@ func (i *InsertStmt) WriteTo(w io.Writer) (int64, error) {
 			sw.JoinComma(i, assignment)
 		}
 	}
+	if len(i.Returning) != 0 {
+		sw.Bytes([]byte(" RETURNING "))
+		for i, r := range i.Returning {
+			sw.JoinComma(i, r)
+		}
+	}
 	return sw.End()
 }
 
@@ -129,22 +145,29 @@ func (r *RowValueExpr) WriteTo(w io.Writer) (int64, error) {
 	return sw.End()
 }
 
-// TODO Remove CopyStmt
+// `COPY {TableName [(Columns...)] | (Query)} {FROM | TO} {STDIN | STDOUT | 'Filename'} [[WITH] (Options...)]`
+// Rows holds the inline data block after `COPY ... FROM STDIN;` terminated by `\.`
 type CopyStmt struct {
 	stmt
-	Copy      sqltoken.Pos
-	TableName *ObjectName
-	Columns   []*Ident
-	Values    []*string
+	Copy        sqltoken.Pos
+	TableName   *ObjectName
+	Columns     []*Ident
+	Query       *QueryStmt // COPY (Query) TO ...
+	Direction   CopyDirection
+	Filename    *SingleQuotedString // nil means STDIN or STDOUT
+	Options     []*CopyOption
+	BareOptions bool         // Options are written without WITH (...) separated by spaces (Redshift)
+	InlineData  bool         // true if inline data block follows the statement
+	Rows        [][]*string  // nil element means NULL (`\N`)
+	CopyEnd     sqltoken.Pos // last position of the statement, or of `\.` if InlineData is true
 }
 
 func (c *CopyStmt) Pos() sqltoken.Pos {
 	return c.Copy
 }
 
-// TODO
 func (c *CopyStmt) End() sqltoken.Pos {
-	panic("not implemented")
+	return c.CopyEnd
 }
 
 func (c *CopyStmt) ToSQLString() string {
@@ -153,34 +176,109 @@ func (c *CopyStmt) ToSQLString() string {
 
 func (c *CopyStmt) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Bytes([]byte("COPY ")).Node(c.TableName)
-	if len(c.Columns) != 0 {
-		sw.Space().LParen().Idents(c.Columns, []byte(", ")).RParen()
-	}
-	sw.Bytes([]byte(" FROM stdin; "))
-	if len(c.Values) != 0 {
-		sw.Bytes([]byte("\n"))
-		for i, val := range c.Values {
-			if i > 0 {
-				sw.Bytes([]byte("\t"))
-			}
-			if val == nil {
-				sw.Bytes([]byte("\\N"))
-			} else {
-				sw.Bytes([]byte(*val))
+	sw.Bytes([]byte("COPY "))
+	if c.Query != nil {
+		sw.LParen().Node(c.Query).RParen()
+	} else {
+		sw.Node(c.TableName)
+		if len(c.Columns) != 0 {
+			sw.Space().LParen().Idents(c.Columns, []byte(", ")).RParen()
+		}
+	}
+
+	if c.Direction == CopyTo {
+		sw.Bytes([]byte(" TO "))
+	} else {
+		sw.Bytes([]byte(" FROM "))
+	}
+
+	switch {
+	case c.Filename != nil:
+		sw.Node(c.Filename)
+	case c.Direction == CopyTo:
+		sw.Bytes([]byte("STDOUT"))
+	default:
+		sw.Bytes([]byte("STDIN"))
+	}
+
+	if c.BareOptions {
+		for _, o := range c.Options {
+			sw.Space().Node(o)
+		}
+	} else if len(c.Options) != 0 {
+		sw.Bytes([]byte(" WITH ")).LParen()
+		for i, o := range c.Options {
+			sw.JoinComma(i, o)
+		}
+		sw.RParen()
+	}
+
+	if c.InlineData {
+		sw.Bytes([]byte(";\n"))
+		for _, row := range c.Rows {
+			for i, val := range row {
+				if i > 0 {
+					sw.Bytes([]byte("\t"))
+				}
+				if val == nil {
+					sw.Bytes([]byte("\\N"))
+				} else {
+					sw.Bytes([]byte(*val))
+				}
 			}
+			sw.Bytes([]byte("\n"))
 		}
+		sw.Bytes([]byte("\\."))
+	}
+	return sw.End()
+}
+
+type CopyDirection int
+
+const (
+	CopyFrom CopyDirection = iota
+	CopyTo
+)
+
+// option of COPY statement such as `FORMAT csv`, `HEADER` or `FORCE_QUOTE (a, b)`
+type CopyOption struct {
+	Name  *Ident
+	As    bool // `Name AS Value` (Redshift)
+	Value Node // nil if omitted
+}
+
+func (c *CopyOption) Pos() sqltoken.Pos {
+	return c.Name.Pos()
+}
+
+func (c *CopyOption) End() sqltoken.Pos {
+	if c.Value != nil {
+		return c.Value.End()
+	}
+	return c.Name.End()
+}
+
+func (c *CopyOption) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CopyOption) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Node(c.Name).If(c.As, []byte(" AS"))
+	if c.Value != nil {
+		sw.Space().Node(c.Value)
 	}
-	sw.Bytes([]byte("\n\\."))
 	return sw.End()
 }
 
 type UpdateStmt struct {
 	stmt
 	Update      sqltoken.Pos
+	Hints       *OptimizerHints
 	TableName   *ObjectName
 	Assignments []*Assignment
 	Selection   Node
+	Returning   []SQLSelectItem
 }
 
 func (u *UpdateStmt) Pos() sqltoken.Pos {
@@ -188,6 +286,10 @@ func (u *UpdateStmt) Pos() sqltoken.Pos {
 }
 
 func (u *UpdateStmt) End() sqltoken.Pos {
+	if len(u.Returning) != 0 {
+		return u.Returning[len(u.Returning)-1].End()
+	}
+
 	if u.Selection != nil {
 		return u.Selection.End()
 	}
@@ -201,7 +303,11 @@ func (u *UpdateStmt) ToSQLString() string {
 
 func (u *UpdateStmt) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Bytes([]byte("UPDATE ")).Node(u.TableName).Bytes([]byte(" SET "))
+	sw.Bytes([]byte("UPDATE "))
+	if u.Hints != nil {
+		sw.Node(u.Hints).Space()
+	}
+	sw.Node(u.TableName).Bytes([]byte(" SET "))
 	if u.Assignments != nil {
 		for i, assignment := range u.Assignments {
 			sw.JoinComma(i, assignment)
@@ -210,14 +316,25 @@ func (u *UpdateStmt) WriteTo(w io.Writer) (int64, error) {
 	if u.Selection != nil {
 		sw.Bytes([]byte(" WHERE ")).Node(u.Selection)
 	}
+	if len(u.Returning) != 0 {
+		sw.Bytes([]byte(" RETURNING "))
+		for i, r := range u.Returning {
+			sw.JoinComma(i, r)
+		}
+	}
 	return sw.End()
 }
 
+// `DELETE [Hints] FROM TableName [[AS] Alias] [USING Using...] [WHERE Selection] [RETURNING Returning...]`
 type DeleteStmt struct {
 	stmt
 	Delete    sqltoken.Pos
+	Hints     *OptimizerHints
 	TableName *ObjectName
+	Alias     *Ident
+	Using     []TableReference
 	Selection Node
+	Returning []SQLSelectItem
 }
 
 func (d *DeleteStmt) Pos() sqltoken.Pos {
@@ -225,10 +342,22 @@ func (d *DeleteStmt) Pos() sqltoken.Pos {
 }
 
 func (d *DeleteStmt) End() sqltoken.Pos {
+	if len(d.Returning) != 0 {
+		return d.Returning[len(d.Returning)-1].End()
+	}
+
 	if d.Selection != nil {
 		return d.Selection.End()
 	}
 
+	if len(d.Using) != 0 {
+		return d.Using[len(d.Using)-1].End()
+	}
+
+	if d.Alias != nil {
+		return d.Alias.End()
+	}
+
 	return d.TableName.End()
 }
 
@@ -238,10 +367,29 @@ func (d *DeleteStmt) ToSQLString() string {
 
 func (d *DeleteStmt) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Bytes([]byte("DELETE FROM ")).Node(d.TableName)
+	sw.Bytes([]byte("DELETE "))
+	if d.Hints != nil {
+		sw.Node(d.Hints).Space()
+	}
+	sw.Bytes([]byte("FROM ")).Node(d.TableName)
+	if d.Alias != nil {
+		sw.As().Node(d.Alias)
+	}
+	if len(d.Using) != 0 {
+		sw.Bytes([]byte(" USING "))
+		for i, u := range d.Using {
+			sw.JoinComma(i, u)
+		}
+	}
 	if d.Selection != nil {
 		sw.Bytes([]byte(" WHERE ")).Node(d.Selection)
 	}
+	if len(d.Returning) != 0 {
+		sw.Bytes([]byte(" RETURNING "))
+		for i, r := range d.Returning {
+			sw.JoinComma(i, r)
+		}
+	}
 	return sw.End()
 }
 
@@ -276,11 +424,15 @@ func (c *CreateViewStmt) WriteTo(w io.Writer) (int64, error) {
 type CreateTableStmt struct {
 	stmt
 	Create    sqltoken.Pos
+	Temporary bool
 	Name      *ObjectName
 	Elements  []TableElement
 	Location  *string
 	NotExists bool
 	Options   []TableOption
+	// Postgres `PARTITION OF PartitionOf [(Elements...)] PartitionBound`
+	PartitionOf    *ObjectName
+	PartitionBound PartitionBound
 }
 
 func (c *CreateTableStmt) Pos() sqltoken.Pos {
@@ -288,6 +440,12 @@ func (c *CreateTableStmt) Pos() sqltoken.Pos {
 }
 
 func (c *CreateTableStmt) End() sqltoken.Pos {
+	if len(c.Options) != 0 {
+		return c.Options[len(c.Options)-1].End()
+	}
+	if c.PartitionBound != nil {
+		return c.PartitionBound.End()
+	}
 	return c.Elements[len(c.Elements)-1].End()
 }
 
@@ -297,17 +455,24 @@ func (c *CreateTableStmt) ToSQLString() string {
 
 func (c *CreateTableStmt) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Bytes([]byte("CREATE TABLE "))
+	sw.Bytes([]byte("CREATE ")).If(c.Temporary, []byte("TEMPORARY ")).Bytes([]byte("TABLE "))
 	sw.If(c.NotExists, []byte("IF NOT EXISTS "))
-	sw.Node(c.Name).Space().LParen()
-	for i, element := range c.Elements {
-		sw.JoinComma(i, element)
+	sw.Node(c.Name)
+	if c.PartitionOf != nil {
+		sw.Bytes([]byte(" PARTITION OF ")).Node(c.PartitionOf)
 	}
-	sw.RParen()
-	if len(c.Options) != 0 {
-		for i, option := range c.Options {
-			sw.JoinComma(i, option)
+	if c.PartitionOf == nil || len(c.Elements) != 0 {
+		sw.Space().LParen()
+		for i, element := range c.Elements {
+			sw.JoinComma(i, element)
 		}
+		sw.RParen()
+	}
+	if c.PartitionBound != nil {
+		sw.Space().Node(c.PartitionBound)
+	}
+	for _, option := range c.Options {
+		sw.Space().Node(option)
 	}
 	return sw.End()
 }
@@ -482,8 +647,10 @@ type ColumnDef struct {
 	tableElement
 	Name                 *Ident
 	DataType             Type
+	Charset              *Ident      // MySQL only, `CHARACTER SET Charset` after DataType
+	Collation            *ObjectName // `COLLATE Collation` after DataType
 	Default              Node
-	MyDataTypeDecoration []MyDataTypeDecoration // DataType Decoration for MySQL eg. AUTO_INCREMENT currently, only supports AUTO_INCREMENT
+	MyDataTypeDecoration []MyDataTypeDecoration // MySQL column attributes, i.e: AUTO_INCREMENT, ON UPDATE and COMMENT
 	Constraints          []*ColumnConstraint
 }
 
@@ -492,7 +659,31 @@ func (c *ColumnDef) Pos() sqltoken.Pos {
 }
 
 func (c *ColumnDef) End() sqltoken.Pos {
-	return c.Constraints[len(c.Constraints)-1].End()
+	// MySQL allows constraints, DEFAULT and decorations in any order
+	var end sqltoken.Pos
+	if len(c.Constraints) != 0 {
+		end = c.Constraints[len(c.Constraints)-1].End()
+	}
+	if len(c.MyDataTypeDecoration) != 0 {
+		if e := c.MyDataTypeDecoration[len(c.MyDataTypeDecoration)-1].End(); sqltoken.ComparePos(e, end) > 0 {
+			end = e
+		}
+	}
+	if c.Default != nil {
+		if e := c.Default.End(); sqltoken.ComparePos(e, end) > 0 {
+			end = e
+		}
+	}
+	if end.Line != 0 {
+		return end
+	}
+	if c.Collation != nil {
+		return c.Collation.End()
+	}
+	if c.Charset != nil {
+		return c.Charset.End()
+	}
+	return c.DataType.End()
 }
 
 func (c *ColumnDef) ToSQLString() string {
@@ -502,6 +693,12 @@ func (c *ColumnDef) ToSQLString() string {
 func (c *ColumnDef) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	sw.Node(c.Name).Space().Node(c.DataType)
+	if c.Charset != nil {
+		sw.Bytes([]byte(" CHARACTER SET ")).Node(c.Charset)
+	}
+	if c.Collation != nil {
+		sw.Bytes([]byte(" COLLATE ")).Node(c.Collation)
+	}
 	if c.Default != nil {
 		sw.Bytes([]byte(" DEFAULT ")).Node(c.Default)
 	}
@@ -514,6 +711,56 @@ func (c *ColumnDef) WriteTo(w io.Writer) (int64, error) {
 	return sw.End()
 }
 
+// Postgres `Name [WITH OPTIONS] [DEFAULT Default] [Constraints...]` of
+// CREATE TABLE ... PARTITION OF, whose type is of the parent table.
+type PartitionColumn struct {
+	tableElement
+	Name        *Ident
+	WithOptions bool
+	Options     sqltoken.Pos // end of WITH OPTIONS
+	Default     Node
+	Constraints []*ColumnConstraint
+}
+
+func (p *PartitionColumn) Pos() sqltoken.Pos {
+	return p.Name.Pos()
+}
+
+func (p *PartitionColumn) End() sqltoken.Pos {
+	var end sqltoken.Pos
+	if len(p.Constraints) != 0 {
+		end = p.Constraints[len(p.Constraints)-1].End()
+	}
+	if p.Default != nil {
+		if e := p.Default.End(); sqltoken.ComparePos(e, end) > 0 {
+			end = e
+		}
+	}
+	if end.Line != 0 {
+		return end
+	}
+	if p.WithOptions {
+		return p.Options
+	}
+	return p.Name.End()
+}
+
+func (p *PartitionColumn) ToSQLString() string {
+	return toSQLString(p)
+}
+
+func (p *PartitionColumn) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Node(p.Name).If(p.WithOptions, []byte(" WITH OPTIONS"))
+	if p.Default != nil {
+		sw.Bytes([]byte(" DEFAULT ")).Node(p.Default)
+	}
+	for _, cons := range p.Constraints {
+		sw.Node(cons)
+	}
+	return sw.End()
+}
+
 //go:generate genmark -t MyDataTypeDecoration -e Node
 
 type AutoIncrement struct {
@@ -538,6 +785,52 @@ func (a *AutoIncrement) End() sqltoken.Pos {
 	return a.Increment
 }
 
+// MySQL `ON UPDATE Expr`, e.g. ON UPDATE CURRENT_TIMESTAMP
+type MyOnUpdate struct {
+	myDataTypeDecoration
+	On   sqltoken.Pos
+	Expr Node
+}
+
+func (m *MyOnUpdate) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MyOnUpdate) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("ON UPDATE ")).Node(m.Expr).End()
+}
+
+func (m *MyOnUpdate) Pos() sqltoken.Pos {
+	return m.On
+}
+
+func (m *MyOnUpdate) End() sqltoken.Pos {
+	return m.Expr.End()
+}
+
+// MySQL `COMMENT 'Comment'` of a column
+type MyColumnComment struct {
+	myDataTypeDecoration
+	From    sqltoken.Pos
+	Comment *SingleQuotedString
+}
+
+func (m *MyColumnComment) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MyColumnComment) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("COMMENT ")).Node(m.Comment).End()
+}
+
+func (m *MyColumnComment) Pos() sqltoken.Pos {
+	return m.From
+}
+
+func (m *MyColumnComment) End() sqltoken.Pos {
+	return m.Comment.End()
+}
+
 type ColumnConstraint struct {
 	Name       *Ident
 	Constraint sqltoken.Pos
@@ -683,6 +976,105 @@ func (c *CheckColumnSpec) WriteTo(w io.Writer) (n int64, err error) {
 	return sw.End()
 }
 
+// `GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY [(Options...)]`
+type IdentityColumnSpec struct {
+	Generated sqltoken.Pos
+	Always    bool
+	Identity  sqltoken.Pos // end of IDENTITY
+	Options   []SequenceOption
+	RParen    sqltoken.Pos
+}
+
+func (i *IdentityColumnSpec) Pos() sqltoken.Pos {
+	return i.Generated
+}
+
+func (i *IdentityColumnSpec) End() sqltoken.Pos {
+	if len(i.Options) != 0 {
+		return i.RParen
+	}
+	return i.Identity
+}
+
+func (i *IdentityColumnSpec) ToSQLString() string {
+	return toSQLString(i)
+}
+
+func (i *IdentityColumnSpec) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("GENERATED "))
+	if i.Always {
+		sw.Bytes([]byte("ALWAYS"))
+	} else {
+		sw.Bytes([]byte("BY DEFAULT"))
+	}
+	sw.Bytes([]byte(" AS IDENTITY"))
+	if len(i.Options) != 0 {
+		sw.Bytes([]byte(" ("))
+		for j, o := range i.Options {
+			if j != 0 {
+				sw.Space()
+			}
+			sw.Node(o)
+		}
+		sw.RParen()
+	}
+	return sw.End()
+}
+
+// `GENERATED ALWAYS AS (Expr) [STORED | VIRTUAL]`, or MySQL
+// `AS (Expr) [STORED | VIRTUAL]` if Always is false
+type GeneratedColumnSpec struct {
+	From    sqltoken.Pos // first position of GENERATED or AS
+	Always  bool
+	Expr    Node
+	RParen  sqltoken.Pos
+	Storage GeneratedColumnStorage
+	To      sqltoken.Pos // end of STORED or VIRTUAL
+}
+
+func (g *GeneratedColumnSpec) Pos() sqltoken.Pos {
+	return g.From
+}
+
+func (g *GeneratedColumnSpec) End() sqltoken.Pos {
+	if g.Storage != GeneratedDefault {
+		return g.To
+	}
+	return g.RParen
+}
+
+func (g *GeneratedColumnSpec) ToSQLString() string {
+	return toSQLString(g)
+}
+
+func (g *GeneratedColumnSpec) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.If(g.Always, []byte("GENERATED ALWAYS ")).Bytes([]byte("AS (")).Node(g.Expr).RParen()
+	if g.Storage != GeneratedDefault {
+		sw.Space().Bytes([]byte(g.Storage.ToSQLString()))
+	}
+	return sw.End()
+}
+
+type GeneratedColumnStorage int
+
+const (
+	GeneratedDefault GeneratedColumnStorage = iota // neither STORED nor VIRTUAL
+	GeneratedStored
+	GeneratedVirtual
+)
+
+func (g GeneratedColumnStorage) ToSQLString() string {
+	switch g {
+	case GeneratedStored:
+		return "STORED"
+	case GeneratedVirtual:
+		return "VIRTUAL"
+	}
+	return ""
+}
+
 //TODO remove
 type FileFormat int
 
@@ -1052,6 +1444,8 @@ type CreateIndexStmt struct {
 	MethodName  *Ident
 	ColumnNames []*Ident
 	RParen      sqltoken.Pos
+	With        *WithOptions
+	Tablespace  *Tablespace
 	Selection   Node
 }
 
@@ -1063,6 +1457,12 @@ func (c *CreateIndexStmt) End() sqltoken.Pos {
 	if c.Selection != nil {
 		return c.Selection.End()
 	}
+	if c.Tablespace != nil {
+		return c.Tablespace.End()
+	}
+	if c.With != nil {
+		return c.With.End()
+	}
 
 	return c.RParen
 }
@@ -1082,6 +1482,12 @@ func (c *CreateIndexStmt) WriteTo(w io.Writer) (int64, error) {
 		sw.Bytes([]byte(" USING ")).Node(c.MethodName)
 	}
 	sw.Space().LParen().Idents(c.ColumnNames, []byte(", ")).RParen()
+	if c.With != nil {
+		sw.Space().Node(c.With)
+	}
+	if c.Tablespace != nil {
+		sw.Space().Node(c.Tablespace)
+	}
 	if c.Selection != nil {
 		sw.Bytes([]byte(" WHERE ")).Node(c.Selection)
 	}
@@ -1133,3 +1539,520 @@ func (e *ExplainStmt) ToSQLString() string {
 func (e *ExplainStmt) WriteTo(w io.Writer) (int64, error) {
 	return newSQLWriter(w).Bytes([]byte("EXPLAIN ")).Node(e.Stmt).End()
 }
+
+// `CREATE [OR REPLACE] {FUNCTION | PROCEDURE} Name ([Args...]) [RETURNS ...] [LANGUAGE Language] [Attributes...] [AS Body]`
+// Body is kept as the string literal it was written as, whatever Language is.
+type CreateFunctionStmt struct {
+	stmt
+	Create       sqltoken.Pos
+	OrReplace    bool
+	IsProcedure  bool
+	Name         *ObjectName
+	Args         []*FunctionArg
+	RParen       sqltoken.Pos
+	Returns      Type // nil unless RETURNS [SETOF] Type is given
+	ReturnsSetOf bool
+	ReturnsTable []*FunctionArg // RETURNS TABLE (ReturnsTable...)
+	Language     *Ident
+	Attributes   []*FunctionAttribute
+	Body         Node
+}
+
+func (c *CreateFunctionStmt) Pos() sqltoken.Pos {
+	return c.Create
+}
+
+// End returns the end of the last option, which can be written in any order.
+func (c *CreateFunctionStmt) End() sqltoken.Pos {
+	end := c.RParen
+	last := func(n Node) {
+		if sqltoken.ComparePos(n.End(), end) > 0 {
+			end = n.End()
+		}
+	}
+	if c.Returns != nil {
+		last(c.Returns)
+	}
+	if len(c.ReturnsTable) != 0 {
+		last(c.ReturnsTable[len(c.ReturnsTable)-1])
+	}
+	if c.Language != nil {
+		last(c.Language)
+	}
+	for _, a := range c.Attributes {
+		last(a)
+	}
+	if c.Body != nil {
+		last(c.Body)
+	}
+	return end
+}
+
+func (c *CreateFunctionStmt) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CreateFunctionStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("CREATE ")).If(c.OrReplace, []byte("OR REPLACE "))
+	if c.IsProcedure {
+		sw.Bytes([]byte("PROCEDURE "))
+	} else {
+		sw.Bytes([]byte("FUNCTION "))
+	}
+	sw.Node(c.Name).LParen()
+	for i, a := range c.Args {
+		sw.JoinComma(i, a)
+	}
+	sw.RParen()
+	if c.Returns != nil {
+		sw.Bytes([]byte(" RETURNS ")).If(c.ReturnsSetOf, []byte("SETOF ")).Node(c.Returns)
+	}
+	if len(c.ReturnsTable) != 0 {
+		sw.Bytes([]byte(" RETURNS TABLE ")).LParen()
+		for i, a := range c.ReturnsTable {
+			sw.JoinComma(i, a)
+		}
+		sw.RParen()
+	}
+	if c.Language != nil {
+		sw.Bytes([]byte(" LANGUAGE ")).Node(c.Language)
+	}
+	for _, a := range c.Attributes {
+		sw.Space().Node(a)
+	}
+	if c.Body != nil {
+		sw.Bytes([]byte(" AS ")).Node(c.Body)
+	}
+	return sw.End()
+}
+
+// `[Mode] [Name] Type [DEFAULT Default]` argument of CREATE FUNCTION.
+// Mode is one of IN, OUT, INOUT and VARIADIC or empty.
+type FunctionArg struct {
+	From    sqltoken.Pos
+	Mode    string
+	Name    *Ident
+	Type    Type
+	Default Node
+}
+
+func (f *FunctionArg) Pos() sqltoken.Pos {
+	return f.From
+}
+
+func (f *FunctionArg) End() sqltoken.Pos {
+	if f.Default != nil {
+		return f.Default.End()
+	}
+	return f.Type.End()
+}
+
+func (f *FunctionArg) ToSQLString() string {
+	return toSQLString(f)
+}
+
+func (f *FunctionArg) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	if f.Mode != "" {
+		sw.Bytes([]byte(f.Mode)).Space()
+	}
+	if f.Name != nil {
+		sw.Node(f.Name).Space()
+	}
+	sw.Node(f.Type)
+	if f.Default != nil {
+		sw.Bytes([]byte(" DEFAULT ")).Node(f.Default)
+	}
+	return sw.End()
+}
+
+// attribute of CREATE FUNCTION such as `IMMUTABLE`, `SECURITY DEFINER` or `COST 100`.
+// Name holds the keywords and Value the argument, if any.
+type FunctionAttribute struct {
+	From, To sqltoken.Pos
+	Name     string
+	Value    Node
+}
+
+func (f *FunctionAttribute) Pos() sqltoken.Pos {
+	return f.From
+}
+
+func (f *FunctionAttribute) End() sqltoken.Pos {
+	if f.Value != nil {
+		return f.Value.End()
+	}
+	return f.To
+}
+
+func (f *FunctionAttribute) ToSQLString() string {
+	return toSQLString(f)
+}
+
+func (f *FunctionAttribute) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte(f.Name))
+	if f.Value != nil {
+		sw.Space().Node(f.Value)
+	}
+	return sw.End()
+}
+
+// `CREATE SCHEMA [IF NOT EXISTS] [Name] [AUTHORIZATION Authorization]`
+// Either Name or Authorization is set.
+type CreateSchemaStmt struct {
+	stmt
+	Create        sqltoken.Pos
+	NotExists     bool
+	Name          *ObjectName
+	Authorization *Ident
+}
+
+func (c *CreateSchemaStmt) Pos() sqltoken.Pos {
+	return c.Create
+}
+
+func (c *CreateSchemaStmt) End() sqltoken.Pos {
+	if c.Authorization != nil {
+		return c.Authorization.End()
+	}
+	return c.Name.End()
+}
+
+func (c *CreateSchemaStmt) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CreateSchemaStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("CREATE SCHEMA ")).If(c.NotExists, []byte("IF NOT EXISTS "))
+	if c.Name != nil {
+		sw.Node(c.Name)
+		if c.Authorization != nil {
+			sw.Space()
+		}
+	}
+	if c.Authorization != nil {
+		sw.Bytes([]byte("AUTHORIZATION ")).Node(c.Authorization)
+	}
+	return sw.End()
+}
+
+// `CREATE DATABASE [IF NOT EXISTS] Name [WITH] [Options...]`
+type CreateDatabaseStmt struct {
+	stmt
+	Create    sqltoken.Pos
+	NotExists bool
+	Name      *ObjectName
+	With      bool
+	Options   []*DatabaseOption
+}
+
+func (c *CreateDatabaseStmt) Pos() sqltoken.Pos {
+	return c.Create
+}
+
+func (c *CreateDatabaseStmt) End() sqltoken.Pos {
+	if len(c.Options) != 0 {
+		return c.Options[len(c.Options)-1].End()
+	}
+	return c.Name.End()
+}
+
+func (c *CreateDatabaseStmt) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CreateDatabaseStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("CREATE DATABASE ")).If(c.NotExists, []byte("IF NOT EXISTS ")).Node(c.Name)
+	sw.If(c.With, []byte(" WITH"))
+	for _, o := range c.Options {
+		sw.Space().Node(o)
+	}
+	return sw.End()
+}
+
+// option of CREATE DATABASE such as `ENCODING = 'UTF8'`, `CONNECTION LIMIT 10`
+// or `DEFAULT CHARACTER SET utf8mb4` (MySQL). Name holds the keywords.
+type DatabaseOption struct {
+	From  sqltoken.Pos
+	Name  string
+	Equal bool
+	Value Node
+}
+
+func (d *DatabaseOption) Pos() sqltoken.Pos {
+	return d.From
+}
+
+func (d *DatabaseOption) End() sqltoken.Pos {
+	return d.Value.End()
+}
+
+func (d *DatabaseOption) ToSQLString() string {
+	return toSQLString(d)
+}
+
+func (d *DatabaseOption) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte(d.Name)).If(d.Equal, []byte(" =")).Space().Node(d.Value).End()
+}
+
+// `CREATE EXTENSION [IF NOT EXISTS] Name [WITH] [SCHEMA Schema] [VERSION Version] [CASCADE]`
+type CreateExtensionStmt struct {
+	stmt
+	Create    sqltoken.Pos
+	NotExists bool
+	Name      *Ident
+	With      bool
+	Schema    *Ident
+	Version   Node // identifier or string literal
+	Cascade   bool
+	CascadeTo sqltoken.Pos
+}
+
+func (c *CreateExtensionStmt) Pos() sqltoken.Pos {
+	return c.Create
+}
+
+func (c *CreateExtensionStmt) End() sqltoken.Pos {
+	if c.Cascade {
+		return c.CascadeTo
+	}
+	if c.Version != nil {
+		return c.Version.End()
+	}
+	if c.Schema != nil {
+		return c.Schema.End()
+	}
+	return c.Name.End()
+}
+
+func (c *CreateExtensionStmt) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CreateExtensionStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("CREATE EXTENSION ")).If(c.NotExists, []byte("IF NOT EXISTS ")).Node(c.Name)
+	sw.If(c.With, []byte(" WITH"))
+	if c.Schema != nil {
+		sw.Bytes([]byte(" SCHEMA ")).Node(c.Schema)
+	}
+	if c.Version != nil {
+		sw.Bytes([]byte(" VERSION ")).Node(c.Version)
+	}
+	sw.If(c.Cascade, []byte(" CASCADE"))
+	return sw.End()
+}
+
+// `CREATE TYPE Name [AS Definition]`
+// Definition is nil for a shell type (`CREATE TYPE Name`).
+type CreateTypeStmt struct {
+	stmt
+	Create     sqltoken.Pos
+	Name       *ObjectName
+	Definition TypeDefinition
+}
+
+func (c *CreateTypeStmt) Pos() sqltoken.Pos {
+	return c.Create
+}
+
+func (c *CreateTypeStmt) End() sqltoken.Pos {
+	if c.Definition != nil {
+		return c.Definition.End()
+	}
+	return c.Name.End()
+}
+
+func (c *CreateTypeStmt) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CreateTypeStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("CREATE TYPE ")).Node(c.Name)
+	if c.Definition != nil {
+		sw.As().Node(c.Definition)
+	}
+	return sw.End()
+}
+
+//go:generate genmark -t TypeDefinition -e Node
+
+// `ENUM (Labels...)`
+type EnumTypeDefinition struct {
+	typeDefinition
+	Enum   sqltoken.Pos
+	Labels []*SingleQuotedString
+	RParen sqltoken.Pos
+}
+
+func (e *EnumTypeDefinition) Pos() sqltoken.Pos {
+	return e.Enum
+}
+
+func (e *EnumTypeDefinition) End() sqltoken.Pos {
+	return e.RParen
+}
+
+func (e *EnumTypeDefinition) ToSQLString() string {
+	return toSQLString(e)
+}
+
+func (e *EnumTypeDefinition) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("ENUM ("))
+	for i, l := range e.Labels {
+		sw.JoinComma(i, l)
+	}
+	return sw.RParen().End()
+}
+
+// `(Attributes...)`
+type CompositeTypeDefinition struct {
+	typeDefinition
+	LParen     sqltoken.Pos
+	Attributes []*ColumnDef
+	RParen     sqltoken.Pos
+}
+
+func (c *CompositeTypeDefinition) Pos() sqltoken.Pos {
+	return c.LParen
+}
+
+func (c *CompositeTypeDefinition) End() sqltoken.Pos {
+	return c.RParen
+}
+
+func (c *CompositeTypeDefinition) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CompositeTypeDefinition) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.LParen()
+	for i, a := range c.Attributes {
+		sw.JoinComma(i, a)
+	}
+	return sw.RParen().End()
+}
+
+// `RANGE (SUBTYPE = Type [, Options...])`
+type RangeTypeDefinition struct {
+	typeDefinition
+	Range   sqltoken.Pos
+	Options []*TypeOption
+	RParen  sqltoken.Pos
+}
+
+func (r *RangeTypeDefinition) Pos() sqltoken.Pos {
+	return r.Range
+}
+
+func (r *RangeTypeDefinition) End() sqltoken.Pos {
+	return r.RParen
+}
+
+func (r *RangeTypeDefinition) ToSQLString() string {
+	return toSQLString(r)
+}
+
+func (r *RangeTypeDefinition) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("RANGE ")).LParen()
+	for i, o := range r.Options {
+		sw.JoinComma(i, o)
+	}
+	return sw.RParen().End()
+}
+
+// `Name = Value` option of RANGE type such as `SUBTYPE = float8`.
+// Value is a Type for SUBTYPE and an *ObjectName otherwise.
+type TypeOption struct {
+	Name  *Ident
+	Value Node
+}
+
+func (t *TypeOption) Pos() sqltoken.Pos {
+	return t.Name.Pos()
+}
+
+func (t *TypeOption) End() sqltoken.Pos {
+	return t.Value.End()
+}
+
+func (t *TypeOption) ToSQLString() string {
+	return toSQLString(t)
+}
+
+func (t *TypeOption) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Node(t.Name).Bytes([]byte(" = ")).Node(t.Value).End()
+}
+
+// `TRUNCATE [TABLE] TableNames... [RESTART IDENTITY | CONTINUE IDENTITY] [CASCADE | RESTRICT]`
+type TruncateStmt struct {
+	stmt
+	Truncate   sqltoken.Pos
+	Table      bool
+	Only       bool // ONLY precedes TableNames, i.e: TRUNCATE TABLE ONLY a
+	TableNames []*ObjectName
+	Identity   TruncateIdentity
+	Behavior   DropBehavior
+	To         sqltoken.Pos // end of the last option, if any
+}
+
+func (t *TruncateStmt) Pos() sqltoken.Pos {
+	return t.Truncate
+}
+
+func (t *TruncateStmt) End() sqltoken.Pos {
+	if t.Identity != TruncateIdentityDefault || t.Behavior != DropBehaviorDefault {
+		return t.To
+	}
+	return t.TableNames[len(t.TableNames)-1].End()
+}
+
+func (t *TruncateStmt) ToSQLString() string {
+	return toSQLString(t)
+}
+
+func (t *TruncateStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("TRUNCATE ")).If(t.Table, []byte("TABLE ")).If(t.Only, []byte("ONLY "))
+	for i, n := range t.TableNames {
+		sw.JoinComma(i, n)
+	}
+	switch t.Identity {
+	case RestartIdentity:
+		sw.Bytes([]byte(" RESTART IDENTITY"))
+	case ContinueIdentity:
+		sw.Bytes([]byte(" CONTINUE IDENTITY"))
+	}
+	switch t.Behavior {
+	case Cascade:
+		sw.Bytes([]byte(" CASCADE"))
+	case Restrict:
+		sw.Bytes([]byte(" RESTRICT"))
+	}
+	return sw.End()
+}
+
+type TruncateIdentity int
+
+const (
+	TruncateIdentityDefault TruncateIdentity = iota
+	RestartIdentity
+	ContinueIdentity
+)
+
+// CASCADE or RESTRICT of DROP or TRUNCATE
+type DropBehavior int
+
+const (
+	DropBehaviorDefault DropBehavior = iota
+	Cascade
+	Restrict
+)
@@ -34,6 +34,7 @@ func (m *MyEngine) End() sqltoken.Pos {
 	return m.Name.To
 }
 
+// MySQL `[DEFAULT] CHARSET [=] Name`. `CHARACTER SET` is written as CHARSET.
 type MyCharset struct {
 	tableOption
 	IsDefault bool
@@ -64,3 +65,322 @@ func (m *MyCharset) Pos() sqltoken.Pos {
 func (m *MyCharset) End() sqltoken.Pos {
 	return m.Name.To
 }
+
+// MySQL `[DEFAULT] COLLATE [=] Name`
+type MyCollate struct {
+	tableOption
+	IsDefault bool
+	Default   sqltoken.Pos
+	Collate   sqltoken.Pos
+	Equal     bool
+	Name      *Ident
+}
+
+func (m *MyCollate) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MyCollate) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.If(m.IsDefault, []byte("DEFAULT ")).Bytes([]byte("COLLATE "))
+	sw.If(m.Equal, []byte("= ")).Node(m.Name)
+	return sw.End()
+}
+
+func (m *MyCollate) Pos() sqltoken.Pos {
+	if m.IsDefault {
+		return m.Default
+	}
+	return m.Collate
+}
+
+func (m *MyCollate) End() sqltoken.Pos {
+	return m.Name.To
+}
+
+// MySQL `AUTO_INCREMENT [=] Value`, the initial value of AUTO_INCREMENT columns
+type MyAutoIncrement struct {
+	tableOption
+	AutoIncrement sqltoken.Pos
+	Equal         bool
+	Value         *LongValue
+}
+
+func (m *MyAutoIncrement) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MyAutoIncrement) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("AUTO_INCREMENT ")).If(m.Equal, []byte("= ")).Node(m.Value)
+	return sw.End()
+}
+
+func (m *MyAutoIncrement) Pos() sqltoken.Pos {
+	return m.AutoIncrement
+}
+
+func (m *MyAutoIncrement) End() sqltoken.Pos {
+	return m.Value.To
+}
+
+// MySQL `COMMENT [=] 'Comment'` of a table
+type MyTableComment struct {
+	tableOption
+	From    sqltoken.Pos
+	Equal   bool
+	Comment *SingleQuotedString
+}
+
+func (m *MyTableComment) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *MyTableComment) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("COMMENT ")).If(m.Equal, []byte("= ")).Node(m.Comment)
+	return sw.End()
+}
+
+func (m *MyTableComment) Pos() sqltoken.Pos {
+	return m.From
+}
+
+func (m *MyTableComment) End() sqltoken.Pos {
+	return m.Comment.To
+}
+
+// ClickHouse `ENGINE = Name[(Args...)]`
+type CHEngine struct {
+	tableOption
+	Engine  sqltoken.Pos
+	Name    *Ident
+	HasArgs bool // Name is followed by parentheses, e.g. MergeTree()
+	Args    []Node
+	RParen  sqltoken.Pos
+}
+
+func (c *CHEngine) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CHEngine) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("ENGINE = ")).Node(c.Name)
+	if c.HasArgs {
+		sw.LParen().Nodes(c.Args).RParen()
+	}
+	return sw.End()
+}
+
+func (c *CHEngine) Pos() sqltoken.Pos {
+	return c.Engine
+}
+
+func (c *CHEngine) End() sqltoken.Pos {
+	if c.HasArgs {
+		return c.RParen
+	}
+	return c.Name.End()
+}
+
+type CHTableKeyKind int
+
+const (
+	CHOrderBy CHTableKeyKind = iota
+	CHPartitionBy
+	CHPrimaryKey
+	CHSampleBy
+)
+
+func (k CHTableKeyKind) String() string {
+	switch k {
+	case CHOrderBy:
+		return "ORDER BY"
+	case CHPartitionBy:
+		return "PARTITION BY"
+	case CHPrimaryKey:
+		return "PRIMARY KEY"
+	case CHSampleBy:
+		return "SAMPLE BY"
+	}
+	return ""
+}
+
+// ClickHouse `ORDER BY Expr`, `PARTITION BY Expr`, `PRIMARY KEY Expr` or `SAMPLE BY Expr`
+type CHTableKey struct {
+	tableOption
+	Kind CHTableKeyKind
+	From sqltoken.Pos
+	Expr Node
+}
+
+func (c *CHTableKey) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CHTableKey) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte(c.Kind.String())).Space().Node(c.Expr).End()
+}
+
+func (c *CHTableKey) Pos() sqltoken.Pos {
+	return c.From
+}
+
+func (c *CHTableKey) End() sqltoken.Pos {
+	return c.Expr.End()
+}
+
+// ClickHouse `SETTINGS Settings...`
+type CHSettings struct {
+	tableOption
+	Settings    sqltoken.Pos
+	Assignments []*Assignment
+}
+
+func (c *CHSettings) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CHSettings) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("SETTINGS "))
+	for i, a := range c.Assignments {
+		sw.JoinComma(i, a)
+	}
+	return sw.End()
+}
+
+func (c *CHSettings) Pos() sqltoken.Pos {
+	return c.Settings
+}
+
+func (c *CHSettings) End() sqltoken.Pos {
+	return c.Assignments[len(c.Assignments)-1].End()
+}
+
+// Postgres `WITH (Options...)` of CREATE TABLE and CREATE INDEX, i.e:
+// storage parameters such as `WITH (fillfactor = 70)`
+type WithOptions struct {
+	tableOption
+	With    sqltoken.Pos
+	Options []*KeyValueOption
+	RParen  sqltoken.Pos
+}
+
+func (o *WithOptions) ToSQLString() string {
+	return toSQLString(o)
+}
+
+func (o *WithOptions) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("WITH ")).LParen()
+	for i, opt := range o.Options {
+		sw.JoinComma(i, opt)
+	}
+	return sw.RParen().End()
+}
+
+func (o *WithOptions) Pos() sqltoken.Pos {
+	return o.With
+}
+
+func (o *WithOptions) End() sqltoken.Pos {
+	return o.RParen
+}
+
+// `Key [= Value]` in WithOptions, e.g. `toast.autovacuum_enabled = false`.
+// Value is nil if omitted.
+type KeyValueOption struct {
+	Key   *ObjectName
+	Value Node
+}
+
+func (k *KeyValueOption) ToSQLString() string {
+	return toSQLString(k)
+}
+
+func (k *KeyValueOption) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Node(k.Key)
+	if k.Value != nil {
+		sw.Bytes([]byte(" = ")).Node(k.Value)
+	}
+	return sw.End()
+}
+
+func (k *KeyValueOption) Pos() sqltoken.Pos {
+	return k.Key.Pos()
+}
+
+func (k *KeyValueOption) End() sqltoken.Pos {
+	if k.Value != nil {
+		return k.Value.End()
+	}
+	return k.Key.End()
+}
+
+// `TABLESPACE Name`
+type Tablespace struct {
+	tableOption
+	Tablespace sqltoken.Pos
+	Name       *Ident
+}
+
+func (t *Tablespace) ToSQLString() string {
+	return toSQLString(t)
+}
+
+func (t *Tablespace) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("TABLESPACE ")).Node(t.Name).End()
+}
+
+func (t *Tablespace) Pos() sqltoken.Pos {
+	return t.Tablespace
+}
+
+func (t *Tablespace) End() sqltoken.Pos {
+	return t.Name.End()
+}
+
+type OnCommitAction int
+
+const (
+	OnCommitPreserveRows OnCommitAction = iota
+	OnCommitDeleteRows
+	OnCommitDrop
+)
+
+func (o OnCommitAction) ToSQLString() string {
+	switch o {
+	case OnCommitDeleteRows:
+		return "DELETE ROWS"
+	case OnCommitDrop:
+		return "DROP"
+	}
+	return "PRESERVE ROWS"
+}
+
+// `ON COMMIT { PRESERVE ROWS | DELETE ROWS | DROP }` of temporary tables
+type OnCommit struct {
+	tableOption
+	On     sqltoken.Pos
+	Action OnCommitAction
+	To     sqltoken.Pos
+}
+
+func (o *OnCommit) ToSQLString() string {
+	return toSQLString(o)
+}
+
+func (o *OnCommit) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("ON COMMIT ")).Bytes([]byte(o.Action.ToSQLString())).End()
+}
+
+func (o *OnCommit) Pos() sqltoken.Pos {
+	return o.On
+}
+
+func (o *OnCommit) End() sqltoken.Pos {
+	return o.To
+}
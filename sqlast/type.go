@@ -78,6 +78,7 @@ func (u *UUID) WriteTo(w io.Writer) (int64, error) {
 	return writeSingleBytes(w, []byte("uuid"))
 }
 
+// `clob[(Size)]`, Size is 0 if omitted
 type Clob struct {
 	Size         uint
 	Clob, RParen sqltoken.Pos
@@ -96,9 +97,13 @@ func (c *Clob) ToSQLString() string {
 }
 
 func (c *Clob) WriteTo(w io.Writer) (int64, error) {
+	if c.Size == 0 {
+		return writeSingleBytes(w, []byte("clob"))
+	}
 	return newSQLWriter(w).TypeWithOptionalLength([]byte("clob"), &c.Size).End()
 }
 
+// `binary[(Size)]`, Size is 0 if omitted
 type Binary struct {
 	Size           uint
 	Binary, RParen sqltoken.Pos
@@ -117,6 +122,9 @@ func (b *Binary) ToSQLString() string {
 }
 
 func (b *Binary) WriteTo(w io.Writer) (int64, error) {
+	if b.Size == 0 {
+		return writeSingleBytes(w, []byte("binary"))
+	}
 	return newSQLWriter(w).TypeWithOptionalLength([]byte("binary"), &b.Size).End()
 }
 
@@ -141,6 +149,7 @@ func (v *Varbinary) WriteTo(w io.Writer) (int64, error) {
 	return newSQLWriter(w).TypeWithOptionalLength([]byte("varbinary"), &v.Size).End()
 }
 
+// `blob[(Size)]`, Size is 0 if omitted
 type Blob struct {
 	Size         uint
 	Blob, RParen sqltoken.Pos
@@ -159,6 +168,9 @@ func (b *Blob) ToSQLString() string {
 }
 
 func (b *Blob) WriteTo(w io.Writer) (int64, error) {
+	if b.Size == 0 {
+		return writeSingleBytes(w, []byte("blob"))
+	}
 	return newSQLWriter(w).TypeWithOptionalLength([]byte("blob"), &b.Size).End()
 }
 
@@ -167,9 +179,11 @@ func (b *Blob) WriteTo(w io.Writer) (int64, error) {
 type Decimal struct {
 	Precision       *uint
 	Scale           *uint
-	Numeric, RParen sqltoken.Pos
+	Numeric, RParen sqltoken.Pos // RParen is the end of NUMERIC without Precision
 	IsUnsigned      bool
 	Unsigned        sqltoken.Pos
+	IsZerofill      bool
+	Zerofill        sqltoken.Pos
 }
 
 func (d *Decimal) Pos() sqltoken.Pos {
@@ -177,6 +191,9 @@ func (d *Decimal) Pos() sqltoken.Pos {
 }
 
 func (d *Decimal) End() sqltoken.Pos {
+	if d.IsZerofill {
+		return d.Zerofill
+	}
 	if d.IsUnsigned {
 		return d.Unsigned
 	}
@@ -199,7 +216,7 @@ func (d *Decimal) WriteTo(w io.Writer) (int64, error) {
 		}
 		sw.RParen()
 	}
-	sw.If(d.IsUnsigned, []byte(" unsigned"))
+	sw.If(d.IsUnsigned, []byte(" unsigned")).If(d.IsZerofill, []byte(" zerofill"))
 	return sw.End()
 }
 
@@ -208,6 +225,8 @@ type Float struct {
 	From, To, RParen sqltoken.Pos
 	IsUnsigned       bool
 	Unsigned         sqltoken.Pos
+	IsZerofill       bool
+	Zerofill         sqltoken.Pos
 }
 
 func (f *Float) Pos() sqltoken.Pos {
@@ -215,6 +234,9 @@ func (f *Float) Pos() sqltoken.Pos {
 }
 
 func (f *Float) End() sqltoken.Pos {
+	if f.IsZerofill {
+		return f.Zerofill
+	}
 	if f.IsUnsigned {
 		return f.Unsigned
 	}
@@ -230,14 +252,18 @@ func (f *Float) ToSQLString() string {
 
 func (f *Float) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.TypeWithOptionalLength([]byte("float"), f.Size).If(f.IsUnsigned, []byte(" unsigned"))
+	sw.TypeWithOptionalLength([]byte("float"), f.Size).If(f.IsUnsigned, []byte(" unsigned")).If(f.IsZerofill, []byte(" zerofill"))
 	return sw.End()
 }
 
 type SmallInt struct {
 	From, To   sqltoken.Pos
+	Width      *uint // MySQL only, display width
+	RParen     sqltoken.Pos
 	IsUnsigned bool
 	Unsigned   sqltoken.Pos
+	IsZerofill bool
+	Zerofill   sqltoken.Pos
 }
 
 func (s *SmallInt) Pos() sqltoken.Pos {
@@ -245,9 +271,15 @@ func (s *SmallInt) Pos() sqltoken.Pos {
 }
 
 func (s *SmallInt) End() sqltoken.Pos {
+	if s.IsZerofill {
+		return s.Zerofill
+	}
 	if s.IsUnsigned {
 		return s.Unsigned
 	}
+	if s.Width != nil {
+		return s.RParen
+	}
 	return s.To
 }
 
@@ -257,14 +289,18 @@ func (s *SmallInt) ToSQLString() string {
 
 func (s *SmallInt) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Bytes([]byte("smallint")).If(s.IsUnsigned, []byte(" unsigned"))
+	sw.TypeWithOptionalLength([]byte("smallint"), s.Width).If(s.IsUnsigned, []byte(" unsigned")).If(s.IsZerofill, []byte(" zerofill"))
 	return sw.End()
 }
 
 type Int struct {
 	From, To   sqltoken.Pos
+	Width      *uint // MySQL only, display width
+	RParen     sqltoken.Pos
 	IsUnsigned bool
 	Unsigned   sqltoken.Pos
+	IsZerofill bool
+	Zerofill   sqltoken.Pos
 }
 
 func (i *Int) Pos() sqltoken.Pos {
@@ -272,9 +308,15 @@ func (i *Int) Pos() sqltoken.Pos {
 }
 
 func (i *Int) End() sqltoken.Pos {
+	if i.IsZerofill {
+		return i.Zerofill
+	}
 	if i.IsUnsigned {
 		return i.Unsigned
 	}
+	if i.Width != nil {
+		return i.RParen
+	}
 	return i.To
 }
 
@@ -284,14 +326,18 @@ func (i *Int) ToSQLString() string {
 
 func (i *Int) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Bytes([]byte("int")).If(i.IsUnsigned, []byte(" unsigned"))
+	sw.TypeWithOptionalLength([]byte("int"), i.Width).If(i.IsUnsigned, []byte(" unsigned")).If(i.IsZerofill, []byte(" zerofill"))
 	return sw.End()
 }
 
 type BigInt struct {
 	From, To   sqltoken.Pos
+	Width      *uint // MySQL only, display width
+	RParen     sqltoken.Pos
 	IsUnsigned bool
 	Unsigned   sqltoken.Pos
+	IsZerofill bool
+	Zerofill   sqltoken.Pos
 }
 
 func (b *BigInt) Pos() sqltoken.Pos {
@@ -299,9 +345,15 @@ func (b *BigInt) Pos() sqltoken.Pos {
 }
 
 func (b *BigInt) End() sqltoken.Pos {
+	if b.IsZerofill {
+		return b.Zerofill
+	}
 	if b.IsUnsigned {
 		return b.Unsigned
 	}
+	if b.Width != nil {
+		return b.RParen
+	}
 	return b.To
 }
 
@@ -311,14 +363,80 @@ func (b *BigInt) ToSQLString() string {
 
 func (b *BigInt) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Bytes([]byte("bigint")).If(b.IsUnsigned, []byte(" unsigned"))
+	sw.TypeWithOptionalLength([]byte("bigint"), b.Width).If(b.IsUnsigned, []byte(" unsigned")).If(b.IsZerofill, []byte(" zerofill"))
 	return sw.End()
 }
 
+// Postgres `smallserial`, smallint with a sequence as the default
+type SmallSerial struct {
+	From, To sqltoken.Pos
+}
+
+func (s *SmallSerial) Pos() sqltoken.Pos {
+	return s.From
+}
+
+func (s *SmallSerial) End() sqltoken.Pos {
+	return s.To
+}
+
+func (*SmallSerial) ToSQLString() string {
+	return "smallserial"
+}
+
+func (*SmallSerial) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("smallserial"))
+}
+
+// `serial`, int with a sequence as the default in Postgres. MySQL's serial
+// is an alias of `bigint unsigned NOT NULL AUTO_INCREMENT UNIQUE`.
+type Serial struct {
+	From, To sqltoken.Pos
+}
+
+func (s *Serial) Pos() sqltoken.Pos {
+	return s.From
+}
+
+func (s *Serial) End() sqltoken.Pos {
+	return s.To
+}
+
+func (*Serial) ToSQLString() string {
+	return "serial"
+}
+
+func (*Serial) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("serial"))
+}
+
+// Postgres `bigserial`, bigint with a sequence as the default
+type BigSerial struct {
+	From, To sqltoken.Pos
+}
+
+func (b *BigSerial) Pos() sqltoken.Pos {
+	return b.From
+}
+
+func (b *BigSerial) End() sqltoken.Pos {
+	return b.To
+}
+
+func (*BigSerial) ToSQLString() string {
+	return "bigserial"
+}
+
+func (*BigSerial) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("bigserial"))
+}
+
 type Real struct {
 	From, To   sqltoken.Pos
 	IsUnsigned bool
 	Unsigned   sqltoken.Pos
+	IsZerofill bool
+	Zerofill   sqltoken.Pos
 }
 
 func (r *Real) Pos() sqltoken.Pos {
@@ -326,6 +444,9 @@ func (r *Real) Pos() sqltoken.Pos {
 }
 
 func (r *Real) End() sqltoken.Pos {
+	if r.IsZerofill {
+		return r.Zerofill
+	}
 	if r.IsUnsigned {
 		return r.Unsigned
 	}
@@ -338,7 +459,7 @@ func (r *Real) ToSQLString() string {
 
 func (r *Real) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Bytes([]byte("real")).If(r.IsUnsigned, []byte(" unsigned"))
+	sw.Bytes([]byte("real")).If(r.IsUnsigned, []byte(" unsigned")).If(r.IsZerofill, []byte(" zerofill"))
 	return sw.End()
 }
 
@@ -513,9 +634,13 @@ func (*Bytea) WriteTo(w io.Writer) (int64, error) {
 	return writeSingleBytes(w, []byte("bytea"))
 }
 
+// `Ty[]` or `Ty[Size]`. Each dimension of a multi-dimensional array is an
+// Array, e.g. int[2][3] is an Array of Size 3 of an Array of Size 2 of int.
+// `Ty ARRAY[Size]` is parsed as `Ty[Size]`.
 type Array struct {
 	Ty     Type
-	RParen sqltoken.Pos
+	Size   *uint
+	RParen sqltoken.Pos // position of right bracket
 }
 
 func (a *Array) Pos() sqltoken.Pos {
@@ -531,11 +656,165 @@ func (a *Array) ToSQLString() string {
 }
 
 func (a *Array) WriteTo(w io.Writer) (int64, error) {
-	return newSQLWriter(w).Node(a.Ty).Bytes([]byte("[]")).End()
+	sw := newSQLWriter(w)
+	sw.Node(a.Ty).Bytes([]byte("["))
+	if a.Size != nil {
+		sw.Int(int(*a.Size))
+	}
+	return sw.Bytes([]byte("]")).End()
+}
+
+// Hive `string`
+type String struct {
+	From, To sqltoken.Pos
+}
+
+func (s *String) Pos() sqltoken.Pos {
+	return s.From
+}
+
+func (s *String) End() sqltoken.Pos {
+	return s.To
+}
+
+func (*String) ToSQLString() string {
+	return "string"
+}
+
+func (*String) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("string"))
+}
+
+// Hive `array<Elem>`
+type HiveArray struct {
+	Array, RAngle sqltoken.Pos
+	Elem          Type
+}
+
+func (a *HiveArray) Pos() sqltoken.Pos {
+	return a.Array
+}
+
+func (a *HiveArray) End() sqltoken.Pos {
+	return a.RAngle
+}
+
+func (a *HiveArray) ToSQLString() string {
+	return toSQLString(a)
+}
+
+func (a *HiveArray) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("array<")).Node(a.Elem).Bytes([]byte(">")).End()
+}
+
+// Hive `map<Key, Value>`
+type HiveMap struct {
+	Map, RAngle sqltoken.Pos
+	Key, Value  Type
+}
+
+func (m *HiveMap) Pos() sqltoken.Pos {
+	return m.Map
+}
+
+func (m *HiveMap) End() sqltoken.Pos {
+	return m.RAngle
+}
+
+func (m *HiveMap) ToSQLString() string {
+	return toSQLString(m)
+}
+
+func (m *HiveMap) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("map<")).Node(m.Key).Bytes([]byte(", ")).Node(m.Value).Bytes([]byte(">")).End()
+}
+
+// Hive `struct<Name:Type, ...>`
+type HiveStruct struct {
+	Struct, RAngle sqltoken.Pos
+	Fields         []*HiveStructField
+}
+
+// Name:Type in HiveStruct
+type HiveStructField struct {
+	Name *Ident
+	Type Type
+}
+
+func (s *HiveStruct) Pos() sqltoken.Pos {
+	return s.Struct
+}
+
+func (s *HiveStruct) End() sqltoken.Pos {
+	return s.RAngle
+}
+
+func (s *HiveStruct) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *HiveStruct) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w).Bytes([]byte("struct<"))
+	for i, f := range s.Fields {
+		if i > 0 {
+			sw.Bytes([]byte(", "))
+		}
+		sw.Node(f.Name).Bytes([]byte(":")).Node(f.Type)
+	}
+	return sw.Bytes([]byte(">")).End()
+}
+
+// ClickHouse `Nullable(Ty)`
+type CHNullable struct {
+	Nullable, RParen sqltoken.Pos
+	Ty               Type
+}
+
+func (c *CHNullable) Pos() sqltoken.Pos {
+	return c.Nullable
+}
+
+func (c *CHNullable) End() sqltoken.Pos {
+	return c.RParen
+}
+
+func (c *CHNullable) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CHNullable) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("Nullable")).LParen().Node(c.Ty).RParen().End()
+}
+
+// ClickHouse `LowCardinality(Ty)`
+type CHLowCardinality struct {
+	LowCardinality, RParen sqltoken.Pos
+	Ty                     Type
+}
+
+func (c *CHLowCardinality) Pos() sqltoken.Pos {
+	return c.LowCardinality
+}
+
+func (c *CHLowCardinality) End() sqltoken.Pos {
+	return c.RParen
+}
+
+func (c *CHLowCardinality) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CHLowCardinality) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("LowCardinality")).LParen().Node(c.Ty).RParen().End()
 }
 
 type Custom struct {
-	Ty *ObjectName
+	Ty     *ObjectName
+	Args   []Node       // MySQL only, e.g. tinyint(1) and datetime(3)
+	RParen sqltoken.Pos // position of RParen if Args is not empty
+	// Definition is the CREATE TYPE statement defining Ty, if it appears
+	// earlier in the same input. It is not visited by Walk.
+	Definition *CreateTypeStmt
 }
 
 func (c *Custom) Pos() sqltoken.Pos {
@@ -543,15 +822,23 @@ func (c *Custom) Pos() sqltoken.Pos {
 }
 
 func (c *Custom) End() sqltoken.Pos {
+	if len(c.Args) != 0 {
+		return c.RParen
+	}
 	return c.Ty.End()
 }
 
 func (c *Custom) ToSQLString() string {
-	return c.Ty.ToSQLString()
+	return toSQLString(c)
 }
 
 func (c *Custom) WriteTo(w io.Writer) (int64, error) {
-	return c.Ty.WriteTo(w)
+	sw := newSQLWriter(w)
+	sw.Node(c.Ty)
+	if len(c.Args) != 0 {
+		sw.LParen().Nodes(c.Args).RParen()
+	}
+	return sw.End()
 }
 
 func NewSize(s uint) *uint {
@@ -0,0 +1,40 @@
+package sqlast
+
+import (
+	"io"
+
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// `UNLOAD ('Query') TO 'To' Options...` (Redshift)
+type UnloadStmt struct {
+	stmt
+	Unload  sqltoken.Pos
+	Query   *SingleQuotedString // the query is not parsed
+	To      *SingleQuotedString
+	Options []*CopyOption
+}
+
+func (u *UnloadStmt) Pos() sqltoken.Pos {
+	return u.Unload
+}
+
+func (u *UnloadStmt) End() sqltoken.Pos {
+	if len(u.Options) != 0 {
+		return u.Options[len(u.Options)-1].End()
+	}
+	return u.To.End()
+}
+
+func (u *UnloadStmt) ToSQLString() string {
+	return toSQLString(u)
+}
+
+func (u *UnloadStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("UNLOAD ")).LParen().Node(u.Query).RParen().Bytes([]byte(" TO ")).Node(u.To)
+	for _, o := range u.Options {
+		sw.Space().Node(o)
+	}
+	return sw.End()
+}
@@ -3,7 +3,9 @@ package sqlast
 import (
 	"fmt"
 	"io"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/akito0107/xsqlparser/sqltoken"
@@ -15,6 +17,7 @@ type Value interface {
 }
 
 type LongValue struct {
+	limitCount
 	From, To sqltoken.Pos
 	Long     int64
 }
@@ -46,6 +49,74 @@ func (l *LongValue) WriteTo(w io.Writer) (int64, error) {
 	return int64(n), err
 }
 
+// UnsignedLongValue is an integer beyond the range of LongValue, i.e:
+// 18446744073709551615 which MySQL uses for LIMIT without an upper bound.
+type UnsignedLongValue struct {
+	limitCount
+	From, To sqltoken.Pos
+	Long     uint64
+}
+
+func NewUnsignedLongValue(i uint64) *UnsignedLongValue {
+	return &UnsignedLongValue{
+		Long: i,
+	}
+}
+
+func (u *UnsignedLongValue) Pos() sqltoken.Pos {
+	return u.From
+}
+
+func (u *UnsignedLongValue) End() sqltoken.Pos {
+	return u.To
+}
+
+func (u *UnsignedLongValue) Value() interface{} {
+	return u
+}
+
+func (u *UnsignedLongValue) ToSQLString() string {
+	return toSQLString(u)
+}
+
+func (u *UnsignedLongValue) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, strconv.FormatUint(u.Long, 10))
+	return int64(n), err
+}
+
+// NumericValue is an integer literal beyond the range of int64, i.e:
+// 99999999999999999999, which is written as it is in the source.
+type NumericValue struct {
+	From, To sqltoken.Pos
+	Numeric  string
+}
+
+func NewNumericValue(s string) *NumericValue {
+	return &NumericValue{
+		Numeric: s,
+	}
+}
+
+func (n *NumericValue) Pos() sqltoken.Pos {
+	return n.From
+}
+
+func (n *NumericValue) End() sqltoken.Pos {
+	return n.To
+}
+
+func (n *NumericValue) Value() interface{} {
+	return n.Numeric
+}
+
+func (n *NumericValue) ToSQLString() string {
+	return toSQLString(n)
+}
+
+func (n *NumericValue) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleString(w, n.Numeric)
+}
+
 type DoubleValue struct {
 	From, To sqltoken.Pos
 	Double   float64
@@ -75,14 +146,24 @@ func (d *DoubleValue) ToSQLString() string {
 
 func (d *DoubleValue) WriteTo(w io.Writer) (int64, error) {
 	var b [32] byte
-	buf := strconv.AppendFloat(b[:0], d.Double, 'f', -1, 64)
+	// exponent notation only for very large or small numbers i.e: 1e+21, 1e-07
+	format := byte('f')
+	if abs := math.Abs(d.Double); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	buf := strconv.AppendFloat(b[:0], d.Double, format, -1, 64)
+	// keep it a float literal i.e: 1.0 instead of 1
+	if !strings.ContainsAny(string(buf), ".eIN") {
+		buf = append(buf, ".0"...)
+	}
 	n, err := w.Write(buf)
 	return int64(n), err
 }
 
 type SingleQuotedString struct {
-	From, To sqltoken.Pos
-	String   string
+	From, To        sqltoken.Pos
+	String          string
+	BackslashEscape bool // write backslashes as \\ for dialects interpreting escape sequences (e.g. MySQL)
 }
 
 func NewSingleQuotedString(str string) *SingleQuotedString {
@@ -112,7 +193,7 @@ func (s *SingleQuotedString) WriteTo(w io.Writer) (int64, error) {
 	if err != nil {
 		return int64(n), err
 	}
-	n1, err := io.WriteString(w, s.String)
+	n1, err := io.WriteString(w, quoteString(s.String, s.BackslashEscape))
 	if err != nil {
 		return int64(n + n1), err
 	}
@@ -120,11 +201,96 @@ func (s *SingleQuotedString) WriteTo(w io.Writer) (int64, error) {
 	return int64(n + n1 + n2), err
 }
 
-type NationalStringLiteral struct {
+// $$string$$ or $tag$string$tag$
+type DollarQuotedString struct {
+	From, To sqltoken.Pos
+	String   string
+	Tag      string
+}
+
+func (d *DollarQuotedString) Pos() sqltoken.Pos {
+	return d.From
+}
+
+func (d *DollarQuotedString) End() sqltoken.Pos {
+	return d.To
+}
+
+func (d *DollarQuotedString) Value() interface{} {
+	return d.String
+}
+
+func (d *DollarQuotedString) ToSQLString() string {
+	return toSQLString(d)
+}
+
+func (d *DollarQuotedString) WriteTo(w io.Writer) (int64, error) {
+	delim := "$" + d.Tag + "$"
+	return writeSingleString(w, delim+d.String+delim)
+}
+
+// X'1F' or 0x1F
+type HexValue struct {
+	From, To sqltoken.Pos
+	Digits   string
+	Quoted   bool // X'1F' style if true
+}
+
+func (h *HexValue) Pos() sqltoken.Pos {
+	return h.From
+}
+
+func (h *HexValue) End() sqltoken.Pos {
+	return h.To
+}
+
+func (h *HexValue) Value() interface{} {
+	return h.Digits
+}
+
+func (h *HexValue) ToSQLString() string {
+	return toSQLString(h)
+}
+
+func (h *HexValue) WriteTo(w io.Writer) (int64, error) {
+	if h.Quoted {
+		return writeSingleString(w, "X'"+h.Digits+"'")
+	}
+	return writeSingleString(w, "0x"+h.Digits)
+}
+
+// B'1010'
+type BitStringLiteral struct {
 	From, To sqltoken.Pos
 	String   string
 }
 
+func (b *BitStringLiteral) Pos() sqltoken.Pos {
+	return b.From
+}
+
+func (b *BitStringLiteral) End() sqltoken.Pos {
+	return b.To
+}
+
+func (b *BitStringLiteral) Value() interface{} {
+	return b.String
+}
+
+func (b *BitStringLiteral) ToSQLString() string {
+	return toSQLString(b)
+}
+
+func (b *BitStringLiteral) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleString(w, "B'"+b.String+"'")
+}
+
+type NationalStringLiteral struct {
+	From, To        sqltoken.Pos
+	String          string
+	BackslashEscape bool // write backslashes as \\ for dialects interpreting escape sequences (e.g. MySQL)
+}
+
 func NewNationalStringLiteral(str string) *NationalStringLiteral {
 	return &NationalStringLiteral{
 		String: str,
@@ -144,7 +310,7 @@ func (n *NationalStringLiteral) Value() interface{} {
 }
 
 func (n *NationalStringLiteral) ToSQLString() string {
-	return fmt.Sprintf("N'%s'", n.String)
+	return toSQLString(n)
 }
 
 func (n *NationalStringLiteral) WriteTo(w io.Writer) (int64, error) {
@@ -152,7 +318,7 @@ func (n *NationalStringLiteral) WriteTo(w io.Writer) (int64, error) {
 	if err != nil {
 		return int64(n0), err
 	}
-	n1, err := io.WriteString(w, n.String)
+	n1, err := io.WriteString(w, quoteString(n.String, n.BackslashEscape))
 	if err != nil {
 		return int64(n0 + n1), err
 	}
@@ -160,6 +326,98 @@ func (n *NationalStringLiteral) WriteTo(w io.Writer) (int64, error) {
 	return int64(n0 + n1 + n2), err
 }
 
+// backslashEscaper escapes backslashes and control characters which MySQL
+// reads from backslash escapes.
+var backslashEscaper = strings.NewReplacer(`\`, `\\`, "\b", `\b`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "\x00", `\0`, "\x1a", `\Z`)
+
+// quoteString escapes s to be written in single quotes.
+func quoteString(s string, backslashEscape bool) string {
+	if backslashEscape {
+		s = backslashEscaper.Replace(s)
+	}
+	return strings.ReplaceAll(s, "'", "''")
+}
+
+// U&'string' [UESCAPE 'Escape'] (Postgres). String holds the decoded value.
+type UnicodeStringLiteral struct {
+	From, To sqltoken.Pos
+	String   string
+	Escape   rune
+}
+
+func (u *UnicodeStringLiteral) Pos() sqltoken.Pos {
+	return u.From
+}
+
+func (u *UnicodeStringLiteral) End() sqltoken.Pos {
+	return u.To
+}
+
+func (u *UnicodeStringLiteral) Value() interface{} {
+	return u.String
+}
+
+func (u *UnicodeStringLiteral) ToSQLString() string {
+	return toSQLString(u)
+}
+
+func (u *UnicodeStringLiteral) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleString(w, sqltoken.QuoteUnicodeEscape(u.String, '\'', u.Escape))
+}
+
+// E'string' (Postgres). String holds the unescaped value.
+type EscapedStringLiteral struct {
+	From, To sqltoken.Pos
+	String   string
+}
+
+func (e *EscapedStringLiteral) Pos() sqltoken.Pos {
+	return e.From
+}
+
+func (e *EscapedStringLiteral) End() sqltoken.Pos {
+	return e.To
+}
+
+func (e *EscapedStringLiteral) Value() interface{} {
+	return e.String
+}
+
+func (e *EscapedStringLiteral) ToSQLString() string {
+	return toSQLString(e)
+}
+
+func (e *EscapedStringLiteral) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	b.WriteString("E'")
+	for i := 0; i < len(e.String); i++ {
+		c := e.String[i]
+		switch c {
+		case '\'', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				fmt.Fprintf(&b, `\x%02x`, c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	b.WriteString("'")
+	return writeSingleString(w, b.String())
+}
+
 type BooleanValue struct {
 	From, To sqltoken.Pos
 	Boolean  bool
@@ -350,3 +608,105 @@ func (n *NullValue) ToSQLString() string {
 func (*NullValue) WriteTo(w io.Writer) (int64, error) {
 	return writeSingleBytes(w, []byte("NULL"))
 }
+
+// INTERVAL Literal [ LeadingField [ (LeadingPrecision) ] [ TO LastField [ (FractionalSecondsPrecision) ] ] ]
+type IntervalValue struct {
+	Interval                   sqltoken.Pos // first position of INTERVAL keyword
+	To                         sqltoken.Pos // last position of the interval literal
+	Literal                    Node         // '1 day', '1' or (MySQL) 1
+	LeadingField               DateTimeField
+	LeadingPrecision           *uint
+	LastField                  DateTimeField
+	FractionalSecondsPrecision *uint // of the last SECOND, or the leading one i.e: SECOND(2, 3)
+}
+
+func (i *IntervalValue) Pos() sqltoken.Pos {
+	return i.Interval
+}
+
+func (i *IntervalValue) End() sqltoken.Pos {
+	return i.To
+}
+
+func (i *IntervalValue) Value() interface{} {
+	return i.Literal
+}
+
+func (i *IntervalValue) ToSQLString() string {
+	return toSQLString(i)
+}
+
+func (i *IntervalValue) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("INTERVAL ")).Node(i.Literal)
+	if i.LeadingField != NoDateTimeField {
+		sw.Space().Node(i.LeadingField)
+		if i.LeadingPrecision != nil {
+			sw.LParen().Int(int(*i.LeadingPrecision))
+			if i.LastField == NoDateTimeField && i.FractionalSecondsPrecision != nil {
+				sw.Bytes([]byte(", ")).Int(int(*i.FractionalSecondsPrecision))
+			}
+			sw.RParen()
+		}
+	}
+	if i.LastField != NoDateTimeField {
+		sw.Bytes([]byte(" TO ")).Node(i.LastField)
+		if i.FractionalSecondsPrecision != nil {
+			sw.LParen().Int(int(*i.FractionalSecondsPrecision)).RParen()
+		}
+	}
+	return sw.End()
+}
+
+// DateTimeField is the unit of INTERVAL literals (YEAR, MONTH, DAY...)
+type DateTimeField int
+
+const (
+	NoDateTimeField DateTimeField = iota
+	YearField
+	MonthField
+	DayField
+	HourField
+	MinuteField
+	SecondField
+)
+
+func (d DateTimeField) ToSQLString() string {
+	switch d {
+	case YearField:
+		return "YEAR"
+	case MonthField:
+		return "MONTH"
+	case DayField:
+		return "DAY"
+	case HourField:
+		return "HOUR"
+	case MinuteField:
+		return "MINUTE"
+	case SecondField:
+		return "SECOND"
+	}
+	return ""
+}
+
+func (d DateTimeField) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleString(w, d.ToSQLString())
+}
+
+func (DateTimeField) FromStr(str string) (DateTimeField, bool) {
+	switch str {
+	case "YEAR":
+		return YearField, true
+	case "MONTH":
+		return MonthField, true
+	case "DAY":
+		return DayField, true
+	case "HOUR":
+		return HourField, true
+	case "MINUTE":
+		return MinuteField, true
+	case "SECOND":
+		return SecondField, true
+	}
+	return NoDateTimeField, false
+}
@@ -4,6 +4,8 @@ import (
 	"log"
 )
 
+//go:generate genvisitor
+
 type Visitor interface {
 	Visit(node Node) Visitor
 }
@@ -34,6 +36,8 @@ func Walk(v Visitor, node Node) {
 		// nothing to do
 	case *Wildcard:
 		// nothing to do
+	case *Placeholder:
+		// nothing to do
 	case *QualifiedWildcard:
 		walkIdentLists(v, n.Idents)
 	case *CompoundIdent:
@@ -56,22 +60,87 @@ func Walk(v Visitor, node Node) {
 		Walk(v, n.Left)
 		Walk(v, n.Op)
 		Walk(v, n.Right)
+	case *LikeEscape:
+		Walk(v, n.Like)
+		Walk(v, n.Escape)
+	case *Collate:
+		Walk(v, n.Expr)
+		Walk(v, n.Collation)
 	case *Cast:
 		Walk(v, n.Expr)
 		Walk(v, n.DataType)
+	case *Extract:
+		Walk(v, n.Field)
+		Walk(v, n.Expr)
+	case *Position:
+		Walk(v, n.Substring)
+		Walk(v, n.String)
+	case *Substring:
+		Walk(v, n.Expr)
+		if n.Start != nil {
+			Walk(v, n.Start)
+		}
+		if n.Length != nil {
+			Walk(v, n.Length)
+		}
+	case *Trim:
+		if n.Characters != nil {
+			Walk(v, n.Characters)
+		}
+		Walk(v, n.Expr)
+	case *Overlay:
+		Walk(v, n.Expr)
+		Walk(v, n.Placing)
+		Walk(v, n.Start)
+		if n.Length != nil {
+			Walk(v, n.Length)
+		}
+	case *Subscript:
+		Walk(v, n.Expr)
+		if n.Index != nil {
+			Walk(v, n.Index)
+		}
+		if n.Upper != nil {
+			Walk(v, n.Upper)
+		}
+	case *ArrayConstructor:
+		walkASTNodeLists(v, n.Elements)
+	case *SQLRowExpr:
+		walkASTNodeLists(v, n.Values)
 	case *Nested:
 		Walk(v, n.AST)
+	case *OuterJoinColumn:
+		Walk(v, n.Column)
 	case *UnaryExpr:
 		Walk(v, n.Op)
 		Walk(v, n.Expr)
 	case *Function:
 		Walk(v, n.Name)
 		walkASTNodeLists(v, n.Args)
+		for _, o := range n.WithinGroup {
+			Walk(v, o)
+		}
+		if n.Filter != nil {
+			Walk(v, n.Filter)
+		}
 		if n.Over != nil {
 			Walk(v, n.Over)
 		}
+		if n.OverName != nil {
+			Walk(v, n.OverName)
+		}
+	case *NamedArg:
+		Walk(v, n.Name)
+		Walk(v, n.Value)
 	case *CaseExpr:
-		Walk(v, n.Operand)
+		if n.Operand != nil {
+			Walk(v, n.Operand)
+		}
+		walkASTNodeLists(v, n.Conditions)
+		walkASTNodeLists(v, n.Results)
+		if n.ElseResult != nil {
+			Walk(v, n.ElseResult)
+		}
 	case *Exists:
 		Walk(v, n.Query)
 	case *SubQuery:
@@ -79,6 +148,9 @@ func Walk(v Visitor, node Node) {
 	case *ObjectName:
 		walkIdentLists(v, n.Idents)
 	case *WindowSpec:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
 		walkASTNodeLists(v, n.PartitionBy)
 		for _, o := range n.OrderBy {
 			Walk(v, o)
@@ -86,6 +158,9 @@ func Walk(v Visitor, node Node) {
 		if n.WindowsFrame != nil {
 			Walk(v, n.WindowsFrame)
 		}
+	case *NamedWindow:
+		Walk(v, n.Name)
+		Walk(v, n.Spec)
 	case *WindowFrame:
 		Walk(v, n.Units)
 		Walk(v, n.StartBound)
@@ -115,6 +190,12 @@ func Walk(v Visitor, node Node) {
 		if n.Limit != nil {
 			Walk(v, n.Limit)
 		}
+		if n.Fetch != nil {
+			Walk(v, n.Fetch)
+		}
+		for _, l := range n.Locks {
+			Walk(v, l)
+		}
 	case *CTE:
 		Walk(v, n.Query)
 		Walk(v, n.Alias)
@@ -126,6 +207,10 @@ func Walk(v Visitor, node Node) {
 		Walk(v, n.Op)
 		Walk(v, n.Left)
 		Walk(v, n.Right)
+	case *SQLValues:
+		for _, r := range n.Rows {
+			Walk(v, r)
+		}
 	case *UnionOperator:
 		// nothing to do
 	case *ExceptOperator:
@@ -133,6 +218,15 @@ func Walk(v Visitor, node Node) {
 	case *IntersectOperator:
 		// nothing to do
 	case *SQLSelect:
+		if n.Hints != nil {
+			Walk(v, n.Hints)
+		}
+		for _, d := range n.DistinctOn {
+			Walk(v, d)
+		}
+		if n.Top != nil {
+			Walk(v, n.Top)
+		}
 		for _, p := range n.Projection {
 			Walk(v, p)
 		}
@@ -141,6 +235,12 @@ func Walk(v Visitor, node Node) {
 				Walk(v, f)
 			}
 		}
+		for _, l := range n.LateralViews {
+			Walk(v, l)
+		}
+		for _, a := range n.ArrayJoins {
+			Walk(v, a)
+		}
 		if n.WhereClause != nil {
 			Walk(v, n.WhereClause)
 		}
@@ -148,6 +248,24 @@ func Walk(v Visitor, node Node) {
 		if n.HavingClause != nil {
 			Walk(v, n.HavingClause)
 		}
+		for _, w := range n.WindowClause {
+			Walk(v, w)
+		}
+		walkASTNodeLists(v, n.DistributeBy)
+		for _, o := range n.SortBy {
+			Walk(v, o)
+		}
+		walkASTNodeLists(v, n.ClusterBy)
+	case *ArrayJoin:
+		for _, i := range n.Items {
+			Walk(v, i)
+		}
+	case *LateralView:
+		Walk(v, n.Generator)
+		if n.TableAlias != nil {
+			Walk(v, n.TableAlias)
+		}
+		walkIdentLists(v, n.ColumnAliases)
 	case *QualifiedJoin:
 		Walk(v, n.LeftElement)
 		Walk(v, n.Type)
@@ -173,13 +291,60 @@ func Walk(v Visitor, node Node) {
 		if n.Alias != nil {
 			Walk(v, n.Alias)
 		}
-		walkASTNodeLists(v, n.Args)
+		walkIdentLists(v, n.Columns)
+		for _, h := range n.IndexHints {
+			Walk(v, h)
+		}
 		walkASTNodeLists(v, n.WithHints)
+		if n.CHSample != nil {
+			Walk(v, n.CHSample)
+		}
+		if n.Sample != nil {
+			Walk(v, n.Sample)
+		}
+	case *CHSample:
+		Walk(v, n.Ratio)
+		if n.Offset != nil {
+			Walk(v, n.Offset)
+		}
+	case *IndexHint:
+		walkIdentLists(v, n.Indexes)
+	case *OptimizerHints:
+		for _, h := range n.Hints {
+			Walk(v, h)
+		}
+	case *OptimizerHint:
+		// nothing to do
+	case *TableSample:
+		Walk(v, n.Method)
+		walkASTNodeLists(v, n.Args)
+		if n.Repeatable != nil {
+			Walk(v, n.Repeatable)
+		}
+	case *GroupingSets:
+		walkASTNodeLists(v, n.Sets)
+	case *Rollup:
+		walkASTNodeLists(v, n.Sets)
+	case *Cube:
+		walkASTNodeLists(v, n.Sets)
 	case *Derived:
 		Walk(v, n.SubQuery)
 		if n.Alias != nil {
 			Walk(v, n.Alias)
 		}
+		walkIdentLists(v, n.Columns)
+	case *TableFunction:
+		Walk(v, n.Function)
+		if n.Alias != nil {
+			Walk(v, n.Alias)
+		}
+		walkIdentLists(v, n.Columns)
+	case *Unnest:
+		walkASTNodeLists(v, n.Exprs)
+		if n.Alias != nil {
+			Walk(v, n.Alias)
+		}
+		walkIdentLists(v, n.Columns)
 	case *UnnamedSelectItem:
 		Walk(v, n.Node)
 	case *AliasSelectItem:
@@ -191,13 +356,23 @@ func Walk(v Visitor, node Node) {
 		// nothing to do
 	case *OrderByExpr:
 		Walk(v, n.Expr)
+	case *LockingClause:
+		for _, o := range n.Of {
+			Walk(v, o)
+		}
 	case *LimitExpr:
-		if !n.All {
+		if n.LimitValue != nil {
 			Walk(v, n.LimitValue)
 		}
 		if n.OffsetValue != nil {
 			Walk(v, n.OffsetValue)
 		}
+	case *Fetch:
+		if n.Count != nil {
+			Walk(v, n.Count)
+		}
+	case *Top:
+		Walk(v, n.Count)
 	case *CharType:
 		// nothing to do
 	case *VarcharType:
@@ -222,6 +397,12 @@ func Walk(v Visitor, node Node) {
 		// nothing to do
 	case *BigInt:
 		// nothing to do
+	case *SmallSerial:
+		// nothing to do
+	case *Serial:
+		// nothing to do
+	case *BigSerial:
+		// nothing to do
 	case *Real:
 		// nothing to do
 	case *Double:
@@ -242,9 +423,24 @@ func Walk(v Visitor, node Node) {
 		// nothing to do
 	case *Array:
 		// nothing to do
-	case *Custom:
+	case *String:
+		// nothing to do
+	case *HiveArray:
+		// nothing to do
+	case *HiveMap:
 		// nothing to do
+	case *HiveStruct:
+		// nothing to do
+	case *CHNullable:
+		// nothing to do
+	case *CHLowCardinality:
+		// nothing to do
+	case *Custom:
+		walkASTNodeLists(v, n.Args)
 	case *InsertStmt:
+		if n.Hints != nil {
+			Walk(v, n.Hints)
+		}
 		Walk(v, n.TableName)
 		walkIdentLists(v, n.Columns)
 		Walk(v, n.Source)
@@ -252,6 +448,9 @@ func Walk(v Visitor, node Node) {
 		for _, a := range n.UpdateAssignments {
 			Walk(v, a)
 		}
+		for _, r := range n.Returning {
+			Walk(v, r)
+		}
 
 	case *ConstructorSource:
 		for _, r := range n.Rows {
@@ -264,27 +463,122 @@ func Walk(v Visitor, node Node) {
 	case *SubQuerySource:
 		Walk(v, n.SubQuery)
 	case *CopyStmt:
-		Walk(v, n.TableName)
+		if n.TableName != nil {
+			Walk(v, n.TableName)
+		}
 		walkIdentLists(v, n.Columns)
+		if n.Query != nil {
+			Walk(v, n.Query)
+		}
+		if n.Filename != nil {
+			Walk(v, n.Filename)
+		}
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+	case *UnloadStmt:
+		Walk(v, n.Query)
+		Walk(v, n.To)
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+	case *CopyOption:
+		Walk(v, n.Name)
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
 	case *UpdateStmt:
+		if n.Hints != nil {
+			Walk(v, n.Hints)
+		}
 		Walk(v, n.TableName)
 		for _, a := range n.Assignments {
 			Walk(v, a)
 		}
-		Walk(v, n.Selection)
+		if n.Selection != nil {
+			Walk(v, n.Selection)
+		}
+		for _, r := range n.Returning {
+			Walk(v, r)
+		}
 	case *DeleteStmt:
+		if n.Hints != nil {
+			Walk(v, n.Hints)
+		}
 		Walk(v, n.TableName)
+		if n.Alias != nil {
+			Walk(v, n.Alias)
+		}
+		for _, u := range n.Using {
+			Walk(v, u)
+		}
 		if n.Selection != nil {
 			Walk(v, n.Selection)
 		}
+		for _, r := range n.Returning {
+			Walk(v, r)
+		}
 	case *CreateViewStmt:
 		Walk(v, n.Name)
 		Walk(v, n.Query)
 	case *CreateTableStmt:
 		Walk(v, n.Name)
+		if n.PartitionOf != nil {
+			Walk(v, n.PartitionOf)
+		}
 		for _, e := range n.Elements {
 			Walk(v, e)
 		}
+		if n.PartitionBound != nil {
+			Walk(v, n.PartitionBound)
+		}
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+	case *WithOptions:
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+	case *KeyValueOption:
+		Walk(v, n.Key)
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *Tablespace:
+		Walk(v, n.Name)
+	case *OnCommit:
+		// nothing to do
+	case *PartitionBy:
+		walkASTNodeLists(v, n.Keys)
+	case *PartitionBoundIn:
+		walkASTNodeLists(v, n.Values)
+	case *PartitionBoundRange:
+		walkASTNodeLists(v, n.From)
+		walkASTNodeLists(v, n.To)
+	case *PartitionBoundHash:
+		Walk(v, n.Modulus)
+		Walk(v, n.Remainder)
+	case *PartitionBoundDefault:
+		// nothing to do
+	case *MyEngine:
+		Walk(v, n.Name)
+	case *MyCharset:
+		Walk(v, n.Name)
+	case *MyCollate:
+		Walk(v, n.Name)
+	case *MyAutoIncrement:
+		Walk(v, n.Value)
+	case *MyTableComment:
+		Walk(v, n.Comment)
+	case *CHEngine:
+		Walk(v, n.Name)
+		walkASTNodeLists(v, n.Args)
+	case *CHTableKey:
+		Walk(v, n.Expr)
+	case *CHSettings:
+		for _, a := range n.Assignments {
+			Walk(v, a)
+		}
 	case *Assignment:
 		Walk(v, n.ID)
 		Walk(v, n.Value)
@@ -306,12 +600,35 @@ func Walk(v Visitor, node Node) {
 	case *ColumnDef:
 		Walk(v, n.Name)
 		Walk(v, n.DataType)
+		if n.Charset != nil {
+			Walk(v, n.Charset)
+		}
+		if n.Collation != nil {
+			Walk(v, n.Collation)
+		}
+		if n.Default != nil {
+			Walk(v, n.Default)
+		}
+		for _, m := range n.MyDataTypeDecoration {
+			Walk(v, m)
+		}
+		for _, c := range n.Constraints {
+			Walk(v, c)
+		}
+	case *PartitionColumn:
+		Walk(v, n.Name)
 		if n.Default != nil {
 			Walk(v, n.Default)
 		}
 		for _, c := range n.Constraints {
 			Walk(v, c)
 		}
+	case *AutoIncrement:
+		// nothing to do
+	case *MyOnUpdate:
+		Walk(v, n.Expr)
+	case *MyColumnComment:
+		Walk(v, n.Comment)
 	case *ColumnConstraint:
 		if n.Name != nil {
 			Walk(v, n.Name)
@@ -326,6 +643,12 @@ func Walk(v Visitor, node Node) {
 		walkIdentLists(v, n.Columns)
 	case *CheckColumnSpec:
 		Walk(v, n.Expr)
+	case *GeneratedColumnSpec:
+		Walk(v, n.Expr)
+	case *IdentityColumnSpec:
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
 	case *AlterTableStmt:
 		Walk(v, n.TableName)
 		Walk(v, n.Action)
@@ -363,20 +686,298 @@ func Walk(v Visitor, node Node) {
 			Walk(v, n.MethodName)
 		}
 		walkIdentLists(v, n.ColumnNames)
+		if n.With != nil {
+			Walk(v, n.With)
+		}
+		if n.Tablespace != nil {
+			Walk(v, n.Tablespace)
+		}
 		if n.Selection != nil {
 			Walk(v, n.Selection)
 		}
+	case *CreateFunctionStmt:
+		Walk(v, n.Name)
+		for _, a := range n.Args {
+			Walk(v, a)
+		}
+		if n.Returns != nil {
+			Walk(v, n.Returns)
+		}
+		for _, a := range n.ReturnsTable {
+			Walk(v, a)
+		}
+		if n.Language != nil {
+			Walk(v, n.Language)
+		}
+		for _, a := range n.Attributes {
+			Walk(v, a)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+	case *FunctionArg:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+		Walk(v, n.Type)
+		if n.Default != nil {
+			Walk(v, n.Default)
+		}
+	case *FunctionAttribute:
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *CreateSequenceStmt:
+		Walk(v, n.Name)
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+	case *AlterSequenceStmt:
+		Walk(v, n.Name)
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+	case *AlterIndexStmt:
+		Walk(v, n.Name)
+		Walk(v, n.Action)
+	case *AlterViewStmt:
+		Walk(v, n.Name)
+		Walk(v, n.Action)
+	case *AlterSchemaStmt:
+		Walk(v, n.Name)
+		Walk(v, n.Action)
+	case *RenameToAction:
+		Walk(v, n.NewName)
+	case *SetTablespaceAction:
+		Walk(v, n.Tablespace)
+	case *OwnerToAction:
+		Walk(v, n.Owner)
+	case *SetSchemaAction:
+		Walk(v, n.Schema)
+	case *SequenceDataType:
+		Walk(v, n.DataType)
+	case *SequenceIncrement:
+		Walk(v, n.Value)
+	case *SequenceStart:
+		Walk(v, n.Value)
+	case *SequenceCache:
+		Walk(v, n.Value)
+	case *SequenceMinValue:
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *SequenceMaxValue:
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *SequenceRestart:
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *SequenceOwnedBy:
+		if n.Column != nil {
+			Walk(v, n.Column)
+		}
+	case *SequenceCycle:
+		// nothing to do
+	case *CreateSchemaStmt:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+		if n.Authorization != nil {
+			Walk(v, n.Authorization)
+		}
+	case *CreateDatabaseStmt:
+		Walk(v, n.Name)
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+	case *DatabaseOption:
+		Walk(v, n.Value)
+	case *CreateExtensionStmt:
+		Walk(v, n.Name)
+		if n.Schema != nil {
+			Walk(v, n.Schema)
+		}
+		if n.Version != nil {
+			Walk(v, n.Version)
+		}
+	case *CreateTypeStmt:
+		Walk(v, n.Name)
+		if n.Definition != nil {
+			Walk(v, n.Definition)
+		}
+	case *EnumTypeDefinition:
+		for _, l := range n.Labels {
+			Walk(v, l)
+		}
+	case *CompositeTypeDefinition:
+		for _, a := range n.Attributes {
+			Walk(v, a)
+		}
+	case *RangeTypeDefinition:
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+	case *TypeOption:
+		Walk(v, n.Name)
+		Walk(v, n.Value)
+	case *TruncateStmt:
+		for _, t := range n.TableNames {
+			Walk(v, t)
+		}
+	case *VacuumStmt:
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+		for _, t := range n.Tables {
+			Walk(v, t)
+		}
+	case *AnalyzeStmt:
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+		for _, t := range n.Tables {
+			Walk(v, t)
+		}
+	case *ReindexStmt:
+		for _, o := range n.Options {
+			Walk(v, o)
+		}
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+	case *MaintenanceOption:
+		Walk(v, n.Name)
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *MaintenanceTable:
+		Walk(v, n.Name)
+		walkIdentLists(v, n.Columns)
+	case *PrepareStmt:
+		Walk(v, n.Name)
+		for _, t := range n.DataTypes {
+			Walk(v, t)
+		}
+		Walk(v, n.Stmt)
+	case *ExecuteStmt:
+		Walk(v, n.Name)
+		for _, a := range n.Args {
+			Walk(v, a)
+		}
+	case *ShowTablesStmt:
+		if n.Database != nil {
+			Walk(v, n.Database)
+		}
+		if n.Like != nil {
+			Walk(v, n.Like)
+		}
+		if n.Where != nil {
+			Walk(v, n.Where)
+		}
+	case *ShowColumnsStmt:
+		Walk(v, n.Table)
+		if n.Database != nil {
+			Walk(v, n.Database)
+		}
+		if n.Like != nil {
+			Walk(v, n.Like)
+		}
+		if n.Where != nil {
+			Walk(v, n.Where)
+		}
+	case *ShowCreateTableStmt:
+		Walk(v, n.Table)
+	case *DescribeStmt:
+		Walk(v, n.Table)
+		if n.Column != nil {
+			Walk(v, n.Column)
+		}
+	case *UseStmt:
+		Walk(v, n.Database)
+	case *CallStmt:
+		Walk(v, n.Name)
+		for _, a := range n.Args {
+			Walk(v, a)
+		}
+	case *DeallocateStmt:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+	case *DeclareCursorStmt:
+		Walk(v, n.Name)
+		Walk(v, n.Query)
+	case *FetchStmt:
+		if n.Count != nil {
+			Walk(v, n.Count)
+		}
+		Walk(v, n.Name)
+	case *CloseStmt:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+	case *MergeStmt:
+		Walk(v, n.Target)
+		if n.Alias != nil {
+			Walk(v, n.Alias)
+		}
+		Walk(v, n.Source)
+		Walk(v, n.On)
+		for _, c := range n.Clauses {
+			Walk(v, c)
+		}
+	case *MergeWhenClause:
+		if n.Condition != nil {
+			Walk(v, n.Condition)
+		}
+		Walk(v, n.Action)
+	case *MergeUpdate:
+		for _, a := range n.Assignments {
+			Walk(v, a)
+		}
+	case *MergeDelete, *MergeDoNothing:
+		// nothing to do
+	case *MergeInsert:
+		walkIdentLists(v, n.Columns)
+		if n.Values != nil {
+			Walk(v, n.Values)
+		}
+	case *SetStmt:
+		Walk(v, n.Name)
+		for _, val := range n.Values {
+			Walk(v, val)
+		}
+	case *SetTimeZoneStmt:
+		Walk(v, n.Value)
+	case *ShowStmt:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+	case *ResetStmt:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
 	case *DropIndexStmt:
 		walkIdentLists(v, n.IndexNames)
 	case *ExplainStmt:
 		Walk(v, n.Stmt)
 	case *Operator:
 		// nothing to do
+	case *IntervalValue:
+		Walk(v, n.Literal)
 	case *NullValue,
 		*LongValue,
+		*UnsignedLongValue,
 		*DoubleValue,
+		*NumericValue,
 		*SingleQuotedString,
 		*NationalStringLiteral,
+		*DollarQuotedString,
+		*EscapedStringLiteral,
+		*UnicodeStringLiteral,
+		*HexValue,
+		*BitStringLiteral,
 		*BooleanValue,
 		*DateValue,
 		*TimeValue,
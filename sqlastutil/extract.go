@@ -0,0 +1,520 @@
+package sqlastutil
+
+import (
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// Access classifies how a statement touches a table or a column.
+type Access int
+
+const (
+	Read Access = iota
+	Write
+)
+
+func (a Access) String() string {
+	switch a {
+	case Read:
+		return "READ"
+	case Write:
+		return "WRITE"
+	}
+	return ""
+}
+
+// TableRef is a table referenced by a statement.
+type TableRef struct {
+	Name   string // name as written in the statement, e.g. `public.users`
+	Access Access
+	Node   *sqlast.ObjectName // first occurrence
+}
+
+// ColumnRef is a column referenced by a statement.
+// Table is the table name the column was resolved to through aliases.
+// It is empty when the column is unqualified and more than one table is in scope.
+type ColumnRef struct {
+	Table  string
+	Column string
+	Access Access
+	Node   sqlast.Node // first occurrence, *sqlast.Ident or *sqlast.CompoundIdent
+}
+
+// ExtractTables returns the tables referenced by stmt without duplicates.
+// Tables that are modified by the statement (INSERT/UPDATE/DELETE/MERGE targets, DDL targets,
+// COPY FROM destinations) are classified as Write, everything else as Read.
+// Names of CTEs are not reported.
+func ExtractTables(stmt sqlast.Node) []*TableRef {
+	e := newExtractor()
+	e.stmt(stmt)
+	return e.tables
+}
+
+// ExtractColumns returns the columns referenced by stmt without duplicates.
+// FROM clauses (including join conditions) are visited before the select list.
+// Columns that receive values (INSERT column lists, UPDATE SET targets and DDL column
+// definitions) are classified as Write, everything else as Read.
+func ExtractColumns(stmt sqlast.Node) []*ColumnRef {
+	e := newExtractor()
+	e.stmt(stmt)
+	return e.columns
+}
+
+type source struct {
+	alias string
+	name  string
+}
+
+type scope struct {
+	parent  *scope
+	ctes    map[string]struct{}
+	sources []*source
+}
+
+func (s *scope) isCTE(name string) bool {
+	for c := s; c != nil; c = c.parent {
+		if _, ok := c.ctes[strings.ToLower(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the table name which qualifier points to.
+func (s *scope) resolve(qualifier string) string {
+	for c := s; c != nil; c = c.parent {
+		for _, src := range c.sources {
+			if src.alias != "" {
+				if strings.EqualFold(src.alias, qualifier) {
+					return src.name
+				}
+				continue
+			}
+			if strings.EqualFold(src.name, qualifier) || strings.EqualFold(lastPart(src.name), qualifier) {
+				return src.name
+			}
+		}
+	}
+	return qualifier
+}
+
+// unqualified returns the table name of an unqualified column
+// if the nearest scope that has sources has exactly one.
+func (s *scope) unqualified() string {
+	for c := s; c != nil; c = c.parent {
+		if len(c.sources) == 0 {
+			continue
+		}
+		if len(c.sources) == 1 {
+			return c.sources[0].name
+		}
+		return ""
+	}
+	return ""
+}
+
+func lastPart(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+type tableKey struct {
+	name   string
+	access Access
+}
+
+type columnKey struct {
+	table, column string
+	access        Access
+}
+
+type extractor struct {
+	tables      []*TableRef
+	columns     []*ColumnRef
+	seenTables  map[tableKey]struct{}
+	seenColumns map[columnKey]struct{}
+}
+
+func newExtractor() *extractor {
+	return &extractor{
+		seenTables:  make(map[tableKey]struct{}),
+		seenColumns: make(map[columnKey]struct{}),
+	}
+}
+
+func (e *extractor) addTable(name *sqlast.ObjectName, access Access) string {
+	n := name.ToSQLString()
+	k := tableKey{name: strings.ToLower(n), access: access}
+	if _, ok := e.seenTables[k]; !ok {
+		e.seenTables[k] = struct{}{}
+		e.tables = append(e.tables, &TableRef{Name: n, Access: access, Node: name})
+	}
+	return n
+}
+
+func (e *extractor) addColumn(table, column string, access Access, node sqlast.Node) {
+	k := columnKey{table: strings.ToLower(table), column: strings.ToLower(column), access: access}
+	if _, ok := e.seenColumns[k]; ok {
+		return
+	}
+	e.seenColumns[k] = struct{}{}
+	e.columns = append(e.columns, &ColumnRef{Table: table, Column: column, Access: access, Node: node})
+}
+
+func (e *extractor) addColumnIdents(table string, idents []*sqlast.Ident, access Access) {
+	for _, i := range idents {
+		e.addColumn(table, i.Value, access, i)
+	}
+}
+
+func (e *extractor) stmt(node sqlast.Node) {
+	switch n := node.(type) {
+	case *sqlast.File:
+		for _, s := range n.Stmts {
+			e.stmt(s)
+		}
+	case *sqlast.QueryStmt:
+		e.query(n, nil)
+	case *sqlast.ExplainStmt:
+		e.stmt(n.Stmt)
+	case *sqlast.PrepareStmt:
+		e.stmt(n.Stmt)
+	case *sqlast.DeclareCursorStmt:
+		e.query(n.Query, nil)
+	case *sqlast.InsertStmt:
+		name := e.addTable(n.TableName, Write)
+		e.addColumnIdents(name, n.Columns, Write)
+		switch src := n.Source.(type) {
+		case *sqlast.SubQuerySource:
+			e.query(src.SubQuery, nil)
+		case *sqlast.ConstructorSource:
+			for _, row := range src.Rows {
+				e.exprs(nil, row.Values...)
+			}
+		}
+		sc := &scope{sources: []*source{{name: name}}}
+		for _, a := range n.UpdateAssignments {
+			e.addColumn(name, a.ID.Value, Write, a.ID)
+			e.exprs(sc, a.Value)
+		}
+		e.selectItems(sc, n.Returning)
+	case *sqlast.UpdateStmt:
+		name := e.addTable(n.TableName, Write)
+		sc := &scope{sources: []*source{{name: name}}}
+		for _, a := range n.Assignments {
+			e.addColumn(name, a.ID.Value, Write, a.ID)
+			e.exprs(sc, a.Value)
+		}
+		e.exprs(sc, n.Selection)
+		e.selectItems(sc, n.Returning)
+	case *sqlast.DeleteStmt:
+		name := e.addTable(n.TableName, Write)
+		sc := &scope{sources: []*source{{name: name}}}
+		if n.Alias != nil {
+			sc.sources[0].alias = n.Alias.Value
+		}
+		e.from(sc, n.Using)
+		e.exprs(sc, n.Selection)
+		e.selectItems(sc, n.Returning)
+	case *sqlast.MergeStmt:
+		name := e.addTable(n.Target, Write)
+		sc := &scope{sources: []*source{{name: name}}}
+		if n.Alias != nil {
+			sc.sources[0].alias = n.Alias.Value
+		}
+		e.from(sc, []sqlast.TableReference{n.Source})
+		e.exprs(sc, n.On)
+		for _, c := range n.Clauses {
+			e.exprs(sc, c.Condition)
+			switch a := c.Action.(type) {
+			case *sqlast.MergeUpdate:
+				for _, as := range a.Assignments {
+					e.addColumn(name, as.ID.Value, Write, as.ID)
+					e.exprs(sc, as.Value)
+				}
+			case *sqlast.MergeInsert:
+				e.addColumnIdents(name, a.Columns, Write)
+				if a.Values != nil {
+					e.exprs(sc, a.Values.Values...)
+				}
+			}
+		}
+	case *sqlast.CopyStmt:
+		if n.Query != nil {
+			e.query(n.Query, nil)
+			return
+		}
+		access := Read
+		if n.Direction == sqlast.CopyFrom {
+			access = Write
+		}
+		name := e.addTable(n.TableName, access)
+		e.addColumnIdents(name, n.Columns, access)
+	case *sqlast.CreateViewStmt:
+		e.addTable(n.Name, Write)
+		e.query(n.Query, nil)
+	case *sqlast.CreateTableStmt:
+		name := e.addTable(n.Name, Write)
+		sc := &scope{sources: []*source{{name: name}}}
+		for _, elem := range n.Elements {
+			e.tableElement(sc, name, elem)
+		}
+	case *sqlast.AlterTableStmt:
+		name := e.addTable(n.TableName, Write)
+		sc := &scope{sources: []*source{{name: name}}}
+		switch a := n.Action.(type) {
+		case *sqlast.AddColumnTableAction:
+			e.tableElement(sc, name, a.Column)
+		case *sqlast.AddConstraintTableAction:
+			e.tableElement(sc, name, a.Constraint)
+		case *sqlast.RemoveColumnTableAction:
+			e.addColumn(name, a.Name.Value, Write, a.Name)
+		case *sqlast.AlterColumnTableAction:
+			e.addColumn(name, a.ColumnName.Value, Write, a.ColumnName)
+		}
+	case *sqlast.DropTableStmt:
+		for _, t := range n.TableNames {
+			e.addTable(t, Write)
+		}
+	case *sqlast.TruncateStmt:
+		for _, t := range n.TableNames {
+			e.addTable(t, Write)
+		}
+	case *sqlast.CreateIndexStmt:
+		name := e.addTable(n.TableName, Write)
+		e.addColumnIdents(name, n.ColumnNames, Read)
+		e.exprs(&scope{sources: []*source{{name: name}}}, n.Selection)
+	}
+}
+
+func (e *extractor) tableElement(sc *scope, table string, elem sqlast.TableElement) {
+	switch el := elem.(type) {
+	case *sqlast.ColumnDef:
+		e.addColumn(table, el.Name.Value, Write, el.Name)
+		e.exprs(sc, el.Default)
+		e.columnConstraints(sc, el.Constraints)
+	case *sqlast.PartitionColumn:
+		e.addColumn(table, el.Name.Value, Write, el.Name)
+		e.exprs(sc, el.Default)
+		e.columnConstraints(sc, el.Constraints)
+	case *sqlast.TableConstraint:
+		switch spec := el.Spec.(type) {
+		case *sqlast.CheckTableConstraint:
+			e.exprs(sc, spec.Expr)
+		case *sqlast.UniqueTableConstraint:
+			e.addColumnIdents(table, spec.Columns, Read)
+		case *sqlast.ReferentialTableConstraint:
+			e.addColumnIdents(table, spec.Columns, Read)
+			ref := e.addTable(sqlast.NewObjectName(spec.KeyExpr.TableName.Value), Read)
+			e.addColumnIdents(ref, spec.KeyExpr.Columns, Read)
+		}
+	}
+}
+
+func (e *extractor) columnConstraints(sc *scope, constraints []*sqlast.ColumnConstraint) {
+	for _, c := range constraints {
+		switch spec := c.Spec.(type) {
+		case *sqlast.CheckColumnSpec:
+			e.exprs(sc, spec.Expr)
+		case *sqlast.ReferencesColumnSpec:
+			ref := e.addTable(spec.TableName, Read)
+			e.addColumnIdents(ref, spec.Columns, Read)
+		}
+	}
+}
+
+// query extracts references from q and returns the scope of its leftmost SELECT.
+func (e *extractor) query(q *sqlast.QueryStmt, parent *scope) *scope {
+	sc := &scope{parent: parent}
+	for _, cte := range q.CTEs {
+		e.query(cte.Query, sc)
+		if sc.ctes == nil {
+			sc.ctes = make(map[string]struct{})
+		}
+		sc.ctes[strings.ToLower(cte.Alias.Value)] = struct{}{}
+	}
+	bodyScope := e.setExpr(q.Body, sc)
+	for _, o := range q.OrderBy {
+		e.exprs(bodyScope, o.Expr)
+	}
+	return bodyScope
+}
+
+func (e *extractor) setExpr(expr sqlast.SQLSetExpr, parent *scope) *scope {
+	switch s := expr.(type) {
+	case *sqlast.SQLSelect:
+		return e.sqlSelect(s, parent)
+	case *sqlast.SetOperationExpr:
+		sc := e.setExpr(s.Left, parent)
+		e.setExpr(s.Right, parent)
+		return sc
+	case *sqlast.QueryExpr:
+		return e.query(s.Query, parent)
+	case *sqlast.SQLValues:
+		for _, r := range s.Rows {
+			e.exprs(parent, r.Values...)
+		}
+	}
+	return parent
+}
+
+func (e *extractor) sqlSelect(s *sqlast.SQLSelect, parent *scope) *scope {
+	sc := &scope{parent: parent}
+	e.from(sc, s.FromClause)
+	e.exprs(sc, s.DistinctOn...)
+	e.selectItems(sc, s.Projection)
+	e.exprs(sc, s.WhereClause)
+	e.exprs(sc, s.GroupByClause...)
+	e.exprs(sc, s.HavingClause)
+	for _, w := range s.WindowClause {
+		e.exprs(sc, w.Spec)
+	}
+	return sc
+}
+
+func (e *extractor) selectItems(sc *scope, items []sqlast.SQLSelectItem) {
+	for _, item := range items {
+		switch i := item.(type) {
+		case *sqlast.UnnamedSelectItem:
+			e.exprs(sc, i.Node)
+		case *sqlast.AliasSelectItem:
+			e.exprs(sc, i.Expr)
+		}
+	}
+}
+
+// from registers the sources of refs to sc. Join conditions are
+// extracted after every source is registered.
+func (e *extractor) from(sc *scope, refs []sqlast.TableReference) {
+	var conds []sqlast.Node
+	var usings []*sqlast.Ident
+	var walkRef func(ref sqlast.Node)
+	walkRef = func(ref sqlast.Node) {
+		switch r := ref.(type) {
+		case *sqlast.Table:
+			name := r.Name.ToSQLString()
+			src := &source{name: name}
+			if r.Alias != nil {
+				src.alias = r.Alias.Value
+			}
+			if len(r.Name.Idents) != 1 || !sc.isCTE(name) {
+				e.addTable(r.Name, Read)
+			}
+			sc.sources = append(sc.sources, src)
+		case *sqlast.Derived:
+			if r.Lateral {
+				e.query(r.SubQuery, sc)
+			} else {
+				e.query(r.SubQuery, sc.parent)
+			}
+			if r.Alias != nil {
+				sc.sources = append(sc.sources, &source{alias: r.Alias.Value, name: r.Alias.Value})
+			}
+		case *sqlast.TableFunction:
+			conds = append(conds, r.Function.Args...)
+			if r.Alias != nil {
+				sc.sources = append(sc.sources, &source{alias: r.Alias.Value, name: r.Alias.Value})
+			}
+		case *sqlast.Unnest:
+			conds = append(conds, r.Exprs...)
+			if r.Alias != nil {
+				sc.sources = append(sc.sources, &source{alias: r.Alias.Value, name: r.Alias.Value})
+			}
+		case *sqlast.PartitionedJoinTable:
+			walkRef(r.Factor)
+		case *sqlast.TableJoinElement:
+			walkRef(r.Ref)
+		case *sqlast.QualifiedJoin:
+			walkRef(r.LeftElement)
+			walkRef(r.RightElement)
+			switch spec := r.Spec.(type) {
+			case *sqlast.JoinCondition:
+				conds = append(conds, spec.SearchCondition)
+			case *sqlast.NamedColumnsJoin:
+				usings = append(usings, spec.ColumnList...)
+			}
+		case *sqlast.NaturalJoin:
+			walkRef(r.LeftElement)
+			walkRef(r.RightElement)
+		case *sqlast.CrossJoin:
+			walkRef(r.Reference)
+			walkRef(r.Factor)
+		}
+	}
+	for _, ref := range refs {
+		walkRef(ref)
+	}
+	// USING columns exist in both sides of the join.
+	e.addColumnIdents("", usings, Read)
+	e.exprs(sc, conds...)
+}
+
+func (e *extractor) exprs(sc *scope, nodes ...sqlast.Node) {
+	for _, n := range nodes {
+		if n == nil {
+			continue
+		}
+		sqlast.Walk(&exprVisitor{e: e, sc: sc}, n)
+	}
+}
+
+type exprVisitor struct {
+	e  *extractor
+	sc *scope
+}
+
+func (v *exprVisitor) Visit(node sqlast.Node) sqlast.Visitor {
+	switch n := node.(type) {
+	case *sqlast.Ident:
+		v.e.addColumn(v.sc.unqualified(), n.Value, Read, n)
+		return nil
+	case *sqlast.CompoundIdent:
+		if len(n.Idents) == 1 {
+			v.e.addColumn(v.sc.unqualified(), n.Idents[0].Value, Read, n)
+			return nil
+		}
+		var parts []string
+		for _, i := range n.Idents[:len(n.Idents)-1] {
+			parts = append(parts, i.Value)
+		}
+		v.e.addColumn(v.sc.resolve(strings.Join(parts, ".")), n.Idents[len(n.Idents)-1].Value, Read, n)
+		return nil
+	case *sqlast.QueryStmt:
+		v.e.query(n, v.sc)
+		return nil
+	case *sqlast.Extract:
+		v.e.exprs(v.sc, n.Expr)
+		return nil
+	case *sqlast.Function:
+		// window names are not columns
+		v.e.exprs(v.sc, n.Args...)
+		for _, o := range n.WithinGroup {
+			v.e.exprs(v.sc, o)
+		}
+		v.e.exprs(v.sc, n.Filter)
+		if n.Over != nil {
+			v.e.exprs(v.sc, n.Over)
+		}
+		return nil
+	case *sqlast.WindowSpec:
+		v.e.exprs(v.sc, n.PartitionBy...)
+		for _, o := range n.OrderBy {
+			v.e.exprs(v.sc, o)
+		}
+		if n.WindowsFrame != nil {
+			v.e.exprs(v.sc, n.WindowsFrame)
+		}
+		return nil
+	case *sqlast.NamedArg:
+		// parameter names are not columns
+		v.e.exprs(v.sc, n.Value)
+		return nil
+	case *sqlast.ObjectName, *sqlast.QualifiedWildcard:
+		// function, type and wildcard prefixes are not columns
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,126 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestExtract(t *testing.T) {
+	cases := []struct {
+		name    string
+		src     string
+		tables  []string
+		columns []string
+	}{
+		{
+			name:    "simple select",
+			src:     "SELECT id, name FROM users WHERE age > 20",
+			tables:  []string{"users:READ"},
+			columns: []string{"users.id:READ", "users.name:READ", "users.age:READ"},
+		},
+		{
+			name: "join with aliases",
+			src: "SELECT o.id, c.name, amount FROM orders AS o " +
+				"INNER JOIN customers AS c ON o.customer_id = c.id",
+			tables: []string{"orders:READ", "customers:READ"},
+			columns: []string{
+				"orders.customer_id:READ", "customers.id:READ",
+				"orders.id:READ", "customers.name:READ", ".amount:READ",
+			},
+		},
+		{
+			name:    "correlated subquery",
+			src:     "SELECT u.id FROM users AS u WHERE EXISTS (SELECT * FROM orders AS o WHERE o.user_id = u.id)",
+			tables:  []string{"users:READ", "orders:READ"},
+			columns: []string{"users.id:READ", "orders.user_id:READ"},
+		},
+		{
+			name:    "cte",
+			src:     "WITH recent AS (SELECT id FROM orders WHERE created_at > '2020-01-01') SELECT r.id FROM recent AS r",
+			tables:  []string{"orders:READ"},
+			columns: []string{"orders.id:READ", "orders.created_at:READ", "recent.id:READ"},
+		},
+		{
+			name:    "insert select",
+			src:     "INSERT INTO archive (id, total) SELECT id, total FROM orders",
+			tables:  []string{"archive:WRITE", "orders:READ"},
+			columns: []string{"archive.id:WRITE", "archive.total:WRITE", "orders.id:READ", "orders.total:READ"},
+		},
+		{
+			name:    "update",
+			src:     "UPDATE public.users SET name = upper(nickname) WHERE id = 1",
+			tables:  []string{"public.users:WRITE"},
+			columns: []string{"public.users.name:WRITE", "public.users.nickname:READ", "public.users.id:READ"},
+		},
+		{
+			name:    "delete using",
+			src:     "DELETE FROM orders AS o USING customers AS c WHERE o.customer_id = c.id",
+			tables:  []string{"orders:WRITE", "customers:READ"},
+			columns: []string{"orders.customer_id:READ", "customers.id:READ"},
+		},
+		{
+			name:    "create table",
+			src:     "CREATE TABLE items (id int PRIMARY KEY, owner_id int REFERENCES users(id))",
+			tables:  []string{"items:WRITE", "users:READ"},
+			columns: []string{"items.id:WRITE", "items.owner_id:WRITE", "users.id:READ"},
+		},
+		{
+			name:   "truncate",
+			src:    "TRUNCATE TABLE logs, audit.events RESTART IDENTITY",
+			tables: []string{"logs:WRITE", "audit.events:WRITE"},
+		},
+		{
+			name:    "merge",
+			src:     "MERGE INTO customers c USING staging s ON c.id = s.id WHEN MATCHED THEN UPDATE SET name = s.name WHEN NOT MATCHED THEN INSERT (id, name) VALUES (s.id, s.name)",
+			tables:  []string{"customers:WRITE", "staging:READ"},
+			columns: []string{"customers.id:READ", "staging.id:READ", "customers.name:WRITE", "staging.name:READ", "customers.id:WRITE"},
+		},
+		{
+			name:    "named arguments",
+			src:     "SELECT make_interval(days => d) FROM t",
+			tables:  []string{"t:READ"},
+			columns: []string{"t.d:READ"},
+		},
+		{
+			name:    "named window",
+			src:     "SELECT sum(a) OVER w FROM t WINDOW w AS (PARTITION BY b ORDER BY c)",
+			tables:  []string{"t:READ"},
+			columns: []string{"t.a:READ", "t.b:READ", "t.c:READ"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			var tables []string
+			for _, r := range ExtractTables(stmt) {
+				tables = append(tables, fmt.Sprintf("%s:%s", r.Name, r.Access))
+			}
+			if d := cmp.Diff(c.tables, tables); d != "" {
+				t.Errorf("tables diff %s", d)
+			}
+
+			var columns []string
+			for _, r := range ExtractColumns(stmt) {
+				columns = append(columns, fmt.Sprintf("%s.%s:%s", r.Table, r.Column, r.Access))
+			}
+			if d := cmp.Diff(c.columns, columns); d != "" {
+				t.Errorf("columns diff %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+const placeholder = "?"
+
+// Normalize returns the shape of stmt as SQL. Literals and placeholders
+// are replaced with `?`, lists of them inside IN (...) are collapsed to
+// a single `?`, keywords are upper-cased and unquoted identifiers are
+// lower-cased. Statements that differ only in those respects have
+// the same normalized form.
+func Normalize(stmt sqlast.Node) (string, error) {
+	src := stmt.ToSQLString()
+	tokens, err := sqltoken.NewTokenizer(bytes.NewBufferString(src), &dialect.GenericSQLDialect{}).Tokenize()
+	if err != nil {
+		return "", errors.Errorf("Tokenize failed: %w", err)
+	}
+
+	var parts []string
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch tok.Kind {
+		case sqltoken.Number, sqltoken.SingleQuotedString, sqltoken.NationalStringLiteral, sqltoken.DollarQuotedString,
+			sqltoken.EscapedStringLiteral, sqltoken.UnicodeStringLiteral, sqltoken.HexStringLiteral, sqltoken.HexNumber, sqltoken.BitStringLiteral, sqltoken.Question:
+			parts = append(parts, placeholder)
+		case sqltoken.SQLKeyword:
+			parts = append(parts, normalizeWord(tok.Value.(*sqltoken.SQLWord)))
+		case sqltoken.Char, sqltoken.Colon:
+			// $1 or :name style placeholders
+			if i+1 < len(tokens) && isPlaceholderSuffix(tok, tokens[i+1]) {
+				parts = append(parts, placeholder)
+				i++
+				continue
+			}
+			parts = append(parts, tok.Value.(string))
+		case sqltoken.Whitespace:
+			parts = append(parts, " ")
+		default:
+			parts = append(parts, tok.Value.(string))
+		}
+	}
+
+	return strings.Join(collapseInLists(parts), ""), nil
+}
+
+// Fingerprint returns a hex encoded SHA-256 digest of Normalize(stmt),
+// which can be used to group queries with the same shape.
+func Fingerprint(stmt sqlast.Node) (string, error) {
+	n, err := Normalize(stmt)
+	if err != nil {
+		return "", errors.Errorf("Normalize failed: %w", err)
+	}
+	sum := sha256.Sum256([]byte(n))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func normalizeWord(w *sqltoken.SQLWord) string {
+	if w.QuoteStyle != 0 {
+		return w.String()
+	}
+	switch w.Keyword {
+	case "TRUE", "FALSE":
+		return placeholder
+	}
+	if _, ok := dialect.Keywords[w.Keyword]; ok {
+		return w.Keyword
+	}
+	return strings.ToLower(w.Value)
+}
+
+func isPlaceholderSuffix(prefix, next *sqltoken.Token) bool {
+	switch {
+	case prefix.Kind == sqltoken.Char && prefix.Value.(string) == "$":
+		return next.Kind == sqltoken.Number
+	case prefix.Kind == sqltoken.Colon:
+		return next.Kind == sqltoken.SQLKeyword
+	}
+	return false
+}
+
+// collapseInLists rewrites `IN (?, ?, ?)` to `IN (?)`.
+func collapseInLists(parts []string) []string {
+	var res []string
+	for i := 0; i < len(parts); i++ {
+		res = append(res, parts[i])
+		if parts[i] != "IN" {
+			continue
+		}
+		j := i + 1
+		for j < len(parts) && parts[j] == " " {
+			j++
+		}
+		if j >= len(parts) || parts[j] != "(" {
+			continue
+		}
+		end := -1
+		for k := j + 1; k < len(parts); k++ {
+			if parts[k] == ")" {
+				end = k
+				break
+			}
+			if parts[k] != placeholder && parts[k] != "," && parts[k] != " " {
+				break
+			}
+		}
+		if end < 0 {
+			continue
+		}
+		res = append(res, parts[i+1:j]...)
+		res = append(res, "(", placeholder, ")")
+		i = end
+	}
+	return res
+}
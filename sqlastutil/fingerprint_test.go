@@ -0,0 +1,85 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		expect string
+	}{
+		{
+			name:   "literals",
+			src:    "SELECT * FROM Users WHERE id = 10 AND name = 'bob' AND active = true",
+			expect: "SELECT * FROM users WHERE id = ? AND name = ? AND active = ?",
+		},
+		{
+			name:   "in list",
+			src:    "select ID from users where id in (1, 2, 3) and name not in ('a')",
+			expect: "SELECT id FROM users WHERE id IN (?) AND name NOT IN (?)",
+		},
+		{
+			name:   "in subquery is kept",
+			src:    "SELECT a FROM t WHERE a IN (SELECT b FROM u WHERE c = 1)",
+			expect: "SELECT a FROM t WHERE a IN (SELECT b FROM u WHERE c = ?)",
+		},
+		{
+			name:   "quoted identifiers are kept",
+			src:    `SELECT "MyColumn" FROM t LIMIT 10`,
+			expect: `SELECT "MyColumn" FROM t LIMIT ?`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			act, err := Normalize(stmt)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act != c.expect {
+				t.Errorf("should be \n %s but \n %s", c.expect, act)
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	fingerprint := func(src string) string {
+		parser, err := xsqlparser.NewParser(bytes.NewBufferString(src), &dialect.GenericSQLDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		f, err := Fingerprint(stmt)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return f
+	}
+
+	a := fingerprint("SELECT * FROM users WHERE id IN (1, 2) AND name = 'a'")
+	b := fingerprint("select * from USERS where id in (3, 4, 5) and name = 'b'")
+	if a != b {
+		t.Errorf("fingerprints should be equal: %s, %s", a, b)
+	}
+	if c := fingerprint("SELECT * FROM users WHERE id = 1"); a == c {
+		t.Errorf("fingerprints should differ: %s", c)
+	}
+}
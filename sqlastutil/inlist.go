@@ -0,0 +1,199 @@
+package sqlastutil
+
+import (
+	"sort"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// CollapseInLists replaces the lists of IN under root which have more than
+// limit elements with a single `?` placeholder, e.g. so that queries taking a
+// different number of arguments are cached as the same one. root is modified
+// in place.
+func CollapseInLists(root sqlast.Node, limit int) {
+	sqlast.Inspect(root, func(node sqlast.Node) bool {
+		if in, ok := node.(*sqlast.InList); ok && len(in.List) > limit {
+			in.List = []sqlast.Node{&sqlast.Placeholder{Value: "?"}}
+		}
+		return true
+	})
+}
+
+// SortInLists sorts the literals of the IN lists under root and removes the
+// duplicates. Lists which have other than numbers, or other than strings,
+// are left as they are. root is modified in place.
+func SortInLists(root sqlast.Node) {
+	sqlast.Inspect(root, func(node sqlast.Node) bool {
+		if in, ok := node.(*sqlast.InList); ok {
+			sortList(in)
+		}
+		return true
+	})
+}
+
+func sortList(in *sqlast.InList) {
+	var numbers []float64
+	var strs []string
+	for _, n := range in.List {
+		switch v := n.(type) {
+		case *sqlast.LongValue:
+			numbers = append(numbers, float64(v.Long))
+		case *sqlast.DoubleValue:
+			numbers = append(numbers, v.Double)
+		case *sqlast.SingleQuotedString:
+			strs = append(strs, v.String)
+		default:
+			return
+		}
+	}
+
+	list := append([]sqlast.Node{}, in.List...)
+	switch {
+	case len(numbers) == len(list):
+		sort.SliceStable(list, func(i, j int) bool { return number(list[i]) < number(list[j]) })
+	case len(strs) == len(list):
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].(*sqlast.SingleQuotedString).String < list[j].(*sqlast.SingleQuotedString).String
+		})
+	default:
+		return
+	}
+
+	in.List = list[:0]
+	for i, n := range list {
+		if i > 0 && equalLiterals(list[i-1], n) {
+			continue
+		}
+		in.List = append(in.List, n)
+	}
+}
+
+func number(n sqlast.Node) float64 {
+	if l, ok := n.(*sqlast.LongValue); ok {
+		return float64(l.Long)
+	}
+	return n.(*sqlast.DoubleValue).Double
+}
+
+func equalLiterals(a, b sqlast.Node) bool {
+	if s, ok := a.(*sqlast.SingleQuotedString); ok {
+		return s.String == b.(*sqlast.SingleQuotedString).String
+	}
+	return number(a) == number(b)
+}
+
+// OrToIn rewrites the chains of OR under root which compare the same column
+// with literals or placeholders into IN lists, e.g. `a = 1 OR b = 2 OR a IN
+// (3, 4)` into `a IN (1, 3, 4) OR b = 2`. The other operands are kept in
+// order. root is modified in place, and the result is returned since root
+// itself may be replaced.
+func OrToIn(root sqlast.Node) sqlast.Node {
+	return Apply(root, nil, func(c *Cursor) bool {
+		or, ok := c.Node().(*sqlast.BinaryExpr)
+		if !ok || or.Op.Type != sqlast.Or {
+			return true
+		}
+		if p, ok := c.Parent().(*sqlast.BinaryExpr); ok && p.Op.Type == sqlast.Or {
+			return true
+		}
+		if n := mergeOrOperands(or); n != nil && c.CanReplace(n) {
+			c.Replace(n)
+		}
+		return true
+	})
+}
+
+// mergeOrOperands returns the chain of OR whose top is or merging the
+// comparisons of the same columns, or nil if no operand is merged.
+func mergeOrOperands(or *sqlast.BinaryExpr) sqlast.Node {
+	type operand struct {
+		node   sqlast.Node
+		column sqlast.Node
+		list   []sqlast.Node
+		merged bool
+	}
+	var operands []*operand
+	var flatten func(n sqlast.Node)
+	flatten = func(n sqlast.Node) {
+		if b, ok := n.(*sqlast.BinaryExpr); ok && b.Op.Type == sqlast.Or {
+			flatten(b.Left)
+			flatten(b.Right)
+			return
+		}
+		column, list := inOperand(n)
+		if column != nil {
+			for _, o := range operands {
+				if o.column != nil && sqlast.Equal(o.column, column) {
+					o.list = append(o.list, list...)
+					o.merged = true
+					return
+				}
+			}
+		}
+		operands = append(operands, &operand{node: n, column: column, list: append([]sqlast.Node{}, list...)})
+	}
+	flatten(or)
+
+	var result sqlast.Node
+	merged := false
+	for _, o := range operands {
+		n := o.node
+		if o.merged {
+			n = &sqlast.InList{Expr: o.column, List: o.list}
+			merged = true
+		}
+		if result == nil {
+			result = n
+			continue
+		}
+		result = &sqlast.BinaryExpr{Left: result, Op: &sqlast.Operator{Type: sqlast.Or}, Right: n}
+	}
+	if !merged {
+		return nil
+	}
+	return result
+}
+
+// inOperand returns the column and the values n compares it with if n is
+// `column = value`, `value = column` or `column IN (values...)`.
+func inOperand(n sqlast.Node) (sqlast.Node, []sqlast.Node) {
+	switch e := n.(type) {
+	case *sqlast.BinaryExpr:
+		if e.Op.Type != sqlast.Eq {
+			return nil, nil
+		}
+		switch {
+		case isColumn(e.Left) && isLiteral(e.Right):
+			return e.Left, []sqlast.Node{e.Right}
+		case isLiteral(e.Left) && isColumn(e.Right):
+			return e.Right, []sqlast.Node{e.Left}
+		}
+	case *sqlast.InList:
+		if e.Negated || !isColumn(e.Expr) {
+			return nil, nil
+		}
+		for _, v := range e.List {
+			if !isLiteral(v) {
+				return nil, nil
+			}
+		}
+		return e.Expr, e.List
+	}
+	return nil, nil
+}
+
+func isColumn(n sqlast.Node) bool {
+	switch n.(type) {
+	case *sqlast.Ident, *sqlast.CompoundIdent:
+		return true
+	}
+	return false
+}
+
+func isLiteral(n sqlast.Node) bool {
+	switch n.(type) {
+	case sqlast.Value, *sqlast.Placeholder:
+		return true
+	}
+	return false
+}
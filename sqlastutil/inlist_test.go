@@ -0,0 +1,81 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestInLists(t *testing.T) {
+	cases := []struct {
+		name    string
+		src     string
+		expr    bool
+		rewrite func(sqlast.Node) sqlast.Node
+		expect  string
+	}{
+		{
+			name: "collapse",
+			src:  "SELECT * FROM t WHERE a IN (1, 2, 3) AND b IN (1, 2) AND c NOT IN (?, ?, ?, ?)",
+			rewrite: func(n sqlast.Node) sqlast.Node {
+				CollapseInLists(n, 2)
+				return n
+			},
+			expect: "SELECT * FROM t WHERE a IN (?) AND b IN (1, 2) AND c NOT IN (?)",
+		},
+		{
+			name: "sort",
+			src:  "SELECT * FROM t WHERE a IN (3, 1.5, 2, 1, 3) AND b IN ('b', 'a', 'b') AND c IN (2, '1') AND d IN (?, 1)",
+			rewrite: func(n sqlast.Node) sqlast.Node {
+				SortInLists(n)
+				return n
+			},
+			expect: "SELECT * FROM t WHERE a IN (1, 1.5, 2, 3) AND b IN ('a', 'b') AND c IN (2, '1') AND d IN (?, 1)",
+		},
+		{
+			name:    "or to in",
+			src:     "SELECT * FROM t WHERE a = 1 OR b = 2 OR 3 = a OR a IN (4, ?) OR a = b",
+			rewrite: OrToIn,
+			expect:  "SELECT * FROM t WHERE a IN (1, 3, 4, ?) OR b = 2 OR a = b",
+		},
+		{
+			name:    "nested",
+			src:     "SELECT * FROM t WHERE x > 0 AND (t.a = 'x' OR t.a = 'y') AND (a = 1 OR b = 1)",
+			rewrite: OrToIn,
+			expect:  "SELECT * FROM t WHERE x > 0 AND (t.a IN ('x', 'y')) AND (a = 1 OR b = 1)",
+		},
+		{
+			name:    "root",
+			src:     "a = 1 OR a = 2",
+			expr:    true,
+			rewrite: OrToIn,
+			expect:  "a IN (1, 2)",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var node sqlast.Node
+			var err error
+			if c.expr {
+				node, err = xsqlparser.ParseExpr(c.src, &dialect.GenericSQLDialect{})
+			} else {
+				var parser *xsqlparser.Parser
+				parser, err = xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				node, err = parser.ParseStatement()
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := c.rewrite(node).ToSQLString(); act != c.expect {
+				t.Errorf("should be \n %s but \n %s", c.expect, act)
+			}
+		})
+	}
+}
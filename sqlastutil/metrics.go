@@ -0,0 +1,98 @@
+package sqlastutil
+
+import (
+	"strings"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// Metrics are counts of the constructs in a statement which make it
+// expensive to execute, used to gate queries in CI or at runtime.
+type Metrics struct {
+	Joins         int      // JOINs and tables joined by commas in FROM clauses
+	Subqueries    int      // queries nested in the statement, except CTEs
+	CTEs          int      // common table expressions in WITH clauses
+	SetOperations int      // UNION, INTERSECT and EXCEPT
+	Depth         int      // maximum nesting depth of queries, 1 for a query without subqueries
+	Aggregates    int      // calls of aggregate functions, except window functions
+	Tables        []string // referenced tables without duplicates, see ExtractTables
+}
+
+// Score returns the complexity score of m, a weighted sum of the counts:
+//
+//	2*Joins + 3*Subqueries + 2*CTEs + 2*SetOperations + 5*(Depth-1) + Aggregates + len(Tables)
+func (m *Metrics) Score() int {
+	score := 2*m.Joins + 3*m.Subqueries + 2*m.CTEs + 2*m.SetOperations + m.Aggregates + len(m.Tables)
+	if m.Depth > 1 {
+		score += 5 * (m.Depth - 1)
+	}
+	return score
+}
+
+var aggregateFunctions = map[string]bool{
+	"COUNT": true, "SUM": true, "AVG": true, "MIN": true, "MAX": true,
+	"ARRAY_AGG": true, "STRING_AGG": true, "GROUP_CONCAT": true, "LISTAGG": true,
+	"JSON_AGG": true, "JSONB_AGG": true, "JSON_OBJECT_AGG": true, "JSONB_OBJECT_AGG": true,
+	"BOOL_AND": true, "BOOL_OR": true, "EVERY": true, "BIT_AND": true, "BIT_OR": true,
+	"STDDEV": true, "STDDEV_POP": true, "STDDEV_SAMP": true, "VARIANCE": true, "VAR_POP": true, "VAR_SAMP": true,
+	"PERCENTILE_CONT": true, "PERCENTILE_DISC": true, "MODE": true,
+}
+
+// Measure returns the metrics of stmt.
+func Measure(stmt sqlast.Node) *Metrics {
+	m := &Metrics{}
+	for _, t := range ExtractTables(stmt) {
+		m.Tables = append(m.Tables, t.Name)
+	}
+
+	ctes := make(map[*sqlast.QueryStmt]bool)
+	var stack []sqlast.Node // nodes being visited
+	depth := 0
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		if node == nil {
+			if q, ok := stack[len(stack)-1].(*sqlast.QueryStmt); ok && !ctes[q] {
+				depth--
+			}
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, node)
+
+		switch n := node.(type) {
+		case *sqlast.QueryStmt:
+			if !ctes[n] {
+				// CTEs are at the depth of the query defining them
+				depth++
+				if depth > m.Depth {
+					m.Depth = depth
+				}
+				if depth > 1 {
+					// not the outermost query, e.g: of INSERT ... SELECT
+					m.Subqueries++
+				}
+			}
+			for _, cte := range n.CTEs {
+				ctes[cte.Query] = true
+				m.CTEs++
+			}
+		case *sqlast.SQLSelect:
+			if len(n.FromClause) > 1 {
+				m.Joins += len(n.FromClause) - 1
+			}
+		case *sqlast.QualifiedJoin, *sqlast.NaturalJoin, *sqlast.CrossJoin:
+			m.Joins++
+		case *sqlast.SetOperationExpr:
+			m.SetOperations++
+		case *sqlast.Function:
+			if n.Over != nil || n.OverName != nil {
+				break
+			}
+			name := n.Name.Idents[len(n.Name.Idents)-1].Value
+			if aggregateFunctions[strings.ToUpper(name)] || n.WithinGroup != nil || n.Filter != nil {
+				m.Aggregates++
+			}
+		}
+		return true
+	})
+	return m
+}
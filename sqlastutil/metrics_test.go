@@ -0,0 +1,69 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestMeasure(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		expect *Metrics
+		score  int
+	}{
+		{
+			name:   "simple",
+			src:    "SELECT a FROM t WHERE b = 1",
+			expect: &Metrics{Depth: 1, Tables: []string{"t"}},
+			score:  1,
+		},
+		{
+			name: "joins and aggregates",
+			src: `SELECT t.a, count(*), sum(u.b) FILTER (WHERE u.c), rank() OVER (ORDER BY t.a), max(v.d) OVER ()
+FROM t JOIN u ON t.id = u.id LEFT JOIN v ON t.id = v.id, w
+GROUP BY t.a`,
+			expect: &Metrics{Joins: 3, Depth: 1, Aggregates: 2, Tables: []string{"t", "u", "v", "w"}},
+			score:  12,
+		},
+		{
+			name: "subqueries",
+			src: `WITH c AS (SELECT id FROM t WHERE id IN (SELECT id FROM u))
+SELECT * FROM c WHERE EXISTS (SELECT 1 FROM (SELECT * FROM v) AS x WHERE x.id = c.id)
+UNION SELECT id FROM w`,
+			expect: &Metrics{Subqueries: 3, CTEs: 1, SetOperations: 1, Depth: 3, Tables: []string{"t", "u", "v", "w"}},
+			score:  27,
+		},
+		{
+			name:   "insert select",
+			src:    "INSERT INTO t SELECT a FROM u",
+			expect: &Metrics{Depth: 1, Tables: []string{"t", "u"}},
+			score:  2,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			m := Measure(stmt)
+			if diff := cmp.Diff(c.expect, m); diff != "" {
+				t.Errorf("diff: %s", diff)
+			}
+			if s := m.Score(); s != c.score {
+				t.Errorf("score must be %d but %d", c.score, s)
+			}
+		})
+	}
+}
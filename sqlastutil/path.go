@@ -0,0 +1,72 @@
+package sqlastutil
+
+import (
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// PathElement is an element of the path returned by PathTo.
+type PathElement struct {
+	Node sqlast.Node
+	// Name is the name of the field of the parent containing Node,
+	// e.g. "WhereClause", or "" for the root.
+	Name string
+	// Index is the index of Node in the field if it's a slice, or -1.
+	Index int
+}
+
+// PathTo returns the path from root to target, i.e: the chain of the
+// ancestors of target starting with root and ending with target itself.
+// It returns nil if target is not found under root.
+func PathTo(root, target sqlast.Node) []PathElement {
+	var stack, path []PathElement
+	Apply(root, func(c *Cursor) bool {
+		if c.Node() == nil || path != nil {
+			return false
+		}
+		e := PathElement{Node: c.Node(), Name: c.Name(), Index: c.Index()}
+		if len(stack) == 0 {
+			e.Name = ""
+		}
+		stack = append(stack, e)
+		if c.Node() == target {
+			path = append([]PathElement{}, stack...)
+			return false
+		}
+		return true
+	}, func(c *Cursor) bool {
+		stack = stack[:len(stack)-1]
+		return path == nil
+	})
+	return path
+}
+
+// Parents returns the map from each node under root to its parent.
+func Parents(root sqlast.Node) map[sqlast.Node]sqlast.Node {
+	parents := make(map[sqlast.Node]sqlast.Node)
+	var stack []sqlast.Node
+	Apply(root, func(c *Cursor) bool {
+		if c.Node() == nil {
+			return false
+		}
+		if len(stack) != 0 {
+			parents[c.Node()] = stack[len(stack)-1]
+		}
+		stack = append(stack, c.Node())
+		return true
+	}, func(c *Cursor) bool {
+		stack = stack[:len(stack)-1]
+		return true
+	})
+	return parents
+}
+
+// InField reports whether the node at the end of path is in the field
+// named name of any of its ancestors, e.g. InField(path, "WhereClause").
+func InField(path []PathElement, name string) bool {
+	for _, e := range path {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestPathTo(t *testing.T) {
+	src := "SELECT a, b FROM t WHERE c IN (SELECT d FROM u WHERE e = 1)"
+	parser, err := xsqlparser.NewParser(bytes.NewBufferString(src), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stmt, err := parser.ParseStatement()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	idents := make(map[string]*sqlast.Ident)
+	sqlast.Inspect(stmt, func(node sqlast.Node) bool {
+		if i, ok := node.(*sqlast.Ident); ok {
+			idents[i.Value] = i
+		}
+		return true
+	})
+
+	t.Run("path", func(t *testing.T) {
+		var actual []string
+		for _, e := range PathTo(stmt, idents["e"]) {
+			actual = append(actual, fmt.Sprintf("%s[%d] %T", e.Name, e.Index, e.Node))
+		}
+		expect := []string{
+			"[-1] *sqlast.QueryStmt",
+			"Body[-1] *sqlast.SQLSelect",
+			"WhereClause[-1] *sqlast.InSubQuery",
+			"SubQuery[-1] *sqlast.QueryStmt",
+			"Body[-1] *sqlast.SQLSelect",
+			"WhereClause[-1] *sqlast.BinaryExpr",
+			"Left[-1] *sqlast.Ident",
+		}
+		if diff := cmp.Diff(expect, actual); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+	})
+
+	t.Run("in field", func(t *testing.T) {
+		if InField(PathTo(stmt, idents["b"]), "WhereClause") {
+			t.Error("b must not be in WHERE")
+		}
+		if !InField(PathTo(stmt, idents["d"]), "WhereClause") {
+			t.Error("d must be in WHERE")
+		}
+		if p := PathTo(stmt, sqlast.NewIdent("a")); p != nil {
+			t.Errorf("must be nil but %v", p)
+		}
+	})
+
+	t.Run("parents", func(t *testing.T) {
+		parents := Parents(stmt)
+		if p, ok := parents[idents["e"]].(*sqlast.BinaryExpr); !ok || p.Left != idents["e"] {
+			t.Errorf("parent of e must be e = 1 but %v", parents[idents["e"]])
+		}
+		if _, ok := parents[stmt]; ok {
+			t.Error("root must have no parent")
+		}
+	})
+}
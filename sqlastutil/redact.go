@@ -0,0 +1,47 @@
+package sqlastutil
+
+import "github.com/akito0107/xsqlparser/sqlast"
+
+// Redact replaces string and numeric literals in stmt with `?` placeholders
+// and returns the resulting SQL together with the replaced literals in the
+// order they appear. stmt itself is left unchanged.
+//
+// Literals held by fields that cannot store a placeholder, and the counts of
+// LIMIT, OFFSET, FETCH and TOP are kept as they are.
+func Redact(stmt sqlast.Node) (string, []sqlast.Value) {
+	var values []sqlast.Value
+	replaced := make(map[*sqlast.Placeholder]sqlast.Value)
+
+	redacted := Apply(stmt, func(c *Cursor) bool {
+		switch c.Parent().(type) {
+		case *sqlast.LimitExpr, *sqlast.Fetch, *sqlast.Top:
+			return true
+		}
+		switch n := c.Node().(type) {
+		case *sqlast.LongValue, *sqlast.DoubleValue, *sqlast.NumericValue, *sqlast.SingleQuotedString, *sqlast.NationalStringLiteral,
+			*sqlast.DollarQuotedString, *sqlast.EscapedStringLiteral, *sqlast.UnicodeStringLiteral, *sqlast.HexValue, *sqlast.BitStringLiteral:
+			p := &sqlast.Placeholder{Value: "?", From: n.Pos(), To: n.End()}
+			if !c.CanReplace(p) {
+				return true
+			}
+			c.Replace(p)
+			v := n.(sqlast.Value)
+			values = append(values, v)
+			replaced[p] = v
+		}
+		return true
+	}, nil)
+	sql := redacted.ToSQLString()
+
+	// put the original literals back
+	Apply(redacted, func(c *Cursor) bool {
+		if p, ok := c.Node().(*sqlast.Placeholder); ok {
+			if v, ok := replaced[p]; ok {
+				c.Replace(v)
+			}
+		}
+		return true
+	}, nil)
+
+	return sql, values
+}
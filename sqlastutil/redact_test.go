@@ -0,0 +1,68 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestRedact(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		expect string
+		values []string
+	}{
+		{
+			name:   "select",
+			src:    "SELECT name FROM users WHERE id = 10 AND email = 'a@example.com' LIMIT 5",
+			expect: "SELECT name FROM users WHERE id = ? AND email = ? LIMIT 5",
+			values: []string{"10", "'a@example.com'"},
+		},
+		{
+			name:   "insert",
+			src:    "INSERT INTO users (id, name, score) VALUES (1, 'bob', 1.5)",
+			expect: "INSERT INTO users (id, name, score) VALUES (?, ?, ?)",
+			values: []string{"1", "'bob'", "1.5"},
+		},
+		{
+			name:   "nested",
+			src:    "UPDATE users SET name = 'x' WHERE id IN (SELECT user_id FROM orders WHERE amount > 100)",
+			expect: "UPDATE users SET name = ? WHERE id IN (SELECT user_id FROM orders WHERE amount > ?)",
+			values: []string{"'x'", "100"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			orig := stmt.ToSQLString()
+
+			act, values := Redact(stmt)
+			if act != c.expect {
+				t.Errorf("should be \n %s but \n %s", c.expect, act)
+			}
+			var strs []string
+			for _, v := range values {
+				strs = append(strs, v.ToSQLString())
+			}
+			if d := cmp.Diff(c.values, strs); d != "" {
+				t.Errorf("values diff %s", d)
+			}
+			if after := stmt.ToSQLString(); after != orig {
+				t.Errorf("stmt should be unchanged but \n %s", after)
+			}
+		})
+	}
+}
@@ -7,10 +7,30 @@ import (
 	"github.com/akito0107/xsqlparser/sqlast"
 )
 
+// ApplyFunc is invoked by Apply for each node n, before and/or after
+// the node's children, using a Cursor describing the current node and
+// providing operations on it.
+//
+// The return value of ApplyFunc controls the syntax tree traversal.
+// See Apply for details.
 type ApplyFunc func(*Cursor) bool
 
 var abort = new(int)
 
+// Apply traverses a syntax tree recursively, starting with root,
+// and calling pre and post for each node as described below.
+// Apply returns the syntax tree, possibly modified.
+//
+// If pre is not nil, it is called for each node before the node's
+// children are traversed (pre-order). If pre returns false, no
+// children are traversed, and post is not called for that node.
+//
+// If post is not nil, and a prior call of pre didn't return false,
+// post is called for each node after its children are traversed
+// (post-order). If post returns false, traversal is terminated and
+// Apply returns immediately.
+//
+// Only fields that refer to AST nodes are considered children.
 func Apply(root sqlast.Node, pre, post ApplyFunc) (result sqlast.Node) {
 	parent := &struct {
 		sqlast.Node
@@ -27,6 +47,9 @@ func Apply(root sqlast.Node, pre, post ApplyFunc) (result sqlast.Node) {
 	return
 }
 
+// A Cursor describes a node encountered during Apply.
+// Information about the node and its parent is available
+// from the Node, Parent, Name, and Index methods.
 type Cursor struct {
 	parent sqlast.Node
 	name   string
@@ -34,12 +57,19 @@ type Cursor struct {
 	node   sqlast.Node
 }
 
+// Node returns the current Node.
 func (c *Cursor) Node() sqlast.Node { return c.node }
 
+// Parent returns the parent of the current Node.
 func (c *Cursor) Parent() sqlast.Node { return c.parent }
 
+// Name returns the name of the parent Node field that contains the current Node.
+// If the parent is a *sqlast.File and the current Node is a statement,
+// Name returns "Stmts".
 func (c *Cursor) Name() string { return c.name }
 
+// Index reports the index >= 0 of the current Node in the slice of Nodes that
+// contains it, or a value < 0 if the current Node is not part of a slice.
 func (c *Cursor) Index() int {
 	if c.iter != nil {
 		return c.iter.index
@@ -51,6 +81,17 @@ func (c *Cursor) field() reflect.Value {
 	return reflect.Indirect(reflect.ValueOf(c.parent)).FieldByName(c.name)
 }
 
+// CanReplace reports whether the current Node can be replaced with n, that is
+// n is assignable to the field or the slice element containing the Node.
+func (c *Cursor) CanReplace(n sqlast.Node) bool {
+	v := c.field()
+	if i := c.Index(); i >= 0 {
+		v = v.Index(i)
+	}
+	return reflect.TypeOf(n).AssignableTo(v.Type())
+}
+
+// Replace replaces the current Node with n.
 func (c *Cursor) Replace(n sqlast.Node) {
 	v := c.field()
 	if i := c.Index(); i >= 0 {
@@ -59,6 +100,8 @@ func (c *Cursor) Replace(n sqlast.Node) {
 	v.Set(reflect.ValueOf(n))
 }
 
+// Delete deletes the current Node from its containing slice.
+// If the current Node is not part of a slice, Delete panics.
 func (c *Cursor) Delete() {
 	i := c.Index()
 	if i < 0 {
@@ -73,6 +116,9 @@ func (c *Cursor) Delete() {
 	c.iter.step--
 }
 
+// InsertAfter inserts n after the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertAfter panics.
+// Apply does not walk n.
 func (c *Cursor) InsertAfter(n sqlast.Node) {
 	i := c.Index()
 	if i < 0 {
@@ -86,6 +132,9 @@ func (c *Cursor) InsertAfter(n sqlast.Node) {
 	c.iter.step++
 }
 
+// InsertBefore inserts n before the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertBefore panics.
+// Apply will not walk n.
 func (c *Cursor) InsertBefore(n sqlast.Node) {
 	i := c.Index()
 	if i < 0 {
@@ -132,6 +181,8 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		// nothing to do
 	case *sqlast.Wildcard:
 		// nothing to do
+	case *sqlast.Placeholder:
+		// nothing to do
 	case *sqlast.QualifiedWildcard:
 		a.applyList(n, "Idents")
 	case *sqlast.CompoundIdent:
@@ -154,34 +205,103 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		a.apply(n, "Left", nil, n.Left)
 		a.apply(n, "Op", nil, n.Op)
 		a.apply(n, "Right", nil, n.Right)
+	case *sqlast.LikeEscape:
+		a.apply(n, "Like", nil, n.Like)
+		a.apply(n, "Escape", nil, n.Escape)
+	case *sqlast.Collate:
+		a.apply(n, "Expr", nil, n.Expr)
+		a.apply(n, "Collation", nil, n.Collation)
 	case *sqlast.Cast:
 		a.apply(n, "Expr", nil, n.Expr)
 		a.apply(n, "DataType", nil, n.DataType)
+	case *sqlast.Extract:
+		a.apply(n, "Field", nil, n.Field)
+		a.apply(n, "Expr", nil, n.Expr)
+	case *sqlast.Position:
+		a.apply(n, "Substring", nil, n.Substring)
+		a.apply(n, "String", nil, n.String)
+	case *sqlast.Substring:
+		a.apply(n, "Expr", nil, n.Expr)
+		if n.Start != nil {
+			a.apply(n, "Start", nil, n.Start)
+		}
+		if n.Length != nil {
+			a.apply(n, "Length", nil, n.Length)
+		}
+	case *sqlast.Trim:
+		if n.Characters != nil {
+			a.apply(n, "Characters", nil, n.Characters)
+		}
+		a.apply(n, "Expr", nil, n.Expr)
+	case *sqlast.Overlay:
+		a.apply(n, "Expr", nil, n.Expr)
+		a.apply(n, "Placing", nil, n.Placing)
+		a.apply(n, "Start", nil, n.Start)
+		if n.Length != nil {
+			a.apply(n, "Length", nil, n.Length)
+		}
+	case *sqlast.Subscript:
+		a.apply(n, "Expr", nil, n.Expr)
+		if n.Index != nil {
+			a.apply(n, "Index", nil, n.Index)
+		}
+		if n.Upper != nil {
+			a.apply(n, "Upper", nil, n.Upper)
+		}
+	case *sqlast.ArrayConstructor:
+		a.applyList(n, "Elements")
+	case *sqlast.SQLRowExpr:
+		a.applyList(n, "Values")
 	case *sqlast.Nested:
 		a.apply(n, "AST", nil, n.AST)
+	case *sqlast.OuterJoinColumn:
+		a.apply(n, "Column", nil, n.Column)
 	case *sqlast.UnaryExpr:
 		a.apply(n, "Op", nil, n.Op)
 		a.apply(n, "Expr", nil, n.Expr)
 	case *sqlast.Function:
 		a.apply(n, "Name", nil, n.Name)
 		a.applyList(n, "Args")
+		a.applyList(n, "WithinGroup")
+		if n.Filter != nil {
+			a.apply(n, "Filter", nil, n.Filter)
+		}
 		if n.Over != nil {
 			a.apply(n, "Over", nil, n.Over)
 		}
+		if n.OverName != nil {
+			a.apply(n, "OverName", nil, n.OverName)
+		}
+	case *sqlast.NamedArg:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Value", nil, n.Value)
 	case *sqlast.CaseExpr:
-		a.apply(n, "Operand", nil, n.Operand)
+		if n.Operand != nil {
+			a.apply(n, "Operand", nil, n.Operand)
+		}
+		a.applyList(n, "Conditions")
+		a.applyList(n, "Results")
+		if n.ElseResult != nil {
+			a.apply(n, "ElseResult", nil, n.ElseResult)
+		}
 	case *sqlast.Exists:
-		a.apply(n, "QueryStmt", nil, n.Query)
+		a.apply(n, "Query", nil, n.Query)
 	case *sqlast.SubQuery:
-		a.apply(n, "QueryStmt", nil, n.Query)
+		a.apply(n, "Query", nil, n.Query)
 	case *sqlast.ObjectName:
 		a.applyList(n, "Idents")
 	case *sqlast.WindowSpec:
+		if n.Name != nil {
+			a.apply(n, "Name", nil, n.Name)
+		}
 		a.applyList(n, "PartitionBy")
 		a.applyList(n, "OrderBy")
 		if n.WindowsFrame != nil {
 			a.apply(n, "WindowsFrame", nil, n.WindowsFrame)
 		}
+	case *sqlast.NamedWindow:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Spec", nil, n.Spec)
 	case *sqlast.WindowFrame:
 		a.apply(n, "Units", nil, n.Units)
 		a.apply(n, "StartBound", nil, n.StartBound)
@@ -202,17 +322,23 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		if n.Limit != nil {
 			a.apply(n, "Limit", nil, n.Limit)
 		}
+		if n.Fetch != nil {
+			a.apply(n, "Fetch", nil, n.Fetch)
+		}
+		a.applyList(n, "Locks")
 	case *sqlast.CTE:
-		a.apply(n, "QueryStmt", nil, n.Query)
+		a.apply(n, "Query", nil, n.Query)
 		a.apply(n, "Alias", nil, n.Alias)
 	case *sqlast.SelectExpr:
 		a.apply(n, "Select", nil, n.Select)
 	case *sqlast.QueryExpr:
-		a.apply(n, "QueryStmt", nil, n.Query)
+		a.apply(n, "Query", nil, n.Query)
 	case *sqlast.SetOperationExpr:
 		a.apply(n, "Op", nil, n.Op)
 		a.apply(n, "Left", nil, n.Left)
 		a.apply(n, "Right", nil, n.Right)
+	case *sqlast.SQLValues:
+		a.applyList(n, "Rows")
 	case *sqlast.UnionOperator:
 		// nothing to do
 	case *sqlast.ExceptOperator:
@@ -220,8 +346,17 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 	case *sqlast.IntersectOperator:
 		// nothing to do
 	case *sqlast.SQLSelect:
+		if n.Hints != nil {
+			a.apply(n, "Hints", nil, n.Hints)
+		}
+		a.applyList(n, "DistinctOn")
+		if n.Top != nil {
+			a.apply(n, "Top", nil, n.Top)
+		}
 		a.applyList(n, "Projection")
 		a.applyList(n, "FromClause")
+		a.applyList(n, "LateralViews")
+		a.applyList(n, "ArrayJoins")
 		if n.WhereClause != nil {
 			a.apply(n, "WhereClause", nil, n.WhereClause)
 		}
@@ -229,6 +364,18 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		if n.HavingClause != nil {
 			a.apply(n, "HavingClause", nil, n.HavingClause)
 		}
+		a.applyList(n, "WindowClause")
+		a.applyList(n, "DistributeBy")
+		a.applyList(n, "SortBy")
+		a.applyList(n, "ClusterBy")
+	case *sqlast.ArrayJoin:
+		a.applyList(n, "Items")
+	case *sqlast.LateralView:
+		a.apply(n, "Generator", nil, n.Generator)
+		if n.TableAlias != nil {
+			a.apply(n, "TableAlias", nil, n.TableAlias)
+		}
+		a.applyList(n, "ColumnAliases")
 	case *sqlast.QualifiedJoin:
 		a.apply(n, "LeftElement", nil, n.LeftElement)
 		a.apply(n, "Type", nil, n.Type)
@@ -240,6 +387,8 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		// nothing to do
 	case *sqlast.JoinCondition:
 		a.apply(n, "SearchCondition", nil, n.SearchCondition)
+	case *sqlast.NamedColumnsJoin:
+		a.applyList(n, "ColumnList")
 	case *sqlast.NaturalJoin:
 		a.apply(n, "LeftElement", nil, n.LeftElement)
 		a.apply(n, "Type", nil, n.Type)
@@ -252,13 +401,56 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		if n.Alias != nil {
 			a.apply(n, "Alias", nil, n.Alias)
 		}
-		a.applyList(n, "Args")
+		a.applyList(n, "Columns")
+		a.applyList(n, "IndexHints")
 		a.applyList(n, "WithHints")
+		if n.CHSample != nil {
+			a.apply(n, "CHSample", nil, n.CHSample)
+		}
+		if n.Sample != nil {
+			a.apply(n, "Sample", nil, n.Sample)
+		}
+	case *sqlast.CHSample:
+		a.apply(n, "Ratio", nil, n.Ratio)
+		if n.Offset != nil {
+			a.apply(n, "Offset", nil, n.Offset)
+		}
+	case *sqlast.IndexHint:
+		a.applyList(n, "Indexes")
+	case *sqlast.OptimizerHints:
+		a.applyList(n, "Hints")
+	case *sqlast.OptimizerHint:
+		// nothing to do
+	case *sqlast.TableSample:
+		a.apply(n, "Method", nil, n.Method)
+		a.applyList(n, "Args")
+		if n.Repeatable != nil {
+			a.apply(n, "Repeatable", nil, n.Repeatable)
+		}
+	case *sqlast.GroupingSets:
+		a.applyList(n, "Sets")
+	case *sqlast.Rollup:
+		a.applyList(n, "Sets")
+	case *sqlast.Cube:
+		a.applyList(n, "Sets")
 	case *sqlast.Derived:
 		a.apply(n, "SubQuery", nil, n.SubQuery)
 		if n.Alias != nil {
 			a.apply(n, "Alias", nil, n.Alias)
 		}
+		a.applyList(n, "Columns")
+	case *sqlast.TableFunction:
+		a.apply(n, "Function", nil, n.Function)
+		if n.Alias != nil {
+			a.apply(n, "Alias", nil, n.Alias)
+		}
+		a.applyList(n, "Columns")
+	case *sqlast.Unnest:
+		a.applyList(n, "Exprs")
+		if n.Alias != nil {
+			a.apply(n, "Alias", nil, n.Alias)
+		}
+		a.applyList(n, "Columns")
 	case *sqlast.UnnamedSelectItem:
 		a.apply(n, "Node", nil, n.Node)
 	case *sqlast.AliasSelectItem:
@@ -270,13 +462,21 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		// nothing to do
 	case *sqlast.OrderByExpr:
 		a.apply(n, "Expr", nil, n.Expr)
+	case *sqlast.LockingClause:
+		a.applyList(n, "Of")
 	case *sqlast.LimitExpr:
-		if !n.All {
+		if n.LimitValue != nil {
 			a.apply(n, "LimitValue", nil, n.LimitValue)
 		}
 		if n.OffsetValue != nil {
 			a.apply(n, "OffsetValue", nil, n.OffsetValue)
 		}
+	case *sqlast.Fetch:
+		if n.Count != nil {
+			a.apply(n, "Count", nil, n.Count)
+		}
+	case *sqlast.Top:
+		a.apply(n, "Count", nil, n.Count)
 	case *sqlast.CharType:
 		// nothing to do
 	case *sqlast.VarcharType:
@@ -301,6 +501,12 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		// nothing to do
 	case *sqlast.BigInt:
 		// nothing to do
+	case *sqlast.SmallSerial:
+		// nothing to do
+	case *sqlast.Serial:
+		// nothing to do
+	case *sqlast.BigSerial:
+		// nothing to do
 	case *sqlast.Real:
 		// nothing to do
 	case *sqlast.Double:
@@ -321,13 +527,29 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		// nothing to do
 	case *sqlast.Array:
 		// nothing to do
-	case *sqlast.Custom:
+	case *sqlast.String:
+		// nothing to do
+	case *sqlast.HiveArray:
+		// nothing to do
+	case *sqlast.HiveMap:
+		// nothing to do
+	case *sqlast.HiveStruct:
 		// nothing to do
+	case *sqlast.CHNullable:
+		// nothing to do
+	case *sqlast.CHLowCardinality:
+		// nothing to do
+	case *sqlast.Custom:
+		a.applyList(n, "Args")
 	case *sqlast.InsertStmt:
+		if n.Hints != nil {
+			a.apply(n, "Hints", nil, n.Hints)
+		}
 		a.apply(n, "TableName", nil, n.TableName)
 		a.applyList(n, "Columns")
 		a.apply(n, "Source", nil, n.Source)
 		a.applyList(n, "UpdateAssignments")
+		a.applyList(n, "Returning")
 	case *sqlast.ConstructorSource:
 		a.applyList(n, "Rows")
 	case *sqlast.RowValueExpr:
@@ -335,23 +557,100 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 	case *sqlast.SubQuerySource:
 		a.apply(n, "SubQuery", nil, n.SubQuery)
 	case *sqlast.CopyStmt:
-		a.apply(n, "TableName", nil, n.TableName)
+		if n.TableName != nil {
+			a.apply(n, "TableName", nil, n.TableName)
+		}
 		a.applyList(n, "Columns")
+		if n.Query != nil {
+			a.apply(n, "Query", nil, n.Query)
+		}
+		if n.Filename != nil {
+			a.apply(n, "Filename", nil, n.Filename)
+		}
+		a.applyList(n, "Options")
+	case *sqlast.UnloadStmt:
+		a.apply(n, "Query", nil, n.Query)
+		a.apply(n, "To", nil, n.To)
+		a.applyList(n, "Options")
+	case *sqlast.CopyOption:
+		a.apply(n, "Name", nil, n.Name)
+		if n.Value != nil {
+			a.apply(n, "Value", nil, n.Value)
+		}
 	case *sqlast.UpdateStmt:
+		if n.Hints != nil {
+			a.apply(n, "Hints", nil, n.Hints)
+		}
 		a.apply(n, "TableName", nil, n.TableName)
 		a.applyList(n, "Assignments")
 		a.apply(n, "Selection", nil, n.Selection)
+		a.applyList(n, "Returning")
 	case *sqlast.DeleteStmt:
+		if n.Hints != nil {
+			a.apply(n, "Hints", nil, n.Hints)
+		}
 		a.apply(n, "TableName", nil, n.TableName)
+		if n.Alias != nil {
+			a.apply(n, "Alias", nil, n.Alias)
+		}
+		a.applyList(n, "Using")
 		if n.Selection != nil {
 			a.apply(n, "Selection", nil, n.Selection)
 		}
+		a.applyList(n, "Returning")
 	case *sqlast.CreateViewStmt:
 		a.apply(n, "Name", nil, n.Name)
-		a.apply(n, "QueryStmt", nil, n.Query)
+		a.apply(n, "Query", nil, n.Query)
 	case *sqlast.CreateTableStmt:
 		a.apply(n, "Name", nil, n.Name)
+		if n.PartitionOf != nil {
+			a.apply(n, "PartitionOf", nil, n.PartitionOf)
+		}
 		a.applyList(n, "Elements")
+		if n.PartitionBound != nil {
+			a.apply(n, "PartitionBound", nil, n.PartitionBound)
+		}
+		a.applyList(n, "Options")
+	case *sqlast.WithOptions:
+		a.applyList(n, "Options")
+	case *sqlast.KeyValueOption:
+		a.apply(n, "Key", nil, n.Key)
+		if n.Value != nil {
+			a.apply(n, "Value", nil, n.Value)
+		}
+	case *sqlast.Tablespace:
+		a.apply(n, "Name", nil, n.Name)
+	case *sqlast.OnCommit:
+		// nothing to do
+	case *sqlast.PartitionBy:
+		a.applyList(n, "Keys")
+	case *sqlast.PartitionBoundIn:
+		a.applyList(n, "Values")
+	case *sqlast.PartitionBoundRange:
+		a.applyList(n, "From")
+		a.applyList(n, "To")
+	case *sqlast.PartitionBoundHash:
+		a.apply(n, "Modulus", nil, n.Modulus)
+		a.apply(n, "Remainder", nil, n.Remainder)
+	case *sqlast.PartitionBoundDefault:
+		// nothing to do
+	case *sqlast.MyEngine:
+		a.apply(n, "Name", nil, n.Name)
+	case *sqlast.MyCharset:
+		a.apply(n, "Name", nil, n.Name)
+	case *sqlast.MyCollate:
+		a.apply(n, "Name", nil, n.Name)
+	case *sqlast.MyAutoIncrement:
+		a.apply(n, "Value", nil, n.Value)
+	case *sqlast.MyTableComment:
+		a.apply(n, "Comment", nil, n.Comment)
+	case *sqlast.CHEngine:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Args")
+	case *sqlast.CHTableKey:
+		a.apply(n, "Expr", nil, n.Expr)
+	case *sqlast.CHSettings:
+		a.applyList(n, "Assignments")
 	case *sqlast.Assignment:
 		a.apply(n, "ID", nil, n.ID)
 		a.apply(n, "Value", nil, n.Value)
@@ -373,10 +672,29 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 	case *sqlast.ColumnDef:
 		a.apply(n, "Name", nil, n.Name)
 		a.apply(n, "DataType", nil, n.DataType)
+		if n.Charset != nil {
+			a.apply(n, "Charset", nil, n.Charset)
+		}
+		if n.Collation != nil {
+			a.apply(n, "Collation", nil, n.Collation)
+		}
+		if n.Default != nil {
+			a.apply(n, "Default", nil, n.Default)
+		}
+		a.applyList(n, "MyDataTypeDecoration")
+		a.applyList(n, "Constraints")
+	case *sqlast.PartitionColumn:
+		a.apply(n, "Name", nil, n.Name)
 		if n.Default != nil {
 			a.apply(n, "Default", nil, n.Default)
 		}
 		a.applyList(n, "Constraints")
+	case *sqlast.AutoIncrement:
+		// nothing to do
+	case *sqlast.MyOnUpdate:
+		a.apply(n, "Expr", nil, n.Expr)
+	case *sqlast.MyColumnComment:
+		a.apply(n, "Comment", nil, n.Comment)
 	case *sqlast.ColumnConstraint:
 		if n.Name != nil {
 			a.apply(n, "Name", nil, n.Name)
@@ -391,6 +709,10 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		a.applyList(n, "Columns")
 	case *sqlast.CheckColumnSpec:
 		a.apply(n, "Expr", nil, n.Expr)
+	case *sqlast.GeneratedColumnSpec:
+		a.apply(n, "Expr", nil, n.Expr)
+	case *sqlast.IdentityColumnSpec:
+		a.applyList(n, "Options")
 	case *sqlast.AlterTableStmt:
 		a.apply(n, "TableName", nil, n.TableName)
 		a.apply(n, "Action", nil, n.Action)
@@ -426,20 +748,256 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 			a.apply(n, "MethodName", nil, n.MethodName)
 		}
 		a.applyList(n, "ColumnNames")
+		if n.With != nil {
+			a.apply(n, "With", nil, n.With)
+		}
+		if n.Tablespace != nil {
+			a.apply(n, "Tablespace", nil, n.Tablespace)
+		}
 		if n.Selection != nil {
 			a.apply(n, "Selection", nil, n.Selection)
 		}
+	case *sqlast.CreateFunctionStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Args")
+		if n.Returns != nil {
+			a.apply(n, "Returns", nil, n.Returns)
+		}
+		a.applyList(n, "ReturnsTable")
+		if n.Language != nil {
+			a.apply(n, "Language", nil, n.Language)
+		}
+		a.applyList(n, "Attributes")
+		if n.Body != nil {
+			a.apply(n, "Body", nil, n.Body)
+		}
+	case *sqlast.FunctionArg:
+		if n.Name != nil {
+			a.apply(n, "Name", nil, n.Name)
+		}
+		a.apply(n, "Type", nil, n.Type)
+		if n.Default != nil {
+			a.apply(n, "Default", nil, n.Default)
+		}
+	case *sqlast.FunctionAttribute:
+		if n.Value != nil {
+			a.apply(n, "Value", nil, n.Value)
+		}
+	case *sqlast.CreateSequenceStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Options")
+	case *sqlast.AlterSequenceStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Options")
+	case *sqlast.AlterIndexStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Action", nil, n.Action)
+	case *sqlast.AlterViewStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Action", nil, n.Action)
+	case *sqlast.AlterSchemaStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Action", nil, n.Action)
+	case *sqlast.RenameToAction:
+		a.apply(n, "NewName", nil, n.NewName)
+	case *sqlast.SetTablespaceAction:
+		a.apply(n, "Tablespace", nil, n.Tablespace)
+	case *sqlast.OwnerToAction:
+		a.apply(n, "Owner", nil, n.Owner)
+	case *sqlast.SetSchemaAction:
+		a.apply(n, "Schema", nil, n.Schema)
+	case *sqlast.SequenceDataType:
+		a.apply(n, "DataType", nil, n.DataType)
+	case *sqlast.SequenceIncrement:
+		a.apply(n, "Value", nil, n.Value)
+	case *sqlast.SequenceStart:
+		a.apply(n, "Value", nil, n.Value)
+	case *sqlast.SequenceCache:
+		a.apply(n, "Value", nil, n.Value)
+	case *sqlast.SequenceMinValue:
+		if n.Value != nil {
+			a.apply(n, "Value", nil, n.Value)
+		}
+	case *sqlast.SequenceMaxValue:
+		if n.Value != nil {
+			a.apply(n, "Value", nil, n.Value)
+		}
+	case *sqlast.SequenceRestart:
+		if n.Value != nil {
+			a.apply(n, "Value", nil, n.Value)
+		}
+	case *sqlast.SequenceOwnedBy:
+		if n.Column != nil {
+			a.apply(n, "Column", nil, n.Column)
+		}
+	case *sqlast.SequenceCycle:
+		// nothing to do
+	case *sqlast.CreateSchemaStmt:
+		if n.Name != nil {
+			a.apply(n, "Name", nil, n.Name)
+		}
+		if n.Authorization != nil {
+			a.apply(n, "Authorization", nil, n.Authorization)
+		}
+	case *sqlast.CreateDatabaseStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Options")
+	case *sqlast.DatabaseOption:
+		a.apply(n, "Value", nil, n.Value)
+	case *sqlast.CreateExtensionStmt:
+		a.apply(n, "Name", nil, n.Name)
+		if n.Schema != nil {
+			a.apply(n, "Schema", nil, n.Schema)
+		}
+		if n.Version != nil {
+			a.apply(n, "Version", nil, n.Version)
+		}
+	case *sqlast.CreateTypeStmt:
+		a.apply(n, "Name", nil, n.Name)
+		if n.Definition != nil {
+			a.apply(n, "Definition", nil, n.Definition)
+		}
+	case *sqlast.EnumTypeDefinition:
+		a.applyList(n, "Labels")
+	case *sqlast.CompositeTypeDefinition:
+		a.applyList(n, "Attributes")
+	case *sqlast.RangeTypeDefinition:
+		a.applyList(n, "Options")
+	case *sqlast.TypeOption:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Value", nil, n.Value)
+	case *sqlast.TruncateStmt:
+		a.applyList(n, "TableNames")
+	case *sqlast.VacuumStmt:
+		a.applyList(n, "Options")
+		a.applyList(n, "Tables")
+	case *sqlast.AnalyzeStmt:
+		a.applyList(n, "Options")
+		a.applyList(n, "Tables")
+	case *sqlast.ReindexStmt:
+		a.applyList(n, "Options")
+		if n.Name != nil {
+			a.apply(n, "Name", nil, n.Name)
+		}
+	case *sqlast.MaintenanceOption:
+		a.apply(n, "Name", nil, n.Name)
+		if n.Value != nil {
+			a.apply(n, "Value", nil, n.Value)
+		}
+	case *sqlast.MaintenanceTable:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Columns")
+	case *sqlast.PrepareStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "DataTypes")
+		a.apply(n, "Stmt", nil, n.Stmt)
+	case *sqlast.ExecuteStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Args")
+	case *sqlast.ShowTablesStmt:
+		if n.Database != nil {
+			a.apply(n, "Database", nil, n.Database)
+		}
+		if n.Like != nil {
+			a.apply(n, "Like", nil, n.Like)
+		}
+		if n.Where != nil {
+			a.apply(n, "Where", nil, n.Where)
+		}
+	case *sqlast.ShowColumnsStmt:
+		a.apply(n, "Table", nil, n.Table)
+		if n.Database != nil {
+			a.apply(n, "Database", nil, n.Database)
+		}
+		if n.Like != nil {
+			a.apply(n, "Like", nil, n.Like)
+		}
+		if n.Where != nil {
+			a.apply(n, "Where", nil, n.Where)
+		}
+	case *sqlast.ShowCreateTableStmt:
+		a.apply(n, "Table", nil, n.Table)
+	case *sqlast.DescribeStmt:
+		a.apply(n, "Table", nil, n.Table)
+		if n.Column != nil {
+			a.apply(n, "Column", nil, n.Column)
+		}
+	case *sqlast.UseStmt:
+		a.apply(n, "Database", nil, n.Database)
+	case *sqlast.CallStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Args")
+	case *sqlast.DeallocateStmt:
+		if n.Name != nil {
+			a.apply(n, "Name", nil, n.Name)
+		}
+	case *sqlast.DeclareCursorStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Query", nil, n.Query)
+	case *sqlast.FetchStmt:
+		if n.Count != nil {
+			a.apply(n, "Count", nil, n.Count)
+		}
+		a.apply(n, "Name", nil, n.Name)
+	case *sqlast.CloseStmt:
+		if n.Name != nil {
+			a.apply(n, "Name", nil, n.Name)
+		}
+	case *sqlast.MergeStmt:
+		a.apply(n, "Target", nil, n.Target)
+		if n.Alias != nil {
+			a.apply(n, "Alias", nil, n.Alias)
+		}
+		a.apply(n, "Source", nil, n.Source)
+		a.apply(n, "On", nil, n.On)
+		a.applyList(n, "Clauses")
+	case *sqlast.MergeWhenClause:
+		if n.Condition != nil {
+			a.apply(n, "Condition", nil, n.Condition)
+		}
+		a.apply(n, "Action", nil, n.Action)
+	case *sqlast.MergeUpdate:
+		a.applyList(n, "Assignments")
+	case *sqlast.MergeDelete, *sqlast.MergeDoNothing:
+		// nothing to do
+	case *sqlast.MergeInsert:
+		a.applyList(n, "Columns")
+		if n.Values != nil {
+			a.apply(n, "Values", nil, n.Values)
+		}
+	case *sqlast.SetStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Values")
+	case *sqlast.SetTimeZoneStmt:
+		a.apply(n, "Value", nil, n.Value)
+	case *sqlast.ShowStmt:
+		if n.Name != nil {
+			a.apply(n, "Name", nil, n.Name)
+		}
+	case *sqlast.ResetStmt:
+		if n.Name != nil {
+			a.apply(n, "Name", nil, n.Name)
+		}
 	case *sqlast.DropIndexStmt:
 		a.applyList(n, "IndexNames")
 	case *sqlast.ExplainStmt:
 		a.apply(n, "Stmt", nil, n.Stmt)
 	case *sqlast.Operator:
 		// nothing to do
+	case *sqlast.IntervalValue:
+		a.apply(n, "Literal", nil, n.Literal)
 	case *sqlast.NullValue,
 		*sqlast.LongValue,
+		*sqlast.UnsignedLongValue,
 		*sqlast.DoubleValue,
+		*sqlast.NumericValue,
 		*sqlast.SingleQuotedString,
 		*sqlast.NationalStringLiteral,
+		*sqlast.DollarQuotedString,
+		*sqlast.EscapedStringLiteral,
+		*sqlast.UnicodeStringLiteral,
+		*sqlast.HexValue,
+		*sqlast.BitStringLiteral,
 		*sqlast.BooleanValue,
 		*sqlast.DateValue,
 		*sqlast.TimeValue,
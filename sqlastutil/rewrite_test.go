@@ -76,6 +76,58 @@ func TestApply(t *testing.T) {
 				return true
 			},
 		},
+		{
+			name:   "inject tenant filter",
+			src:    "SELECT * FROM orders WHERE amount > 10",
+			expect: "SELECT * FROM orders WHERE amount > 10 AND tenant_id = 1",
+			postFunc: func(cursor *Cursor) bool {
+				switch n := cursor.node.(type) {
+				case *sqlast.SQLSelect:
+					n.WhereClause = &sqlast.BinaryExpr{
+						Left: n.WhereClause,
+						Op:   &sqlast.Operator{Type: sqlast.And},
+						Right: &sqlast.BinaryExpr{
+							Left:  sqlast.NewIdent("tenant_id"),
+							Op:    &sqlast.Operator{Type: sqlast.Eq},
+							Right: sqlast.NewLongValue(1),
+						},
+					}
+				}
+				return true
+			},
+		},
+		{
+			name:   "replace exists subquery",
+			src:    "SELECT * FROM a WHERE EXISTS (SELECT * FROM b)",
+			expect: "SELECT * FROM a WHERE EXISTS (SELECT * FROM c)",
+			preFunc: func(cursor *Cursor) bool {
+				switch n := cursor.node.(type) {
+				case *sqlast.Ident:
+					if n.Value == "b" {
+						cursor.Replace(sqlast.NewIdent("c"))
+					}
+				}
+				return true
+			},
+		},
+		{
+			name:   "stop traversal",
+			src:    "SELECT a FROM table_a WHERE id = 1",
+			expect: "SELECT b FROM table_a WHERE id = 1",
+			postFunc: func(cursor *Cursor) bool {
+				switch n := cursor.node.(type) {
+				case *sqlast.Ident:
+					if n.Value == "a" {
+						cursor.Replace(sqlast.NewIdent("b"))
+						return false
+					}
+					if n.Value == "id" {
+						cursor.Replace(sqlast.NewIdent("x"))
+					}
+				}
+				return true
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -0,0 +1,191 @@
+package sqlastutil
+
+import (
+	"math"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// Simplify folds constant expressions under root and simplifies the
+// expressions which are known to be always the same, e.g. for comparing or
+// caching canonicalized queries. root is modified in place, and the result
+// is returned since root itself may be replaced.
+//
+// It folds the arithmetic and comparisons of integer literals, signs of
+// numeric literals and NOT of booleans, removes double negations and
+// redundant parentheses, drops TRUE and FALSE operands of AND and OR, and
+// removes the branches of searched CASE whose conditions are literal
+// booleans. Decimal arithmetic and comparisons
+// of strings aren't folded since their results depend on the database, e.g.
+// the precision and the collation.
+func Simplify(root sqlast.Node) sqlast.Node {
+	return Apply(root, nil, func(c *Cursor) bool {
+		if n := simplify(c.Node()); n != c.Node() && c.CanReplace(n) {
+			c.Replace(n)
+		}
+		return true
+	})
+}
+
+func simplify(n sqlast.Node) sqlast.Node {
+	switch e := n.(type) {
+	case *sqlast.Nested:
+		if operand(e.AST) == e.AST {
+			return e.AST
+		}
+	case *sqlast.UnaryExpr:
+		return simplifyUnary(e)
+	case *sqlast.BinaryExpr:
+		return simplifyBinary(e)
+	case *sqlast.CaseExpr:
+		return simplifyCase(e)
+	}
+	return n
+}
+
+func simplifyUnary(e *sqlast.UnaryExpr) sqlast.Node {
+	switch e.Op.Type {
+	case sqlast.Not:
+		switch x := e.Expr.(type) {
+		case *sqlast.BooleanValue:
+			return &sqlast.BooleanValue{Boolean: !x.Boolean}
+		case *sqlast.UnaryExpr:
+			if x.Op.Type == sqlast.Not {
+				return x.Expr
+			}
+		}
+	case sqlast.Minus:
+		switch x := e.Expr.(type) {
+		case *sqlast.LongValue:
+			if x.Long != math.MinInt64 {
+				return &sqlast.LongValue{Long: -x.Long}
+			}
+		case *sqlast.DoubleValue:
+			return &sqlast.DoubleValue{Double: -x.Double}
+		case *sqlast.UnaryExpr:
+			if x.Op.Type == sqlast.Minus {
+				return x.Expr
+			}
+		}
+	case sqlast.Plus:
+		switch e.Expr.(type) {
+		case *sqlast.LongValue, *sqlast.DoubleValue, *sqlast.NumericValue:
+			return e.Expr
+		}
+	}
+	return e
+}
+
+func simplifyBinary(e *sqlast.BinaryExpr) sqlast.Node {
+	switch e.Op.Type {
+	case sqlast.And, sqlast.Or:
+		// TRUE is the identity of AND and FALSE is the one of OR, and the
+		// other absorbs any operand including NULL
+		identity := e.Op.Type == sqlast.And
+		for _, pair := range [][2]sqlast.Node{{e.Left, e.Right}, {e.Right, e.Left}} {
+			if b, ok := pair[0].(*sqlast.BooleanValue); ok {
+				if b.Boolean == identity {
+					return pair[1]
+				}
+				return &sqlast.BooleanValue{Boolean: b.Boolean}
+			}
+		}
+		return e
+	}
+
+	left, ok := e.Left.(*sqlast.LongValue)
+	if !ok {
+		return e
+	}
+	right, ok := e.Right.(*sqlast.LongValue)
+	if !ok {
+		return e
+	}
+	a, b := left.Long, right.Long
+	switch e.Op.Type {
+	case sqlast.Plus:
+		if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+			return e
+		}
+		return &sqlast.LongValue{Long: a + b}
+	case sqlast.Minus:
+		if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+			return e
+		}
+		return &sqlast.LongValue{Long: a - b}
+	case sqlast.Multiply:
+		r := a * b
+		if a != 0 && (r/a != b || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64)) {
+			return e
+		}
+		return &sqlast.LongValue{Long: r}
+	case sqlast.Divide:
+		// only exact division, since some databases (e.g. MySQL) return decimals
+		if b == 0 || a%b != 0 || (a == math.MinInt64 && b == -1) {
+			return e
+		}
+		return &sqlast.LongValue{Long: a / b}
+	case sqlast.Modulus:
+		if b == 0 || b == -1 {
+			return e
+		}
+		return &sqlast.LongValue{Long: a % b}
+	case sqlast.Eq:
+		return &sqlast.BooleanValue{Boolean: a == b}
+	case sqlast.NotEq:
+		return &sqlast.BooleanValue{Boolean: a != b}
+	case sqlast.Lt:
+		return &sqlast.BooleanValue{Boolean: a < b}
+	case sqlast.LtEq:
+		return &sqlast.BooleanValue{Boolean: a <= b}
+	case sqlast.Gt:
+		return &sqlast.BooleanValue{Boolean: a > b}
+	case sqlast.GtEq:
+		return &sqlast.BooleanValue{Boolean: a >= b}
+	}
+	return e
+}
+
+// simplifyCase removes the branches of searched CASE whose conditions are
+// FALSE, and the ones following TRUE whose result becomes ELSE.
+func simplifyCase(e *sqlast.CaseExpr) sqlast.Node {
+	if e.Operand != nil {
+		return e
+	}
+	var conditions, results []sqlast.Node
+	elseResult := e.ElseResult
+	changed := false
+	for i, cond := range e.Conditions {
+		b, ok := cond.(*sqlast.BooleanValue)
+		if !ok {
+			conditions = append(conditions, cond)
+			results = append(results, e.Results[i])
+			continue
+		}
+		changed = true
+		if b.Boolean {
+			elseResult = e.Results[i]
+			break
+		}
+	}
+	if !changed {
+		return e
+	}
+	if len(conditions) == 0 {
+		if elseResult == nil {
+			return &sqlast.NullValue{}
+		}
+		return operand(elseResult)
+	}
+	return &sqlast.CaseExpr{Conditions: conditions, Results: results, ElseResult: elseResult}
+}
+
+// operand returns n parenthesized unless it can be an operand as is.
+func operand(n sqlast.Node) sqlast.Node {
+	switch n.(type) {
+	case sqlast.Value, *sqlast.Placeholder, *sqlast.Ident, *sqlast.CompoundIdent, *sqlast.Function,
+		*sqlast.Nested, *sqlast.Cast, *sqlast.CaseExpr, *sqlast.SubQuery:
+		return n
+	}
+	return &sqlast.Nested{AST: n}
+}
@@ -0,0 +1,96 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestSimplify(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		expr   bool
+		expect string
+	}{
+		{
+			name:   "arithmetic",
+			src:    "1 + 2 * 3 - (4 - 6) + a * (10 / 5) + 7 / 2 + 7 % 3",
+			expr:   true,
+			expect: "9 + a * 2 + 7 / 2 + 1",
+		},
+		{
+			name:   "overflow",
+			src:    "9223372036854775807 + 1 + a",
+			expr:   true,
+			expect: "9223372036854775807 + 1 + a",
+		},
+		{
+			name:   "decimal",
+			src:    "0.1 + 0.2 = -(-0.3)",
+			expr:   true,
+			expect: "0.1 + 0.2 = 0.3",
+		},
+		{
+			name:   "negation",
+			src:    "NOT NOT a AND - - b > - 1 AND NOT (1 > 2)",
+			expr:   true,
+			expect: "a AND b > -1",
+		},
+		{
+			name:   "and or",
+			src:    "(a OR 1 = 1) AND (b AND 2 < 1 OR c) AND (1 = 1 AND d)",
+			expr:   true,
+			expect: "c AND d",
+		},
+		{
+			name:   "case",
+			src:    "CASE WHEN 1 = 2 THEN a WHEN b THEN c WHEN 1 = 1 THEN d WHEN e THEN f END",
+			expr:   true,
+			expect: "CASE WHEN b THEN c ELSE d END",
+		},
+		{
+			name:   "case result",
+			src:    "CASE WHEN false THEN a WHEN true THEN b OR c END = d",
+			expr:   true,
+			expect: "(b OR c) = d",
+		},
+		{
+			name:   "case null",
+			src:    "CASE WHEN 1 > 2 THEN a END",
+			expr:   true,
+			expect: "NULL",
+		},
+		{
+			name:   "statement",
+			src:    "SELECT a + 1 * 2 FROM t WHERE true AND b = 60 * 60 ORDER BY 1",
+			expect: "SELECT a + 2 FROM t WHERE b = 3600 ORDER BY 1",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var node sqlast.Node
+			var err error
+			if c.expr {
+				node, err = xsqlparser.ParseExpr(c.src, &dialect.GenericSQLDialect{})
+			} else {
+				var parser *xsqlparser.Parser
+				parser, err = xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				node, err = parser.ParseStatement()
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := Simplify(node).ToSQLString(); act != c.expect {
+				t.Errorf("should be \n %s but \n %s", c.expect, act)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package sqlastutil
+
+import (
+	"regexp"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// Substitute replaces the named placeholders `:name` under root with the AST
+// fragments bound to the names in bindings, which are keyed without colons,
+// so that queries can be composed from trusted templates without building
+// SQL from strings, e.g:
+//
+//	SELECT * FROM :table WHERE :column = :value
+//
+// A placeholder in place of an expression is replaced with an expression,
+// which is parenthesized if needed, or with a subquery if a *sqlast.QueryStmt
+// is bound. A placeholder in place of an identifier, such as a part of a table
+// name or a column of INSERT, is replaced with a *sqlast.Ident, and a part of
+// a name is also replaced with the parts of a *sqlast.ObjectName or a
+// *sqlast.CompoundIdent. Since the fragments are nodes, values bound to
+// placeholders are written as the nodes they are, e.g. a string is quoted.
+// A bound identifier, or a part of a bound name, which is a reserved keyword
+// or not a plain identifier is quoted as well, so that it can't be written
+// as SQL, e.g. `users; DROP TABLE users`.
+//
+// root is modified in place, and the fragments are copied for each
+// placeholder. Clone the template before substituting it to use it again.
+// Substitute returns an error if a placeholder has no binding or is bound to
+// a fragment which can't be placed there. Bindings which are not used are
+// ignored.
+func Substitute(root sqlast.Node, bindings map[string]sqlast.Node) error {
+	var err error
+	Apply(root, func(c *Cursor) bool {
+		if err != nil {
+			return false
+		}
+		switch n := c.Node().(type) {
+		case *sqlast.Placeholder:
+			if !strings.HasPrefix(n.Value, ":") {
+				return false
+			}
+			var e sqlast.Node
+			if e, err = bindExpr(n.Value, bindings); err != nil {
+				return false
+			}
+			if !c.CanReplace(e) {
+				err = errors.Errorf("can't substitute %s with %T", n.Value, e)
+				return false
+			}
+			c.Replace(e)
+			return false
+		case *sqlast.ObjectName:
+			err = substituteName(n, bindings)
+			return false
+		case *sqlast.Ident:
+			if !isNamedPlaceholder(n) {
+				return false
+			}
+			var ident *sqlast.Ident
+			if ident, err = bindIdent(n.Value, bindings); err != nil {
+				return false
+			}
+			if !c.CanReplace(ident) {
+				err = errors.Errorf("can't substitute %s with %T", n.Value, ident)
+				return false
+			}
+			c.Replace(ident)
+			return false
+		}
+		return true
+	}, nil)
+	return err
+}
+
+func isNamedPlaceholder(ident *sqlast.Ident) bool {
+	return !ident.Quoted && strings.HasPrefix(ident.Value, ":")
+}
+
+func binding(placeholder string, bindings map[string]sqlast.Node) (sqlast.Node, error) {
+	n, ok := bindings[strings.TrimPrefix(placeholder, ":")]
+	if !ok || n == nil {
+		return nil, errors.Errorf("no binding for %s", placeholder)
+	}
+	n = sqlast.Clone(n)
+	switch n := n.(type) {
+	case *sqlast.Ident:
+		quoteIdent(n)
+	case *sqlast.ObjectName:
+		for _, i := range n.Idents {
+			quoteIdent(i)
+		}
+	case *sqlast.CompoundIdent:
+		for _, i := range n.Idents {
+			quoteIdent(i)
+		}
+	}
+	return n, nil
+}
+
+var plainIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// quoteIdent quotes ident if it is a reserved keyword or not a plain
+// identifier, as sqlbuilder does.
+func quoteIdent(ident *sqlast.Ident) {
+	if ident.Quoted {
+		return
+	}
+	_, reserved := dialect.ReservedKeywords[strings.ToUpper(ident.Value)]
+	ident.Quoted = reserved || !plainIdent.MatchString(ident.Value)
+}
+
+// bindExpr returns the expression bound to placeholder.
+func bindExpr(placeholder string, bindings map[string]sqlast.Node) (sqlast.Node, error) {
+	n, err := binding(placeholder, bindings)
+	if err != nil {
+		return nil, err
+	}
+	switch n := n.(type) {
+	case *sqlast.QueryStmt:
+		return &sqlast.SubQuery{Query: n}, nil
+	case *sqlast.ObjectName:
+		if len(n.Idents) == 1 {
+			return n.Idents[0], nil
+		}
+		return &sqlast.CompoundIdent{Idents: n.Idents}, nil
+	case sqlast.Stmt, sqlast.TableReference, sqlast.SQLSelectItem, *sqlast.OrderByExpr:
+		return nil, errors.Errorf("%s is bound to %T, which is not an expression", placeholder, n)
+	}
+	return operand(n), nil
+}
+
+// bindIdent returns the identifier bound to placeholder.
+func bindIdent(placeholder string, bindings map[string]sqlast.Node) (*sqlast.Ident, error) {
+	n, err := binding(placeholder, bindings)
+	if err != nil {
+		return nil, err
+	}
+	ident, ok := n.(*sqlast.Ident)
+	if !ok {
+		return nil, errors.Errorf("%s is bound to %T, which is not an identifier", placeholder, n)
+	}
+	return ident, nil
+}
+
+// substituteName replaces the placeholders in the parts of name with the
+// parts bound to them.
+func substituteName(name *sqlast.ObjectName, bindings map[string]sqlast.Node) error {
+	idents := make([]*sqlast.Ident, 0, len(name.Idents))
+	for _, i := range name.Idents {
+		if !isNamedPlaceholder(i) {
+			idents = append(idents, i)
+			continue
+		}
+		n, err := binding(i.Value, bindings)
+		if err != nil {
+			return err
+		}
+		switch n := n.(type) {
+		case *sqlast.Ident:
+			idents = append(idents, n)
+		case *sqlast.ObjectName:
+			idents = append(idents, n.Idents...)
+		case *sqlast.CompoundIdent:
+			idents = append(idents, n.Idents...)
+		default:
+			return errors.Errorf("%s is bound to %T, which is not a name", i.Value, n)
+		}
+	}
+	name.Idents = idents
+	return nil
+}
@@ -0,0 +1,133 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestSubstitute(t *testing.T) {
+	parse := func(t *testing.T, src string) sqlast.Stmt {
+		t.Helper()
+		parser, err := xsqlparser.NewParser(bytes.NewBufferString(src), &dialect.PostgresqlDialect{})
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		stmt, err := parser.ParseStatement()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		return stmt
+	}
+	ident := func(v string) *sqlast.Ident { return sqlast.NewIdent(v) }
+
+	cases := []struct {
+		name     string
+		src      string
+		bindings func(t *testing.T) map[string]sqlast.Node
+		expect   string
+		err      bool
+	}{
+		{
+			name: "identifiers and expressions",
+			src:  "SELECT :column FROM :schema.:table WHERE :column = :value AND owner_id = :owner",
+			bindings: func(t *testing.T) map[string]sqlast.Node {
+				return map[string]sqlast.Node{
+					"column": ident("name"),
+					"schema": ident("public"),
+					"table":  &sqlast.Ident{Value: "user table", Quoted: true, QuoteStyle: '"'},
+					"value":  sqlast.NewSingleQuotedString("x' OR 1 = 1 --"),
+					"owner":  parse(t, "SELECT id FROM admins LIMIT 1"),
+				}
+			},
+			expect: `SELECT name FROM public."user table" WHERE name = 'x'' OR 1 = 1 --' AND owner_id = (SELECT id FROM admins LIMIT 1)`,
+		},
+		{
+			name: "parenthesized expression",
+			src:  "SELECT a * :x FROM t",
+			bindings: func(t *testing.T) map[string]sqlast.Node {
+				return map[string]sqlast.Node{
+					"x": &sqlast.BinaryExpr{Left: ident("b"), Op: &sqlast.Operator{Type: sqlast.Plus}, Right: sqlast.NewLongValue(1)},
+				}
+			},
+			expect: "SELECT a * (b + 1) FROM t",
+		},
+		{
+			name: "qualified names",
+			src:  "INSERT INTO :table (:column) SELECT :source FROM s",
+			bindings: func(t *testing.T) map[string]sqlast.Node {
+				return map[string]sqlast.Node{
+					"table":  &sqlast.ObjectName{Idents: []*sqlast.Ident{ident("app"), ident("users")}},
+					"column": ident("name"),
+					"source": &sqlast.ObjectName{Idents: []*sqlast.Ident{ident("s"), ident("name")}},
+				}
+			},
+			expect: "INSERT INTO app.users (name) SELECT s.name FROM s",
+		},
+		{
+			name: "identifiers written as SQL are quoted",
+			src:  "SELECT * FROM :table WHERE :column = 1",
+			bindings: func(t *testing.T) map[string]sqlast.Node {
+				return map[string]sqlast.Node{
+					"table":  ident("users; DROP TABLE users; --"),
+					"column": &sqlast.CompoundIdent{Idents: []*sqlast.Ident{ident("u"), ident("select")}},
+				}
+			},
+			expect: `SELECT * FROM "users; DROP TABLE users; --" WHERE u."select" = 1`,
+		},
+		{
+			name: "positional placeholders are kept",
+			src:  "SELECT * FROM t WHERE a = $1 AND b = ?",
+			bindings: func(t *testing.T) map[string]sqlast.Node {
+				return nil
+			},
+			expect: "SELECT * FROM t WHERE a = $1 AND b = ?",
+		},
+		{
+			name: "missing binding",
+			src:  "SELECT * FROM t WHERE a = :a",
+			bindings: func(t *testing.T) map[string]sqlast.Node {
+				return map[string]sqlast.Node{"b": sqlast.NewLongValue(1)}
+			},
+			err: true,
+		},
+		{
+			name: "expression in place of identifier",
+			src:  "SELECT * FROM :table",
+			bindings: func(t *testing.T) map[string]sqlast.Node {
+				return map[string]sqlast.Node{"table": sqlast.NewSingleQuotedString("users; DROP TABLE users")}
+			},
+			err: true,
+		},
+		{
+			name: "statement in place of expression",
+			src:  "SELECT :x",
+			bindings: func(t *testing.T) map[string]sqlast.Node {
+				return map[string]sqlast.Node{"x": parse(t, "DROP TABLE users")}
+			},
+			err: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt := parse(t, c.src)
+			err := Substitute(stmt, c.bindings(t))
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but %s", stmt.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := stmt.ToSQLString(); act != c.expect {
+				t.Errorf("should be \n %s but \n %s", c.expect, act)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package sqlastutil
+
+import (
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// ExpandViews replaces the references to views under root with the queries
+// defining them, e.g. `FROM v` with `FROM (SELECT ...) AS v`, so that the
+// tables a query actually reads are visible for lineage and optimization
+// analysis. Views referenced in the definitions of views are expanded as
+// well. root is modified in place, and the queries of views are copied.
+//
+// Views are matched case-insensitively by their names, and an unqualified
+// name also matches a schema-qualified view if it is unique. CTEs of the same
+// name shadow the views. A reference keeps its alias, or is aliased by the
+// name of the view, so that columns qualified by the name still resolve.
+// References to views with TABLESAMPLE or table hints, and views defined
+// recursively, are reported as errors.
+func ExpandViews(root sqlast.Node, views []*sqlast.CreateViewStmt) error {
+	e := &viewExpander{views: views}
+	e.expand(root, nil)
+	return e.err
+}
+
+type viewExpander struct {
+	views     []*sqlast.CreateViewStmt
+	expanding []*sqlast.CreateViewStmt // the views whose definitions are being expanded
+	err       error
+}
+
+func (e *viewExpander) expand(root sqlast.Node, sc *scope) {
+	Apply(root, func(c *Cursor) bool {
+		if e.err != nil {
+			return false
+		}
+		switch n := c.Node().(type) {
+		case *sqlast.QueryStmt:
+			e.query(n, sc)
+			return false
+		case *sqlast.Table:
+			if d := e.table(n, sc); d != nil && c.CanReplace(d) {
+				c.Replace(d)
+			}
+			return false
+		}
+		return true
+	}, nil)
+}
+
+func (e *viewExpander) query(q *sqlast.QueryStmt, parent *scope) {
+	sc := &scope{parent: parent, ctes: make(map[string]struct{})}
+	for _, cte := range q.CTEs {
+		e.query(cte.Query, sc)
+		sc.ctes[strings.ToLower(cte.Alias.Value)] = struct{}{}
+	}
+	e.expand(q.Body, sc)
+	for _, o := range q.OrderBy {
+		e.expand(o, sc)
+	}
+	if q.Limit != nil {
+		e.expand(q.Limit, sc)
+	}
+}
+
+// table returns the derived table replacing t, or nil if t doesn't refer to
+// a view.
+func (e *viewExpander) table(t *sqlast.Table, sc *scope) *sqlast.Derived {
+	if len(t.Name.Idents) == 1 && sc.isCTE(t.Name.Idents[0].Value) {
+		return nil
+	}
+	view := e.view(t.Name)
+	if view == nil {
+		return nil
+	}
+	if t.Sample != nil || t.CHSample != nil || len(t.WithHints) != 0 || t.Final {
+		e.err = errors.Errorf("can't expand view %s with TABLESAMPLE or table hints", t.Name.ToSQLString())
+		return nil
+	}
+	for i, v := range e.expanding {
+		if v == view {
+			var names []string
+			for _, v := range append(e.expanding[i:], view) {
+				names = append(names, v.Name.ToSQLString())
+			}
+			e.err = errors.Errorf("view %s is defined recursively: %s", view.Name.ToSQLString(), strings.Join(names, " -> "))
+			return nil
+		}
+	}
+
+	q := sqlast.Clone(view.Query).(*sqlast.QueryStmt)
+	e.expanding = append(e.expanding, view)
+	// the definition doesn't see the CTEs of the query referencing the view
+	e.query(q, nil)
+	e.expanding = e.expanding[:len(e.expanding)-1]
+
+	alias := t.Alias
+	if alias == nil {
+		alias = sqlast.Clone(t.Name.Idents[len(t.Name.Idents)-1]).(*sqlast.Ident)
+	}
+	return &sqlast.Derived{SubQuery: q, Alias: alias, Columns: t.Columns}
+}
+
+// view returns the view named name, or nil if there is no such view or
+// the name is ambiguous.
+func (e *viewExpander) view(name *sqlast.ObjectName) *sqlast.CreateViewStmt {
+	key := viewKey(name)
+	for _, v := range e.views {
+		if viewKey(v.Name) == key {
+			return v
+		}
+	}
+
+	last := strings.ToLower(name.Idents[len(name.Idents)-1].Value)
+	var found *sqlast.CreateViewStmt
+	for _, v := range e.views {
+		if len(v.Name.Idents) != 1 && len(name.Idents) != 1 {
+			continue
+		}
+		if strings.ToLower(v.Name.Idents[len(v.Name.Idents)-1].Value) != last {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = v
+	}
+	return found
+}
+
+func viewKey(name *sqlast.ObjectName) string {
+	parts := make([]string, 0, len(name.Idents))
+	for _, i := range name.Idents {
+		parts = append(parts, strings.ToLower(i.Value))
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,98 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+func TestExpandViews(t *testing.T) {
+	parser, err := xsqlparser.NewParser(bytes.NewBufferString(`
+CREATE VIEW active_users AS SELECT id, name FROM users WHERE deleted_at IS NULL;
+CREATE VIEW public.big_orders AS SELECT o.* FROM orders AS o JOIN active_users AS u ON o.user_id = u.id WHERE total > 100;
+CREATE VIEW a AS SELECT * FROM b;
+CREATE VIEW b AS SELECT * FROM a;
+`), &dialect.PostgresqlDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var views []*sqlast.CreateViewStmt
+	for _, s := range stmts {
+		views = append(views, s.(*sqlast.CreateViewStmt))
+	}
+
+	cases := []struct {
+		name   string
+		src    string
+		expect string
+		err    bool
+	}{
+		{
+			name:   "view",
+			src:    "SELECT active_users.name FROM active_users ORDER BY 1",
+			expect: "SELECT active_users.name FROM (SELECT id, name FROM users WHERE deleted_at IS NULL) AS active_users ORDER BY 1",
+		},
+		{
+			name:   "nested views",
+			src:    "SELECT b.id FROM public.big_orders AS b JOIN users ON b.user_id = users.id",
+			expect: "SELECT b.id FROM (SELECT o.* FROM orders AS o JOIN (SELECT id, name FROM users WHERE deleted_at IS NULL) AS u ON o.user_id = u.id WHERE total > 100) AS b JOIN users ON b.user_id = users.id",
+		},
+		{
+			name:   "cte",
+			src:    "WITH active_users AS (SELECT * FROM active_users WHERE id > 1) SELECT * FROM active_users",
+			expect: "WITH active_users AS (SELECT * FROM (SELECT id, name FROM users WHERE deleted_at IS NULL) AS active_users WHERE id > 1) SELECT * FROM active_users",
+		},
+		{
+			name:   "subquery",
+			src:    "DELETE FROM t WHERE user_id IN (SELECT id FROM Active_Users)",
+			expect: "DELETE FROM t WHERE user_id IN (SELECT id FROM (SELECT id, name FROM users WHERE deleted_at IS NULL) AS Active_Users)",
+		},
+		{
+			name: "recursive",
+			src:  "SELECT * FROM a",
+			err:  true,
+		},
+		{
+			name: "tablesample",
+			src:  "SELECT * FROM active_users TABLESAMPLE BERNOULLI(10)",
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			err = ExpandViews(stmt, views)
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but %s", stmt.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := stmt.ToSQLString(); act != c.expect {
+				t.Errorf("should be \n %s but \n %s", c.expect, act)
+			}
+		})
+	}
+
+	if act := views[0].ToSQLString(); act != "CREATE VIEW active_users AS SELECT id, name FROM users WHERE deleted_at IS NULL" {
+		t.Errorf("views must not be modified but %s", act)
+	}
+}
@@ -0,0 +1,270 @@
+package sqlastutil
+
+import (
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// AddWhere ANDs pred into the condition filtering every reference to table
+// under root, including those in subqueries, joins and CTEs, e.g. to restrict
+// queries to the rows of a tenant. root is modified in place.
+//
+// table is matched case-insensitively, and an unqualified name also matches
+// schema-qualified references. CTEs of the same name shadow the table.
+// Columns of pred qualified by table are requalified by the alias of each
+// reference, and unqualified columns are qualified if the table is joined
+// with others.
+//
+// The predicate is added to WHERE of the SELECT, UPDATE or DELETE, except for
+// a table on the nullable side of an outer join, where it is added to ON so
+// that the join stays outer. The target of MERGE is filtered in ON, and its
+// source is replaced by a subquery filtering the table. A table in a FULL
+// join, on the nullable side of an outer join without ON, with column
+// aliases in FROM, or of a statement which can't be filtered, e.g. COPY and
+// TRUNCATE, is reported as an error.
+func AddWhere(root sqlast.Node, table string, pred sqlast.Node) error {
+	a := &whereAdder{table: strings.Split(table, "."), pred: pred}
+	a.walk(root, nil)
+	if a.err != nil {
+		return a.err
+	}
+	// the predicates are added after the walk so that subqueries in them
+	// aren't filtered again
+	for _, f := range a.pending {
+		f()
+	}
+	return nil
+}
+
+type whereAdder struct {
+	table   []string
+	pred    sqlast.Node
+	pending []func()
+	err     error
+}
+
+func (a *whereAdder) walk(root sqlast.Node, sc *scope) {
+	sqlast.Inspect(root, func(node sqlast.Node) bool {
+		if a.err != nil {
+			return false
+		}
+		switch n := node.(type) {
+		case *sqlast.QueryStmt:
+			a.query(n, sc)
+			return false
+		case *sqlast.SQLSelect:
+			qualify := len(n.FromClause) > 1
+			for _, ref := range n.FromClause {
+				if _, ok := ref.(*sqlast.Table); !ok {
+					qualify = true
+				}
+			}
+			for _, ref := range n.FromClause {
+				a.tableReference(ref, &n.WhereClause, sc, qualify)
+			}
+		case *sqlast.UpdateStmt:
+			a.filter(n.TableName, nil, &n.Selection, sc, false)
+		case *sqlast.DeleteStmt:
+			a.filter(n.TableName, n.Alias, &n.Selection, sc, len(n.Using) > 0)
+			for _, ref := range n.Using {
+				a.tableReference(ref, &n.Selection, sc, true)
+			}
+		case *sqlast.MergeStmt:
+			a.filter(n.Target, n.Alias, &n.On, sc, true)
+			if t, ok := n.Source.(*sqlast.Table); ok {
+				a.mergeSource(n, t, sc)
+			}
+		case *sqlast.CopyStmt:
+			if n.TableName != nil {
+				a.unfilterable(n.TableName, "COPY", sc)
+			}
+		case *sqlast.TruncateStmt:
+			for _, name := range n.TableNames {
+				a.unfilterable(name, "TRUNCATE", sc)
+			}
+		}
+		return true
+	})
+}
+
+// mergeSource replaces the source t of m with
+// `(SELECT * FROM t WHERE pred) AS alias` if t refers to the table.
+func (a *whereAdder) mergeSource(m *sqlast.MergeStmt, t *sqlast.Table, sc *scope) {
+	if !a.refers(t.Name, sc) {
+		return
+	}
+	alias := t.Alias
+	if alias == nil {
+		alias = sqlast.Clone(t.Name.Idents[len(t.Name.Idents)-1]).(*sqlast.Ident)
+	}
+	table := *t
+	table.Alias = nil
+	table.Columns = nil
+	sel := &sqlast.SQLSelect{
+		Projection:  []sqlast.SQLSelectItem{&sqlast.WildcardSelectItem{}},
+		FromClause:  []sqlast.TableReference{&table},
+		WhereClause: a.qualify(t.Name.Idents, false),
+	}
+	a.pending = append(a.pending, func() {
+		m.Source = &sqlast.Derived{SubQuery: &sqlast.QueryStmt{Body: sel}, Alias: alias, Columns: t.Columns}
+	})
+}
+
+// unfilterable reports an error if name of the statement stmt refers to the
+// table.
+func (a *whereAdder) unfilterable(name *sqlast.ObjectName, stmt string, sc *scope) {
+	if a.refers(name, sc) {
+		a.err = errors.Errorf("can't add predicate to %s in %s", name.ToSQLString(), stmt)
+	}
+}
+
+func (a *whereAdder) query(q *sqlast.QueryStmt, parent *scope) {
+	sc := &scope{parent: parent, ctes: make(map[string]struct{})}
+	for _, cte := range q.CTEs {
+		a.query(cte.Query, sc)
+		sc.ctes[strings.ToLower(cte.Alias.Value)] = struct{}{}
+	}
+	a.walk(q.Body, sc)
+	for _, o := range q.OrderBy {
+		a.walk(o, sc)
+	}
+	if q.Limit != nil {
+		a.walk(q.Limit, sc)
+	}
+}
+
+// tableReference adds the predicate of the tables in ref to cond, or to the
+// ON condition of the outer joins whose nullable side they are on. cond is
+// nil if they can't be filtered.
+func (a *whereAdder) tableReference(ref sqlast.TableReference, cond *sqlast.Node, sc *scope, qualify bool) {
+	switch r := ref.(type) {
+	case *sqlast.Table:
+		if len(r.Columns) != 0 && a.refers(r.Name, sc) {
+			a.err = errors.Errorf("can't add predicate to %s with column aliases", r.Name.ToSQLString())
+			return
+		}
+		a.filter(r.Name, r.Alias, cond, sc, qualify)
+	case *sqlast.PartitionedJoinTable:
+		a.tableReference(r.Factor, cond, sc, qualify)
+	case *sqlast.CrossJoin:
+		a.tableReference(r.Reference, cond, sc, qualify)
+		a.tableReference(r.Factor, cond, sc, qualify)
+	case *sqlast.QualifiedJoin:
+		var on *sqlast.Node
+		if c, ok := r.Spec.(*sqlast.JoinCondition); ok {
+			on = &c.SearchCondition
+		}
+		left, right := outerJoin(r.Type, cond, on)
+		a.tableReference(r.LeftElement.Ref, left, sc, qualify)
+		a.tableReference(r.RightElement.Ref, right, sc, qualify)
+	case *sqlast.NaturalJoin:
+		left, right := outerJoin(r.Type, cond, nil)
+		a.tableReference(r.LeftElement.Ref, left, sc, qualify)
+		a.tableReference(r.RightElement.Ref, right, sc, qualify)
+	}
+}
+
+// outerJoin returns the conditions filtering the left and right side of a
+// join of the type t.
+func outerJoin(t *sqlast.JoinType, cond, on *sqlast.Node) (left, right *sqlast.Node) {
+	switch t.Condition {
+	case sqlast.LEFT, sqlast.LEFTOUTER:
+		return cond, on
+	case sqlast.RIGHT, sqlast.RIGHTOUTER:
+		return on, cond
+	case sqlast.FULL, sqlast.FULLOUTER:
+		return nil, nil
+	}
+	return cond, cond
+}
+
+func (a *whereAdder) filter(name *sqlast.ObjectName, alias *sqlast.Ident, cond *sqlast.Node, sc *scope, qualify bool) {
+	if !a.refers(name, sc) {
+		return
+	}
+	if cond == nil {
+		a.err = errors.Errorf("can't add predicate to %s in a FULL join or an outer join without ON", name.ToSQLString())
+		return
+	}
+	qualifier := name.Idents
+	if alias != nil {
+		qualifier = []*sqlast.Ident{alias}
+	}
+	pred := a.qualify(qualifier, qualify)
+	a.pending = append(a.pending, func() {
+		*cond = and(*cond, pred)
+	})
+}
+
+// refers reports whether name refers to the table and not to a CTE.
+func (a *whereAdder) refers(name *sqlast.ObjectName, sc *scope) bool {
+	return a.matches(name.Idents) && !(len(name.Idents) == 1 && sc.isCTE(name.Idents[0].Value))
+}
+
+// matches reports whether the name of idents refers to the table, comparing
+// the parts both have.
+func (a *whereAdder) matches(idents []*sqlast.Ident) bool {
+	for i, j := len(idents)-1, len(a.table)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if !strings.EqualFold(idents[i].Value, a.table[j]) {
+			return false
+		}
+	}
+	return len(idents) > 0
+}
+
+// qualify returns a copy of the predicate whose columns qualified by the
+// table are qualified by qualifier instead. If all is true, unqualified
+// columns are also qualified.
+func (a *whereAdder) qualify(qualifier []*sqlast.Ident, all bool) sqlast.Node {
+	qualified := func(column *sqlast.Ident) *sqlast.CompoundIdent {
+		c := &sqlast.CompoundIdent{}
+		for _, q := range qualifier {
+			c.Idents = append(c.Idents, sqlast.Clone(q).(*sqlast.Ident))
+		}
+		c.Idents = append(c.Idents, column)
+		return c
+	}
+
+	return Apply(sqlast.Clone(a.pred), func(c *Cursor) bool {
+		switch n := c.Node().(type) {
+		case *sqlast.QueryStmt, *sqlast.ObjectName:
+			return false
+		case *sqlast.CompoundIdent:
+			if l := len(n.Idents); l > 1 && a.matches(n.Idents[:l-1]) {
+				c.Replace(qualified(n.Idents[l-1]))
+			}
+			return false
+		case *sqlast.Ident:
+			switch c.Name() {
+			case "Field", "Name", "OverName", "Alias":
+				return false
+			}
+			if q := qualified(n); all && c.CanReplace(q) {
+				c.Replace(q)
+			}
+		}
+		return true
+	}, nil)
+}
+
+// and returns `left AND right`, or right if left is nil.
+func and(left, right sqlast.Node) sqlast.Node {
+	if left == nil {
+		return right
+	}
+	return &sqlast.BinaryExpr{
+		Left:  parenthesizeOr(left),
+		Op:    &sqlast.Operator{Type: sqlast.And},
+		Right: parenthesizeOr(right),
+	}
+}
+
+func parenthesizeOr(n sqlast.Node) sqlast.Node {
+	if b, ok := n.(*sqlast.BinaryExpr); ok && b.Op.Type == sqlast.Or {
+		return &sqlast.Nested{AST: n}
+	}
+	return n
+}
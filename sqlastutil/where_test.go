@@ -0,0 +1,171 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestAddWhere(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		table  string
+		pred   string
+		expect string
+		err    bool
+	}{
+		{
+			name:   "no where",
+			src:    "SELECT * FROM orders",
+			pred:   "tenant_id = 1",
+			expect: "SELECT * FROM orders WHERE tenant_id = 1",
+		},
+		{
+			name:   "or",
+			src:    "SELECT * FROM orders WHERE a = 1 OR b = 2",
+			pred:   "tenant_id = 1",
+			expect: "SELECT * FROM orders WHERE (a = 1 OR b = 2) AND tenant_id = 1",
+		},
+		{
+			name:   "alias",
+			src:    "SELECT * FROM public.orders AS o WHERE o.id > 10",
+			pred:   "orders.tenant_id = 1",
+			expect: "SELECT * FROM public.orders AS o WHERE o.id > 10 AND o.tenant_id = 1",
+		},
+		{
+			name:   "join",
+			src:    "SELECT * FROM users AS u INNER JOIN orders ON u.id = orders.user_id",
+			pred:   "tenant_id = 1",
+			expect: "SELECT * FROM users AS u INNER JOIN orders ON u.id = orders.user_id WHERE orders.tenant_id = 1",
+		},
+		{
+			name:   "outer join",
+			src:    "SELECT * FROM users AS u LEFT JOIN orders AS o ON u.id = o.user_id, orders",
+			pred:   "tenant_id = 1",
+			expect: "SELECT * FROM users AS u LEFT JOIN orders AS o ON u.id = o.user_id AND o.tenant_id = 1, orders WHERE orders.tenant_id = 1",
+		},
+		{
+			name:   "subqueries",
+			src:    "SELECT * FROM (SELECT * FROM orders) AS s WHERE s.id IN (SELECT id FROM Orders WHERE x = 1)",
+			pred:   "tenant_id = 1",
+			expect: "SELECT * FROM (SELECT * FROM orders WHERE tenant_id = 1) AS s WHERE s.id IN (SELECT id FROM Orders WHERE x = 1 AND tenant_id = 1)",
+		},
+		{
+			name:   "cte",
+			src:    "WITH orders AS (SELECT * FROM orders) SELECT * FROM orders",
+			pred:   "tenant_id = 1",
+			expect: "WITH orders AS (SELECT * FROM orders WHERE tenant_id = 1) SELECT * FROM orders",
+		},
+		{
+			name:   "subquery in predicate",
+			src:    "SELECT * FROM orders",
+			pred:   "tenant_id IN (SELECT tenant_id FROM orders)",
+			expect: "SELECT * FROM orders WHERE tenant_id IN (SELECT tenant_id FROM orders)",
+		},
+		{
+			name:   "update",
+			src:    "UPDATE orders SET a = 1 WHERE id = 2",
+			pred:   "tenant_id = 1",
+			expect: "UPDATE orders SET a = 1 WHERE id = 2 AND tenant_id = 1",
+		},
+		{
+			name:   "delete",
+			src:    "DELETE FROM orders",
+			pred:   "tenant_id = 1",
+			expect: "DELETE FROM orders WHERE tenant_id = 1",
+		},
+		{
+			name:   "qualified table",
+			src:    "SELECT * FROM public.orders, other.orders",
+			table:  "public.orders",
+			pred:   "tenant_id = 1",
+			expect: "SELECT * FROM public.orders, other.orders WHERE public.orders.tenant_id = 1",
+		},
+		{
+			name:   "merge target",
+			src:    "MERGE INTO orders AS o USING src AS s ON o.id = s.id WHEN MATCHED THEN UPDATE SET a = s.a",
+			pred:   "tenant_id = 1",
+			expect: "MERGE INTO orders AS o USING src AS s ON o.id = s.id AND o.tenant_id = 1 WHEN MATCHED THEN UPDATE SET a = s.a",
+		},
+		{
+			name:   "merge source",
+			src:    "MERGE INTO t USING orders ON t.id = orders.id WHEN NOT MATCHED THEN INSERT (id) VALUES (orders.id)",
+			pred:   "orders.tenant_id = 1",
+			expect: "MERGE INTO t USING (SELECT * FROM orders WHERE orders.tenant_id = 1) AS orders ON t.id = orders.id WHEN NOT MATCHED THEN INSERT (id) VALUES (orders.id)",
+		},
+		{
+			name:   "merge source subquery",
+			src:    "MERGE INTO t USING (SELECT * FROM orders) AS s ON t.id = s.id WHEN MATCHED THEN DELETE",
+			pred:   "tenant_id = 1",
+			expect: "MERGE INTO t USING (SELECT * FROM orders WHERE tenant_id = 1) AS s ON t.id = s.id WHEN MATCHED THEN DELETE",
+		},
+		{
+			name: "copy",
+			src:  "COPY orders TO STDOUT",
+			pred: "tenant_id = 1",
+			err:  true,
+		},
+		{
+			name:   "copy query",
+			src:    "COPY (SELECT * FROM orders) TO STDOUT",
+			pred:   "tenant_id = 1",
+			expect: "COPY (SELECT * FROM orders WHERE tenant_id = 1) TO STDOUT",
+		},
+		{
+			name: "truncate",
+			src:  "TRUNCATE orders",
+			pred: "tenant_id = 1",
+			err:  true,
+		},
+		{
+			name: "column aliases",
+			src:  "SELECT * FROM orders AS o(id, tenant)",
+			pred: "tenant_id = 1",
+			err:  true,
+		},
+		{
+			name: "full join",
+			src:  "SELECT * FROM users FULL JOIN orders ON users.id = orders.user_id",
+			pred: "tenant_id = 1",
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			pred, err := xsqlparser.ParseExpr(c.pred, &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			table := c.table
+			if table == "" {
+				table = "orders"
+			}
+
+			err = AddWhere(stmt, table, pred)
+			if c.err {
+				if err == nil {
+					t.Errorf("must be error but %s", stmt.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := stmt.ToSQLString(); act != c.expect {
+				t.Errorf("should be \n %s but \n %s", c.expect, act)
+			}
+		})
+	}
+}
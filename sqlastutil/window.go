@@ -0,0 +1,147 @@
+package sqlastutil
+
+import (
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// ExpandWindows replaces references to named windows, `OVER w` and `OVER (w ...)`,
+// in every SELECT under root with the definitions in its WINDOW clause and
+// removes the WINDOW clause. root is modified in place.
+//
+// As in PostgreSQL, a window which inherits another one cannot override its
+// PARTITION BY, nor its ORDER BY if the base has one, and cannot inherit a
+// window with a frame clause.
+func ExpandWindows(root sqlast.Node) error {
+	var err error
+	sqlast.Inspect(root, func(node sqlast.Node) bool {
+		if err != nil {
+			return false
+		}
+		switch n := node.(type) {
+		case *sqlast.QueryStmt:
+			if s, ok := n.Body.(*sqlast.SQLSelect); ok {
+				var orderBy []sqlast.Node
+				for _, o := range n.OrderBy {
+					orderBy = append(orderBy, o)
+				}
+				err = expandWindows(s, orderBy...)
+			}
+		case *sqlast.SQLSelect:
+			err = expandWindows(n)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// expandWindows expands named windows in s and extra, which are the clauses
+// of the enclosing query (e.g. ORDER BY) that can refer to the windows of s.
+func expandWindows(s *sqlast.SQLSelect, extra ...sqlast.Node) error {
+	windows := make(map[string]*sqlast.WindowSpec)
+	for _, w := range s.WindowClause {
+		key := windowKey(w.Name)
+		if _, ok := windows[key]; ok {
+			return errors.Errorf("window %s is already defined", w.Name.ToSQLString())
+		}
+		spec, err := resolveWindow(w.Spec, windows)
+		if err != nil {
+			return errors.Errorf("resolveWindow failed: %w", err)
+		}
+		windows[key] = spec
+	}
+
+	var err error
+	expand := func(node sqlast.Node) bool {
+		if err != nil {
+			return false
+		}
+		switch n := node.(type) {
+		case *sqlast.QueryStmt:
+			// windows are not visible from subqueries
+			return false
+		case *sqlast.Function:
+			if n.OverName != nil {
+				base, ok := windows[windowKey(n.OverName)]
+				if !ok {
+					err = errors.Errorf("window %s does not exist", n.OverName.ToSQLString())
+					return false
+				}
+				spec := *base
+				n.Over = &spec
+				n.OverRparen = n.OverName.End()
+				n.OverName = nil
+			} else if n.Over != nil {
+				spec, e := resolveWindow(n.Over, windows)
+				if e != nil {
+					err = errors.Errorf("resolveWindow failed: %w", e)
+					return false
+				}
+				n.Over = spec
+			}
+		}
+		return true
+	}
+
+	nodes := append([]sqlast.Node{}, extra...)
+	for _, p := range s.Projection {
+		nodes = append(nodes, p)
+	}
+	if s.HavingClause != nil {
+		nodes = append(nodes, s.HavingClause)
+	}
+	for _, n := range nodes {
+		sqlast.Inspect(n, expand)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.WindowClause = nil
+	return nil
+}
+
+// resolveWindow returns spec with the definition of the window it refers to merged.
+func resolveWindow(spec *sqlast.WindowSpec, windows map[string]*sqlast.WindowSpec) (*sqlast.WindowSpec, error) {
+	if spec.Name == nil {
+		return spec, nil
+	}
+	name := spec.Name.ToSQLString()
+	base, ok := windows[windowKey(spec.Name)]
+	if !ok {
+		return nil, errors.Errorf("window %s does not exist", name)
+	}
+	if len(spec.PartitionBy) != 0 {
+		return nil, errors.Errorf("cannot override PARTITION BY clause of window %s", name)
+	}
+	if len(spec.OrderBy) != 0 && len(base.OrderBy) != 0 {
+		return nil, errors.Errorf("cannot override ORDER BY clause of window %s", name)
+	}
+	if base.WindowsFrame != nil {
+		return nil, errors.Errorf("cannot copy window %s because it has a frame clause", name)
+	}
+
+	resolved := &sqlast.WindowSpec{
+		PartitionBy:  base.PartitionBy,
+		Partition:    base.Partition,
+		OrderBy:      base.OrderBy,
+		Order:        base.Order,
+		WindowsFrame: spec.WindowsFrame,
+	}
+	if len(spec.OrderBy) != 0 {
+		resolved.OrderBy = spec.OrderBy
+		resolved.Order = spec.Order
+	}
+	return resolved, nil
+}
+
+// windowKey returns the name of a window as it is compared; unquoted names are case-insensitive.
+func windowKey(name *sqlast.Ident) string {
+	if name.Quoted {
+		return name.Value
+	}
+	return strings.ToLower(name.Value)
+}
@@ -0,0 +1,81 @@
+package sqlastutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestExpandWindows(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		expect string
+		err    bool
+	}{
+		{
+			name:   "reference",
+			src:    "SELECT sum(a) OVER w FROM t WINDOW w AS (PARTITION BY b ORDER BY c)",
+			expect: "SELECT sum(a) OVER (PARTITION BY b ORDER BY c) FROM t",
+		},
+		{
+			name:   "inheritance",
+			src:    "SELECT sum(a) OVER (w ORDER BY c ROWS UNBOUNDED PRECEDING) FROM t WINDOW w AS (PARTITION BY b)",
+			expect: "SELECT sum(a) OVER (PARTITION BY b ORDER BY c ROWS UNBOUNDED PRECEDING) FROM t",
+		},
+		{
+			name:   "chained definitions",
+			src:    "SELECT rank() OVER w2 FROM t WINDOW w1 AS (PARTITION BY b), W2 AS (w1 ORDER BY c) ORDER BY sum(a) OVER W1",
+			expect: "SELECT rank() OVER (PARTITION BY b ORDER BY c) FROM t ORDER BY sum(a) OVER (PARTITION BY b)",
+		},
+		{
+			name:   "subquery",
+			src:    "SELECT x FROM (SELECT sum(a) OVER w AS x FROM t WINDOW w AS (ORDER BY b)) AS s",
+			expect: "SELECT x FROM (SELECT sum(a) OVER (ORDER BY b) AS x FROM t) AS s",
+		},
+		{
+			name: "undefined window",
+			src:  "SELECT sum(a) OVER w FROM t",
+			err:  true,
+		},
+		{
+			name: "override order by",
+			src:  "SELECT sum(a) OVER (w ORDER BY c) FROM t WINDOW w AS (ORDER BY b)",
+			err:  true,
+		},
+		{
+			name: "inherit frame",
+			src:  "SELECT sum(a) OVER (w) FROM t WINDOW w AS (ORDER BY b ROWS UNBOUNDED PRECEDING)",
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(c.src), &dialect.GenericSQLDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			err = ExpandWindows(stmt)
+			if c.err {
+				if err == nil {
+					t.Errorf("must be error but %s", stmt.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := stmt.ToSQLString(); act != c.expect {
+				t.Errorf("should be \n %s but \n %s", c.expect, act)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// Package sqlbuilder builds sqlast trees of queries programmatically with a
+// fluent API, so that SQL is generated from ASTs instead of concatenating
+// strings, e.g:
+//
+//	q := sqlbuilder.Select("id", "name").From("users").
+//		Where(sqlbuilder.Eq(sqlbuilder.Col("status"), "active")).
+//		OrderBy(sqlbuilder.Desc("created_at")).Limit(10)
+//	q.AST()    // *sqlast.QueryStmt
+//	q.String() // SELECT id, name FROM users WHERE status = 'active' ORDER BY created_at DESC LIMIT 10
+//
+// Names of tables and columns are split by dots, and each part is quoted if
+// it is a reserved keyword or not a plain identifier.
+package sqlbuilder
+
+import (
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// SelectBuilder builds a SELECT query.
+type SelectBuilder struct {
+	sel   *sqlast.SQLSelect
+	query *sqlast.QueryStmt
+}
+
+// Select starts a SELECT query of columns. A string column is a column name,
+// "*" or "t.*", and the others are expressions (see Lit) or select items
+// returned by As.
+func Select(columns ...interface{}) *SelectBuilder {
+	b := &SelectBuilder{sel: &sqlast.SQLSelect{}}
+	b.query = &sqlast.QueryStmt{Body: b.sel}
+	for _, c := range columns {
+		b.sel.Projection = append(b.sel.Projection, selectItem(c))
+	}
+	return b
+}
+
+func selectItem(c interface{}) sqlast.SQLSelectItem {
+	switch c := c.(type) {
+	case sqlast.SQLSelectItem:
+		return c
+	case string:
+		if c == "*" {
+			return &sqlast.WildcardSelectItem{}
+		}
+		if len(c) > 2 && c[len(c)-2:] == ".*" {
+			return &sqlast.QualifiedWildcardSelectItem{Prefix: &sqlast.ObjectName{Idents: idents(c[:len(c)-2])}}
+		}
+	}
+	return &sqlast.UnnamedSelectItem{Node: column(c)}
+}
+
+// Distinct makes the query SELECT DISTINCT.
+func (b *SelectBuilder) Distinct() *SelectBuilder {
+	b.sel.Distinct = true
+	return b
+}
+
+// From adds tables to FROM. A string table is a table name, and the others
+// are table references, e.g. returned by TableAs or SubqueryAs.
+func (b *SelectBuilder) From(tables ...interface{}) *SelectBuilder {
+	for _, t := range tables {
+		b.sel.FromClause = append(b.sel.FromClause, tableReference(t))
+	}
+	return b
+}
+
+// Join joins table to the last table of FROM by INNER JOIN with the
+// condition on.
+func (b *SelectBuilder) Join(table interface{}, on sqlast.Node) *SelectBuilder {
+	return b.join(sqlast.INNER, table, on)
+}
+
+// LeftJoin joins table to the last table of FROM by LEFT JOIN with the
+// condition on.
+func (b *SelectBuilder) LeftJoin(table interface{}, on sqlast.Node) *SelectBuilder {
+	return b.join(sqlast.LEFT, table, on)
+}
+
+// RightJoin joins table to the last table of FROM by RIGHT JOIN with the
+// condition on.
+func (b *SelectBuilder) RightJoin(table interface{}, on sqlast.Node) *SelectBuilder {
+	return b.join(sqlast.RIGHT, table, on)
+}
+
+func (b *SelectBuilder) join(cond sqlast.JoinTypeCondition, table interface{}, on sqlast.Node) *SelectBuilder {
+	from := b.sel.FromClause
+	if len(from) == 0 {
+		panic("sqlbuilder: join without FROM")
+	}
+	from[len(from)-1] = &sqlast.QualifiedJoin{
+		LeftElement:  &sqlast.TableJoinElement{Ref: from[len(from)-1]},
+		Type:         &sqlast.JoinType{Condition: cond},
+		RightElement: &sqlast.TableJoinElement{Ref: tableReference(table)},
+		Spec:         &sqlast.JoinCondition{SearchCondition: on},
+	}
+	return b
+}
+
+// Where ANDs conds into WHERE.
+func (b *SelectBuilder) Where(conds ...sqlast.Node) *SelectBuilder {
+	b.sel.WhereClause = And(append([]sqlast.Node{b.sel.WhereClause}, conds...)...)
+	return b
+}
+
+// GroupBy adds exprs to GROUP BY. A string expr is a column name.
+func (b *SelectBuilder) GroupBy(exprs ...interface{}) *SelectBuilder {
+	for _, e := range exprs {
+		b.sel.GroupByClause = append(b.sel.GroupByClause, column(e))
+	}
+	return b
+}
+
+// Having ANDs conds into HAVING.
+func (b *SelectBuilder) Having(conds ...sqlast.Node) *SelectBuilder {
+	b.sel.HavingClause = And(append([]sqlast.Node{b.sel.HavingClause}, conds...)...)
+	return b
+}
+
+// OrderBy adds exprs to ORDER BY. A string expr is a column name, and the
+// others are expressions or returned by Asc or Desc.
+func (b *SelectBuilder) OrderBy(exprs ...interface{}) *SelectBuilder {
+	for _, e := range exprs {
+		o, ok := e.(*sqlast.OrderByExpr)
+		if !ok {
+			o = &sqlast.OrderByExpr{Expr: column(e)}
+		}
+		b.query.OrderBy = append(b.query.OrderBy, o)
+	}
+	return b
+}
+
+// Limit sets LIMIT.
+func (b *SelectBuilder) Limit(n int64) *SelectBuilder {
+	b.limit().LimitValue = sqlast.NewLongValue(n)
+	return b
+}
+
+// Offset sets OFFSET.
+func (b *SelectBuilder) Offset(n int64) *SelectBuilder {
+	b.limit().OffsetValue = sqlast.NewLongValue(n)
+	return b
+}
+
+func (b *SelectBuilder) limit() *sqlast.LimitExpr {
+	if b.query.Limit == nil {
+		b.query.Limit = &sqlast.LimitExpr{}
+	}
+	return b.query.Limit
+}
+
+// AST returns the query built by b. Calling methods of b afterwards modifies
+// the returned query.
+func (b *SelectBuilder) AST() *sqlast.QueryStmt {
+	return b.query
+}
+
+// String returns the SQL of the query.
+func (b *SelectBuilder) String() string {
+	return b.query.ToSQLString()
+}
+
+// TableAs returns the table reference `name AS alias`.
+func TableAs(name, alias string) sqlast.TableReference {
+	return &sqlast.Table{Name: &sqlast.ObjectName{Idents: idents(name)}, Alias: ident(alias)}
+}
+
+// SubqueryAs returns the derived table `(q) AS alias`.
+func SubqueryAs(q *SelectBuilder, alias string) sqlast.TableReference {
+	return &sqlast.Derived{SubQuery: q.AST(), Alias: ident(alias)}
+}
+
+func tableReference(t interface{}) sqlast.TableReference {
+	switch t := t.(type) {
+	case string:
+		return &sqlast.Table{Name: &sqlast.ObjectName{Idents: idents(t)}}
+	case sqlast.TableReference:
+		return t
+	}
+	panic("sqlbuilder: unsupported table reference")
+}
+
+// InsertBuilder builds an INSERT statement.
+type InsertBuilder struct {
+	stmt *sqlast.InsertStmt
+}
+
+// InsertInto starts an INSERT statement into table.
+func InsertInto(table string) *InsertBuilder {
+	return &InsertBuilder{stmt: &sqlast.InsertStmt{TableName: &sqlast.ObjectName{Idents: idents(table)}}}
+}
+
+// Columns sets the columns to insert.
+func (b *InsertBuilder) Columns(columns ...string) *InsertBuilder {
+	for _, c := range columns {
+		b.stmt.Columns = append(b.stmt.Columns, ident(c))
+	}
+	return b
+}
+
+// Values adds a row of values to VALUES.
+func (b *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
+	src, ok := b.stmt.Source.(*sqlast.ConstructorSource)
+	if !ok {
+		src = &sqlast.ConstructorSource{}
+		b.stmt.Source = src
+	}
+	row := &sqlast.RowValueExpr{}
+	for _, v := range values {
+		row.Values = append(row.Values, operand(v))
+	}
+	src.Rows = append(src.Rows, row)
+	return b
+}
+
+// Select makes the statement insert the result of q instead of VALUES.
+func (b *InsertBuilder) Select(q *SelectBuilder) *InsertBuilder {
+	b.stmt.Source = &sqlast.SubQuerySource{SubQuery: q.AST()}
+	return b
+}
+
+// AST returns the statement built by b.
+func (b *InsertBuilder) AST() *sqlast.InsertStmt {
+	return b.stmt
+}
+
+// String returns the SQL of the statement.
+func (b *InsertBuilder) String() string {
+	return b.stmt.ToSQLString()
+}
+
+// UpdateBuilder builds an UPDATE statement.
+type UpdateBuilder struct {
+	stmt *sqlast.UpdateStmt
+}
+
+// Update starts an UPDATE statement of table.
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{stmt: &sqlast.UpdateStmt{TableName: &sqlast.ObjectName{Idents: idents(table)}}}
+}
+
+// Set adds the assignment `column = value`.
+func (b *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
+	b.stmt.Assignments = append(b.stmt.Assignments, &sqlast.Assignment{ID: ident(column), Value: operand(value)})
+	return b
+}
+
+// Where ANDs conds into WHERE.
+func (b *UpdateBuilder) Where(conds ...sqlast.Node) *UpdateBuilder {
+	b.stmt.Selection = And(append([]sqlast.Node{b.stmt.Selection}, conds...)...)
+	return b
+}
+
+// AST returns the statement built by b.
+func (b *UpdateBuilder) AST() *sqlast.UpdateStmt {
+	return b.stmt
+}
+
+// String returns the SQL of the statement.
+func (b *UpdateBuilder) String() string {
+	return b.stmt.ToSQLString()
+}
+
+// DeleteBuilder builds a DELETE statement.
+type DeleteBuilder struct {
+	stmt *sqlast.DeleteStmt
+}
+
+// DeleteFrom starts a DELETE statement from table.
+func DeleteFrom(table string) *DeleteBuilder {
+	return &DeleteBuilder{stmt: &sqlast.DeleteStmt{TableName: &sqlast.ObjectName{Idents: idents(table)}}}
+}
+
+// Where ANDs conds into WHERE.
+func (b *DeleteBuilder) Where(conds ...sqlast.Node) *DeleteBuilder {
+	b.stmt.Selection = And(append([]sqlast.Node{b.stmt.Selection}, conds...)...)
+	return b
+}
+
+// AST returns the statement built by b.
+func (b *DeleteBuilder) AST() *sqlast.DeleteStmt {
+	return b.stmt
+}
+
+// String returns the SQL of the statement.
+func (b *DeleteBuilder) String() string {
+	return b.stmt.ToSQLString()
+}
@@ -0,0 +1,90 @@
+package sqlbuilder
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+func TestBuilder(t *testing.T) {
+	cases := []struct {
+		name    string
+		builder fmt.Stringer
+		expect  string
+	}{
+		{
+			name: "select",
+			builder: Select("id", "u.name", As(Func("count", Col("o.id")), "orders")).
+				From(TableAs("users", "u")).
+				LeftJoin(TableAs("orders", "o"), Eq(Col("o.user_id"), Col("u.id"))).
+				Where(Eq(Col("u.status"), "active"), Or(Gt(Col("u.age"), 20), IsNull(Col("u.age")))).
+				Where(Not(In(Col("u.id"), 1, 2))).
+				GroupBy("id", "u.name").
+				Having(Ge(Func("count", Col("o.id")), 1)).
+				OrderBy(Desc("orders"), "id").
+				Limit(10).Offset(20),
+			expect: "SELECT id, u.name, count(o.id) AS orders FROM users AS u LEFT JOIN orders AS o ON o.user_id = u.id " +
+				"WHERE u.status = 'active' AND (u.age > 20 OR u.age IS NULL) AND NOT u.id IN (1, 2) " +
+				"GROUP BY id, u.name HAVING count(o.id) >= 1 ORDER BY orders DESC, id LIMIT 10 OFFSET 20",
+		},
+		{
+			name: "quoted",
+			builder: Select("*", "t.*", "select", "Mixed Case").Distinct().From("s.t").
+				Where(Like(Col("name"), "it's%"), Between(Col("n"), Param(), ParamN(2)), Lt(Col("at"), time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))),
+			expect: `SELECT DISTINCT *, t.*, "select", "Mixed Case" FROM s.t WHERE name LIKE 'it''s%' AND n BETWEEN ? AND $2 AND at < CAST('2020-01-02 03:04:05' AS timestamp)`,
+		},
+		{
+			name: "subquery",
+			builder: Select("x.id").
+				From(SubqueryAs(Select("id").From("a").Where(IsNotNull(Col("id"))), "x")).
+				Where(InQuery(Col("x.id"), Select("id").From("b")), Ne(Col("x.id"), Select(Func("max", Col("id"))).From("c"))),
+			expect: "SELECT x.id FROM (SELECT id FROM a WHERE id IS NOT NULL) AS x WHERE x.id IN (SELECT id FROM b) AND x.id != (SELECT max(id) FROM c)",
+		},
+		{
+			name:    "insert",
+			builder: InsertInto("users").Columns("id", "name", "deleted").Values(1, "a", nil).Values(2, Param(), false),
+			expect:  "INSERT INTO users (id, name, deleted) VALUES (1, 'a', NULL), (2, ?, false)",
+		},
+		{
+			name:    "insert select",
+			builder: InsertInto("archive").Select(Select("*").From("users").Where(Lt(Col("id"), 10))),
+			expect:  "INSERT INTO archive SELECT * FROM users WHERE id < 10",
+		},
+		{
+			name:    "update",
+			builder: Update("users").Set("name", "b").Set("score", 1.5).Where(Eq(Col("id"), 1)),
+			expect:  "UPDATE users SET name = 'b', score = 1.5 WHERE id = 1",
+		},
+		{
+			name:    "delete",
+			builder: DeleteFrom("users").Where(Or(Eq(Col("id"), 1), Eq(Col("id"), 2)), Eq(Col("tenant"), 3)),
+			expect:  "DELETE FROM users WHERE (id = 1 OR id = 2) AND tenant = 3",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			act := c.builder.String()
+			if act != c.expect {
+				t.Errorf("should be \n %s but \n %s", c.expect, act)
+			}
+
+			// the generated SQL must be parsed back to the same one
+			parser, err := xsqlparser.NewParser(bytes.NewBufferString(act), &dialect.PostgresqlDialect{})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			stmt, err := parser.ParseStatement()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if stmt.ToSQLString() != act {
+				t.Errorf("must be parsed back to \n %s but \n %s", act, stmt.ToSQLString())
+			}
+		})
+	}
+}
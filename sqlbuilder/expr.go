@@ -0,0 +1,260 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+)
+
+// The functions building expressions take operands as interface{}: a
+// sqlast.Node is used as is, and the other values are literals, i.e. a string
+// is a string literal, not a column. Use Col for columns.
+//
+// They panic if an operand is of an unsupported type, which is a bug of the
+// program as passing a wrong type to a function.
+
+// Col returns the column reference named name, e.g. "a" or "t.a".
+func Col(name string) sqlast.Node {
+	idents := idents(name)
+	if len(idents) == 1 {
+		return idents[0]
+	}
+	return &sqlast.CompoundIdent{Idents: idents}
+}
+
+// Lit returns the literal of v, which is an integer, a floating point
+// number, a string, a bool, a time.Time (as a timestamp in its location) or
+// nil (NULL).
+func Lit(v interface{}) sqlast.Node {
+	switch v := v.(type) {
+	case nil:
+		return sqlast.NewNullValue()
+	case int:
+		return sqlast.NewLongValue(int64(v))
+	case int8:
+		return sqlast.NewLongValue(int64(v))
+	case int16:
+		return sqlast.NewLongValue(int64(v))
+	case int32:
+		return sqlast.NewLongValue(int64(v))
+	case int64:
+		return sqlast.NewLongValue(v)
+	case uint8:
+		return sqlast.NewLongValue(int64(v))
+	case uint16:
+		return sqlast.NewLongValue(int64(v))
+	case uint32:
+		return sqlast.NewLongValue(int64(v))
+	case float32:
+		return sqlast.NewDoubleValue(float64(v))
+	case float64:
+		return sqlast.NewDoubleValue(v)
+	case string:
+		return sqlast.NewSingleQuotedString(v)
+	case bool:
+		return sqlast.NewBooleanValue(v)
+	case time.Time:
+		return &sqlast.Cast{
+			Expr:     sqlast.NewSingleQuotedString(v.Format("2006-01-02 15:04:05.999999")),
+			DataType: &sqlast.Timestamp{},
+		}
+	}
+	panic(fmt.Sprintf("sqlbuilder: unsupported literal type %T", v))
+}
+
+// Param returns a `?` placeholder.
+func Param() sqlast.Node {
+	return &sqlast.Placeholder{Value: "?"}
+}
+
+// ParamN returns the numbered placeholder `$n`.
+func ParamN(n int) sqlast.Node {
+	return &sqlast.Placeholder{Value: "$" + strconv.Itoa(n)}
+}
+
+// Func returns the call of the function name with args.
+func Func(name string, args ...interface{}) sqlast.Node {
+	f := &sqlast.Function{Name: &sqlast.ObjectName{Idents: idents(name)}}
+	for _, a := range args {
+		f.Args = append(f.Args, operand(a))
+	}
+	return f
+}
+
+// Eq returns `l = r`.
+func Eq(l, r interface{}) sqlast.Node { return binary(l, sqlast.Eq, r) }
+
+// Ne returns `l != r`.
+func Ne(l, r interface{}) sqlast.Node { return binary(l, sqlast.NotEq, r) }
+
+// Lt returns `l < r`.
+func Lt(l, r interface{}) sqlast.Node { return binary(l, sqlast.Lt, r) }
+
+// Le returns `l <= r`.
+func Le(l, r interface{}) sqlast.Node { return binary(l, sqlast.LtEq, r) }
+
+// Gt returns `l > r`.
+func Gt(l, r interface{}) sqlast.Node { return binary(l, sqlast.Gt, r) }
+
+// Ge returns `l >= r`.
+func Ge(l, r interface{}) sqlast.Node { return binary(l, sqlast.GtEq, r) }
+
+// Like returns `l LIKE r`.
+func Like(l, r interface{}) sqlast.Node { return binary(l, sqlast.Like, r) }
+
+func binary(l interface{}, op sqlast.OperatorType, r interface{}) sqlast.Node {
+	return &sqlast.BinaryExpr{Left: operand(l), Op: &sqlast.Operator{Type: op}, Right: operand(r)}
+}
+
+// And returns the conditions joined by AND, or nil if conds is empty. nil
+// conditions are ignored.
+func And(conds ...sqlast.Node) sqlast.Node {
+	return logical(sqlast.And, conds)
+}
+
+// Or returns the conditions joined by OR, or nil if conds is empty. nil
+// conditions are ignored.
+func Or(conds ...sqlast.Node) sqlast.Node {
+	return logical(sqlast.Or, conds)
+}
+
+func logical(op sqlast.OperatorType, conds []sqlast.Node) sqlast.Node {
+	var result sqlast.Node
+	for _, c := range conds {
+		if c == nil {
+			continue
+		}
+		// OR binds more loosely than AND
+		if b, ok := c.(*sqlast.BinaryExpr); ok && op == sqlast.And && b.Op.Type == sqlast.Or {
+			c = &sqlast.Nested{AST: c}
+		}
+		if result == nil {
+			result = c
+			continue
+		}
+		result = &sqlast.BinaryExpr{Left: result, Op: &sqlast.Operator{Type: op}, Right: c}
+	}
+	return result
+}
+
+// Not returns `NOT cond`.
+func Not(cond sqlast.Node) sqlast.Node {
+	if _, ok := cond.(*sqlast.BinaryExpr); ok {
+		cond = &sqlast.Nested{AST: cond}
+	}
+	return &sqlast.UnaryExpr{Op: &sqlast.Operator{Type: sqlast.Not}, Expr: cond}
+}
+
+// In returns `x IN (values...)`.
+func In(x interface{}, values ...interface{}) sqlast.Node {
+	in := &sqlast.InList{Expr: operand(x)}
+	for _, v := range values {
+		in.List = append(in.List, operand(v))
+	}
+	return in
+}
+
+// NotIn returns `x NOT IN (values...)`.
+func NotIn(x interface{}, values ...interface{}) sqlast.Node {
+	in := In(x, values...).(*sqlast.InList)
+	in.Negated = true
+	return in
+}
+
+// InQuery returns `x IN (q)`.
+func InQuery(x interface{}, q *SelectBuilder) sqlast.Node {
+	return &sqlast.InSubQuery{Expr: operand(x), SubQuery: q.AST()}
+}
+
+// Between returns `x BETWEEN low AND high`.
+func Between(x, low, high interface{}) sqlast.Node {
+	return &sqlast.Between{Expr: operand(x), Low: operand(low), High: operand(high)}
+}
+
+// IsNull returns `x IS NULL`.
+func IsNull(x interface{}) sqlast.Node {
+	return &sqlast.IsNull{X: operand(x)}
+}
+
+// IsNotNull returns `x IS NOT NULL`.
+func IsNotNull(x interface{}) sqlast.Node {
+	return &sqlast.IsNotNull{X: operand(x)}
+}
+
+// As returns the select item of expr aliased by alias. expr is a column name
+// if it is a string.
+func As(expr interface{}, alias string) sqlast.SQLSelectItem {
+	return &sqlast.AliasSelectItem{Expr: column(expr), Alias: ident(alias)}
+}
+
+// Asc returns `expr ASC` for ORDER BY. expr is a column name if it is a
+// string.
+func Asc(expr interface{}) *sqlast.OrderByExpr {
+	return orderBy(expr, true)
+}
+
+// Desc returns `expr DESC` for ORDER BY. expr is a column name if it is a
+// string.
+func Desc(expr interface{}) *sqlast.OrderByExpr {
+	return orderBy(expr, false)
+}
+
+func orderBy(expr interface{}, asc bool) *sqlast.OrderByExpr {
+	return &sqlast.OrderByExpr{Expr: column(expr), ASC: &asc}
+}
+
+// operand returns the node of the operand v, parenthesizing a condition.
+func operand(v interface{}) sqlast.Node {
+	if b, ok := v.(*SelectBuilder); ok {
+		return &sqlast.SubQuery{Query: b.AST()}
+	}
+	n, ok := v.(sqlast.Node)
+	if !ok {
+		return Lit(v)
+	}
+	switch e := n.(type) {
+	case *sqlast.BinaryExpr:
+		switch e.Op.Type {
+		case sqlast.And, sqlast.Or:
+			return &sqlast.Nested{AST: n}
+		}
+	case *sqlast.UnaryExpr:
+		if e.Op.Type == sqlast.Not {
+			return &sqlast.Nested{AST: n}
+		}
+	case *sqlast.QueryStmt:
+		return &sqlast.SubQuery{Query: e}
+	}
+	return n
+}
+
+// column returns the node of v, which is a column name if it is a string.
+func column(v interface{}) sqlast.Node {
+	if s, ok := v.(string); ok {
+		return Col(s)
+	}
+	return operand(v)
+}
+
+var plainIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ident returns the identifier name, which is quoted if it can't be written
+// as is.
+func ident(name string) *sqlast.Ident {
+	_, reserved := dialect.ReservedKeywords[strings.ToUpper(name)]
+	return &sqlast.Ident{Value: name, Quoted: reserved || !plainIdent.MatchString(name)}
+}
+
+// idents returns the identifiers of the name separated by dots.
+func idents(name string) []*sqlast.Ident {
+	var idents []*sqlast.Ident
+	for _, part := range strings.Split(name, ".") {
+		idents = append(idents, ident(part))
+	}
+	return idents
+}
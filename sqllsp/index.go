@@ -0,0 +1,422 @@
+// Package sqllsp provides building blocks of a language server for SQL:
+// document symbols, hover information and go-to-definition of the names
+// defined in statements, i.e: CTEs, table aliases and column aliases.
+package sqllsp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqlastutil"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+// SymbolKind is the kind of a Symbol.
+type SymbolKind int
+
+const (
+	StatementSymbol SymbolKind = iota
+	CTESymbol
+	TableAliasSymbol
+	ColumnAliasSymbol
+	// TableSymbol is a table in FROM without alias, which qualified columns
+	// can refer to by its name.
+	TableSymbol
+)
+
+func (k SymbolKind) String() string {
+	switch k {
+	case StatementSymbol:
+		return "statement"
+	case CTESymbol:
+		return "CTE"
+	case TableAliasSymbol:
+		return "table alias"
+	case ColumnAliasSymbol:
+		return "column alias"
+	case TableSymbol:
+		return "table"
+	}
+	return ""
+}
+
+// Symbol is a statement or a name defined in a statement.
+type Symbol struct {
+	Kind     SymbolKind
+	Name     string
+	Detail   string        // e.g. the aliased table or expression
+	Node     sqlast.Node   // the statement, *sqlast.CTE, table factor or *sqlast.AliasSelectItem
+	Ident    *sqlast.Ident // the name, nil for statements
+	From, To sqltoken.Pos  // range of Node
+	Children []*Symbol     // symbols defined in the statement or the CTE
+}
+
+// NameRange returns the range of the name of s, or the range of the statement.
+func (s *Symbol) NameRange() (sqltoken.Pos, sqltoken.Pos) {
+	if s.Ident == nil {
+		return s.From, s.To
+	}
+	return s.Ident.From, s.Ident.To
+}
+
+// Index resolves the references to the names defined in statements.
+type Index struct {
+	stmts   []sqlast.Stmt
+	symbols []*Symbol
+	defs    map[*sqlast.Ident]*Symbol
+	refs    map[*sqlast.Ident]*Symbol
+}
+
+// NewIndex resolves the names in stmts, which are usually the statements of a file.
+// Names are resolved in each statement.
+func NewIndex(stmts ...sqlast.Stmt) *Index {
+	x := &Index{
+		stmts: stmts,
+		defs:  make(map[*sqlast.Ident]*Symbol),
+		refs:  make(map[*sqlast.Ident]*Symbol),
+	}
+	for _, stmt := range stmts {
+		if stmt == nil {
+			continue
+		}
+		sym := &Symbol{
+			Kind:   StatementSymbol,
+			Name:   stmtName(stmt),
+			Detail: stmtDetail(stmt),
+			Node:   stmt,
+			From:   stmt.Pos(),
+			To:     stmt.End(),
+		}
+		x.symbols = append(x.symbols, sym)
+		r := &resolver{index: x, containers: []*Symbol{sym}}
+		sqlastutil.Apply(stmt, r.pre, r.post)
+	}
+	return x
+}
+
+// Symbols returns the symbols of the statements for document symbols,
+// except TableSymbols.
+func (x *Index) Symbols() []*Symbol {
+	return x.symbols
+}
+
+// Definition returns the symbol which the name at pos refers to or defines,
+// or nil if the name is not resolved.
+func (x *Index) Definition(pos sqltoken.Pos) *Symbol {
+	ident := x.identAt(pos)
+	if ident == nil {
+		return nil
+	}
+	if sym, ok := x.refs[ident]; ok {
+		return sym
+	}
+	return x.defs[ident]
+}
+
+// References returns the names referring to sym in the source order.
+func (x *Index) References(sym *Symbol) []*sqlast.Ident {
+	if sym == nil {
+		return nil
+	}
+	var idents []*sqlast.Ident
+	x.inspect(func(i *sqlast.Ident) {
+		if x.refs[i] == sym {
+			idents = append(idents, i)
+		}
+	})
+	return idents
+}
+
+// Hover is the hover information of a name.
+type Hover struct {
+	Symbol   *Symbol
+	Text     string
+	From, To sqltoken.Pos // range of the name
+}
+
+// Hover returns the information of the symbol which the name at pos refers
+// to or defines, or nil if the name is not resolved.
+func (x *Index) Hover(pos sqltoken.Pos) *Hover {
+	ident := x.identAt(pos)
+	if ident == nil {
+		return nil
+	}
+	sym, ok := x.refs[ident]
+	if !ok {
+		if sym, ok = x.defs[ident]; !ok {
+			return nil
+		}
+	}
+	text := fmt.Sprintf("(%s) %s", sym.Kind, sym.Name)
+	if sym.Detail != "" {
+		text += ": " + sym.Detail
+	}
+	return &Hover{Symbol: sym, Text: text, From: ident.From, To: ident.To}
+}
+
+func (x *Index) identAt(pos sqltoken.Pos) *sqlast.Ident {
+	var found *sqlast.Ident
+	x.inspect(func(i *sqlast.Ident) {
+		if i.From.Line != 0 && sqltoken.ComparePos(i.From, pos) <= 0 && sqltoken.ComparePos(pos, i.To) < 0 {
+			found = i
+		}
+	})
+	return found
+}
+
+func (x *Index) inspect(f func(*sqlast.Ident)) {
+	for _, stmt := range x.stmts {
+		if stmt == nil {
+			continue
+		}
+		sqlastutil.Apply(stmt, func(c *sqlastutil.Cursor) bool {
+			if i, ok := c.Node().(*sqlast.Ident); ok {
+				f(i)
+			}
+			return c.Node() != nil
+		}, nil)
+	}
+}
+
+type scope struct {
+	parent  *scope
+	ctes    []*Symbol
+	sources []*Symbol
+	aliases []*Symbol
+	body    *scope // scope of the first SELECT of a query, whose aliases ORDER BY refers to
+	query   bool
+
+	aliasScope *scope // set while visiting ORDER BY, GROUP BY or HAVING
+}
+
+type resolver struct {
+	index      *Index
+	scope      *scope
+	containers []*Symbol // the statement and CTEs being visited
+}
+
+func (r *resolver) define(kind SymbolKind, ident *sqlast.Ident, node sqlast.Node, detail string) *Symbol {
+	sym := &Symbol{
+		Kind:   kind,
+		Name:   ident.Value,
+		Detail: detail,
+		Node:   node,
+		Ident:  ident,
+		From:   node.Pos(),
+		To:     node.End(),
+	}
+	r.index.defs[ident] = sym
+	if kind != TableSymbol {
+		c := r.containers[len(r.containers)-1]
+		c.Children = append(c.Children, sym)
+	}
+	return sym
+}
+
+func (r *resolver) push(s *scope) {
+	s.parent = r.scope
+	r.scope = s
+}
+
+func (r *resolver) pre(c *sqlastutil.Cursor) bool {
+	switch n := c.Node().(type) {
+	case nil:
+		return false
+	case *sqlast.QueryStmt:
+		r.push(&scope{query: true})
+	case *sqlast.CTE:
+		sym := r.define(CTESymbol, n.Alias, n, n.Query.ToSQLString())
+		r.scope.ctes = append(r.scope.ctes, sym)
+		r.containers = append(r.containers, sym)
+	case *sqlast.SQLSelect:
+		s := &scope{}
+		if p := r.scope; p != nil && p.query && p.body == nil {
+			p.body = s
+		}
+		r.push(s)
+		for _, ref := range n.FromClause {
+			r.sources(ref)
+		}
+		for _, l := range n.LateralViews {
+			if l.TableAlias != nil {
+				s.sources = append(s.sources, r.define(TableAliasSymbol, l.TableAlias, l, l.Generator.ToSQLString()))
+			}
+		}
+		for _, item := range n.Projection {
+			if a, ok := item.(*sqlast.AliasSelectItem); ok {
+				s.aliases = append(s.aliases, r.define(ColumnAliasSymbol, a.Alias, a, a.Expr.ToSQLString()))
+			}
+		}
+	case *sqlast.UpdateStmt:
+		s := &scope{}
+		r.push(s)
+		s.sources = append(s.sources, r.table(n.TableName, nil, n))
+	case *sqlast.DeleteStmt:
+		s := &scope{}
+		r.push(s)
+		s.sources = append(s.sources, r.table(n.TableName, n.Alias, n))
+		for _, ref := range n.Using {
+			r.sources(ref)
+		}
+	case *sqlast.Table:
+		if len(n.Name.Idents) == 1 {
+			if cte := r.scope.lookup(n.Name.Idents[0].Value, func(s *scope) []*Symbol { return s.ctes }); cte != nil {
+				r.index.refs[n.Name.Idents[0]] = cte
+			}
+		}
+	case *sqlast.CompoundIdent:
+		r.qualifier(n.Idents[:len(n.Idents)-1])
+		return false
+	case *sqlast.QualifiedWildcardSelectItem:
+		r.qualifier(n.Prefix.Idents)
+		return false
+	case *sqlast.Ident:
+		if _, ok := c.Parent().(*sqlast.ObjectName); ok {
+			break
+		}
+		if s := r.scope; s != nil && s.aliasScope != nil {
+			if sym := s.aliasScope.find(n.Value, s.aliasScope.aliases); sym != nil {
+				r.index.refs[n] = sym
+			}
+		}
+	}
+
+	if s := r.scope; s != nil && refersAliases(c) {
+		s.aliasScope = s
+		if c.Name() == "OrderBy" {
+			s.aliasScope = s.body
+		}
+	}
+	return true
+}
+
+func (r *resolver) post(c *sqlastutil.Cursor) bool {
+	switch c.Node().(type) {
+	case *sqlast.QueryStmt, *sqlast.SQLSelect, *sqlast.UpdateStmt, *sqlast.DeleteStmt:
+		r.scope = r.scope.parent
+	case *sqlast.CTE:
+		r.containers = r.containers[:len(r.containers)-1]
+	}
+	if s := r.scope; s != nil && refersAliases(c) {
+		s.aliasScope = nil
+	}
+	return true
+}
+
+// refersAliases reports whether the node of c may refer to the column aliases
+// of the select list, i.e: it's in ORDER BY, GROUP BY or HAVING.
+func refersAliases(c *sqlastutil.Cursor) bool {
+	switch c.Parent().(type) {
+	case *sqlast.QueryStmt:
+		return c.Name() == "OrderBy"
+	case *sqlast.SQLSelect:
+		return c.Name() == "GroupByClause" || c.Name() == "HavingClause"
+	}
+	return false
+}
+
+// sources defines the tables in ref in the current scope.
+func (r *resolver) sources(ref sqlast.TableReference) {
+	s := r.scope
+	switch n := ref.(type) {
+	case *sqlast.Table:
+		s.sources = append(s.sources, r.table(n.Name, n.Alias, n))
+	case *sqlast.Derived:
+		if n.Alias != nil {
+			s.sources = append(s.sources, r.define(TableAliasSymbol, n.Alias, n, n.SubQuery.ToSQLString()))
+		}
+	case *sqlast.TableFunction:
+		if n.Alias != nil {
+			s.sources = append(s.sources, r.define(TableAliasSymbol, n.Alias, n, n.Function.ToSQLString()))
+		}
+	case *sqlast.Unnest:
+		if n.Alias != nil {
+			s.sources = append(s.sources, r.define(TableAliasSymbol, n.Alias, n, "UNNEST"))
+		}
+	case *sqlast.QualifiedJoin:
+		r.sources(n.LeftElement.Ref)
+		r.sources(n.RightElement.Ref)
+	case *sqlast.NaturalJoin:
+		r.sources(n.LeftElement.Ref)
+		r.sources(n.RightElement.Ref)
+	case *sqlast.CrossJoin:
+		r.sources(n.Reference)
+		r.sources(n.Factor)
+	}
+}
+
+// table defines a table named name with optional alias.
+func (r *resolver) table(name *sqlast.ObjectName, alias *sqlast.Ident, node sqlast.Node) *Symbol {
+	if alias != nil {
+		return r.define(TableAliasSymbol, alias, node, name.ToSQLString())
+	}
+	last := name.Idents[len(name.Idents)-1]
+	sym := r.define(TableSymbol, last, node, "")
+	sym.Name = name.ToSQLString()
+	return sym
+}
+
+// qualifier resolves the qualifier of a name such as `t` of `t.col`.
+func (r *resolver) qualifier(qualifier []*sqlast.Ident) {
+	if len(qualifier) == 0 || r.scope == nil {
+		return
+	}
+	var parts []string
+	for _, i := range qualifier {
+		parts = append(parts, i.Value)
+	}
+	if sym := r.scope.lookup(strings.Join(parts, "."), func(s *scope) []*Symbol { return s.sources }); sym != nil {
+		r.index.refs[qualifier[len(qualifier)-1]] = sym
+	}
+}
+
+// lookup finds the symbol named name in the nearest scope.
+func (s *scope) lookup(name string, symbols func(*scope) []*Symbol) *Symbol {
+	for c := s; c != nil; c = c.parent {
+		if sym := c.find(name, symbols(c)); sym != nil {
+			return sym
+		}
+	}
+	return nil
+}
+
+func (s *scope) find(name string, symbols []*Symbol) *Symbol {
+	for _, sym := range symbols {
+		if strings.EqualFold(sym.Name, name) {
+			return sym
+		}
+		if sym.Kind == TableSymbol && strings.EqualFold(sym.Ident.Value, name) {
+			return sym
+		}
+	}
+	return nil
+}
+
+// stmtName returns the kind of stmt in SQL, e.g. "CREATE TABLE" for *sqlast.CreateTableStmt.
+func stmtName(stmt sqlast.Stmt) string {
+	if _, ok := stmt.(*sqlast.QueryStmt); ok {
+		return "SELECT"
+	}
+	name := strings.TrimSuffix(reflect.TypeOf(stmt).Elem().Name(), "Stmt")
+	var b strings.Builder
+	for i, r := range name {
+		if i != 0 && unicode.IsUpper(r) {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// stmtDetail returns the table modified by stmt if any.
+func stmtDetail(stmt sqlast.Stmt) string {
+	for _, t := range sqlastutil.ExtractTables(stmt) {
+		if t.Access == sqlastutil.Write {
+			return t.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,145 @@
+package sqllsp
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqltoken"
+)
+
+const src = `WITH recent AS (SELECT * FROM orders WHERE created_at > '2020-01-01')
+SELECT u.name, count(*) AS cnt
+FROM users AS u
+JOIN recent r ON r.user_id = u.id
+WHERE EXISTS (SELECT 1 FROM recent WHERE recent.user_id = u.id)
+GROUP BY u.name
+HAVING cnt > 1
+ORDER BY cnt;
+UPDATE users SET name = 'x' WHERE users.id = 1;`
+
+func newIndex(t *testing.T) *Index {
+	t.Helper()
+	parser, err := xsqlparser.NewParser(bytes.NewBufferString(src), &dialect.GenericSQLDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return NewIndex(stmts...)
+}
+
+// posOf returns the position of the n-th occurrence of s in src, starting from 0.
+func posOf(s string, n int) sqltoken.Pos {
+	offset := -1
+	for i := 0; i <= n; i++ {
+		offset += 1 + strings.Index(src[offset+1:], s)
+	}
+	line := strings.Count(src[:offset], "\n") + 1
+	col := offset - strings.LastIndex(src[:offset], "\n")
+	return sqltoken.NewPos(line, col)
+}
+
+func TestIndex_Symbols(t *testing.T) {
+	index := newIndex(t)
+
+	var actual []string
+	var dump func(syms []*Symbol, indent string)
+	dump = func(syms []*Symbol, indent string) {
+		for _, s := range syms {
+			actual = append(actual, fmt.Sprintf("%s%s %s %d:%d", indent, s.Kind, s.Name, s.From.Line, s.From.Col))
+			dump(s.Children, indent+"  ")
+		}
+	}
+	dump(index.Symbols(), "")
+
+	expect := []string{
+		"statement SELECT 1:1",
+		"  CTE recent 1:6",
+		"  table alias u 3:6",
+		"  table alias r 4:6",
+		"  column alias cnt 2:16",
+		"statement UPDATE 9:1",
+	}
+	if diff := cmp.Diff(expect, actual); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+	if d := index.Symbols()[1].Detail; d != "users" {
+		t.Errorf("detail must be users but %s", d)
+	}
+}
+
+func TestIndex_Definition(t *testing.T) {
+	index := newIndex(t)
+
+	cases := []struct {
+		name   string
+		at     sqltoken.Pos
+		expect string // kind and position of the definition
+	}{
+		{name: "table alias", at: posOf("u.name", 0), expect: "table alias 3:15"},
+		{name: "alias in join condition", at: posOf("r.user_id", 0), expect: "table alias 4:13"},
+		{name: "CTE in FROM", at: posOf("recent r", 0), expect: "CTE 1:6"},
+		{name: "correlated", at: posOf("u.id", 1), expect: "table alias 3:15"},
+		{name: "table without alias", at: posOf("recent.user_id", 0), expect: "table 5:29"},
+		{name: "column alias in HAVING", at: posOf("cnt > 1", 0), expect: "column alias 2:28"},
+		{name: "column alias in ORDER BY", at: posOf("cnt;", 0), expect: "column alias 2:28"},
+		{name: "definition", at: posOf("cnt", 0), expect: "column alias 2:28"},
+		{name: "UPDATE", at: posOf("users.id", 0), expect: "table 9:8"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sym := index.Definition(c.at)
+			if sym == nil {
+				t.Fatalf("definition at %d:%d is not found", c.at.Line, c.at.Col)
+			}
+			from, _ := sym.NameRange()
+			if actual := fmt.Sprintf("%s %d:%d", sym.Kind, from.Line, from.Col); actual != c.expect {
+				t.Errorf("must be %s but %s", c.expect, actual)
+			}
+		})
+	}
+
+	if sym := index.Definition(posOf("created_at", 0)); sym != nil {
+		t.Errorf("column must not be resolved but %+v", sym)
+	}
+}
+
+func TestIndex_Hover(t *testing.T) {
+	index := newIndex(t)
+
+	h := index.Hover(posOf("r.user_id", 0))
+	if h == nil {
+		t.Fatal("hover must not be nil")
+	}
+	if h.Text != "(table alias) r: recent" {
+		t.Errorf("unexpected text %s", h.Text)
+	}
+	if h.From != posOf("r.user_id", 0) {
+		t.Errorf("unexpected range %v", h.From)
+	}
+
+	if h := index.Hover(posOf("count", 0)); h != nil {
+		t.Errorf("must be nil but %+v", h)
+	}
+}
+
+func TestIndex_References(t *testing.T) {
+	index := newIndex(t)
+
+	var actual []string
+	for _, i := range index.References(index.Definition(posOf("u.id", 0))) {
+		actual = append(actual, fmt.Sprintf("%d:%d", i.From.Line, i.From.Col))
+	}
+	expect := []string{"2:8", "4:30", "5:59", "6:10"}
+	if diff := cmp.Diff(expect, actual); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
@@ -0,0 +1,90 @@
+package sqltoken
+
+import (
+	"io"
+	"sync"
+)
+
+// tokenChunkSize is the number of tokens allocated at once by TokenArena.
+const tokenChunkSize = 64
+
+type tokenChunk [tokenChunkSize]Token
+
+var tokenChunkPool = sync.Pool{
+	New: func() interface{} {
+		return new(tokenChunk)
+	},
+}
+
+// TokenArena allocates tokens in chunks instead of one by one. The chunks
+// of an arena created by NewTokenArena are taken from a pool shared by all
+// arenas and put back by Release, so that tokenizing many sources allocates
+// few tokens. The zero value allocates new chunks and Release only drops them.
+type TokenArena struct {
+	pooled bool
+	chunks []*tokenChunk
+	next   int // index of the next token in the last chunk
+}
+
+// NewTokenArena returns a TokenArena which reuses released tokens.
+func NewTokenArena() *TokenArena {
+	return &TokenArena{pooled: true}
+}
+
+// New returns a zeroed token owned by a.
+func (a *TokenArena) New() *Token {
+	if len(a.chunks) == 0 || a.next == tokenChunkSize {
+		var c *tokenChunk
+		if a.pooled {
+			c = tokenChunkPool.Get().(*tokenChunk)
+		} else {
+			c = new(tokenChunk)
+		}
+		a.chunks = append(a.chunks, c)
+		a.next = 0
+	}
+	tok := &a.chunks[len(a.chunks)-1][a.next]
+	a.next++
+	return tok
+}
+
+// Release frees all tokens returned by New at once. They must not be used
+// after Release. The arena can be used again.
+func (a *TokenArena) Release() {
+	if a.pooled {
+		for _, c := range a.chunks {
+			*c = tokenChunk{}
+			tokenChunkPool.Put(c)
+		}
+	}
+	a.chunks = nil
+	a.next = 0
+}
+
+// TokenizeIn is like Tokenize but allocates the tokens in a.
+func (t *Tokenizer) TokenizeIn(a *TokenArena) ([]*Token, error) {
+	var tokenset []*Token
+	var tok *Token
+
+	for {
+		// a token not filled by Scan is used for the next one
+		if tok == nil {
+			tok = a.New()
+		}
+		scanned, err := t.Scan(tok)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if scanned == nil {
+			continue
+		}
+		tokenset = append(tokenset, scanned)
+		tok = nil
+	}
+
+	return tokenset, nil
+}
@@ -66,6 +66,60 @@ const (
 	LBrace
 	// Right brace `}`
 	RBrace
+	// -> operator
+	Arrow
+	// ->> operator
+	LongArrow
+	// #> operator
+	HashArrow
+	// #>> operator
+	HashLongArrow
+	// @> operator
+	AtArrow
+	// <@ operator
+	ArrowAt
+	// ? operator
+	Question
+	// ?| operator
+	QuestionPipe
+	// ?& operator
+	QuestionAnd
+	// => operator
+	RArrow
+	// || operator
+	StringConcat
+	// << operator
+	ShiftLeft
+	// >> operator
+	ShiftRight
+	// operator defined by dialect.OperatorDialect
+	CustomOperator
+	// Dollar quoted string i.e: $$string$$ or $tag$string$tag$
+	DollarQuotedString
+	// Hexadecimal string i.e: X'1F'
+	HexStringLiteral
+	// Hexadecimal number i.e: 0x1F
+	HexNumber
+	// Bit string i.e: B'1010'
+	BitStringLiteral
+	// Postgres escape string i.e: E'it\'s'
+	EscapedStringLiteral
+	// := operator
+	ColonEq
+	// ~ operator
+	Tilde
+	// ~* operator
+	TildeAsterisk
+	// !~ operator
+	ExclamationMarkTilde
+	// !~* operator
+	ExclamationMarkTildeAsterisk
+	// | operator
+	Pipe
+	// # operator
+	Sharp
+	// String with Unicode escapes i.e: U&'d\0061t\+000061'
+	UnicodeStringLiteral
 	// ILLEGAL sqltoken
 	ILLEGAL
 )
@@ -39,12 +39,39 @@ func _() {
 	_ = x[Ampersand-28]
 	_ = x[LBrace-29]
 	_ = x[RBrace-30]
-	_ = x[ILLEGAL-31]
+	_ = x[Arrow-31]
+	_ = x[LongArrow-32]
+	_ = x[HashArrow-33]
+	_ = x[HashLongArrow-34]
+	_ = x[AtArrow-35]
+	_ = x[ArrowAt-36]
+	_ = x[Question-37]
+	_ = x[QuestionPipe-38]
+	_ = x[QuestionAnd-39]
+	_ = x[RArrow-40]
+	_ = x[StringConcat-41]
+	_ = x[ShiftLeft-42]
+	_ = x[ShiftRight-43]
+	_ = x[CustomOperator-44]
+	_ = x[DollarQuotedString-45]
+	_ = x[HexStringLiteral-46]
+	_ = x[HexNumber-47]
+	_ = x[BitStringLiteral-48]
+	_ = x[EscapedStringLiteral-49]
+	_ = x[ColonEq-50]
+	_ = x[Tilde-51]
+	_ = x[TildeAsterisk-52]
+	_ = x[ExclamationMarkTilde-53]
+	_ = x[ExclamationMarkTildeAsterisk-54]
+	_ = x[Pipe-55]
+	_ = x[Sharp-56]
+	_ = x[UnicodeStringLiteral-57]
+	_ = x[ILLEGAL-58]
 }
 
-const _Kind_name = "SQLKeywordNumberCharSingleQuotedStringNationalStringLiteralCommaWhitespaceCommentEqNeqLtGtLtEqGtEqPlusMinusMultDivModLParenRParenPeriodColonDoubleColonSemicolonBackslashLBracketRBracketAmpersandLBraceRBraceILLEGAL"
+const _Kind_name = "SQLKeywordNumberCharSingleQuotedStringNationalStringLiteralCommaWhitespaceCommentEqNeqLtGtLtEqGtEqPlusMinusMultDivModLParenRParenPeriodColonDoubleColonSemicolonBackslashLBracketRBracketAmpersandLBraceRBraceArrowLongArrowHashArrowHashLongArrowAtArrowArrowAtQuestionQuestionPipeQuestionAndRArrowStringConcatShiftLeftShiftRightCustomOperatorDollarQuotedStringHexStringLiteralHexNumberBitStringLiteralEscapedStringLiteralColonEqTildeTildeAsteriskExclamationMarkTildeExclamationMarkTildeAsteriskPipeSharpUnicodeStringLiteralILLEGAL"
 
-var _Kind_index = [...]uint8{0, 10, 16, 20, 38, 59, 64, 74, 81, 83, 86, 88, 90, 94, 98, 102, 107, 111, 114, 117, 123, 129, 135, 140, 151, 160, 169, 177, 185, 194, 200, 206, 213}
+var _Kind_index = [...]uint16{0, 10, 16, 20, 38, 59, 64, 74, 81, 83, 86, 88, 90, 94, 98, 102, 107, 111, 114, 117, 123, 129, 135, 140, 151, 160, 169, 177, 185, 194, 200, 206, 211, 220, 229, 242, 249, 256, 264, 276, 287, 293, 305, 314, 324, 338, 356, 372, 381, 397, 417, 424, 429, 442, 462, 490, 494, 499, 519, 526}
 
 func (i Kind) String() string {
 	if i < 0 || i >= Kind(len(_Kind_index)-1) {
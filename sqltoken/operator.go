@@ -0,0 +1,139 @@
+package sqltoken
+
+import (
+	"unicode/utf8"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser/dialect"
+)
+
+// operators maps every operator and punctuation the tokenizer recognizes to its Kind.
+// Operators are matched by maximal munch, one character at a time, so every prefix
+// of an operator longer than one character must be an operator as well
+// (e.g. `->>` needs `->`).
+var operators = map[string]Kind{
+	"(":   LParen,
+	")":   RParen,
+	",":   Comma,
+	".":   Period,
+	";":   Semicolon,
+	":":   Colon,
+	"::":  DoubleColon,
+	":=":  ColonEq,
+	"\\":  Backslash,
+	"[":   LBracket,
+	"]":   RBracket,
+	"{":   LBrace,
+	"}":   RBrace,
+	"&":   Ampersand,
+	"|":   Pipe,
+	"+":   Plus,
+	"-":   Minus,
+	"*":   Mult,
+	"/":   Div,
+	"%":   Mod,
+	"=":   Eq,
+	"!=":  Neq,
+	"!~":  ExclamationMarkTilde,
+	"!~*": ExclamationMarkTildeAsterisk,
+	"~":   Tilde,
+	"~*":  TildeAsterisk,
+	"<>":  Neq,
+	"<":   Lt,
+	">":   Gt,
+	"<=":  LtEq,
+	">=":  GtEq,
+	"=>":  RArrow,
+	"||":  StringConcat,
+	"<<":  ShiftLeft,
+	">>":  ShiftRight,
+	"?":   Question, // also a placeholder
+}
+
+// dialectOperators are the kinds of the operators which are recognized only
+// if the dialect lists them in dialect.OperatorDialect. The other operators
+// of the dialect are tokenized as CustomOperator.
+var dialectOperators = map[string]Kind{
+	"#":   Sharp,
+	"->":  Arrow,
+	"->>": LongArrow,
+	"#>":  HashArrow,
+	"#>>": HashLongArrow,
+	"@>":  AtArrow,
+	"<@":  ArrowAt,
+	"?|":  QuestionPipe,
+	"?&":  QuestionAnd,
+}
+
+// operatorValues holds the operators as Token.Value, which are boxed once
+// here instead of for every token.
+var operatorValues = make(map[string]interface{}, len(operators)+len(dialectOperators))
+
+func init() {
+	for op := range operators {
+		operatorValues[op] = op
+	}
+	for op := range dialectOperators {
+		operatorValues[op] = op
+	}
+}
+
+func (t *Tokenizer) operatorKind(op []byte) (Kind, bool) {
+	if k, ok := operators[string(op)]; ok {
+		return k, true
+	}
+	if t.operators == nil {
+		t.operators = make(map[string]Kind)
+		if d, ok := t.Dialect.(dialect.OperatorDialect); ok {
+			for _, o := range d.Operators() {
+				if k, ok := dialectOperators[o]; ok {
+					t.operators[o] = k
+				} else {
+					t.operators[o] = CustomOperator
+				}
+			}
+		}
+	}
+	k, ok := t.operators[string(op)]
+	return k, ok
+}
+
+// tokenizeOperator reads the longest operator starting with the consumed rune first.
+// A single character which is not an operator is returned as Char.
+func (t *Tokenizer) tokenizeOperator(first rune) (Kind, interface{}, error) {
+	var buf [8]byte
+	op := appendRune(buf[:0], first)
+	for {
+		n := t.Scanner.Peek()
+		if n < 0 {
+			break
+		}
+		next := appendRune(op, n)
+		if _, ok := t.operatorKind(next); !ok {
+			break
+		}
+		t.Scanner.Next()
+		op = next
+	}
+
+	if k, ok := t.operatorKind(op); ok {
+		t.Col += utf8.RuneCount(op)
+		if v, ok := operatorValues[string(op)]; ok {
+			return k, v, nil
+		}
+		return k, string(op), nil
+	}
+	if first == '!' {
+		return ILLEGAL, "", errors.Errorf("tokenizer error: illegal sequence %s%s", op, string(t.Scanner.Peek()))
+	}
+	t.Col += 1
+	return Char, string(op), nil
+}
+
+// appendRune is utf8.AppendRune, which isn't available before Go 1.18.
+func appendRune(b []byte, r rune) []byte {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	return append(b, buf[:n]...)
+}
@@ -1,10 +1,30 @@
+/*
+Package sqltoken implements the lexer of xsqlparser.
+
+Tokenizer can be used on its own, e.g. for syntax highlighting or error
+reporting. Every Token carries its position (Line and Col of From and To,
+and the byte Offset in the source) and the exact source text in Raw, so
+concatenating Raw of all tokens reproduces the input.
+
+	tokens, err := sqltoken.NewTokenizer(strings.NewReader(src), &dialect.GenericSQLDialect{}).Tokenize()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, tok := range tokens {
+		fmt.Println(tok.Kind, tok.From.Line, tok.From.Col, tok.Offset, tok.Raw)
+	}
+*/
 package sqltoken
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 	"text/scanner"
+	"unicode"
 
 	errors "golang.org/x/xerrors"
 
@@ -15,17 +35,34 @@ type SQLWord struct {
 	Value      string
 	QuoteStyle rune
 	Keyword    string
+	// escape character of U&"..." identifiers, i.e: '\\' or the one given by UESCAPE
+	UnicodeEscape rune
 }
 
 func (s *SQLWord) String() string {
+	if s.UnicodeEscape != 0 && s.QuoteStyle == '"' {
+		return QuoteUnicodeEscape(s.Value, '"', s.UnicodeEscape)
+	}
 	if s.QuoteStyle == '"' || s.QuoteStyle == '[' || s.QuoteStyle == '`' {
-		return string(s.QuoteStyle) + s.Value + string(matchingEndQuote(s.QuoteStyle))
+		end := string(matchingEndQuote(s.QuoteStyle))
+		return string(s.QuoteStyle) + strings.ReplaceAll(s.Value, end, end+end) + end
 	} else if s.QuoteStyle == 0 {
 		return s.Value
 	}
 	return ""
 }
 
+// QuoteUnicodeEscape returns value quoted as U&"value" or U&'value' by quote,
+// followed by UESCAPE unless escape is a backslash.
+func QuoteUnicodeEscape(value string, quote, escape rune) string {
+	e, q := string(escape), string(quote)
+	s := "U&" + q + strings.ReplaceAll(strings.ReplaceAll(value, e, e+e), q, q+q) + q
+	if escape != '\\' {
+		s += " UESCAPE '" + e + "'"
+	}
+	return s
+}
+
 func matchingEndQuote(quoteStyle rune) rune {
 	switch quoteStyle {
 	case '"':
@@ -68,20 +105,34 @@ func MakeKeyword(word string, quoteStyle rune) *SQLWord {
 			Value:   word,
 			Keyword: w,
 		}
-	} else {
+	} else if quoteStyle != 0 {
+		// quoted identifiers are never keywords
 		return &SQLWord{
 			Value:      word,
-			Keyword:    w,
 			QuoteStyle: quoteStyle,
 		}
+	} else {
+		return &SQLWord{
+			Value:   word,
+			Keyword: w,
+		}
 	}
 }
 
+// Token is a lexical token. Value is a *SQLWord for SQLKeyword, and a string
+// (the unquoted, unescaped content for literals) for other kinds.
 type Token struct {
-	Kind  Kind
-	Value interface{}
-	From  Pos
-	To    Pos
+	Kind   Kind
+	Value  interface{}
+	From   Pos
+	To     Pos    // position just after the last character of the token
+	Raw    string // source text of the token, e.g. `'it''s'` for the string it's
+	Offset int    // byte offset of the first byte of Raw in the source
+}
+
+// EndOffset returns the byte offset just after the last byte of the token in the source.
+func (t *Token) EndOffset() int {
+	return t.Offset + len(t.Raw)
 }
 
 func NewPos(line, col int) Pos {
@@ -91,6 +142,7 @@ func NewPos(line, col int) Pos {
 	}
 }
 
+// Pos is a position in the source. Line and Col are 1-based.
 type Pos struct {
 	Line int
 	Col  int
@@ -118,22 +170,31 @@ func ComparePos(x, y Pos) int {
 	return -1
 }
 
+// Tokenizer splits SQL source into Tokens according to Dialect.
 type Tokenizer struct {
 	Dialect      dialect.Dialect
 	Scanner      *scanner.Scanner
 	Line         int
 	Col          int
 	parseComment bool
+	operators    map[string]Kind // operators defined by Dialect
+	src          *bytes.Buffer   // source read by Scanner, used for Token.Raw
+	pending      *Token          // token read ahead by next, returned by the following Scan
+	queue        []*Token        // tokens read ahead by Scan, returned before pending
+	offset       int             // offset of the token being read by next
 }
 
+// NewTokenizer returns a Tokenizer which reads src. Whitespace and comments are returned as tokens.
 func NewTokenizer(src io.Reader, dialect dialect.Dialect) *Tokenizer {
 	var scan scanner.Scanner
+	var buf bytes.Buffer
 	return &Tokenizer{
 		Dialect:      dialect,
-		Scanner:      scan.Init(src),
+		Scanner:      scan.Init(io.TeeReader(src, &buf)),
 		Line:         1,
 		Col:          1,
 		parseComment: true,
+		src:          &buf,
 	}
 }
 
@@ -159,34 +220,34 @@ func NewTokenizerWithOptions(src io.Reader, options ...TokenizerOption) *Tokeniz
 	return tokenizer
 }
 
+// Tokenize reads all tokens until EOF.
 func (t *Tokenizer) Tokenize() ([]*Token, error) {
-	var tokenset []*Token
-
-	for {
-		t, err := t.NextToken()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-
-		if t == nil {
-			continue
-		}
-		tokenset = append(tokenset, t)
-	}
-
-	return tokenset, nil
+	return t.TokenizeIn(&TokenArena{})
 }
 
+// NextToken reads the next token. It returns io.EOF at the end of the source,
+// and nil without error for a skipped whitespace or comment (see DisableParseComment).
 func (t *Tokenizer) NextToken() (*Token, error) {
 	var tok Token
 	return t.Scan(&tok)
 }
 
+// Scan is like NextToken but reads the token into token to avoid allocation.
 func (t *Tokenizer) Scan(token *Token) (*Token, error) {
+	if len(t.queue) != 0 {
+		*token = *t.queue[0]
+		t.queue = t.queue[1:]
+		return token, nil
+	}
+	if t.pending != nil {
+		*token = *t.pending
+		t.pending = nil
+		return token, nil
+	}
+
 	pos := t.Pos()
+	offset := t.Scanner.Pos().Offset
+	t.offset = offset
 	tok, str, err := t.next()
 	if err == io.EOF {
 		return nil, io.EOF
@@ -196,6 +257,7 @@ func (t *Tokenizer) Scan(token *Token) (*Token, error) {
 		token.Value = ""
 		token.From = pos
 		token.To = t.Pos()
+		token.Offset = offset
 		return token, errors.Errorf("tokenize failed: %w", err)
 	}
 
@@ -203,13 +265,128 @@ func (t *Tokenizer) Scan(token *Token) (*Token, error) {
 		return nil, nil
 	}
 
+	end := t.Scanner.Pos().Offset
 	token.Kind = tok
 	token.Value = str
 	token.From = pos
 	token.To = t.Pos()
+	if t.pending != nil {
+		end -= len(t.pending.Raw)
+		token.To = t.pending.From
+		t.pending.Offset = end
+	}
+	token.Raw = rawString(t.src.Bytes()[offset:end], str)
+	token.Offset = offset
+	if lit, ok := str.(*unicodeLiteral); ok {
+		if err := t.uescape(token, lit); err != nil {
+			token.Kind = ILLEGAL
+			token.Value = ""
+			return token, errors.Errorf("tokenize failed: %w", err)
+		}
+	}
 	return token, nil
 }
 
+// unicodeLiteral is the body of U&"..." or U&'...' returned by next. It is
+// decoded by Scan after UESCAPE following it is read.
+type unicodeLiteral struct {
+	body  string
+	ident bool
+}
+
+// uescape reads `UESCAPE 'c'` following token, a U&"..." identifier or a
+// U&'...' string, and sets the decoded value to token. The tokens read ahead
+// are returned by the following Scan.
+func (t *Tokenizer) uescape(token *Token, lit *unicodeLiteral) error {
+	escape := '\\'
+	var ahead []*Token
+	for {
+		next, err := t.Scan(&Token{})
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			continue
+		}
+		if next.Kind == Whitespace || next.Kind == Comment {
+			ahead = append(ahead, next)
+			continue
+		}
+		if w, ok := next.Value.(*SQLWord); !ok || w.QuoteStyle != 0 || !strings.EqualFold(w.Value, "UESCAPE") {
+			ahead = append(ahead, next)
+			break
+		}
+		c, err := t.uescapeChar()
+		if err != nil {
+			return err
+		}
+		escape = c
+		ahead = nil
+		token.To = t.Pos()
+		token.Raw = string(t.src.Bytes()[token.Offset:t.Scanner.Pos().Offset])
+		break
+	}
+	t.queue = append(ahead, t.queue...)
+
+	s, err := decodeUnicodeEscape(lit.body, escape)
+	if lit.ident {
+		if err != nil {
+			return errors.Errorf("invalid unicode identifier at %+v: %w", token.From, err)
+		}
+		token.Value = &SQLWord{Value: s, QuoteStyle: '"', UnicodeEscape: escape}
+		return nil
+	}
+	if err != nil {
+		return errors.Errorf("invalid unicode string at %+v: %w", token.From, err)
+	}
+	token.Value = &UnicodeStringValue{Value: s, Escape: escape}
+	return nil
+}
+
+// uescapeChar reads the escape character in quotes after UESCAPE.
+func (t *Tokenizer) uescapeChar() (rune, error) {
+	for {
+		tok, err := t.Scan(&Token{})
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		if tok != nil && (tok.Kind == Whitespace || tok.Kind == Comment) {
+			continue
+		}
+		if tok == nil && err == nil {
+			continue
+		}
+		if tok == nil || tok.Kind != SingleQuotedString || utf8.RuneCountInString(tok.Value.(string)) != 1 {
+			return 0, errors.Errorf("expected a single character string after UESCAPE but %v", tok)
+		}
+		c, _ := utf8.DecodeRuneInString(tok.Value.(string))
+		if isDigit(c, 16) || c == '+' || c == '\'' || c == '"' || unicode.IsSpace(c) {
+			return 0, errors.Errorf("invalid UESCAPE character %q at %+v", c, tok.From)
+		}
+		return c, nil
+	}
+}
+
+// rawString returns raw as a string. It reuses value instead of allocating
+// if value is the same string, e.g. for unquoted words and numbers.
+func rawString(raw []byte, value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if v == string(raw) {
+			return v
+		}
+	case *SQLWord:
+		if v.QuoteStyle == 0 && v.Value == string(raw) {
+			return v.Value
+		}
+	}
+	return string(raw)
+}
+
+// Pos returns the position of the next character to be read.
 func (t *Tokenizer) Pos() Pos {
 	return Pos{
 		Line: t.Line,
@@ -251,93 +428,153 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 		n := t.Scanner.Peek()
 		if n == '\'' {
 			t.Col += 1
-			str, err := t.tokenizeSingleQuotedString()
+			str, err := t.tokenizeSingleQuotedString(t.stringEscape())
 			if err != nil {
 				return ILLEGAL, "", err
 			}
 			return NationalStringLiteral, str, nil
 		}
-		s := t.tokenizeWord('N')
-		v := MakeKeyword(s, 0)
-		return SQLKeyword, v, nil
+		return SQLKeyword, t.tokenizeWord(), nil
+
+	case 'E' == r || 'e' == r:
+		t.Scanner.Next()
+		if t.Scanner.Peek() == '\'' {
+			t.Col += 1
+			str, err := t.tokenizeSingleQuotedString(t.tokenizeEscapeSequence)
+			if err != nil {
+				return ILLEGAL, "", err
+			}
+			return EscapedStringLiteral, str, nil
+		}
+		return SQLKeyword, t.tokenizeWord(), nil
+
+	case 'X' == r || 'x' == r || 'B' == r || 'b' == r:
+		t.Scanner.Next()
+		if t.Scanner.Peek() == '\'' {
+			t.Col += 1
+			str, err := t.tokenizeSingleQuotedString(nil)
+			if err != nil {
+				return ILLEGAL, "", err
+			}
+			if r == 'X' || r == 'x' {
+				if !isDigits(str, 16) {
+					return ILLEGAL, "", errors.Errorf("invalid hexadecimal string: %s at %+v", str, t.Pos())
+				}
+				return HexStringLiteral, str, nil
+			}
+			if !isDigits(str, 2) {
+				return ILLEGAL, "", errors.Errorf("invalid bit string: %s at %+v", str, t.Pos())
+			}
+			return BitStringLiteral, str, nil
+		}
+		return SQLKeyword, t.tokenizeWord(), nil
+
+	case '@' == r:
+		t.Scanner.Next()
+		if _, ok := t.operatorKind(appendRune([]byte{'@'}, t.Scanner.Peek())); !ok && t.Dialect.IsIdentifierStart(r) {
+			return SQLKeyword, t.tokenizeWord(), nil
+		}
+		return t.tokenizeOperator(r)
+
+	case ('U' == r || 'u' == r) && t.unicodeEscape():
+		t.Scanner.Next()
+		if t.Scanner.Peek() != '&' {
+			return SQLKeyword, t.tokenizeWord(), nil
+		}
+		t.Scanner.Next()
+		quote := t.Scanner.Peek()
+		if quote != '"' && quote != '\'' {
+			// neither an identifier nor a string but `U & ...`
+			t.Col += 1
+			t.pending = &Token{
+				Kind:  Ampersand,
+				Value: "&",
+				From:  t.Pos(),
+				To:    Pos{Line: t.Line, Col: t.Col + 1},
+				Raw:   "&",
+			}
+			t.Col += 1
+			return SQLKeyword, MakeKeyword(string(r), 0), nil
+		}
+		t.Col += 2
+		if quote == '\'' {
+			s, err := t.tokenizeSingleQuotedString(nil)
+			if err != nil {
+				return ILLEGAL, "", err
+			}
+			return UnicodeStringLiteral, &unicodeLiteral{body: s}, nil
+		}
+		s, err := t.tokenizeDelimitedIdentifier()
+		if err != nil {
+			return ILLEGAL, "", err
+		}
+		return SQLKeyword, &unicodeLiteral{body: s, ident: true}, nil
 
 	case t.Dialect.IsIdentifierStart(r):
 		t.Scanner.Next()
-		s := t.tokenizeWord(r)
-		return SQLKeyword, MakeKeyword(s, 0), nil
+		return SQLKeyword, t.tokenizeWord(), nil
 
 	case '\'' == r:
-		s, err := t.tokenizeSingleQuotedString()
+		s, err := t.tokenizeSingleQuotedString(t.stringEscape())
 		if err != nil {
 			return ILLEGAL, "", err
 		}
 		return SingleQuotedString, s, nil
 
 	case t.Dialect.IsDelimitedIdentifierStart(r):
-		t.Scanner.Next()
-		end := matchingEndQuote(r)
-
-		var s []rune
-		for {
-			n := t.Scanner.Next()
-			if n == end {
-				break
-			}
-			s = append(s, n)
+		s, err := t.tokenizeDelimitedIdentifier()
+		if err != nil {
+			return ILLEGAL, "", err
 		}
-		t.Col += 2 + len(s)
-
-		return SQLKeyword, MakeKeyword(string(s), r), nil
+		return SQLKeyword, MakeKeyword(s, r), nil
 
 	case '0' <= r && r <= '9':
-		var s []rune
-		for {
-			n := t.Scanner.Peek()
-			if ('0' <= n && n <= '9') || n == '.' {
-				s = append(s, n)
-				t.Scanner.Next()
-			} else {
-				break
+		t.Scanner.Next()
+		if n := t.Scanner.Peek(); r == '0' && (n == 'x' || n == 'X') {
+			t.Scanner.Next()
+			t.Col += 2
+			s := t.tokenizeDigits(16, 0)
+			if s == "" {
+				return ILLEGAL, "", errors.Errorf("invalid hexadecimal number at %+v", t.Pos())
 			}
+			return HexNumber, s, nil
 		}
-		t.Col += len(s)
-		return Number, string(s), nil
 
-	case '(' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return LParen, "(", nil
+		return t.tokenizeNumber()
 
-	case ')' == r:
+	case '.' == r:
 		t.Scanner.Next()
-		t.Col += 1
-		return RParen, ")", nil
+		// a number without the integer part i.e: .5
+		if n := t.Scanner.Peek(); '0' <= n && n <= '9' {
+			return t.tokenizeNumber()
+		}
+		return t.tokenizeOperator(r)
 
-	case ',' == r:
+	case '$' == r:
 		t.Scanner.Next()
-		t.Col += 1
-		return Comma, ",", nil
+		if n := t.Scanner.Peek(); n == '$' || isDollarTagStart(n) {
+			v, err := t.tokenizeDollarQuotedString()
+			if err != nil {
+				return ILLEGAL, "", err
+			}
+			return DollarQuotedString, v, nil
+		}
+		return t.tokenizeOperator(r)
 
 	case '-' == r:
 		t.Scanner.Next()
 
 		if '-' == t.Scanner.Peek() {
 			t.Scanner.Next()
-
-			var s []rune
-			for {
-				ch := t.Scanner.Peek()
-				if ch != scanner.EOF && ch != '\n' {
-					t.Scanner.Next()
-					s = append(s, ch)
-				} else {
-					t.Col += len(s) + 2
-					return Comment, string(s), nil // Comment Node
-				}
-			}
+			t.Col += 2
+			return Comment, t.tokenizeLineComment(), nil
 		}
+		return t.tokenizeOperator(r)
+
+	case '#' == r && t.hashComment():
+		t.Scanner.Next()
 		t.Col += 1
-		return Minus, "-", nil
+		return Comment, t.tokenizeLineComment(), nil
 
 	case '/' == r:
 		t.Scanner.Next()
@@ -350,159 +587,409 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 			}
 			return Comment, str, nil
 		}
-		t.Col += 1
-		return Div, "/", nil
+		return t.tokenizeOperator(r)
 
-	case '+' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return Plus, "+", nil
-	case '*' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return Mult, "*", nil
-	case '%' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return Mod, "%", nil
-	case '=' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return Eq, "=", nil
-	case '.' == r:
+	case scanner.EOF == r:
+		return ILLEGAL, "", io.EOF
+	default:
 		t.Scanner.Next()
-		t.Col += 1
-		return Period, ".", nil
+		return t.tokenizeOperator(r)
+	}
+}
 
-	case '!' == r:
-		t.Scanner.Next()
+// tokenizeNumber reads the rest of a number, whose first rune is consumed.
+func (t *Tokenizer) tokenizeNumber() (Kind, interface{}, error) {
+	// the number is sliced from the source, in which every character is a byte
+	from := t.Scanner.Pos().Offset - 1
+	// a number has at most one dot i.e: 1.2.3 is 1.2 followed by .3
+	dot := t.src.Bytes()[from] == '.'
+	for {
 		n := t.Scanner.Peek()
-		if n == '=' {
-			t.Scanner.Next()
-			t.Col += 2
-			return Neq, "!=", nil
-		}
-		return ILLEGAL, "", errors.Errorf("tokenizer error: illegal sequence %s%s", string(r), string(n))
-
-	case '<' == r:
-		t.Scanner.Next()
-		switch t.Scanner.Peek() {
-		case '=':
+		if n == '.' && !dot {
+			dot = true
 			t.Scanner.Next()
-			t.Col += 2
-			return LtEq, "<=", nil
-		case '>':
+		} else if '0' <= n && n <= '9' {
 			t.Scanner.Next()
-			t.Col += 2
-			return Neq, "<>", nil
-		default:
-			t.Col += 1
-			return Lt, "<", nil
+		} else {
+			break
 		}
-	case '>' == r:
+	}
+	// exponent i.e: 1e10, 1.5E-3
+	if n := t.Scanner.Peek(); n == 'e' || n == 'E' {
 		t.Scanner.Next()
-		switch t.Scanner.Peek() {
-		case '=':
+		if n := t.Scanner.Peek(); n == '+' || n == '-' {
 			t.Scanner.Next()
-			t.Col += 2
-			return GtEq, ">=", nil
-		default:
-			t.Col += 1
-			return Gt, ">", nil
 		}
-	case ':' == r:
-		t.Scanner.Next()
-		n := t.Scanner.Peek()
-		if n == ':' {
-			t.Scanner.Next()
-			t.Col += 2
-			return DoubleColon, "::", nil
+		t.Col += t.Scanner.Pos().Offset - from
+		if exp := t.tokenizeDigits(10, 0); exp == "" {
+			return ILLEGAL, "", errors.Errorf("invalid exponent of number %s at %+v", t.src.Bytes()[from:t.Scanner.Pos().Offset], t.Pos())
 		}
-		t.Col += 1
-		return Colon, ":", nil
-	case ';' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return Semicolon, ";", nil
-	case '\\' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return Backslash, "\\", nil
-	case '[' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return LBracket, "[", nil
-	case ']' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return RBracket, "]", nil
-	case '&' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return Ampersand, "&", nil
-	case '{' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return LBrace, "{", nil
-	case '}' == r:
-		t.Scanner.Next()
-		t.Col += 1
-		return RBrace, "}", nil
-	case scanner.EOF == r:
-		return ILLEGAL, "", io.EOF
-	default:
-		t.Scanner.Next()
-		t.Col += 1
-		return Char, string(r), nil
+	} else {
+		t.Col += t.Scanner.Pos().Offset - from
 	}
+	return Number, string(t.src.Bytes()[from:t.Scanner.Pos().Offset]), nil
 }
 
-func (t *Tokenizer) tokenizeWord(f rune) string {
-	var builder strings.Builder
-	builder.WriteRune(f)
+// tokenizeWord reads the rest of an unquoted word, whose first rune is consumed.
+func (t *Tokenizer) tokenizeWord() *SQLWord {
+	t.Col += 1
 	for {
 		r := t.Scanner.Peek()
-		if t.Dialect.IsIdentifierPart(r) {
-			t.Scanner.Next()
-			builder.WriteRune(r)
-		} else {
+		if !t.Dialect.IsIdentifierPart(r) {
 			break
 		}
+		t.Scanner.Next()
+		t.Col += 1
 	}
 
-	str := builder.String()
-	t.Col += len(str)
-	return str
+	word := t.src.Bytes()[t.offset:t.Scanner.Pos().Offset]
+	// keywords are looked up without allocating a string
+	if w, ok := keywordCache[string(word)]; ok {
+		return w
+	}
+	return MakeKeyword(string(word), 0)
 }
 
-func (t *Tokenizer) tokenizeSingleQuotedString() (string, error) {
+// tokenizeSingleQuotedString reads a single quoted string. Doubled quotes are
+// unescaped and, if escape is true, backslash escape sequences are interpreted.
+func (t *Tokenizer) tokenizeSingleQuotedString(escape func() (string, error)) (string, error) {
 	var builder strings.Builder
 	t.Scanner.Next()
+	t.Col += 1
+	// Until the first escape, the string is sliced from the source instead
+	// of written to builder.
+	from, sliced := t.Scanner.Pos().Offset, true
+	unslice := func(end int) {
+		if sliced {
+			builder.Write(t.src.Bytes()[from:end])
+			sliced = false
+		}
+	}
 	for {
+		end := t.Scanner.Pos().Offset
 		n := t.Scanner.Peek()
-		if n == '\'' {
+		if n == scanner.EOF {
+			unslice(end)
+			return "", errors.Errorf("unclosed single quoted string: %s at %+v", builder.String(), t.Pos())
+		}
+		t.Scanner.Next()
+		t.advance(n)
+
+		switch {
+		case n == '\'':
+			if t.Scanner.Peek() != '\'' {
+				if sliced {
+					return string(t.src.Bytes()[from:end]), nil
+				}
+				return builder.String(), nil
+			}
+			unslice(end)
 			t.Scanner.Next()
-			if t.Scanner.Peek() == '\'' {
-				// str = append(str, '\'')
-				builder.WriteRune('\'')
-				t.Scanner.Next()
-			} else {
-				break
+			t.advance('\'')
+			builder.WriteRune('\'')
+		case n == '\\' && escape != nil:
+			unslice(end)
+			str, err := escape()
+			if err != nil {
+				return "", err
 			}
-			continue
+			builder.WriteString(str)
+		case n == utf8.RuneError:
+			// an invalid byte is replaced by U+FFFD as text/scanner does
+			unslice(end)
+			builder.WriteRune(n)
+		default:
+			if !sliced {
+				builder.WriteRune(n)
+			}
+		}
+	}
+}
+
+// tokenizeEscapeSequence reads an escape sequence after the backslash, i.e: \n, \x41, \u00e9.
+func (t *Tokenizer) tokenizeEscapeSequence() (string, error) {
+	c := t.Scanner.Next()
+	if c == scanner.EOF {
+		return "", errors.Errorf("unterminated escape sequence at %+v", t.Pos())
+	}
+	t.advance(c)
+
+	switch c {
+	case 'b':
+		return "\b", nil
+	case 'f':
+		return "\f", nil
+	case 'n':
+		return "\n", nil
+	case 'r':
+		return "\r", nil
+	case 't':
+		return "\t", nil
+	case 'x':
+		d := t.tokenizeDigits(16, 2)
+		if d == "" {
+			return "x", nil
+		}
+		v, _ := strconv.ParseUint(d, 16, 8)
+		return string([]byte{byte(v)}), nil
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		d := t.tokenizeDigits(16, size)
+		if len(d) != size {
+			return "", errors.Errorf("invalid unicode escape sequence \\%c%s at %+v", c, d, t.Pos())
+		}
+		v, _ := strconv.ParseUint(d, 16, 32)
+		return string(rune(v)), nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		d := string(c) + t.tokenizeDigits(8, 2)
+		v, _ := strconv.ParseUint(d, 8, 8)
+		return string([]byte{byte(v)}), nil
+	}
+	return string(c), nil
+}
+
+// tokenizeLineComment reads the rest of a comment to the end of the line.
+func (t *Tokenizer) tokenizeLineComment() string {
+	var s []rune
+	for {
+		ch := t.Scanner.Peek()
+		if ch == scanner.EOF || ch == '\n' {
+			t.Col += len(s)
+			return string(s)
 		}
+		t.Scanner.Next()
+		s = append(s, ch)
+	}
+}
+
+func (t *Tokenizer) hashComment() bool {
+	d, ok := t.Dialect.(dialect.HashCommentDialect)
+	return ok && d.HashComment()
+}
+
+// stringEscape returns the function reading backslash escapes in ordinary
+// string literals of the dialect, or nil if they aren't interpreted.
+func (t *Tokenizer) stringEscape() func() (string, error) {
+	if !t.backslashEscape() {
+		return nil
+	}
+	return t.tokenizeMySQLEscapeSequence
+}
+
+// tokenizeMySQLEscapeSequence reads an escape sequence of MySQL after the
+// backslash. \% and \_ are kept as they are for LIKE, and the backslash of
+// the other unknown sequences is ignored.
+func (t *Tokenizer) tokenizeMySQLEscapeSequence() (string, error) {
+	c := t.Scanner.Next()
+	if c == scanner.EOF {
+		return "", errors.Errorf("unterminated escape sequence at %+v", t.Pos())
+	}
+	t.advance(c)
+
+	switch c {
+	case '0':
+		return "\x00", nil
+	case 'b':
+		return "\b", nil
+	case 'n':
+		return "\n", nil
+	case 'r':
+		return "\r", nil
+	case 't':
+		return "\t", nil
+	case 'Z':
+		return "\x1a", nil
+	case '%', '_':
+		return "\\" + string(c), nil
+	}
+	return string(c), nil
+}
+
+// tokenizeDelimitedIdentifier reads a quoted identifier, i.e: "My Table".
+// A doubled closing quote stands for the quote itself.
+func (t *Tokenizer) tokenizeDelimitedIdentifier() (string, error) {
+	start := t.Scanner.Next()
+	t.Col += 1
+	end := matchingEndQuote(start)
+
+	var builder strings.Builder
+	for {
+		n := t.Scanner.Next()
 		if n == scanner.EOF {
-			return "", errors.Errorf("unclosed single quoted string: %s at %+v", builder.String(), t.Pos())
+			return "", errors.Errorf("unclosed quoted identifier: %s at %+v", builder.String(), t.Pos())
 		}
+		t.advance(n)
+		if n == end {
+			if t.Scanner.Peek() != end {
+				return builder.String(), nil
+			}
+			t.Scanner.Next()
+			t.advance(end)
+		}
+		builder.WriteRune(n)
+	}
+}
 
+// decodeUnicodeEscape interprets escapes in the body of U&"..." or U&'...'
+// of the escape character, i.e: \0061 (4 hex digits), \+000061 (6 hex digits)
+// and \\ if escape is a backslash.
+func decodeUnicodeEscape(s string, escape rune) (string, error) {
+	e := string(escape)
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		if !strings.HasPrefix(s[i:], e) {
+			builder.WriteByte(s[i])
+			continue
+		}
+		i += len(e) - 1
+		if strings.HasPrefix(s[i+1:], e) {
+			builder.WriteString(e)
+			i += len(e)
+			continue
+		}
+		size := 4
+		if i+1 < len(s) && s[i+1] == '+' {
+			size = 6
+			i++
+		}
+		if i+size >= len(s) || !isDigits(s[i+1:i+1+size], 16) {
+			return "", errors.Errorf("invalid unicode escape in %q", s)
+		}
+		v, _ := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+		if !utf8.ValidRune(rune(v)) {
+			return "", errors.Errorf("invalid unicode code point %X", v)
+		}
+		builder.WriteRune(rune(v))
+		i += size
+	}
+	return builder.String(), nil
+}
+
+func (t *Tokenizer) unicodeEscape() bool {
+	d, ok := t.Dialect.(dialect.UnicodeEscapeDialect)
+	return ok && d.UnicodeEscape()
+}
+
+func (t *Tokenizer) advance(r rune) {
+	if r == '\n' {
+		t.Line += 1
+		t.Col = 1
+		return
+	}
+	t.Col += 1
+}
+
+func (t *Tokenizer) backslashEscape() bool {
+	d, ok := t.Dialect.(dialect.StringEscapeDialect)
+	return ok && d.BackslashEscape()
+}
+
+func isDigit(r rune, base int) bool {
+	switch {
+	case '0' <= r && r <= '1':
+		return true
+	case '2' <= r && r <= '9':
+		return base >= 10
+	case 'a' <= r && r <= 'f', 'A' <= r && r <= 'F':
+		return base == 16
+	}
+	return false
+}
+
+func isDigits(s string, base int) bool {
+	for _, r := range s {
+		if !isDigit(r, base) {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenizeDigits reads at most max (unlimited if max <= 0) digits of base.
+func (t *Tokenizer) tokenizeDigits(base, max int) string {
+	var builder strings.Builder
+	for max <= 0 || builder.Len() < max {
+		n := t.Scanner.Peek()
+		if !isDigit(n, base) {
+			break
+		}
 		t.Scanner.Next()
 		builder.WriteRune(n)
-		// str = append(str, n)
 	}
-	str := builder.String()
-	t.Col += 2 + len(str)
+	t.Col += builder.Len()
+	return builder.String()
+}
+
+// DollarQuotedValue is the value of DollarQuotedString token.
+type DollarQuotedValue struct {
+	Tag   string
+	Value string
+}
+
+func (d *DollarQuotedValue) String() string {
+	return "$" + d.Tag + "$" + d.Value + "$" + d.Tag + "$"
+}
+
+// UnicodeStringValue is the value of UnicodeStringLiteral token.
+type UnicodeStringValue struct {
+	Value  string
+	Escape rune // '\\' or the one given by UESCAPE
+}
+
+func (u *UnicodeStringValue) String() string {
+	return QuoteUnicodeEscape(u.Value, '\'', u.Escape)
+}
+
+func isDollarTagStart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || r > unicode.MaxASCII
+}
+
+func isDollarTagPart(r rune) bool {
+	return isDollarTagStart(r) || (r >= '0' && r <= '9')
+}
+
+// tokenizeDollarQuotedString reads $tag$...$tag$ after the first `$` is consumed.
+func (t *Tokenizer) tokenizeDollarQuotedString() (*DollarQuotedValue, error) {
+	var tag strings.Builder
+	for {
+		n := t.Scanner.Peek()
+		if n == '$' {
+			t.Scanner.Next()
+			break
+		}
+		if !isDollarTagPart(n) {
+			return nil, errors.Errorf("invalid dollar quote tag: $%s at %+v", tag.String(), t.Pos())
+		}
+		t.Scanner.Next()
+		tag.WriteRune(n)
+	}
+	t.Col += 2 + utf8.RuneCountInString(tag.String())
 
-	return str, nil
+	delim := "$" + tag.String() + "$"
+	var body strings.Builder
+	for {
+		n := t.Scanner.Next()
+		if n == scanner.EOF {
+			return nil, errors.Errorf("unclosed dollar-quoted string: %s at %+v", body.String(), t.Pos())
+		}
+		if n == '\n' {
+			t.Line += 1
+			t.Col = 1
+		} else {
+			t.Col += 1
+		}
+		body.WriteRune(n)
+		if n == '$' && strings.HasSuffix(body.String(), delim) {
+			str := body.String()
+			return &DollarQuotedValue{
+				Tag:   tag.String(),
+				Value: str[:len(str)-len(delim)],
+			}, nil
+		}
+	}
 }
 
 func (t *Tokenizer) tokenizeMultilineComment() (string, error) {
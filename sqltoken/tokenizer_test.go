@@ -3,6 +3,8 @@ package sqltoken
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -14,9 +16,10 @@ import (
 
 func TestTokenizer_Tokenize(t *testing.T) {
 	cases := []struct {
-		name string
-		in   string
-		out  []*Token
+		name    string
+		dialect dialect.Dialect // GenericSQLDialect if nil
+		in      string
+		out     []*Token
 	}{
 		{
 			name: "whitespace",
@@ -141,7 +144,6 @@ func TestTokenizer_Tokenize(t *testing.T) {
 					Kind: SQLKeyword,
 					Value: &SQLWord{
 						Value:      "SELECT",
-						Keyword:    "SELECT",
 						QuoteStyle: '"',
 					},
 					From: Pos{Line: 1, Col: 1},
@@ -339,7 +341,7 @@ comment */`,
 		},
 		{
 			name: "Lts",
-			in:   "<<=<>",
+			in:   "< <=<>",
 			out: []*Token{
 				{
 					Kind:  Lt,
@@ -347,23 +349,29 @@ comment */`,
 					From:  Pos{Line: 1, Col: 1},
 					To:    Pos{Line: 1, Col: 2},
 				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 2},
+					To:    Pos{Line: 1, Col: 3},
+				},
 				{
 					Kind:  LtEq,
 					Value: "<=",
-					From:  Pos{Line: 1, Col: 2},
-					To:    Pos{Line: 1, Col: 4},
+					From:  Pos{Line: 1, Col: 3},
+					To:    Pos{Line: 1, Col: 5},
 				},
 				{
 					Kind:  Neq,
 					Value: "<>",
-					From:  Pos{Line: 1, Col: 4},
-					To:    Pos{Line: 1, Col: 6},
+					From:  Pos{Line: 1, Col: 5},
+					To:    Pos{Line: 1, Col: 7},
 				},
 			},
 		},
 		{
 			name: "Gts",
-			in:   ">>=",
+			in:   "> >=",
 			out: []*Token{
 				{
 					Kind:  Gt,
@@ -371,17 +379,209 @@ comment */`,
 					From:  Pos{Line: 1, Col: 1},
 					To:    Pos{Line: 1, Col: 2},
 				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 2},
+					To:    Pos{Line: 1, Col: 3},
+				},
 				{
 					Kind:  GtEq,
 					Value: ">=",
+					From:  Pos{Line: 1, Col: 3},
+					To:    Pos{Line: 1, Col: 5},
+				},
+			},
+		},
+		{
+			name: "numeric literals",
+			in:   "0xFF X'ab' b'10' 1.5E-3",
+			out: []*Token{
+				{
+					Kind:  HexNumber,
+					Value: "FF",
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 5},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 5},
+					To:    Pos{Line: 1, Col: 6},
+				},
+				{
+					Kind:  HexStringLiteral,
+					Value: "ab",
+					From:  Pos{Line: 1, Col: 6},
+					To:    Pos{Line: 1, Col: 11},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 11},
+					To:    Pos{Line: 1, Col: 12},
+				},
+				{
+					Kind:  BitStringLiteral,
+					Value: "10",
+					From:  Pos{Line: 1, Col: 12},
+					To:    Pos{Line: 1, Col: 17},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 17},
+					To:    Pos{Line: 1, Col: 18},
+				},
+				{
+					Kind:  Number,
+					Value: "1.5E-3",
+					From:  Pos{Line: 1, Col: 18},
+					To:    Pos{Line: 1, Col: 24},
+				},
+			},
+		},
+		{
+			name: "number without integer part",
+			in:   "t.a.5",
+			out: []*Token{
+				{
+					Kind:  SQLKeyword,
+					Value: MakeKeyword("t", 0),
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 2},
+				},
+				{
+					Kind:  Period,
+					Value: ".",
 					From:  Pos{Line: 1, Col: 2},
+					To:    Pos{Line: 1, Col: 3},
+				},
+				{
+					Kind:  SQLKeyword,
+					Value: MakeKeyword("a", 0),
+					From:  Pos{Line: 1, Col: 3},
+					To:    Pos{Line: 1, Col: 4},
+				},
+				{
+					Kind:  Number,
+					Value: ".5",
+					From:  Pos{Line: 1, Col: 4},
+					To:    Pos{Line: 1, Col: 6},
+				},
+			},
+		},
+		{
+			name: "number with two dots",
+			in:   "1.2.3",
+			out: []*Token{
+				{
+					Kind:  Number,
+					Value: "1.2",
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 4},
+				},
+				{
+					Kind:  Number,
+					Value: ".3",
+					From:  Pos{Line: 1, Col: 4},
+					To:    Pos{Line: 1, Col: 6},
+				},
+			},
+		},
+		{
+			name: "dollar quoted string",
+			in:   "$$it's$$ $fn$a\n$$b$fn$",
+			out: []*Token{
+				{
+					Kind:  DollarQuotedString,
+					Value: &DollarQuotedValue{Value: "it's"},
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 9},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 9},
+					To:    Pos{Line: 1, Col: 10},
+				},
+				{
+					Kind:  DollarQuotedString,
+					Value: &DollarQuotedValue{Tag: "fn", Value: "a\n$$b"},
+					From:  Pos{Line: 1, Col: 10},
+					To:    Pos{Line: 2, Col: 8},
+				},
+			},
+		},
+		{
+			name: "maximal munch operators",
+			in:   "<<=||>>=>|",
+			out: []*Token{
+				{
+					Kind:  ShiftLeft,
+					Value: "<<",
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 3},
+				},
+				{
+					Kind:  Eq,
+					Value: "=",
+					From:  Pos{Line: 1, Col: 3},
+					To:    Pos{Line: 1, Col: 4},
+				},
+				{
+					Kind:  StringConcat,
+					Value: "||",
+					From:  Pos{Line: 1, Col: 4},
+					To:    Pos{Line: 1, Col: 6},
+				},
+				{
+					Kind:  ShiftRight,
+					Value: ">>",
+					From:  Pos{Line: 1, Col: 6},
+					To:    Pos{Line: 1, Col: 8},
+				},
+				{
+					Kind:  RArrow,
+					Value: "=>",
+					From:  Pos{Line: 1, Col: 8},
+					To:    Pos{Line: 1, Col: 10},
+				},
+				{
+					Kind:  Pipe,
+					Value: "|",
+					From:  Pos{Line: 1, Col: 10},
+					To:    Pos{Line: 1, Col: 11},
+				},
+			},
+		},
+		{
+			name: "json operators in generic dialect",
+			in:   "->#",
+			out: []*Token{
+				{
+					Kind:  Minus,
+					Value: "-",
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 2},
+				},
+				{
+					Kind:  Gt,
+					Value: ">",
+					From:  Pos{Line: 1, Col: 2},
+					To:    Pos{Line: 1, Col: 3},
+				},
+				{
+					Kind:  Char,
+					Value: "#",
+					From:  Pos{Line: 1, Col: 3},
 					To:    Pos{Line: 1, Col: 4},
 				},
 			},
 		},
 		{
 			name: "colons",
-			in:   ":1::1;",
+			in:   ":1::1:=;",
 			out: []*Token{
 				{
 					Kind:  Colon,
@@ -407,12 +607,54 @@ comment */`,
 					From:  Pos{Line: 1, Col: 5},
 					To:    Pos{Line: 1, Col: 6},
 				},
+				{
+					Kind:  ColonEq,
+					Value: ":=",
+					From:  Pos{Line: 1, Col: 6},
+					To:    Pos{Line: 1, Col: 8},
+				},
 				{
 					Kind:  Semicolon,
 					Value: ";",
-					From:  Pos{Line: 1, Col: 6},
+					From:  Pos{Line: 1, Col: 8},
+					To:    Pos{Line: 1, Col: 9},
+				},
+			},
+		},
+		{
+			name: "regex match operators",
+			in:   "~ ~*!~!~*",
+			out: []*Token{
+				{
+					Kind:  Tilde,
+					Value: "~",
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 2},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 2},
+					To:    Pos{Line: 1, Col: 3},
+				},
+				{
+					Kind:  TildeAsterisk,
+					Value: "~*",
+					From:  Pos{Line: 1, Col: 3},
+					To:    Pos{Line: 1, Col: 5},
+				},
+				{
+					Kind:  ExclamationMarkTilde,
+					Value: "!~",
+					From:  Pos{Line: 1, Col: 5},
 					To:    Pos{Line: 1, Col: 7},
 				},
+				{
+					Kind:  ExclamationMarkTildeAsterisk,
+					Value: "!~*",
+					From:  Pos{Line: 1, Col: 7},
+					To:    Pos{Line: 1, Col: 10},
+				},
 			},
 		},
 		{
@@ -457,12 +699,233 @@ comment */`,
 				},
 			},
 		},
+		{
+			name:    "json operators",
+			dialect: &dialect.PostgresqlDialect{},
+			in:      "->>->#>>#>@><@??|?&",
+			out: []*Token{
+				{
+					Kind:  LongArrow,
+					Value: "->>",
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 4},
+				},
+				{
+					Kind:  Arrow,
+					Value: "->",
+					From:  Pos{Line: 1, Col: 4},
+					To:    Pos{Line: 1, Col: 6},
+				},
+				{
+					Kind:  HashLongArrow,
+					Value: "#>>",
+					From:  Pos{Line: 1, Col: 6},
+					To:    Pos{Line: 1, Col: 9},
+				},
+				{
+					Kind:  HashArrow,
+					Value: "#>",
+					From:  Pos{Line: 1, Col: 9},
+					To:    Pos{Line: 1, Col: 11},
+				},
+				{
+					Kind:  AtArrow,
+					Value: "@>",
+					From:  Pos{Line: 1, Col: 11},
+					To:    Pos{Line: 1, Col: 13},
+				},
+				{
+					Kind:  ArrowAt,
+					Value: "<@",
+					From:  Pos{Line: 1, Col: 13},
+					To:    Pos{Line: 1, Col: 15},
+				},
+				{
+					Kind:  Question,
+					Value: "?",
+					From:  Pos{Line: 1, Col: 15},
+					To:    Pos{Line: 1, Col: 16},
+				},
+				{
+					Kind:  QuestionPipe,
+					Value: "?|",
+					From:  Pos{Line: 1, Col: 16},
+					To:    Pos{Line: 1, Col: 18},
+				},
+				{
+					Kind:  QuestionAnd,
+					Value: "?&",
+					From:  Pos{Line: 1, Col: 18},
+					To:    Pos{Line: 1, Col: 20},
+				},
+			},
+		},
+		{
+			name: "escaped strings",
+			in:   `'it''s' E'a\'b\n\x41\101\u00e9\q' 'c\n'`,
+			out: []*Token{
+				{
+					Kind:  SingleQuotedString,
+					Value: "it's",
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 8},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 8},
+					To:    Pos{Line: 1, Col: 9},
+				},
+				{
+					Kind:  EscapedStringLiteral,
+					Value: "a'b\nAAéq",
+					From:  Pos{Line: 1, Col: 9},
+					To:    Pos{Line: 1, Col: 34},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 34},
+					To:    Pos{Line: 1, Col: 35},
+				},
+				{
+					Kind:  SingleQuotedString,
+					Value: `c\n`,
+					From:  Pos{Line: 1, Col: 35},
+					To:    Pos{Line: 1, Col: 40},
+				},
+			},
+		},
+		{
+			name: "quoted identifiers",
+			in:   `"a""b" U&"d\0061t\+000061"`,
+			out: []*Token{
+				{
+					Kind:  SQLKeyword,
+					Value: MakeKeyword(`a"b`, '"'),
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 7},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 7},
+					To:    Pos{Line: 1, Col: 8},
+				},
+				{
+					Kind:  SQLKeyword,
+					Value: &SQLWord{Value: "data", QuoteStyle: '"', UnicodeEscape: '\\'},
+					From:  Pos{Line: 1, Col: 8},
+					To:    Pos{Line: 1, Col: 27},
+				},
+			},
+		},
+		{
+			name: "uescape",
+			in:   `U&"d!0061t" UESCAPE '!' U&'a\0062' x`,
+			out: []*Token{
+				{
+					Kind:  SQLKeyword,
+					Value: &SQLWord{Value: "dat", QuoteStyle: '"', UnicodeEscape: '!'},
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 24},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 24},
+					To:    Pos{Line: 1, Col: 25},
+				},
+				{
+					Kind:  UnicodeStringLiteral,
+					Value: &UnicodeStringValue{Value: "ab", Escape: '\\'},
+					From:  Pos{Line: 1, Col: 25},
+					To:    Pos{Line: 1, Col: 35},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 35},
+					To:    Pos{Line: 1, Col: 36},
+				},
+				{
+					Kind:  SQLKeyword,
+					Value: MakeKeyword("x", 0),
+					From:  Pos{Line: 1, Col: 36},
+					To:    Pos{Line: 1, Col: 37},
+				},
+			},
+		},
+		{
+			name: "unicode identifier and U &",
+			in:   "名前 u&1",
+			out: []*Token{
+				{
+					Kind:  SQLKeyword,
+					Value: MakeKeyword("名前", 0),
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 3},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 1, Col: 3},
+					To:    Pos{Line: 1, Col: 4},
+				},
+				{
+					Kind:  SQLKeyword,
+					Value: MakeKeyword("u", 0),
+					From:  Pos{Line: 1, Col: 4},
+					To:    Pos{Line: 1, Col: 5},
+				},
+				{
+					Kind:  Ampersand,
+					Value: "&",
+					From:  Pos{Line: 1, Col: 5},
+					To:    Pos{Line: 1, Col: 6},
+				},
+				{
+					Kind:  Number,
+					Value: "1",
+					From:  Pos{Line: 1, Col: 6},
+					To:    Pos{Line: 1, Col: 7},
+				},
+			},
+		},
+		{
+			name: "multiline string",
+			in:   "'a\nb' c",
+			out: []*Token{
+				{
+					Kind:  SingleQuotedString,
+					Value: "a\nb",
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 2, Col: 3},
+				},
+				{
+					Kind:  Whitespace,
+					Value: " ",
+					From:  Pos{Line: 2, Col: 3},
+					To:    Pos{Line: 2, Col: 4},
+				},
+				{
+					Kind:  SQLKeyword,
+					Value: MakeKeyword("c", 0),
+					From:  Pos{Line: 2, Col: 4},
+					To:    Pos{Line: 2, Col: 5},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			src := strings.NewReader(c.in)
-			tokenizer := NewTokenizer(src, &dialect.GenericSQLDialect{})
+			d := c.dialect
+			if d == nil {
+				d = &dialect.GenericSQLDialect{}
+			}
+			tokenizer := NewTokenizer(src, d)
 
 			tok, err := tokenizer.Tokenize()
 			if err != nil {
@@ -491,6 +954,141 @@ comment */`,
 	}
 }
 
+func TestTokenizer_DialectOperators(t *testing.T) {
+	tokenizer := NewTokenizer(strings.NewReader("a<=>b <= c"), &dialect.MySQLDialect{})
+	tok, err := tokenizer.Tokenize()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	var kinds []Kind
+	var values []string
+	for _, tk := range tok {
+		if tk.Kind == Whitespace || tk.Kind == SQLKeyword {
+			continue
+		}
+		kinds = append(kinds, tk.Kind)
+		values = append(values, tk.Value.(string))
+	}
+
+	if d := cmp.Diff([]Kind{CustomOperator, LtEq}, kinds); d != "" {
+		t.Errorf("diff %s", d)
+	}
+	if d := cmp.Diff([]string{"<=>", "<="}, values); d != "" {
+		t.Errorf("diff %s", d)
+	}
+}
+
+func TestTokenizer_Raw(t *testing.T) {
+	in := `SELECT E'a\tb', 'it''s' FROM "t"`
+	tokens, err := NewTokenizer(strings.NewReader(in), &dialect.GenericSQLDialect{}).Tokenize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw strings.Builder
+	for _, tok := range tokens {
+		raw.WriteString(tok.Raw)
+	}
+	if raw.String() != in {
+		t.Errorf("concatenated raw text must be same as input but %s", raw.String())
+	}
+
+	if d := cmp.Diff(`E'a\tb'`, tokens[2].Raw); d != "" {
+		t.Errorf("diff %s", d)
+	}
+	if d := cmp.Diff("a\tb", tokens[2].Value); d != "" {
+		t.Errorf("diff %s", d)
+	}
+	if d := cmp.Diff(`'it''s'`, tokens[5].Raw); d != "" {
+		t.Errorf("diff %s", d)
+	}
+}
+
+func TestTokenizer_UnicodeEscapeRaw(t *testing.T) {
+	in := `SELECT U&'a!0062' /* c */ UESCAPE '!', U&"c" FROM t`
+	tokens, err := NewTokenizer(strings.NewReader(in), &dialect.PostgresqlDialect{}).Tokenize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw strings.Builder
+	for _, tok := range tokens {
+		if d := cmp.Diff(tok.Raw, in[tok.Offset:tok.EndOffset()]); d != "" {
+			t.Errorf("diff %s", d)
+		}
+		raw.WriteString(tok.Raw)
+	}
+	if raw.String() != in {
+		t.Errorf("concatenated raw text must be same as input but %s", raw.String())
+	}
+	if d := cmp.Diff(`U&'a!0062' /* c */ UESCAPE '!'`, tokens[2].Raw); d != "" {
+		t.Errorf("diff %s", d)
+	}
+	if d := cmp.Diff(&UnicodeStringValue{Value: "ab", Escape: '!'}, tokens[2].Value); d != "" {
+		t.Errorf("diff %s", d)
+	}
+	if d := cmp.Diff(`U&"c"`, tokens[5].Raw); d != "" {
+		t.Errorf("diff %s", d)
+	}
+}
+
+func TestTokenizer_Offset(t *testing.T) {
+	in := "SELECT 'é',\n  u&x"
+	tokens, err := NewTokenizer(strings.NewReader(in), &dialect.PostgresqlDialect{}).Tokenize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var offsets []int
+	for _, tok := range tokens {
+		if d := cmp.Diff(tok.Raw, in[tok.Offset:tok.EndOffset()]); d != "" {
+			t.Errorf("diff %s", d)
+		}
+		offsets = append(offsets, tok.Offset)
+	}
+	if d := cmp.Diff([]int{0, 6, 7, 11, 12, 13, 14, 15, 16, 17}, offsets); d != "" {
+		t.Errorf("diff %s", d)
+	}
+}
+
+func TestTokenizer_BackslashEscapeDialect(t *testing.T) {
+	in := `'it\'s\n'`
+	tokens, err := NewTokenizer(strings.NewReader(in), &dialect.MySQLDialect{}).Tokenize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff("it's\n", tokens[0].Value); d != "" {
+		t.Errorf("diff %s", d)
+	}
+}
+
+func TestTokenizer_HashCommentDialect(t *testing.T) {
+	in := "a # c\nb"
+	tokens, err := NewTokenizer(strings.NewReader(in), &dialect.MySQLDialect{}).Tokenize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []Kind
+	for _, tok := range tokens {
+		kinds = append(kinds, tok.Kind)
+	}
+	if d := cmp.Diff([]Kind{SQLKeyword, Whitespace, Comment, Whitespace, SQLKeyword}, kinds); d != "" {
+		t.Errorf("diff %s", d)
+	}
+	if d := cmp.Diff(Pos{Line: 1, Col: 6}, tokens[2].To); d != "" {
+		t.Errorf("diff %s", d)
+	}
+
+	tokens, err = NewTokenizer(strings.NewReader(in), &dialect.PostgresqlDialect{}).Tokenize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(Sharp, tokens[2].Kind); d != "" {
+		t.Errorf("diff %s", d)
+	}
+}
+
 func TestTokenizer_Pos(t *testing.T) {
 	t.Run("operators", func(t *testing.T) {
 		cases := []struct {
@@ -623,6 +1221,46 @@ test comment
 				name: "incomplete quoted string",
 				src:  "'test",
 			},
+			{
+				name: "invalid hexadecimal string",
+				src:  "X'0g'",
+			},
+			{
+				name: "invalid bit string",
+				src:  "B'102'",
+			},
+			{
+				name: "missing exponent",
+				src:  "1e+",
+			},
+			{
+				name: "unterminated escape sequence",
+				src:  `E'abc\`,
+			},
+			{
+				name: "invalid unicode escape sequence",
+				src:  `E'\u12'`,
+			},
+			{
+				name: "unclosed quoted identifier",
+				src:  `SELECT "abc`,
+			},
+			{
+				name: "invalid unicode identifier",
+				src:  `U&"\00g1"`,
+			},
+			{
+				name: "invalid uescape character",
+				src:  `U&'a' UESCAPE '+'`,
+			},
+			{
+				name: "missing uescape character",
+				src:  `U&'a' UESCAPE`,
+			},
+			{
+				name: "unclosed dollar quoted string",
+				src:  "$tag$ test $$",
+			},
 			{
 				name: "unclosed multiline comment",
 				src: `
@@ -647,6 +1285,32 @@ test
 	})
 }
 
+func TestTokenizer_TokenizeIn(t *testing.T) {
+	src := "SELECT a, b FROM t WHERE c = 'x' -- comment\n" + strings.Repeat("AND d = 1 ", tokenChunkSize)
+
+	expect, err := NewTokenizer(strings.NewReader(src), &dialect.GenericSQLDialect{}).Tokenize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	arena := NewTokenArena()
+	for i := 0; i < 2; i++ {
+		tokens, err := NewTokenizer(strings.NewReader(src), &dialect.GenericSQLDialect{}).TokenizeIn(arena)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(expect, tokens); diff != "" {
+			t.Errorf("diff: %s", diff)
+		}
+		arena.Release()
+		for _, tok := range tokens {
+			if *tok != (Token{}) {
+				t.Fatalf("must be zeroed after Release but %+v", tok)
+			}
+		}
+	}
+}
+
 func BenchmarkTokenizer_Tokenize(b *testing.B) {
 	cases := []struct {
 		name string
@@ -757,3 +1421,47 @@ HAVING COUNT(customer_id) > 3`,
 		})
 	}
 }
+
+// benchWorkloads returns the TPC-H queries in testdata/bench and a big INSERT.
+func benchWorkloads(b *testing.B) map[string]string {
+	files, err := filepath.Glob("../testdata/bench/*.sql")
+	if err != nil {
+		b.Fatal(err)
+	}
+	workloads := make(map[string]string)
+	for _, f := range files {
+		src, err := ioutil.ReadFile(f)
+		if err != nil {
+			b.Fatal(err)
+		}
+		workloads[strings.TrimSuffix(filepath.Base(f), ".sql")] = string(src)
+	}
+
+	var insert strings.Builder
+	insert.WriteString("INSERT INTO users (id, name, email, score, created_at) VALUES\n")
+	for i := 0; i < 1000; i++ {
+		if i != 0 {
+			insert.WriteString(",\n")
+		}
+		fmt.Fprintf(&insert, "(%d, 'user %d', 'user%d@example.com', %d.5, '2020-01-01 00:00:00')", i, i, i, i)
+	}
+	insert.WriteString(";\n")
+	workloads["big_insert"] = insert.String()
+	return workloads
+}
+
+func BenchmarkTokenizer_Workloads(b *testing.B) {
+	for name, src := range benchWorkloads(b) {
+		src := src
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(src)))
+			for i := 0; i < b.N; i++ {
+				tokenizer := NewTokenizer(strings.NewReader(src), &dialect.GenericSQLDialect{})
+				if _, err := tokenizer.Tokenize(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("genvisitor: ")
+
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// nodeMethods are the methods of sqlast.Node.
+var nodeMethods = []string{"ToSQLString", "Pos", "End", "WriteTo"}
+
+// nodeTypes returns the names of the types declared in the files which have
+// all methods of sqlast.Node with a pointer or value receiver.
+func nodeTypes(files []*ast.File) []string {
+	methods := make(map[string]map[string]bool)
+	for _, f := range files {
+		for _, d := range f.Decls {
+			fn, ok := d.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+				continue
+			}
+			recv := fn.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			ident, ok := recv.(*ast.Ident)
+			if !ok || !ident.IsExported() {
+				continue
+			}
+			if methods[ident.Name] == nil {
+				methods[ident.Name] = make(map[string]bool)
+			}
+			methods[ident.Name][fn.Name.Name] = true
+		}
+	}
+
+	var types []string
+L:
+	for name, m := range methods {
+		for _, method := range nodeMethods {
+			if !m[method] {
+				continue L
+			}
+		}
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func run() error {
+	var flags struct {
+		OutputName string
+		Package    string
+	}
+
+	flag.StringVar(&flags.OutputName, "o", "node_visitor_gen.go", "output filename")
+	flag.StringVar(&flags.Package, "pkg", os.Getenv("GOPACKAGE"), "package name")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return fmt.Errorf("failed to parse package: %s", err.Error())
+	}
+	pkg, ok := pkgs[flags.Package]
+	if !ok {
+		return fmt.Errorf("package %s is not found", flags.Package)
+	}
+	var files []*ast.File
+	for _, f := range pkg.Files {
+		files = append(files, f)
+	}
+	types := nodeTypes(files)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "package %s\n", flags.Package)
+	fmt.Fprintf(buf, "// Code generated by genvisitor. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "// NodeVisitor is a Visitor calling the callback for the type of each node.\n")
+	fmt.Fprintf(buf, "// Every callback is optional. If a callback returns false, the children of the\n")
+	fmt.Fprintf(buf, "// node are not visited. Default is called for nodes without their callback.\n")
+	fmt.Fprintf(buf, "//\n")
+	fmt.Fprintf(buf, "//	Walk(&NodeVisitor{\n")
+	fmt.Fprintf(buf, "//		BinaryExpr: func(n *BinaryExpr) bool { ...; return true },\n")
+	fmt.Fprintf(buf, "//	}, stmt)\n")
+	fmt.Fprintf(buf, "type NodeVisitor struct {\n")
+	fmt.Fprintf(buf, "Default func(node Node) bool\n")
+	for _, t := range types {
+		fmt.Fprintf(buf, "%s func(node *%s) bool\n", t, t)
+	}
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func (v *NodeVisitor) Visit(node Node) Visitor {\n")
+	fmt.Fprintf(buf, "switch n := node.(type) {\n")
+	fmt.Fprintf(buf, "case nil:\nreturn nil\n")
+	for _, t := range types {
+		fmt.Fprintf(buf, "case *%s:\n", t)
+		fmt.Fprintf(buf, "if v.%s != nil {\nreturn v.descend(v.%s(n))\n}\n", t, t)
+	}
+	fmt.Fprintf(buf, "}\n")
+	fmt.Fprintf(buf, "if v.Default != nil {\nreturn v.descend(v.Default(node))\n}\n")
+	fmt.Fprintf(buf, "return v\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func (v *NodeVisitor) descend(ok bool) Visitor {\n")
+	fmt.Fprintf(buf, "if !ok {\nreturn nil\n}\n")
+	fmt.Fprintf(buf, "return v\n")
+	fmt.Fprintf(buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format source code: %s", err.Error())
+	}
+
+	err = ioutil.WriteFile(flags.OutputName, src, 0666)
+	if err != nil {
+		return fmt.Errorf("failed to write generate code: %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNodeTypes(t *testing.T) {
+	src := `package sqlast
+
+type Foo struct{}
+
+func (*Foo) ToSQLString() string { return "" }
+func (*Foo) Pos() sqltoken.Pos { return sqltoken.Pos{} }
+func (*Foo) End() sqltoken.Pos { return sqltoken.Pos{} }
+func (*Foo) WriteTo(w io.Writer) (int64, error) { return 0, nil }
+
+type Bar struct{}
+
+func (Bar) ToSQLString() string { return "" }
+func (*Bar) Pos() sqltoken.Pos { return sqltoken.Pos{} }
+func (*Bar) End() sqltoken.Pos { return sqltoken.Pos{} }
+func (*Bar) WriteTo(w io.Writer) (int64, error) { return 0, nil }
+
+type Enum int
+
+func (Enum) ToSQLString() string { return "" }
+
+type private struct{}
+
+func (*private) ToSQLString() string { return "" }
+func (*private) Pos() sqltoken.Pos { return sqltoken.Pos{} }
+func (*private) End() sqltoken.Pos { return sqltoken.Pos{} }
+func (*private) WriteTo(w io.Writer) (int64, error) { return 0, nil }
+`
+	f, err := parser.ParseFile(token.NewFileSet(), "src.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := nodeTypes([]*ast.File{f})
+	if diff := cmp.Diff([]string{"Bar", "Foo"}, got); diff != "" {
+		t.Errorf("diff: %s", diff)
+	}
+}
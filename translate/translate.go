@@ -0,0 +1,371 @@
+package translate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/akito0107/xsqlparser"
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqlastutil"
+)
+
+// Hook translates the node at c for the dialect to. It is called for each
+// node before its children. If it returns true, the node is regarded as
+// translated by the hook, and its children and the node itself are not
+// translated further.
+type Hook func(c *sqlastutil.Cursor, to dialect.Dialect) (bool, error)
+
+// Translator rewrites ASTs so that they are printed as SQL of the dialect To.
+type Translator struct {
+	To    dialect.Dialect
+	Hooks []Hook
+}
+
+func New(to dialect.Dialect, hooks ...Hook) *Translator {
+	return &Translator{To: to, Hooks: hooks}
+}
+
+// Translate rewrites node in place for t.To and returns it. The built-in
+// translation covers:
+//
+//   - quoted identifiers, e.g. "a" <-> `a`
+//   - LIMIT / OFFSET <-> OFFSET ... FETCH FIRST
+//   - LIMIT / FETCH FIRST <-> TOP (SQL Server)
+//   - ILIKE to LIKE of lower-cased operands
+//   - dollar-quoted strings, E'...' and U&'...' to single-quoted ones
+//   - U&"..." identifiers to plain quoted ones
+//   - backslashes in string literals escaped for MySQL
+//   - data types missing in t.To, e.g. text -> clob (Oracle)
+//
+// Note that `x::type` is always printed as CAST(x AS type). It returns an
+// error if node has a construct which can't be translated, e.g. DISTINCT ON.
+func (t *Translator) Translate(node sqlast.Node) (sqlast.Node, error) {
+	var err error
+	pre := func(c *sqlastutil.Cursor) bool {
+		for _, h := range t.Hooks {
+			handled, e := h(c, t.To)
+			if e != nil {
+				err = e
+				return false
+			}
+			if handled {
+				return false
+			}
+		}
+		return true
+	}
+	post := func(c *sqlastutil.Cursor) bool {
+		if e := t.translate(c); e != nil {
+			err = e
+			return false
+		}
+		return true
+	}
+	translated := sqlastutil.Apply(node, pre, post)
+	if err != nil {
+		return nil, err
+	}
+	return translated, nil
+}
+
+// TranslateSQL parses src in the dialect from and returns the statements
+// translated for t.To, each of which is terminated with a semicolon and a newline.
+func (t *Translator) TranslateSQL(src io.Reader, from dialect.Dialect) (string, error) {
+	parser, err := xsqlparser.NewParser(src, from)
+	if err != nil {
+		return "", errors.Errorf("NewParser failed: %w", err)
+	}
+	stmts, err := parser.ParseSQL()
+	if err != nil {
+		return "", errors.Errorf("ParseSQL failed: %w", err)
+	}
+	var buf bytes.Buffer
+	for _, stmt := range stmts {
+		translated, err := t.Translate(stmt)
+		if err != nil {
+			return "", errors.Errorf("Translate failed: %w", err)
+		}
+		fmt.Fprintf(&buf, "%s;\n", translated.ToSQLString())
+	}
+	return buf.String(), nil
+}
+
+func (t *Translator) translate(c *sqlastutil.Cursor) error {
+	switch n := c.Node().(type) {
+	case *sqlast.Ident:
+		if n.Quoted {
+			n.QuoteStyle = quoteStyle(t.To)
+		}
+		if !t.hasUnicodeEscape() {
+			n.UnicodeEscape = 0
+		}
+	case *sqlast.QueryStmt:
+		if n.Limit != nil || n.Fetch != nil {
+			return t.limit(n)
+		}
+	case *sqlast.SQLSelect:
+		if len(n.DistinctOn) != 0 {
+			if d, ok := t.To.(dialect.DistinctOnDialect); !ok || !d.DistinctOn() {
+				return errors.Errorf("DISTINCT ON is not supported by %T", t.To)
+			}
+		}
+		if n.Top != nil && !t.hasTop() {
+			return t.top(c, n)
+		}
+	case *sqlast.BinaryExpr:
+		if !t.hasILike() && (n.Op.Type == sqlast.ILike || n.Op.Type == sqlast.NotILike) {
+			op := sqlast.Like
+			if n.Op.Type == sqlast.NotILike {
+				op = sqlast.NotLike
+			}
+			n.Op = &sqlast.Operator{Type: op}
+			n.Left = lower(n.Left)
+			n.Right = lower(n.Right)
+		}
+	case *sqlast.SingleQuotedString:
+		n.BackslashEscape = t.backslashEscape()
+	case *sqlast.NationalStringLiteral:
+		n.BackslashEscape = t.backslashEscape()
+	case *sqlast.EscapedStringLiteral:
+		if !t.hasEscapedString() {
+			c.Replace(&sqlast.SingleQuotedString{
+				From:            n.From,
+				To:              n.To,
+				String:          n.String,
+				BackslashEscape: t.backslashEscape(),
+			})
+		}
+	case *sqlast.UnicodeStringLiteral:
+		if !t.hasUnicodeEscape() {
+			c.Replace(&sqlast.SingleQuotedString{
+				From:            n.From,
+				To:              n.To,
+				String:          n.String,
+				BackslashEscape: t.backslashEscape(),
+			})
+		}
+	case *sqlast.DollarQuotedString:
+		if !t.hasDollarQuote() {
+			c.Replace(&sqlast.SingleQuotedString{
+				From:            n.From,
+				To:              n.To,
+				String:          n.String,
+				BackslashEscape: t.backslashEscape(),
+			})
+		}
+	case *sqlast.Text, *sqlast.Bytea, *sqlast.Boolean, *sqlast.UUID:
+		if ty := t.dataType(n); ty != nil {
+			c.Replace(ty)
+		}
+	}
+	return nil
+}
+
+// quoteStyle returns the quote of identifiers in d.
+func quoteStyle(d dialect.Dialect) rune {
+	if _, ok := d.(*dialect.MySQLDialect); ok || !d.IsDelimitedIdentifierStart('"') {
+		return '`'
+	}
+	return '"'
+}
+
+// mysqlMaxLimit is the LIMIT used for OFFSET without LIMIT in MySQL, which
+// is the largest BIGINT UNSIGNED.
+const mysqlMaxLimit = math.MaxUint64
+
+func (t *Translator) limit(q *sqlast.QueryStmt) error {
+	if l := q.Limit; l != nil {
+		// LIMIT beyond int64 is the way MySQL writes no limit
+		if _, ok := l.LimitValue.(*sqlast.UnsignedLongValue); ok {
+			if _, ok := t.To.(*dialect.MySQLDialect); !ok {
+				l.LimitValue = nil
+			}
+		}
+		if !l.All && l.LimitValue == nil && l.OffsetValue == nil {
+			q.Limit = nil
+		}
+	}
+
+	switch t.To.(type) {
+	case *dialect.OracleDialect:
+		t.limitToFetch(q)
+	case *dialect.SQLServerDialect:
+		t.limitToFetch(q)
+		f := q.Fetch
+		if f == nil {
+			return nil
+		}
+		// TOP can't skip rows, so OFFSET ... FETCH is kept if there is OFFSET
+		if s, ok := q.Body.(*sqlast.SQLSelect); ok && q.Limit == nil && s.Top == nil {
+			s.Top = &sqlast.Top{Count: f.Count, WithTies: f.WithTies}
+			// only a constant can be written without parentheses
+			_, s.Top.Parens = f.Count.(*sqlast.Placeholder)
+			if f.Count == nil {
+				s.Top.Count = sqlast.NewLongValue(1)
+			}
+			q.Fetch = nil
+			return nil
+		}
+		// FETCH requires OFFSET in SQL Server
+		if q.Limit == nil {
+			q.Limit = &sqlast.LimitExpr{OffsetValue: sqlast.NewLongValue(0), OffsetUnit: sqlast.FetchUnitRows}
+		}
+	case *dialect.MySQLDialect, *dialect.HiveDialect, *dialect.ClickHouseDialect:
+		if f := q.Fetch; f != nil {
+			if f.WithTies {
+				return errors.Errorf("FETCH ... WITH TIES is not supported by %T", t.To)
+			}
+			if q.Limit == nil {
+				q.Limit = &sqlast.LimitExpr{}
+			}
+			if q.Limit.LimitValue == nil && !q.Limit.All {
+				if f.Count != nil {
+					q.Limit.LimitValue = f.Count
+				} else {
+					q.Limit.LimitValue = sqlast.NewLongValue(1)
+				}
+			}
+			q.Fetch = nil
+		}
+		l := q.Limit
+		if l == nil {
+			return nil
+		}
+		l.OffsetUnit = sqlast.FetchUnitNone
+		l.All = false
+		if l.LimitValue == nil && l.OffsetValue != nil {
+			if _, ok := t.To.(*dialect.MySQLDialect); ok {
+				l.LimitValue = sqlast.NewUnsignedLongValue(mysqlMaxLimit)
+			}
+		}
+		if l.LimitValue == nil && l.OffsetValue == nil {
+			q.Limit = nil
+		}
+	}
+	return nil
+}
+
+// limitToFetch rewrites `LIMIT n OFFSET m` of q to `OFFSET m ROWS FETCH FIRST n ROWS ONLY`.
+func (t *Translator) limitToFetch(q *sqlast.QueryStmt) {
+	l := q.Limit
+	if l == nil {
+		return
+	}
+	if l.LimitValue != nil && q.Fetch == nil {
+		q.Fetch = &sqlast.Fetch{Count: l.LimitValue, Unit: sqlast.FetchUnitRows}
+	}
+	l.All = false
+	l.LimitValue = nil
+	if l.OffsetValue == nil {
+		q.Limit = nil
+		return
+	}
+	l.OffsetUnit = sqlast.FetchUnitRows
+}
+
+// top moves TOP of s into the fetch clause of the query, which is
+// translated by limit afterwards.
+func (t *Translator) top(c *sqlastutil.Cursor, s *sqlast.SQLSelect) error {
+	if s.Top.Percent {
+		return errors.Errorf("TOP ... PERCENT is not supported by %T", t.To)
+	}
+	q, ok := c.Parent().(*sqlast.QueryStmt)
+	if !ok || q.Limit != nil || q.Fetch != nil {
+		return errors.Errorf("TOP in a set operation or with LIMIT is not supported by %T", t.To)
+	}
+	q.Fetch = &sqlast.Fetch{Count: s.Top.Count, Unit: sqlast.FetchUnitRows, WithTies: s.Top.WithTies}
+	s.Top = nil
+	return nil
+}
+
+func (t *Translator) hasILike() bool {
+	switch t.To.(type) {
+	case *dialect.MySQLDialect, *dialect.OracleDialect, *dialect.HiveDialect, *dialect.SQLServerDialect:
+		return false
+	}
+	return true
+}
+
+func (t *Translator) hasTop() bool {
+	d, ok := t.To.(dialect.TopDialect)
+	return ok && d.Top()
+}
+
+func (t *Translator) backslashEscape() bool {
+	d, ok := t.To.(dialect.StringEscapeDialect)
+	return ok && d.BackslashEscape()
+}
+
+func (t *Translator) hasUnicodeEscape() bool {
+	d, ok := t.To.(dialect.UnicodeEscapeDialect)
+	return ok && d.UnicodeEscape()
+}
+
+func (t *Translator) hasEscapedString() bool {
+	switch t.To.(type) {
+	case *dialect.PostgresqlDialect, *dialect.GenericSQLDialect:
+		return true
+	}
+	return false
+}
+
+func (t *Translator) hasDollarQuote() bool {
+	switch t.To.(type) {
+	case *dialect.PostgresqlDialect, *dialect.RedshiftDialect, *dialect.GenericSQLDialect:
+		return true
+	}
+	return false
+}
+
+// dataType returns the type to which ty is translated, or nil if ty is supported by t.To.
+func (t *Translator) dataType(ty sqlast.Type) sqlast.Type {
+	switch t.To.(type) {
+	case *dialect.OracleDialect:
+		switch ty.(type) {
+		case *sqlast.Text:
+			return &sqlast.Clob{}
+		case *sqlast.Bytea:
+			return &sqlast.Blob{}
+		case *sqlast.Boolean:
+			p := uint(1)
+			return &sqlast.Decimal{Precision: &p}
+		}
+	case *dialect.MySQLDialect:
+		switch ty.(type) {
+		case *sqlast.Bytea:
+			return &sqlast.Blob{}
+		case *sqlast.UUID:
+			size := uint(36)
+			return &sqlast.CharType{Size: &size}
+		}
+	case *dialect.HiveDialect:
+		switch ty.(type) {
+		case *sqlast.Text:
+			return &sqlast.String{}
+		case *sqlast.Bytea:
+			return &sqlast.Binary{}
+		}
+	case *dialect.ClickHouseDialect:
+		switch ty.(type) {
+		case *sqlast.Text, *sqlast.Bytea:
+			return &sqlast.Custom{Ty: sqlast.NewObjectName("String")}
+		}
+	case *dialect.SQLServerDialect:
+		switch ty.(type) {
+		case *sqlast.Boolean:
+			return &sqlast.Custom{Ty: sqlast.NewObjectName("bit")}
+		case *sqlast.UUID:
+			return &sqlast.Custom{Ty: sqlast.NewObjectName("uniqueidentifier")}
+		}
+	}
+	return nil
+}
+
+func lower(node sqlast.Node) sqlast.Node {
+	return &sqlast.Function{Name: sqlast.NewObjectName("lower"), Args: []sqlast.Node{node}}
+}
@@ -0,0 +1,217 @@
+package translate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/akito0107/xsqlparser/dialect"
+	"github.com/akito0107/xsqlparser/sqlast"
+	"github.com/akito0107/xsqlparser/sqlastutil"
+)
+
+func TestTranslator_TranslateSQL(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		from   dialect.Dialect
+		to     dialect.Dialect
+		expect string
+		err    bool
+	}{
+		{
+			name:   "postgres to mysql",
+			src:    `SELECT "id", name::text FROM "users" WHERE name ILIKE $$a%$$ OFFSET 10`,
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.MySQLDialect{},
+			expect: "SELECT `id`, CAST(name AS text) FROM `users` WHERE lower(name) LIKE lower('a%') LIMIT 18446744073709551615 OFFSET 10;\n",
+		},
+		{
+			name:   "mysql to postgres",
+			src:    "SELECT `a` FROM t LIMIT 10",
+			from:   &dialect.MySQLDialect{},
+			to:     &dialect.PostgresqlDialect{},
+			expect: "SELECT \"a\" FROM t LIMIT 10;\n",
+		},
+		{
+			name:   "postgres to oracle",
+			src:    "SELECT a FROM t WHERE b NOT ILIKE 'x' LIMIT 10 OFFSET 5",
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.OracleDialect{},
+			expect: "SELECT a FROM t WHERE lower(b) NOT LIKE lower('x') OFFSET 5 ROWS FETCH FIRST 10 ROWS ONLY;\n",
+		},
+		{
+			name:   "oracle to mysql",
+			src:    "SELECT a FROM t FETCH FIRST 3 ROWS ONLY",
+			from:   &dialect.OracleDialect{},
+			to:     &dialect.MySQLDialect{},
+			expect: "SELECT a FROM t LIMIT 3;\n",
+		},
+		{
+			name:   "fetch without count to mysql",
+			src:    "SELECT a FROM t OFFSET 2 ROWS FETCH NEXT ROW ONLY",
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.MySQLDialect{},
+			expect: "SELECT a FROM t LIMIT 1 OFFSET 2;\n",
+		},
+		{
+			name: "fetch with ties to mysql",
+			src:  "SELECT a FROM t ORDER BY a FETCH FIRST 3 ROWS WITH TIES",
+			from: &dialect.PostgresqlDialect{},
+			to:   &dialect.MySQLDialect{},
+			err:  true,
+		},
+		{
+			name:   "mysql max limit to postgres",
+			src:    "SELECT a FROM t LIMIT 18446744073709551615 OFFSET 2",
+			from:   &dialect.MySQLDialect{},
+			to:     &dialect.PostgresqlDialect{},
+			expect: "SELECT a FROM t OFFSET 2;\n",
+		},
+		{
+			name:   "strings to mysql",
+			src:    `SELECT $$it's C:\dir$$, 'a\b', N'\'`,
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.MySQLDialect{},
+			expect: `SELECT 'it''s C:\\dir', 'a\\b', N'\\';` + "\n",
+		},
+		{
+			name:   "escaped strings to mysql",
+			src:    `SELECT E'a\nb\\c', E'it\'s'`,
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.MySQLDialect{},
+			expect: `SELECT 'a\nb\\c', 'it''s';` + "\n",
+		},
+		{
+			name:   "escaped strings to oracle",
+			src:    `SELECT E'a\tb', E'it\'s'`,
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.OracleDialect{},
+			expect: "SELECT 'a\tb', 'it''s';\n",
+		},
+		{
+			name:   "unicode escapes to oracle",
+			src:    `SELECT U&"d!0061t" UESCAPE '!', U&'caf\00e9' FROM t`,
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.OracleDialect{},
+			expect: `SELECT "dat", 'café' FROM t;` + "\n",
+		},
+		{
+			name:   "unicode escapes to postgres",
+			src:    `SELECT U&"d!0061t" UESCAPE '!', U&'caf\00e9' FROM t`,
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.PostgresqlDialect{},
+			expect: `SELECT U&"dat" UESCAPE '!', U&'café' FROM t;` + "\n",
+		},
+		{
+			name:   "limit to top",
+			src:    "SELECT a FROM t ORDER BY a LIMIT 10",
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.SQLServerDialect{},
+			expect: "SELECT TOP 10 a FROM t ORDER BY a;\n",
+		},
+		{
+			name:   "placeholder of limit to top",
+			src:    "SELECT a FROM t ORDER BY a LIMIT ?",
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.SQLServerDialect{},
+			expect: "SELECT TOP (?) a FROM t ORDER BY a;\n",
+		},
+		{
+			name:   "placeholders of limit to oracle",
+			src:    "SELECT a FROM t ORDER BY a LIMIT :n OFFSET :m",
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.OracleDialect{},
+			expect: "SELECT a FROM t ORDER BY a OFFSET :m ROWS FETCH FIRST :n ROWS ONLY;\n",
+		},
+		{
+			name:   "limit with offset to sql server",
+			src:    "SELECT a FROM t ORDER BY a LIMIT 10 OFFSET 5",
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.SQLServerDialect{},
+			expect: "SELECT a FROM t ORDER BY a OFFSET 5 ROWS FETCH FIRST 10 ROWS ONLY;\n",
+		},
+		{
+			name:   "fetch of set operation to sql server",
+			src:    "SELECT a FROM t UNION SELECT a FROM u ORDER BY a FETCH FIRST 3 ROWS ONLY",
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.SQLServerDialect{},
+			expect: "SELECT a FROM t UNION SELECT a FROM u ORDER BY a OFFSET 0 ROWS FETCH FIRST 3 ROWS ONLY;\n",
+		},
+		{
+			name:   "top to mysql",
+			src:    "SELECT TOP (3) a FROM [t] ORDER BY a",
+			from:   &dialect.SQLServerDialect{},
+			to:     &dialect.MySQLDialect{},
+			expect: "SELECT a FROM `t` ORDER BY a LIMIT 3;\n",
+		},
+		{
+			name:   "top with ties to postgres",
+			src:    "SELECT TOP 3 WITH TIES a FROM t ORDER BY a",
+			from:   &dialect.SQLServerDialect{},
+			to:     &dialect.PostgresqlDialect{},
+			expect: "SELECT a FROM t ORDER BY a FETCH FIRST 3 ROWS WITH TIES;\n",
+		},
+		{
+			name: "top percent",
+			src:  "SELECT TOP 10 PERCENT a FROM t",
+			from: &dialect.SQLServerDialect{},
+			to:   &dialect.PostgresqlDialect{},
+			err:  true,
+		},
+		{
+			name:   "data types",
+			src:    "CREATE TABLE t (a text, b bytea, c boolean)",
+			from:   &dialect.PostgresqlDialect{},
+			to:     &dialect.OracleDialect{},
+			expect: "CREATE TABLE t (a clob, b blob, c numeric(1));\n",
+		},
+		{
+			name: "distinct on",
+			src:  "SELECT DISTINCT ON (a) a, b FROM t",
+			from: &dialect.PostgresqlDialect{},
+			to:   &dialect.MySQLDialect{},
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := New(c.to).TranslateSQL(bytes.NewBufferString(c.src), c.from)
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but %s", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if actual != c.expect {
+				t.Errorf("must be \n%s but \n%s", c.expect, actual)
+			}
+		})
+	}
+}
+
+func TestTranslator_Hook(t *testing.T) {
+	// translates now() to SYSDATE
+	sysdate := func(c *sqlastutil.Cursor, to dialect.Dialect) (bool, error) {
+		f, ok := c.Node().(*sqlast.Function)
+		if !ok || !strings.EqualFold(f.Name.ToSQLString(), "now") {
+			return false, nil
+		}
+		c.Replace(sqlast.NewIdent("SYSDATE"))
+		return true, nil
+	}
+
+	actual, err := New(&dialect.OracleDialect{}, sysdate).TranslateSQL(
+		bytes.NewBufferString("SELECT now(), a FROM t LIMIT 1"), &dialect.PostgresqlDialect{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expect := "SELECT SYSDATE, a FROM t FETCH FIRST 1 ROWS ONLY;\n"
+	if actual != expect {
+		t.Errorf("must be \n%s but \n%s", expect, actual)
+	}
+}